@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"flag"
+	"fmt"
 	"github.com/aalug/job-finder-go/internal/api"
 	"github.com/aalug/job-finder-go/internal/config"
 	db "github.com/aalug/job-finder-go/internal/db/sqlc"
@@ -38,6 +39,8 @@ func main() {
 
 	// === loading test data ===
 	loadDataFlag := flag.Bool("load_test_data", false, "If set, the application will load test data into db")
+	normalizeLocationsFlag := flag.Bool("normalize_locations", false, "If set, the application will normalize the existing locations to the gazetteer entries")
+	reindexFlag := flag.Bool("reindex", false, "If set, the application will rebuild the search index from the db and exit")
 	flag.Parse()
 
 	if *loadDataFlag != false {
@@ -45,29 +48,96 @@ func main() {
 		store.LoadTestData(context.Background())
 	}
 
-	// === Elasticsearch ===
+	// === normalizing locations ===
+	if *normalizeLocationsFlag {
+		result, err := store.NormalizeLocations(context.Background())
+		if err != nil {
+			zerolog.Fatal().Err(err).Msg("cannot normalize locations")
+		}
+		zerolog.Info().Int("jobs", result.Jobs).Int("companies", result.Companies).
+			Int("users", result.Users).Msg("normalized locations")
+	}
+
+	// === search ===
 	ctx := context.Background()
-	ctx, err = esearch.LoadJobsFromDB(ctx, store)
+	client, err := newSearchClient(cfg)
 	if err != nil {
-		zerolog.Fatal().Err(err).Msg("cannot load jobs from db")
+		zerolog.Fatal().Err(err).Msg("cannot create the search client")
 	}
-	newClient, err := esearch.ConnectWithElasticsearch(cfg.ElasticSearchAddress)
+
+	synonyms, err := esearch.LoadSynonyms(cfg.ElasticSearchSynonymsFile)
 	if err != nil {
-		zerolog.Fatal().Err(err).Msg("cannot connect to the elasticsearch")
+		zerolog.Fatal().Err(err).Msg("cannot load the search synonyms")
+	}
+
+	// === reindexing ===
+	// the test data is indexed as well, otherwise the server starts
+	// with the existing index and does not index all the jobs.
+	// The memory backend starts empty, so it is always reindexed.
+	if _, ok := client.(*esearch.MemoryClient); ok || *reindexFlag || *loadDataFlag {
+		result, err := client.Reindex(ctx, store, synonyms)
+		if err != nil {
+			zerolog.Fatal().Err(err).Msg("cannot reindex jobs")
+		}
+		zerolog.Info().Str("index", result.Index).Int64("documents", result.Documents).
+			Strs("deleted_indices", result.DeletedIndices).Int64("replayed_entries", result.ReplayedEntries).
+			Msg("reindexed jobs")
+		if *reindexFlag {
+			return
+		}
 	}
 
-	client := esearch.NewClient(newClient)
-	err = client.IndexJobsAsDocuments(ctx)
+	err = client.EnsureJobsIndex(ctx, synonyms)
 	if err != nil {
-		zerolog.Fatal().Err(err).Msg("cannot index jobs as documents")
+		// with failover or postgres, the server starts while elasticsearch is down and searches postgres.
+		// The index is created by the next start or reindex, the changes stay in the search outbox until then
+		if cfg.SearchProvider != searchProviderFailover && cfg.SearchProvider != searchProviderPostgres {
+			zerolog.Fatal().Err(err).Msg("cannot create the jobs index")
+		}
+		zerolog.Error().Err(err).Str("search_provider", cfg.SearchProvider).
+			Msg("cannot create the jobs index, searching with postgres")
 	}
 
-	// === task processor ===
-	go runTaskProcessor(cfg, redisOpt, store)
+	// === task processor and scheduler ===
+	go runTaskProcessor(cfg, redisOpt, store, client)
+	runTaskScheduler(cfg, redisOpt)
 	// === HTTP server ===
 	runHTTPServer(cfg, store, client, taskDistributor)
 }
 
+const (
+	searchBackendElasticsearch = "elasticsearch"
+	searchBackendMemory        = "memory"
+	searchProviderPostgres     = "postgres"
+	searchProviderFailover     = "failover"
+)
+
+// newSearchClient creates the search client of the backend selected with SEARCH_BACKEND.
+// Elasticsearch is the default, except for the postgres search provider - the jobs are searched
+// in postgres then, so the memory backend is the default and elasticsearch is not needed.
+func newSearchClient(cfg config.Config) (esearch.ESearchClient, error) {
+	backend := cfg.SearchBackend
+	if backend == "" {
+		backend = searchBackendElasticsearch
+		if cfg.SearchProvider == searchProviderPostgres {
+			backend = searchBackendMemory
+		}
+	}
+
+	switch backend {
+	case searchBackendElasticsearch:
+		newClient, err := esearch.ConnectWithElasticsearch(cfg.ElasticSearchAddress)
+		if err != nil {
+			return nil, err
+		}
+		return esearch.NewClient(newClient), nil
+	case searchBackendMemory:
+		return esearch.NewMemoryClient(), nil
+	default:
+		return nil, fmt.Errorf("unsupported search backend %q, use elasticsearch or memory", cfg.SearchBackend)
+	}
+}
+
 func runHTTPServer(cfg config.Config, store db.Store, client esearch.ESearchClient, taskDistributor worker.TaskDistributor) {
 	server, err := api.NewServer(cfg, store, client, taskDistributor)
 	if err != nil {
@@ -87,12 +157,22 @@ func runHTTPServer(cfg config.Config, store db.Store, client esearch.ESearchClie
 	}
 }
 
-func runTaskProcessor(cfg config.Config, redisOpt asynq.RedisClientOpt, store db.Store) {
+func runTaskProcessor(cfg config.Config, redisOpt asynq.RedisClientOpt, store db.Store, client esearch.ESearchClient) {
 	emailSender := mail.NewHogSender(cfg.EmailSenderAddress)
-	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, store, emailSender)
+	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, store, emailSender, client)
 	zerolog.Info().Msg("task processor started")
 	err := taskProcessor.Start()
 	if err != nil {
 		zerolog.Fatal().Err(err).Msg("failed to start task processor")
 	}
 }
+
+// runTaskScheduler starts the scheduler of the periodic tasks, it does not block
+func runTaskScheduler(cfg config.Config, redisOpt asynq.RedisClientOpt) {
+	taskScheduler := worker.NewRedisTaskScheduler(redisOpt, cfg)
+	err := taskScheduler.Start()
+	if err != nil {
+		zerolog.Fatal().Err(err).Msg("failed to start task scheduler")
+	}
+	zerolog.Info().Msg("task scheduler started")
+}
@@ -0,0 +1,339 @@
+package api
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	db "github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/aalug/job-finder-go/pkg/token"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	apiKeyPrefix = "jf_"
+
+	apiKeyScopeJobsRead         = "jobs:read"
+	apiKeyScopeJobsWrite        = "jobs:write"
+	apiKeyScopeApplicationsRead = "applications:read"
+)
+
+var (
+	invalidApiKeyError    = errors.New("invalid API key")
+	apiKeyNotAllowedError = errors.New("API keys cannot access this endpoint")
+)
+
+type apiKeyResponse struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// newApiKeyResponse returns an API key without its hash
+func newApiKeyResponse(apiKey db.ApiKey) apiKeyResponse {
+	res := apiKeyResponse{
+		ID:        apiKey.ID,
+		Name:      apiKey.Name,
+		Prefix:    apiKey.Prefix,
+		Scopes:    apiKey.Scopes,
+		CreatedAt: apiKey.CreatedAt,
+	}
+	if res.Scopes == nil {
+		res.Scopes = []string{}
+	}
+	if apiKey.LastUsedAt.Valid {
+		res.LastUsedAt = &apiKey.LastUsedAt.Time
+	}
+
+	return res
+}
+
+type createApiKeyRequest struct {
+	Name   string   `json:"name" binding:"required,max=100"`
+	Scopes []string `json:"scopes" binding:"omitempty,dive,oneof=jobs:read jobs:write applications:read"`
+}
+
+type createApiKeyResponse struct {
+	Key    string         `json:"key"`
+	ApiKey apiKeyResponse `json:"api_key"`
+}
+
+// @Schemes
+// @Summary Create API key
+// @Description Create a new named API key for the logged-in employer. The key is returned only once, only its hash is stored. Keys without scopes have all of them.
+// @Tags api keys
+// @Accept json
+// @Produce json
+// @param CreateApiKeyRequest body createApiKeyRequest true "API key name and scopes (jobs:read, jobs:write, applications:read)"
+// @Success 201 {object} createApiKeyResponse
+// @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Only employers can access this endpoint or API key with this name already exists"
+// @Failure 500 {object} ErrorResponse "Any other error"
+// @Security ApiKeyAuth
+// @Router /employers/api-keys [post]
+// createApiKey handles creating API keys of employers
+func (server *Server) createApiKey(ctx *gin.Context) {
+	var request createApiKeyRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	key, prefix, secret, err := generateApiKey()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	hashedKey, err := utils.HashPassword(secret)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	scopes := request.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	apiKey, err := server.store.CreateApiKey(ctx, db.CreateApiKeyParams{
+		EmployerID: authPayload.AccountID,
+		Name:       request.Name,
+		Prefix:     prefix,
+		HashedKey:  hashedKey,
+		Scopes:     scopes,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				err := fmt.Errorf("API key with this name already exists")
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, createApiKeyResponse{
+		Key:    key,
+		ApiKey: newApiKeyResponse(apiKey),
+	})
+}
+
+// @Schemes
+// @Summary List API keys
+// @Description List API keys of the logged-in employer, newest first
+// @Tags api keys
+// @Produce json
+// @Success 200 {array} apiKeyResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Only employers can access this endpoint"
+// @Failure 500 {object} ErrorResponse "Any other error"
+// @Security ApiKeyAuth
+// @Router /employers/api-keys [get]
+// listApiKeys lists API keys of the logged-in employer
+func (server *Server) listApiKeys(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	apiKeys, err := server.store.ListApiKeysByEmployerID(ctx, authPayload.AccountID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]apiKeyResponse, len(apiKeys))
+	for i, apiKey := range apiKeys {
+		res[i] = newApiKeyResponse(apiKey)
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type revokeApiKeyRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// @Schemes
+// @Summary Revoke API key
+// @Description Revoke (delete) an API key of the logged-in employer. It can no longer be used.
+// @Tags api keys
+// @param id path integer true "API key ID"
+// @Success 204 {null} null
+// @Failure 400 {object} ErrorResponse "Invalid API key ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Only employers can access this endpoint"
+// @Failure 404 {object} ErrorResponse "API key not found"
+// @Failure 500 {object} ErrorResponse "Any other error"
+// @Security ApiKeyAuth
+// @Router /employers/api-keys/{id} [delete]
+// revokeApiKey handles revoking API keys of employers
+func (server *Server) revokeApiKey(ctx *gin.Context) {
+	var request revokeApiKeyRequest
+	if err := ctx.ShouldBindUri(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	_, err := server.store.DeleteApiKey(ctx, db.DeleteApiKeyParams{
+		ID:         request.ID,
+		EmployerID: authPayload.AccountID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = fmt.Errorf("API key with given ID does not exist")
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// generateApiKey returns a new API key in the "jf_<prefix>_<secret>" format.
+// The prefix identifies the key, only the hash of the secret is stored.
+func generateApiKey() (key string, prefix string, secret string, err error) {
+	b := make([]byte, 6)
+	if _, err = rand.Read(b); err != nil {
+		return
+	}
+	prefix = hex.EncodeToString(b)
+
+	secret, err = randomURLSafeString(32)
+	if err != nil {
+		return
+	}
+
+	key = apiKeyPrefix + prefix + "_" + secret
+	return
+}
+
+// parseApiKey splits the API key into its prefix and secret
+func parseApiKey(key string) (prefix string, secret string, ok bool) {
+	key, ok = strings.CutPrefix(key, apiKeyPrefix)
+	if !ok {
+		return "", "", false
+	}
+
+	prefix, secret, ok = strings.Cut(key, "_")
+	if !ok || prefix == "" || secret == "" {
+		return "", "", false
+	}
+
+	return prefix, secret, true
+}
+
+// verifyApiKey checks the API key and returns the payload of the employer that owns it,
+// just like the payload of an access token. If the key is invalid, it writes
+// the error response and returns false.
+func verifyApiKey(ctx *gin.Context, store db.Store, key string) (*token.Payload, bool) {
+	prefix, secret, ok := parseApiKey(key)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(invalidApiKeyError))
+		return nil, false
+	}
+
+	apiKey, err := store.GetApiKeyByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(invalidApiKeyError))
+			return nil, false
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		return nil, false
+	}
+
+	if err := utils.CheckPassword(secret, apiKey.HashedKey); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(invalidApiKeyError))
+		return nil, false
+	}
+
+	employer, err := store.GetEmployerByID(ctx, apiKey.EmployerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(accountNotFoundError))
+			return nil, false
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		return nil, false
+	}
+
+	if err := store.UpdateApiKeyLastUsedAt(ctx, apiKey.ID); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		return nil, false
+	}
+
+	ctx.Set(authorizationApiKeyKey, apiKey)
+
+	return &token.Payload{
+		Email:       employer.Email,
+		AccountType: token.AccountTypeEmployer,
+		AccountID:   employer.ID,
+		IssuedAt:    apiKey.CreatedAt,
+	}, true
+}
+
+// requireApiKeyScope creates a gin middleware that lets API keys access the route only if
+// they have all the given scopes. Without scopes, API keys cannot access the route at all.
+// Access tokens are not limited by scopes. It has to be used after authMiddleware.
+func requireApiKeyScope(scopes ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		value, exists := ctx.Get(authorizationApiKeyKey)
+		if !exists {
+			ctx.Next()
+			return
+		}
+		apiKey := value.(db.ApiKey)
+
+		if len(scopes) == 0 {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(apiKeyNotAllowedError))
+			return
+		}
+
+		for _, scope := range scopes {
+			if !apiKeyHasScope(apiKey, scope) {
+				err := fmt.Errorf("API key does not have the %s scope", scope)
+				ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		}
+
+		ctx.Next()
+	}
+}
+
+// apiKeyHasScope checks if the API key has the scope, keys without scopes have all of them
+func apiKeyHasScope(apiKey db.ApiKey, scope string) bool {
+	if len(apiKey.Scopes) == 0 {
+		return true
+	}
+
+	for _, s := range apiKey.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
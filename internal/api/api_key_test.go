@@ -0,0 +1,501 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	mockdb "github.com/aalug/job-finder-go/internal/db/mock"
+	db "github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/aalug/job-finder-go/pkg/token"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// generateTestApiKey returns a new API key of the employer and its db row
+func generateTestApiKey(t *testing.T, employerID int32, scopes []string) (string, db.ApiKey) {
+	key, prefix, secret, err := generateApiKey()
+	require.NoError(t, err)
+
+	hashedKey, err := utils.HashPassword(secret)
+	require.NoError(t, err)
+
+	return key, db.ApiKey{
+		ID:         int64(utils.RandomInt(1, 1000)),
+		EmployerID: employerID,
+		Name:       utils.RandomString(8),
+		Prefix:     prefix,
+		HashedKey:  hashedKey,
+		Scopes:     scopes,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// addApiKeyAuthorization adds the "ApiKey" authorization header to the request
+func addApiKeyAuthorization(request *http.Request, key string) {
+	request.Header.Set(authorizationHeaderKey, fmt.Sprintf("ApiKey %s", key))
+}
+
+// buildApiKeyAuthStubs builds the stubs of a successful API key authorization
+func buildApiKeyAuthStubs(store *mockdb.MockStore, apiKey db.ApiKey, employer db.Employer) {
+	store.EXPECT().
+		GetApiKeyByPrefix(gomock.Any(), gomock.Eq(apiKey.Prefix)).
+		Times(1).
+		Return(apiKey, nil)
+	store.EXPECT().
+		GetEmployerByID(gomock.Any(), gomock.Eq(apiKey.EmployerID)).
+		Times(1).
+		Return(employer, nil)
+	store.EXPECT().
+		UpdateApiKeyLastUsedAt(gomock.Any(), gomock.Eq(apiKey.ID)).
+		Times(1).
+		Return(nil)
+}
+
+func TestCreateApiKeyAPI(t *testing.T) {
+	employer, _, _ := generateRandomEmployerAndCompany(t)
+	user, _ := generateRandomUser(t)
+	key, apiKey := generateTestApiKey(t, employer.ID, []string{})
+	name := utils.RandomString(8)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, r *http.Request, maker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"name":   name,
+				"scopes": []string{apiKeyScopeJobsWrite, apiKeyScopeApplicationsRead},
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateApiKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.CreateApiKeyParams) (db.ApiKey, error) {
+						require.Equal(t, employer.ID, arg.EmployerID)
+						require.Equal(t, name, arg.Name)
+						require.Equal(t, []string{apiKeyScopeJobsWrite, apiKeyScopeApplicationsRead}, arg.Scopes)
+						return db.ApiKey{
+							ID:         1,
+							EmployerID: arg.EmployerID,
+							Name:       arg.Name,
+							Prefix:     arg.Prefix,
+							HashedKey:  arg.HashedKey,
+							Scopes:     arg.Scopes,
+							CreatedAt:  time.Now(),
+						}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var res createApiKeyResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, name, res.ApiKey.Name)
+				require.Nil(t, res.ApiKey.LastUsedAt)
+
+				prefix, _, ok := parseApiKey(res.Key)
+				require.True(t, ok)
+				require.Equal(t, res.ApiKey.Prefix, prefix)
+			},
+		},
+		{
+			name: "OK Without Scopes",
+			body: gin.H{
+				"name": name,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateApiKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.CreateApiKeyParams) (db.ApiKey, error) {
+						require.NotNil(t, arg.Scopes)
+						require.Empty(t, arg.Scopes)
+						return db.ApiKey{ID: 1, Name: arg.Name, Prefix: arg.Prefix, Scopes: arg.Scopes}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "Invalid Scope",
+			body: gin.H{
+				"name":   name,
+				"scopes": []string{"users:delete"},
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateApiKey(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Missing Name",
+			body: gin.H{
+				"scopes": []string{apiKeyScopeJobsWrite},
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateApiKey(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Duplicate Name",
+			body: gin.H{
+				"name": name,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateApiKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ApiKey{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Unauthorized",
+			body: gin.H{
+				"name": name,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateApiKey(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden User",
+			body: gin.H{
+				"name": name,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateApiKey(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden API Key",
+			body: gin.H{
+				"name": name,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addApiKeyAuthorization(r, key)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				buildApiKeyAuthStubs(store, apiKey, employer)
+				store.EXPECT().
+					CreateApiKey(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error",
+			body: gin.H{
+				"name": name,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateApiKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ApiKey{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil, nil)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := BaseUrl + "/employers/api-keys"
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, req, server.tokenMaker)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListApiKeysAPI(t *testing.T) {
+	employer, _, _ := generateRandomEmployerAndCompany(t)
+	_, apiKey1 := generateTestApiKey(t, employer.ID, []string{apiKeyScopeJobsWrite})
+	_, apiKey2 := generateTestApiKey(t, employer.ID, []string{})
+	apiKey2.LastUsedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	apiKeys := []db.ApiKey{apiKey1, apiKey2}
+
+	testCases := []struct {
+		name          string
+		setupAuth     func(t *testing.T, r *http.Request, maker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListApiKeysByEmployerID(gomock.Any(), gomock.Eq(employer.ID)).
+					Times(1).
+					Return(apiKeys, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var res []apiKeyResponse
+				err = json.Unmarshal(data, &res)
+				require.NoError(t, err)
+				require.Len(t, res, len(apiKeys))
+				require.Equal(t, apiKey1.Prefix, res[0].Prefix)
+				require.Equal(t, apiKey1.Scopes, res[0].Scopes)
+				require.Nil(t, res[0].LastUsedAt)
+				require.NotNil(t, res[1].LastUsedAt)
+				// hashes must never be returned
+				require.NotContains(t, string(data), apiKey1.HashedKey)
+			},
+		},
+		{
+			name:      "Unauthorized",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListApiKeysByEmployerID(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListApiKeysByEmployerID(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ApiKey{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil, nil)
+			recorder := httptest.NewRecorder()
+
+			url := BaseUrl + "/employers/api-keys"
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, req, server.tokenMaker)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestRevokeApiKeyAPI(t *testing.T) {
+	employer, _, _ := generateRandomEmployerAndCompany(t)
+	_, apiKey := generateTestApiKey(t, employer.ID, []string{})
+
+	testCases := []struct {
+		name          string
+		id            int64
+		setupAuth     func(t *testing.T, r *http.Request, maker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			id:   apiKey.ID,
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteApiKey(gomock.Any(), gomock.Eq(db.DeleteApiKeyParams{
+						ID:         apiKey.ID,
+						EmployerID: employer.ID,
+					})).
+					Times(1).
+					Return(apiKey, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNoContent, recorder.Code)
+			},
+		},
+		{
+			name: "Not Found",
+			id:   apiKey.ID,
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteApiKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ApiKey{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "Invalid ID",
+			id:   0,
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteApiKey(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error",
+			id:   apiKey.ID,
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteApiKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ApiKey{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil, nil)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("%s/employers/api-keys/%d", BaseUrl, tc.id)
+			req, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, req, server.tokenMaker)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestParseApiKey(t *testing.T) {
+	key, prefix, secret, err := generateApiKey()
+	require.NoError(t, err)
+
+	prefix2, secret2, ok := parseApiKey(key)
+	require.True(t, ok)
+	require.Equal(t, prefix, prefix2)
+	require.Equal(t, secret, secret2)
+
+	for _, invalid := range []string{"", "jf_", "jf__secret", "jf_prefix_", "jf_prefix", prefix + "_" + secret} {
+		_, _, ok = parseApiKey(invalid)
+		require.False(t, ok, invalid)
+	}
+}
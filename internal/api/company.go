@@ -0,0 +1,462 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	db "github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/aalug/job-finder-go/internal/worker"
+	"github.com/aalug/job-finder-go/pkg/token"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/hibiken/asynq"
+	"github.com/lib/pq"
+	"net/http"
+	"time"
+)
+
+var (
+	insufficientCompanyRoleError    = errors.New("your role in the company does not allow this action")
+	companyMemberNotFoundError      = errors.New("employer with given ID is not a member of your company")
+	companyMemberIsSelfError        = errors.New("you cannot change your own role or remove yourself, use DELETE /employers instead")
+	companyInvitationNotFoundError  = errors.New("no invitation found with the provided details. It may have expired or been used already")
+	lastCompanyOwnerError           = errors.New("you are the last owner of the company, make another member an owner first")
+	companyMemberAlreadyExistsError = errors.New("employer with this email already exists")
+)
+
+// companyRoleRanks orders the company roles, each role can do everything the roles below it can
+var companyRoleRanks = map[db.CompanyRole]int{
+	db.CompanyRoleViewer:    1,
+	db.CompanyRoleRecruiter: 2,
+	db.CompanyRoleAdmin:     3,
+	db.CompanyRoleOwner:     4,
+}
+
+// hasCompanyRole checks if the employer has at least the given role in their company
+func hasCompanyRole(employer db.Employer, minRole db.CompanyRole) bool {
+	return companyRoleRanks[employer.Role] >= companyRoleRanks[minRole]
+}
+
+// companyRoleError returns the error used when the role of the employer is lower than minRole
+func companyRoleError(minRole db.CompanyRole) error {
+	return fmt.Errorf("%w, it requires at least the %s role", insufficientCompanyRoleError, minRole)
+}
+
+// canManageCompanyRole checks if an employer with the role can invite, promote or demote
+// members to, and remove members with, the other role. Owners can manage every role,
+// admins only the roles below their own.
+func canManageCompanyRole(role db.CompanyRole, otherRole db.CompanyRole) bool {
+	if role == db.CompanyRoleOwner {
+		return true
+	}
+
+	return role == db.CompanyRoleAdmin && companyRoleRanks[otherRole] < companyRoleRanks[db.CompanyRoleAdmin]
+}
+
+type companyMemberResponse struct {
+	EmployerID int32          `json:"employer_id"`
+	FullName   string         `json:"full_name"`
+	Email      string         `json:"email"`
+	Role       db.CompanyRole `json:"role"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+// newCompanyMemberResponse returns an employer without the password hash
+func newCompanyMemberResponse(employer db.Employer) companyMemberResponse {
+	return companyMemberResponse{
+		EmployerID: employer.ID,
+		FullName:   employer.FullName,
+		Email:      employer.Email,
+		Role:       employer.Role,
+		CreatedAt:  employer.CreatedAt,
+	}
+}
+
+// @Schemes
+// @Summary List company members
+// @Description List employers of the company of the logged-in employer, with their roles
+// @Tags company
+// @Produce json
+// @Success 200 {array} companyMemberResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Only employers can access this endpoint"
+// @Failure 500 {object} ErrorResponse "Any other error"
+// @Security ApiKeyAuth
+// @Router /employers/company/members [get]
+// listCompanyMembers lists employers of the company of the logged-in employer
+func (server *Server) listCompanyMembers(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	authEmployer, err := server.store.GetEmployerByEmail(ctx, authPayload.Email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(accountNotFoundError))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	employers, err := server.store.ListCompanyEmployers(ctx, authEmployer.CompanyID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]companyMemberResponse, len(employers))
+	for i, employer := range employers {
+		res[i] = newCompanyMemberResponse(employer)
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type inviteCompanyMemberRequest struct {
+	Email string         `json:"email" binding:"required,email"`
+	Role  db.CompanyRole `json:"role" binding:"required,oneof=owner admin recruiter viewer"`
+}
+
+type inviteCompanyMemberResponse struct {
+	Message string `json:"message"`
+}
+
+// @Schemes
+// @Summary Invite company member
+// @Description Send an email with an invitation to join the company of the logged-in employer. Only owners and admins can invite, admins only as recruiters or viewers.
+// @Tags company
+// @Accept json
+// @Produce json
+// @param InviteCompanyMemberRequest body inviteCompanyMemberRequest true "Email and role (owner, admin, recruiter, viewer) of the new member"
+// @Success 200 {object} inviteCompanyMemberResponse
+// @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Only employers can access this endpoint, role does not allow it or employer with this email already exists"
+// @Failure 500 {object} ErrorResponse "Any other error"
+// @Security ApiKeyAuth
+// @Router /employers/company/invitations [post]
+// inviteCompanyMember sends the invitation to join the company of the logged-in employer
+func (server *Server) inviteCompanyMember(ctx *gin.Context) {
+	var request inviteCompanyMemberRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	authEmployer, err := server.store.GetEmployerByEmail(ctx, authPayload.Email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(accountNotFoundError))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if !canManageCompanyRole(authEmployer.Role, request.Role) {
+		ctx.JSON(http.StatusForbidden, errorResponse(insufficientCompanyRoleError))
+		return
+	}
+
+	// an employer can be a member of only one company
+	_, err = server.store.GetEmployerByEmail(ctx, request.Email)
+	if err == nil {
+		ctx.JSON(http.StatusForbidden, errorResponse(companyMemberAlreadyExistsError))
+		return
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	companyName, err := server.store.GetCompanyNameByID(ctx, authEmployer.CompanyID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	taskPayload := &worker.PayloadSendCompanyInvitationEmail{
+		Email:           request.Email,
+		CompanyID:       authEmployer.CompanyID,
+		CompanyName:     companyName,
+		Role:            request.Role,
+		InvitedBy:       authEmployer.ID,
+		InviterFullName: authEmployer.FullName,
+	}
+
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.Queue(worker.QueueCritical),
+	}
+
+	err = server.taskDistributor.DistributeTaskSendCompanyInvitationEmail(ctx, taskPayload, opts...)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, inviteCompanyMemberResponse{Message: "invitation email sent"})
+}
+
+type acceptCompanyInvitationRequest struct {
+	ID         int64  `json:"id" binding:"required,min=1"`
+	SecretCode string `json:"code" binding:"required,min=32"`
+	FullName   string `json:"full_name" binding:"required"`
+	Password   string `json:"password" binding:"required,min=6"`
+}
+
+// @Schemes
+// @Summary Accept company invitation
+// @Description Create an employer account in the company using the ID and the code from the invitation email. The email of the invitation is used and treated as verified. The code can be used only once.
+// @Tags company
+// @Accept json
+// @Produce json
+// @param AcceptCompanyInvitationRequest body acceptCompanyInvitationRequest true "Invitation ID, code and the employer details"
+// @Success 201 {object} employerResponse
+// @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 403 {object} ErrorResponse "Employer with this email already exists"
+// @Failure 404 {object} ErrorResponse "Invitation not found, expired or already used"
+// @Failure 500 {object} ErrorResponse "Any other error"
+// @Router /employers/company/invitations/accept [post]
+// acceptCompanyInvitation creates an employer in the company of the invitation
+func (server *Server) acceptCompanyInvitation(ctx *gin.Context) {
+	var request acceptCompanyInvitationRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	hashedPassword, err := utils.HashPassword(request.Password)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	txResult, err := server.store.AcceptCompanyInvitationTx(ctx, db.AcceptCompanyInvitationTxParams{
+		ID:             request.ID,
+		SecretCode:     request.SecretCode,
+		FullName:       request.FullName,
+		HashedPassword: hashedPassword,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(companyInvitationNotFoundError))
+			return
+		}
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(companyMemberAlreadyExistsError))
+				return
+			}
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	company, err := server.store.GetCompanyByID(ctx, txResult.Employer.CompanyID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, newEmployerResponse(txResult.Employer, company))
+}
+
+type companyMemberUriRequest struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+type updateCompanyMemberRoleRequest struct {
+	Role db.CompanyRole `json:"role" binding:"required,oneof=owner admin recruiter viewer"`
+}
+
+// @Schemes
+// @Summary Update company member role
+// @Description Change the role of another member of the company of the logged-in employer. Owners can change every role, admins only between recruiter and viewer.
+// @Tags company
+// @Accept json
+// @Produce json
+// @param id path integer true "Employer ID"
+// @param UpdateCompanyMemberRoleRequest body updateCompanyMemberRoleRequest true "New role (owner, admin, recruiter, viewer)"
+// @Success 200 {object} companyMemberResponse
+// @Failure 400 {object} ErrorResponse "Invalid employer ID or request body"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Only employers can access this endpoint, role does not allow it or employer tries to change their own role"
+// @Failure 404 {object} ErrorResponse "Employer is not a member of the company"
+// @Failure 500 {object} ErrorResponse "Any other error"
+// @Security ApiKeyAuth
+// @Router /employers/company/members/{id}/role [patch]
+// updateCompanyMemberRole handles changing roles of company members
+func (server *Server) updateCompanyMemberRole(ctx *gin.Context) {
+	var uriRequest companyMemberUriRequest
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var request updateCompanyMemberRoleRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authEmployer, member, ok := server.getManagedCompanyMember(ctx, uriRequest.ID)
+	if !ok {
+		return
+	}
+
+	if !canManageCompanyRole(authEmployer.Role, request.Role) {
+		ctx.JSON(http.StatusForbidden, errorResponse(insufficientCompanyRoleError))
+		return
+	}
+
+	member, err := server.store.UpdateEmployerRole(ctx, db.UpdateEmployerRoleParams{
+		ID:        member.ID,
+		CompanyID: authEmployer.CompanyID,
+		Role:      request.Role,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(companyMemberNotFoundError))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newCompanyMemberResponse(member))
+}
+
+// @Schemes
+// @Summary Remove company member
+// @Description Remove (delete) another member of the company of the logged-in employer. Owners can remove every member, admins only recruiters and viewers. The tokens and sessions of the removed member are revoked.
+// @Tags company
+// @param id path integer true "Employer ID"
+// @Success 204 {null} null
+// @Failure 400 {object} ErrorResponse "Invalid employer ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Only employers can access this endpoint, role does not allow it or employer tries to remove themselves"
+// @Failure 404 {object} ErrorResponse "Employer is not a member of the company"
+// @Failure 500 {object} ErrorResponse "Any other error"
+// @Security ApiKeyAuth
+// @Router /employers/company/members/{id} [delete]
+// removeCompanyMember handles removing members of the company
+func (server *Server) removeCompanyMember(ctx *gin.Context) {
+	var request companyMemberUriRequest
+	if err := ctx.ShouldBindUri(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	_, member, ok := server.getManagedCompanyMember(ctx, request.ID)
+	if !ok {
+		return
+	}
+
+	err := server.store.DeleteEmployerTx(ctx, db.DeleteEmployerTxParams{
+		ID:        member.ID,
+		Email:     member.Email,
+		CompanyID: member.CompanyID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// getManagedCompanyMember returns the logged-in employer and the member of their company
+// with the given ID, if the logged-in employer can manage the current role of the member.
+// Employers cannot manage themselves, so the company always keeps at least one owner.
+// If any check fails, it writes the error response and returns false.
+func (server *Server) getManagedCompanyMember(ctx *gin.Context, memberID int32) (db.Employer, db.Employer, bool) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	authEmployer, err := server.store.GetEmployerByEmail(ctx, authPayload.Email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(accountNotFoundError))
+			return db.Employer{}, db.Employer{}, false
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.Employer{}, db.Employer{}, false
+	}
+
+	if !hasCompanyRole(authEmployer, db.CompanyRoleAdmin) {
+		ctx.JSON(http.StatusForbidden, errorResponse(companyRoleError(db.CompanyRoleAdmin)))
+		return db.Employer{}, db.Employer{}, false
+	}
+
+	if memberID == authEmployer.ID {
+		ctx.JSON(http.StatusForbidden, errorResponse(companyMemberIsSelfError))
+		return db.Employer{}, db.Employer{}, false
+	}
+
+	member, err := server.store.GetEmployerByID(ctx, memberID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(companyMemberNotFoundError))
+			return db.Employer{}, db.Employer{}, false
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.Employer{}, db.Employer{}, false
+	}
+
+	if member.CompanyID != authEmployer.CompanyID {
+		ctx.JSON(http.StatusNotFound, errorResponse(companyMemberNotFoundError))
+		return db.Employer{}, db.Employer{}, false
+	}
+
+	if !canManageCompanyRole(authEmployer.Role, member.Role) {
+		ctx.JSON(http.StatusForbidden, errorResponse(insufficientCompanyRoleError))
+		return db.Employer{}, db.Employer{}, false
+	}
+
+	return authEmployer, member, true
+}
+
+// removeEmployerFromCompany deletes the employer and revokes its tokens. The company is deleted
+// with its last member, and the last owner cannot leave while other members remain.
+// If it fails, it writes the error response and returns false.
+func (server *Server) removeEmployerFromCompany(ctx *gin.Context, employer db.Employer) bool {
+	members, err := server.store.ListCompanyEmployers(ctx, employer.CompanyID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	// the company is deleted with its last member
+	deleteCompany := len(members) <= 1
+	if !deleteCompany && employer.Role == db.CompanyRoleOwner {
+		otherOwner := false
+		for _, member := range members {
+			if member.ID != employer.ID && member.Role == db.CompanyRoleOwner {
+				otherOwner = true
+				break
+			}
+		}
+		if !otherOwner {
+			ctx.JSON(http.StatusForbidden, errorResponse(lastCompanyOwnerError))
+			return false
+		}
+	}
+
+	err = server.store.DeleteEmployerTx(ctx, db.DeleteEmployerTxParams{
+		ID:            employer.ID,
+		Email:         employer.Email,
+		CompanyID:     employer.CompanyID,
+		DeleteCompany: deleteCompany,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	return true
+}
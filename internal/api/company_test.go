@@ -0,0 +1,939 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	mockdb "github.com/aalug/job-finder-go/internal/db/mock"
+	db "github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/aalug/job-finder-go/internal/worker"
+	mockworker "github.com/aalug/job-finder-go/internal/worker/mock"
+	"github.com/aalug/job-finder-go/pkg/token"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type eqAcceptCompanyInvitationTxParamsMatcher struct {
+	arg      db.AcceptCompanyInvitationTxParams
+	password string
+}
+
+func (e eqAcceptCompanyInvitationTxParamsMatcher) Matches(x interface{}) bool {
+	actualArg, ok := x.(db.AcceptCompanyInvitationTxParams)
+	if !ok {
+		return false
+	}
+
+	err := utils.CheckPassword(e.password, actualArg.HashedPassword)
+	if err != nil {
+		return false
+	}
+
+	e.arg.HashedPassword = actualArg.HashedPassword
+	return reflect.DeepEqual(e.arg, actualArg)
+}
+
+func (e eqAcceptCompanyInvitationTxParamsMatcher) String() string {
+	return fmt.Sprintf("matches arg %v and password %v", e.arg, e.password)
+}
+
+func EqAcceptCompanyInvitationTxParams(arg db.AcceptCompanyInvitationTxParams, password string) gomock.Matcher {
+	return eqAcceptCompanyInvitationTxParamsMatcher{arg, password}
+}
+
+// generateCompanyMember returns a random employer of the company with the given role
+func generateCompanyMember(t *testing.T, company db.Company, role db.CompanyRole) db.Employer {
+	employer, _, _ := generateRandomEmployerAndCompany(t)
+	employer.ID = utils.RandomInt(101, 1000)
+	employer.CompanyID = company.ID
+	employer.Role = role
+	return employer
+}
+
+func TestCanManageCompanyRole(t *testing.T) {
+	testCases := []struct {
+		role      db.CompanyRole
+		otherRole db.CompanyRole
+		expected  bool
+	}{
+		{db.CompanyRoleOwner, db.CompanyRoleOwner, true},
+		{db.CompanyRoleOwner, db.CompanyRoleViewer, true},
+		{db.CompanyRoleAdmin, db.CompanyRoleOwner, false},
+		{db.CompanyRoleAdmin, db.CompanyRoleAdmin, false},
+		{db.CompanyRoleAdmin, db.CompanyRoleRecruiter, true},
+		{db.CompanyRoleAdmin, db.CompanyRoleViewer, true},
+		{db.CompanyRoleRecruiter, db.CompanyRoleViewer, false},
+		{db.CompanyRoleViewer, db.CompanyRoleViewer, false},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.expected, canManageCompanyRole(tc.role, tc.otherRole), "%s managing %s", tc.role, tc.otherRole)
+	}
+}
+
+func TestListCompanyMembersAPI(t *testing.T) {
+	employer, _, company := generateRandomEmployerAndCompany(t)
+	member := generateCompanyMember(t, company, db.CompanyRoleViewer)
+
+	testCases := []struct {
+		name          string
+		setupAuth     func(t *testing.T, r *http.Request, maker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					ListCompanyEmployers(gomock.Any(), gomock.Eq(company.ID)).
+					Times(1).
+					Return([]db.Employer{employer, member}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+				require.NotContains(t, string(data), "hashed_password")
+
+				var response []companyMemberResponse
+				err = json.Unmarshal(data, &response)
+				require.NoError(t, err)
+				require.Len(t, response, 2)
+				require.Equal(t, employer.ID, response[0].EmployerID)
+				require.Equal(t, db.CompanyRoleOwner, response[0].Role)
+				require.Equal(t, member.Email, response[1].Email)
+				require.Equal(t, db.CompanyRoleViewer, response[1].Role)
+			},
+		},
+		{
+			name: "Unauthorized",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListCompanyEmployers(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error ListCompanyEmployers",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					ListCompanyEmployers(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.Employer{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil, nil)
+			recorder := httptest.NewRecorder()
+
+			url := BaseUrl + "/employers/company/members"
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, req, server.tokenMaker)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestInviteCompanyMemberAPI(t *testing.T) {
+	employer, _, company := generateRandomEmployerAndCompany(t)
+	admin := generateCompanyMember(t, company, db.CompanyRoleAdmin)
+	recruiter := generateCompanyMember(t, company, db.CompanyRoleRecruiter)
+	email := utils.RandomEmail()
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, r *http.Request, maker token.Maker)
+		buildStubs    func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"email": email,
+				"role":  db.CompanyRoleAdmin,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(email)).
+					Times(1).
+					Return(db.Employer{}, sql.ErrNoRows)
+				store.EXPECT().
+					GetCompanyNameByID(gomock.Any(), gomock.Eq(company.ID)).
+					Times(1).
+					Return(company.Name, nil)
+				taskPayload := &worker.PayloadSendCompanyInvitationEmail{
+					Email:           email,
+					CompanyID:       company.ID,
+					CompanyName:     company.Name,
+					Role:            db.CompanyRoleAdmin,
+					InvitedBy:       employer.ID,
+					InviterFullName: employer.FullName,
+				}
+				distributor.EXPECT().
+					DistributeTaskSendCompanyInvitationEmail(gomock.Any(), gomock.Eq(taskPayload), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "OK Admin Invites Recruiter",
+			body: gin.H{
+				"email": email,
+				"role":  db.CompanyRoleRecruiter,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, admin.Email, token.AccountTypeEmployer, admin.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(admin.Email)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(email)).
+					Times(1).
+					Return(db.Employer{}, sql.ErrNoRows)
+				store.EXPECT().
+					GetCompanyNameByID(gomock.Any(), gomock.Eq(company.ID)).
+					Times(1).
+					Return(company.Name, nil)
+				distributor.EXPECT().
+					DistributeTaskSendCompanyInvitationEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden Admin Invites Admin",
+			body: gin.H{
+				"email": email,
+				"role":  db.CompanyRoleAdmin,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, admin.Email, token.AccountTypeEmployer, admin.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(admin.Email)).
+					Times(1).
+					Return(admin, nil)
+				distributor.EXPECT().
+					DistributeTaskSendCompanyInvitationEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden Recruiter",
+			body: gin.H{
+				"email": email,
+				"role":  db.CompanyRoleViewer,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, recruiter.Email, token.AccountTypeEmployer, recruiter.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(recruiter.Email)).
+					Times(1).
+					Return(recruiter, nil)
+				distributor.EXPECT().
+					DistributeTaskSendCompanyInvitationEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Employer Already Exists",
+			body: gin.H{
+				"email": recruiter.Email,
+				"role":  db.CompanyRoleViewer,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(recruiter.Email)).
+					Times(1).
+					Return(recruiter, nil)
+				distributor.EXPECT().
+					DistributeTaskSendCompanyInvitationEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Invalid Role",
+			body: gin.H{
+				"email": email,
+				"role":  "manager",
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Any()).
+					Times(0)
+				distributor.EXPECT().
+					DistributeTaskSendCompanyInvitationEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error DistributeTaskSendCompanyInvitationEmail",
+			body: gin.H{
+				"email": email,
+				"role":  db.CompanyRoleViewer,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(email)).
+					Times(1).
+					Return(db.Employer{}, sql.ErrNoRows)
+				store.EXPECT().
+					GetCompanyNameByID(gomock.Any(), gomock.Eq(company.ID)).
+					Times(1).
+					Return(company.Name, nil)
+				distributor.EXPECT().
+					DistributeTaskSendCompanyInvitationEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("some error"))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockworker.NewMockTaskDistributor(taskCtrl)
+
+			tc.buildStubs(store, taskDistributor)
+
+			server := newTestServer(t, store, nil, taskDistributor)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := BaseUrl + "/employers/company/invitations"
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, req, server.tokenMaker)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestAcceptCompanyInvitationAPI(t *testing.T) {
+	_, _, company := generateRandomEmployerAndCompany(t)
+	employer := generateCompanyMember(t, company, db.CompanyRoleRecruiter)
+	employer.IsEmailVerified = true
+	password := utils.RandomString(8)
+	invitation := db.CompanyInvitation{
+		ID:         int64(utils.RandomInt(1, 100)),
+		CompanyID:  company.ID,
+		Email:      employer.Email,
+		Role:       employer.Role,
+		SecretCode: utils.RandomString(32),
+		IsUsed:     true,
+	}
+
+	requestBody := gin.H{
+		"id":        invitation.ID,
+		"code":      invitation.SecretCode,
+		"full_name": employer.FullName,
+		"password":  password,
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: requestBody,
+			buildStubs: func(store *mockdb.MockStore) {
+				params := db.AcceptCompanyInvitationTxParams{
+					ID:         invitation.ID,
+					SecretCode: invitation.SecretCode,
+					FullName:   employer.FullName,
+				}
+				store.EXPECT().
+					AcceptCompanyInvitationTx(gomock.Any(), EqAcceptCompanyInvitationTxParams(params, password)).
+					Times(1).
+					Return(db.AcceptCompanyInvitationTxResult{
+						Employer:   employer,
+						Invitation: invitation,
+					}, nil)
+				store.EXPECT().
+					GetCompanyByID(gomock.Any(), gomock.Eq(company.ID)).
+					Times(1).
+					Return(company, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				requireBodyMatchEmployerAndCompany(t, recorder.Body, employer, company)
+			},
+		},
+		{
+			name: "Invalid Code",
+			body: gin.H{
+				"id":        invitation.ID,
+				"code":      "short",
+				"full_name": employer.FullName,
+				"password":  password,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					AcceptCompanyInvitationTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Invitation Not Found",
+			body: requestBody,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					AcceptCompanyInvitationTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AcceptCompanyInvitationTxResult{}, sql.ErrNoRows)
+				store.EXPECT().
+					GetCompanyByID(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "Employer Already Exists",
+			body: requestBody,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					AcceptCompanyInvitationTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AcceptCompanyInvitationTxResult{}, &pq.Error{Code: "23505"})
+				store.EXPECT().
+					GetCompanyByID(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error GetCompanyByID",
+			body: requestBody,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					AcceptCompanyInvitationTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AcceptCompanyInvitationTxResult{
+						Employer:   employer,
+						Invitation: invitation,
+					}, nil)
+				store.EXPECT().
+					GetCompanyByID(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Company{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil, nil)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := BaseUrl + "/employers/company/invitations/accept"
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUpdateCompanyMemberRoleAPI(t *testing.T) {
+	employer, _, company := generateRandomEmployerAndCompany(t)
+	admin := generateCompanyMember(t, company, db.CompanyRoleAdmin)
+	recruiter := generateCompanyMember(t, company, db.CompanyRoleRecruiter)
+	otherCompanyEmployer, _, _ := generateRandomEmployerAndCompany(t)
+	otherCompanyEmployer.ID = recruiter.ID + 1
+	otherCompanyEmployer.CompanyID = company.ID + 1
+
+	testCases := []struct {
+		name          string
+		memberID      int32
+		body          gin.H
+		setupAuth     func(t *testing.T, r *http.Request, maker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			memberID: recruiter.ID,
+			body: gin.H{
+				"role": db.CompanyRoleAdmin,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					GetEmployerByID(gomock.Any(), gomock.Eq(recruiter.ID)).
+					Times(1).
+					Return(recruiter, nil)
+				promoted := recruiter
+				promoted.Role = db.CompanyRoleAdmin
+				store.EXPECT().
+					UpdateEmployerRole(gomock.Any(), gomock.Eq(db.UpdateEmployerRoleParams{
+						ID:        recruiter.ID,
+						CompanyID: company.ID,
+						Role:      db.CompanyRoleAdmin,
+					})).
+					Times(1).
+					Return(promoted, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+				var response companyMemberResponse
+				err = json.Unmarshal(data, &response)
+				require.NoError(t, err)
+				require.Equal(t, recruiter.ID, response.EmployerID)
+				require.Equal(t, db.CompanyRoleAdmin, response.Role)
+			},
+		},
+		{
+			name:     "Forbidden Own Role",
+			memberID: employer.ID,
+			body: gin.H{
+				"role": db.CompanyRoleViewer,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					UpdateEmployerRole(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "Forbidden Admin Promotes To Admin",
+			memberID: recruiter.ID,
+			body: gin.H{
+				"role": db.CompanyRoleAdmin,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, admin.Email, token.AccountTypeEmployer, admin.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(admin.Email)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetEmployerByID(gomock.Any(), gomock.Eq(recruiter.ID)).
+					Times(1).
+					Return(recruiter, nil)
+				store.EXPECT().
+					UpdateEmployerRole(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "Forbidden Admin Demotes Owner",
+			memberID: employer.ID,
+			body: gin.H{
+				"role": db.CompanyRoleViewer,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, admin.Email, token.AccountTypeEmployer, admin.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(admin.Email)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetEmployerByID(gomock.Any(), gomock.Eq(employer.ID)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					UpdateEmployerRole(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "Forbidden Recruiter",
+			memberID: admin.ID,
+			body: gin.H{
+				"role": db.CompanyRoleViewer,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, recruiter.Email, token.AccountTypeEmployer, recruiter.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(recruiter.Email)).
+					Times(1).
+					Return(recruiter, nil)
+				store.EXPECT().
+					GetEmployerByID(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					UpdateEmployerRole(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "Member Of Other Company",
+			memberID: otherCompanyEmployer.ID,
+			body: gin.H{
+				"role": db.CompanyRoleViewer,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					GetEmployerByID(gomock.Any(), gomock.Eq(otherCompanyEmployer.ID)).
+					Times(1).
+					Return(otherCompanyEmployer, nil)
+				store.EXPECT().
+					UpdateEmployerRole(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:     "Invalid Role",
+			memberID: recruiter.ID,
+			body: gin.H{
+				"role": "manager",
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					UpdateEmployerRole(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil, nil)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("%s/employers/company/members/%d/role", BaseUrl, tc.memberID)
+			req, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, req, server.tokenMaker)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestRemoveCompanyMemberAPI(t *testing.T) {
+	employer, _, company := generateRandomEmployerAndCompany(t)
+	admin := generateCompanyMember(t, company, db.CompanyRoleAdmin)
+	viewer := generateCompanyMember(t, company, db.CompanyRoleViewer)
+
+	testCases := []struct {
+		name          string
+		memberID      int32
+		setupAuth     func(t *testing.T, r *http.Request, maker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			memberID: viewer.ID,
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, admin.Email, token.AccountTypeEmployer, admin.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(admin.Email)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetEmployerByID(gomock.Any(), gomock.Eq(viewer.ID)).
+					Times(1).
+					Return(viewer, nil)
+				store.EXPECT().
+					DeleteEmployerTx(gomock.Any(), gomock.Eq(db.DeleteEmployerTxParams{
+						ID:        viewer.ID,
+						Email:     viewer.Email,
+						CompanyID: company.ID,
+					})).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNoContent, recorder.Code)
+			},
+		},
+		{
+			name:     "Forbidden Admin Removes Owner",
+			memberID: employer.ID,
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, admin.Email, token.AccountTypeEmployer, admin.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(admin.Email)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetEmployerByID(gomock.Any(), gomock.Eq(employer.ID)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					DeleteEmployerTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "Member Not Found",
+			memberID: viewer.ID,
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					GetEmployerByID(gomock.Any(), gomock.Eq(viewer.ID)).
+					Times(1).
+					Return(db.Employer{}, sql.ErrNoRows)
+				store.EXPECT().
+					DeleteEmployerTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:     "Internal Server Error DeleteEmployerTx",
+			memberID: viewer.ID,
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					GetEmployerByID(gomock.Any(), gomock.Eq(viewer.ID)).
+					Times(1).
+					Return(viewer, nil)
+				store.EXPECT().
+					DeleteEmployerTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil, nil)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("%s/employers/company/members/%d", BaseUrl, tc.memberID)
+			req, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, req, server.tokenMaker)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
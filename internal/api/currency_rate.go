@@ -0,0 +1,128 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	db "github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/aalug/job-finder-go/internal/salary"
+	"github.com/aalug/job-finder-go/internal/worker"
+	"github.com/gin-gonic/gin"
+	"github.com/hibiken/asynq"
+	"net/http"
+)
+
+var (
+	unsupportedCurrencyError = errors.New("salary currency is not supported, there is no exchange rate for it")
+	baseCurrencyRateError    = fmt.Errorf("rate of the base currency (%s) cannot be changed", salary.BaseCurrency)
+)
+
+// @Schemes
+// @Summary List currency rates
+// @Description List the exchange rates used to convert salaries to the base currency (USD). Rate is the value of 1 unit of the currency in the base currency.
+// @Tags currency rates
+// @Produce json
+// @Success 200 {array} []db.CurrencyRate
+// @Failure 500 {object} ErrorResponse "Any other error"
+// @Router /currency-rates [get]
+// listCurrencyRates handles listing the currency rates
+func (server *Server) listCurrencyRates(ctx *gin.Context) {
+	rates, err := server.store.ListCurrencyRates(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rates)
+}
+
+type updateCurrencyRateUri struct {
+	Currency string `uri:"currency" binding:"required,iso4217"`
+}
+
+type updateCurrencyRateRequest struct {
+	Rate float64 `json:"rate" binding:"required,gt=0"`
+}
+
+// @Schemes
+// @Summary Update currency rate
+// @Description Create or update the exchange rate of a currency. Only admins can access this endpoint. Normalized salaries of the jobs in this currency are recalculated in the background.
+// @Tags currency rates
+// @Accept json
+// @Produce json
+// @Param currency path string true "ISO 4217 currency code"
+// @param UpdateCurrencyRateRequest body updateCurrencyRateRequest true "Value of 1 unit of the currency in the base currency"
+// @Success 200 {object} db.CurrencyRate
+// @Failure 400 {object} ErrorResponse "Invalid currency, rate or the currency is the base currency"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Only admins can access this endpoint"
+// @Failure 500 {object} ErrorResponse "Any other error"
+// @Security ApiKeyAuth
+// @Router /admin/currency-rates/{currency} [put]
+// updateCurrencyRate handles updating the currency rates by admins
+func (server *Server) updateCurrencyRate(ctx *gin.Context) {
+	var uri updateCurrencyRateUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var request updateCurrencyRateRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if uri.Currency == salary.BaseCurrency {
+		ctx.JSON(http.StatusBadRequest, errorResponse(baseCurrencyRateError))
+		return
+	}
+
+	rate, err := server.store.UpsertCurrencyRate(ctx, db.UpsertCurrencyRateParams{
+		Currency: uri.Currency,
+		Rate:     request.Rate,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// recalculate the normalized salaries of the jobs in this currency
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.Queue(worker.QueueDefault),
+	}
+	err = server.taskDistributor.DistributeTaskNormalizeJobSalaries(
+		ctx,
+		&worker.PayloadNormalizeJobSalaries{Currency: rate.Currency},
+		opts...,
+	)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rate)
+}
+
+// annualizeSalary converts the salary range of a job to annual amounts in the base currency.
+// It returns unsupportedCurrencyError if there is no rate for the currency.
+func (server *Server) annualizeSalary(ctx *gin.Context, salaryMin, salaryMax int32, currency string, period db.SalaryPeriod) (int32, int32, error) {
+	rate, err := server.store.GetCurrencyRate(ctx, currency)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, 0, unsupportedCurrencyError
+		}
+		return 0, 0, err
+	}
+
+	return salary.AnnualizeRange(salaryMin, salaryMax, period, rate.Rate)
+}
+
+// annualizeSalaryErrorStatus returns the status code of the annualizeSalary error
+func annualizeSalaryErrorStatus(err error) int {
+	if errors.Is(err, unsupportedCurrencyError) || errors.Is(err, salary.ErrSalaryTooHigh) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
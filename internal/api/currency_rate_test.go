@@ -0,0 +1,328 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/aalug/job-finder-go/internal/db/mock"
+	db "github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/aalug/job-finder-go/internal/worker"
+	mockworker "github.com/aalug/job-finder-go/internal/worker/mock"
+	"github.com/aalug/job-finder-go/pkg/token"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// testAdminEmail is in the ADMIN_EMAILS of the test server
+const testAdminEmail = "admin@example.com"
+
+// eurRate is the currency rate of the generated jobs
+var eurRate = db.CurrencyRate{
+	Currency:  "EUR",
+	Rate:      1.08,
+	UpdatedAt: time.Now(),
+}
+
+func TestListCurrencyRatesAPI(t *testing.T) {
+	rates := []db.CurrencyRate{
+		eurRate,
+		{
+			Currency:  "USD",
+			Rate:      1,
+			UpdatedAt: time.Now(),
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListCurrencyRates(gomock.Any()).
+					Times(1).
+					Return(rates, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+				var gotRates []db.CurrencyRate
+				err = json.Unmarshal(data, &gotRates)
+				require.NoError(t, err)
+				require.Len(t, gotRates, len(rates))
+				for i := range rates {
+					require.Equal(t, rates[i].Currency, gotRates[i].Currency)
+					require.Equal(t, rates[i].Rate, gotRates[i].Rate)
+				}
+			},
+		},
+		{
+			name: "Internal Server Error",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListCurrencyRates(gomock.Any()).
+					Times(1).
+					Return([]db.CurrencyRate{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil, nil)
+			recorder := httptest.NewRecorder()
+
+			url := BaseUrl + "/currency-rates"
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUpdateCurrencyRateAPI(t *testing.T) {
+	rate := db.CurrencyRate{
+		Currency:  "PLN",
+		Rate:      0.26,
+		UpdatedAt: time.Now(),
+	}
+	adminID := utils.RandomInt(1, 1000)
+
+	testCases := []struct {
+		name          string
+		currency      string
+		body          gin.H
+		setupAuth     func(t *testing.T, r *http.Request, maker token.Maker)
+		buildStubs    func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			currency: rate.Currency,
+			body: gin.H{
+				"rate": rate.Rate,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, testAdminEmail, token.AccountTypeUser, adminID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					UpsertCurrencyRate(gomock.Any(), gomock.Eq(db.UpsertCurrencyRateParams{
+						Currency: rate.Currency,
+						Rate:     rate.Rate,
+					})).
+					Times(1).
+					Return(rate, nil)
+				distributor.EXPECT().
+					DistributeTaskNormalizeJobSalaries(
+						gomock.Any(),
+						gomock.Eq(&worker.PayloadNormalizeJobSalaries{Currency: rate.Currency}),
+						gomock.Any(),
+					).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+				var gotRate db.CurrencyRate
+				err = json.Unmarshal(data, &gotRate)
+				require.NoError(t, err)
+				require.Equal(t, rate.Currency, gotRate.Currency)
+				require.Equal(t, rate.Rate, gotRate.Rate)
+			},
+		},
+		{
+			name:     "Unauthorized",
+			currency: rate.Currency,
+			body: gin.H{
+				"rate": rate.Rate,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					UpsertCurrencyRate(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "Not Admin",
+			currency: rate.Currency,
+			body: gin.H{
+				"rate": rate.Rate,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, utils.RandomEmail(), token.AccountTypeEmployer, adminID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					UpsertCurrencyRate(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "Invalid Currency",
+			currency: "pln",
+			body: gin.H{
+				"rate": rate.Rate,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, testAdminEmail, token.AccountTypeUser, adminID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					UpsertCurrencyRate(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "Invalid Rate",
+			currency: rate.Currency,
+			body: gin.H{
+				"rate": -1,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, testAdminEmail, token.AccountTypeUser, adminID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					UpsertCurrencyRate(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "Base Currency",
+			currency: "USD",
+			body: gin.H{
+				"rate": 2,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, testAdminEmail, token.AccountTypeUser, adminID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					UpsertCurrencyRate(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "Internal Server Error UpsertCurrencyRate",
+			currency: rate.Currency,
+			body: gin.H{
+				"rate": rate.Rate,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, testAdminEmail, token.AccountTypeUser, adminID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					UpsertCurrencyRate(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CurrencyRate{}, sql.ErrConnDone)
+				distributor.EXPECT().
+					DistributeTaskNormalizeJobSalaries(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:     "Internal Server Error DistributeTaskNormalizeJobSalaries",
+			currency: rate.Currency,
+			body: gin.H{
+				"rate": rate.Rate,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, testAdminEmail, token.AccountTypeUser, adminID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					UpsertCurrencyRate(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(rate, nil)
+				distributor.EXPECT().
+					DistributeTaskNormalizeJobSalaries(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockworker.NewMockTaskDistributor(taskCtrl)
+
+			tc.buildStubs(store, taskDistributor)
+
+			server := newTestServer(t, store, nil, taskDistributor)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("%s/admin/currency-rates/%s", BaseUrl, tc.currency)
+			req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, req, server.tokenMaker)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -6,11 +6,13 @@ import (
 	"errors"
 	"fmt"
 	db "github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/aalug/job-finder-go/internal/geo"
 	"github.com/aalug/job-finder-go/internal/worker"
 	"github.com/aalug/job-finder-go/pkg/token"
 	"github.com/aalug/job-finder-go/pkg/utils"
 	"github.com/aalug/job-finder-go/pkg/validation"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
 	"github.com/lib/pq"
 	"net/http"
@@ -27,14 +29,15 @@ type createEmployerRequest struct {
 }
 
 type employerResponse struct {
-	EmployerID        int32     `json:"employer_id"`
-	FullName          string    `json:"full_name"`
-	Email             string    `json:"email"`
-	EmployerCreatedAt time.Time `json:"employer_created_at"`
-	CompanyID         int32     `json:"company_id"`
-	CompanyName       string    `json:"company_name"`
-	CompanyIndustry   string    `json:"company_industry"`
-	CompanyLocation   string    `json:"company_location"`
+	EmployerID        int32          `json:"employer_id"`
+	FullName          string         `json:"full_name"`
+	Email             string         `json:"email"`
+	EmployerCreatedAt time.Time      `json:"employer_created_at"`
+	Role              db.CompanyRole `json:"role"`
+	CompanyID         int32          `json:"company_id"`
+	CompanyName       string         `json:"company_name"`
+	CompanyIndustry   string         `json:"company_industry"`
+	CompanyLocation   string         `json:"company_location"`
 }
 
 // newEmployerResponse creates a new employer response from a db.Employer and db.Company
@@ -44,6 +47,7 @@ func newEmployerResponse(employer db.Employer, company db.Company) employerRespo
 		FullName:          employer.FullName,
 		Email:             employer.Email,
 		EmployerCreatedAt: employer.CreatedAt,
+		Role:              employer.Role,
 		CompanyID:         company.ID,
 		CompanyName:       company.Name,
 		CompanyIndustry:   company.Industry,
@@ -53,7 +57,7 @@ func newEmployerResponse(employer db.Employer, company db.Company) employerRespo
 
 // @Schemes
 // @Summary Create employer
-// @Description Create a new employer
+// @Description Create a new employer and their company, the employer becomes the owner of the company. To join an existing company, ask its owner or admin for an invitation.
 // @Tags employers
 // @Accept json
 // @Produce json
@@ -72,10 +76,11 @@ func (server *Server) createEmployer(ctx *gin.Context) {
 	}
 
 	// create a company
+	companyLocation, _ := geo.NormalizeLocation(request.CompanyLocation)
 	companyParams := db.CreateCompanyParams{
 		Name:     request.CompanyName,
 		Industry: request.CompanyIndustry,
-		Location: request.CompanyLocation,
+		Location: companyLocation,
 	}
 
 	company, err := server.store.CreateCompany(ctx, companyParams)
@@ -83,7 +88,7 @@ func (server *Server) createEmployer(ctx *gin.Context) {
 		if pqErr, ok := err.(*pq.Error); ok {
 			switch pqErr.Code.Name() {
 			case "unique_violation":
-				err := fmt.Errorf("company with this name already exists")
+				err := fmt.Errorf("company with this name already exists, ask its owner or admin for an invitation")
 				ctx.JSON(http.StatusForbidden, errorResponse(err))
 				return
 			}
@@ -106,6 +111,7 @@ func (server *Server) createEmployer(ctx *gin.Context) {
 			FullName:       request.FullName,
 			Email:          request.Email,
 			HashedPassword: hashedPassword,
+			Role:           db.CompanyRoleOwner,
 		},
 		AfterCreate: func(employer db.Employer) error {
 			taskPayload := &worker.PayloadSendVerificationEmail{
@@ -146,8 +152,12 @@ type loginEmployerRequest struct {
 }
 
 type loginEmployerResponse struct {
-	AccessToken string           `json:"access_token"`
-	Employer    employerResponse `json:"employer"`
+	SessionID             uuid.UUID        `json:"session_id"`
+	AccessToken           string           `json:"access_token"`
+	AccessTokenExpiresAt  time.Time        `json:"access_token_expires_at"`
+	RefreshToken          string           `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time        `json:"refresh_token_expires_at"`
+	Employer              employerResponse `json:"employer"`
 }
 
 // @Schemes
@@ -158,10 +168,13 @@ type loginEmployerResponse struct {
 // @Produce json
 // @param LoginEmployerRequest body loginEmployerRequest true "Employer credentials"
 // @Success 200 {object} loginEmployerResponse
+// @Success 202 {object} mfaRequiredResponse "MFA is enabled, finish the login with POST /employers/login/mfa"
 // @Failure 400 {object} ErrorResponse "Invalid request body"
 // @Failure 401 {object} ErrorResponse "Incorrect password"
 // @Failure 403 {object} ErrorResponse "Email not verified"
 // @Failure 404 {object} ErrorResponse "Employer with given email or company with given id does not exist"
+// @Failure 423 {object} ErrorResponse "Account locked after too many failed login attempts"
+// @Failure 429 {object} ErrorResponse "Too many failed login attempts, retry after the time in the Retry-After header"
 // @Failure 500 {object} ErrorResponse "Any other error"
 // @Router /employers/login [post]
 // loginEmployer handles login of an employer
@@ -172,10 +185,18 @@ func (server *Server) loginEmployer(ctx *gin.Context) {
 		return
 	}
 
+	if !server.checkLoginAttempts(ctx, token.AccountTypeEmployer, request.Email) {
+		return
+	}
+
 	// get the employer
 	employer, err := server.store.GetEmployerByEmail(ctx, request.Email)
 	if err != nil {
 		if err == sql.ErrNoRows {
+			if err := server.recordFailedLoginFromIP(ctx); err != nil {
+				ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+				return
+			}
 			err = fmt.Errorf("employer with this email does not exist")
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
 			return
@@ -193,13 +214,60 @@ func (server *Server) loginEmployer(ctx *gin.Context) {
 	// check password
 	err = utils.CheckPassword(request.Password, employer.HashedPassword)
 	if err != nil {
+		locked, err := server.recordFailedLogin(ctx, token.AccountTypeEmployer, employer.Email)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if locked {
+			ctx.JSON(http.StatusLocked, errorResponse(accountLockedError))
+			return
+		}
+
 		err = fmt.Errorf("incorrect password")
 		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
 	}
 
+	// accounts with MFA enabled have to finish the login with POST /employers/login/mfa
+	if server.requireMfaLogin(ctx, employer.Email, token.AccountTypeEmployer, employer.ID) {
+		return
+	}
+
+	server.completeEmployerLogin(ctx, employer)
+}
+
+// completeEmployerLogin creates the access and refresh tokens and the session of the employer
+// and writes the login response
+func (server *Server) completeEmployerLogin(ctx *gin.Context, employer db.Employer) {
+	// the login succeeded, so the failed attempts of the account are forgotten
+	if err := server.resetLoginAttempts(ctx, token.AccountTypeEmployer, employer.Email); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
 	// create access token
-	accessToken, err := server.tokenMaker.CreateToken(employer.Email, server.config.AccessTokenDuration)
+	accessToken, accessPayload, err := server.tokenMaker.CreateToken(employer.Email, token.AccountTypeEmployer, employer.ID, server.config.AccessTokenDuration)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	refreshToken, refreshPayload, err := server.tokenMaker.CreateRefreshToken(employer.Email, token.AccountTypeEmployer, employer.ID, server.config.RefreshTokenDuration)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	session, err := server.store.CreateSession(ctx, db.CreateSessionParams{
+		ID:           refreshPayload.ID,
+		Email:        employer.Email,
+		RefreshToken: refreshToken,
+		UserAgent:    ctx.Request.UserAgent(),
+		ClientIp:     ctx.ClientIP(),
+		IsBlocked:    false,
+		ExpiresAt:    refreshPayload.ExpiredAt,
+	})
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
@@ -219,20 +287,60 @@ func (server *Server) loginEmployer(ctx *gin.Context) {
 	}
 
 	res := loginEmployerResponse{
-		AccessToken: accessToken,
-		Employer:    newEmployerResponse(employer, company),
+		SessionID:             session.ID,
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessPayload.ExpiredAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: refreshPayload.ExpiredAt,
+		Employer:              newEmployerResponse(employer, company),
 	}
 
 	ctx.JSON(http.StatusOK, res)
 }
 
+// @Schemes
+// @Summary Login employer with MFA
+// @Description Second step of the login of an employer with MFA enabled. Exchanges the "mfa pending" token returned by POST /employers/login and a code from the authenticator app (or a recovery code) for the access and refresh tokens.
+// @Tags employers
+// @Accept json
+// @Produce json
+// @param LoginMfaRequest body loginMfaRequest true "MFA token and code"
+// @Success 200 {object} loginEmployerResponse
+// @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 401 {object} ErrorResponse "Invalid or used MFA token or invalid code"
+// @Failure 423 {object} ErrorResponse "Account locked after too many failed login attempts"
+// @Failure 429 {object} ErrorResponse "Too many failed login attempts, retry after the time in the Retry-After header"
+// @Failure 500 {object} ErrorResponse "Any other error"
+// @Router /employers/login/mfa [post]
+// loginEmployerMfa handles the second step of the login of an employer with MFA enabled
+func (server *Server) loginEmployerMfa(ctx *gin.Context) {
+	mfaPayload, ok := server.verifyMfaLogin(ctx, token.AccountTypeEmployer)
+	if !ok {
+		return
+	}
+
+	employer, err := server.store.GetEmployerByEmail(ctx, mfaPayload.Email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(accountNotFoundError))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.completeEmployerLogin(ctx, employer)
+}
+
 // @Schemes
 // @Summary Get employer
 // @Description Get the details of the authenticated employer
 // @Tags employers
 // @Produce json
 // @Success 200 {object} employerResponse
-// @Failure 401 {object} ErrorResponse "Only employers can access this endpoint."
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Only employers can access this endpoint, not users."
 // @Failure 500 {object} ErrorResponse "Internal error"
 // @Security ApiKeyAuth
 // @Router /employers [get]
@@ -242,9 +350,7 @@ func (server *Server) getEmployer(ctx *gin.Context) {
 	authEmployer, err := server.store.GetEmployerByEmail(ctx, authPayload.Email)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			// but middleware did not stop the request, so we assume
-			// that the request was made by a user
-			ctx.JSON(http.StatusUnauthorized, errorResponse(onlyEmployersAccessError))
+			ctx.JSON(http.StatusUnauthorized, errorResponse(accountNotFoundError))
 			return
 		}
 
@@ -278,7 +384,8 @@ type updateEmployerRequest struct {
 // @param UpdateEmployerRequest body updateEmployerRequest true "Employer details to update"
 // @Success 200 {object} employerResponse
 // @Failure 400 {object} ErrorResponse "Invalid request body"
-// @Failure 401 {object} ErrorResponse "Only employers can access this endpoint."
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Only employers can access this endpoint, not users. Only owners and admins can update the company details."
 // @Failure 500 {object} ErrorResponse "Any other error"
 // @Security ApiKeyAuth
 // @Router /employers [patch]
@@ -302,9 +409,7 @@ func (server *Server) updateEmployer(ctx *gin.Context) {
 	authEmployer, err := server.store.GetEmployerByEmail(ctx, authPayload.Email)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			// but middleware did not stop the request, so we assume
-			// that the request was made by a user
-			ctx.JSON(http.StatusUnauthorized, errorResponse(onlyEmployersAccessError))
+			ctx.JSON(http.StatusUnauthorized, errorResponse(accountNotFoundError))
 			return
 		}
 
@@ -336,17 +441,24 @@ func (server *Server) updateEmployer(ctx *gin.Context) {
 		shouldUpdateCompany = true
 	}
 	if request.CompanyLocation != "" {
-		companyParams.Location = request.CompanyLocation
+		companyParams.Location, _ = geo.NormalizeLocation(request.CompanyLocation)
 		shouldUpdateCompany = true
 	}
 
 	if shouldUpdateCompany {
-		// Update the company
-		company, err = server.store.UpdateCompany(ctx, companyParams)
+		// only owners and admins can change the company details
+		if !hasCompanyRole(authEmployer, db.CompanyRoleAdmin) {
+			ctx.JSON(http.StatusForbidden, errorResponse(companyRoleError(db.CompanyRoleAdmin)))
+			return
+		}
+
+		// Update the company, its jobs are updated in the elasticsearch index through the search outbox
+		company, err = server.store.UpdateCompanyTx(ctx, companyParams)
 		if err != nil {
 			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 			return
 		}
+		server.syncSearchIndex(ctx)
 
 		employerParams := db.UpdateEmployerParams{
 			ID:        authEmployer.ID,
@@ -389,14 +501,15 @@ type updateEmployerPasswordResponse struct {
 
 // @Schemes
 // @Summary Update employer password
-// @Description Update/change logged-in employer password
+// @Description Update/change logged-in employer password. All existing tokens and sessions of the employer are revoked, so they have to log in again.
 // @Tags employers
 // @Accept json
 // @Produce json
 // @param UpdateEmployerPasswordRequest body updateEmployerPasswordRequest true "Employer old and new password"
 // @Success 200 {object} updateEmployerPasswordResponse
 // @Failure 400 {object} ErrorResponse "Invalid request body"
-// @Failure 401 {object} ErrorResponse "Incorrect password or request made a user, not employer."
+// @Failure 401 {object} ErrorResponse "Incorrect password"
+// @Failure 403 {object} ErrorResponse "Only employers can access this endpoint, not users."
 // @Failure 500 {object} ErrorResponse "Any other error"
 // @Security ApiKeyAuth
 // @Router /employers/password [patch]
@@ -412,9 +525,7 @@ func (server *Server) updateEmployerPassword(ctx *gin.Context) {
 	authEmployer, err := server.store.GetEmployerByEmail(ctx, authPayload.Email)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			// but middleware did not stop the request, so we assume
-			// that the request was made by a user
-			ctx.JSON(http.StatusUnauthorized, errorResponse(onlyEmployersAccessError))
+			ctx.JSON(http.StatusUnauthorized, errorResponse(accountNotFoundError))
 			return
 		}
 
@@ -435,12 +546,12 @@ func (server *Server) updateEmployerPassword(ctx *gin.Context) {
 		return
 	}
 
-	params := db.UpdateEmployerPasswordParams{
+	// every token issued before the password change is revoked
+	err = server.store.UpdateEmployerPasswordTx(ctx, db.UpdatePasswordTxParams{
 		ID:             authEmployer.ID,
+		Email:          authEmployer.Email,
 		HashedPassword: hashedPassword,
-	}
-
-	err = server.store.UpdateEmployerPassword(ctx, params)
+	})
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
@@ -451,10 +562,11 @@ func (server *Server) updateEmployerPassword(ctx *gin.Context) {
 
 // @Schemes
 // @Summary Delete employer
-// @Description Delete the logged-in employer
+// @Description Delete the logged-in employer and revoke all of its tokens and sessions. The company is deleted together with its last member. The last owner cannot be deleted while the company has other members.
 // @Tags employers
 // @Success 204 {null} null
-// @Failure 401 {object} ErrorResponse "Only employers can access this endpoint."
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Only employers can access this endpoint, not users. The employer is the last owner of the company."
 // @Failure 500 {object} ErrorResponse "Any other error"
 // @Security ApiKeyAuth
 // @Router /employers [delete]
@@ -464,9 +576,7 @@ func (server *Server) deleteEmployer(ctx *gin.Context) {
 	authEmployer, err := server.store.GetEmployerByEmail(ctx, authPayload.Email)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			// but middleware did not stop the request, so we assume
-			// that the request was made by a user
-			ctx.JSON(http.StatusUnauthorized, errorResponse(onlyEmployersAccessError))
+			ctx.JSON(http.StatusUnauthorized, errorResponse(accountNotFoundError))
 			return
 		}
 
@@ -474,17 +584,7 @@ func (server *Server) deleteEmployer(ctx *gin.Context) {
 		return
 	}
 
-	// delete the company
-	err = server.store.DeleteCompany(ctx, authEmployer.CompanyID)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
-	}
-
-	// delete the employer
-	err = server.store.DeleteEmployer(ctx, authEmployer.ID)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	if !server.removeEmployerFromCompany(ctx, authEmployer) {
 		return
 	}
 
@@ -501,7 +601,8 @@ type getUserAsEmployerRequest struct {
 // @Tags employers
 // @Success 200 {object} userResponse
 // @Failure 400 {object} ErrorResponse "Invalid email in uri."
-// @Failure 401 {object} ErrorResponse "Only employers can access this endpoint."
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Only employers can access this endpoint, not users."
 // @Failure 404 {object} ErrorResponse "User with given email does not exist."
 // @Failure 500 {object} ErrorResponse "Any other error."
 // @Security ApiKeyAuth
@@ -514,21 +615,7 @@ func (server *Server) getUserAsEmployer(ctx *gin.Context) {
 		return
 	}
 
-	// authenticate the employer
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	_, err := server.store.GetEmployerByEmail(ctx, authPayload.Email)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			// but middleware did not stop the request, so we assume
-			// that the request was made by a user
-			ctx.JSON(http.StatusUnauthorized, errorResponse(onlyEmployersAccessError))
-			return
-		}
-
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
-	}
-
+	// requireEmployer middleware already checked that this is an employer
 	user, userSkills, err := server.store.GetUserDetailsByEmail(ctx, request.Email)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -695,3 +782,128 @@ func (server *Server) sendVerificationEmailToEmployer(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, sendVerificationEmailToEmployerResponse{Message: "verification email sent"})
 }
+
+type forgotEmployerPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type forgotEmployerPasswordResponse struct {
+	Message string `json:"message"`
+}
+
+// @Schemes
+// @Summary Forgot employer password
+// @Description Send to the employer an email with a link and a single-use code that can be used to reset the password.
+// @Tags employers
+// @Accept json
+// @Produce json
+// @param ForgotEmployerPasswordRequest body forgotEmployerPasswordRequest true "Email address of the employer"
+// @Success 200 {object} forgotEmployerPasswordResponse
+// @Failure 400 {object} ErrorResponse "Invalid request body."
+// @Failure 404 {object} ErrorResponse "No employer found with the provided email."
+// @Failure 500 {object} ErrorResponse "Any other error."
+// @Router /employers/forgot-password [post]
+// forgotEmployerPassword sends the reset password email to the employer
+func (server *Server) forgotEmployerPassword(ctx *gin.Context) {
+	var request forgotEmployerPasswordRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	employer, err := server.store.GetEmployerByEmail(ctx, request.Email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = fmt.Errorf("employer with email %s does not exist", request.Email)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// delete previous reset password from the database,
+	// so only the code from the latest email can be used
+	err = server.store.DeleteResetPassword(ctx, db.DeleteResetPasswordParams{
+		Email:       employer.Email,
+		AccountType: string(token.AccountTypeEmployer),
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	taskPayload := &worker.PayloadSendResetPasswordEmail{
+		Email:       employer.Email,
+		FullName:    employer.FullName,
+		AccountType: "employers",
+	}
+
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.Queue(worker.QueueCritical),
+	}
+
+	err = server.taskDistributor.DistributeTaskSendResetPasswordEmail(ctx, taskPayload, opts...)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, forgotEmployerPasswordResponse{Message: "reset password email sent"})
+}
+
+type resetEmployerPasswordRequest struct {
+	ID          int64  `json:"id" form:"id" binding:"required,min=1"`
+	SecretCode  string `json:"code" form:"code" binding:"required,min=32"`
+	NewPassword string `json:"new_password" form:"new_password" binding:"required,min=6"`
+}
+
+type resetEmployerPasswordResponse struct {
+	Message string `json:"message"`
+}
+
+// @Schemes
+// @Summary Reset employer password
+// @Description Set a new password of the employer using the ID and the code from the reset password email. The code can be used only once. All existing tokens and sessions of the employer are revoked. The page of the reset link posts the request as a form.
+// @Tags employers
+// @Accept json,x-www-form-urlencoded
+// @Produce json
+// @param ResetEmployerPasswordRequest body resetEmployerPasswordRequest true "Reset password ID, code and the new password"
+// @Success 200 {object} resetEmployerPasswordResponse
+// @Failure 400 {object} ErrorResponse "Invalid request body."
+// @Failure 404 {object} ErrorResponse "Code not found, expired or already used."
+// @Failure 500 {object} ErrorResponse "Any other error."
+// @Router /employers/reset-password [post]
+// resetEmployerPassword sets the new password of the employer
+func (server *Server) resetEmployerPassword(ctx *gin.Context) {
+	var request resetEmployerPasswordRequest
+	if err := bindResetPasswordRequest(ctx, &request); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	hashedPassword, err := utils.HashPassword(request.NewPassword)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	_, err = server.store.ResetEmployerPasswordTx(ctx, db.ResetPasswordTxParams{
+		ID:             request.ID,
+		SecretCode:     request.SecretCode,
+		HashedPassword: hashedPassword,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(resetPasswordNotFoundErr))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resetEmployerPasswordResponse{Message: "password reset successfully"})
+}
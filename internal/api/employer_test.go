@@ -14,6 +14,7 @@ import (
 	"github.com/aalug/job-finder-go/pkg/utils"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 	"io"
@@ -95,6 +96,7 @@ func TestCreateEmployerAPI(t *testing.T) {
 						FullName:       employer.FullName,
 						Email:          employer.Email,
 						HashedPassword: employer.HashedPassword,
+						Role:           db.CompanyRoleOwner,
 					},
 				}
 				store.EXPECT().
@@ -265,10 +267,29 @@ func TestLoginEmployerAPI(t *testing.T) {
 				"password": password,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{}, nil)
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
 					Return(employer, nil)
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Eq(db.GetMfaSettingParams{
+						AccountType: string(token.AccountTypeEmployer),
+						AccountID:   employer.ID,
+					})).
+					Times(1).
+					Return(db.MfaSetting{}, sql.ErrNoRows)
+				store.EXPECT().
+					DeleteLoginAttempt(gomock.Any(), gomock.Eq("employer:"+employer.Email)).
+					Times(1).
+					Return(nil)
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Session{ID: uuid.New(), Email: employer.Email}, nil)
 				store.EXPECT().
 					GetCompanyByID(gomock.Any(), gomock.Eq(employer.CompanyID)).
 					Times(1).
@@ -285,10 +306,18 @@ func TestLoginEmployerAPI(t *testing.T) {
 				"password": password,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{}, nil)
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
 					Return(db.Employer{}, sql.ErrNoRows)
+				store.EXPECT().
+					RecordFailedLoginAttempt(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.LoginAttempt{FailedAttempts: 1}, nil)
 				store.EXPECT().
 					GetCompanyByID(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -304,6 +333,10 @@ func TestLoginEmployerAPI(t *testing.T) {
 				"password": password,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{}, nil)
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Any()).
 					Times(1).
@@ -323,10 +356,29 @@ func TestLoginEmployerAPI(t *testing.T) {
 				"password": password,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{}, nil)
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
 					Return(employer, nil)
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Eq(db.GetMfaSettingParams{
+						AccountType: string(token.AccountTypeEmployer),
+						AccountID:   employer.ID,
+					})).
+					Times(1).
+					Return(db.MfaSetting{}, sql.ErrNoRows)
+				store.EXPECT().
+					DeleteLoginAttempt(gomock.Any(), gomock.Eq("employer:"+employer.Email)).
+					Times(1).
+					Return(nil)
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Session{ID: uuid.New(), Email: employer.Email}, nil)
 				store.EXPECT().
 					GetCompanyByID(gomock.Any(), gomock.Any()).
 					Times(1).
@@ -343,10 +395,29 @@ func TestLoginEmployerAPI(t *testing.T) {
 				"password": password,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{}, nil)
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
 					Return(employer, nil)
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Eq(db.GetMfaSettingParams{
+						AccountType: string(token.AccountTypeEmployer),
+						AccountID:   employer.ID,
+					})).
+					Times(1).
+					Return(db.MfaSetting{}, sql.ErrNoRows)
+				store.EXPECT().
+					DeleteLoginAttempt(gomock.Any(), gomock.Eq("employer:"+employer.Email)).
+					Times(1).
+					Return(nil)
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Session{ID: uuid.New(), Email: employer.Email}, nil)
 				store.EXPECT().
 					GetCompanyByID(gomock.Any(), gomock.Any()).
 					Times(1).
@@ -356,6 +427,44 @@ func TestLoginEmployerAPI(t *testing.T) {
 				require.Equal(t, http.StatusNotFound, recorder.Code)
 			},
 		},
+		{
+			name: "Internal Server Error CreateSession",
+			body: gin.H{
+				"email":    employer.Email,
+				"password": password,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{}, nil)
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Eq(db.GetMfaSettingParams{
+						AccountType: string(token.AccountTypeEmployer),
+						AccountID:   employer.ID,
+					})).
+					Times(1).
+					Return(db.MfaSetting{}, sql.ErrNoRows)
+				store.EXPECT().
+					DeleteLoginAttempt(gomock.Any(), gomock.Eq("employer:"+employer.Email)).
+					Times(1).
+					Return(nil)
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Session{}, sql.ErrConnDone)
+				store.EXPECT().
+					GetCompanyByID(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
 		{
 			name: "Invalid Email",
 			body: gin.H{
@@ -381,10 +490,18 @@ func TestLoginEmployerAPI(t *testing.T) {
 				"password": fmt.Sprintf("%d, %s", utils.RandomInt(1, 1000), utils.RandomString(10)),
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{}, nil)
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
 					Return(employer, nil)
+				store.EXPECT().
+					RecordFailedLoginAttempt(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(db.LoginAttempt{FailedAttempts: 1}, nil)
 				store.EXPECT().
 					GetCompanyByID(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -419,6 +536,10 @@ func TestLoginEmployerAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				employer.IsEmailVerified = false
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{}, nil)
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
@@ -431,6 +552,72 @@ func TestLoginEmployerAPI(t *testing.T) {
 				require.Equal(t, http.StatusForbidden, recorder.Code)
 			},
 		},
+		{
+			name: "MFA Required",
+			body: gin.H{
+				"email":    employer.Email,
+				"password": password,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				employer.IsEmailVerified = true
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{}, nil)
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MfaSetting{
+						AccountType: string(token.AccountTypeEmployer),
+						AccountID:   employer.ID,
+						IsEnabled:   true,
+					}, nil)
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+
+				var res mfaRequiredResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.True(t, res.MfaRequired)
+				require.NotEmpty(t, res.MfaToken)
+			},
+		},
+		{
+			name: "Internal Server Error GetMfaSetting",
+			body: gin.H{
+				"email":    employer.Email,
+				"password": password,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				employer.IsEmailVerified = true
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{}, nil)
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MfaSetting{}, sql.ErrConnDone)
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
 	}
 	for i := range testCases {
 		tc := testCases[i]
@@ -472,7 +659,7 @@ func TestGetEmployerAPI(t *testing.T) {
 		{
 			name: "OK",
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -490,27 +677,26 @@ func TestGetEmployerAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "Unauthorized Only Employer Access",
+			name: "Forbidden Only Employer Access",
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(db.Employer{}, sql.ErrNoRows)
+					Times(0)
 				store.EXPECT().
 					GetCompanyByID(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				require.Equal(t, http.StatusForbidden, recorder.Code)
 			},
 		},
 		{
 			name: "Internal Server Error GetEmployerByEmail",
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -528,7 +714,7 @@ func TestGetEmployerAPI(t *testing.T) {
 		{
 			name: "Internal Server Error GetCompanyByID",
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -575,12 +761,14 @@ func TestUpdateEmployerAPI(t *testing.T) {
 	employer, _, company := generateRandomEmployerAndCompany(t)
 	newEmployer, _, newCompany := generateRandomEmployerAndCompany(t)
 	user, _ := generateRandomUser(t)
+	viewer := employer
+	viewer.Role = db.CompanyRoleViewer
 
 	testCases := []struct {
 		name          string
 		body          gin.H
 		setupAuth     func(t *testing.T, r *http.Request, maker token.Maker)
-		buildStubs    func(store *mockdb.MockStore)
+		buildStubs    func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor)
 		checkResponse func(recorder *httptest.ResponseRecorder)
 	}{
 		{
@@ -593,9 +781,9 @@ func TestUpdateEmployerAPI(t *testing.T) {
 				"company_location": newCompany.Location,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
@@ -611,9 +799,13 @@ func TestUpdateEmployerAPI(t *testing.T) {
 					Location: newCompany.Location,
 				}
 				store.EXPECT().
-					UpdateCompany(gomock.Any(), gomock.Eq(companyParams)).
+					UpdateCompanyTx(gomock.Any(), gomock.Eq(companyParams)).
 					Times(1).
 					Return(newCompany, nil)
+				distributor.EXPECT().
+					DistributeTaskProcessSearchOutbox(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
 				employerParams := db.UpdateEmployerParams{
 					ID:        employer.ID,
 					CompanyID: employer.CompanyID,
@@ -631,32 +823,59 @@ func TestUpdateEmployerAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "Unauthorized Only Employer Access",
+			name: "Forbidden Viewer Updates Company",
+			body: gin.H{
+				"company_name": newCompany.Name,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, viewer.Email, token.AccountTypeEmployer, viewer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(viewer.Email)).
+					Times(1).
+					Return(viewer, nil)
+				store.EXPECT().
+					GetCompanyByID(gomock.Any(), gomock.Eq(viewer.CompanyID)).
+					Times(1).
+					Return(company, nil)
+				store.EXPECT().
+					UpdateCompanyTx(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					UpdateEmployer(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden Only Employer Access",
 			body: gin.H{
 				"email":            newEmployer.Email,
 				"company_industry": newCompany.Industry,
 				"company_location": newCompany.Location,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(db.Employer{}, sql.ErrNoRows)
+					Times(0)
 				store.EXPECT().
 					GetCompanyByID(gomock.Any(), gomock.Any()).
 					Times(0)
 				store.EXPECT().
-					UpdateCompany(gomock.Any(), gomock.Any()).
+					UpdateCompanyTx(gomock.Any(), gomock.Any()).
 					Times(0)
 				store.EXPECT().
 					UpdateEmployer(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				require.Equal(t, http.StatusForbidden, recorder.Code)
 			},
 		},
 		{
@@ -667,9 +886,9 @@ func TestUpdateEmployerAPI(t *testing.T) {
 				"company_location": newCompany.Location,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
@@ -678,7 +897,7 @@ func TestUpdateEmployerAPI(t *testing.T) {
 					GetCompanyByID(gomock.Any(), gomock.Any()).
 					Times(0)
 				store.EXPECT().
-					UpdateCompany(gomock.Any(), gomock.Any()).
+					UpdateCompanyTx(gomock.Any(), gomock.Any()).
 					Times(0)
 				store.EXPECT().
 					UpdateEmployer(gomock.Any(), gomock.Any()).
@@ -695,9 +914,9 @@ func TestUpdateEmployerAPI(t *testing.T) {
 				"company_location": newCompany.Location,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
@@ -707,7 +926,7 @@ func TestUpdateEmployerAPI(t *testing.T) {
 					Times(1).
 					Return(db.Company{}, sql.ErrConnDone)
 				store.EXPECT().
-					UpdateCompany(gomock.Any(), gomock.Any()).
+					UpdateCompanyTx(gomock.Any(), gomock.Any()).
 					Times(0)
 				store.EXPECT().
 					UpdateEmployer(gomock.Any(), gomock.Any()).
@@ -718,15 +937,15 @@ func TestUpdateEmployerAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "Internal Server Error UpdateCompany",
+			name: "Internal Server Error UpdateCompanyTx",
 			body: gin.H{
 				"full_name":    newEmployer.FullName,
 				"company_name": newCompany.Name,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
@@ -736,9 +955,12 @@ func TestUpdateEmployerAPI(t *testing.T) {
 					Times(1).
 					Return(company, nil)
 				store.EXPECT().
-					UpdateCompany(gomock.Any(), gomock.Any()).
+					UpdateCompanyTx(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(db.Company{}, sql.ErrConnDone)
+				distributor.EXPECT().
+					DistributeTaskProcessSearchOutbox(gomock.Any(), gomock.Any()).
+					Times(0)
 				store.EXPECT().
 					UpdateEmployer(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -754,9 +976,9 @@ func TestUpdateEmployerAPI(t *testing.T) {
 				"full_name":    newEmployer.FullName,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
@@ -766,9 +988,13 @@ func TestUpdateEmployerAPI(t *testing.T) {
 					Times(1).
 					Return(company, nil)
 				store.EXPECT().
-					UpdateCompany(gomock.Any(), gomock.Any()).
+					UpdateCompanyTx(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(newCompany, nil)
+				distributor.EXPECT().
+					DistributeTaskProcessSearchOutbox(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
 				store.EXPECT().
 					UpdateEmployer(gomock.Any(), gomock.Any()).
 					Times(1).
@@ -784,9 +1010,9 @@ func TestUpdateEmployerAPI(t *testing.T) {
 				"company_name": 123,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -794,7 +1020,7 @@ func TestUpdateEmployerAPI(t *testing.T) {
 					GetCompanyByID(gomock.Any(), gomock.Any()).
 					Times(0)
 				store.EXPECT().
-					UpdateCompany(gomock.Any(), gomock.Any()).
+					UpdateCompanyTx(gomock.Any(), gomock.Any()).
 					Times(0)
 				store.EXPECT().
 					UpdateEmployer(gomock.Any(), gomock.Any()).
@@ -810,9 +1036,9 @@ func TestUpdateEmployerAPI(t *testing.T) {
 				"email": "invalid",
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -820,7 +1046,7 @@ func TestUpdateEmployerAPI(t *testing.T) {
 					GetCompanyByID(gomock.Any(), gomock.Any()).
 					Times(0)
 				store.EXPECT().
-					UpdateCompany(gomock.Any(), gomock.Any()).
+					UpdateCompanyTx(gomock.Any(), gomock.Any()).
 					Times(0)
 				store.EXPECT().
 					UpdateEmployer(gomock.Any(), gomock.Any()).
@@ -839,9 +1065,14 @@ func TestUpdateEmployerAPI(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mockdb.NewMockStore(ctrl)
-			tc.buildStubs(store)
 
-			server := newTestServer(t, store, nil, nil)
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockworker.NewMockTaskDistributor(taskCtrl)
+
+			tc.buildStubs(store, taskDistributor)
+
+			server := newTestServer(t, store, nil, taskDistributor)
 			recorder := httptest.NewRecorder()
 
 			data, err := json.Marshal(tc.body)
@@ -879,7 +1110,7 @@ func TestUpdateEmployerPasswordAPI(t *testing.T) {
 				"new_password": newPassword,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -887,7 +1118,10 @@ func TestUpdateEmployerPasswordAPI(t *testing.T) {
 					Times(1).
 					Return(employer, nil)
 				store.EXPECT().
-					UpdateEmployerPassword(gomock.Any(), gomock.Any()).
+					UpdateEmployerPasswordTx(gomock.Any(), EqUpdatePasswordTxParams(db.UpdatePasswordTxParams{
+						ID:    employer.ID,
+						Email: employer.Email,
+					}, newPassword)).
 					Times(1).
 					Return(nil)
 			},
@@ -902,14 +1136,14 @@ func TestUpdateEmployerPasswordAPI(t *testing.T) {
 				"new_password": newPassword,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Any()).
 					Times(0)
 				store.EXPECT().
-					UpdateEmployerPassword(gomock.Any(), gomock.Any()).
+					UpdateEmployerPasswordTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -923,14 +1157,14 @@ func TestUpdateEmployerPasswordAPI(t *testing.T) {
 				"new_password": "123",
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Any()).
 					Times(0)
 				store.EXPECT().
-					UpdateEmployerPassword(gomock.Any(), gomock.Any()).
+					UpdateEmployerPasswordTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -944,7 +1178,7 @@ func TestUpdateEmployerPasswordAPI(t *testing.T) {
 				"new_password": newPassword,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -952,7 +1186,7 @@ func TestUpdateEmployerPasswordAPI(t *testing.T) {
 					Times(1).
 					Return(employer, nil)
 				store.EXPECT().
-					UpdateEmployerPassword(gomock.Any(), gomock.Any()).
+					UpdateEmployerPasswordTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -960,13 +1194,13 @@ func TestUpdateEmployerPasswordAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "Internal Server Error UpdateEmployerPassword",
+			name: "Internal Server Error UpdateEmployerPasswordTx",
 			body: gin.H{
 				"old_password": password,
 				"new_password": newPassword,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -974,7 +1208,7 @@ func TestUpdateEmployerPasswordAPI(t *testing.T) {
 					Times(1).
 					Return(employer, nil)
 				store.EXPECT().
-					UpdateEmployerPassword(gomock.Any(), gomock.Any()).
+					UpdateEmployerPasswordTx(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(sql.ErrConnDone)
 			},
@@ -983,25 +1217,24 @@ func TestUpdateEmployerPasswordAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "Unauthorized Only Employer Access",
+			name: "Forbidden Only Employer Access",
 			body: gin.H{
 				"old_password": password,
 				"new_password": newPassword,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(db.Employer{}, sql.ErrNoRows)
+					Times(0)
 				store.EXPECT().
-					UpdateEmployerPassword(gomock.Any(), gomock.Any()).
+					UpdateEmployerPasswordTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				require.Equal(t, http.StatusForbidden, recorder.Code)
 			},
 		},
 		{
@@ -1011,7 +1244,7 @@ func TestUpdateEmployerPasswordAPI(t *testing.T) {
 				"new_password": newPassword,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -1019,7 +1252,7 @@ func TestUpdateEmployerPasswordAPI(t *testing.T) {
 					Times(1).
 					Return(db.Employer{}, sql.ErrConnDone)
 				store.EXPECT().
-					UpdateEmployerPassword(gomock.Any(), gomock.Any()).
+					UpdateEmployerPasswordTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -1060,6 +1293,12 @@ func TestDeleteEmployerAPI(t *testing.T) {
 	employer, _, company := generateRandomEmployerAndCompany(t)
 	user, _ := generateRandomUser(t)
 
+	otherOwner, _, _ := generateRandomEmployerAndCompany(t)
+	otherOwner.ID = employer.ID + 1
+	otherOwner.CompanyID = company.ID
+	recruiter := otherOwner
+	recruiter.Role = db.CompanyRoleRecruiter
+
 	testCases := []struct {
 		name          string
 		setupAuth     func(t *testing.T, r *http.Request, maker token.Maker)
@@ -1069,7 +1308,7 @@ func TestDeleteEmployerAPI(t *testing.T) {
 		{
 			name: "OK",
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -1077,11 +1316,43 @@ func TestDeleteEmployerAPI(t *testing.T) {
 					Times(1).
 					Return(employer, nil)
 				store.EXPECT().
-					DeleteCompany(gomock.Any(), gomock.Eq(company.ID)).
+					ListCompanyEmployers(gomock.Any(), gomock.Eq(company.ID)).
+					Times(1).
+					Return([]db.Employer{employer}, nil)
+				store.EXPECT().
+					DeleteEmployerTx(gomock.Any(), gomock.Eq(db.DeleteEmployerTxParams{
+						ID:            employer.ID,
+						Email:         employer.Email,
+						CompanyID:     company.ID,
+						DeleteCompany: true,
+					})).
 					Times(1).
 					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNoContent, recorder.Code)
+			},
+		},
+		{
+			name: "OK Other Owner Keeps Company",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					ListCompanyEmployers(gomock.Any(), gomock.Eq(company.ID)).
+					Times(1).
+					Return([]db.Employer{employer, otherOwner}, nil)
 				store.EXPECT().
-					DeleteEmployer(gomock.Any(), gomock.Eq(employer.ID)).
+					DeleteEmployerTx(gomock.Any(), gomock.Eq(db.DeleteEmployerTxParams{
+						ID:        employer.ID,
+						Email:     employer.Email,
+						CompanyID: company.ID,
+					})).
 					Times(1).
 					Return(nil)
 			},
@@ -1090,30 +1361,48 @@ func TestDeleteEmployerAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "Unauthorized Only Employer Access",
+			name: "Forbidden Last Owner",
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					GetEmployerByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
-					Return(db.Employer{}, sql.ErrNoRows)
+					Return(employer, nil)
+				store.EXPECT().
+					ListCompanyEmployers(gomock.Any(), gomock.Eq(company.ID)).
+					Times(1).
+					Return([]db.Employer{employer, recruiter}, nil)
+				store.EXPECT().
+					DeleteEmployerTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden Only Employer Access",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					DeleteCompany(gomock.Any(), gomock.Any()).
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(user.Email)).
 					Times(0)
 				store.EXPECT().
-					DeleteEmployer(gomock.Any(), gomock.Any()).
+					DeleteEmployerTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				require.Equal(t, http.StatusForbidden, recorder.Code)
 			},
 		},
 		{
 			name: "Internal Server Error GetEmployerByEmail",
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -1121,10 +1410,7 @@ func TestDeleteEmployerAPI(t *testing.T) {
 					Times(1).
 					Return(db.Employer{}, sql.ErrConnDone)
 				store.EXPECT().
-					DeleteCompany(gomock.Any(), gomock.Any()).
-					Times(0)
-				store.EXPECT().
-					DeleteEmployer(gomock.Any(), gomock.Any()).
+					DeleteEmployerTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -1132,9 +1418,9 @@ func TestDeleteEmployerAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "Internal Server Error DeleteCompany",
+			name: "Internal Server Error ListCompanyEmployers",
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -1142,11 +1428,11 @@ func TestDeleteEmployerAPI(t *testing.T) {
 					Times(1).
 					Return(employer, nil)
 				store.EXPECT().
-					DeleteCompany(gomock.Any(), gomock.Any()).
+					ListCompanyEmployers(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(sql.ErrConnDone)
+					Return([]db.Employer{}, sql.ErrConnDone)
 				store.EXPECT().
-					DeleteEmployer(gomock.Any(), gomock.Eq(employer.ID)).
+					DeleteEmployerTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -1154,9 +1440,9 @@ func TestDeleteEmployerAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "Internal Server Error DeleteEmployer",
+			name: "Internal Server Error DeleteEmployerTx",
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -1164,11 +1450,11 @@ func TestDeleteEmployerAPI(t *testing.T) {
 					Times(1).
 					Return(employer, nil)
 				store.EXPECT().
-					DeleteCompany(gomock.Any(), gomock.Any()).
+					ListCompanyEmployers(gomock.Any(), gomock.Eq(company.ID)).
 					Times(1).
-					Return(nil)
+					Return([]db.Employer{employer}, nil)
 				store.EXPECT().
-					DeleteEmployer(gomock.Any(), gomock.Eq(employer.ID)).
+					DeleteEmployerTx(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(sql.ErrConnDone)
 			},
@@ -1227,13 +1513,9 @@ func TestGetUserAsEmployerAPI(t *testing.T) {
 			name:      "OK",
 			userEmail: user.Email,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
-					Times(1).
-					Return(employer, nil)
 				store.EXPECT().
 					GetUserDetailsByEmail(gomock.Any(), gomock.Eq(user.Email)).
 					Times(1).
@@ -1248,12 +1530,9 @@ func TestGetUserAsEmployerAPI(t *testing.T) {
 			name:      "Invalid Email",
 			userEmail: "invalid",
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetEmployerByEmail(gomock.Any(), gomock.Any()).
-					Times(0)
 				store.EXPECT().
 					GetUserDetailsByEmail(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -1263,97 +1542,47 @@ func TestGetUserAsEmployerAPI(t *testing.T) {
 			},
 		},
 		{
-			name:      "Unauthorized Only Employer Access",
+			name:      "Forbidden Only Employer Access",
 			userEmail: user.Email,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetEmployerByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(db.Employer{}, sql.ErrNoRows)
 				store.EXPECT().
 					GetUserDetailsByEmail(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				require.Equal(t, http.StatusForbidden, recorder.Code)
 			},
 		},
 		{
-			name:      "Internal Server Error GetEmployerByEmail",
+			name:      "User Not Found",
 			userEmail: user.Email,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					GetUserDetailsByEmail(gomock.Any(), gomock.Eq(user.Email)).
 					Times(1).
-					Return(db.Employer{}, sql.ErrConnDone)
-				store.EXPECT().
-					GetUserDetailsByEmail(gomock.Any(), gomock.Any()).
-					Times(0)
+					Return(db.User{}, []db.UserSkill{}, sql.ErrNoRows)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, http.StatusNotFound, recorder.Code)
 			},
 		},
 		{
-			name:      "Internal Server Error GetEmployerByEmail",
+			name:      "Internal Server Error GetUserDetailsByEmail",
 			userEmail: user.Email,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					GetUserDetailsByEmail(gomock.Any(), gomock.Eq(user.Email)).
 					Times(1).
-					Return(db.Employer{}, sql.ErrConnDone)
-				store.EXPECT().
-					GetUserDetailsByEmail(gomock.Any(), gomock.Any()).
-					Times(0)
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
-			},
-		},
-		{
-			name:      "User Not Found",
-			userEmail: user.Email,
-			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
-					Times(1).
-					Return(employer, nil)
-				store.EXPECT().
-					GetUserDetailsByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(db.User{}, []db.UserSkill{}, sql.ErrNoRows)
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusNotFound, recorder.Code)
-			},
-		},
-		{
-			name:      "Internal Server Error GetUserDetailsByEmail",
-			userEmail: user.Email,
-			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
-					Times(1).
-					Return(employer, nil)
-				store.EXPECT().
-					GetUserDetailsByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(db.User{}, []db.UserSkill{}, sql.ErrConnDone)
+					Return(db.User{}, []db.UserSkill{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
@@ -1805,6 +2034,325 @@ func TestSendVerificationEmailToEmployerAPI(t *testing.T) {
 	}
 }
 
+func TestForgotEmployerPasswordAPI(t *testing.T) {
+	employer, _, _ := generateRandomEmployerAndCompany(t)
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"email": employer.Email,
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					DeleteResetPassword(gomock.Any(), gomock.Eq(db.DeleteResetPasswordParams{
+						Email:       employer.Email,
+						AccountType: string(token.AccountTypeEmployer),
+					})).
+					Times(1).
+					Return(nil)
+				taskPayload := &worker.PayloadSendResetPasswordEmail{
+					Email:       employer.Email,
+					FullName:    employer.FullName,
+					AccountType: "employers",
+				}
+				distributor.EXPECT().
+					DistributeTaskSendResetPasswordEmail(gomock.Any(), gomock.Eq(taskPayload), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Invalid Email",
+			body: gin.H{
+				"email": "invalid",
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					DeleteResetPassword(gomock.Any(), gomock.Any()).
+					Times(0)
+				distributor.EXPECT().
+					DistributeTaskSendResetPasswordEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Employer Not Found",
+			body: gin.H{
+				"email": employer.Email,
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(db.Employer{}, sql.ErrNoRows)
+				store.EXPECT().
+					DeleteResetPassword(gomock.Any(), gomock.Any()).
+					Times(0)
+				distributor.EXPECT().
+					DistributeTaskSendResetPasswordEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error GetEmployerByEmail",
+			body: gin.H{
+				"email": employer.Email,
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(db.Employer{}, sql.ErrConnDone)
+				store.EXPECT().
+					DeleteResetPassword(gomock.Any(), gomock.Any()).
+					Times(0)
+				distributor.EXPECT().
+					DistributeTaskSendResetPasswordEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error DeleteResetPassword",
+			body: gin.H{
+				"email": employer.Email,
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					DeleteResetPassword(gomock.Any(), gomock.Eq(db.DeleteResetPasswordParams{
+						Email:       employer.Email,
+						AccountType: string(token.AccountTypeEmployer),
+					})).
+					Times(1).
+					Return(sql.ErrConnDone)
+				distributor.EXPECT().
+					DistributeTaskSendResetPasswordEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error DistributeTaskSendResetPasswordEmail",
+			body: gin.H{
+				"email": employer.Email,
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					DeleteResetPassword(gomock.Any(), gomock.Eq(db.DeleteResetPasswordParams{
+						Email:       employer.Email,
+						AccountType: string(token.AccountTypeEmployer),
+					})).
+					Times(1).
+					Return(nil)
+				distributor.EXPECT().
+					DistributeTaskSendResetPasswordEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("some error"))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockworker.NewMockTaskDistributor(taskCtrl)
+
+			tc.buildStubs(store, taskDistributor)
+
+			server := newTestServer(t, store, nil, taskDistributor)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := BaseUrl + "/employers/forgot-password"
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestResetEmployerPasswordAPI(t *testing.T) {
+	employer, _, _ := generateRandomEmployerAndCompany(t)
+	resetPassword := db.ResetPassword{
+		ID:         int64(utils.RandomInt(1, 1000)),
+		Email:      employer.Email,
+		SecretCode: utils.RandomString(32),
+		CreatedAt:  time.Now(),
+		ExpiredAt:  time.Now().Add(30 * time.Minute),
+	}
+	newPassword := utils.RandomString(6)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"id":           resetPassword.ID,
+				"code":         resetPassword.SecretCode,
+				"new_password": newPassword,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				params := db.ResetPasswordTxParams{
+					ID:         resetPassword.ID,
+					SecretCode: resetPassword.SecretCode,
+				}
+				store.EXPECT().
+					ResetEmployerPasswordTx(gomock.Any(), EqResetPasswordTxParams(params, newPassword)).
+					Times(1).
+					Return(db.ResetEmployerPasswordResult{
+						Employer:      employer,
+						ResetPassword: resetPassword,
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Invalid Code",
+			body: gin.H{
+				"id":           resetPassword.ID,
+				"code":         "short",
+				"new_password": newPassword,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ResetEmployerPasswordTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Password Too Short",
+			body: gin.H{
+				"id":           resetPassword.ID,
+				"code":         resetPassword.SecretCode,
+				"new_password": "123",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ResetEmployerPasswordTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Not Found",
+			body: gin.H{
+				"id":           resetPassword.ID,
+				"code":         resetPassword.SecretCode,
+				"new_password": newPassword,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ResetEmployerPasswordTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ResetEmployerPasswordResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error",
+			body: gin.H{
+				"id":           resetPassword.ID,
+				"code":         resetPassword.SecretCode,
+				"new_password": newPassword,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ResetEmployerPasswordTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ResetEmployerPasswordResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil, nil)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := BaseUrl + "/employers/reset-password"
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
 // generateRandomEmployer create a random employer and company
 func generateRandomEmployerAndCompany(t *testing.T) (db.Employer, string, db.Company) {
 	password := utils.RandomString(6)
@@ -1824,6 +2372,7 @@ func generateRandomEmployerAndCompany(t *testing.T) (db.Employer, string, db.Com
 		FullName:       utils.RandomString(5),
 		Email:          utils.RandomEmail(),
 		HashedPassword: hashedPassword,
+		Role:           db.CompanyRoleOwner,
 		CreatedAt:      time.Now(),
 	}
 
@@ -1843,6 +2392,7 @@ func requireBodyMatchEmployerAndCompany(t *testing.T, body *bytes.Buffer, employ
 	require.Equal(t, employer.Email, response.Email)
 	require.Equal(t, employer.FullName, response.FullName)
 	require.Equal(t, employer.CompanyID, response.CompanyID)
+	require.Equal(t, employer.Role, response.Role)
 	require.Equal(t, company.Name, response.CompanyName)
 	require.Equal(t, company.Industry, response.CompanyIndustry)
 	require.Equal(t, company.Location, response.CompanyLocation)
@@ -7,9 +7,13 @@ import (
 	"fmt"
 	db "github.com/aalug/job-finder-go/internal/db/sqlc"
 	"github.com/aalug/job-finder-go/internal/esearch"
+	"github.com/aalug/job-finder-go/internal/geo"
 	"github.com/aalug/job-finder-go/pkg/token"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"net/http"
+	"strings"
+	"time"
 )
 
 var (
@@ -17,8 +21,12 @@ var (
 	onlyUsersAccessError     = errors.New("only users can access this endpoint")
 	jobOwnershipError        = errors.New("job does not belong to this employer")
 	salaryRangeError         = errors.New("salary min cannot be greater than salary max")
+	unknownLocationError     = errors.New("location is not a known city")
 )
 
+// defaultRadiusKm is the radius of the near filter if it is not given
+const defaultRadiusKm = 50
+
 type jobResponse struct {
 	Title          string                       `json:"title"`
 	Description    string                       `json:"description"`
@@ -28,11 +36,19 @@ type jobResponse struct {
 	SalaryMax      int32                        `json:"salary_max"`
 	Requirements   string                       `json:"requirements"`
 	RequiredSkills []db.ListJobSkillsByJobIDRow `json:"required_skills"`
+	Status         db.JobStatus                 `json:"status"`
+	PublishedAt    *time.Time                   `json:"published_at"`
+	ClosesAt       *time.Time                   `json:"closes_at"`
+	EmploymentType db.EmploymentType            `json:"employment_type"`
+	SeniorityLevel db.SeniorityLevel            `json:"seniority_level"`
+	RemotePolicy   db.RemotePolicy              `json:"remote_policy"`
+	SalaryCurrency string                       `json:"salary_currency"`
+	SalaryPeriod   db.SalaryPeriod              `json:"salary_period"`
 }
 
 // newJobResponse creates a job response from a db.Job and db.ListJobSkillsByJobIDRow
 func newJobResponse(job db.Job, skills []db.ListJobSkillsByJobIDRow) jobResponse {
-	return jobResponse{
+	res := jobResponse{
 		Title:          job.Title,
 		Description:    job.Description,
 		Industry:       job.Industry,
@@ -41,29 +57,80 @@ func newJobResponse(job db.Job, skills []db.ListJobSkillsByJobIDRow) jobResponse
 		SalaryMax:      job.SalaryMax,
 		Requirements:   job.Requirements,
 		RequiredSkills: skills,
+		Status:         job.Status,
+		EmploymentType: job.EmploymentType,
+		SeniorityLevel: job.SeniorityLevel,
+		RemotePolicy:   job.RemotePolicy,
+		SalaryCurrency: job.SalaryCurrency,
+		SalaryPeriod:   job.SalaryPeriod,
+	}
+	if job.PublishedAt.Valid {
+		res.PublishedAt = &job.PublishedAt.Time
+	}
+	if job.ClosesAt.Valid {
+		res.ClosesAt = &job.ClosesAt.Time
 	}
+
+	return res
+}
+
+// nearLocation looks up the city of the near filter and returns it with the radius
+// of the filter. It returns nil if the filter is not set.
+func nearLocation(near string, radiusKm float64) (*geo.City, float64, error) {
+	if strings.TrimSpace(near) == "" {
+		return nil, 0, nil
+	}
+
+	city, ok := geo.Lookup(near)
+	if !ok {
+		return nil, 0, unknownLocationError
+	}
+	if radiusKm == 0 {
+		radiusKm = defaultRadiusKm
+	}
+
+	return &city, radiusKm, nil
+}
+
+// locationCoordinates returns the coordinates of the gazetteer city,
+// they are not set if the location is not in the gazetteer
+func locationCoordinates(city *geo.City) (sql.NullFloat64, sql.NullFloat64) {
+	if city == nil {
+		return sql.NullFloat64{}, sql.NullFloat64{}
+	}
+
+	return sql.NullFloat64{Float64: city.Latitude, Valid: true},
+		sql.NullFloat64{Float64: city.Longitude, Valid: true}
 }
 
 type createJobRequest struct {
-	Title          string   `json:"title" binding:"required"`
-	Description    string   `json:"description" binding:"required"`
-	Industry       string   `json:"industry" binding:"required"`
-	Location       string   `json:"location" binding:"required"`
-	SalaryMin      int32    `json:"salary_min" binding:"required,min=0"`
-	SalaryMax      int32    `json:"salary_max" binding:"required,min=0"`
-	Requirements   string   `json:"requirements" binding:"required"`
-	RequiredSkills []string `json:"required_skills" binding:"required"`
+	Title          string            `json:"title" binding:"required"`
+	Description    string            `json:"description" binding:"required"`
+	Industry       string            `json:"industry" binding:"required"`
+	Location       string            `json:"location" binding:"required"`
+	SalaryMin      int32             `json:"salary_min" binding:"required,min=0"`
+	SalaryMax      int32             `json:"salary_max" binding:"required,min=0"`
+	Requirements   string            `json:"requirements" binding:"required"`
+	RequiredSkills []string          `json:"required_skills" binding:"required"`
+	Status         db.JobStatus      `json:"status" binding:"omitempty,oneof=draft published"`
+	ClosesAt       *time.Time        `json:"closes_at"`
+	EmploymentType db.EmploymentType `json:"employment_type" binding:"required,oneof=full_time part_time contract internship temporary"`
+	SeniorityLevel db.SeniorityLevel `json:"seniority_level" binding:"required,oneof=intern junior mid senior lead"`
+	RemotePolicy   db.RemotePolicy   `json:"remote_policy" binding:"required,oneof=onsite hybrid remote"`
+	SalaryCurrency string            `json:"salary_currency" binding:"required,iso4217"`
+	SalaryPeriod   db.SalaryPeriod   `json:"salary_period" binding:"required,oneof=hour day week month year"`
 }
 
 // @Schemes
 // @Summary Create job
-// @Description Create a new job
+// @Description Create a new job. Jobs are created as drafts unless the status is published. Only published jobs are listed and can be applied for.
 // @Tags jobs
 // @Accept json
 // @Produce json
 // @param CreateJobRequest body createJobRequest true "Job details"
 // @Success 201 {object} jobResponse
 // @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 403 {object} ErrorResponse "Viewers of the company cannot create jobs"
 // @Failure 500 {object} ErrorResponse "Any other error"
 // @Security ApiKeyAuth
 // @Router /jobs [post]
@@ -80,6 +147,11 @@ func (server *Server) createJob(ctx *gin.Context) {
 		return
 	}
 
+	if request.ClosesAt != nil && !request.ClosesAt.After(time.Now()) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(closesAtInPastError))
+		return
+	}
+
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 	authEmployer, err := server.store.GetEmployerByEmail(ctx, authPayload.Email)
 	if err != nil {
@@ -87,30 +159,70 @@ func (server *Server) createJob(ctx *gin.Context) {
 		return
 	}
 
-	// create job
-	params := db.CreateJobParams{
-		Title:        request.Title,
-		Industry:     request.Industry,
-		CompanyID:    authEmployer.CompanyID,
-		Description:  request.Description,
-		Location:     request.Location,
-		SalaryMin:    request.SalaryMin,
-		SalaryMax:    request.SalaryMax,
-		Requirements: request.Requirements,
+	// viewers can only read the jobs of the company
+	if !hasCompanyRole(authEmployer, db.CompanyRoleRecruiter) {
+		ctx.JSON(http.StatusForbidden, errorResponse(companyRoleError(db.CompanyRoleRecruiter)))
+		return
 	}
 
-	job, err := server.store.CreateJob(ctx, params)
+	// salaries are filtered and matched as annual amounts in the base currency
+	salaryMinAnnual, salaryMaxAnnual, err := server.annualizeSalary(
+		ctx, request.SalaryMin, request.SalaryMax, request.SalaryCurrency, request.SalaryPeriod)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(annualizeSalaryErrorStatus(err), errorResponse(err))
 		return
 	}
 
-	// create job skills
-	err = server.store.CreateMultipleJobSkills(ctx, request.RequiredSkills, job.ID)
+	// locations in the gazetteer are normalized and get their coordinates
+	location, city := geo.NormalizeLocation(request.Location)
+	latitude, longitude := locationCoordinates(city)
+
+	// create job
+	params := db.CreateJobParams{
+		Title:           request.Title,
+		Industry:        request.Industry,
+		CompanyID:       authEmployer.CompanyID,
+		Description:     request.Description,
+		Location:        location,
+		SalaryMin:       request.SalaryMin,
+		SalaryMax:       request.SalaryMax,
+		Requirements:    request.Requirements,
+		Status:          db.JobStatusDraft,
+		EmploymentType:  request.EmploymentType,
+		SeniorityLevel:  request.SeniorityLevel,
+		RemotePolicy:    request.RemotePolicy,
+		SalaryCurrency:  request.SalaryCurrency,
+		SalaryPeriod:    request.SalaryPeriod,
+		SalaryMinAnnual: salaryMinAnnual,
+		SalaryMaxAnnual: salaryMaxAnnual,
+		Latitude:        latitude,
+		Longitude:       longitude,
+	}
+	if request.Status == db.JobStatusPublished {
+		params.Status = db.JobStatusPublished
+		params.PublishedAt = sql.NullTime{
+			Time:  time.Now(),
+			Valid: true,
+		}
+	}
+	if request.ClosesAt != nil {
+		params.ClosesAt = sql.NullTime{
+			Time:  *request.ClosesAt,
+			Valid: true,
+		}
+	}
+
+	// create the job with its skills, it is added to the elasticsearch index
+	// through the search outbox if it is published
+	txResult, err := server.store.CreateJobTx(ctx, db.CreateJobTxParams{
+		CreateJobParams: params,
+		Skills:          request.RequiredSkills,
+	})
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+	job := txResult.Job
 
 	listJobSkillsParams := db.ListJobSkillsByJobIDParams{
 		JobID:  job.ID,
@@ -124,41 +236,7 @@ func (server *Server) createJob(ctx *gin.Context) {
 		return
 	}
 
-	// creation was successful - create an elasticsearch index
-
-	// get the company name
-	companyName, err := server.store.GetCompanyNameByID(ctx, authEmployer.CompanyID)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
-	}
-	var skills []string
-	// get the skill names from the job skills
-	for _, skill := range jobSkills {
-		skills = append(skills, skill.Skill)
-	}
-
-	j := esearch.Job{
-		ID:           job.ID,
-		Title:        job.Title,
-		Industry:     job.Industry,
-		CompanyName:  companyName,
-		Description:  job.Description,
-		Location:     job.Location,
-		SalaryMin:    job.SalaryMin,
-		SalaryMax:    job.SalaryMax,
-		Requirements: job.Requirements,
-		JobSkills:    skills,
-	}
-
-	err = server.esDetails.client.IndexJobAsDocument(
-		int(server.esDetails.lastDocumentIndex),
-		j,
-	)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
-	}
+	server.syncSearchIndex(ctx)
 
 	ctx.JSON(http.StatusCreated, newJobResponse(job, jobSkills))
 }
@@ -169,15 +247,19 @@ type deleteJobRequest struct {
 
 // @Schemes
 // @Summary Delete job
-// @Description Delete the job with the given id
+// @Description Delete the job with the given id. Only drafts are deleted, other jobs are closed instead so that their applications are kept.
 // @Tags jobs
 // @param id path integer true "Job ID"
 // @Success 204 {null} null
+// @Failure 403 {object} ErrorResponse "Viewers of the company cannot delete jobs"
 // @Failure 404 {object} ErrorResponse "Job not found"
 // @Failure 500 {object} ErrorResponse "Any error"
 // @Security ApiKeyAuth
 // @Router /jobs/{id} [delete]
-// deleteJob handles deleting a job posting
+// deleteJob handles deleting a job posting.
+// Drafts cannot have applications, so they are deleted with their skills.
+// Other jobs are closed and removed from the elasticsearch index in the background,
+// the job and its applications stay in the database.
 func (server *Server) deleteJob(ctx *gin.Context) {
 	var request deleteJobRequest
 	if err := ctx.ShouldBindUri(&request); err != nil {
@@ -210,34 +292,37 @@ func (server *Server) deleteJob(ctx *gin.Context) {
 		return
 	}
 
-	// delete job skills first
-	err = server.store.DeleteJobSkillsByJobID(ctx, job.ID)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	// viewers can only read the jobs of the company
+	if !hasCompanyRole(authEmployer, db.CompanyRoleRecruiter) {
+		ctx.JSON(http.StatusForbidden, errorResponse(companyRoleError(db.CompanyRoleRecruiter)))
 		return
 	}
 
-	// delete job
-	err = server.store.DeleteJob(ctx, request.ID)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	switch job.Status {
+	case db.JobStatusDraft:
+		// delete job with its skills
+		err = server.store.DeleteJobPosting(ctx, request.ID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	case db.JobStatusClosed:
+		// already closed, nothing to do
+		ctx.JSON(http.StatusNoContent, nil)
 		return
+	default:
+		_, err = server.store.UpdateJobStatusTx(ctx, db.UpdateJobStatusParams{
+			ID:     job.ID,
+			Status: db.JobStatusClosed,
+		})
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
 	}
 
-	// delete the job from the elasticsearch index
-	// get document ID
-	documentID, err := server.esDetails.client.GetDocumentIDByJobID(int(job.ID))
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
-	}
-
-	// delete the document from the index
-	err = server.esDetails.client.DeleteJobDocument(documentID)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
-	}
+	// the job is removed from the elasticsearch index through the search outbox
+	server.syncSearchIndex(ctx)
 
 	ctx.JSON(http.StatusNoContent, nil)
 }
@@ -247,15 +332,21 @@ type updateJobUriRequest struct {
 }
 
 type updateJobRequest struct {
-	Title                    string   `json:"title"`
-	Description              string   `json:"description"`
-	Industry                 string   `json:"industry"`
-	Location                 string   `json:"location"`
-	SalaryMin                int32    `json:"salary_min"`
-	SalaryMax                int32    `json:"salary_max"`
-	Requirements             string   `json:"requirements"`
-	RequiredSkillsToAdd      []string `json:"required_skills_to_add"`
-	RequiredSkillIDsToRemove []int32  `json:"required_skill_ids_to_remove"`
+	Title                    string            `json:"title"`
+	Description              string            `json:"description"`
+	Industry                 string            `json:"industry"`
+	Location                 string            `json:"location"`
+	SalaryMin                int32             `json:"salary_min"`
+	SalaryMax                int32             `json:"salary_max"`
+	Requirements             string            `json:"requirements"`
+	RequiredSkillsToAdd      []string          `json:"required_skills_to_add"`
+	RequiredSkillIDsToRemove []int32           `json:"required_skill_ids_to_remove"`
+	ClosesAt                 *time.Time        `json:"closes_at"`
+	EmploymentType           db.EmploymentType `json:"employment_type" binding:"omitempty,oneof=full_time part_time contract internship temporary"`
+	SeniorityLevel           db.SeniorityLevel `json:"seniority_level" binding:"omitempty,oneof=intern junior mid senior lead"`
+	RemotePolicy             db.RemotePolicy   `json:"remote_policy" binding:"omitempty,oneof=onsite hybrid remote"`
+	SalaryCurrency           string            `json:"salary_currency" binding:"omitempty,iso4217"`
+	SalaryPeriod             db.SalaryPeriod   `json:"salary_period" binding:"omitempty,oneof=hour day week month year"`
 }
 
 // @Schemes
@@ -268,7 +359,8 @@ type updateJobRequest struct {
 // @Produce json
 // @Success 200 {object} jobResponse
 // @Failure 400 {object} ErrorResponse "Invalid request query or body"
-// @Failure 401 {object} ErrorResponse "User making the request not an employer or employer not the owner of the job"
+// @Failure 401 {object} ErrorResponse "Employer not the owner of the job"
+// @Failure 403 {object} ErrorResponse "Only employers can access this endpoint, not users. Viewers of the company cannot update jobs."
 // @Failure 404 {object} ErrorResponse "Job not found"
 // @Failure 500 {object} ErrorResponse "Any other error"
 // @Security ApiKeyAuth
@@ -289,13 +381,17 @@ func (server *Server) updateJob(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
+	if err = binding.Validator.ValidateStruct(request); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
 
 	// get employer that is making the request
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 	authEmployer, err := server.store.GetEmployerByEmail(ctx, authPayload.Email)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusUnauthorized, errorResponse(onlyEmployersAccessError))
+			ctx.JSON(http.StatusUnauthorized, errorResponse(accountNotFoundError))
 			return
 		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
@@ -320,17 +416,29 @@ func (server *Server) updateJob(ctx *gin.Context) {
 		return
 	}
 
+	// viewers can only read the jobs of the company
+	if !hasCompanyRole(authEmployer, db.CompanyRoleRecruiter) {
+		ctx.JSON(http.StatusForbidden, errorResponse(companyRoleError(db.CompanyRoleRecruiter)))
+		return
+	}
+
 	// update job
 	params := db.UpdateJobParams{
-		ID:           job.ID,
-		Title:        request.Title,
-		Description:  request.Description,
-		Industry:     request.Industry,
-		Location:     request.Location,
-		SalaryMin:    request.SalaryMin,
-		SalaryMax:    request.SalaryMax,
-		Requirements: request.Requirements,
-		CompanyID:    job.CompanyID,
+		ID:             job.ID,
+		Title:          request.Title,
+		Description:    request.Description,
+		Industry:       request.Industry,
+		Location:       request.Location,
+		SalaryMin:      request.SalaryMin,
+		SalaryMax:      request.SalaryMax,
+		Requirements:   request.Requirements,
+		CompanyID:      job.CompanyID,
+		ClosesAt:       job.ClosesAt,
+		EmploymentType: request.EmploymentType,
+		SeniorityLevel: request.SeniorityLevel,
+		RemotePolicy:   request.RemotePolicy,
+		SalaryCurrency: request.SalaryCurrency,
+		SalaryPeriod:   request.SalaryPeriod,
 	}
 
 	if params.SalaryMin > params.SalaryMax {
@@ -350,6 +458,12 @@ func (server *Server) updateJob(ctx *gin.Context) {
 	}
 	if request.Location == "" {
 		params.Location = job.Location
+		params.Latitude = job.Latitude
+		params.Longitude = job.Longitude
+	} else {
+		var city *geo.City
+		params.Location, city = geo.NormalizeLocation(request.Location)
+		params.Latitude, params.Longitude = locationCoordinates(city)
 	}
 	if request.SalaryMin == 0 {
 		params.SalaryMin = job.SalaryMin
@@ -360,76 +474,66 @@ func (server *Server) updateJob(ctx *gin.Context) {
 	if request.Requirements == "" {
 		params.Requirements = job.Requirements
 	}
-
-	job, err = server.store.UpdateJob(ctx, params)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
+	if request.EmploymentType == "" {
+		params.EmploymentType = job.EmploymentType
 	}
-
-	// --- update job skills
-
-	// delete
-	if len(request.RequiredSkillIDsToRemove) > 0 {
-		err = server.store.DeleteMultipleJobSkills(ctx, request.RequiredSkillIDsToRemove)
-		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-			return
-		}
+	if request.SeniorityLevel == "" {
+		params.SeniorityLevel = job.SeniorityLevel
 	}
-
-	// add
-	if len(request.RequiredSkillsToAdd) > 0 {
-		err = server.store.CreateMultipleJobSkills(ctx, request.RequiredSkillsToAdd, job.ID)
-		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	if request.RemotePolicy == "" {
+		params.RemotePolicy = job.RemotePolicy
+	}
+	if request.SalaryCurrency == "" {
+		params.SalaryCurrency = job.SalaryCurrency
+	}
+	if request.SalaryPeriod == "" {
+		params.SalaryPeriod = job.SalaryPeriod
+	}
+	if request.ClosesAt != nil {
+		if !request.ClosesAt.After(time.Now()) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(closesAtInPastError))
 			return
 		}
+		params.ClosesAt = sql.NullTime{
+			Time:  *request.ClosesAt,
+			Valid: true,
+		}
 	}
 
-	// get skills
-	jobSkillsParams := db.ListJobSkillsByJobIDParams{
-		JobID:  job.ID,
-		Limit:  10,
-		Offset: 0,
-	}
-	jobSkills, err := server.store.ListJobSkillsByJobID(ctx, jobSkillsParams)
+	params.SalaryMinAnnual, params.SalaryMaxAnnual, err = server.annualizeSalary(
+		ctx, params.SalaryMin, params.SalaryMax, params.SalaryCurrency, params.SalaryPeriod)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(annualizeSalaryErrorStatus(err), errorResponse(err))
 		return
 	}
 
-	// get document id for the job to update the elasticsearch index
-	documentID, err := server.esDetails.client.GetDocumentIDByJobID(int(job.ID))
+	// update the job with its skills, it is updated in the elasticsearch index
+	// through the search outbox if it is published
+	txResult, err := server.store.UpdateJobTx(ctx, db.UpdateJobTxParams{
+		UpdateJobParams:  params,
+		SkillIDsToRemove: request.RequiredSkillIDsToRemove,
+		SkillsToAdd:      request.RequiredSkillsToAdd,
+	})
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+	job = txResult.Job
 
-	// convert job skills to slice of strings
-	var skills []string
-	for _, skill := range jobSkills {
-		skills = append(skills, skill.Skill)
-	}
-
-	esJob := esearch.Job{
-		Title:        job.Title,
-		Industry:     job.Industry,
-		Description:  job.Description,
-		Location:     job.Location,
-		SalaryMin:    job.SalaryMin,
-		SalaryMax:    job.SalaryMax,
-		Requirements: job.Requirements,
-		JobSkills:    skills,
+	// get skills
+	jobSkillsParams := db.ListJobSkillsByJobIDParams{
+		JobID:  job.ID,
+		Limit:  10,
+		Offset: 0,
 	}
-
-	// update elasticsearch index
-	err = server.esDetails.client.UpdateJobDocument(documentID, esJob)
+	jobSkills, err := server.store.ListJobSkillsByJobID(ctx, jobSkillsParams)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
+	server.syncSearchIndex(ctx)
+
 	ctx.JSON(http.StatusOK, newJobResponse(job, jobSkills))
 }
 
@@ -439,7 +543,7 @@ type getJobRequest struct {
 
 // @Schemes
 // @Summary Get job
-// @Description Get details of the job with the given id
+// @Description Get details of the job with the given id. Drafts are not visible.
 // @Tags jobs
 // @Param id path integer true "Job ID"
 // @Produce json
@@ -468,30 +572,50 @@ func (server *Server) getJob(ctx *gin.Context) {
 		return
 	}
 
+	// drafts are visible only in the list of jobs of the employer
+	if job.Status == db.JobStatusDraft {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
 	ctx.JSON(http.StatusOK, job)
 }
 
 type filterAndListJobs struct {
-	Title       string `form:"title"`
-	Industry    string `form:"industry"`
-	JobLocation string `form:"job_location"`
-	SalaryMin   int32  `form:"salary_min"`
-	SalaryMax   int32  `form:"salary_max"`
-	Page        int32  `form:"page" binding:"required,min=1"`
-	PageSize    int32  `form:"page_size" binding:"required,min=5,max=15"`
+	Title          string  `form:"title"`
+	Industry       string  `form:"industry"`
+	JobLocation    string  `form:"job_location"`
+	SalaryMin      int32   `form:"salary_min"`
+	SalaryMax      int32   `form:"salary_max"`
+	EmploymentType string  `form:"employment_type" binding:"omitempty,oneof=full_time part_time contract internship temporary"`
+	SeniorityLevel string  `form:"seniority_level" binding:"omitempty,oneof=intern junior mid senior lead"`
+	RemotePolicy   string  `form:"remote_policy" binding:"omitempty,oneof=onsite hybrid remote"`
+	SalaryCurrency string  `form:"salary_currency" binding:"omitempty,iso4217"`
+	SalaryPeriod   string  `form:"salary_period" binding:"omitempty,oneof=hour day week month year"`
+	Near           string  `form:"near"`
+	RadiusKm       float64 `form:"radius_km" binding:"omitempty,gt=0,max=1000"`
+	Page           int32   `form:"page" binding:"required,min=1"`
+	PageSize       int32   `form:"page_size" binding:"required,min=5,max=15"`
 }
 
 // @Schemes
 // @Summary Filter and list jobs
-// @Description Filter and list jobs
+// @Description Filter and list published jobs
 // @Tags jobs
 // @Param page query integer true "Page number"
 // @Param page_size query integer true "Page size"
 // @Param title query string false "Job title - matches partially (ILIKE)"
 // @Param industry query string false "Job industry - exact name"
 // @Param job_location query string false "Job location - exact name"
-// @Param salary_min query integer false "Salary min - must be smaller or equal salary_max"
-// @Param salary_max query integer false "Salary max - must be greater or equal salary_min"
+// @Param salary_min query integer false "Salary min - annual amount in USD, salaries in other currencies and periods are converted"
+// @Param salary_max query integer false "Salary max - annual amount in USD, salaries in other currencies and periods are converted"
+// @Param employment_type query string false "Employment type - full_time, part_time, contract, internship or temporary"
+// @Param seniority_level query string false "Seniority level - intern, junior, mid, senior or lead"
+// @Param remote_policy query string false "Remote policy - onsite, hybrid or remote"
+// @Param salary_currency query string false "Salary currency - ISO 4217 code, e.g. USD"
+// @Param salary_period query string false "Salary period - hour, day, week, month or year"
+// @Param near query string false "City to search around, e.g. Berlin or Berlin, Germany"
+// @Param radius_km query number false "Radius around the near city in kilometers, 50 by default, max 1000"
 // @Produce json
 // @Success 200 {array} []db.ListJobsByFiltersRow
 // @Failure 400 {object} ErrorResponse "Invalid query"
@@ -505,6 +629,14 @@ func (server *Server) filterAndListJobs(ctx *gin.Context) {
 		return
 	}
 
+	near, radiusKm, err := nearLocation(request.Near, request.RadiusKm)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	// locations of the jobs are normalized, so the filter has to be normalized as well
+	jobLocation, _ := geo.NormalizeLocation(request.JobLocation)
+
 	params := db.ListJobsByFiltersParams{
 		Limit:  request.PageSize,
 		Offset: (request.Page - 1) * request.PageSize,
@@ -513,8 +645,8 @@ func (server *Server) filterAndListJobs(ctx *gin.Context) {
 			Valid:  request.Title != "",
 		},
 		JobLocation: sql.NullString{
-			String: request.JobLocation,
-			Valid:  request.JobLocation != "",
+			String: jobLocation,
+			Valid:  jobLocation != "",
 		},
 		Industry: sql.NullString{
 			String: request.Industry,
@@ -528,6 +660,31 @@ func (server *Server) filterAndListJobs(ctx *gin.Context) {
 			Int32: request.SalaryMax,
 			Valid: request.SalaryMax != 0,
 		},
+		EmploymentType: db.NullEmploymentType{
+			EmploymentType: db.EmploymentType(request.EmploymentType),
+			Valid:          request.EmploymentType != "",
+		},
+		SeniorityLevel: db.NullSeniorityLevel{
+			SeniorityLevel: db.SeniorityLevel(request.SeniorityLevel),
+			Valid:          request.SeniorityLevel != "",
+		},
+		RemotePolicy: db.NullRemotePolicy{
+			RemotePolicy: db.RemotePolicy(request.RemotePolicy),
+			Valid:        request.RemotePolicy != "",
+		},
+		SalaryCurrency: sql.NullString{
+			String: request.SalaryCurrency,
+			Valid:  request.SalaryCurrency != "",
+		},
+		SalaryPeriod: db.NullSalaryPeriod{
+			SalaryPeriod: db.SalaryPeriod(request.SalaryPeriod),
+			Valid:        request.SalaryPeriod != "",
+		},
+	}
+	if near != nil {
+		params.NearLatitude = sql.NullFloat64{Float64: near.Latitude, Valid: true}
+		params.NearLongitude = sql.NullFloat64{Float64: near.Longitude, Valid: true}
+		params.RadiusKm = sql.NullFloat64{Float64: radiusKm, Valid: true}
 	}
 
 	jobs, err := server.store.ListJobsByFilters(ctx, params)
@@ -553,8 +710,10 @@ type listJobsByMatchingSkillsRequest struct {
 // @Produce json
 // @Success 200 {array} []db.ListJobsMatchingUserSkillsRow
 // @Failure 400 {object} ErrorResponse "Invalid query"
-// @Failure 401 {object} ErrorResponse "Employer making the request - only users can access"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Employer making the request - only users can access"
 // @Failure 500 {object} ErrorResponse "Any other error"
+// @Security ApiKeyAuth
 // @Router /jobs/match-skills [get]
 // listJobsByMatchingSkills handles listing all jobs
 // that skills match the users skills.
@@ -565,21 +724,11 @@ func (server *Server) listJobsByMatchingSkills(ctx *gin.Context) {
 		return
 	}
 
+	// requireUser middleware guarantees that this is a user
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	authUser, err := server.store.GetUserByEmail(ctx, authPayload.Email)
-	if err != nil {
-		// person is authenticated but cannot be find in users table
-		// means that this is an employer
-		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusUnauthorized, errorResponse(onlyUsersAccessError))
-		}
-
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
-	}
 
 	params := db.ListJobsMatchingUserSkillsParams{
-		UserID: authUser.ID,
+		UserID: authPayload.AccountID,
 		Limit:  request.PageSize,
 		Offset: (request.Page - 1) * request.PageSize,
 	}
@@ -603,7 +752,7 @@ type listJobsByCompanyRequest struct {
 
 // @Schemes
 // @Summary List jobs by company
-// @Description List jobs by company name, id or part of the name.
+// @Description List published jobs by company name, id or part of the name.
 // @Tags jobs
 // @Param page query integer true "Page number"
 // @Param page_size query integer true "Page size"
@@ -703,26 +852,58 @@ func (server *Server) listJobsByCompany(ctx *gin.Context) {
 }
 
 type searchJobsRequest struct {
-	Search   string `form:"search" binding:"required"`
-	Page     int32  `form:"page" binding:"required,min=1"`
-	PageSize int32  `form:"page_size" binding:"required,min=5,max=15"`
+	Search         string   `form:"search" binding:"required"`
+	SalaryMin      int32    `form:"salary_min" binding:"omitempty,min=0"`
+	SalaryMax      int32    `form:"salary_max" binding:"omitempty,min=0"`
+	EmploymentType string   `form:"employment_type" binding:"omitempty,oneof=full_time part_time contract internship temporary"`
+	SeniorityLevel string   `form:"seniority_level" binding:"omitempty,oneof=intern junior mid senior lead"`
+	RemotePolicy   string   `form:"remote_policy" binding:"omitempty,oneof=onsite hybrid remote"`
+	SalaryCurrency string   `form:"salary_currency" binding:"omitempty,iso4217"`
+	SalaryPeriod   string   `form:"salary_period" binding:"omitempty,oneof=hour day week month year"`
+	Near           string   `form:"near"`
+	RadiusKm       float64  `form:"radius_km" binding:"omitempty,gt=0,max=1000"`
+	Industry       string   `form:"industry"`
+	Location       string   `form:"location"`
+	Company        string   `form:"company"`
+	Skills         []string `form:"skills" binding:"omitempty,max=10,dive,required"`
+	Explain        bool     `form:"explain"`
+	Page           int32    `form:"page" binding:"required,min=1"`
+	PageSize       int32    `form:"page_size" binding:"required,min=5,max=15"`
 }
 
 // @Schemes
 // @Summary Search jobs
-// @Description Search for jobs with elasticsearch.
+// @Description Search for published jobs with elasticsearch. The response contains a page of the jobs with their relevance scores and highlighted fragments of the matched fields, the total number of the matching jobs and the facets (counts by industry, location, company, skill and annual salary) of all the matching jobs. The filters narrow the facets as well.
 // @Tags jobs
 // @Param page query integer true "Page number"
 // @Param page_size query integer true "Page size"
 // @Param search query string true "Search query"
+// @Param salary_min query integer false "Salary min - annual amount in USD, salaries in other currencies and periods are converted"
+// @Param salary_max query integer false "Salary max - annual amount in USD, salaries in other currencies and periods are converted"
+// @Param employment_type query string false "Employment type - full_time, part_time, contract, internship or temporary"
+// @Param seniority_level query string false "Seniority level - intern, junior, mid, senior or lead"
+// @Param remote_policy query string false "Remote policy - onsite, hybrid or remote"
+// @Param salary_currency query string false "Salary currency - ISO 4217 code, e.g. USD"
+// @Param salary_period query string false "Salary period - hour, day, week, month or year"
+// @Param near query string false "City to search around, e.g. Berlin or Berlin, Germany"
+// @Param radius_km query number false "Radius around the near city in kilometers, 50 by default, max 1000"
+// @Param industry query string false "Industry - exact name, as in the industries facet"
+// @Param location query string false "Location - exact name, as in the locations facet"
+// @Param company query string false "Company name - exact name, as in the companies facet"
+// @Param skills query []string false "Skills - jobs have to require all of them, max 10" collectionFormat(multi)
+// @Param explain query boolean false "Explain the scores of the jobs - only for admins, at /admin/jobs/search"
 // @Produce json
-// @Success 200 {array} []esearch.Job
+// @Success 200 {object} esearch.SearchJobsResult
 // @Failure 400 {object} ErrorResponse "Invalid query"
+// @Failure 403 {object} ErrorResponse "Explain mode outside of the admin route"
 // @Failure 500 {object} ErrorResponse "Any other error"
 // @Router /jobs/search [get]
+// @Router /admin/jobs/search [get]
 // searchJobs handles searching for jobs with elasticsearch.
 // Function uses esearch package that is an implementation of
-// elasticsearch in this application.
+// elasticsearch in this application. Depending on SEARCH_PROVIDER, the jobs
+// are searched with the full-text search of postgres instead or when elasticsearch fails.
+// On the admin route, the scores of the jobs can be explained.
 func (server *Server) searchJobs(ctx *gin.Context) {
 	var request searchJobsRequest
 	if err := ctx.ShouldBindQuery(&request); err != nil {
@@ -730,13 +911,89 @@ func (server *Server) searchJobs(ctx *gin.Context) {
 		return
 	}
 
-	jobs, err := server.esDetails.client.SearchJobs(ctx, request.Search, request.Page, request.PageSize)
+	// the explanation of the scores is meant for tuning the relevance, only admins can see it
+	if request.Explain && !ctx.GetBool(authorizationAdminKey) {
+		ctx.JSON(http.StatusForbidden, errorResponse(onlyAdminsAccessError))
+		return
+	}
+
+	near, radiusKm, err := nearLocation(request.Near, request.RadiusKm)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	filters := esearch.JobFilters{
+		EmploymentType: request.EmploymentType,
+		SeniorityLevel: request.SeniorityLevel,
+		RemotePolicy:   request.RemotePolicy,
+		SalaryCurrency: request.SalaryCurrency,
+		SalaryPeriod:   request.SalaryPeriod,
+		SalaryMin:      request.SalaryMin,
+		SalaryMax:      request.SalaryMax,
+		Industry:       request.Industry,
+		CompanyName:    request.Company,
+		Skills:         request.Skills,
+	}
+	if request.Location != "" {
+		// locations of the documents are normalized
+		filters.Location, _ = geo.NormalizeLocation(request.Location)
+	}
+	if near != nil {
+		filters.Near = &esearch.GeoPoint{
+			Lat: near.Latitude,
+			Lon: near.Longitude,
+		}
+		filters.RadiusKm = radiusKm
+	}
+
+	result, err := server.search.SearchJobs(ctx, request.Search, filters, request.Page, request.PageSize, request.Explain)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, jobs)
+	ctx.JSON(http.StatusOK, result)
+}
+
+type autocompleteJobsRequest struct {
+	Query string `form:"q" binding:"required,max=100"`
+	Size  int    `form:"size" binding:"omitempty,min=1,max=10"`
+}
+
+// defaultSuggestionsSize is the number of the suggestions of every type if the size is not given
+const defaultSuggestionsSize = 5
+
+// @Schemes
+// @Summary Autocomplete jobs
+// @Description Suggest job titles, skills and company names of the published jobs as the query is typed. Suggestions are ordered by type (title, skill, company) and the number of the jobs. Served by the provider selected with SEARCH_PROVIDER, like the search.
+// @Tags jobs
+// @Param q query string true "Typed query, the last word can be incomplete"
+// @Param size query integer false "Max number of the suggestions of every type, 5 by default, max 10"
+// @Produce json
+// @Success 200 {array} []esearch.Suggestion
+// @Failure 400 {object} ErrorResponse "Invalid query"
+// @Failure 500 {object} ErrorResponse "Any other error"
+// @Router /jobs/autocomplete [get]
+// autocompleteJobs handles suggesting job titles, skills and companies with the search provider
+func (server *Server) autocompleteJobs(ctx *gin.Context) {
+	var request autocompleteJobsRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if request.Size == 0 {
+		request.Size = defaultSuggestionsSize
+	}
+
+	suggestions, err := server.search.Autocomplete(ctx, request.Query, request.Size)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, suggestions)
 }
 
 type listEmployerJobsRequest struct {
@@ -747,14 +1004,15 @@ type listEmployerJobsRequest struct {
 
 // @Schemes
 // @Summary List all jobs of an employer
-// @Description List all jobs of an employer. Only employers can access this endpoint. Returns a list of jobs that were created by the authenticated employer. Results are paginated based on page and page_size query parameters.
+// @Description List all jobs of an employer, with all statuses. Only employers can access this endpoint. Returns a list of jobs that were created by the authenticated employer. Results are paginated based on page and page_size query parameters.
 // @Tags jobs
 // @param page query int true "page number"
 // @param page_size query int true "page size"
 // @param sort query string false "sort by date ('date-asc' or 'date-desc')"
 // @Success 200 {object} []db.ListJobsForEmployerRow
 // @Failure 400 {object} ErrorResponse "Invalid query parameters"
-// @Failure 401 {object} ErrorResponse "Unauthorized. Only employers can access, not users."
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Only employers can access this endpoint, not users."
 // @Failure 500 {object} ErrorResponse "Any other error"
 // @Security ApiKeyAuth
 // @Router /jobs/employer [get]
@@ -771,8 +1029,7 @@ func (server *Server) listEmployerJobs(ctx *gin.Context) {
 	authEmployer, err := server.store.GetEmployerByEmail(ctx, authPayload.Email)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			// but middleware did not stop the request, so it had to be made by a user
-			ctx.JSON(http.StatusUnauthorized, errorResponse(onlyUsersAccessError))
+			ctx.JSON(http.StatusUnauthorized, errorResponse(accountNotFoundError))
 			return
 		}
 
@@ -59,7 +59,9 @@ func newJobApplicationResponse(jobApplication db.JobApplication) jobApplicationR
 // @Produce json
 // @Success 200 {object} jobApplicationResponse
 // @Failure 400 {object} ErrorResponse "Invalid request body"
-// @Failure 401 {object} ErrorResponse "Unauthorized. Only users can access, not employers."
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Only users can access this endpoint, not employers. The job is not open for applications."
+// @Failure 404 {object} ErrorResponse "Job not found"
 // @Failure 500 {object} ErrorResponse "Any other error"
 // @Security ApiKeyAuth
 // @Router /job-applications [post]
@@ -70,8 +72,7 @@ func (server *Server) createJobApplication(ctx *gin.Context) {
 	authUser, err := server.store.GetUserByEmail(ctx, authPayload.Email)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			// but middleware did not stop the request, so it had to be made by the employer
-			ctx.JSON(http.StatusUnauthorized, errorResponse(onlyUsersAccessError))
+			ctx.JSON(http.StatusUnauthorized, errorResponse(accountNotFoundError))
 			return
 		}
 
@@ -108,6 +109,21 @@ func (server *Server) createJobApplication(ctx *gin.Context) {
 		return
 	}
 
+	// only published jobs that are not past their closes_at can be applied for
+	job, err := server.store.GetJob(ctx, int32(jobID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if !isJobOpen(job, time.Now()) {
+		ctx.JSON(http.StatusForbidden, errorResponse(jobNotOpenError))
+		return
+	}
+
 	// create job application in the database
 	params := db.CreateJobApplicationTxParams{
 		CreateJobApplicationParams: db.CreateJobApplicationParams{
@@ -184,8 +200,8 @@ type getJobApplicationForUserResponse struct {
 // @Produce json
 // @Success 200 {object} getJobApplicationForUserResponse
 // @Failure 400 {object} ErrorResponse "Invalid ID"
-// @Failure 401 {object} ErrorResponse "Unauthorized. Only users can access, not employers."
-// @Failure 403 {object} ErrorResponse "Only the applicant (the owner) of the job application can access this endpoint."
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Only users can access this endpoint. Only the applicant (the owner) of the job application can access this endpoint."
 // @Failure 500 {object} ErrorResponse "Any other error"
 // @Security ApiKeyAuth
 // @Router /job-applications/user/{id} [get]
@@ -201,19 +217,8 @@ func (server *Server) getJobApplicationForUser(ctx *gin.Context) {
 		return
 	}
 
-	// check if the user is authenticated (and is a user, not employer)
+	// requireUser middleware guarantees that this is a user
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	authUser, err := server.store.GetUserByEmail(ctx, authPayload.Email)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			// but middleware did not stop the request, so it had to be made by an employer
-			ctx.JSON(http.StatusUnauthorized, errorResponse(onlyUsersAccessError))
-			return
-		}
-
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
-	}
 
 	// get the job application from the database
 	jobApplication, err := server.store.GetJobApplicationForUser(ctx, request.ID)
@@ -230,8 +235,8 @@ func (server *Server) getJobApplicationForUser(ctx *gin.Context) {
 	}
 
 	// check if the authenticated user is the applicant
-	if authUser.ID != jobApplication.UserID {
-		err = fmt.Errorf("user with ID %d is not the applicant of this job application", authUser.ID)
+	if authPayload.AccountID != jobApplication.UserID {
+		err = fmt.Errorf("user with ID %d is not the applicant of this job application", authPayload.AccountID)
 		ctx.JSON(http.StatusForbidden, errorResponse(err))
 		return
 	}
@@ -289,8 +294,8 @@ type getJobApplicationForEmployerResponse struct {
 // @Produce json
 // @Success 200 {object} getJobApplicationForEmployerResponse
 // @Failure 400 {object} ErrorResponse "Invalid ID"
-// @Failure 401 {object} ErrorResponse "Unauthorized. Only employers can access, not users."
-// @Failure 403 {object} ErrorResponse "Only an employer that is part of the company that created the job that this application is for can access this endpoint.
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Only employers can access this endpoint. Only an employer that is part of the company that created the job that this application is for can access this endpoint.
 // @Failure 500 {object} ErrorResponse "Any other error"
 // @Security ApiKeyAuth
 // @Router /job-applications/employer/{id} [get]
@@ -311,8 +316,7 @@ func (server *Server) getJobApplicationForEmployer(ctx *gin.Context) {
 	authEmployer, err := server.store.GetEmployerByEmail(ctx, authPayload.Email)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			// but middleware did not stop the request, so it had to be made by a user
-			ctx.JSON(http.StatusUnauthorized, errorResponse(onlyEmployersAccessError))
+			ctx.JSON(http.StatusUnauthorized, errorResponse(accountNotFoundError))
 			return
 		}
 
@@ -417,8 +421,8 @@ type changeJobApplicationStatusResponse struct {
 // @Produce json
 // @Success 200 {object} changeJobApplicationStatusResponse
 // @Failure 400 {object} ErrorResponse "Invalid status or job application ID"
-// @Failure 401 {object} ErrorResponse "Unauthorized. Only employers can access, not users."
-// @Failure 403 {object} ErrorResponse "Only an employer that is part of the company that created the job that this application is for can access this endpoint.
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Only employers can access this endpoint. Only an employer that is part of the company that created the job that this application is for can access this endpoint. Viewers of the company cannot change statuses.
 // @Failure 404 {object} ErrorResponse "Job application with given ID does not exist"
 // @Failure 500 {object} ErrorResponse "Any other error"
 // @Security ApiKeyAuth
@@ -442,8 +446,7 @@ func (server *Server) changeJobApplicationStatus(ctx *gin.Context) {
 	authEmployer, err := server.store.GetEmployerByEmail(ctx, authPayload.Email)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			// but middleware did not stop the request, so it had to be made by a user
-			ctx.JSON(http.StatusUnauthorized, errorResponse(onlyEmployersAccessError))
+			ctx.JSON(http.StatusUnauthorized, errorResponse(accountNotFoundError))
 			return
 		}
 
@@ -481,6 +484,12 @@ func (server *Server) changeJobApplicationStatus(ctx *gin.Context) {
 		return
 	}
 
+	// viewers can only read the job applications
+	if !hasCompanyRole(authEmployer, db.CompanyRoleRecruiter) {
+		ctx.JSON(http.StatusForbidden, errorResponse(companyRoleError(db.CompanyRoleRecruiter)))
+		return
+	}
+
 	// update the job application status
 	err = server.store.UpdateJobApplicationStatus(ctx, db.UpdateJobApplicationStatusParams{
 		ID:     uriRequest.ID,
@@ -516,8 +525,8 @@ type updateJobApplicationRequest struct {
 // @Produce json
 // @Success 200 {object} jobApplicationResponse
 // @Failure 400 {object} ErrorResponse "Invalid data or job application ID"
-// @Failure 401 {object} ErrorResponse "Unauthorized. Only users can access, not employers."
-// @Failure 403 {object} ErrorResponse "Only a user that created this job application can access this endpoint.
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Only users can access this endpoint. Only a user that created this job application can access this endpoint.
 // @Failure 404 {object} ErrorResponse "Job application with given ID does not exist"
 // @Failure 500 {object} ErrorResponse "Any other error"
 // @Security ApiKeyAuth
@@ -530,19 +539,8 @@ func (server *Server) updateJobApplication(ctx *gin.Context) {
 		return
 	}
 
-	// check if the user is authenticated (and is a user, not an employer)
+	// requireUser middleware guarantees that this is a user
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	authUser, err := server.store.GetUserByEmail(ctx, authPayload.Email)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			// but middleware did not stop the request, so it had to be made by an employer
-			ctx.JSON(http.StatusUnauthorized, errorResponse(onlyUsersAccessError))
-			return
-		}
-
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
-	}
 
 	// get the job application and check if the user created it
 	applicationDetails, err := server.store.GetJobApplicationUserIDAndStatus(ctx, request.ID)
@@ -566,9 +564,9 @@ func (server *Server) updateJobApplication(ctx *gin.Context) {
 	}
 
 	// compare userID and users ID to check if the user created the job application
-	if applicationDetails.UserID != authUser.ID {
+	if applicationDetails.UserID != authPayload.AccountID {
 		ctx.JSON(http.StatusForbidden, errorResponse(
-			userNotOwnerOfApplicationError(authUser.ID),
+			userNotOwnerOfApplicationError(authPayload.AccountID),
 		))
 		return
 	}
@@ -641,8 +639,8 @@ type deleteJobApplicationRequest struct {
 // @param id path int true "job application ID"
 // @Success 204 {null} null
 // @Failure 400 {object} ErrorResponse "Invalid job application ID"
-// @Failure 401 {object} ErrorResponse "Unauthorized. Only users can access, not employers."
-// @Failure 403 {object} ErrorResponse "Only a user that created this job application can access this endpoint.
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Only users can access this endpoint. Only a user that created this job application can access this endpoint.
 // @Failure 404 {object} ErrorResponse "Job application with given ID does not exist"
 // @Failure 500 {object} ErrorResponse "Any other error"
 // @Security ApiKeyAuth
@@ -656,19 +654,8 @@ func (server *Server) deleteJobApplication(ctx *gin.Context) {
 		return
 	}
 
-	// check if the user is authenticated (and is a user, not an employer)
+	// requireUser middleware guarantees that this is a user
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	authUser, err := server.store.GetUserByEmail(ctx, authPayload.Email)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			// but middleware did not stop the request, so it had to be made by an employer
-			ctx.JSON(http.StatusUnauthorized, errorResponse(onlyUsersAccessError))
-			return
-		}
-
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
-	}
 
 	// get the userID of the job application and check if the user created it
 	userID, err := server.store.GetJobApplicationUserID(ctx, request.ID)
@@ -685,9 +672,9 @@ func (server *Server) deleteJobApplication(ctx *gin.Context) {
 	}
 
 	//  check if the user created the job application
-	if userID != authUser.ID {
+	if userID != authPayload.AccountID {
 		ctx.JSON(http.StatusForbidden, errorResponse(
-			userNotOwnerOfApplicationError(authUser.ID),
+			userNotOwnerOfApplicationError(authPayload.AccountID),
 		))
 		return
 	}
@@ -718,7 +705,8 @@ type listJobApplicationsForUser struct {
 // @param status query string false "filter by status ('Applied', 'Seen', 'Interviewing', 'Offered', 'Rejected')"
 // @Success 200 {object} []db.ListJobApplicationsForUserRow
 // @Failure 400 {object} ErrorResponse "Invalid query parameters"
-// @Failure 401 {object} ErrorResponse "Unauthorized. Only users can access, not employers."
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Only users can access this endpoint, not employers."
 // @Failure 500 {object} ErrorResponse "Any other error"
 // @Security ApiKeyAuth
 // @Router /job-applications/user [get]
@@ -731,23 +719,12 @@ func (server *Server) listJobApplicationsForUser(ctx *gin.Context) {
 		return
 	}
 
-	// check if the user is authenticated (and is a user, not an employer)
+	// requireUser middleware guarantees that this is a user
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	authUser, err := server.store.GetUserByEmail(ctx, authPayload.Email)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			// but middleware did not stop the request, so it had to be made by an employer
-			ctx.JSON(http.StatusUnauthorized, errorResponse(onlyUsersAccessError))
-			return
-		}
-
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
-	}
 
 	// get the job applications
 	params := db.ListJobApplicationsForUserParams{
-		UserID: authUser.ID,
+		UserID: authPayload.AccountID,
 		Limit:  request.PageSize,
 		Offset: (request.Page - 1) * request.PageSize,
 
@@ -802,8 +779,8 @@ type listJobApplicationsForEmployer struct {
 // @param status query string false "filter by status ('Applied', 'Seen', 'Interviewing', 'Offered', 'Rejected')"
 // @Success 200 {object} []db.ListJobApplicationsForEmployerRow
 // @Failure 400 {object} ErrorResponse "Invalid query parameters"
-// @Failure 401 {object} ErrorResponse "Unauthorized. Only employers can access, not users."
-// @Failure 403 {object} ErrorResponse "Employer is trying to access job that does not belong to them."
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Only employers can access this endpoint. Employer is trying to access job that does not belong to them."
 // @Failure 404 {object} ErrorResponse "Job with given ID does not exist"
 // @Failure 500 {object} ErrorResponse "Any other error"
 // @Security ApiKeyAuth
@@ -822,8 +799,7 @@ func (server *Server) listJobApplicationsForEmployer(ctx *gin.Context) {
 	authEmployer, err := server.store.GetEmployerByEmail(ctx, authPayload.Email)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			// but middleware did not stop the request, so it had to be made by a user
-			ctx.JSON(http.StatusUnauthorized, errorResponse(onlyUsersAccessError))
+			ctx.JSON(http.StatusUnauthorized, errorResponse(accountNotFoundError))
 			return
 		}
 
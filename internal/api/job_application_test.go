@@ -36,6 +36,14 @@ func TestCreateJobApplicationAPI(t *testing.T) {
 
 	message := utils.RandomString(5)
 
+	pausedJob := job
+	pausedJob.Status = db.JobStatusPaused
+	passedJob := job
+	passedJob.ClosesAt = sql.NullTime{
+		Time:  time.Now().Add(-time.Hour),
+		Valid: true,
+	}
+
 	jobApplication := db.JobApplication{
 		ID:     utils.RandomInt(1, 1000),
 		UserID: user.ID,
@@ -70,13 +78,17 @@ func TestCreateJobApplicationAPI(t *testing.T) {
 			},
 			cv: fakeFileData,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
 					Times(1).
 					Return(user, nil)
+				store.EXPECT().
+					GetJob(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(job, nil)
 				store.EXPECT().
 					CreateJobApplicationTx(gomock.Any(), gomock.Any()).
 					Times(1).
@@ -97,7 +109,7 @@ func TestCreateJobApplicationAPI(t *testing.T) {
 			},
 			cv: fakeFileData,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
@@ -120,7 +132,7 @@ func TestCreateJobApplicationAPI(t *testing.T) {
 			},
 			cv: fakeFileData,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
@@ -143,13 +155,17 @@ func TestCreateJobApplicationAPI(t *testing.T) {
 			},
 			cv: fakeFileData,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
 					Times(1).
 					Return(user, nil)
+				store.EXPECT().
+					GetJob(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(job, nil)
 				store.EXPECT().
 					CreateJobApplicationTx(gomock.Any(), gomock.Any()).
 					Times(1).
@@ -160,26 +176,133 @@ func TestCreateJobApplicationAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "Unauthorized Only Users Access",
+			name: "Job Not Found",
 			body: Body{
 				Message: message,
 				JobID:   job.ID,
 			},
 			cv: fakeFileData,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetJob(gomock.Any(), gomock.Eq(job.ID)).
 					Times(1).
-					Return(db.User{}, sql.ErrNoRows)
+					Return(db.Job{}, sql.ErrNoRows)
 				store.EXPECT().
 					CreateJobApplicationTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error GetJob",
+			body: Body{
+				Message: message,
+				JobID:   job.ID,
+			},
+			cv: fakeFileData,
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetJob(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(db.Job{}, sql.ErrConnDone)
+				store.EXPECT().
+					CreateJobApplicationTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden Job Paused",
+			body: Body{
+				Message: message,
+				JobID:   job.ID,
+			},
+			cv: fakeFileData,
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetJob(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(pausedJob, nil)
+				store.EXPECT().
+					CreateJobApplicationTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden Job Closes At Passed",
+			body: Body{
+				Message: message,
+				JobID:   job.ID,
+			},
+			cv: fakeFileData,
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetJob(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(passedJob, nil)
+				store.EXPECT().
+					CreateJobApplicationTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden Only Users Access",
+			body: Body{
+				Message: message,
+				JobID:   job.ID,
+			},
+			cv: fakeFileData,
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(0)
+				store.EXPECT().
+					CreateJobApplicationTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
 			},
 		},
 		{
@@ -189,7 +312,7 @@ func TestCreateJobApplicationAPI(t *testing.T) {
 				JobID:   job.ID,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
@@ -212,13 +335,17 @@ func TestCreateJobApplicationAPI(t *testing.T) {
 			},
 			cv: fakeFileData,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
 					Times(1).
 					Return(user, nil)
+				store.EXPECT().
+					GetJob(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(job, nil)
 				store.EXPECT().
 					CreateJobApplicationTx(gomock.Any(), gomock.Any()).
 					Times(1).
@@ -317,13 +444,9 @@ func TestGetJobApplicationForUserAPI(t *testing.T) {
 			name:             "OK",
 			JobApplicationID: jobApplicationID,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(user, nil)
 				getJobApplicationForUserRow.ApplicationMessage.Valid = true
 				getJobApplicationForUserRow.ApplicationMessage.String = utils.RandomString(5)
 				store.EXPECT().
@@ -340,12 +463,9 @@ func TestGetJobApplicationForUserAPI(t *testing.T) {
 			name:             "Invalid Job Application ID",
 			JobApplicationID: 0,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Any()).
-					Times(0)
 				store.EXPECT().
 					GetJobApplicationForUser(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -355,54 +475,27 @@ func TestGetJobApplicationForUserAPI(t *testing.T) {
 			},
 		},
 		{
-			name:             "Unauthorized",
-			JobApplicationID: jobApplicationID,
-			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Eq(employer.Email)).
-					Times(1).
-					Return(db.User{}, sql.ErrNoRows)
-				store.EXPECT().
-					GetJobApplicationForUser(gomock.Any(), gomock.Any()).
-					Times(0)
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusUnauthorized, recorder.Code)
-			},
-		},
-		{
-			name:             "Internal Server Error GetUserByEmail",
+			name:             "Forbidden",
 			JobApplicationID: jobApplicationID,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(db.User{}, sql.ErrConnDone)
 				store.EXPECT().
 					GetJobApplicationForUser(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, http.StatusForbidden, recorder.Code)
 			},
 		},
 		{
 			name:             "Not Found",
 			JobApplicationID: jobApplicationID,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(user, nil)
 				store.EXPECT().
 					GetJobApplicationForUser(gomock.Any(), gomock.Any()).
 					Times(1).
@@ -416,13 +509,9 @@ func TestGetJobApplicationForUserAPI(t *testing.T) {
 			name:             "Internal Server Error GetJobApplicationForUser",
 			JobApplicationID: jobApplicationID,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(user, nil)
 				store.EXPECT().
 					GetJobApplicationForUser(gomock.Any(), gomock.Any()).
 					Times(1).
@@ -436,13 +525,9 @@ func TestGetJobApplicationForUserAPI(t *testing.T) {
 			name:             "Internal Server Error GetJobApplicationForUser",
 			JobApplicationID: jobApplicationID,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(user, nil)
 				store.EXPECT().
 					GetJobApplicationForUser(gomock.Any(), gomock.Any()).
 					Times(1).
@@ -456,13 +541,9 @@ func TestGetJobApplicationForUserAPI(t *testing.T) {
 			name:             "Forbidden Not Owner",
 			JobApplicationID: jobApplicationID,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(user, nil)
 
 				// change userID so that the job application does not belong to the user
 				getJobApplicationForUserRow.UserID = user.ID + 1
@@ -558,7 +639,7 @@ func TestGetJobApplicationForEmployerAPI(t *testing.T) {
 			name:             "OK",
 			JobApplicationID: jobApplicationID,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -595,7 +676,7 @@ func TestGetJobApplicationForEmployerAPI(t *testing.T) {
 			name:             "Invalid Job Application ID",
 			JobApplicationID: 0,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -620,7 +701,7 @@ func TestGetJobApplicationForEmployerAPI(t *testing.T) {
 			name:             "Unauthorized",
 			JobApplicationID: jobApplicationID,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -645,7 +726,7 @@ func TestGetJobApplicationForEmployerAPI(t *testing.T) {
 			name:             "Internal Server Error GetEmployerByEmail",
 			JobApplicationID: jobApplicationID,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -670,7 +751,7 @@ func TestGetJobApplicationForEmployerAPI(t *testing.T) {
 			name:             "Not Found",
 			JobApplicationID: jobApplicationID,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -696,7 +777,7 @@ func TestGetJobApplicationForEmployerAPI(t *testing.T) {
 			name:             "Internal Server Error GetJobApplicationForEmployer",
 			JobApplicationID: jobApplicationID,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -722,7 +803,7 @@ func TestGetJobApplicationForEmployerAPI(t *testing.T) {
 			name:             "Forbidden Only Owner",
 			JobApplicationID: jobApplicationID,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -750,7 +831,7 @@ func TestGetJobApplicationForEmployerAPI(t *testing.T) {
 			name:             "Internal Server Error GetCompanyIDOfJob",
 			JobApplicationID: jobApplicationID,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -777,7 +858,7 @@ func TestGetJobApplicationForEmployerAPI(t *testing.T) {
 			name:             "Internal Server Error UpdateJobApplicationStatus",
 			JobApplicationID: jobApplicationID,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -853,6 +934,8 @@ func TestChangeJobApplicationStatusAPI(t *testing.T) {
 	employer, _, company := generateRandomEmployerAndCompany(t)
 	jobApplicationID := utils.RandomInt(1, 1000)
 	job := generateRandomJob()
+	viewer := employer
+	viewer.Role = db.CompanyRoleViewer
 
 	testCases := []struct {
 		name             string
@@ -869,7 +952,7 @@ func TestChangeJobApplicationStatusAPI(t *testing.T) {
 				"new_status": "Rejected",
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -906,6 +989,36 @@ func TestChangeJobApplicationStatusAPI(t *testing.T) {
 				require.Equal(t, "Status updated successfully", response.Message)
 			},
 		},
+		{
+			name:             "Forbidden Viewer",
+			JobApplicationID: jobApplicationID,
+			body: gin.H{
+				"new_status": "Rejected",
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, viewer.Email, token.AccountTypeEmployer, viewer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(viewer.Email)).
+					Times(1).
+					Return(viewer, nil)
+				store.EXPECT().
+					GetJobIDOfJobApplication(gomock.Any(), gomock.Eq(jobApplicationID)).
+					Times(1).
+					Return(job.ID, nil)
+				store.EXPECT().
+					GetCompanyIDOfJob(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(company.ID, nil)
+				store.EXPECT().
+					UpdateJobApplicationStatus(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
 		{
 			name:             "Invalid Status",
 			JobApplicationID: jobApplicationID,
@@ -913,7 +1026,7 @@ func TestChangeJobApplicationStatusAPI(t *testing.T) {
 				"new_status": "Invalid",
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -940,7 +1053,7 @@ func TestChangeJobApplicationStatusAPI(t *testing.T) {
 				"new_status": "Rejected",
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -961,19 +1074,18 @@ func TestChangeJobApplicationStatusAPI(t *testing.T) {
 			},
 		},
 		{
-			name:             "Unauthorized",
+			name:             "Forbidden",
 			JobApplicationID: jobApplicationID,
 			body: gin.H{
 				"new_status": "Rejected",
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(db.Employer{}, sql.ErrNoRows)
+					Times(0)
 				store.EXPECT().
 					GetJobIDOfJobApplication(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -985,7 +1097,7 @@ func TestChangeJobApplicationStatusAPI(t *testing.T) {
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				require.Equal(t, http.StatusForbidden, recorder.Code)
 			},
 		},
 		{
@@ -995,7 +1107,7 @@ func TestChangeJobApplicationStatusAPI(t *testing.T) {
 				"new_status": "Rejected",
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -1023,11 +1135,11 @@ func TestChangeJobApplicationStatusAPI(t *testing.T) {
 				"new_status": "Rejected",
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					GetEmployerByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
 					Return(employer, nil)
 				store.EXPECT().
@@ -1052,11 +1164,11 @@ func TestChangeJobApplicationStatusAPI(t *testing.T) {
 				"new_status": "Rejected",
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					GetEmployerByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
 					Return(employer, nil)
 				store.EXPECT().
@@ -1082,11 +1194,11 @@ func TestChangeJobApplicationStatusAPI(t *testing.T) {
 				"new_status": "Rejected",
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					GetEmployerByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
 					Return(employer, nil)
 				store.EXPECT().
@@ -1112,11 +1224,11 @@ func TestChangeJobApplicationStatusAPI(t *testing.T) {
 				"new_status": "Rejected",
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					GetEmployerByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
 					Return(employer, nil)
 				store.EXPECT().
@@ -1147,11 +1259,11 @@ func TestChangeJobApplicationStatusAPI(t *testing.T) {
 				"new_status": "Rejected",
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					GetEmployerByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
 					Return(employer, nil)
 				store.EXPECT().
@@ -1246,13 +1358,9 @@ func TestUpdateJobApplicationAPI(t *testing.T) {
 			cv:               fakeFileData,
 			cvProvided:       "true",
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(user, nil)
 				store.EXPECT().
 					GetJobApplicationUserIDAndStatus(gomock.Any(), gomock.Eq(jobApplication.ID)).
 					Times(1).
@@ -1274,12 +1382,9 @@ func TestUpdateJobApplicationAPI(t *testing.T) {
 			cv:               fakeFileData,
 			cvProvided:       "true",
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Any()).
-					Times(0)
 				store.EXPECT().
 					GetJobApplicationUserIDAndStatus(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -1292,19 +1397,15 @@ func TestUpdateJobApplicationAPI(t *testing.T) {
 			},
 		},
 		{
-			name:             "Unauthorized Only Users Can Access",
+			name:             "Forbidden Only Users Can Access",
 			JobApplicationID: jobApplication.ID,
 			message:          message,
 			cv:               fakeFileData,
 			cvProvided:       "1",
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Eq(employer.Email)).
-					Times(1).
-					Return(db.User{}, sql.ErrNoRows)
 				store.EXPECT().
 					GetJobApplicationUserIDAndStatus(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -1313,31 +1414,7 @@ func TestUpdateJobApplicationAPI(t *testing.T) {
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusUnauthorized, recorder.Code)
-			},
-		},
-		{
-			name:             "Internal Server Error GetUserByEmail",
-			JobApplicationID: jobApplication.ID,
-			message:          message,
-			cvProvided:       "false",
-			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(db.User{}, sql.ErrConnDone)
-				store.EXPECT().
-					GetJobApplicationUserIDAndStatus(gomock.Any(), gomock.Any()).
-					Times(0)
-				store.EXPECT().
-					UpdateJobApplication(gomock.Any(), gomock.Any()).
-					Times(0)
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, http.StatusForbidden, recorder.Code)
 			},
 		},
 		{
@@ -1347,13 +1424,9 @@ func TestUpdateJobApplicationAPI(t *testing.T) {
 			cv:               fakeFileData,
 			cvProvided:       "1",
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(user, nil)
 				store.EXPECT().
 					GetJobApplicationUserIDAndStatus(gomock.Any(), gomock.Eq(jobApplication.ID)).
 					Times(1).
@@ -1373,13 +1446,9 @@ func TestUpdateJobApplicationAPI(t *testing.T) {
 			cv:               fakeFileData,
 			cvProvided:       "1",
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(user, nil)
 				store.EXPECT().
 					GetJobApplicationUserIDAndStatus(gomock.Any(), gomock.Eq(jobApplication.ID)).
 					Times(1).
@@ -1399,13 +1468,9 @@ func TestUpdateJobApplicationAPI(t *testing.T) {
 			cv:               fakeFileData,
 			cvProvided:       "1",
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(user, nil)
 				store.EXPECT().
 					GetJobApplicationUserIDAndStatus(gomock.Any(), gomock.Eq(jobApplication.ID)).
 					Times(1).
@@ -1428,13 +1493,9 @@ func TestUpdateJobApplicationAPI(t *testing.T) {
 			cv:               fakeFileData,
 			cvProvided:       "1",
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(user, nil)
 				store.EXPECT().
 					GetJobApplicationUserIDAndStatus(gomock.Any(), gomock.Eq(jobApplication.ID)).
 					Times(1).
@@ -1456,13 +1517,9 @@ func TestUpdateJobApplicationAPI(t *testing.T) {
 			message:          message,
 			cvProvided:       "0",
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(user, nil)
 				store.EXPECT().
 					GetJobApplicationUserIDAndStatus(gomock.Any(), gomock.Eq(jobApplication.ID)).
 					Times(1).
@@ -1482,13 +1539,9 @@ func TestUpdateJobApplicationAPI(t *testing.T) {
 			message:          message,
 			cvProvided:       "1",
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(user, nil)
 				store.EXPECT().
 					GetJobApplicationUserIDAndStatus(gomock.Any(), gomock.Eq(jobApplication.ID)).
 					Times(1).
@@ -1573,13 +1626,9 @@ func TestDeleteJobApplicationAPI(t *testing.T) {
 			name:             "OK",
 			JobApplicationID: jobApplicationID,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(user, nil)
 				store.EXPECT().
 					GetJobApplicationUserID(gomock.Any(), gomock.Eq(jobApplicationID)).
 					Times(1).
@@ -1597,12 +1646,9 @@ func TestDeleteJobApplicationAPI(t *testing.T) {
 			name:             "Invalid Job Application ID",
 			JobApplicationID: 0,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Any()).
-					Times(0)
 				store.EXPECT().
 					GetJobApplicationUserID(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -1615,16 +1661,12 @@ func TestDeleteJobApplicationAPI(t *testing.T) {
 			},
 		},
 		{
-			name:             "Unauthorized Only User Access",
+			name:             "Forbidden Only User Access",
 			JobApplicationID: jobApplicationID,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Eq(employer.Email)).
-					Times(1).
-					Return(db.User{}, sql.ErrNoRows)
 				store.EXPECT().
 					GetJobApplicationUserID(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -1633,42 +1675,16 @@ func TestDeleteJobApplicationAPI(t *testing.T) {
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusUnauthorized, recorder.Code)
-			},
-		},
-		{
-			name:             "Internal Server Error GetUserByEmail",
-			JobApplicationID: jobApplicationID,
-			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(db.User{}, sql.ErrConnDone)
-				store.EXPECT().
-					GetJobApplicationUserID(gomock.Any(), gomock.Any()).
-					Times(0)
-				store.EXPECT().
-					DeleteJobApplication(gomock.Any(), gomock.Any()).
-					Times(0)
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, http.StatusForbidden, recorder.Code)
 			},
 		},
 		{
 			name:             "Job Application Not Found",
 			JobApplicationID: jobApplicationID,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(user, nil)
 				store.EXPECT().
 					GetJobApplicationUserID(gomock.Any(), gomock.Eq(jobApplicationID)).
 					Times(1).
@@ -1685,13 +1701,9 @@ func TestDeleteJobApplicationAPI(t *testing.T) {
 			name:             "Internal Server Error GetJobApplicationUserID",
 			JobApplicationID: jobApplicationID,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(user, nil)
 				store.EXPECT().
 					GetJobApplicationUserID(gomock.Any(), gomock.Eq(jobApplicationID)).
 					Times(1).
@@ -1708,13 +1720,9 @@ func TestDeleteJobApplicationAPI(t *testing.T) {
 			name:             "Forbidden User Not Owner",
 			JobApplicationID: jobApplicationID,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(user, nil)
 				store.EXPECT().
 					GetJobApplicationUserID(gomock.Any(), gomock.Eq(jobApplicationID)).
 					Times(1).
@@ -1731,13 +1739,9 @@ func TestDeleteJobApplicationAPI(t *testing.T) {
 			name:             "Internal Server Error DeleteJobApplication",
 			JobApplicationID: jobApplicationID,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(user, nil)
 				store.EXPECT().
 					GetJobApplicationUserID(gomock.Any(), gomock.Eq(jobApplicationID)).
 					Times(1).
@@ -1819,13 +1823,9 @@ func TestLustJobApplicationsForUserAPI(t *testing.T) {
 				status:   db.ApplicationStatusApplied,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(user, nil)
 				params := db.ListJobApplicationsForUserParams{
 					UserID:        user.ID,
 					Limit:         10,
@@ -1854,12 +1854,9 @@ func TestLustJobApplicationsForUserAPI(t *testing.T) {
 				status:   db.ApplicationStatusApplied,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Any()).
-					Times(0)
 				store.EXPECT().
 					ListJobApplicationsForUser(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -1877,12 +1874,9 @@ func TestLustJobApplicationsForUserAPI(t *testing.T) {
 				status:   db.ApplicationStatusApplied,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Any()).
-					Times(0)
 				store.EXPECT().
 					ListJobApplicationsForUser(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -1900,12 +1894,9 @@ func TestLustJobApplicationsForUserAPI(t *testing.T) {
 				status:   db.ApplicationStatusApplied,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Any()).
-					Times(0)
 				store.EXPECT().
 					ListJobApplicationsForUser(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -1923,12 +1914,9 @@ func TestLustJobApplicationsForUserAPI(t *testing.T) {
 				status:   "invalid",
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Any()).
-					Times(0)
 				store.EXPECT().
 					ListJobApplicationsForUser(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -1938,31 +1926,7 @@ func TestLustJobApplicationsForUserAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "Unauthorized Only Users Access",
-			query: Query{
-				page:     1,
-				pageSize: 10,
-				sort:     "date-desc",
-				status:   db.ApplicationStatusApplied,
-			},
-			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Eq(employer.Email)).
-					Times(1).
-					Return(db.User{}, sql.ErrNoRows)
-				store.EXPECT().
-					ListJobApplicationsForUser(gomock.Any(), gomock.Any()).
-					Times(0)
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusUnauthorized, recorder.Code)
-			},
-		},
-		{
-			name: "Internal Server Error GetUserByEmail",
+			name: "Forbidden Only Users Access",
 			query: Query{
 				page:     1,
 				pageSize: 10,
@@ -1970,44 +1934,15 @@ func TestLustJobApplicationsForUserAPI(t *testing.T) {
 				status:   db.ApplicationStatusApplied,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Eq(employer.Email)).
-					Times(1).
-					Return(db.User{}, sql.ErrConnDone)
 				store.EXPECT().
 					ListJobApplicationsForUser(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
-			},
-		},
-		{
-			name: "Internal Server Error GetUserByEmail",
-			query: Query{
-				page:     1,
-				pageSize: 10,
-				sort:     "date-asc",
-				status:   db.ApplicationStatusApplied,
-			},
-			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(user, nil)
-				store.EXPECT().
-					ListJobApplicationsForUser(gomock.Any(), gomock.Any()).
-					Times(1).
-					Return([]db.ListJobApplicationsForUserRow{}, sql.ErrConnDone)
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, http.StatusForbidden, recorder.Code)
 			},
 		},
 	}
@@ -2088,7 +2023,7 @@ func TestLustJobApplicationsForEmployerAPI(t *testing.T) {
 				status:   db.ApplicationStatusApplied,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -2128,7 +2063,7 @@ func TestLustJobApplicationsForEmployerAPI(t *testing.T) {
 				status:   db.ApplicationStatusApplied,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -2155,7 +2090,7 @@ func TestLustJobApplicationsForEmployerAPI(t *testing.T) {
 				status:   db.ApplicationStatusApplied,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -2182,7 +2117,7 @@ func TestLustJobApplicationsForEmployerAPI(t *testing.T) {
 				status:   db.ApplicationStatusApplied,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -2209,7 +2144,7 @@ func TestLustJobApplicationsForEmployerAPI(t *testing.T) {
 				status:   "invalid",
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -2235,7 +2170,7 @@ func TestLustJobApplicationsForEmployerAPI(t *testing.T) {
 				status:   db.ApplicationStatusApplied,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -2253,7 +2188,7 @@ func TestLustJobApplicationsForEmployerAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "Unauthorized Only Employer Access",
+			name: "Forbidden Only Employer Access",
 			query: Query{
 				jobID:    job.ID,
 				page:     1,
@@ -2262,13 +2197,12 @@ func TestLustJobApplicationsForEmployerAPI(t *testing.T) {
 				status:   db.ApplicationStatusApplied,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(db.Employer{}, sql.ErrNoRows)
+					Times(0)
 				store.EXPECT().
 					GetCompanyIDOfJob(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -2277,7 +2211,7 @@ func TestLustJobApplicationsForEmployerAPI(t *testing.T) {
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				require.Equal(t, http.StatusForbidden, recorder.Code)
 			},
 		},
 		{
@@ -2290,7 +2224,7 @@ func TestLustJobApplicationsForEmployerAPI(t *testing.T) {
 				status:   db.ApplicationStatusApplied,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -2318,7 +2252,7 @@ func TestLustJobApplicationsForEmployerAPI(t *testing.T) {
 				status:   db.ApplicationStatusApplied,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -2347,7 +2281,7 @@ func TestLustJobApplicationsForEmployerAPI(t *testing.T) {
 				status:   db.ApplicationStatusApplied,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -2376,7 +2310,7 @@ func TestLustJobApplicationsForEmployerAPI(t *testing.T) {
 				status:   db.ApplicationStatusApplied,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -2405,7 +2339,7 @@ func TestLustJobApplicationsForEmployerAPI(t *testing.T) {
 				status:   db.ApplicationStatusApplied,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -0,0 +1,240 @@
+package api
+
+import (
+	"database/sql"
+	db "github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/aalug/job-finder-go/internal/esearch"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"net/http"
+	"sort"
+)
+
+const (
+	// defaultSimilarJobsSize is the number of the similar jobs if the size is not given
+	defaultSimilarJobsSize = 10
+	// similarJobCandidates is the number of the candidates from the search provider
+	// and from the skill overlap that the similar jobs are picked from
+	similarJobCandidates = 50
+	// weights of the text similarity and of the skill overlap in the scores of the similar jobs
+	similarTextWeight   = 0.6
+	similarSkillsWeight = 0.4
+)
+
+type listSimilarJobsUriRequest struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+type listSimilarJobsRequest struct {
+	Size int `form:"size" binding:"omitempty,min=1,max=20"`
+}
+
+// similarJobResponse is a job similar to another job with the skills they share.
+// The score is between 0 and 1, the higher the more similar the jobs are.
+type similarJobResponse struct {
+	ID             int32    `json:"id"`
+	Title          string   `json:"title"`
+	CompanyName    string   `json:"company_name"`
+	Industry       string   `json:"industry"`
+	Location       string   `json:"location"`
+	SalaryMin      int32    `json:"salary_min"`
+	SalaryMax      int32    `json:"salary_max"`
+	SalaryCurrency string   `json:"salary_currency"`
+	SalaryPeriod   string   `json:"salary_period"`
+	EmploymentType string   `json:"employment_type"`
+	SeniorityLevel string   `json:"seniority_level"`
+	RemotePolicy   string   `json:"remote_policy"`
+	SharedSkills   []string `json:"shared_skills"`
+	Score          float64  `json:"score"`
+}
+
+// similarJobCandidate is a job that can be similar, with its text similarity
+// normalized to the best candidate and the numbers of its skills
+type similarJobCandidate struct {
+	response     similarJobResponse
+	textScore    float64
+	skillsCount  int
+	sharedSkills []string
+}
+
+// @Schemes
+// @Summary List similar jobs
+// @Description List the published jobs similar to the job with the given id. The jobs are scored by the similarity of their titles, descriptions and skills (more_like_this of elasticsearch, or the shared words in postgres with SEARCH_PROVIDER=postgres or failover) and by the overlap of their required skills.
+// @Tags jobs
+// @Param id path integer true "Job ID"
+// @Param size query integer false "Max number of the similar jobs, 10 by default, max 20"
+// @Produce json
+// @Success 200 {array} []similarJobResponse
+// @Failure 400 {object} ErrorResponse "Invalid request"
+// @Failure 404 {object} ErrorResponse "Job not found"
+// @Failure 500 {object} ErrorResponse "Any other error"
+// @Router /jobs/{id}/similar [get]
+// listSimilarJobs handles listing the jobs similar to a job posting.
+// The candidates are the jobs found by the search provider and the jobs that share
+// skills with the job. When the search provider fails, only the skills are compared.
+func (server *Server) listSimilarJobs(ctx *gin.Context) {
+	var uriRequest listSimilarJobsUriRequest
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var request listSimilarJobsRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if request.Size == 0 {
+		request.Size = defaultSimilarJobsSize
+	}
+
+	job, err := server.store.GetJobDetails(ctx, uriRequest.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// drafts are visible only in the list of jobs of the employer
+	if job.Status == db.JobStatusDraft {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	jobSkills, err := server.store.ListAllJobSkillsByJobID(ctx, job.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	hits, err := server.search.SimilarJobs(ctx, job.ID, similarJobCandidates)
+	if err != nil {
+		log.Error().Err(err).Int32("job_id", job.ID).Msg("cannot find similar jobs with the search provider, comparing only the skills")
+		hits = nil
+	}
+
+	overlaps, err := server.store.ListJobsBySkillOverlap(ctx, db.ListJobsBySkillOverlapParams{
+		JobID: job.ID,
+		Size:  similarJobCandidates,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rankSimilarJobs(jobSkills, hits, overlaps, request.Size))
+}
+
+// rankSimilarJobs merges the candidates of the search provider and of the skill overlap and returns up to size
+// of them with the highest scores. The score is the weighted sum of the text score normalized
+// to the best hit and the Jaccard index of the skills of the jobs.
+func rankSimilarJobs(jobSkills []string, hits []*esearch.JobHit, overlaps []db.ListJobsBySkillOverlapRow, size int) []similarJobResponse {
+	candidates := map[int32]*similarJobCandidate{}
+
+	maxScore := 0.0
+	for _, hit := range hits {
+		if hit.Score > maxScore {
+			maxScore = hit.Score
+		}
+	}
+	for _, hit := range hits {
+		candidate := &similarJobCandidate{
+			response: similarJobResponse{
+				ID:             hit.ID,
+				Title:          hit.Title,
+				CompanyName:    hit.CompanyName,
+				Industry:       hit.Industry,
+				Location:       hit.Location,
+				SalaryMin:      hit.SalaryMin,
+				SalaryMax:      hit.SalaryMax,
+				SalaryCurrency: hit.SalaryCurrency,
+				SalaryPeriod:   hit.SalaryPeriod,
+				EmploymentType: hit.EmploymentType,
+				SeniorityLevel: hit.SeniorityLevel,
+				RemotePolicy:   hit.RemotePolicy,
+			},
+			skillsCount:  len(hit.JobSkills),
+			sharedSkills: sharedSkills(jobSkills, hit.JobSkills),
+		}
+		if maxScore > 0 {
+			candidate.textScore = hit.Score / maxScore
+		}
+		candidates[hit.ID] = candidate
+	}
+
+	// the skills of the database are up to date, the documents can lag behind
+	for _, overlap := range overlaps {
+		candidate, ok := candidates[overlap.ID]
+		if !ok {
+			candidate = &similarJobCandidate{
+				response: similarJobResponse{
+					ID:             overlap.ID,
+					Title:          overlap.Title,
+					CompanyName:    overlap.CompanyName,
+					Industry:       overlap.Industry,
+					Location:       overlap.Location,
+					SalaryMin:      overlap.SalaryMin,
+					SalaryMax:      overlap.SalaryMax,
+					SalaryCurrency: overlap.SalaryCurrency,
+					SalaryPeriod:   string(overlap.SalaryPeriod),
+					EmploymentType: string(overlap.EmploymentType),
+					SeniorityLevel: string(overlap.SeniorityLevel),
+					RemotePolicy:   string(overlap.RemotePolicy),
+				},
+			}
+			candidates[overlap.ID] = candidate
+		}
+		candidate.skillsCount = int(overlap.SkillsCount)
+		candidate.sharedSkills = overlap.SharedSkills
+	}
+
+	similarJobs := []similarJobResponse{}
+	for _, candidate := range candidates {
+		skillsScore := 0.0
+		if union := len(jobSkills) + candidate.skillsCount - len(candidate.sharedSkills); union > 0 {
+			skillsScore = float64(len(candidate.sharedSkills)) / float64(union)
+		}
+
+		response := candidate.response
+		response.SharedSkills = candidate.sharedSkills
+		if response.SharedSkills == nil {
+			response.SharedSkills = []string{}
+		}
+		response.Score = similarTextWeight*candidate.textScore + similarSkillsWeight*skillsScore
+		similarJobs = append(similarJobs, response)
+	}
+
+	sort.Slice(similarJobs, func(i, j int) bool {
+		if similarJobs[i].Score != similarJobs[j].Score {
+			return similarJobs[i].Score > similarJobs[j].Score
+		}
+		return similarJobs[i].ID < similarJobs[j].ID
+	})
+	if len(similarJobs) > size {
+		similarJobs = similarJobs[:size]
+	}
+
+	return similarJobs
+}
+
+// sharedSkills returns the skills of the job that the other job requires as well, sorted
+func sharedSkills(jobSkills, otherSkills []string) []string {
+	required := map[string]bool{}
+	for _, skill := range otherSkills {
+		required[skill] = true
+	}
+
+	shared := []string{}
+	for _, skill := range jobSkills {
+		if required[skill] {
+			shared = append(shared, skill)
+			delete(required, skill)
+		}
+	}
+	sort.Strings(shared)
+
+	return shared
+}
@@ -0,0 +1,306 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/aalug/job-finder-go/internal/db/mock"
+	db "github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/aalug/job-finder-go/internal/esearch"
+	mockesearch "github.com/aalug/job-finder-go/internal/esearch/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListSimilarJobsAPI(t *testing.T) {
+	job := generateRandomJob()
+	jobDetails := db.GetJobDetailsRow{
+		ID:     job.ID,
+		Title:  job.Title,
+		Status: db.JobStatusPublished,
+	}
+	jobSkills := []string{"Go", "Docker"}
+
+	hits := []*esearch.JobHit{
+		{
+			Job:   esearch.Job{ID: job.ID + 1, Title: "Senior Go Developer", JobSkills: []string{"Go", "Docker"}},
+			Score: 10,
+		},
+		{
+			Job:   esearch.Job{ID: job.ID + 2, Title: "Go Engineer"},
+			Score: 5,
+		},
+	}
+	overlaps := []db.ListJobsBySkillOverlapRow{
+		{
+			ID:           job.ID + 1,
+			Title:        "Senior Go Developer",
+			SharedSkills: []string{"Docker", "Go"},
+			SkillsCount:  2,
+		},
+		{
+			ID:           job.ID + 3,
+			Title:        "Platform Engineer",
+			SharedSkills: []string{"Go"},
+			SkillsCount:  3,
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		jobID         int32
+		size          int
+		buildStubs    func(store *mockdb.MockStore, client *mockesearch.MockESearchClient)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			jobID: job.ID,
+			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+				store.EXPECT().
+					GetJobDetails(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(jobDetails, nil)
+				store.EXPECT().
+					ListAllJobSkillsByJobID(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(jobSkills, nil)
+				client.EXPECT().
+					SimilarJobs(gomock.Any(), gomock.Eq(job.ID), gomock.Eq(similarJobCandidates)).
+					Times(1).
+					Return(hits, nil)
+				store.EXPECT().
+					ListJobsBySkillOverlap(gomock.Any(), gomock.Eq(db.ListJobsBySkillOverlapParams{
+						JobID: job.ID,
+						Size:  similarJobCandidates,
+					})).
+					Times(1).
+					Return(overlaps, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				similarJobs := requireBodySimilarJobs(t, recorder)
+
+				require.Len(t, similarJobs, 3)
+				// the same text and the same skills
+				require.Equal(t, job.ID+1, similarJobs[0].ID)
+				require.InDelta(t, 1.0, similarJobs[0].Score, 1e-9)
+				require.Equal(t, []string{"Docker", "Go"}, similarJobs[0].SharedSkills)
+				// half of the best text score and no skills
+				require.Equal(t, job.ID+2, similarJobs[1].ID)
+				require.InDelta(t, similarTextWeight*0.5, similarJobs[1].Score, 1e-9)
+				require.Equal(t, []string{}, similarJobs[1].SharedSkills)
+				// only one of the four skills of both jobs is shared
+				require.Equal(t, job.ID+3, similarJobs[2].ID)
+				require.InDelta(t, similarSkillsWeight*0.25, similarJobs[2].Score, 1e-9)
+				require.Equal(t, "Platform Engineer", similarJobs[2].Title)
+			},
+		},
+		{
+			name:  "OK Size",
+			jobID: job.ID,
+			size:  1,
+			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+				store.EXPECT().
+					GetJobDetails(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(jobDetails, nil)
+				store.EXPECT().
+					ListAllJobSkillsByJobID(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(jobSkills, nil)
+				client.EXPECT().
+					SimilarJobs(gomock.Any(), gomock.Eq(job.ID), gomock.Any()).
+					Times(1).
+					Return(hits, nil)
+				store.EXPECT().
+					ListJobsBySkillOverlap(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(overlaps, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				similarJobs := requireBodySimilarJobs(t, recorder)
+				require.Len(t, similarJobs, 1)
+				require.Equal(t, job.ID+1, similarJobs[0].ID)
+			},
+		},
+		{
+			name:  "OK Elasticsearch Error",
+			jobID: job.ID,
+			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+				store.EXPECT().
+					GetJobDetails(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(jobDetails, nil)
+				store.EXPECT().
+					ListAllJobSkillsByJobID(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(jobSkills, nil)
+				client.EXPECT().
+					SimilarJobs(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, errors.New("elasticsearch is unavailable"))
+				store.EXPECT().
+					ListJobsBySkillOverlap(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(overlaps, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				similarJobs := requireBodySimilarJobs(t, recorder)
+
+				// only the skills are compared
+				require.Len(t, similarJobs, 2)
+				require.Equal(t, job.ID+1, similarJobs[0].ID)
+				require.InDelta(t, similarSkillsWeight, similarJobs[0].Score, 1e-9)
+				require.Equal(t, job.ID+3, similarJobs[1].ID)
+			},
+		},
+		{
+			name:  "Not Found",
+			jobID: job.ID,
+			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+				store.EXPECT().
+					GetJobDetails(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(db.GetJobDetailsRow{}, sql.ErrNoRows)
+				client.EXPECT().
+					SimilarJobs(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:  "Draft Not Found",
+			jobID: job.ID,
+			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+				store.EXPECT().
+					GetJobDetails(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(db.GetJobDetailsRow{ID: job.ID, Status: db.JobStatusDraft}, nil)
+				client.EXPECT().
+					SimilarJobs(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:  "Internal Server Error",
+			jobID: job.ID,
+			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+				store.EXPECT().
+					GetJobDetails(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(db.GetJobDetailsRow{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:  "Internal Server Error Skill Overlap",
+			jobID: job.ID,
+			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+				store.EXPECT().
+					GetJobDetails(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(jobDetails, nil)
+				store.EXPECT().
+					ListAllJobSkillsByJobID(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(jobSkills, nil)
+				client.EXPECT().
+					SimilarJobs(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(hits, nil)
+				store.EXPECT().
+					ListJobsBySkillOverlap(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:  "Invalid ID",
+			jobID: 0,
+			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+				store.EXPECT().
+					GetJobDetails(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Invalid Size",
+			jobID: job.ID,
+			size:  21,
+			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+				store.EXPECT().
+					GetJobDetails(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			client := mockesearch.NewMockESearchClient(ctrl)
+			tc.buildStubs(store, client)
+
+			server := newTestServer(t, store, client, nil)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("%s/jobs/%d/similar", BaseUrl, tc.jobID)
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			if tc.size != 0 {
+				q := req.URL.Query()
+				q.Add("size", fmt.Sprintf("%d", tc.size))
+				req.URL.RawQuery = q.Encode()
+			}
+
+			server.router.ServeHTTP(recorder, req)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestSharedSkills(t *testing.T) {
+	require.Equal(t, []string{"Docker", "Go"}, sharedSkills([]string{"Go", "Docker", "SQL"}, []string{"Docker", "Go", "Go"}))
+	require.Equal(t, []string{}, sharedSkills([]string{"Go"}, nil))
+}
+
+func requireBodySimilarJobs(t *testing.T, recorder *httptest.ResponseRecorder) []similarJobResponse {
+	data, err := io.ReadAll(recorder.Body)
+	require.NoError(t, err)
+
+	var similarJobs []similarJobResponse
+	err = json.Unmarshal(data, &similarJobs)
+	require.NoError(t, err)
+
+	return similarJobs
+}
@@ -0,0 +1,174 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	db "github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/aalug/job-finder-go/pkg/token"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+var (
+	jobNotOpenError        = errors.New("job is not open for applications")
+	closesAtInPastError    = errors.New("closes_at must be in the future")
+	jobClosesAtPassedError = errors.New("closes_at of the job has passed, provide a new one to publish it again")
+)
+
+// jobStatusTransitions lists the statuses that a job can be moved to from each status.
+// Jobs expire when their closes_at passes, they cannot be expired manually.
+// Closed jobs cannot be changed anymore.
+var jobStatusTransitions = map[db.JobStatus][]db.JobStatus{
+	db.JobStatusDraft:     {db.JobStatusPublished, db.JobStatusClosed},
+	db.JobStatusPublished: {db.JobStatusPaused, db.JobStatusClosed},
+	db.JobStatusPaused:    {db.JobStatusPublished, db.JobStatusClosed},
+	db.JobStatusExpired:   {db.JobStatusPublished, db.JobStatusClosed},
+}
+
+// canChangeJobStatus checks if a job can be moved from one status to the other
+func canChangeJobStatus(from, to db.JobStatus) bool {
+	for _, status := range jobStatusTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isJobOpen checks if the job is published and its closes_at has not passed
+func isJobOpen(job db.Job, now time.Time) bool {
+	if job.Status != db.JobStatusPublished {
+		return false
+	}
+
+	return !job.ClosesAt.Valid || job.ClosesAt.Time.After(now)
+}
+
+type changeJobStatusUriRequest struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+type changeJobStatusRequest struct {
+	Status   db.JobStatus `json:"status" binding:"required,oneof=published paused closed"`
+	ClosesAt *time.Time   `json:"closes_at"`
+}
+
+// @Schemes
+// @Summary Change job status
+// @Description Change the status of the job with the given id. Allowed changes: draft -> published or closed, published -> paused or closed, paused -> published or closed, expired -> published (with a new closes_at) or closed. Closed jobs cannot be changed. Only published jobs are listed and can be applied for.
+// @Tags jobs
+// @Param id path integer true "Job ID"
+// @Param ChangeJobStatusRequest body changeJobStatusRequest true "New status (published, paused, closed) and optional closes_at"
+// @Accept json
+// @Produce json
+// @Success 200 {object} jobResponse
+// @Failure 400 {object} ErrorResponse "Invalid request body or status change not allowed"
+// @Failure 401 {object} ErrorResponse "Employer not the owner of the job"
+// @Failure 403 {object} ErrorResponse "Only employers can access this endpoint, not users. Viewers of the company cannot change job status."
+// @Failure 404 {object} ErrorResponse "Job not found"
+// @Failure 500 {object} ErrorResponse "Any other error"
+// @Security ApiKeyAuth
+// @Router /jobs/{id}/status [patch]
+// changeJobStatus handles publishing, pausing and closing job postings
+func (server *Server) changeJobStatus(ctx *gin.Context) {
+	var uriRequest changeJobStatusUriRequest
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var request changeJobStatusRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	now := time.Now()
+	if request.ClosesAt != nil && !request.ClosesAt.After(now) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(closesAtInPastError))
+		return
+	}
+
+	// get employer that is making the request
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	authEmployer, err := server.store.GetEmployerByEmail(ctx, authPayload.Email)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(accountNotFoundError))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	job, err := server.store.GetJob(ctx, uriRequest.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// check if job is owned by the employer
+	if job.CompanyID != authEmployer.CompanyID {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(jobOwnershipError))
+		return
+	}
+
+	// viewers can only read the jobs of the company
+	if !hasCompanyRole(authEmployer, db.CompanyRoleRecruiter) {
+		ctx.JSON(http.StatusForbidden, errorResponse(companyRoleError(db.CompanyRoleRecruiter)))
+		return
+	}
+
+	if !canChangeJobStatus(job.Status, request.Status) {
+		err = fmt.Errorf("cannot change job status from %s to %s", job.Status, request.Status)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// a job cannot be published again with closes_at that has already passed
+	if request.Status == db.JobStatusPublished &&
+		request.ClosesAt == nil &&
+		job.ClosesAt.Valid && !job.ClosesAt.Time.After(now) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(jobClosesAtPassedError))
+		return
+	}
+
+	params := db.UpdateJobStatusParams{
+		ID:     job.ID,
+		Status: request.Status,
+	}
+	if request.ClosesAt != nil {
+		params.ClosesAt = sql.NullTime{
+			Time:  *request.ClosesAt,
+			Valid: true,
+		}
+	}
+
+	// the job is added to or removed from the elasticsearch index through the search outbox
+	job, err = server.store.UpdateJobStatusTx(ctx, params)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	jobSkills, err := server.store.ListJobSkillsByJobID(ctx, db.ListJobSkillsByJobIDParams{
+		JobID:  job.ID,
+		Limit:  10,
+		Offset: 0,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.syncSearchIndex(ctx)
+
+	ctx.JSON(http.StatusOK, newJobResponse(job, jobSkills))
+}
@@ -0,0 +1,565 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	mockdb "github.com/aalug/job-finder-go/internal/db/mock"
+	db "github.com/aalug/job-finder-go/internal/db/sqlc"
+	mockworker "github.com/aalug/job-finder-go/internal/worker/mock"
+	"github.com/aalug/job-finder-go/pkg/token"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCanChangeJobStatus(t *testing.T) {
+	testCases := []struct {
+		from db.JobStatus
+		to   db.JobStatus
+		ok   bool
+	}{
+		{db.JobStatusDraft, db.JobStatusPublished, true},
+		{db.JobStatusDraft, db.JobStatusClosed, true},
+		{db.JobStatusDraft, db.JobStatusPaused, false},
+		{db.JobStatusPublished, db.JobStatusPaused, true},
+		{db.JobStatusPublished, db.JobStatusClosed, true},
+		{db.JobStatusPublished, db.JobStatusDraft, false},
+		{db.JobStatusPublished, db.JobStatusExpired, false},
+		{db.JobStatusPaused, db.JobStatusPublished, true},
+		{db.JobStatusPaused, db.JobStatusClosed, true},
+		{db.JobStatusExpired, db.JobStatusPublished, true},
+		{db.JobStatusExpired, db.JobStatusPaused, false},
+		{db.JobStatusClosed, db.JobStatusPublished, false},
+		{db.JobStatusClosed, db.JobStatusPaused, false},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.ok, canChangeJobStatus(tc.from, tc.to), "%s -> %s", tc.from, tc.to)
+	}
+}
+
+func TestIsJobOpen(t *testing.T) {
+	now := time.Now()
+	job := generateRandomJob()
+	require.True(t, isJobOpen(job, now))
+
+	job.ClosesAt = sql.NullTime{Time: now.Add(time.Hour), Valid: true}
+	require.True(t, isJobOpen(job, now))
+
+	job.ClosesAt = sql.NullTime{Time: now.Add(-time.Hour), Valid: true}
+	require.False(t, isJobOpen(job, now))
+
+	job.ClosesAt = sql.NullTime{}
+	for _, status := range []db.JobStatus{db.JobStatusDraft, db.JobStatusPaused, db.JobStatusClosed, db.JobStatusExpired} {
+		job.Status = status
+		require.False(t, isJobOpen(job, now))
+	}
+}
+
+func TestChangeJobStatusAPI(t *testing.T) {
+	employer, _, _ := generateRandomEmployerAndCompany(t)
+	employer2, _, _ := generateRandomEmployerAndCompany(t)
+	viewer := employer
+	viewer.Role = db.CompanyRoleViewer
+
+	job := generateRandomJob()
+	job.CompanyID = employer.CompanyID
+
+	pausedJob := job
+	pausedJob.Status = db.JobStatusPaused
+	draftJob := job
+	draftJob.Status = db.JobStatusDraft
+	draftJob.PublishedAt = sql.NullTime{}
+	closedJob := job
+	closedJob.Status = db.JobStatusClosed
+	expiredJob := job
+	expiredJob.Status = db.JobStatusExpired
+	expiredJob.ClosesAt = sql.NullTime{
+		Time:  time.Now().Add(-time.Hour),
+		Valid: true,
+	}
+
+	closesAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+
+	jobSkills := []db.ListJobSkillsByJobIDRow{
+		{
+			ID:    utils.RandomInt(1, 100),
+			Skill: utils.RandomString(5),
+		},
+	}
+	listSkillsParams := db.ListJobSkillsByJobIDParams{
+		JobID:  job.ID,
+		Limit:  10,
+		Offset: 0,
+	}
+
+	testCases := []struct {
+		name          string
+		jobID         int32
+		body          gin.H
+		setupAuth     func(t *testing.T, r *http.Request, maker token.Maker)
+		buildStubs    func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK Publish Draft",
+			jobID: job.ID,
+			body: gin.H{
+				"status": db.JobStatusPublished,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					GetJob(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(draftJob, nil)
+				params := db.UpdateJobStatusParams{
+					ID:     job.ID,
+					Status: db.JobStatusPublished,
+				}
+				store.EXPECT().
+					UpdateJobStatusTx(gomock.Any(), gomock.Eq(params)).
+					Times(1).
+					Return(job, nil)
+				store.EXPECT().
+					ListJobSkillsByJobID(gomock.Any(), gomock.Eq(listSkillsParams)).
+					Times(1).
+					Return(jobSkills, nil)
+				distributor.EXPECT().
+					DistributeTaskProcessSearchOutbox(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchJob(t, recorder.Body, job, jobSkills)
+			},
+		},
+		{
+			name:  "OK Pause",
+			jobID: job.ID,
+			body: gin.H{
+				"status": db.JobStatusPaused,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					GetJob(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(job, nil)
+				params := db.UpdateJobStatusParams{
+					ID:     job.ID,
+					Status: db.JobStatusPaused,
+				}
+				store.EXPECT().
+					UpdateJobStatusTx(gomock.Any(), gomock.Eq(params)).
+					Times(1).
+					Return(pausedJob, nil)
+				store.EXPECT().
+					ListJobSkillsByJobID(gomock.Any(), gomock.Eq(listSkillsParams)).
+					Times(1).
+					Return(jobSkills, nil)
+				distributor.EXPECT().
+					DistributeTaskProcessSearchOutbox(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchJob(t, recorder.Body, pausedJob, jobSkills)
+			},
+		},
+		{
+			name:  "OK Close Paused",
+			jobID: job.ID,
+			body: gin.H{
+				"status": db.JobStatusClosed,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					GetJob(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(pausedJob, nil)
+				store.EXPECT().
+					UpdateJobStatusTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(closedJob, nil)
+				store.EXPECT().
+					ListJobSkillsByJobID(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(jobSkills, nil)
+				distributor.EXPECT().
+					DistributeTaskProcessSearchOutbox(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchJob(t, recorder.Body, closedJob, jobSkills)
+			},
+		},
+		{
+			name:  "OK Publish Expired With New Closes At",
+			jobID: job.ID,
+			body: gin.H{
+				"status":    db.JobStatusPublished,
+				"closes_at": closesAt,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					GetJob(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(expiredJob, nil)
+				params := db.UpdateJobStatusParams{
+					ID:     job.ID,
+					Status: db.JobStatusPublished,
+					ClosesAt: sql.NullTime{
+						Time:  closesAt,
+						Valid: true,
+					},
+				}
+				store.EXPECT().
+					UpdateJobStatusTx(gomock.Any(), gomock.Eq(params)).
+					Times(1).
+					Return(job, nil)
+				store.EXPECT().
+					ListJobSkillsByJobID(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(jobSkills, nil)
+				distributor.EXPECT().
+					DistributeTaskProcessSearchOutbox(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "Expired Without New Closes At",
+			jobID: job.ID,
+			body: gin.H{
+				"status": db.JobStatusPublished,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					GetJob(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(expiredJob, nil)
+				store.EXPECT().
+					UpdateJobStatusTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Transition Not Allowed",
+			jobID: job.ID,
+			body: gin.H{
+				"status": db.JobStatusPublished,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					GetJob(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(closedJob, nil)
+				store.EXPECT().
+					UpdateJobStatusTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Invalid Status",
+			jobID: job.ID,
+			body: gin.H{
+				"status": db.JobStatusExpired,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					UpdateJobStatusTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Closes At In The Past",
+			jobID: job.ID,
+			body: gin.H{
+				"status":    db.JobStatusPublished,
+				"closes_at": time.Now().Add(-time.Hour),
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					UpdateJobStatusTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Not Found",
+			jobID: job.ID,
+			body: gin.H{
+				"status": db.JobStatusPaused,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					GetJob(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(db.Job{}, sql.ErrNoRows)
+				store.EXPECT().
+					UpdateJobStatusTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:  "Unauthorized Not Owner",
+			jobID: job.ID,
+			body: gin.H{
+				"status": db.JobStatusPaused,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer2.Email, token.AccountTypeEmployer, employer2.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer2.Email)).
+					Times(1).
+					Return(employer2, nil)
+				store.EXPECT().
+					GetJob(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(job, nil)
+				store.EXPECT().
+					UpdateJobStatusTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:  "Forbidden Viewer",
+			jobID: job.ID,
+			body: gin.H{
+				"status": db.JobStatusPaused,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, viewer.Email, token.AccountTypeEmployer, viewer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(viewer.Email)).
+					Times(1).
+					Return(viewer, nil)
+				store.EXPECT().
+					GetJob(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(job, nil)
+				store.EXPECT().
+					UpdateJobStatusTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "Forbidden User",
+			jobID: job.ID,
+			body: gin.H{
+				"status": db.JobStatusPaused,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeUser, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					UpdateJobStatusTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "Internal Server Error UpdateJobStatus",
+			jobID: job.ID,
+			body: gin.H{
+				"status": db.JobStatusPaused,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					GetJob(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(job, nil)
+				store.EXPECT().
+					UpdateJobStatusTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Job{}, sql.ErrConnDone)
+				distributor.EXPECT().
+					DistributeTaskProcessSearchOutbox(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:  "OK DistributeTaskProcessSearchOutbox Error",
+			jobID: job.ID,
+			body: gin.H{
+				"status": db.JobStatusPublished,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					GetJob(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(pausedJob, nil)
+				store.EXPECT().
+					UpdateJobStatusTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(job, nil)
+				store.EXPECT().
+					ListJobSkillsByJobID(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(jobSkills, nil)
+				distributor.EXPECT().
+					DistributeTaskProcessSearchOutbox(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("some error"))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockworker.NewMockTaskDistributor(taskCtrl)
+
+			tc.buildStubs(store, taskDistributor)
+
+			server := newTestServer(t, store, nil, taskDistributor)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf(BaseUrl+"/jobs/%d/status", tc.jobID)
+			req, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, req, server.tokenMaker)
+
+			server.router.ServeHTTP(recorder, req)
+
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -10,6 +10,9 @@ import (
 	db "github.com/aalug/job-finder-go/internal/db/sqlc"
 	"github.com/aalug/job-finder-go/internal/esearch"
 	mockesearch "github.com/aalug/job-finder-go/internal/esearch/mock"
+	"github.com/aalug/job-finder-go/internal/geo"
+	"github.com/aalug/job-finder-go/internal/salary"
+	mockworker "github.com/aalug/job-finder-go/internal/worker/mock"
 	"github.com/aalug/job-finder-go/pkg/token"
 	"github.com/aalug/job-finder-go/pkg/utils"
 	"github.com/gin-gonic/gin"
@@ -18,16 +21,92 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
-	"strconv"
+	"reflect"
 	"testing"
 	"time"
 )
 
+type eqCreateJobTxParamsMatcher struct {
+	arg db.CreateJobTxParams
+}
+
+func (e eqCreateJobTxParamsMatcher) Matches(x interface{}) bool {
+	actualArg, ok := x.(db.CreateJobTxParams)
+	if !ok {
+		return false
+	}
+
+	// published_at is set to the time of the request
+	publishedAt := actualArg.PublishedAt
+	if publishedAt.Valid != (e.arg.Status == db.JobStatusPublished) {
+		return false
+	}
+	if publishedAt.Valid && time.Since(publishedAt.Time) > time.Minute {
+		return false
+	}
+
+	e.arg.PublishedAt = publishedAt
+	return reflect.DeepEqual(e.arg, actualArg)
+}
+
+func (e eqCreateJobTxParamsMatcher) String() string {
+	return fmt.Sprintf("matches arg %v", e.arg)
+}
+
+func EqCreateJobTxParams(arg db.CreateJobParams, skills []string) gomock.Matcher {
+	return eqCreateJobTxParamsMatcher{db.CreateJobTxParams{
+		CreateJobParams: arg,
+		Skills:          skills,
+	}}
+}
+
+type eqUpdateJobTxSkillsMatcher struct {
+	skillIDsToRemove []int32
+	skillsToAdd      []string
+}
+
+func (e eqUpdateJobTxSkillsMatcher) Matches(x interface{}) bool {
+	actualArg, ok := x.(db.UpdateJobTxParams)
+	if !ok {
+		return false
+	}
+
+	return reflect.DeepEqual(e.skillIDsToRemove, actualArg.SkillIDsToRemove) &&
+		reflect.DeepEqual(e.skillsToAdd, actualArg.SkillsToAdd)
+}
+
+func (e eqUpdateJobTxSkillsMatcher) String() string {
+	return fmt.Sprintf("matches skills to remove %v and skills to add %v", e.skillIDsToRemove, e.skillsToAdd)
+}
+
+// EqUpdateJobTxSkills matches the update job tx params with the skills, other params are not checked
+func EqUpdateJobTxSkills(skillIDsToRemove []int32, skillsToAdd []string) gomock.Matcher {
+	return eqUpdateJobTxSkillsMatcher{skillIDsToRemove, skillsToAdd}
+}
+
+// withJobAttribute returns a copy of the request body with the key set to the value,
+// the key is removed if the value is nil
+func withJobAttribute(body gin.H, key string, value interface{}) gin.H {
+	newBody := gin.H{}
+	for k, v := range body {
+		newBody[k] = v
+	}
+	if value == nil {
+		delete(newBody, key)
+	} else {
+		newBody[key] = value
+	}
+
+	return newBody
+}
+
 func TestCreateJobAPI(t *testing.T) {
-	employer, _, company := generateRandomEmployerAndCompany(t)
+	employer, _, _ := generateRandomEmployerAndCompany(t)
 
 	job := generateRandomJob()
 
+	viewer := employer
+	viewer.Role = db.CompanyRoleViewer
 	requiredSkills := []string{"skill1", "skill2"}
 	var jobSkills []db.ListJobSkillsByJobIDRow
 	for _, skill := range requiredSkills {
@@ -47,44 +126,69 @@ func TestCreateJobAPI(t *testing.T) {
 		"salary_max":      job.SalaryMax,
 		"requirements":    job.Requirements,
 		"required_skills": requiredSkills,
+		"status":          db.JobStatusPublished,
+		"employment_type": job.EmploymentType,
+		"seniority_level": job.SeniorityLevel,
+		"remote_policy":   job.RemotePolicy,
+		"salary_currency": job.SalaryCurrency,
+		"salary_period":   job.SalaryPeriod,
+	}
+
+	draftJob := job
+	draftJob.Status = db.JobStatusDraft
+	draftJob.PublishedAt = sql.NullTime{}
+	draftRequestBody := gin.H{}
+	for k, v := range requestBody {
+		draftRequestBody[k] = v
 	}
+	delete(draftRequestBody, "status")
 
 	testCases := []struct {
 		name          string
 		body          gin.H
 		setupAuth     func(t *testing.T, r *http.Request, maker token.Maker)
-		buildStubs    func(store *mockdb.MockStore, client *mockesearch.MockESearchClient)
+		buildStubs    func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor)
 		checkResponse func(recorder *httptest.ResponseRecorder)
 	}{
 		{
 			name: "OK",
 			body: requestBody,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
 					Return(employer, nil)
 				params := db.CreateJobParams{
-					Title:        job.Title,
-					Industry:     job.Industry,
-					CompanyID:    employer.CompanyID,
-					Description:  job.Description,
-					Location:     job.Location,
-					SalaryMin:    job.SalaryMin,
-					SalaryMax:    job.SalaryMax,
-					Requirements: job.Requirements,
+					Title:           job.Title,
+					Industry:        job.Industry,
+					CompanyID:       employer.CompanyID,
+					Description:     job.Description,
+					Location:        job.Location,
+					SalaryMin:       job.SalaryMin,
+					SalaryMax:       job.SalaryMax,
+					Requirements:    job.Requirements,
+					Status:          db.JobStatusPublished,
+					EmploymentType:  job.EmploymentType,
+					SeniorityLevel:  job.SeniorityLevel,
+					RemotePolicy:    job.RemotePolicy,
+					SalaryCurrency:  job.SalaryCurrency,
+					SalaryPeriod:    job.SalaryPeriod,
+					SalaryMinAnnual: job.SalaryMinAnnual,
+					SalaryMaxAnnual: job.SalaryMaxAnnual,
+					Latitude:        job.Latitude,
+					Longitude:       job.Longitude,
 				}
 				store.EXPECT().
-					CreateJob(gomock.Any(), gomock.Eq(params)).
+					GetCurrencyRate(gomock.Any(), gomock.Eq(eurRate.Currency)).
 					Times(1).
-					Return(job, nil)
+					Return(eurRate, nil)
 				store.EXPECT().
-					CreateMultipleJobSkills(gomock.Any(), gomock.Eq(requiredSkills), gomock.Eq(job.ID)).
+					CreateJobTx(gomock.Any(), EqCreateJobTxParams(params, requiredSkills)).
 					Times(1).
-					Return(nil)
+					Return(db.CreateJobTxResult{Job: job}, nil)
 				listSkillsParams := db.ListJobSkillsByJobIDParams{
 					JobID:  job.ID,
 					Limit:  10,
@@ -94,24 +198,8 @@ func TestCreateJobAPI(t *testing.T) {
 					ListJobSkillsByJobID(gomock.Any(), gomock.Eq(listSkillsParams)).
 					Times(1).
 					Return(jobSkills, nil)
-				store.EXPECT().
-					GetCompanyNameByID(gomock.Any(), gomock.Eq(employer.CompanyID)).
-					Times(1).
-					Return(company.Name, nil)
-				j := esearch.Job{
-					ID:           job.ID,
-					Title:        job.Title,
-					Industry:     job.Industry,
-					CompanyName:  company.Name,
-					Description:  job.Description,
-					Location:     job.Location,
-					SalaryMin:    job.SalaryMin,
-					SalaryMax:    job.SalaryMax,
-					Requirements: job.Requirements,
-					JobSkills:    requiredSkills,
-				}
-				client.EXPECT().
-					IndexJobAsDocument(gomock.Eq(1), gomock.Eq(j)).
+				distributor.EXPECT().
+					DistributeTaskProcessSearchOutbox(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(nil)
 			},
@@ -121,86 +209,227 @@ func TestCreateJobAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "Internal Server Error ListJobSkillsByJobID",
-			body: requestBody,
+			name: "OK Draft",
+			body: draftRequestBody,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
 					Return(employer, nil)
 				params := db.CreateJobParams{
-					Title:        job.Title,
-					Industry:     job.Industry,
-					CompanyID:    employer.CompanyID,
-					Description:  job.Description,
-					Location:     job.Location,
-					SalaryMin:    job.SalaryMin,
-					SalaryMax:    job.SalaryMax,
-					Requirements: job.Requirements,
+					Title:           job.Title,
+					Industry:        job.Industry,
+					CompanyID:       employer.CompanyID,
+					Description:     job.Description,
+					Location:        job.Location,
+					SalaryMin:       job.SalaryMin,
+					SalaryMax:       job.SalaryMax,
+					Requirements:    job.Requirements,
+					Status:          db.JobStatusDraft,
+					EmploymentType:  job.EmploymentType,
+					SeniorityLevel:  job.SeniorityLevel,
+					RemotePolicy:    job.RemotePolicy,
+					SalaryCurrency:  job.SalaryCurrency,
+					SalaryPeriod:    job.SalaryPeriod,
+					SalaryMinAnnual: job.SalaryMinAnnual,
+					SalaryMaxAnnual: job.SalaryMaxAnnual,
+					Latitude:        job.Latitude,
+					Longitude:       job.Longitude,
 				}
 				store.EXPECT().
-					CreateJob(gomock.Any(), gomock.Eq(params)).
+					GetCurrencyRate(gomock.Any(), gomock.Eq(eurRate.Currency)).
 					Times(1).
-					Return(job, nil)
+					Return(eurRate, nil)
 				store.EXPECT().
-					CreateMultipleJobSkills(gomock.Any(), gomock.Eq(requiredSkills), gomock.Eq(job.ID)).
+					CreateJobTx(gomock.Any(), EqCreateJobTxParams(params, requiredSkills)).
 					Times(1).
-					Return(nil)
+					Return(db.CreateJobTxResult{Job: draftJob}, nil)
 				store.EXPECT().
 					ListJobSkillsByJobID(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return([]db.ListJobSkillsByJobIDRow{}, sql.ErrConnDone)
+					Return(jobSkills, nil)
+				distributor.EXPECT().
+					DistributeTaskProcessSearchOutbox(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				requireBodyMatchJob(t, recorder.Body, draftJob, jobSkills)
+			},
+		},
+		{
+			name: "Closes At In The Past",
+			body: gin.H{
+				"title":           job.Title,
+				"description":     job.Description,
+				"industry":        job.Industry,
+				"location":        job.Location,
+				"salary_min":      job.SalaryMin,
+				"salary_max":      job.SalaryMax,
+				"requirements":    job.Requirements,
+				"required_skills": requiredSkills,
+				"closes_at":       time.Now().Add(-time.Hour),
+				"employment_type": job.EmploymentType,
+				"seniority_level": job.SeniorityLevel,
+				"remote_policy":   job.RemotePolicy,
+				"salary_currency": job.SalaryCurrency,
+				"salary_period":   job.SalaryPeriod,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
-					GetCompanyNameByID(gomock.Any(), gomock.Any()).
+					GetEmployerByEmail(gomock.Any(), gomock.Any()).
 					Times(0)
-				client.EXPECT().
-					IndexJobAsDocument(gomock.Any(), gomock.Any()).
+				store.EXPECT().
+					CreateJobTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Invalid Employment Type",
+			body: withJobAttribute(requestBody, "employment_type", "freelance"),
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					CreateJobTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Invalid Salary Currency",
+			body: withJobAttribute(requestBody, "salary_currency", "EURO"),
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					CreateJobTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Invalid Salary Period",
+			body: withJobAttribute(requestBody, "salary_period", "quarter"),
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					CreateJobTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "No Seniority Level",
+			body: withJobAttribute(requestBody, "seniority_level", nil),
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					CreateJobTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden Viewer",
+			body: requestBody,
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, viewer.Email, token.AccountTypeEmployer, viewer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(viewer.Email)).
+					Times(1).
+					Return(viewer, nil)
+				store.EXPECT().
+					CreateJobTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
 			},
 		},
 		{
-			name: "Internal Server Error CreateMultipleJobSkills",
+			name: "Internal Server Error ListJobSkillsByJobID",
 			body: requestBody,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
 					Return(employer, nil)
 				params := db.CreateJobParams{
-					Title:        job.Title,
-					Industry:     job.Industry,
-					CompanyID:    employer.CompanyID,
-					Description:  job.Description,
-					Location:     job.Location,
-					SalaryMin:    job.SalaryMin,
-					SalaryMax:    job.SalaryMax,
-					Requirements: job.Requirements,
+					Title:           job.Title,
+					Industry:        job.Industry,
+					CompanyID:       employer.CompanyID,
+					Description:     job.Description,
+					Location:        job.Location,
+					SalaryMin:       job.SalaryMin,
+					SalaryMax:       job.SalaryMax,
+					Requirements:    job.Requirements,
+					Status:          db.JobStatusPublished,
+					EmploymentType:  job.EmploymentType,
+					SeniorityLevel:  job.SeniorityLevel,
+					RemotePolicy:    job.RemotePolicy,
+					SalaryCurrency:  job.SalaryCurrency,
+					SalaryPeriod:    job.SalaryPeriod,
+					SalaryMinAnnual: job.SalaryMinAnnual,
+					SalaryMaxAnnual: job.SalaryMaxAnnual,
+					Latitude:        job.Latitude,
+					Longitude:       job.Longitude,
 				}
 				store.EXPECT().
-					CreateJob(gomock.Any(), gomock.Eq(params)).
+					GetCurrencyRate(gomock.Any(), gomock.Eq(eurRate.Currency)).
 					Times(1).
-					Return(job, nil)
+					Return(eurRate, nil)
 				store.EXPECT().
-					CreateMultipleJobSkills(gomock.Any(), gomock.Any(), gomock.Any()).
+					CreateJobTx(gomock.Any(), EqCreateJobTxParams(params, requiredSkills)).
 					Times(1).
-					Return(sql.ErrConnDone)
+					Return(db.CreateJobTxResult{Job: job}, nil)
 				store.EXPECT().
 					ListJobSkillsByJobID(gomock.Any(), gomock.Any()).
-					Times(0)
-				store.EXPECT().
-					GetCompanyNameByID(gomock.Any(), gomock.Any()).
-					Times(0)
-				client.EXPECT().
-					IndexJobAsDocument(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListJobSkillsByJobIDRow{}, sql.ErrConnDone)
+				distributor.EXPECT().
+					DistributeTaskProcessSearchOutbox(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -208,62 +437,45 @@ func TestCreateJobAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "Internal Server Error CreateJob",
-			body: requestBody,
+			name: "Unsupported Salary Currency",
+			body: withJobAttribute(requestBody, "salary_currency", "XAU"),
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
 					Return(employer, nil)
 				store.EXPECT().
-					CreateJob(gomock.Any(), gomock.Any()).
+					GetCurrencyRate(gomock.Any(), gomock.Eq("XAU")).
 					Times(1).
-					Return(db.Job{}, sql.ErrConnDone)
-				store.EXPECT().
-					CreateMultipleJobSkills(gomock.Any(), gomock.Any(), gomock.Any()).
-					Times(0)
-				store.EXPECT().
-					ListJobSkillsByJobID(gomock.Any(), gomock.Any()).
-					Times(0)
+					Return(db.CurrencyRate{}, sql.ErrNoRows)
 				store.EXPECT().
-					GetCompanyNameByID(gomock.Any(), gomock.Any()).
-					Times(0)
-				client.EXPECT().
-					IndexJobAsDocument(gomock.Any(), gomock.Any()).
+					CreateJobTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
 		{
-			name: "Internal Server Error GetEmployerByEmail",
+			name: "Internal Server Error GetCurrencyRate",
 			body: requestBody,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
-					GetEmployerByEmail(gomock.Any(), gomock.Any()).
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
-					Return(db.Employer{}, sql.ErrConnDone)
-				store.EXPECT().
-					CreateJob(gomock.Any(), gomock.Any()).
-					Times(0)
-				store.EXPECT().
-					CreateMultipleJobSkills(gomock.Any(), gomock.Any(), gomock.Any()).
-					Times(0)
+					Return(employer, nil)
 				store.EXPECT().
-					ListJobSkillsByJobID(gomock.Any(), gomock.Any()).
-					Times(0)
+					GetCurrencyRate(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CurrencyRate{}, sql.ErrConnDone)
 				store.EXPECT().
-					GetCompanyNameByID(gomock.Any(), gomock.Any()).
-					Times(0)
-				client.EXPECT().
-					IndexJobAsDocument(gomock.Any(), gomock.Any()).
+					CreateJobTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -271,34 +483,48 @@ func TestCreateJobAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "Internal Server Error GetCompanyNameByID",
+			name: "Internal Server Error CreateJobTx",
 			body: requestBody,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
 					Return(employer, nil)
 				store.EXPECT().
-					CreateJob(gomock.Any(), gomock.Any()).
+					GetCurrencyRate(gomock.Any(), gomock.Eq(eurRate.Currency)).
 					Times(1).
-					Return(job, nil)
+					Return(eurRate, nil)
 				store.EXPECT().
-					CreateMultipleJobSkills(gomock.Any(), gomock.Any(), gomock.Any()).
+					CreateJobTx(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(nil)
+					Return(db.CreateJobTxResult{}, sql.ErrConnDone)
 				store.EXPECT().
 					ListJobSkillsByJobID(gomock.Any(), gomock.Any()).
-					Times(1).
-					Return(jobSkills, nil)
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error GetEmployerByEmail",
+			body: requestBody,
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
-					GetCompanyNameByID(gomock.Any(), gomock.Any()).
+					GetEmployerByEmail(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return("", sql.ErrConnDone)
-				client.EXPECT().
-					IndexJobAsDocument(gomock.Any(), gomock.Any()).
+					Return(db.Employer{}, sql.ErrConnDone)
+				store.EXPECT().
+					CreateJobTx(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					ListJobSkillsByJobID(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -306,39 +532,35 @@ func TestCreateJobAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "Internal Server Error IndexJobAsDocument",
+			name: "OK DistributeTaskProcessSearchOutbox Error",
 			body: requestBody,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
 					Return(employer, nil)
 				store.EXPECT().
-					CreateJob(gomock.Any(), gomock.Any()).
+					GetCurrencyRate(gomock.Any(), gomock.Eq(eurRate.Currency)).
 					Times(1).
-					Return(job, nil)
+					Return(eurRate, nil)
 				store.EXPECT().
-					CreateMultipleJobSkills(gomock.Any(), gomock.Any(), gomock.Any()).
+					CreateJobTx(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(nil)
+					Return(db.CreateJobTxResult{Job: job}, nil)
 				store.EXPECT().
 					ListJobSkillsByJobID(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(jobSkills, nil)
-				store.EXPECT().
-					GetCompanyNameByID(gomock.Any(), gomock.Any()).
-					Times(1).
-					Return(company.Name, nil)
-				client.EXPECT().
-					IndexJobAsDocument(gomock.Any(), gomock.Any()).
+				distributor.EXPECT().
+					DistributeTaskProcessSearchOutbox(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(errors.New("some error"))
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, http.StatusCreated, recorder.Code)
 			},
 		},
 		{
@@ -348,27 +570,18 @@ func TestCreateJobAPI(t *testing.T) {
 				"industry": job.Industry,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Any()).
 					Times(0)
 				store.EXPECT().
-					CreateJob(gomock.Any(), gomock.Any()).
-					Times(0)
-				store.EXPECT().
-					CreateMultipleJobSkills(gomock.Any(), gomock.Any(), gomock.Any()).
+					CreateJobTx(gomock.Any(), gomock.Any()).
 					Times(0)
 				store.EXPECT().
 					ListJobSkillsByJobID(gomock.Any(), gomock.Any()).
 					Times(0)
-				store.EXPECT().
-					GetCompanyNameByID(gomock.Any(), gomock.Any()).
-					Times(0)
-				client.EXPECT().
-					IndexJobAsDocument(gomock.Any(), gomock.Any()).
-					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
@@ -387,27 +600,18 @@ func TestCreateJobAPI(t *testing.T) {
 				"required_skills": requiredSkills,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Any()).
 					Times(0)
 				store.EXPECT().
-					CreateJob(gomock.Any(), gomock.Any()).
-					Times(0)
-				store.EXPECT().
-					CreateMultipleJobSkills(gomock.Any(), gomock.Any(), gomock.Any()).
+					CreateJobTx(gomock.Any(), gomock.Any()).
 					Times(0)
 				store.EXPECT().
 					ListJobSkillsByJobID(gomock.Any(), gomock.Any()).
 					Times(0)
-				store.EXPECT().
-					GetCompanyNameByID(gomock.Any(), gomock.Any()).
-					Times(0)
-				client.EXPECT().
-					IndexJobAsDocument(gomock.Any(), gomock.Any()).
-					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
@@ -422,10 +626,14 @@ func TestCreateJobAPI(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mockdb.NewMockStore(ctrl)
-			client := mockesearch.NewMockESearchClient(ctrl)
-			tc.buildStubs(store, client)
 
-			server := newTestServer(t, store, client, nil)
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockworker.NewMockTaskDistributor(taskCtrl)
+
+			tc.buildStubs(store, taskDistributor)
+
+			server := newTestServer(t, store, nil, taskDistributor)
 			recorder := httptest.NewRecorder()
 
 			data, err := json.Marshal(tc.body)
@@ -451,21 +659,33 @@ func TestDeleteJobAPI(t *testing.T) {
 	// set the company ID to the employer's company ID
 	// so that the job belongs to the employer
 	job.CompanyID = employer.CompanyID
+	viewer := employer
+	viewer.Role = db.CompanyRoleViewer
+
+	draftJob := job
+	draftJob.Status = db.JobStatusDraft
+	draftJob.PublishedAt = sql.NullTime{}
+	closedJob := job
+	closedJob.Status = db.JobStatusClosed
+	closeJobParams := db.UpdateJobStatusParams{
+		ID:     job.ID,
+		Status: db.JobStatusClosed,
+	}
 
 	testCases := []struct {
 		name          string
 		jobID         int32
 		setupAuth     func(t *testing.T, r *http.Request, maker token.Maker)
-		buildStubs    func(store *mockdb.MockStore, client *mockesearch.MockESearchClient)
+		buildStubs    func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor)
 		checkResponse func(recorder *httptest.ResponseRecorder)
 	}{
 		{
-			name:  "OK",
+			name:  "OK Draft",
 			jobID: job.ID,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
@@ -473,120 +693,104 @@ func TestDeleteJobAPI(t *testing.T) {
 				store.EXPECT().
 					GetJob(gomock.Any(), gomock.Eq(job.ID)).
 					Times(1).
-					Return(job, nil)
-				store.EXPECT().
-					DeleteJobSkillsByJobID(gomock.Any(), gomock.Eq(job.ID)).
-					Times(1).
-					Return(nil)
+					Return(draftJob, nil)
 				store.EXPECT().
-					DeleteJob(gomock.Any(), gomock.Eq(job.ID)).
+					DeleteJobPosting(gomock.Any(), gomock.Eq(job.ID)).
 					Times(1).
 					Return(nil)
-				documentID := strconv.Itoa(int(job.ID))
-				client.EXPECT().
-					GetDocumentIDByJobID(gomock.Eq(int(job.ID))).
-					Times(1).
-					Return(documentID, nil)
-				client.EXPECT().
-					DeleteJobDocument(gomock.Eq(documentID)).
+				distributor.EXPECT().
+					DistributeTaskProcessSearchOutbox(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(nil)
+				store.EXPECT().
+					UpdateJobStatusTx(gomock.Any(), gomock.Any()).
+					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusNoContent, recorder.Code)
 			},
 		},
 		{
-			name:  "Unauthorized User",
+			name:  "OK Published",
 			jobID: job.ID,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, "unauthorized@example.com", time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
-					GetEmployerByEmail(gomock.Any(), gomock.Eq("unauthorized@example.com")).
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
-					Return(db.Employer{
-						ID:        employer.ID + 1,
-						CompanyID: employer.CompanyID + 1,
-						Email:     "unauthorized@example.com",
-					}, nil)
+					Return(employer, nil)
 				store.EXPECT().
 					GetJob(gomock.Any(), gomock.Eq(job.ID)).
 					Times(1).
 					Return(job, nil)
 				store.EXPECT().
-					DeleteJobSkillsByJobID(gomock.Any(), gomock.Any()).
+					DeleteJobPosting(gomock.Any(), gomock.Any()).
 					Times(0)
 				store.EXPECT().
-					DeleteJob(gomock.Any(), gomock.Any()).
-					Times(0)
-				client.EXPECT().
-					GetDocumentIDByJobID(gomock.Any()).
-					Times(0)
-				client.EXPECT().
-					DeleteJobDocument(gomock.Any()).
-					Times(0)
+					UpdateJobStatusTx(gomock.Any(), gomock.Eq(closeJobParams)).
+					Times(1).
+					Return(closedJob, nil)
+				distributor.EXPECT().
+					DistributeTaskProcessSearchOutbox(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				require.Equal(t, http.StatusNoContent, recorder.Code)
 			},
 		},
 		{
-			name:  "Invalid Job ID",
-			jobID: 0,
+			name:  "OK Closed",
+			jobID: job.ID,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
-					GetEmployerByEmail(gomock.Any(), gomock.Any()).
-					Times(0)
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(employer, nil)
 				store.EXPECT().
-					GetJob(gomock.Any(), gomock.Any()).
-					Times(0)
+					GetJob(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(closedJob, nil)
 				store.EXPECT().
-					DeleteJobSkillsByJobID(gomock.Any(), gomock.Any()).
+					DeleteJobPosting(gomock.Any(), gomock.Any()).
 					Times(0)
 				store.EXPECT().
-					DeleteJob(gomock.Any(), gomock.Any()).
-					Times(0)
-				client.EXPECT().
-					GetDocumentIDByJobID(gomock.Any()).
+					UpdateJobStatusTx(gomock.Any(), gomock.Any()).
 					Times(0)
-				client.EXPECT().
-					DeleteJobDocument(gomock.Any()).
+				distributor.EXPECT().
+					DistributeTaskProcessSearchOutbox(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Equal(t, http.StatusNoContent, recorder.Code)
 			},
 		},
 		{
-			name:  "Internal Server Error GetEmployerByEmail",
+			name:  "Internal Server Error UpdateJobStatusTx",
 			jobID: job.ID,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
-					GetEmployerByEmail(gomock.Any(), gomock.Any()).
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
-					Return(db.Employer{}, sql.ErrConnDone)
-				store.EXPECT().
-					GetJob(gomock.Any(), gomock.Any()).
-					Times(0)
+					Return(employer, nil)
 				store.EXPECT().
-					DeleteJobSkillsByJobID(gomock.Any(), gomock.Any()).
-					Times(0)
+					GetJob(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(job, nil)
 				store.EXPECT().
-					DeleteJob(gomock.Any(), gomock.Any()).
-					Times(0)
-				client.EXPECT().
-					GetDocumentIDByJobID(gomock.Any()).
-					Times(0)
-				client.EXPECT().
-					DeleteJobDocument(gomock.Any()).
+					UpdateJobStatusTx(gomock.Any(), gomock.Eq(closeJobParams)).
+					Times(1).
+					Return(db.Job{}, sql.ErrConnDone)
+				distributor.EXPECT().
+					DistributeTaskProcessSearchOutbox(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -594,65 +798,92 @@ func TestDeleteJobAPI(t *testing.T) {
 			},
 		},
 		{
-			name:  "Internal Server Error GetJob",
+			name:  "Forbidden Viewer",
 			jobID: job.ID,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, viewer.Email, token.AccountTypeEmployer, viewer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
-					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(viewer.Email)).
 					Times(1).
-					Return(employer, nil)
+					Return(viewer, nil)
 				store.EXPECT().
-					GetJob(gomock.Any(), gomock.Any()).
+					GetJob(gomock.Any(), gomock.Eq(job.ID)).
 					Times(1).
-					Return(db.Job{}, sql.ErrConnDone)
-				store.EXPECT().
-					DeleteJobSkillsByJobID(gomock.Any(), gomock.Any()).
-					Times(0)
+					Return(job, nil)
 				store.EXPECT().
-					DeleteJob(gomock.Any(), gomock.Any()).
-					Times(0)
-				client.EXPECT().
-					GetDocumentIDByJobID(gomock.Any()).
-					Times(0)
-				client.EXPECT().
-					DeleteJobDocument(gomock.Any()).
+					DeleteJobPosting(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, http.StatusForbidden, recorder.Code)
 			},
 		},
 		{
-			name:  "Internal Server Error DeleteJob",
+			name:  "Unauthorized User",
 			jobID: job.ID,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, "unauthorized@example.com", token.AccountTypeEmployer, employer.ID+1, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
-					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					GetEmployerByEmail(gomock.Any(), gomock.Eq("unauthorized@example.com")).
 					Times(1).
-					Return(employer, nil)
+					Return(db.Employer{
+						ID:        employer.ID + 1,
+						CompanyID: employer.CompanyID + 1,
+						Email:     "unauthorized@example.com",
+					}, nil)
 				store.EXPECT().
 					GetJob(gomock.Any(), gomock.Eq(job.ID)).
 					Times(1).
 					Return(job, nil)
 				store.EXPECT().
-					DeleteJobSkillsByJobID(gomock.Any(), gomock.Eq(job.ID)).
-					Times(1).
-					Return(nil)
+					DeleteJobPosting(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:  "Invalid Job ID",
+			jobID: 0,
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					GetJob(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					DeleteJobPosting(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Internal Server Error GetEmployerByEmail",
+			jobID: job.ID,
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
-					DeleteJob(gomock.Any(), gomock.Any()).
+					GetEmployerByEmail(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(sql.ErrConnDone)
-				client.EXPECT().
-					GetDocumentIDByJobID(gomock.Any()).
+					Return(db.Employer{}, sql.ErrConnDone)
+				store.EXPECT().
+					GetJob(gomock.Any(), gomock.Any()).
 					Times(0)
-				client.EXPECT().
-					DeleteJobDocument(gomock.Any()).
+				store.EXPECT().
+					DeleteJobPosting(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -660,34 +891,22 @@ func TestDeleteJobAPI(t *testing.T) {
 			},
 		},
 		{
-			name:  "Internal Server Error GetDocumentIDByJobID",
+			name:  "Internal Server Error GetJob",
 			jobID: job.ID,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
 					Return(employer, nil)
 				store.EXPECT().
-					GetJob(gomock.Any(), gomock.Eq(job.ID)).
-					Times(1).
-					Return(job, nil)
-				store.EXPECT().
-					DeleteJobSkillsByJobID(gomock.Any(), gomock.Eq(job.ID)).
+					GetJob(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(nil)
+					Return(db.Job{}, sql.ErrConnDone)
 				store.EXPECT().
-					DeleteJob(gomock.Any(), gomock.Eq(job.ID)).
-					Times(1).
-					Return(nil)
-				client.EXPECT().
-					GetDocumentIDByJobID(gomock.Eq(int(job.ID))).
-					Times(1).
-					Return("", errors.New("some error"))
-				client.EXPECT().
-					DeleteJobDocument(gomock.Any()).
+					DeleteJobPosting(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -695,12 +914,12 @@ func TestDeleteJobAPI(t *testing.T) {
 			},
 		},
 		{
-			name:  "Internal Server Error DeleteJobDocument",
+			name:  "Internal Server Error DeleteJobPosting",
 			jobID: job.ID,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
@@ -708,36 +927,26 @@ func TestDeleteJobAPI(t *testing.T) {
 				store.EXPECT().
 					GetJob(gomock.Any(), gomock.Eq(job.ID)).
 					Times(1).
-					Return(job, nil)
-				store.EXPECT().
-					DeleteJobSkillsByJobID(gomock.Any(), gomock.Eq(job.ID)).
-					Times(1).
-					Return(nil)
+					Return(draftJob, nil)
 				store.EXPECT().
-					DeleteJob(gomock.Any(), gomock.Eq(job.ID)).
-					Times(1).
-					Return(nil)
-				documentID := strconv.Itoa(int(job.ID))
-				client.EXPECT().
-					GetDocumentIDByJobID(gomock.Eq(int(job.ID))).
+					DeleteJobPosting(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(documentID, nil)
-				client.EXPECT().
-					DeleteJobDocument(gomock.Any()).
-					Times(1).
-					Return(errors.New("some error"))
+					Return(sql.ErrConnDone)
+				distributor.EXPECT().
+					DistributeTaskProcessSearchOutbox(gomock.Any(), gomock.Any()).
+					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
 		{
-			name:  "Not Found",
+			name:  "OK DistributeTaskProcessSearchOutbox Error",
 			jobID: job.ID,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
@@ -745,31 +954,27 @@ func TestDeleteJobAPI(t *testing.T) {
 				store.EXPECT().
 					GetJob(gomock.Any(), gomock.Eq(job.ID)).
 					Times(1).
-					Return(db.Job{}, sql.ErrNoRows)
-				store.EXPECT().
-					DeleteJobSkillsByJobID(gomock.Any(), gomock.Any()).
-					Times(0)
+					Return(job, nil)
 				store.EXPECT().
-					DeleteJob(gomock.Any(), gomock.Any()).
-					Times(0)
-				client.EXPECT().
-					GetDocumentIDByJobID(gomock.Any()).
-					Times(0)
-				client.EXPECT().
-					DeleteJobDocument(gomock.Any()).
-					Times(0)
+					UpdateJobStatusTx(gomock.Any(), gomock.Eq(closeJobParams)).
+					Times(1).
+					Return(closedJob, nil)
+				distributor.EXPECT().
+					DistributeTaskProcessSearchOutbox(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("some error"))
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusNotFound, recorder.Code)
+				require.Equal(t, http.StatusNoContent, recorder.Code)
 			},
 		},
 		{
-			name:  "Internal Server Error DeleteJobSkillsByJobID",
+			name:  "Not Found",
 			jobID: job.ID,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
@@ -777,23 +982,13 @@ func TestDeleteJobAPI(t *testing.T) {
 				store.EXPECT().
 					GetJob(gomock.Any(), gomock.Eq(job.ID)).
 					Times(1).
-					Return(job, nil)
-				store.EXPECT().
-					DeleteJobSkillsByJobID(gomock.Any(), gomock.Eq(job.ID)).
-					Times(1).
-					Return(sql.ErrConnDone)
+					Return(db.Job{}, sql.ErrNoRows)
 				store.EXPECT().
-					DeleteJob(gomock.Any(), gomock.Any()).
-					Times(0)
-				client.EXPECT().
-					GetDocumentIDByJobID(gomock.Any()).
-					Times(0)
-				client.EXPECT().
-					DeleteJobDocument(gomock.Any()).
+					DeleteJobPosting(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, http.StatusNotFound, recorder.Code)
 			},
 		},
 	}
@@ -805,10 +1000,14 @@ func TestDeleteJobAPI(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mockdb.NewMockStore(ctrl)
-			client := mockesearch.NewMockESearchClient(ctrl)
-			tc.buildStubs(store, client)
 
-			server := newTestServer(t, store, client, nil)
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockworker.NewMockTaskDistributor(taskCtrl)
+
+			tc.buildStubs(store, taskDistributor)
+
+			server := newTestServer(t, store, nil, taskDistributor)
 			recorder := httptest.NewRecorder()
 
 			url := fmt.Sprintf(BaseUrl+"/jobs/%d", tc.jobID)
@@ -843,6 +1042,7 @@ func TestGetJobAPI(t *testing.T) {
 		SalaryMax:        job.SalaryMax,
 		Requirements:     job.Requirements,
 		CreatedAt:        job.CreatedAt,
+		Status:           job.Status,
 		CompanyName:      company.Name,
 		CompanyLocation:  company.Location,
 		CompanyIndustry:  company.Industry,
@@ -851,6 +1051,9 @@ func TestGetJobAPI(t *testing.T) {
 		EmployerFullName: employer.FullName,
 	}
 
+	draftJobRow := getJobRow
+	draftJobRow.Status = db.JobStatusDraft
+
 	testCases := []struct {
 		name          string
 		jobID         int32
@@ -871,6 +1074,19 @@ func TestGetJobAPI(t *testing.T) {
 				requireBodyMatchJobDetails(t, recorder.Body, getJobRow)
 			},
 		},
+		{
+			name:  "Not Found Draft",
+			jobID: job.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetJobDetails(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(draftJobRow, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
 		{
 			name:  "Not Found",
 			jobID: job.ID,
@@ -985,15 +1201,25 @@ func TestFilterAndListJobsAPI(t *testing.T) {
 	}
 
 	type Query struct {
-		page        int32
-		pageSize    int32
-		industry    string
-		jobLocation string
-		title       string
-		salaryMin   int32
-		salaryMax   int32
+		page           int32
+		pageSize       int32
+		industry       string
+		jobLocation    string
+		title          string
+		salaryMin      int32
+		salaryMax      int32
+		employmentType string
+		seniorityLevel string
+		remotePolicy   string
+		salaryCurrency string
+		salaryPeriod   string
+		near           string
+		radiusKm       float64
 	}
 
+	berlin, ok := geo.Lookup("Berlin")
+	require.True(t, ok)
+
 	testCases := []struct {
 		name          string
 		query         Query
@@ -1044,11 +1270,131 @@ func TestFilterAndListJobsAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "No Page In Query",
+			name: "OK Job Attributes",
+			query: Query{
+				page:           1,
+				pageSize:       10,
+				employmentType: "contract",
+				seniorityLevel: "senior",
+				remotePolicy:   "hybrid",
+				salaryCurrency: "USD",
+				salaryPeriod:   "hour",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				params := db.ListJobsByFiltersParams{
+					Limit:  10,
+					Offset: 0,
+					EmploymentType: db.NullEmploymentType{
+						EmploymentType: db.EmploymentTypeContract,
+						Valid:          true,
+					},
+					SeniorityLevel: db.NullSeniorityLevel{
+						SeniorityLevel: db.SeniorityLevelSenior,
+						Valid:          true,
+					},
+					RemotePolicy: db.NullRemotePolicy{
+						RemotePolicy: db.RemotePolicyHybrid,
+						Valid:        true,
+					},
+					SalaryCurrency: sql.NullString{
+						String: "USD",
+						Valid:  true,
+					},
+					SalaryPeriod: db.NullSalaryPeriod{
+						SalaryPeriod: db.SalaryPeriodHour,
+						Valid:        true,
+					},
+				}
+				store.EXPECT().
+					ListJobsByFilters(gomock.Any(), gomock.Eq(params)).
+					Times(1).
+					Return(jobs, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchJobs(t, recorder.Body, jobs)
+			},
+		},
+		{
+			name: "OK Near",
 			query: Query{
+				page:        1,
 				pageSize:    10,
-				industry:    industry,
-				jobLocation: jobLocation,
+				jobLocation: "berlin",
+				near:        "Berlin, DE",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				params := db.ListJobsByFiltersParams{
+					Limit:  10,
+					Offset: 0,
+					JobLocation: sql.NullString{
+						String: "Berlin, Germany",
+						Valid:  true,
+					},
+					NearLatitude: sql.NullFloat64{
+						Float64: berlin.Latitude,
+						Valid:   true,
+					},
+					NearLongitude: sql.NullFloat64{
+						Float64: berlin.Longitude,
+						Valid:   true,
+					},
+					RadiusKm: sql.NullFloat64{
+						Float64: defaultRadiusKm,
+						Valid:   true,
+					},
+				}
+				store.EXPECT().
+					ListJobsByFilters(gomock.Any(), gomock.Eq(params)).
+					Times(1).
+					Return(jobs, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchJobs(t, recorder.Body, jobs)
+			},
+		},
+		{
+			name: "OK Near With Radius",
+			query: Query{
+				page:     1,
+				pageSize: 10,
+				near:     "Berlin",
+				radiusKm: 12.5,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				params := db.ListJobsByFiltersParams{
+					Limit:  10,
+					Offset: 0,
+					NearLatitude: sql.NullFloat64{
+						Float64: berlin.Latitude,
+						Valid:   true,
+					},
+					NearLongitude: sql.NullFloat64{
+						Float64: berlin.Longitude,
+						Valid:   true,
+					},
+					RadiusKm: sql.NullFloat64{
+						Float64: 12.5,
+						Valid:   true,
+					},
+				}
+				store.EXPECT().
+					ListJobsByFilters(gomock.Any(), gomock.Eq(params)).
+					Times(1).
+					Return(jobs, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchJobs(t, recorder.Body, jobs)
+			},
+		},
+		{
+			name: "Unknown Near Location",
+			query: Query{
+				page:     1,
+				pageSize: 10,
+				near:     "Atlantis",
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -1060,11 +1406,12 @@ func TestFilterAndListJobsAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "No Page Size In Query",
+			name: "Invalid Radius",
 			query: Query{
-				page:        1,
-				industry:    industry,
-				jobLocation: jobLocation,
+				page:     1,
+				pageSize: 10,
+				near:     "Berlin",
+				radiusKm: 5000,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -1076,12 +1423,11 @@ func TestFilterAndListJobsAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "Invalid Page",
+			name: "Invalid Seniority Level",
 			query: Query{
-				page:        0,
-				pageSize:    10,
-				industry:    industry,
-				jobLocation: jobLocation,
+				page:           1,
+				pageSize:       10,
+				seniorityLevel: "principal",
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -1093,10 +1439,9 @@ func TestFilterAndListJobsAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "Invalid Page Size",
+			name: "No Page In Query",
 			query: Query{
-				page:        1,
-				pageSize:    50,
+				pageSize:    10,
 				industry:    industry,
 				jobLocation: jobLocation,
 			},
@@ -1110,23 +1455,73 @@ func TestFilterAndListJobsAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "Internal Server Error",
+			name: "No Page Size In Query",
 			query: Query{
-				page:     1,
-				pageSize: 10,
-				title:    title,
+				page:        1,
+				industry:    industry,
+				jobLocation: jobLocation,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
 					ListJobsByFilters(gomock.Any(), gomock.Any()).
-					Times(1).
-					Return([]db.ListJobsByFiltersRow{}, sql.ErrConnDone)
+					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
-	}
+		{
+			name: "Invalid Page",
+			query: Query{
+				page:        0,
+				pageSize:    10,
+				industry:    industry,
+				jobLocation: jobLocation,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListJobsByFilters(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Invalid Page Size",
+			query: Query{
+				page:        1,
+				pageSize:    50,
+				industry:    industry,
+				jobLocation: jobLocation,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListJobsByFilters(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error",
+			query: Query{
+				page:     1,
+				pageSize: 10,
+				title:    title,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListJobsByFilters(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListJobsByFiltersRow{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
 	for i := range testCases {
 		tc := testCases[i]
 
@@ -1153,6 +1548,13 @@ func TestFilterAndListJobsAPI(t *testing.T) {
 			q.Add("title", tc.query.title)
 			q.Add("salary_min", fmt.Sprintf("%d", tc.query.salaryMin))
 			q.Add("salary_max", fmt.Sprintf("%d", tc.query.salaryMax))
+			q.Add("employment_type", tc.query.employmentType)
+			q.Add("seniority_level", tc.query.seniorityLevel)
+			q.Add("remote_policy", tc.query.remotePolicy)
+			q.Add("salary_currency", tc.query.salaryCurrency)
+			q.Add("salary_period", tc.query.salaryPeriod)
+			q.Add("near", tc.query.near)
+			q.Add("radius_km", fmt.Sprintf("%g", tc.query.radiusKm))
 			req.URL.RawQuery = q.Encode()
 
 			server.router.ServeHTTP(recorder, req)
@@ -1217,13 +1619,9 @@ func TestListJobsByMatchingSkillsAPI(t *testing.T) {
 				pageSize: 10,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(user, nil)
 				params := db.ListJobsMatchingUserSkillsParams{
 					UserID: user.ID,
 					Limit:  10,
@@ -1247,41 +1645,15 @@ func TestListJobsByMatchingSkillsAPI(t *testing.T) {
 				pageSize: 10,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Eq(employer.Email)).
-					Times(1).
-					Return(db.User{}, sql.ErrNoRows)
-				store.EXPECT().
-					ListJobsMatchingUserSkills(gomock.Any(), gomock.Any()).
-					Times(0)
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusUnauthorized, recorder.Code)
-			},
-		},
-		{
-			name: "Internal Server Error GetUserByEmail",
-			query: Query{
-				page:     1,
-				pageSize: 10,
-			},
-			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Any()).
-					Times(1).
-					Return(db.User{}, sql.ErrConnDone)
 				store.EXPECT().
 					ListJobsMatchingUserSkills(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, http.StatusForbidden, recorder.Code)
 			},
 		},
 		{
@@ -1291,13 +1663,9 @@ func TestListJobsByMatchingSkillsAPI(t *testing.T) {
 				pageSize: 10,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(user, nil)
 				store.EXPECT().
 					ListJobsMatchingUserSkills(gomock.Any(), gomock.Any()).
 					Times(1).
@@ -1314,12 +1682,9 @@ func TestListJobsByMatchingSkillsAPI(t *testing.T) {
 				pageSize: 50,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Any()).
-					Times(0)
 				store.EXPECT().
 					ListJobsMatchingUserSkills(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -1335,12 +1700,9 @@ func TestListJobsByMatchingSkillsAPI(t *testing.T) {
 				pageSize: 10,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Any()).
-					Times(0)
 				store.EXPECT().
 					ListJobsMatchingUserSkills(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -1355,12 +1717,9 @@ func TestListJobsByMatchingSkillsAPI(t *testing.T) {
 				page: 1,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Any()).
-					Times(0)
 				store.EXPECT().
 					ListJobsMatchingUserSkills(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -1375,12 +1734,9 @@ func TestListJobsByMatchingSkillsAPI(t *testing.T) {
 				pageSize: 10,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUserByEmail(gomock.Any(), gomock.Any()).
-					Times(0)
 				store.EXPECT().
 					ListJobsMatchingUserSkills(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -1455,13 +1811,16 @@ func TestUpdateJobAPI(t *testing.T) {
 			Skill: utils.RandomString(5),
 		},
 	}
+	draftJob := newJob
+	draftJob.Status = db.JobStatusDraft
+	draftJob.PublishedAt = sql.NullTime{}
 
 	testCases := []struct {
 		name          string
 		jobID         int32
 		body          gin.H
 		setupAuth     func(t *testing.T, r *http.Request, maker token.Maker)
-		buildStubs    func(store *mockdb.MockStore, client *mockesearch.MockESearchClient)
+		buildStubs    func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor)
 		checkResponse func(recorder *httptest.ResponseRecorder)
 	}{
 		{
@@ -1469,9 +1828,9 @@ func TestUpdateJobAPI(t *testing.T) {
 			jobID: job.ID,
 			body:  requestBody,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
@@ -1481,17 +1840,13 @@ func TestUpdateJobAPI(t *testing.T) {
 					Times(1).
 					Return(job, nil)
 				store.EXPECT().
-					UpdateJob(gomock.Any(), gomock.Any()).
-					Times(1).
-					Return(newJob, nil)
-				store.EXPECT().
-					DeleteMultipleJobSkills(gomock.Any(), gomock.Eq(requiredSkillIDsToRemove)).
+					GetCurrencyRate(gomock.Any(), gomock.Eq(eurRate.Currency)).
 					Times(1).
-					Return(nil)
+					Return(eurRate, nil)
 				store.EXPECT().
-					CreateMultipleJobSkills(gomock.Any(), gomock.Eq(requiredSkillsToAdd), gomock.Eq(newJob.ID)).
+					UpdateJobTx(gomock.Any(), EqUpdateJobTxSkills(requiredSkillIDsToRemove, requiredSkillsToAdd)).
 					Times(1).
-					Return(nil)
+					Return(db.UpdateJobTxResult{Job: newJob}, nil)
 				listSkillsParams := db.ListJobSkillsByJobIDParams{
 					JobID:  newJob.ID,
 					Limit:  10,
@@ -1501,12 +1856,8 @@ func TestUpdateJobAPI(t *testing.T) {
 					ListJobSkillsByJobID(gomock.Any(), gomock.Eq(listSkillsParams)).
 					Times(1).
 					Return(listedSkills, nil)
-				client.EXPECT().
-					GetDocumentIDByJobID(gomock.Any()).
-					Times(1).
-					Return(strconv.Itoa(int(job.ID)), nil)
-				client.EXPECT().
-					UpdateJobDocument(gomock.Any(), gomock.Any()).
+				distributor.EXPECT().
+					DistributeTaskProcessSearchOutbox(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(nil)
 			},
@@ -1516,13 +1867,19 @@ func TestUpdateJobAPI(t *testing.T) {
 			},
 		},
 		{
-			name:  "Not Found",
+			name:  "OK Job Attributes",
 			jobID: job.ID,
-			body:  requestBody,
+			body: gin.H{
+				"employment_type": db.EmploymentTypeContract,
+				"seniority_level": db.SeniorityLevelLead,
+				"remote_policy":   db.RemotePolicyHybrid,
+				"salary_currency": "PLN",
+				"salary_period":   db.SalaryPeriodDay,
+			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
@@ -1530,38 +1887,97 @@ func TestUpdateJobAPI(t *testing.T) {
 				store.EXPECT().
 					GetJob(gomock.Any(), gomock.Eq(job.ID)).
 					Times(1).
-					Return(db.Job{}, sql.ErrNoRows)
-				store.EXPECT().
-					UpdateJob(gomock.Any(), gomock.Any()).
-					Times(0)
+					Return(job, nil)
+				params := db.UpdateJobParams{
+					ID:             job.ID,
+					Title:          job.Title,
+					Industry:       job.Industry,
+					CompanyID:      job.CompanyID,
+					Description:    job.Description,
+					Location:       job.Location,
+					SalaryMin:      job.SalaryMin,
+					SalaryMax:      job.SalaryMax,
+					Requirements:   job.Requirements,
+					ClosesAt:       job.ClosesAt,
+					EmploymentType: db.EmploymentTypeContract,
+					SeniorityLevel: db.SeniorityLevelLead,
+					RemotePolicy:   db.RemotePolicyHybrid,
+					SalaryCurrency: "PLN",
+					SalaryPeriod:   db.SalaryPeriodDay,
+				}
+				// annual salaries are recalculated with the rate of the new currency
+				plnRate := db.CurrencyRate{Currency: "PLN", Rate: 0.25}
+				params.SalaryMinAnnual, params.SalaryMaxAnnual, _ = salary.AnnualizeRange(
+					params.SalaryMin, params.SalaryMax, params.SalaryPeriod, plnRate.Rate)
+				updatedJob := job
+				updatedJob.EmploymentType = params.EmploymentType
+				updatedJob.SeniorityLevel = params.SeniorityLevel
+				updatedJob.RemotePolicy = params.RemotePolicy
+				updatedJob.SalaryCurrency = params.SalaryCurrency
+				updatedJob.SalaryPeriod = params.SalaryPeriod
+				updatedJob.SalaryMinAnnual = params.SalaryMinAnnual
+				updatedJob.SalaryMaxAnnual = params.SalaryMaxAnnual
 				store.EXPECT().
-					DeleteMultipleJobSkills(gomock.Any(), gomock.Any()).
-					Times(0)
+					GetCurrencyRate(gomock.Any(), gomock.Eq(plnRate.Currency)).
+					Times(1).
+					Return(plnRate, nil)
 				store.EXPECT().
-					CreateMultipleJobSkills(gomock.Any(), gomock.Any(), gomock.Any()).
-					Times(0)
+					UpdateJobTx(gomock.Any(), gomock.Eq(db.UpdateJobTxParams{UpdateJobParams: params})).
+					Times(1).
+					Return(db.UpdateJobTxResult{Job: updatedJob}, nil)
 				store.EXPECT().
 					ListJobSkillsByJobID(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(listedSkills, nil)
+				distributor.EXPECT().
+					DistributeTaskProcessSearchOutbox(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+				var gotJob jobResponse
+				err = json.Unmarshal(data, &gotJob)
+				require.NoError(t, err)
+				require.Equal(t, db.EmploymentTypeContract, gotJob.EmploymentType)
+				require.Equal(t, db.SeniorityLevelLead, gotJob.SeniorityLevel)
+				require.Equal(t, db.RemotePolicyHybrid, gotJob.RemotePolicy)
+				require.Equal(t, "PLN", gotJob.SalaryCurrency)
+				require.Equal(t, db.SalaryPeriodDay, gotJob.SalaryPeriod)
+			},
+		},
+		{
+			name:  "Invalid Salary Currency",
+			jobID: job.ID,
+			body: gin.H{
+				"salary_currency": "usd",
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Any()).
 					Times(0)
-				client.EXPECT().
-					GetDocumentIDByJobID(gomock.Any()).
-					Times(0)
-				client.EXPECT().
-					UpdateJobDocument(gomock.Any(), gomock.Any()).
+				store.EXPECT().
+					UpdateJobTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusNotFound, recorder.Code)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
 		{
-			name:  "Internal Server Error GetJob",
+			name:  "OK Draft",
 			jobID: job.ID,
 			body:  requestBody,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
@@ -1569,76 +1985,90 @@ func TestUpdateJobAPI(t *testing.T) {
 				store.EXPECT().
 					GetJob(gomock.Any(), gomock.Eq(job.ID)).
 					Times(1).
-					Return(db.Job{}, sql.ErrConnDone)
-				store.EXPECT().
-					UpdateJob(gomock.Any(), gomock.Any()).
-					Times(0)
+					Return(job, nil)
 				store.EXPECT().
-					DeleteMultipleJobSkills(gomock.Any(), gomock.Any()).
-					Times(0)
+					GetCurrencyRate(gomock.Any(), gomock.Eq(eurRate.Currency)).
+					Times(1).
+					Return(eurRate, nil)
 				store.EXPECT().
-					CreateMultipleJobSkills(gomock.Any(), gomock.Any(), gomock.Any()).
-					Times(0)
+					UpdateJobTx(gomock.Any(), EqUpdateJobTxSkills(requiredSkillIDsToRemove, requiredSkillsToAdd)).
+					Times(1).
+					Return(db.UpdateJobTxResult{Job: draftJob}, nil)
 				store.EXPECT().
 					ListJobSkillsByJobID(gomock.Any(), gomock.Any()).
-					Times(0)
-				client.EXPECT().
-					GetDocumentIDByJobID(gomock.Any()).
-					Times(0)
-				client.EXPECT().
-					UpdateJobDocument(gomock.Any(), gomock.Any()).
-					Times(0)
+					Times(1).
+					Return(listedSkills, nil)
+				distributor.EXPECT().
+					DistributeTaskProcessSearchOutbox(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchJob(t, recorder.Body, draftJob, listedSkills)
 			},
 		},
 		{
-			name:  "Internal Server Error GetEmployerByEmail",
+			name:  "Closes At In The Past",
 			jobID: job.ID,
-			body:  requestBody,
+			body: gin.H{
+				"closes_at": time.Now().Add(-time.Hour),
+			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
-					Return(db.Employer{}, sql.ErrConnDone)
+					Return(employer, nil)
 				store.EXPECT().
-					GetJob(gomock.Any(), gomock.Any()).
-					Times(0)
+					GetJob(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(job, nil)
 				store.EXPECT().
-					UpdateJob(gomock.Any(), gomock.Any()).
+					UpdateJobTx(gomock.Any(), gomock.Any()).
 					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Not Found",
+			jobID: job.ID,
+			body:  requestBody,
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
-					DeleteMultipleJobSkills(gomock.Any(), gomock.Any()).
-					Times(0)
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					GetJob(gomock.Any(), gomock.Eq(job.ID)).
+					Times(1).
+					Return(db.Job{}, sql.ErrNoRows)
 				store.EXPECT().
-					CreateMultipleJobSkills(gomock.Any(), gomock.Any(), gomock.Any()).
+					UpdateJobTx(gomock.Any(), gomock.Any()).
 					Times(0)
 				store.EXPECT().
 					ListJobSkillsByJobID(gomock.Any(), gomock.Any()).
 					Times(0)
-				client.EXPECT().
-					GetDocumentIDByJobID(gomock.Any()).
-					Times(0)
-				client.EXPECT().
-					UpdateJobDocument(gomock.Any(), gomock.Any()).
-					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, http.StatusNotFound, recorder.Code)
 			},
 		},
 		{
-			name:  "Internal Server Error UpdateJob",
+			name:  "Internal Server Error GetJob",
 			jobID: job.ID,
 			body:  requestBody,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
@@ -1646,88 +2076,52 @@ func TestUpdateJobAPI(t *testing.T) {
 				store.EXPECT().
 					GetJob(gomock.Any(), gomock.Eq(job.ID)).
 					Times(1).
-					Return(job, nil)
-				store.EXPECT().
-					UpdateJob(gomock.Any(), gomock.Any()).
-					Times(1).
 					Return(db.Job{}, sql.ErrConnDone)
 				store.EXPECT().
-					DeleteMultipleJobSkills(gomock.Any(), gomock.Any()).
-					Times(0)
-				store.EXPECT().
-					CreateMultipleJobSkills(gomock.Any(), gomock.Any(), gomock.Any()).
+					UpdateJobTx(gomock.Any(), gomock.Any()).
 					Times(0)
 				store.EXPECT().
 					ListJobSkillsByJobID(gomock.Any(), gomock.Any()).
 					Times(0)
-				client.EXPECT().
-					GetDocumentIDByJobID(gomock.Any()).
-					Times(0)
-				client.EXPECT().
-					UpdateJobDocument(gomock.Any(), gomock.Any()).
-					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
 		{
-			name:  "Internal Server Error DeleteMultipleJobSkills",
+			name:  "Internal Server Error GetEmployerByEmail",
 			jobID: job.ID,
-			body: gin.H{
-				"requirements ":                "new requirements",
-				"required_skills_to_add":       requiredSkillsToAdd,
-				"required_skill_ids_to_remove": requiredSkillIDsToRemove,
-			},
+			body:  requestBody,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
-					Return(employer, nil)
-				store.EXPECT().
-					GetJob(gomock.Any(), gomock.Eq(job.ID)).
-					Times(1).
-					Return(job, nil)
-				store.EXPECT().
-					UpdateJob(gomock.Any(), gomock.Any()).
-					Times(1).
-					Return(newJob, nil)
+					Return(db.Employer{}, sql.ErrConnDone)
 				store.EXPECT().
-					DeleteMultipleJobSkills(gomock.Any(), gomock.Any()).
-					Times(1).
-					Return(sql.ErrConnDone)
+					GetJob(gomock.Any(), gomock.Any()).
+					Times(0)
 				store.EXPECT().
-					CreateMultipleJobSkills(gomock.Any(), gomock.Any(), gomock.Any()).
+					UpdateJobTx(gomock.Any(), gomock.Any()).
 					Times(0)
 				store.EXPECT().
 					ListJobSkillsByJobID(gomock.Any(), gomock.Any()).
 					Times(0)
-				client.EXPECT().
-					GetDocumentIDByJobID(gomock.Any()).
-					Times(0)
-				client.EXPECT().
-					UpdateJobDocument(gomock.Any(), gomock.Any()).
-					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
 		{
-			name:  "Internal Server Error CreateMultipleJobSkills",
+			name:  "Internal Server Error UpdateJobTx",
 			jobID: job.ID,
-			body: gin.H{
-				"title":                        "new title",
-				"required_skills_to_add":       requiredSkillsToAdd,
-				"required_skill_ids_to_remove": requiredSkillIDsToRemove,
-			},
+			body:  requestBody,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
@@ -1737,26 +2131,16 @@ func TestUpdateJobAPI(t *testing.T) {
 					Times(1).
 					Return(job, nil)
 				store.EXPECT().
-					UpdateJob(gomock.Any(), gomock.Any()).
-					Times(1).
-					Return(newJob, nil)
-				store.EXPECT().
-					DeleteMultipleJobSkills(gomock.Any(), gomock.Any()).
+					GetCurrencyRate(gomock.Any(), gomock.Eq(eurRate.Currency)).
 					Times(1).
-					Return(nil)
+					Return(eurRate, nil)
 				store.EXPECT().
-					CreateMultipleJobSkills(gomock.Any(), gomock.Any(), gomock.Any()).
+					UpdateJobTx(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(sql.ErrConnDone)
+					Return(db.UpdateJobTxResult{}, sql.ErrConnDone)
 				store.EXPECT().
 					ListJobSkillsByJobID(gomock.Any(), gomock.Any()).
 					Times(0)
-				client.EXPECT().
-					GetDocumentIDByJobID(gomock.Any()).
-					Times(0)
-				client.EXPECT().
-					UpdateJobDocument(gomock.Any(), gomock.Any()).
-					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
@@ -1767,9 +2151,9 @@ func TestUpdateJobAPI(t *testing.T) {
 			jobID: job.ID,
 			body:  requestBody,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
@@ -1779,26 +2163,19 @@ func TestUpdateJobAPI(t *testing.T) {
 					Times(1).
 					Return(job, nil)
 				store.EXPECT().
-					UpdateJob(gomock.Any(), gomock.Any()).
-					Times(1).
-					Return(newJob, nil)
-				store.EXPECT().
-					DeleteMultipleJobSkills(gomock.Any(), gomock.Any()).
+					GetCurrencyRate(gomock.Any(), gomock.Eq(eurRate.Currency)).
 					Times(1).
-					Return(nil)
+					Return(eurRate, nil)
 				store.EXPECT().
-					CreateMultipleJobSkills(gomock.Any(), gomock.Any(), gomock.Any()).
+					UpdateJobTx(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(nil)
+					Return(db.UpdateJobTxResult{Job: newJob}, nil)
 				store.EXPECT().
 					ListJobSkillsByJobID(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return([]db.ListJobSkillsByJobIDRow{}, sql.ErrConnDone)
-				client.EXPECT().
-					GetDocumentIDByJobID(gomock.Any()).
-					Times(0)
-				client.EXPECT().
-					UpdateJobDocument(gomock.Any(), gomock.Any()).
+				distributor.EXPECT().
+					DistributeTaskProcessSearchOutbox(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -1810,9 +2187,9 @@ func TestUpdateJobAPI(t *testing.T) {
 			jobID: 0,
 			body:  requestBody,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -1820,23 +2197,11 @@ func TestUpdateJobAPI(t *testing.T) {
 					GetJob(gomock.Any(), gomock.Any()).
 					Times(0)
 				store.EXPECT().
-					UpdateJob(gomock.Any(), gomock.Any()).
-					Times(0)
-				store.EXPECT().
-					DeleteMultipleJobSkills(gomock.Any(), gomock.Any()).
-					Times(0)
-				store.EXPECT().
-					CreateMultipleJobSkills(gomock.Any(), gomock.Any(), gomock.Any()).
+					UpdateJobTx(gomock.Any(), gomock.Any()).
 					Times(0)
 				store.EXPECT().
 					ListJobSkillsByJobID(gomock.Any(), gomock.Any()).
 					Times(0)
-				client.EXPECT().
-					GetDocumentIDByJobID(gomock.Any()).
-					Times(0)
-				client.EXPECT().
-					UpdateJobDocument(gomock.Any(), gomock.Any()).
-					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
@@ -1850,9 +2215,9 @@ func TestUpdateJobAPI(t *testing.T) {
 				"title":      100,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -1860,23 +2225,11 @@ func TestUpdateJobAPI(t *testing.T) {
 					GetJob(gomock.Any(), gomock.Any()).
 					Times(0)
 				store.EXPECT().
-					UpdateJob(gomock.Any(), gomock.Any()).
-					Times(0)
-				store.EXPECT().
-					DeleteMultipleJobSkills(gomock.Any(), gomock.Any()).
-					Times(0)
-				store.EXPECT().
-					CreateMultipleJobSkills(gomock.Any(), gomock.Any(), gomock.Any()).
+					UpdateJobTx(gomock.Any(), gomock.Any()).
 					Times(0)
 				store.EXPECT().
 					ListJobSkillsByJobID(gomock.Any(), gomock.Any()).
 					Times(0)
-				client.EXPECT().
-					GetDocumentIDByJobID(gomock.Any()).
-					Times(0)
-				client.EXPECT().
-					UpdateJobDocument(gomock.Any(), gomock.Any()).
-					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
@@ -1887,9 +2240,9 @@ func TestUpdateJobAPI(t *testing.T) {
 			jobID: job.ID,
 			body:  requestBody,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer2.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer2.Email, token.AccountTypeEmployer, employer2.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer2.Email)).
 					Times(1).
@@ -1899,23 +2252,11 @@ func TestUpdateJobAPI(t *testing.T) {
 					Times(1).
 					Return(job, nil)
 				store.EXPECT().
-					UpdateJob(gomock.Any(), gomock.Any()).
-					Times(0)
-				store.EXPECT().
-					DeleteMultipleJobSkills(gomock.Any(), gomock.Any()).
-					Times(0)
-				store.EXPECT().
-					CreateMultipleJobSkills(gomock.Any(), gomock.Any(), gomock.Any()).
+					UpdateJobTx(gomock.Any(), gomock.Any()).
 					Times(0)
 				store.EXPECT().
 					ListJobSkillsByJobID(gomock.Any(), gomock.Any()).
 					Times(0)
-				client.EXPECT().
-					GetDocumentIDByJobID(gomock.Any()).
-					Times(0)
-				client.EXPECT().
-					UpdateJobDocument(gomock.Any(), gomock.Any()).
-					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
@@ -1929,9 +2270,9 @@ func TestUpdateJobAPI(t *testing.T) {
 				"salary_max": 5,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
@@ -1941,23 +2282,11 @@ func TestUpdateJobAPI(t *testing.T) {
 					Times(1).
 					Return(job, nil)
 				store.EXPECT().
-					UpdateJob(gomock.Any(), gomock.Any()).
-					Times(0)
-				store.EXPECT().
-					DeleteMultipleJobSkills(gomock.Any(), gomock.Any()).
-					Times(0)
-				store.EXPECT().
-					CreateMultipleJobSkills(gomock.Any(), gomock.Any(), gomock.Any()).
+					UpdateJobTx(gomock.Any(), gomock.Any()).
 					Times(0)
 				store.EXPECT().
 					ListJobSkillsByJobID(gomock.Any(), gomock.Any()).
 					Times(0)
-				client.EXPECT().
-					GetDocumentIDByJobID(gomock.Any()).
-					Times(0)
-				client.EXPECT().
-					UpdateJobDocument(gomock.Any(), gomock.Any()).
-					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
@@ -1968,9 +2297,9 @@ func TestUpdateJobAPI(t *testing.T) {
 			jobID: job.ID,
 			body:  requestBody,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
@@ -1979,36 +2308,24 @@ func TestUpdateJobAPI(t *testing.T) {
 					GetJob(gomock.Any(), gomock.Any()).
 					Times(0)
 				store.EXPECT().
-					UpdateJob(gomock.Any(), gomock.Any()).
-					Times(0)
-				store.EXPECT().
-					DeleteMultipleJobSkills(gomock.Any(), gomock.Any()).
-					Times(0)
-				store.EXPECT().
-					CreateMultipleJobSkills(gomock.Any(), gomock.Any(), gomock.Any()).
+					UpdateJobTx(gomock.Any(), gomock.Any()).
 					Times(0)
 				store.EXPECT().
 					ListJobSkillsByJobID(gomock.Any(), gomock.Any()).
 					Times(0)
-				client.EXPECT().
-					GetDocumentIDByJobID(gomock.Any()).
-					Times(0)
-				client.EXPECT().
-					UpdateJobDocument(gomock.Any(), gomock.Any()).
-					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
 		},
 		{
-			name:  "Internal Server Error GetDocumentIDByJobID",
+			name:  "OK DistributeTaskProcessSearchOutbox Error",
 			jobID: job.ID,
 			body:  requestBody,
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
 					Times(1).
@@ -2018,76 +2335,24 @@ func TestUpdateJobAPI(t *testing.T) {
 					Times(1).
 					Return(job, nil)
 				store.EXPECT().
-					UpdateJob(gomock.Any(), gomock.Any()).
-					Times(1).
-					Return(newJob, nil)
-				store.EXPECT().
-					DeleteMultipleJobSkills(gomock.Any(), gomock.Eq(requiredSkillIDsToRemove)).
+					GetCurrencyRate(gomock.Any(), gomock.Eq(eurRate.Currency)).
 					Times(1).
-					Return(nil)
+					Return(eurRate, nil)
 				store.EXPECT().
-					CreateMultipleJobSkills(gomock.Any(), gomock.Eq(requiredSkillsToAdd), gomock.Eq(newJob.ID)).
+					UpdateJobTx(gomock.Any(), EqUpdateJobTxSkills(requiredSkillIDsToRemove, requiredSkillsToAdd)).
 					Times(1).
-					Return(nil)
+					Return(db.UpdateJobTxResult{Job: newJob}, nil)
 				store.EXPECT().
 					ListJobSkillsByJobID(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return([]db.ListJobSkillsByJobIDRow{}, nil)
-				client.EXPECT().
-					GetDocumentIDByJobID(gomock.Any()).
-					Times(1).
-					Return("", errors.New("some error"))
-				client.EXPECT().
-					UpdateJobDocument(gomock.Any(), gomock.Any()).
-					Times(0)
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
-			},
-		},
-		{
-			name:  "Internal Server Error UpdateJobDocument",
-			jobID: job.ID,
-			body:  requestBody,
-			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
-				store.EXPECT().
-					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
-					Times(1).
-					Return(employer, nil)
-				store.EXPECT().
-					GetJob(gomock.Any(), gomock.Eq(job.ID)).
-					Times(1).
-					Return(job, nil)
-				store.EXPECT().
-					UpdateJob(gomock.Any(), gomock.Any()).
-					Times(1).
-					Return(newJob, nil)
-				store.EXPECT().
-					DeleteMultipleJobSkills(gomock.Any(), gomock.Eq(requiredSkillIDsToRemove)).
-					Times(1).
-					Return(nil)
-				store.EXPECT().
-					CreateMultipleJobSkills(gomock.Any(), gomock.Eq(requiredSkillsToAdd), gomock.Eq(newJob.ID)).
-					Times(1).
-					Return(nil)
-				store.EXPECT().
-					ListJobSkillsByJobID(gomock.Any(), gomock.Any()).
-					Times(1).
-					Return([]db.ListJobSkillsByJobIDRow{}, nil)
-				client.EXPECT().
-					GetDocumentIDByJobID(gomock.Any()).
-					Times(1).
-					Return(strconv.Itoa(int(job.ID)), nil)
-				client.EXPECT().
-					UpdateJobDocument(gomock.Any(), gomock.Any()).
+				distributor.EXPECT().
+					DistributeTaskProcessSearchOutbox(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(errors.New("some error"))
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
 	}
@@ -2099,10 +2364,14 @@ func TestUpdateJobAPI(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mockdb.NewMockStore(ctrl)
-			client := mockesearch.NewMockESearchClient(ctrl)
-			tc.buildStubs(store, client)
 
-			server := newTestServer(t, store, client, nil)
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockworker.NewMockTaskDistributor(taskCtrl)
+
+			tc.buildStubs(store, taskDistributor)
+
+			server := newTestServer(t, store, nil, taskDistributor)
 			recorder := httptest.NewRecorder()
 
 			data, err := json.Marshal(tc.body)
@@ -2356,20 +2625,448 @@ func TestListJobsByCompanyAPI(t *testing.T) {
 		{
 			name: "To Many Parameters",
 			query: Query{
-				page:     1,
-				pageSize: 10,
-				id:       company.ID,
-				name:     company.Name,
+				page:     1,
+				pageSize: 10,
+				id:       company.ID,
+				name:     company.Name,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListJobsByCompanyID(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					ListJobsByCompanyExactName(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					ListJobsByCompanyName(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error ListJobsByCompanyID",
+			query: Query{
+				page:     1,
+				pageSize: 10,
+				id:       company.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListJobsByCompanyID(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListJobsByCompanyIDRow{}, sql.ErrConnDone)
+				store.EXPECT().
+					ListJobsByCompanyExactName(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					ListJobsByCompanyName(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error ListJobsByCompanyExactName",
+			query: Query{
+				page:     1,
+				pageSize: 10,
+				name:     company.Name,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListJobsByCompanyID(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					ListJobsByCompanyExactName(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListJobsByCompanyExactNameRow{}, sql.ErrConnDone)
+				store.EXPECT().
+					ListJobsByCompanyName(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error ListJobsByCompanyExactName",
+			query: Query{
+				page:         1,
+				pageSize:     10,
+				nameContains: company.Name[1:3],
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListJobsByCompanyID(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					ListJobsByCompanyExactName(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					ListJobsByCompanyName(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListJobsByCompanyNameRow{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil, nil)
+			recorder := httptest.NewRecorder()
+
+			url := BaseUrl + "/jobs/company"
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			// Add query params
+			q := req.URL.Query()
+			q.Add("page", fmt.Sprintf("%d", tc.query.page))
+			q.Add("page_size", fmt.Sprintf("%d", tc.query.pageSize))
+			q.Add("name", tc.query.name)
+			q.Add("name_contains", tc.query.nameContains)
+			q.Add("id", fmt.Sprintf("%d", tc.query.id))
+			req.URL.RawQuery = q.Encode()
+
+			server.router.ServeHTTP(recorder, req)
+
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestSearchJobsAPI(t *testing.T) {
+	_, _, company := generateRandomEmployerAndCompany(t)
+	var jobs []*esearch.JobHit
+	title := utils.RandomString(5)
+	industry := utils.RandomString(4)
+	jobLocation := utils.RandomString(6)
+	salaryMin := utils.RandomInt(100, 150)
+	salaryMax := utils.RandomInt(151, 200)
+
+	job := generateJob(
+		title,
+		industry,
+		jobLocation,
+		salaryMin,
+		salaryMax,
+	)
+
+	for i := 0; i < 10; i++ {
+		row := esearch.Job{
+			ID:              job.ID,
+			Title:           job.Title,
+			Industry:        job.Industry,
+			CompanyName:     company.Name,
+			Description:     job.Description,
+			Location:        job.Location,
+			SalaryMin:       job.SalaryMin,
+			SalaryMax:       job.SalaryMax,
+			Requirements:    job.Requirements,
+			EmploymentType:  string(job.EmploymentType),
+			SeniorityLevel:  string(job.SeniorityLevel),
+			RemotePolicy:    string(job.RemotePolicy),
+			SalaryCurrency:  job.SalaryCurrency,
+			SalaryPeriod:    string(job.SalaryPeriod),
+			SalaryMinAnnual: job.SalaryMinAnnual,
+			SalaryMaxAnnual: job.SalaryMaxAnnual,
+			LocationPoint:   esearch.NewGeoPoint(job.Latitude, job.Longitude),
+		}
+		jobs = append(jobs, &esearch.JobHit{
+			Job:   row,
+			Score: float64(10 - i),
+			Highlights: map[string][]string{
+				"title": {"<em>" + job.Title + "</em>"},
+			},
+		})
+	}
+
+	var page int32 = 1
+	var pageSize int32 = 10
+	result := esearch.SearchJobsResult{
+		Jobs:       jobs,
+		Total:      int64(len(jobs)),
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: 1,
+		Facets: esearch.Facets{
+			Industries: []esearch.FacetBucket{{Value: job.Industry, Count: int64(len(jobs))}},
+			Locations:  []esearch.FacetBucket{{Value: job.Location, Count: int64(len(jobs))}},
+			Companies:  []esearch.FacetBucket{{Value: company.Name, Count: int64(len(jobs))}},
+			Skills:     []esearch.FacetBucket{},
+			Salaries:   []esearch.SalaryBucket{{Count: int64(len(jobs))}},
+		},
+	}
+
+	type Query struct {
+		page           int32
+		pageSize       int32
+		search         string
+		employmentType string
+		remotePolicy   string
+		salaryCurrency string
+		salaryMin      int32
+		salaryMax      int32
+		near           string
+		radiusKm       float64
+		industry       string
+		location       string
+		company        string
+		skills         []string
+		explain        bool
+	}
+
+	warsaw, ok := geo.Lookup("Warsaw")
+	require.True(t, ok)
+
+	testCases := []struct {
+		name          string
+		query         Query
+		buildStubs    func(client *mockesearch.MockESearchClient)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			query: Query{
+				page:     page,
+				pageSize: pageSize,
+				search:   title,
+			},
+			buildStubs: func(client *mockesearch.MockESearchClient) {
+				client.EXPECT().
+					SearchJobs(gomock.Any(), gomock.Eq(title), gomock.Eq(esearch.JobFilters{}), gomock.Eq(page), gomock.Eq(pageSize), gomock.Eq(false)).
+					Times(1).
+					Return(result, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchJobs(t, recorder.Body, result)
+			},
+		},
+		{
+			name: "OK With Filters",
+			query: Query{
+				page:           page,
+				pageSize:       pageSize,
+				search:         title,
+				employmentType: string(job.EmploymentType),
+				remotePolicy:   string(job.RemotePolicy),
+				salaryCurrency: job.SalaryCurrency,
+				salaryMin:      job.SalaryMinAnnual,
+				salaryMax:      job.SalaryMaxAnnual,
+			},
+			buildStubs: func(client *mockesearch.MockESearchClient) {
+				filters := esearch.JobFilters{
+					EmploymentType: string(job.EmploymentType),
+					RemotePolicy:   string(job.RemotePolicy),
+					SalaryCurrency: job.SalaryCurrency,
+					SalaryMin:      job.SalaryMinAnnual,
+					SalaryMax:      job.SalaryMaxAnnual,
+				}
+				client.EXPECT().
+					SearchJobs(gomock.Any(), gomock.Eq(title), gomock.Eq(filters), gomock.Eq(page), gomock.Eq(pageSize), gomock.Eq(false)).
+					Times(1).
+					Return(result, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchJobs(t, recorder.Body, result)
+			},
+		},
+		{
+			name: "OK Near",
+			query: Query{
+				page:     page,
+				pageSize: pageSize,
+				search:   title,
+				near:     "warszawa",
+				radiusKm: 25,
+			},
+			buildStubs: func(client *mockesearch.MockESearchClient) {
+				filters := esearch.JobFilters{
+					Near: &esearch.GeoPoint{
+						Lat: warsaw.Latitude,
+						Lon: warsaw.Longitude,
+					},
+					RadiusKm: 25,
+				}
+				client.EXPECT().
+					SearchJobs(gomock.Any(), gomock.Eq(title), gomock.Eq(filters), gomock.Eq(page), gomock.Eq(pageSize), gomock.Eq(false)).
+					Times(1).
+					Return(result, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchJobs(t, recorder.Body, result)
+			},
+		},
+		{
+			name: "OK Facet Filters",
+			query: Query{
+				page:     page,
+				pageSize: pageSize,
+				search:   title,
+				industry: job.Industry,
+				location: "warszawa",
+				company:  company.Name,
+				skills:   []string{"Go", "SQL"},
+			},
+			buildStubs: func(client *mockesearch.MockESearchClient) {
+				filters := esearch.JobFilters{
+					Industry:    job.Industry,
+					Location:    "Warsaw, Poland",
+					CompanyName: company.Name,
+					Skills:      []string{"Go", "SQL"},
+				}
+				client.EXPECT().
+					SearchJobs(gomock.Any(), gomock.Eq(title), gomock.Eq(filters), gomock.Eq(page), gomock.Eq(pageSize), gomock.Eq(false)).
+					Times(1).
+					Return(result, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchJobs(t, recorder.Body, result)
+			},
+		},
+		{
+			name: "Too Many Skills",
+			query: Query{
+				page:     page,
+				pageSize: pageSize,
+				search:   title,
+				skills:   []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"},
+			},
+			buildStubs: func(client *mockesearch.MockESearchClient) {
+				client.EXPECT().
+					SearchJobs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Explain Not Admin",
+			query: Query{
+				page:     page,
+				pageSize: pageSize,
+				search:   title,
+				explain:  true,
+			},
+			buildStubs: func(client *mockesearch.MockESearchClient) {
+				client.EXPECT().
+					SearchJobs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Unknown Near Location",
+			query: Query{
+				page:     page,
+				pageSize: pageSize,
+				search:   title,
+				near:     "Atlantis",
+			},
+			buildStubs: func(client *mockesearch.MockESearchClient) {
+				client.EXPECT().
+					SearchJobs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Invalid Salary Min",
+			query: Query{
+				page:      page,
+				pageSize:  pageSize,
+				search:    title,
+				salaryMin: -1,
+			},
+			buildStubs: func(client *mockesearch.MockESearchClient) {
+				client.EXPECT().
+					SearchJobs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Invalid Remote Policy",
+			query: Query{
+				page:         page,
+				pageSize:     pageSize,
+				search:       title,
+				remotePolicy: "sometimes",
+			},
+			buildStubs: func(client *mockesearch.MockESearchClient) {
+				client.EXPECT().
+					SearchJobs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error",
+			query: Query{
+				page:     page,
+				pageSize: pageSize,
+				search:   title,
+			},
+			buildStubs: func(client *mockesearch.MockESearchClient) {
+				client.EXPECT().
+					SearchJobs(gomock.Any(), gomock.Eq(title), gomock.Eq(esearch.JobFilters{}), gomock.Eq(page), gomock.Eq(pageSize), gomock.Eq(false)).
+					Times(1).
+					Return(esearch.SearchJobsResult{}, errors.New("some error"))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "No Page",
+			query: Query{
+				pageSize: pageSize,
+				search:   title,
+			},
+			buildStubs: func(client *mockesearch.MockESearchClient) {
+				client.EXPECT().
+					SearchJobs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "No Search",
+			query: Query{
+				pageSize: pageSize,
+				page:     page,
 			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					ListJobsByCompanyID(gomock.Any(), gomock.Any()).
-					Times(0)
-				store.EXPECT().
-					ListJobsByCompanyExactName(gomock.Any(), gomock.Any()).
-					Times(0)
-				store.EXPECT().
-					ListJobsByCompanyName(gomock.Any(), gomock.Any()).
+			buildStubs: func(client *mockesearch.MockESearchClient) {
+				client.EXPECT().
+					SearchJobs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -2377,72 +3074,48 @@ func TestListJobsByCompanyAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "Internal Server Error ListJobsByCompanyID",
+			name: "No Page Size",
 			query: Query{
-				page:     1,
-				pageSize: 10,
-				id:       company.ID,
+				page:   page,
+				search: title,
 			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					ListJobsByCompanyID(gomock.Any(), gomock.Any()).
-					Times(1).
-					Return([]db.ListJobsByCompanyIDRow{}, sql.ErrConnDone)
-				store.EXPECT().
-					ListJobsByCompanyExactName(gomock.Any(), gomock.Any()).
-					Times(0)
-				store.EXPECT().
-					ListJobsByCompanyName(gomock.Any(), gomock.Any()).
+			buildStubs: func(client *mockesearch.MockESearchClient) {
+				client.EXPECT().
+					SearchJobs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
 		{
-			name: "Internal Server Error ListJobsByCompanyExactName",
+			name: "Invalid Page Size",
 			query: Query{
-				page:     1,
-				pageSize: 10,
-				name:     company.Name,
+				pageSize: 50,
+				search:   title,
 			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					ListJobsByCompanyID(gomock.Any(), gomock.Any()).
-					Times(0)
-				store.EXPECT().
-					ListJobsByCompanyExactName(gomock.Any(), gomock.Any()).
-					Times(1).
-					Return([]db.ListJobsByCompanyExactNameRow{}, sql.ErrConnDone)
-				store.EXPECT().
-					ListJobsByCompanyName(gomock.Any(), gomock.Any()).
+			buildStubs: func(client *mockesearch.MockESearchClient) {
+				client.EXPECT().
+					SearchJobs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
 		{
-			name: "Internal Server Error ListJobsByCompanyExactName",
+			name: "Invalid Page",
 			query: Query{
-				page:         1,
-				pageSize:     10,
-				nameContains: company.Name[1:3],
+				page:   0,
+				search: title,
 			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					ListJobsByCompanyID(gomock.Any(), gomock.Any()).
-					Times(0)
-				store.EXPECT().
-					ListJobsByCompanyExactName(gomock.Any(), gomock.Any()).
+			buildStubs: func(client *mockesearch.MockESearchClient) {
+				client.EXPECT().
+					SearchJobs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(0)
-				store.EXPECT().
-					ListJobsByCompanyName(gomock.Any(), gomock.Any()).
-					Times(1).
-					Return([]db.ListJobsByCompanyNameRow{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
 	}
@@ -2452,14 +3125,15 @@ func TestListJobsByCompanyAPI(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
-
 			store := mockdb.NewMockStore(ctrl)
-			tc.buildStubs(store)
 
-			server := newTestServer(t, store, nil, nil)
+			client := mockesearch.NewMockESearchClient(ctrl)
+			tc.buildStubs(client)
+
+			server := newTestServer(t, store, client, nil)
 			recorder := httptest.NewRecorder()
 
-			url := BaseUrl + "/jobs/company"
+			url := BaseUrl + "/jobs/search"
 			req, err := http.NewRequest(http.MethodGet, url, nil)
 			require.NoError(t, err)
 
@@ -2467,9 +3141,21 @@ func TestListJobsByCompanyAPI(t *testing.T) {
 			q := req.URL.Query()
 			q.Add("page", fmt.Sprintf("%d", tc.query.page))
 			q.Add("page_size", fmt.Sprintf("%d", tc.query.pageSize))
-			q.Add("name", tc.query.name)
-			q.Add("name_contains", tc.query.nameContains)
-			q.Add("id", fmt.Sprintf("%d", tc.query.id))
+			q.Add("search", tc.query.search)
+			q.Add("employment_type", tc.query.employmentType)
+			q.Add("remote_policy", tc.query.remotePolicy)
+			q.Add("salary_currency", tc.query.salaryCurrency)
+			q.Add("salary_min", fmt.Sprintf("%d", tc.query.salaryMin))
+			q.Add("salary_max", fmt.Sprintf("%d", tc.query.salaryMax))
+			q.Add("near", tc.query.near)
+			q.Add("radius_km", fmt.Sprintf("%g", tc.query.radiusKm))
+			q.Add("industry", tc.query.industry)
+			q.Add("location", tc.query.location)
+			q.Add("company", tc.query.company)
+			for _, skill := range tc.query.skills {
+				q.Add("skills", skill)
+			}
+			q.Add("explain", fmt.Sprintf("%t", tc.query.explain))
 			req.URL.RawQuery = q.Encode()
 
 			server.router.ServeHTTP(recorder, req)
@@ -2479,127 +3165,164 @@ func TestListJobsByCompanyAPI(t *testing.T) {
 	}
 }
 
-func TestSearchJobsAPI(t *testing.T) {
-	_, _, company := generateRandomEmployerAndCompany(t)
-	var jobs []*esearch.Job
-	title := utils.RandomString(5)
-	industry := utils.RandomString(4)
-	jobLocation := utils.RandomString(6)
-	salaryMin := utils.RandomInt(100, 150)
-	salaryMax := utils.RandomInt(151, 200)
-
-	job := generateJob(
-		title,
-		industry,
-		jobLocation,
-		salaryMin,
-		salaryMax,
-	)
-
-	for i := 0; i < 10; i++ {
-		row := esearch.Job{
-			ID:           job.ID,
-			Title:        job.Title,
-			Industry:     job.Industry,
-			CompanyName:  company.Name,
-			Description:  job.Description,
-			Location:     job.Location,
-			SalaryMin:    job.SalaryMin,
-			SalaryMax:    job.SalaryMax,
-			Requirements: job.Requirements,
-		}
-		jobs = append(jobs, &row)
-	}
-
-	var page int32 = 1
-	var pageSize int32 = 10
-
-	type Query struct {
-		page     int32
-		pageSize int32
-		search   string
+func TestSearchJobsExplainAPI(t *testing.T) {
+	job := generateRandomJob()
+	title := job.Title
+	result := esearch.SearchJobsResult{
+		Jobs: []*esearch.JobHit{
+			{
+				Job: esearch.Job{
+					ID:       job.ID,
+					Title:    job.Title,
+					Industry: job.Industry,
+				},
+				Score:       1.5,
+				Explanation: json.RawMessage(`{"value":1.5,"description":"sum of:","details":[]}`),
+			},
+		},
+		Total:      1,
+		Page:       1,
+		PageSize:   5,
+		TotalPages: 1,
 	}
+	adminID := utils.RandomInt(1, 1000)
 
 	testCases := []struct {
 		name          string
-		query         Query
+		setupAuth     func(t *testing.T, r *http.Request, maker token.Maker)
 		buildStubs    func(client *mockesearch.MockESearchClient)
 		checkResponse func(recorder *httptest.ResponseRecorder)
 	}{
 		{
 			name: "OK",
-			query: Query{
-				page:     page,
-				pageSize: pageSize,
-				search:   title,
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, testAdminEmail, token.AccountTypeUser, adminID, time.Minute)
 			},
 			buildStubs: func(client *mockesearch.MockESearchClient) {
 				client.EXPECT().
-					SearchJobs(gomock.Any(), gomock.Eq(title), gomock.Eq(page), gomock.Eq(pageSize)).
+					SearchJobs(gomock.Any(), gomock.Eq(title), gomock.Eq(esearch.JobFilters{}), gomock.Eq(int32(1)), gomock.Eq(int32(5)), gomock.Eq(true)).
 					Times(1).
-					Return(jobs, nil)
+					Return(result, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchJobs(t, recorder.Body, jobs)
+				requireBodyMatchJobs(t, recorder.Body, result)
 			},
 		},
 		{
-			name: "Internal Server Error",
-			query: Query{
-				page:     page,
-				pageSize: pageSize,
-				search:   title,
+			name: "Not Admin",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, utils.RandomEmail(), token.AccountTypeUser, adminID, time.Minute)
 			},
 			buildStubs: func(client *mockesearch.MockESearchClient) {
 				client.EXPECT().
-					SearchJobs(gomock.Any(), gomock.Eq(title), gomock.Eq(page), gomock.Eq(pageSize)).
-					Times(1).
-					Return([]*esearch.Job{}, errors.New("some error"))
+					SearchJobs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, http.StatusForbidden, recorder.Code)
 			},
 		},
 		{
-			name: "No Page",
-			query: Query{
-				pageSize: pageSize,
-				search:   title,
+			name: "Unauthorized",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
 			},
 			buildStubs: func(client *mockesearch.MockESearchClient) {
 				client.EXPECT().
-					SearchJobs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					SearchJobs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
 		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			client := mockesearch.NewMockESearchClient(ctrl)
+			tc.buildStubs(client)
+
+			server := newTestServer(t, store, client, nil)
+			recorder := httptest.NewRecorder()
+
+			url := BaseUrl + "/admin/jobs/search"
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			q := req.URL.Query()
+			q.Add("page", "1")
+			q.Add("page_size", "5")
+			q.Add("search", title)
+			q.Add("explain", "true")
+			req.URL.RawQuery = q.Encode()
+
+			tc.setupAuth(t, req, server.tokenMaker)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestAutocompleteJobsAPI(t *testing.T) {
+	suggestions := []esearch.Suggestion{
+		{Type: esearch.SuggestionTypeTitle, Value: "Senior Go Developer", Count: 3},
+		{Type: esearch.SuggestionTypeSkill, Value: "Go", Count: 5},
+		{Type: esearch.SuggestionTypeCompany, Value: "Gopher Inc", Count: 1},
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		size          string
+		buildStubs    func(client *mockesearch.MockESearchClient)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
 		{
-			name: "No Search",
-			query: Query{
-				pageSize: pageSize,
-				page:     page,
-			},
+			name:  "OK",
+			query: "go",
 			buildStubs: func(client *mockesearch.MockESearchClient) {
 				client.EXPECT().
-					SearchJobs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Times(0)
+					Autocomplete(gomock.Any(), gomock.Eq("go"), gomock.Eq(defaultSuggestionsSize)).
+					Times(1).
+					Return(suggestions, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+				var gotSuggestions []esearch.Suggestion
+				err = json.Unmarshal(data, &gotSuggestions)
+				require.NoError(t, err)
+				require.Equal(t, suggestions, gotSuggestions)
 			},
 		},
 		{
-			name: "No Page Size",
-			query: Query{
-				page:   page,
-				search: title,
+			name:  "OK Size",
+			query: "go dev",
+			size:  "10",
+			buildStubs: func(client *mockesearch.MockESearchClient) {
+				client.EXPECT().
+					Autocomplete(gomock.Any(), gomock.Eq("go dev"), gomock.Eq(10)).
+					Times(1).
+					Return(suggestions[:1], nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
 			},
+		},
+		{
+			name: "No Query",
 			buildStubs: func(client *mockesearch.MockESearchClient) {
 				client.EXPECT().
-					SearchJobs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Autocomplete(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -2607,14 +3330,12 @@ func TestSearchJobsAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "Invalid Page Size",
-			query: Query{
-				pageSize: 50,
-				search:   title,
-			},
+			name:  "Invalid Size",
+			query: "go",
+			size:  "50",
 			buildStubs: func(client *mockesearch.MockESearchClient) {
 				client.EXPECT().
-					SearchJobs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Autocomplete(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -2622,21 +3343,20 @@ func TestSearchJobsAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "Invalid Page",
-			query: Query{
-				page:   0,
-				search: title,
-			},
+			name:  "Internal Server Error",
+			query: "go",
 			buildStubs: func(client *mockesearch.MockESearchClient) {
 				client.EXPECT().
-					SearchJobs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Times(0)
+					Autocomplete(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]esearch.Suggestion{}, errors.New("some error"))
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
 	}
+
 	for i := range testCases {
 		tc := testCases[i]
 
@@ -2651,24 +3371,20 @@ func TestSearchJobsAPI(t *testing.T) {
 			server := newTestServer(t, store, client, nil)
 			recorder := httptest.NewRecorder()
 
-			url := BaseUrl + "/jobs/search"
+			url := BaseUrl + "/jobs/autocomplete"
 			req, err := http.NewRequest(http.MethodGet, url, nil)
 			require.NoError(t, err)
 
-			// Add query params
 			q := req.URL.Query()
-			q.Add("page", fmt.Sprintf("%d", tc.query.page))
-			q.Add("page_size", fmt.Sprintf("%d", tc.query.pageSize))
-			q.Add("search", tc.query.search)
+			q.Add("q", tc.query)
+			q.Add("size", tc.size)
 			req.URL.RawQuery = q.Encode()
 
 			server.router.ServeHTTP(recorder, req)
-
 			tc.checkResponse(recorder)
 		})
 	}
 }
-
 func TestListEmployerJobsAPI(t *testing.T) {
 	employer, _, _ := generateRandomEmployerAndCompany(t)
 	user, _ := generateRandomUser(t)
@@ -2709,7 +3425,7 @@ func TestListEmployerJobsAPI(t *testing.T) {
 				sort:     "date-asc",
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -2741,7 +3457,7 @@ func TestListEmployerJobsAPI(t *testing.T) {
 				sort:     "date-asc",
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -2763,7 +3479,7 @@ func TestListEmployerJobsAPI(t *testing.T) {
 				sort:     "date-asc",
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -2785,7 +3501,7 @@ func TestListEmployerJobsAPI(t *testing.T) {
 				sort:     "invalid",
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -2800,26 +3516,25 @@ func TestListEmployerJobsAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "Unauthorized Only Employer Access",
+			name: "Forbidden Only Employer Access",
 			query: Query{
 				page:     1,
 				pageSize: 10,
 				sort:     "date-desc",
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
 					GetEmployerByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
-					Return(db.Employer{}, sql.ErrNoRows)
+					Times(0)
 				store.EXPECT().
 					ListJobsForEmployer(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				require.Equal(t, http.StatusForbidden, recorder.Code)
 			},
 		},
 		{
@@ -2830,7 +3545,7 @@ func TestListEmployerJobsAPI(t *testing.T) {
 				sort:     "date-desc",
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -2853,7 +3568,7 @@ func TestListEmployerJobsAPI(t *testing.T) {
 				sort:     "date-desc",
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -2904,7 +3619,7 @@ func TestListEmployerJobsAPI(t *testing.T) {
 }
 
 func generateJob(title, industry, jobLocation string, salaryMin, salaryMax int32) db.Job {
-	return db.Job{
+	job := db.Job{
 		ID:           utils.RandomInt(1, 1000),
 		Title:        title,
 		Industry:     industry,
@@ -2913,11 +3628,25 @@ func generateJob(title, industry, jobLocation string, salaryMin, salaryMax int32
 		SalaryMin:    salaryMin,
 		SalaryMax:    salaryMax,
 		Requirements: utils.RandomString(5),
+		Status:       db.JobStatusPublished,
+		PublishedAt: sql.NullTime{
+			Time:  time.Now(),
+			Valid: true,
+		},
+		EmploymentType: db.EmploymentTypeFullTime,
+		SeniorityLevel: db.SeniorityLevelMid,
+		RemotePolicy:   db.RemotePolicyRemote,
+		SalaryCurrency: "EUR",
+		SalaryPeriod:   db.SalaryPeriodMonth,
 	}
+	job.SalaryMinAnnual, job.SalaryMaxAnnual, _ = salary.AnnualizeRange(
+		job.SalaryMin, job.SalaryMax, job.SalaryPeriod, eurRate.Rate)
+
+	return job
 }
 
 func generateRandomJob() db.Job {
-	return db.Job{
+	job := db.Job{
 		ID:           utils.RandomInt(1, 1000),
 		Title:        utils.RandomString(4),
 		Industry:     utils.RandomString(2),
@@ -2926,7 +3655,21 @@ func generateRandomJob() db.Job {
 		SalaryMin:    utils.RandomInt(100, 200),
 		SalaryMax:    utils.RandomInt(201, 300),
 		Requirements: utils.RandomString(5),
+		Status:       db.JobStatusPublished,
+		PublishedAt: sql.NullTime{
+			Time:  time.Now(),
+			Valid: true,
+		},
+		EmploymentType: db.EmploymentTypeFullTime,
+		SeniorityLevel: db.SeniorityLevelMid,
+		RemotePolicy:   db.RemotePolicyRemote,
+		SalaryCurrency: "EUR",
+		SalaryPeriod:   db.SalaryPeriodMonth,
 	}
+	job.SalaryMinAnnual, job.SalaryMaxAnnual, _ = salary.AnnualizeRange(
+		job.SalaryMin, job.SalaryMax, job.SalaryPeriod, eurRate.Rate)
+
+	return job
 }
 
 func requireBodyMatchJob(t *testing.T, body *bytes.Buffer, job db.Job, skills []db.ListJobSkillsByJobIDRow) {
@@ -2945,6 +3688,12 @@ func requireBodyMatchJob(t *testing.T, body *bytes.Buffer, job db.Job, skills []
 	require.Equal(t, job.SalaryMax, gotJob.SalaryMax)
 	require.Equal(t, job.Requirements, gotJob.Requirements)
 	require.Equal(t, skills, gotJob.RequiredSkills)
+	require.Equal(t, job.Status, gotJob.Status)
+	require.Equal(t, job.EmploymentType, gotJob.EmploymentType)
+	require.Equal(t, job.SeniorityLevel, gotJob.SeniorityLevel)
+	require.Equal(t, job.RemotePolicy, gotJob.RemotePolicy)
+	require.Equal(t, job.SalaryCurrency, gotJob.SalaryCurrency)
+	require.Equal(t, job.SalaryPeriod, gotJob.SalaryPeriod)
 }
 
 func requireBodyMatchJobDetails(t *testing.T, body *bytes.Buffer, row db.GetJobDetailsRow) {
@@ -3002,22 +3751,31 @@ func requireBodyMatchJobs(t *testing.T, body *bytes.Buffer, jobs interface{}) {
 		for i := 0; i < len(j); i++ {
 			require.Equal(t, j[i], gotJobRows[i])
 		}
-	case []*esearch.Job:
-		var gotJobRows []esearch.Job
-		err = json.Unmarshal(data, &gotJobRows)
+	case esearch.SearchJobsResult:
+		var gotResult esearch.SearchJobsResult
+		err = json.Unmarshal(data, &gotResult)
 		require.NoError(t, err)
 
-		for i := 0; i < len(j); i++ {
-			require.Equal(t, j[i].Title, gotJobRows[i].Title)
-			require.Equal(t, j[i].Industry, gotJobRows[i].Industry)
-			require.Equal(t, j[i].Description, gotJobRows[i].Description)
-			require.Equal(t, j[i].Location, gotJobRows[i].Location)
-			require.Equal(t, j[i].SalaryMin, gotJobRows[i].SalaryMin)
-			require.Equal(t, j[i].SalaryMax, gotJobRows[i].SalaryMax)
-			require.Equal(t, j[i].Requirements, gotJobRows[i].Requirements)
-			require.Equal(t, j[i].CompanyName, gotJobRows[i].CompanyName)
-			require.Equal(t, j[i].ID, gotJobRows[i].ID)
-			require.Equal(t, j[i].JobSkills, gotJobRows[i].JobSkills)
+		require.Equal(t, j.Total, gotResult.Total)
+		require.Equal(t, j.Page, gotResult.Page)
+		require.Equal(t, j.PageSize, gotResult.PageSize)
+		require.Equal(t, j.TotalPages, gotResult.TotalPages)
+		require.Equal(t, j.Facets, gotResult.Facets)
+		require.Len(t, gotResult.Jobs, len(j.Jobs))
+		for i := 0; i < len(j.Jobs); i++ {
+			require.Equal(t, j.Jobs[i].Score, gotResult.Jobs[i].Score)
+			require.Equal(t, j.Jobs[i].Highlights, gotResult.Jobs[i].Highlights)
+			require.Equal(t, string(j.Jobs[i].Explanation), string(gotResult.Jobs[i].Explanation))
+			require.Equal(t, j.Jobs[i].Title, gotResult.Jobs[i].Title)
+			require.Equal(t, j.Jobs[i].Industry, gotResult.Jobs[i].Industry)
+			require.Equal(t, j.Jobs[i].Description, gotResult.Jobs[i].Description)
+			require.Equal(t, j.Jobs[i].Location, gotResult.Jobs[i].Location)
+			require.Equal(t, j.Jobs[i].SalaryMin, gotResult.Jobs[i].SalaryMin)
+			require.Equal(t, j.Jobs[i].SalaryMax, gotResult.Jobs[i].SalaryMax)
+			require.Equal(t, j.Jobs[i].Requirements, gotResult.Jobs[i].Requirements)
+			require.Equal(t, j.Jobs[i].CompanyName, gotResult.Jobs[i].CompanyName)
+			require.Equal(t, j.Jobs[i].ID, gotResult.Jobs[i].ID)
+			require.Equal(t, j.Jobs[i].JobSkills, gotResult.Jobs[i].JobSkills)
 		}
 	case []db.ListJobsForEmployerRow:
 		var gotJobRows []db.ListJobsForEmployerRow
@@ -0,0 +1,182 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	db "github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/aalug/job-finder-go/internal/worker"
+	"github.com/aalug/job-finder-go/pkg/token"
+	"github.com/gin-gonic/gin"
+	"github.com/hibiken/asynq"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// loginFreeAttempts is the number of failed logins of an account
+	// that are allowed before the exponential back-off starts
+	loginFreeAttempts = 3
+	// loginIPFreeAttempts is the same for a client IP, it is higher because
+	// many users can share one address
+	loginIPFreeAttempts = 10
+	loginBackoffBase    = time.Second
+)
+
+var (
+	accountLockedError        = errors.New("account is temporarily locked because of too many failed login attempts")
+	tooManyLoginAttemptsError = errors.New("too many failed login attempts, try again later")
+)
+
+// loginAttemptSubject returns the subject that failed logins to the account are recorded for
+func loginAttemptSubject(accountType token.AccountType, email string) string {
+	return fmt.Sprintf("%s:%s", accountType, email)
+}
+
+// loginAttemptIPSubject returns the subject that failed logins from the client IP are recorded for
+func loginAttemptIPSubject(ctx *gin.Context) string {
+	return fmt.Sprintf("ip:%s", ctx.ClientIP())
+}
+
+// checkLoginAttempts checks if the account or the client IP is not locked because of failed logins.
+// Locked account gets 423 Locked, back-off (account or IP) gets 429 Too Many Requests.
+// Both set the Retry-After header. If login is not allowed, it writes
+// the error response and returns false.
+func (server *Server) checkLoginAttempts(ctx *gin.Context, accountType token.AccountType, email string) bool {
+	accountSubject := loginAttemptSubject(accountType, email)
+	loginAttempts, err := server.store.ListLoginAttempts(ctx, []string{accountSubject, loginAttemptIPSubject(ctx)})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	now := time.Now()
+	var lockedUntil time.Time
+	accountLocked := false
+	for _, loginAttempt := range loginAttempts {
+		if !loginAttempt.LockedUntil.After(now) {
+			continue
+		}
+		if loginAttempt.LockedUntil.After(lockedUntil) {
+			lockedUntil = loginAttempt.LockedUntil
+		}
+		if loginAttempt.Subject == accountSubject && server.accountLockedOut(loginAttempt) {
+			accountLocked = true
+		}
+	}
+
+	if lockedUntil.IsZero() {
+		return true
+	}
+
+	retryAfter := int(math.Ceil(lockedUntil.Sub(now).Seconds()))
+	ctx.Header("Retry-After", strconv.Itoa(retryAfter))
+	if accountLocked {
+		ctx.JSON(http.StatusLocked, errorResponse(accountLockedError))
+		return false
+	}
+
+	ctx.JSON(http.StatusTooManyRequests, errorResponse(tooManyLoginAttemptsError))
+	return false
+}
+
+// recordFailedLogin records a failed login to the account from the client IP.
+// When the account gets locked by this attempt, the owner of the account is notified
+// by email and true is returned.
+func (server *Server) recordFailedLogin(ctx *gin.Context, accountType token.AccountType, email string) (bool, error) {
+	if err := server.recordFailedLoginFromIP(ctx); err != nil {
+		return false, err
+	}
+
+	loginAttempt, err := server.recordFailedLoginAttempt(ctx, loginAttemptSubject(accountType, email), loginFreeAttempts, server.config.LoginMaxAttempts)
+	if err != nil {
+		return false, err
+	}
+
+	if !server.accountLockedOut(loginAttempt) {
+		return false, nil
+	}
+
+	emailAccountType := "users"
+	if accountType == token.AccountTypeEmployer {
+		emailAccountType = "employers"
+	}
+	taskPayload := &worker.PayloadSendAccountLockedEmail{
+		Email:       email,
+		AccountType: emailAccountType,
+		LockedUntil: loginAttempt.LockedUntil,
+	}
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.ProcessIn(10 * time.Second),
+		asynq.Queue(worker.QueueCritical),
+	}
+
+	err = server.taskDistributor.DistributeTaskSendAccountLockedEmail(ctx, taskPayload, opts...)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// recordFailedLoginFromIP records a failed login from the client IP only.
+// It is used when there is no account that the attempt could be recorded for.
+func (server *Server) recordFailedLoginFromIP(ctx *gin.Context) error {
+	_, err := server.recordFailedLoginAttempt(ctx, loginAttemptIPSubject(ctx), loginIPFreeAttempts, 0)
+	return err
+}
+
+// recordFailedLoginAttempt increments the failed attempts of the subject and locks it
+// for the back-off duration. When maxAttempts is reached (0 means never),
+// the subject is locked for the whole lockout duration.
+func (server *Server) recordFailedLoginAttempt(ctx *gin.Context, subject string, freeAttempts int, maxAttempts int) (db.LoginAttempt, error) {
+	// failures older than the lockout duration are forgotten
+	loginAttempt, err := server.store.RecordFailedLoginAttempt(ctx, db.RecordFailedLoginAttemptParams{
+		Subject:     subject,
+		ResetBefore: time.Now().Add(-server.config.LoginLockoutDuration),
+	})
+	if err != nil {
+		return loginAttempt, err
+	}
+
+	failedAttempts := int(loginAttempt.FailedAttempts)
+	if failedAttempts <= freeAttempts {
+		return loginAttempt, nil
+	}
+
+	lockDuration := server.config.LoginLockoutDuration
+	if maxAttempts == 0 || failedAttempts < maxAttempts {
+		lockDuration = loginBackoffDuration(failedAttempts-freeAttempts, lockDuration)
+	}
+
+	return server.store.LockLoginAttempt(ctx, db.LockLoginAttemptParams{
+		Subject:     subject,
+		LockedUntil: time.Now().Add(lockDuration),
+	})
+}
+
+// accountLockedOut checks if the failed logins of the account reached the maximum.
+// LOGIN_MAX_ATTEMPTS set to 0 disables the lockout.
+func (server *Server) accountLockedOut(loginAttempt db.LoginAttempt) bool {
+	return server.config.LoginMaxAttempts > 0 && int(loginAttempt.FailedAttempts) >= server.config.LoginMaxAttempts
+}
+
+// resetLoginAttempts forgets the failed logins of the account after a successful login
+func (server *Server) resetLoginAttempts(ctx *gin.Context, accountType token.AccountType, email string) error {
+	return server.store.DeleteLoginAttempt(ctx, loginAttemptSubject(accountType, email))
+}
+
+// loginBackoffDuration returns the back-off after the n-th failed attempt over the free ones.
+// It doubles with every attempt and never exceeds max.
+func loginBackoffDuration(n int, max time.Duration) time.Duration {
+	if n > 30 {
+		return max
+	}
+	backoff := loginBackoffBase << (n - 1)
+	if backoff > max {
+		return max
+	}
+	return backoff
+}
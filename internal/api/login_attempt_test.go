@@ -0,0 +1,425 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	mockdb "github.com/aalug/job-finder-go/internal/db/mock"
+	db "github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/aalug/job-finder-go/internal/worker"
+	mockworker "github.com/aalug/job-finder-go/internal/worker/mock"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestLoginAttemptsAPI(t *testing.T) {
+	user, password := generateRandomUser(t)
+	user.IsEmailVerified = true
+	employer, employerPassword, _ := generateRandomEmployerAndCompany(t)
+	employer.IsEmailVerified = true
+
+	userSubject := "user:" + user.Email
+	employerSubject := "employer:" + employer.Email
+
+	testCases := []struct {
+		name          string
+		path          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Too Many Requests IP",
+			path: "/users/login",
+			body: gin.H{
+				"email":    user.Email,
+				"password": password,
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Eq([]string{userSubject, "ip:"})).
+					Times(1).
+					Return([]db.LoginAttempt{
+						{Subject: "ip:", FailedAttempts: 12, LockedUntil: time.Now().Add(30 * time.Second)},
+					}, nil)
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+				retryAfter, err := strconv.Atoi(recorder.Header().Get("Retry-After"))
+				require.NoError(t, err)
+				require.InDelta(t, 30, retryAfter, 1)
+			},
+		},
+		{
+			name: "Too Many Requests Account Back-off",
+			path: "/users/login",
+			body: gin.H{
+				"email":    user.Email,
+				"password": password,
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{
+						{Subject: userSubject, FailedAttempts: 4, LockedUntil: time.Now().Add(time.Second)},
+					}, nil)
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+				require.Equal(t, "1", recorder.Header().Get("Retry-After"))
+			},
+		},
+		{
+			name: "Account Locked",
+			path: "/users/login",
+			body: gin.H{
+				"email":    user.Email,
+				"password": password,
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{
+						{Subject: userSubject, FailedAttempts: 5, LockedUntil: time.Now().Add(time.Minute)},
+						{Subject: "ip:", FailedAttempts: 5, LockedUntil: time.Now().Add(time.Second)},
+					}, nil)
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusLocked, recorder.Code)
+				require.NotEmpty(t, recorder.Header().Get("Retry-After"))
+			},
+		},
+		{
+			name: "Expired Lock",
+			path: "/users/login",
+			body: gin.H{
+				"email":    user.Email,
+				"password": "wrong password",
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{
+						{Subject: userSubject, FailedAttempts: 5, LockedUntil: time.Now().Add(-time.Second)},
+					}, nil)
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					RecordFailedLoginAttempt(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(db.LoginAttempt{FailedAttempts: 1}, nil)
+				store.EXPECT().
+					LockLoginAttempt(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "Back-off Starts",
+			path: "/users/login",
+			body: gin.H{
+				"email":    user.Email,
+				"password": "wrong password",
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{}, nil)
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					RecordFailedLoginAttempt(gomock.Any(), gomock.Any()).
+					Times(2).
+					DoAndReturn(func(_ interface{}, arg db.RecordFailedLoginAttemptParams) (db.LoginAttempt, error) {
+						require.WithinDuration(t, time.Now().Add(-time.Minute), arg.ResetBefore, time.Second)
+						if arg.Subject == userSubject {
+							return db.LoginAttempt{Subject: arg.Subject, FailedAttempts: loginFreeAttempts + 1}, nil
+						}
+						return db.LoginAttempt{Subject: arg.Subject, FailedAttempts: 1}, nil
+					})
+				store.EXPECT().
+					LockLoginAttempt(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.LockLoginAttemptParams) (db.LoginAttempt, error) {
+						require.Equal(t, userSubject, arg.Subject)
+						require.WithinDuration(t, time.Now().Add(loginBackoffBase), arg.LockedUntil, 100*time.Millisecond)
+						return db.LoginAttempt{Subject: arg.Subject, FailedAttempts: loginFreeAttempts + 1, LockedUntil: arg.LockedUntil}, nil
+					})
+				distributor.EXPECT().
+					DistributeTaskSendAccountLockedEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "User Account Gets Locked",
+			path: "/users/login",
+			body: gin.H{
+				"email":    user.Email,
+				"password": "wrong password",
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{}, nil)
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					RecordFailedLoginAttempt(gomock.Any(), gomock.Any()).
+					Times(2).
+					DoAndReturn(func(_ interface{}, arg db.RecordFailedLoginAttemptParams) (db.LoginAttempt, error) {
+						if arg.Subject == userSubject {
+							return db.LoginAttempt{Subject: arg.Subject, FailedAttempts: 5}, nil
+						}
+						return db.LoginAttempt{Subject: arg.Subject, FailedAttempts: loginIPFreeAttempts + 1}, nil
+					})
+				store.EXPECT().
+					LockLoginAttempt(gomock.Any(), gomock.Any()).
+					Times(2).
+					DoAndReturn(func(_ interface{}, arg db.LockLoginAttemptParams) (db.LoginAttempt, error) {
+						if arg.Subject == userSubject {
+							// the whole lockout duration for the account, only the back-off for the IP
+							require.WithinDuration(t, time.Now().Add(time.Minute), arg.LockedUntil, 100*time.Millisecond)
+						} else {
+							require.WithinDuration(t, time.Now().Add(loginBackoffBase), arg.LockedUntil, 100*time.Millisecond)
+						}
+						return db.LoginAttempt{Subject: arg.Subject, FailedAttempts: 5, LockedUntil: arg.LockedUntil}, nil
+					})
+				distributor.EXPECT().
+					DistributeTaskSendAccountLockedEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, payload *worker.PayloadSendAccountLockedEmail, _ ...interface{}) error {
+						require.Equal(t, user.Email, payload.Email)
+						require.Equal(t, "users", payload.AccountType)
+						require.WithinDuration(t, time.Now().Add(time.Minute), payload.LockedUntil, time.Second)
+						return nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusLocked, recorder.Code)
+			},
+		},
+		{
+			name: "Employer Account Gets Locked",
+			path: "/employers/login",
+			body: gin.H{
+				"email":    employer.Email,
+				"password": "wrong password",
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Eq([]string{employerSubject, "ip:"})).
+					Times(1).
+					Return([]db.LoginAttempt{}, nil)
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					RecordFailedLoginAttempt(gomock.Any(), gomock.Any()).
+					Times(2).
+					DoAndReturn(func(_ interface{}, arg db.RecordFailedLoginAttemptParams) (db.LoginAttempt, error) {
+						if arg.Subject == employerSubject {
+							return db.LoginAttempt{Subject: arg.Subject, FailedAttempts: 5}, nil
+						}
+						return db.LoginAttempt{Subject: arg.Subject, FailedAttempts: 1}, nil
+					})
+				store.EXPECT().
+					LockLoginAttempt(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.LoginAttempt{Subject: employerSubject, FailedAttempts: 5, LockedUntil: time.Now().Add(time.Minute)}, nil)
+				distributor.EXPECT().
+					DistributeTaskSendAccountLockedEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, payload *worker.PayloadSendAccountLockedEmail, _ ...interface{}) error {
+						require.Equal(t, employer.Email, payload.Email)
+						require.Equal(t, "employers", payload.AccountType)
+						return nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusLocked, recorder.Code)
+			},
+		},
+		{
+			name: "Successful Login Resets Attempts",
+			path: "/employers/login",
+			body: gin.H{
+				"email":    employer.Email,
+				"password": employerPassword,
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{
+						{Subject: employerSubject, FailedAttempts: 2, LockedUntil: time.Now().Add(-time.Minute)},
+					}, nil)
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MfaSetting{}, sql.ErrNoRows)
+				store.EXPECT().
+					DeleteLoginAttempt(gomock.Any(), gomock.Eq(employerSubject)).
+					Times(1).
+					Return(sql.ErrConnDone)
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error ListLoginAttempts",
+			path: "/users/login",
+			body: gin.H{
+				"email":    user.Email,
+				"password": password,
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{}, sql.ErrConnDone)
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error RecordFailedLoginAttempt",
+			path: "/users/login",
+			body: gin.H{
+				"email":    user.Email,
+				"password": "wrong password",
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{}, nil)
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					RecordFailedLoginAttempt(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.LoginAttempt{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error DistributeTaskSendAccountLockedEmail",
+			path: "/users/login",
+			body: gin.H{
+				"email":    user.Email,
+				"password": "wrong password",
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{}, nil)
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					RecordFailedLoginAttempt(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(db.LoginAttempt{FailedAttempts: 5}, nil)
+				store.EXPECT().
+					LockLoginAttempt(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.LoginAttempt{FailedAttempts: 5, LockedUntil: time.Now().Add(time.Minute)}, nil)
+				distributor.EXPECT().
+					DistributeTaskSendAccountLockedEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("some error"))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockworker.NewMockTaskDistributor(taskCtrl)
+
+			tc.buildStubs(store, taskDistributor)
+
+			server := newTestServer(t, store, nil, taskDistributor)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := BaseUrl + tc.path
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestLoginBackoffDuration(t *testing.T) {
+	require.Equal(t, time.Second, loginBackoffDuration(1, time.Hour))
+	require.Equal(t, 2*time.Second, loginBackoffDuration(2, time.Hour))
+	require.Equal(t, 8*time.Second, loginBackoffDuration(4, time.Hour))
+	require.Equal(t, time.Minute, loginBackoffDuration(10, time.Minute))
+	require.Equal(t, time.Minute, loginBackoffDuration(100, time.Minute))
+}
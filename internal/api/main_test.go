@@ -2,11 +2,13 @@ package api
 
 import (
 	"github.com/aalug/job-finder-go/internal/config"
+	mockdb "github.com/aalug/job-finder-go/internal/db/mock"
 	"github.com/aalug/job-finder-go/internal/db/sqlc"
 	"github.com/aalug/job-finder-go/internal/esearch"
 	"github.com/aalug/job-finder-go/internal/worker"
 	"github.com/aalug/job-finder-go/pkg/utils"
 	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 	"os"
 	"testing"
@@ -15,17 +17,26 @@ import (
 
 func newTestServer(t *testing.T, store db.Store, client esearch.ESearchClient, taskDistributor worker.TaskDistributor) *Server {
 	cfg := config.Config{
-		TokenSymmetricKey:   utils.RandomString(32),
-		AccessTokenDuration: time.Minute,
+		TokenSymmetricKey:    utils.RandomString(32),
+		AccessTokenDuration:  time.Minute,
+		RefreshTokenDuration: time.Hour,
+		MfaTokenDuration:     time.Minute,
+		LoginMaxAttempts:     5,
+		LoginLockoutDuration: time.Minute,
+		AdminEmails:          []string{testAdminEmail},
+	}
+
+	// tokens are not revoked unless a test sets up its own expectation first
+	if mockStore, ok := store.(*mockdb.MockStore); ok {
+		mockStore.EXPECT().
+			IsTokenRevoked(gomock.Any(), gomock.Any()).
+			AnyTimes().
+			Return(false, nil)
 	}
 
 	server, err := NewServer(cfg, store, client, taskDistributor)
 	require.NoError(t, err)
 
-	if client != nil {
-		server.esDetails.lastDocumentIndex = 1
-	}
-
 	return server
 }
 
@@ -0,0 +1,407 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	db "github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/aalug/job-finder-go/pkg/token"
+	"github.com/aalug/job-finder-go/pkg/totp"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+const (
+	mfaIssuer             = "Go Job Search"
+	mfaRecoveryCodesCount = 10
+)
+
+var (
+	mfaAlreadyEnabledError = errors.New("multi-factor authentication is already enabled")
+	mfaNotEnrolledError    = errors.New("multi-factor authentication has not been enrolled")
+	mfaNotEnabledError     = errors.New("multi-factor authentication is not enabled")
+	invalidMfaCodeError    = errors.New("invalid multi-factor authentication code")
+	invalidMfaTokenError   = errors.New("invalid multi-factor authentication token")
+)
+
+type enrollMfaResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningUri string `json:"provisioning_uri"`
+}
+
+// @Schemes
+// @Summary Enroll MFA
+// @Description Generate a new TOTP secret for the logged-in user or employer. The provisioning URI should be shown as a QR code, so it can be scanned with an authenticator app. MFA is not enabled until it is confirmed with a code.
+// @Tags mfa
+// @Produce json
+// @Success 200 {object} enrollMfaResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "MFA is already enabled"
+// @Failure 500 {object} ErrorResponse "Any other error"
+// @Security ApiKeyAuth
+// @Router /users/mfa/enroll [post]
+// @Router /employers/mfa/enroll [post]
+// enrollMfa handles MFA enrollment
+func (server *Server) enrollMfa(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	mfaSetting, err := server.store.GetMfaSetting(ctx, db.GetMfaSettingParams{
+		AccountType: string(authPayload.AccountType),
+		AccountID:   authPayload.AccountID,
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if err == nil && mfaSetting.IsEnabled {
+		ctx.JSON(http.StatusForbidden, errorResponse(mfaAlreadyEnabledError))
+		return
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	_, err = server.store.UpsertMfaSetting(ctx, db.UpsertMfaSettingParams{
+		AccountType: string(authPayload.AccountType),
+		AccountID:   authPayload.AccountID,
+		Secret:      secret,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, enrollMfaResponse{
+		Secret:          secret,
+		ProvisioningUri: totp.ProvisioningURI(secret, mfaIssuer, authPayload.Email),
+	})
+}
+
+type confirmMfaRequest struct {
+	Code string `json:"code" binding:"required,len=6,numeric"`
+}
+
+type confirmMfaResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// @Schemes
+// @Summary Confirm MFA
+// @Description Enable MFA of the logged-in user or employer by providing a code from the authenticator app. Returns recovery codes that can be used instead of the codes from the app. They are shown only once.
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @param ConfirmMfaRequest body confirmMfaRequest true "Code from the authenticator app"
+// @Success 200 {object} confirmMfaResponse
+// @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 401 {object} ErrorResponse "Unauthorized or invalid code"
+// @Failure 403 {object} ErrorResponse "MFA is already enabled"
+// @Failure 404 {object} ErrorResponse "MFA has not been enrolled"
+// @Failure 500 {object} ErrorResponse "Any other error"
+// @Security ApiKeyAuth
+// @Router /users/mfa/confirm [post]
+// @Router /employers/mfa/confirm [post]
+// confirmMfa handles confirmation of the MFA enrollment
+func (server *Server) confirmMfa(ctx *gin.Context) {
+	var request confirmMfaRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	mfaSetting, err := server.store.GetMfaSetting(ctx, db.GetMfaSettingParams{
+		AccountType: string(authPayload.AccountType),
+		AccountID:   authPayload.AccountID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(mfaNotEnrolledError))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if mfaSetting.IsEnabled {
+		ctx.JSON(http.StatusForbidden, errorResponse(mfaAlreadyEnabledError))
+		return
+	}
+
+	if !totp.ValidateCode(mfaSetting.Secret, request.Code, time.Now()) {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(invalidMfaCodeError))
+		return
+	}
+
+	recoveryCodes, err := totp.GenerateRecoveryCodes(mfaRecoveryCodesCount)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// recovery codes are stored hashed, the same way as passwords
+	hashedRecoveryCodes := make([]string, len(recoveryCodes))
+	for i, code := range recoveryCodes {
+		hashedRecoveryCodes[i], err = utils.HashPassword(code)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	_, err = server.store.EnableMfaTx(ctx, db.EnableMfaTxParams{
+		AccountType:         mfaSetting.AccountType,
+		AccountID:           mfaSetting.AccountID,
+		HashedRecoveryCodes: hashedRecoveryCodes,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, confirmMfaResponse{RecoveryCodes: recoveryCodes})
+}
+
+type disableMfaRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// @Schemes
+// @Summary Disable MFA
+// @Description Disable MFA of the logged-in user or employer. Requires a code from the authenticator app or a recovery code.
+// @Tags mfa
+// @Accept json
+// @param DisableMfaRequest body disableMfaRequest true "Code from the authenticator app or a recovery code"
+// @Success 204
+// @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 401 {object} ErrorResponse "Unauthorized or invalid code"
+// @Failure 404 {object} ErrorResponse "MFA is not enabled"
+// @Failure 500 {object} ErrorResponse "Any other error"
+// @Security ApiKeyAuth
+// @Router /users/mfa [delete]
+// @Router /employers/mfa [delete]
+// disableMfa handles disabling MFA
+func (server *Server) disableMfa(ctx *gin.Context) {
+	var request disableMfaRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	mfaSetting, err := server.store.GetMfaSetting(ctx, db.GetMfaSettingParams{
+		AccountType: string(authPayload.AccountType),
+		AccountID:   authPayload.AccountID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(mfaNotEnabledError))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if !mfaSetting.IsEnabled {
+		ctx.JSON(http.StatusNotFound, errorResponse(mfaNotEnabledError))
+		return
+	}
+
+	valid, err := server.checkMfaCode(ctx, mfaSetting, request.Code)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if !valid {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(invalidMfaCodeError))
+		return
+	}
+
+	err = server.store.DisableMfaTx(ctx, mfaSetting.AccountType, mfaSetting.AccountID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+type mfaRequiredResponse struct {
+	MfaRequired       bool      `json:"mfa_required"`
+	MfaToken          string    `json:"mfa_token"`
+	MfaTokenExpiresAt time.Time `json:"mfa_token_expires_at"`
+}
+
+// requireMfaLogin responds with an "mfa pending" token if the account has MFA enabled.
+// It returns true if the response has been written and the login must not continue.
+func (server *Server) requireMfaLogin(ctx *gin.Context, email string, accountType token.AccountType, accountID int32) bool {
+	mfaSetting, err := server.store.GetMfaSetting(ctx, db.GetMfaSettingParams{
+		AccountType: string(accountType),
+		AccountID:   accountID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return true
+	}
+
+	if !mfaSetting.IsEnabled {
+		return false
+	}
+
+	mfaToken, mfaPayload, err := server.tokenMaker.CreateMfaPendingToken(email, accountType, accountID, server.config.MfaTokenDuration)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return true
+	}
+
+	ctx.JSON(http.StatusAccepted, mfaRequiredResponse{
+		MfaRequired:       true,
+		MfaToken:          mfaToken,
+		MfaTokenExpiresAt: mfaPayload.ExpiredAt,
+	})
+	return true
+}
+
+type loginMfaRequest struct {
+	MfaToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+// verifyMfaLogin checks the "mfa pending" token and the code sent in the second step of the login.
+// The token can be used only once. If the login can not be completed, it writes
+// the error response and returns false.
+func (server *Server) verifyMfaLogin(ctx *gin.Context, accountType token.AccountType) (*token.Payload, bool) {
+	var request loginMfaRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return nil, false
+	}
+
+	mfaPayload, err := server.tokenMaker.VerifyToken(request.MfaToken)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return nil, false
+	}
+
+	if !mfaPayload.MfaPending || mfaPayload.AccountType != accountType {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(invalidMfaTokenError))
+		return nil, false
+	}
+
+	revoked, err := server.store.IsTokenRevoked(ctx, db.IsTokenRevokedParams{
+		ID:       mfaPayload.ID,
+		Email:    mfaPayload.Email,
+		IssuedAt: mfaPayload.IssuedAt,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return nil, false
+	}
+	if revoked {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(revokedTokenError))
+		return nil, false
+	}
+
+	// wrong codes count as failed logins, so they can not be guessed
+	if !server.checkLoginAttempts(ctx, accountType, mfaPayload.Email) {
+		return nil, false
+	}
+
+	mfaSetting, err := server.store.GetMfaSetting(ctx, db.GetMfaSettingParams{
+		AccountType: string(mfaPayload.AccountType),
+		AccountID:   mfaPayload.AccountID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(mfaNotEnabledError))
+			return nil, false
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return nil, false
+	}
+
+	if !mfaSetting.IsEnabled {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(mfaNotEnabledError))
+		return nil, false
+	}
+
+	valid, err := server.checkMfaCode(ctx, mfaSetting, request.Code)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return nil, false
+	}
+	if !valid {
+		locked, err := server.recordFailedLogin(ctx, accountType, mfaPayload.Email)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return nil, false
+		}
+		if locked {
+			ctx.JSON(http.StatusLocked, errorResponse(accountLockedError))
+			return nil, false
+		}
+
+		ctx.JSON(http.StatusUnauthorized, errorResponse(invalidMfaCodeError))
+		return nil, false
+	}
+
+	// the "mfa pending" token can not be exchanged again
+	err = server.store.RevokeToken(ctx, db.RevokeTokenParams{
+		ID:        mfaPayload.ID,
+		Email:     mfaPayload.Email,
+		ExpiresAt: mfaPayload.ExpiredAt,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return nil, false
+	}
+
+	return mfaPayload, true
+}
+
+// checkMfaCode checks if the code is a valid TOTP code or one of the unused recovery codes.
+// A matching recovery code is marked as used.
+func (server *Server) checkMfaCode(ctx *gin.Context, mfaSetting db.MfaSetting, code string) (bool, error) {
+	if totp.ValidateCode(mfaSetting.Secret, code, time.Now()) {
+		return true, nil
+	}
+
+	recoveryCodes, err := server.store.ListUnusedMfaRecoveryCodes(ctx, db.ListUnusedMfaRecoveryCodesParams{
+		AccountType: mfaSetting.AccountType,
+		AccountID:   mfaSetting.AccountID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	for _, recoveryCode := range recoveryCodes {
+		if utils.CheckPassword(code, recoveryCode.HashedCode) != nil {
+			continue
+		}
+
+		_, err = server.store.UseMfaRecoveryCode(ctx, recoveryCode.ID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				// used by a concurrent request
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	}
+
+	return false, nil
+}
@@ -0,0 +1,807 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	mockdb "github.com/aalug/job-finder-go/internal/db/mock"
+	db "github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/aalug/job-finder-go/pkg/token"
+	"github.com/aalug/job-finder-go/pkg/totp"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEnrollMfaAPI(t *testing.T) {
+	user, _ := generateRandomUser(t)
+	employer, _, _ := generateRandomEmployerAndCompany(t)
+
+	testCases := []struct {
+		name          string
+		path          string
+		setupAuth     func(t *testing.T, r *http.Request, maker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK User",
+			path: "/users/mfa/enroll",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Eq(db.GetMfaSettingParams{
+						AccountType: string(token.AccountTypeUser),
+						AccountID:   user.ID,
+					})).
+					Times(1).
+					Return(db.MfaSetting{}, sql.ErrNoRows)
+				store.EXPECT().
+					UpsertMfaSetting(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MfaSetting{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res enrollMfaResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.NotEmpty(t, res.Secret)
+				require.True(t, strings.HasPrefix(res.ProvisioningUri, "otpauth://totp/"))
+				require.Contains(t, res.ProvisioningUri, res.Secret)
+			},
+		},
+		{
+			name: "OK Employer Not Confirmed Yet",
+			path: "/employers/mfa/enroll",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MfaSetting{IsEnabled: false}, nil)
+				store.EXPECT().
+					UpsertMfaSetting(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MfaSetting{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Already Enabled",
+			path: "/users/mfa/enroll",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MfaSetting{IsEnabled: true}, nil)
+				store.EXPECT().
+					UpsertMfaSetting(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:      "Unauthorized",
+			path:      "/users/mfa/enroll",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					UpsertMfaSetting(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error GetMfaSetting",
+			path: "/users/mfa/enroll",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MfaSetting{}, sql.ErrConnDone)
+				store.EXPECT().
+					UpsertMfaSetting(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error UpsertMfaSetting",
+			path: "/users/mfa/enroll",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MfaSetting{}, sql.ErrNoRows)
+				store.EXPECT().
+					UpsertMfaSetting(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MfaSetting{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil, nil)
+			recorder := httptest.NewRecorder()
+
+			url := BaseUrl + tc.path
+			req, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, req, server.tokenMaker)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestConfirmMfaAPI(t *testing.T) {
+	user, _ := generateRandomUser(t)
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+
+	mfaSetting := db.MfaSetting{
+		AccountType: string(token.AccountTypeUser),
+		AccountID:   user.ID,
+		Secret:      secret,
+		IsEnabled:   false,
+	}
+
+	testCases := []struct {
+		name          string
+		body          func(t *testing.T) gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: func(t *testing.T) gin.H {
+				return gin.H{"code": generateMfaCode(t, secret)}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(mfaSetting, nil)
+				store.EXPECT().
+					EnableMfaTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.EnableMfaTxParams) (db.MfaSetting, error) {
+						require.Equal(t, mfaSetting.AccountType, arg.AccountType)
+						require.Equal(t, mfaSetting.AccountID, arg.AccountID)
+						require.Len(t, arg.HashedRecoveryCodes, mfaRecoveryCodesCount)
+						return db.MfaSetting{IsEnabled: true}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res confirmMfaResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Len(t, res.RecoveryCodes, mfaRecoveryCodesCount)
+			},
+		},
+		{
+			name: "Invalid Code Format",
+			body: func(t *testing.T) gin.H {
+				return gin.H{"code": "abc"}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Wrong Code",
+			body: func(t *testing.T) gin.H {
+				return gin.H{"code": wrongMfaCode(t, secret)}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(mfaSetting, nil)
+				store.EXPECT().
+					EnableMfaTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "Not Enrolled",
+			body: func(t *testing.T) gin.H {
+				return gin.H{"code": generateMfaCode(t, secret)}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MfaSetting{}, sql.ErrNoRows)
+				store.EXPECT().
+					EnableMfaTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "Already Enabled",
+			body: func(t *testing.T) gin.H {
+				return gin.H{"code": generateMfaCode(t, secret)}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				enabled := mfaSetting
+				enabled.IsEnabled = true
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(enabled, nil)
+				store.EXPECT().
+					EnableMfaTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error EnableMfaTx",
+			body: func(t *testing.T) gin.H {
+				return gin.H{"code": generateMfaCode(t, secret)}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(mfaSetting, nil)
+				store.EXPECT().
+					EnableMfaTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MfaSetting{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil, nil)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body(t))
+			require.NoError(t, err)
+
+			url := BaseUrl + "/users/mfa/confirm"
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDisableMfaAPI(t *testing.T) {
+	employer, _, _ := generateRandomEmployerAndCompany(t)
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+
+	mfaSetting := db.MfaSetting{
+		AccountType: string(token.AccountTypeEmployer),
+		AccountID:   employer.ID,
+		Secret:      secret,
+		IsEnabled:   true,
+	}
+
+	recoveryCode := "abcde-12345"
+	hashedRecoveryCode, err := utils.HashPassword(recoveryCode)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		body          func(t *testing.T) gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK TOTP Code",
+			body: func(t *testing.T) gin.H {
+				return gin.H{"code": generateMfaCode(t, secret)}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(mfaSetting, nil)
+				store.EXPECT().
+					ListUnusedMfaRecoveryCodes(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					DisableMfaTx(gomock.Any(), gomock.Eq(mfaSetting.AccountType), gomock.Eq(mfaSetting.AccountID)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNoContent, recorder.Code)
+			},
+		},
+		{
+			name: "OK Recovery Code",
+			body: func(t *testing.T) gin.H {
+				return gin.H{"code": recoveryCode}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(mfaSetting, nil)
+				store.EXPECT().
+					ListUnusedMfaRecoveryCodes(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.MfaRecoveryCode{{ID: 1, HashedCode: hashedRecoveryCode}}, nil)
+				store.EXPECT().
+					UseMfaRecoveryCode(gomock.Any(), gomock.Eq(int64(1))).
+					Times(1).
+					Return(db.MfaRecoveryCode{ID: 1, IsUsed: true}, nil)
+				store.EXPECT().
+					DisableMfaTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNoContent, recorder.Code)
+			},
+		},
+		{
+			name: "Invalid Code",
+			body: func(t *testing.T) gin.H {
+				return gin.H{"code": "invalid"}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(mfaSetting, nil)
+				store.EXPECT().
+					ListUnusedMfaRecoveryCodes(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.MfaRecoveryCode{{ID: 1, HashedCode: hashedRecoveryCode}}, nil)
+				store.EXPECT().
+					UseMfaRecoveryCode(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					DisableMfaTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "Not Enabled",
+			body: func(t *testing.T) gin.H {
+				return gin.H{"code": generateMfaCode(t, secret)}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MfaSetting{}, sql.ErrNoRows)
+				store.EXPECT().
+					DisableMfaTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "Bad Request",
+			body: func(t *testing.T) gin.H {
+				return gin.H{}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error DisableMfaTx",
+			body: func(t *testing.T) gin.H {
+				return gin.H{"code": generateMfaCode(t, secret)}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(mfaSetting, nil)
+				store.EXPECT().
+					DisableMfaTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil, nil)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body(t))
+			require.NoError(t, err)
+
+			url := BaseUrl + "/employers/mfa"
+			req, err := http.NewRequest(http.MethodDelete, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestLoginEmployerMfaAPI(t *testing.T) {
+	employer, _, company := generateRandomEmployerAndCompany(t)
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+
+	mfaSetting := db.MfaSetting{
+		AccountType: string(token.AccountTypeEmployer),
+		AccountID:   employer.ID,
+		Secret:      secret,
+		IsEnabled:   true,
+	}
+
+	testCases := []struct {
+		name          string
+		body          func(t *testing.T, maker token.Maker) gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: func(t *testing.T, maker token.Maker) gin.H {
+				return gin.H{
+					"mfa_token": createMfaPendingToken(t, maker, employer.Email, token.AccountTypeEmployer, employer.ID),
+					"code":      generateMfaCode(t, secret),
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{}, nil)
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(mfaSetting, nil)
+				store.EXPECT().
+					RevokeToken(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+				store.EXPECT().
+					GetEmployerByEmail(gomock.Any(), gomock.Eq(employer.Email)).
+					Times(1).
+					Return(employer, nil)
+				store.EXPECT().
+					DeleteLoginAttempt(gomock.Any(), gomock.Eq("employer:"+employer.Email)).
+					Times(1).
+					Return(nil)
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Session{Email: employer.Email}, nil)
+				store.EXPECT().
+					GetCompanyByID(gomock.Any(), gomock.Eq(employer.CompanyID)).
+					Times(1).
+					Return(company, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res loginEmployerResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.NotEmpty(t, res.AccessToken)
+				require.NotEmpty(t, res.RefreshToken)
+			},
+		},
+		{
+			name: "Access Token Instead Of MFA Token",
+			body: func(t *testing.T, maker token.Maker) gin.H {
+				tkn, _, err := maker.CreateToken(employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+				require.NoError(t, err)
+				return gin.H{
+					"mfa_token": tkn,
+					"code":      generateMfaCode(t, secret),
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "User MFA Token",
+			body: func(t *testing.T, maker token.Maker) gin.H {
+				return gin.H{
+					"mfa_token": createMfaPendingToken(t, maker, employer.Email, token.AccountTypeUser, employer.ID),
+					"code":      generateMfaCode(t, secret),
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "Used MFA Token",
+			body: func(t *testing.T, maker token.Maker) gin.H {
+				return gin.H{
+					"mfa_token": createMfaPendingToken(t, maker, employer.Email, token.AccountTypeEmployer, employer.ID),
+					"code":      generateMfaCode(t, secret),
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					IsTokenRevoked(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(true, nil)
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "Wrong Code",
+			body: func(t *testing.T, maker token.Maker) gin.H {
+				return gin.H{
+					"mfa_token": createMfaPendingToken(t, maker, employer.Email, token.AccountTypeEmployer, employer.ID),
+					"code":      wrongMfaCode(t, secret),
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{}, nil)
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(mfaSetting, nil)
+				store.EXPECT().
+					ListUnusedMfaRecoveryCodes(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.MfaRecoveryCode{}, nil)
+				store.EXPECT().
+					RecordFailedLoginAttempt(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(db.LoginAttempt{FailedAttempts: 1}, nil)
+				store.EXPECT().
+					RevokeToken(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "MFA Disabled In The Meantime",
+			body: func(t *testing.T, maker token.Maker) gin.H {
+				return gin.H{
+					"mfa_token": createMfaPendingToken(t, maker, employer.Email, token.AccountTypeEmployer, employer.ID),
+					"code":      generateMfaCode(t, secret),
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{}, nil)
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MfaSetting{}, sql.ErrNoRows)
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error RevokeToken",
+			body: func(t *testing.T, maker token.Maker) gin.H {
+				return gin.H{
+					"mfa_token": createMfaPendingToken(t, maker, employer.Email, token.AccountTypeEmployer, employer.ID),
+					"code":      generateMfaCode(t, secret),
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{}, nil)
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(mfaSetting, nil)
+				store.EXPECT().
+					RevokeToken(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(sql.ErrConnDone)
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "Bad Request",
+			body: func(t *testing.T, maker token.Maker) gin.H {
+				return gin.H{"code": generateMfaCode(t, secret)}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil, nil)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body(t, server.tokenMaker))
+			require.NoError(t, err)
+
+			url := BaseUrl + "/employers/login/mfa"
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+// generateMfaCode generates the current TOTP code for the secret
+func generateMfaCode(t *testing.T, secret string) string {
+	code, err := totp.GenerateCode(secret, time.Now())
+	require.NoError(t, err)
+	return code
+}
+
+// wrongMfaCode returns a 6-digit code that is not valid for the secret right now
+func wrongMfaCode(t *testing.T, secret string) string {
+	for {
+		code := generateMfaCode(t, secret)
+		wrong := []byte(code)
+		wrong[0] = '0' + (wrong[0]-'0'+5)%10
+		if !totp.ValidateCode(secret, string(wrong), time.Now()) {
+			return string(wrong)
+		}
+	}
+}
+
+// createMfaPendingToken creates an "mfa pending" token returned by the first step of the login
+func createMfaPendingToken(t *testing.T, maker token.Maker, email string, accountType token.AccountType, accountID int32) string {
+	tkn, payload, err := maker.CreateMfaPendingToken(email, accountType, accountID, time.Minute)
+	require.NoError(t, err)
+	require.True(t, payload.MfaPending)
+	return tkn
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"errors"
 	"fmt"
+	db "github.com/aalug/job-finder-go/internal/db/sqlc"
 	"github.com/aalug/job-finder-go/pkg/token"
 	"github.com/gin-gonic/gin"
 	"net/http"
@@ -12,11 +13,24 @@ import (
 const (
 	authorizationHeaderKey  = "authorization"
 	authorizationTypeBearer = "bearer"
+	authorizationTypeApiKey = "apikey"
 	authorizationPayloadKey = "authorization_payload"
+	authorizationApiKeyKey  = "authorization_api_key"
+	authorizationAdminKey   = "authorization_admin"
 )
 
-// AuthMiddleware creates a gin middleware for authorization
-func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
+var (
+	revokedTokenError     = errors.New("token has been revoked")
+	accountNotFoundError  = errors.New("account that this token was issued for does not exist")
+	mfaPendingTokenError  = errors.New("multi-factor authentication has not been completed")
+	notAccessTokenError   = errors.New("token is not an access token")
+	onlyAdminsAccessError = errors.New("only admins can access this endpoint")
+)
+
+// AuthMiddleware creates a gin middleware for authorization.
+// It accepts access tokens ("bearer") and API keys of employers ("ApiKey").
+// Tokens that were revoked (logout, password change) are rejected.
+func authMiddleware(tokenMaker token.Maker, store db.Store) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authorizationHeader := ctx.GetHeader(authorizationHeaderKey)
 
@@ -33,17 +47,20 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 			return
 		}
 
+		var payload *token.Payload
+		var ok bool
 		authorizationType := strings.ToLower(fields[0])
-		if authorizationType != authorizationTypeBearer {
+		switch authorizationType {
+		case authorizationTypeBearer:
+			payload, ok = verifyAccessToken(ctx, tokenMaker, store, fields[1])
+		case authorizationTypeApiKey:
+			payload, ok = verifyApiKey(ctx, store, fields[1])
+		default:
 			err := fmt.Errorf("unsupported authorization type %s", authorizationType)
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
 			return
 		}
-
-		accessToken := fields[1]
-		payload, err := tokenMaker.VerifyToken(accessToken)
-		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+		if !ok {
 			return
 		}
 
@@ -51,3 +68,84 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 		ctx.Next()
 	}
 }
+
+// verifyAccessToken checks the access token and returns its payload.
+// If the token is invalid, it writes the error response and returns false.
+func verifyAccessToken(ctx *gin.Context, tokenMaker token.Maker, store db.Store, accessToken string) (*token.Payload, bool) {
+	payload, err := tokenMaker.VerifyToken(accessToken)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+		return nil, false
+	}
+
+	// "mfa pending" tokens can only be exchanged for access tokens
+	if payload.MfaPending {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(mfaPendingTokenError))
+		return nil, false
+	}
+
+	// refresh tokens (and tokens issued before the token types) are not access tokens
+	if payload.TokenType != token.TokenTypeAccess {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(notAccessTokenError))
+		return nil, false
+	}
+
+	revoked, err := store.IsTokenRevoked(ctx, db.IsTokenRevokedParams{
+		ID:       payload.ID,
+		Email:    payload.Email,
+		IssuedAt: payload.IssuedAt,
+	})
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		return nil, false
+	}
+	if revoked {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(revokedTokenError))
+		return nil, false
+	}
+
+	return payload, true
+}
+
+// requireUser creates a gin middleware that allows only users to access the route.
+// It has to be used after authMiddleware.
+func requireUser() gin.HandlerFunc {
+	return requireAccountType(token.AccountTypeUser, onlyUsersAccessError)
+}
+
+// requireEmployer creates a gin middleware that allows only employers to access the route.
+// It has to be used after authMiddleware.
+func requireEmployer() gin.HandlerFunc {
+	return requireAccountType(token.AccountTypeEmployer, onlyEmployersAccessError)
+}
+
+// requireAdmin creates a gin middleware that allows only the accounts
+// with emails from the ADMIN_EMAILS config to access the route.
+// It has to be used after authMiddleware.
+func requireAdmin(adminEmails []string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+		for _, email := range adminEmails {
+			if strings.EqualFold(email, authPayload.Email) {
+				ctx.Set(authorizationAdminKey, true)
+				ctx.Next()
+				return
+			}
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(onlyAdminsAccessError))
+	}
+}
+
+// requireAccountType aborts with 403 if the authenticated account is not of the given type
+func requireAccountType(accountType token.AccountType, accessError error) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+		if authPayload.AccountType != accountType {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(accessError))
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -1,9 +1,14 @@
 package api
 
 import (
+	"database/sql"
 	"fmt"
+	mockdb "github.com/aalug/job-finder-go/internal/db/mock"
+	db "github.com/aalug/job-finder-go/internal/db/sqlc"
 	"github.com/aalug/job-finder-go/pkg/token"
+	"github.com/aalug/job-finder-go/pkg/utils"
 	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 	"net/http"
 	"net/http/httptest"
@@ -17,33 +22,42 @@ func addAuthorization(
 	tokenMaker token.Maker,
 	authorizationType string,
 	email string,
+	accountType token.AccountType,
+	accountID int32,
 	duration time.Duration,
 ) {
-	tkn, err := tokenMaker.CreateToken(email, duration)
+	tkn, payload, err := tokenMaker.CreateToken(email, accountType, accountID, duration)
 	require.NoError(t, err)
+	require.NotEmpty(t, payload)
 
 	authorizationHeader := fmt.Sprintf("%s %s", authorizationType, tkn)
 	request.Header.Set(authorizationHeaderKey, authorizationHeader)
 }
 
 func TestAuthMiddleware(t *testing.T) {
+	employer, _, _ := generateRandomEmployerAndCompany(t)
+	key, apiKey := generateTestApiKey(t, employer.ID, []string{})
+
 	testCases := []struct {
 		name          string
 		setupAuth     func(t *testing.T, r *http.Request, maker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
 	}{
 		{
 			name: "OK",
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, "user@example.com", time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, "user@example.com", token.AccountTypeUser, 1, time.Minute)
 			},
+			buildStubs: func(store *mockdb.MockStore) {},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
 		{
-			name:      "No authorization header",
-			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {},
+			name:       "No authorization header",
+			setupAuth:  func(t *testing.T, r *http.Request, maker token.Maker) {},
+			buildStubs: func(store *mockdb.MockStore) {},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
@@ -51,8 +65,9 @@ func TestAuthMiddleware(t *testing.T) {
 		{
 			name: "Unsupported authorization type",
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, "unsupported auth type", "user@example.com", time.Minute)
+				addAuthorization(t, r, maker, "unsupported auth type", "user@example.com", token.AccountTypeUser, 1, time.Minute)
 			},
+			buildStubs: func(store *mockdb.MockStore) {},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
@@ -60,8 +75,9 @@ func TestAuthMiddleware(t *testing.T) {
 		{
 			name: "Invalid authorization format",
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, "", "user@example.com", time.Minute)
+				addAuthorization(t, r, maker, "", "user@example.com", token.AccountTypeUser, 1, time.Minute)
 			},
+			buildStubs: func(store *mockdb.MockStore) {},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
@@ -69,21 +85,189 @@ func TestAuthMiddleware(t *testing.T) {
 		{
 			name: "Expired token",
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, "user@example.com", -time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, "user@example.com", token.AccountTypeUser, 1, -time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "MFA pending token",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				tkn, _, err := maker.CreateMfaPendingToken("user@example.com", token.AccountTypeUser, 1, time.Minute)
+				require.NoError(t, err)
+				r.Header.Set(authorizationHeaderKey, fmt.Sprintf("%s %s", authorizationTypeBearer, tkn))
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					IsTokenRevoked(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "Refresh token",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				tkn, _, err := maker.CreateRefreshToken("user@example.com", token.AccountTypeUser, 1, time.Minute)
+				require.NoError(t, err)
+				r.Header.Set(authorizationHeaderKey, fmt.Sprintf("%s %s", authorizationTypeBearer, tkn))
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					IsTokenRevoked(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "Revoked token",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, "user@example.com", token.AccountTypeUser, 1, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					IsTokenRevoked(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(true, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error IsTokenRevoked",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, "user@example.com", token.AccountTypeUser, 1, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					IsTokenRevoked(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(false, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "OK API Key",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addApiKeyAuthorization(r, key)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				buildApiKeyAuthStubs(store, apiKey, employer)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Invalid API Key Format",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addApiKeyAuthorization(r, utils.RandomString(32))
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetApiKeyByPrefix(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "API Key Not Found",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addApiKeyAuthorization(r, key)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetApiKeyByPrefix(gomock.Any(), gomock.Eq(apiKey.Prefix)).
+					Times(1).
+					Return(db.ApiKey{}, sql.ErrNoRows)
+				store.EXPECT().
+					GetEmployerByID(gomock.Any(), gomock.Any()).
+					Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
 		},
+		{
+			name: "Wrong API Key Secret",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addApiKeyAuthorization(r, apiKeyPrefix+apiKey.Prefix+"_"+utils.RandomString(43))
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetApiKeyByPrefix(gomock.Any(), gomock.Eq(apiKey.Prefix)).
+					Times(1).
+					Return(apiKey, nil)
+				store.EXPECT().
+					GetEmployerByID(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "API Key Employer Not Found",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addApiKeyAuthorization(r, key)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetApiKeyByPrefix(gomock.Any(), gomock.Eq(apiKey.Prefix)).
+					Times(1).
+					Return(apiKey, nil)
+				store.EXPECT().
+					GetEmployerByID(gomock.Any(), gomock.Eq(employer.ID)).
+					Times(1).
+					Return(db.Employer{}, sql.ErrNoRows)
+				store.EXPECT().
+					UpdateApiKeyLastUsedAt(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error GetApiKeyByPrefix",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addApiKeyAuthorization(r, key)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetApiKeyByPrefix(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ApiKey{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			server := newTestServer(t, nil, nil, nil) // nil because for middleware tests db is not needed
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil, nil)
 			authPath := "/auth"
 			server.router.GET(
 				authPath,
-				authMiddleware(server.tokenMaker),
+				authMiddleware(server.tokenMaker, server.store),
 				func(ctx *gin.Context) {
 					ctx.JSON(http.StatusOK, gin.H{})
 				},
@@ -99,3 +283,192 @@ func TestAuthMiddleware(t *testing.T) {
 		})
 	}
 }
+
+func TestRequireAccountTypeMiddleware(t *testing.T) {
+	testCases := []struct {
+		name          string
+		path          string
+		setupAuth     func(t *testing.T, r *http.Request, maker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK User",
+			path: "/user",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, "user@example.com", token.AccountTypeUser, 1, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "OK Employer",
+			path: "/employer",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, "employer@example.com", token.AccountTypeEmployer, 1, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden Employer On User Route",
+			path: "/user",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, "employer@example.com", token.AccountTypeEmployer, 1, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden User On Employer Route",
+			path: "/employer",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, "user@example.com", token.AccountTypeUser, 1, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+
+			server := newTestServer(t, store, nil, nil)
+			handler := func(ctx *gin.Context) {
+				ctx.JSON(http.StatusOK, gin.H{})
+			}
+			server.router.GET("/user", authMiddleware(server.tokenMaker, server.store), requireUser(), handler)
+			server.router.GET("/employer", authMiddleware(server.tokenMaker, server.store), requireEmployer(), handler)
+
+			recorder := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, req, server.tokenMaker)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestRequireApiKeyScopeMiddleware(t *testing.T) {
+	employer, _, _ := generateRandomEmployerAndCompany(t)
+	jobsWriteKey, jobsWriteApiKey := generateTestApiKey(t, employer.ID, []string{apiKeyScopeJobsWrite})
+	applicationsReadKey, applicationsReadApiKey := generateTestApiKey(t, employer.ID, []string{apiKeyScopeApplicationsRead})
+	allScopesKey, allScopesApiKey := generateTestApiKey(t, employer.ID, []string{})
+
+	testCases := []struct {
+		name          string
+		path          string
+		setupAuth     func(t *testing.T, r *http.Request, maker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK Access Token Without Scope",
+			path: "/unscoped",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "OK Access Token With Scope",
+			path: "/scoped",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "OK API Key With Scope",
+			path: "/scoped",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addApiKeyAuthorization(r, jobsWriteKey)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				buildApiKeyAuthStubs(store, jobsWriteApiKey, employer)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "OK API Key With All Scopes",
+			path: "/scoped",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addApiKeyAuthorization(r, allScopesKey)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				buildApiKeyAuthStubs(store, allScopesApiKey, employer)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden API Key Without Scope",
+			path: "/scoped",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addApiKeyAuthorization(r, applicationsReadKey)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				buildApiKeyAuthStubs(store, applicationsReadApiKey, employer)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden API Key On Route Without Scope",
+			path: "/unscoped",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addApiKeyAuthorization(r, allScopesKey)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				buildApiKeyAuthStubs(store, allScopesApiKey, employer)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil, nil)
+			handler := func(ctx *gin.Context) {
+				ctx.JSON(http.StatusOK, gin.H{})
+			}
+			server.router.GET("/unscoped", authMiddleware(server.tokenMaker, server.store), requireApiKeyScope(), handler)
+			server.router.GET("/scoped", authMiddleware(server.tokenMaker, server.store), requireApiKeyScope(apiKeyScopeJobsWrite), handler)
+
+			recorder := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, req, server.tokenMaker)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/aalug/job-finder-go/internal/config"
+	db "github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/aalug/job-finder-go/pkg/token"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/oauth2"
+	"net/http"
+)
+
+const defaultOidcProviderName = "oidc"
+
+var (
+	oidcNotConfiguredError    = errors.New("login with an external provider is not configured")
+	invalidOidcStateError     = errors.New("invalid or expired login state, start the login again")
+	invalidOidcIDTokenError   = errors.New("invalid id token returned by the provider")
+	oidcEmailNotVerifiedError = errors.New("the provider did not return a verified email")
+)
+
+// oidcDetails holds the OAuth2 client and the id token verifier of the OpenID Connect provider
+type oidcDetails struct {
+	providerName string
+	oauth2Config *oauth2.Config
+	verifier     *oidc.IDTokenVerifier
+}
+
+// newOidcDetails creates the OpenID Connect client from the config.
+// It returns nil if the provider is not configured.
+func newOidcDetails(config config.Config) *oidcDetails {
+	if config.OidcIssuerURL == "" {
+		return nil
+	}
+
+	providerName := config.OidcProviderName
+	if providerName == "" {
+		providerName = defaultOidcProviderName
+	}
+
+	// the endpoints are configured explicitly instead of using the discovery document
+	provider := (&oidc.ProviderConfig{
+		IssuerURL: config.OidcIssuerURL,
+		AuthURL:   config.OidcAuthURL,
+		TokenURL:  config.OidcTokenURL,
+		JWKSURL:   config.OidcJwksURL,
+	}).NewProvider(context.Background())
+
+	return &oidcDetails{
+		providerName: providerName,
+		oauth2Config: &oauth2.Config{
+			ClientID:     config.OidcClientID,
+			ClientSecret: config.OidcClientSecret,
+			RedirectURL:  config.OidcRedirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       []string{oidc.ScopeOpenID, "email", "profile"},
+		},
+		verifier: provider.Verifier(&oidc.Config{ClientID: config.OidcClientID}),
+	}
+}
+
+type oidcLoginResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+// @Schemes
+// @Summary Start OIDC login
+// @Description Start the login (or sign up) of a user with the external OpenID Connect provider. The user should be redirected to the returned URL. After the login, the provider redirects back to GET /users/oidc/callback.
+// @Tags users
+// @Produce json
+// @Success 200 {object} oidcLoginResponse
+// @Failure 404 {object} ErrorResponse "Login with an external provider is not configured"
+// @Failure 500 {object} ErrorResponse "Any other error"
+// @Router /users/oidc/login [get]
+// startOidcLogin handles creating the authorization URL of the OpenID Connect provider
+func (server *Server) startOidcLogin(ctx *gin.Context) {
+	if server.oidc == nil {
+		ctx.JSON(http.StatusNotFound, errorResponse(oidcNotConfiguredError))
+		return
+	}
+
+	state, err := randomURLSafeString(32)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	nonce, err := randomURLSafeString(32)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	// PKCE code verifier, only its hash (the code challenge) is sent to the provider now
+	codeVerifier, err := randomURLSafeString(32)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	_, err = server.store.CreateOidcState(ctx, db.CreateOidcStateParams{
+		State:        state,
+		CodeVerifier: codeVerifier,
+		Nonce:        nonce,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authorizationURL := server.oidc.oauth2Config.AuthCodeURL(
+		state,
+		oidc.Nonce(nonce),
+		oauth2.SetAuthURLParam("code_challenge", pkceCodeChallenge(codeVerifier)),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+	)
+
+	ctx.JSON(http.StatusOK, oidcLoginResponse{AuthorizationURL: authorizationURL})
+}
+
+type oidcCallbackRequest struct {
+	Code             string `form:"code"`
+	State            string `form:"state" binding:"required"`
+	Error            string `form:"error"`
+	ErrorDescription string `form:"error_description"`
+}
+
+type oidcIDTokenClaims struct {
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+}
+
+// @Schemes
+// @Summary Finish OIDC login
+// @Description The provider redirects here after the login started with GET /users/oidc/login. The external identity is linked to the user with the same email, or a new user is created. The email of the user is treated as verified.
+// @Tags users
+// @Produce json
+// @param code query string false "Authorization code"
+// @param state query string true "State returned by the provider"
+// @Success 200 {object} loginUserResponse
+// @Success 202 {object} mfaRequiredResponse "MFA is enabled, finish the login with POST /users/login/mfa"
+// @Failure 400 {object} ErrorResponse "Invalid query"
+// @Failure 401 {object} ErrorResponse "Invalid state, code or id token"
+// @Failure 403 {object} ErrorResponse "The provider did not return a verified email"
+// @Failure 404 {object} ErrorResponse "Login with an external provider is not configured"
+// @Failure 500 {object} ErrorResponse "Any other error"
+// @Router /users/oidc/callback [get]
+// finishOidcLogin handles the redirect from the OpenID Connect provider and logs in the user
+func (server *Server) finishOidcLogin(ctx *gin.Context) {
+	if server.oidc == nil {
+		ctx.JSON(http.StatusNotFound, errorResponse(oidcNotConfiguredError))
+		return
+	}
+
+	var request oidcCallbackRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// the state can be used only once, even if the login fails
+	oidcState, err := server.store.DeleteOidcState(ctx, request.State)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(invalidOidcStateError))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if request.Error != "" {
+		err = fmt.Errorf("the provider returned an error: %s %s", request.Error, request.ErrorDescription)
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+	if request.Code == "" {
+		err = fmt.Errorf("the provider did not return an authorization code")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	oauth2Token, err := server.oidc.oauth2Config.Exchange(
+		ctx,
+		request.Code,
+		oauth2.SetAuthURLParam("code_verifier", oidcState.CodeVerifier),
+	)
+	if err != nil {
+		err = fmt.Errorf("cannot exchange the authorization code: %w", err)
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	rawIDToken, ok := oauth2Token.Extra("id_token").(string)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(invalidOidcIDTokenError))
+		return
+	}
+
+	idToken, err := server.oidc.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(invalidOidcIDTokenError))
+		return
+	}
+	if idToken.Nonce != oidcState.Nonce {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(invalidOidcIDTokenError))
+		return
+	}
+
+	var claims oidcIDTokenClaims
+	if err := idToken.Claims(&claims); err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(invalidOidcIDTokenError))
+		return
+	}
+	if claims.Email == "" || !claims.EmailVerified {
+		ctx.JSON(http.StatusForbidden, errorResponse(oidcEmailNotVerifiedError))
+		return
+	}
+	if claims.Name == "" {
+		claims.Name = claims.Email
+	}
+
+	// users created (or unverified users linked) with OIDC do not know this password,
+	// they can set their own with the forgot password flow
+	password, err := randomURLSafeString(32)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	result, err := server.store.OidcLoginTx(ctx, db.OidcLoginTxParams{
+		Provider:       server.oidc.providerName,
+		Subject:        idToken.Subject,
+		Email:          claims.Email,
+		FullName:       claims.Name,
+		HashedPassword: hashedPassword,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// accounts with MFA enabled have to finish the login with POST /users/login/mfa
+	if server.requireMfaLogin(ctx, result.User.Email, token.AccountTypeUser, result.User.ID) {
+		return
+	}
+
+	server.completeUserLogin(ctx, result.User)
+}
+
+// randomURLSafeString returns a base64url encoded string of n random bytes
+func randomURLSafeString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// pkceCodeChallenge returns the S256 code challenge of the PKCE code verifier
+func pkceCodeChallenge(codeVerifier string) string {
+	hash := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
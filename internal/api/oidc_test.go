@@ -0,0 +1,599 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"database/sql"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"github.com/aalug/job-finder-go/internal/config"
+	mockdb "github.com/aalug/job-finder-go/internal/db/mock"
+	db "github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/aalug/job-finder-go/pkg/token"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testOidcProviderName = "test"
+	testOidcClientID     = "job-finder"
+)
+
+// fakeOidcProvider - minimal OpenID Connect provider with the token and JWKS endpoints.
+// Authorization codes are issued directly with issueCode instead of the login page.
+type fakeOidcProvider struct {
+	server  *httptest.Server
+	key     *token.Key
+	keyring *token.Keyring
+
+	mu    sync.Mutex
+	codes map[string]fakeOidcAuthorization
+}
+
+type fakeOidcAuthorization struct {
+	codeChallenge string
+	idToken       string
+}
+
+func newFakeOidcProvider(t *testing.T) *fakeOidcProvider {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+
+	key, err := token.ParseKey("test-key", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	require.NoError(t, err)
+
+	keyring, err := token.NewKeyring(key.ID, key)
+	require.NoError(t, err)
+
+	provider := &fakeOidcProvider{
+		key:     key,
+		keyring: keyring,
+		codes:   make(map[string]fakeOidcAuthorization),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", provider.handleToken)
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(provider.keyring.JWKS())
+	})
+	provider.server = httptest.NewServer(mux)
+	t.Cleanup(provider.server.Close)
+
+	return provider
+}
+
+// config returns the config of the server that uses the fake provider
+func (provider *fakeOidcProvider) config() config.Config {
+	return config.Config{
+		OidcProviderName: testOidcProviderName,
+		OidcIssuerURL:    provider.server.URL,
+		OidcAuthURL:      provider.server.URL + "/authorize",
+		OidcTokenURL:     provider.server.URL + "/token",
+		OidcJwksURL:      provider.server.URL + "/jwks",
+		OidcClientID:     testOidcClientID,
+		OidcClientSecret: utils.RandomString(32),
+		OidcRedirectURL:  "http://localhost:8080/api/v1/users/oidc/callback",
+	}
+}
+
+// issueCode issues an authorization code for the PKCE code verifier,
+// it can be exchanged for an id token with the given claims
+func (provider *fakeOidcProvider) issueCode(t *testing.T, codeVerifier string, claims jwt.MapClaims) string {
+	idTokenClaims := jwt.MapClaims{
+		"iss": provider.server.URL,
+		"aud": testOidcClientID,
+		"iat": time.Now().Unix(),
+		"exp": time.Now().Add(time.Minute).Unix(),
+	}
+	for k, v := range claims {
+		idTokenClaims[k] = v
+	}
+
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodRS256, idTokenClaims)
+	jwtToken.Header["kid"] = provider.key.ID
+	idToken, err := jwtToken.SignedString(provider.key.PrivateKey)
+	require.NoError(t, err)
+
+	code := utils.RandomString(32)
+
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+	provider.codes[code] = fakeOidcAuthorization{
+		codeChallenge: pkceCodeChallenge(codeVerifier),
+		idToken:       idToken,
+	}
+
+	return code
+}
+
+func (provider *fakeOidcProvider) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	provider.mu.Lock()
+	authorization, ok := provider.codes[r.PostForm.Get("code")]
+	delete(provider.codes, r.PostForm.Get("code"))
+	provider.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if !ok || r.PostForm.Get("grant_type") != "authorization_code" ||
+		pkceCodeChallenge(r.PostForm.Get("code_verifier")) != authorization.codeChallenge {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"access_token": utils.RandomString(32),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     authorization.idToken,
+	})
+}
+
+func TestStartOidcLoginAPI(t *testing.T) {
+	provider := newFakeOidcProvider(t)
+
+	testCases := []struct {
+		name          string
+		notConfigured bool
+		buildStubs    func(store *mockdb.MockStore, params *db.CreateOidcStateParams)
+		checkResponse func(recorder *httptest.ResponseRecorder, params db.CreateOidcStateParams)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore, params *db.CreateOidcStateParams) {
+				store.EXPECT().
+					CreateOidcState(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.CreateOidcStateParams) (db.OidcState, error) {
+						*params = arg
+						return db.OidcState{State: arg.State}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, params db.CreateOidcStateParams) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res oidcLoginResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+
+				authorizationURL, err := url.Parse(res.AuthorizationURL)
+				require.NoError(t, err)
+				require.Equal(t, provider.server.URL+"/authorize", fmt.Sprintf("%s://%s%s", authorizationURL.Scheme, authorizationURL.Host, authorizationURL.Path))
+
+				query := authorizationURL.Query()
+				require.Equal(t, "code", query.Get("response_type"))
+				require.Equal(t, testOidcClientID, query.Get("client_id"))
+				require.Contains(t, query.Get("scope"), "openid")
+				require.NotEmpty(t, params.State)
+				require.Equal(t, params.State, query.Get("state"))
+				require.Equal(t, params.Nonce, query.Get("nonce"))
+				require.Equal(t, pkceCodeChallenge(params.CodeVerifier), query.Get("code_challenge"))
+				require.Equal(t, "S256", query.Get("code_challenge_method"))
+				require.Empty(t, query.Get("code_verifier"))
+			},
+		},
+		{
+			name:          "Not Configured",
+			notConfigured: true,
+			buildStubs: func(store *mockdb.MockStore, params *db.CreateOidcStateParams) {
+				store.EXPECT().
+					CreateOidcState(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, params db.CreateOidcStateParams) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error CreateOidcState",
+			buildStubs: func(store *mockdb.MockStore, params *db.CreateOidcStateParams) {
+				store.EXPECT().
+					CreateOidcState(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.OidcState{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, params db.CreateOidcStateParams) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var params db.CreateOidcStateParams
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store, &params)
+
+			server := newTestServer(t, store, nil, nil)
+			if !tc.notConfigured {
+				server.oidc = newOidcDetails(provider.config())
+			}
+			recorder := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, BaseUrl+"/users/oidc/login", nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder, params)
+		})
+	}
+}
+
+func TestFinishOidcLoginAPI(t *testing.T) {
+	provider := newFakeOidcProvider(t)
+	user, _ := generateRandomUser(t)
+	user.IsEmailVerified = true
+	subject := utils.RandomString(16)
+
+	oidcState := db.OidcState{
+		State:        utils.RandomString(32),
+		CodeVerifier: utils.RandomString(43),
+		Nonce:        utils.RandomString(32),
+	}
+	validClaims := jwt.MapClaims{
+		"sub":            subject,
+		"nonce":          oidcState.Nonce,
+		"email":          user.Email,
+		"email_verified": true,
+		"name":           user.FullName,
+	}
+
+	testCases := []struct {
+		name          string
+		notConfigured bool
+		query         func(t *testing.T) url.Values
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			query: func(t *testing.T) url.Values {
+				return url.Values{
+					"state": {oidcState.State},
+					"code":  {provider.issueCode(t, oidcState.CodeVerifier, validClaims)},
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteOidcState(gomock.Any(), gomock.Eq(oidcState.State)).
+					Times(1).
+					Return(oidcState, nil)
+				store.EXPECT().
+					OidcLoginTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.OidcLoginTxParams) (db.OidcLoginTxResult, error) {
+						require.Equal(t, testOidcProviderName, arg.Provider)
+						require.Equal(t, subject, arg.Subject)
+						require.Equal(t, user.Email, arg.Email)
+						require.Equal(t, user.FullName, arg.FullName)
+						require.NotEmpty(t, arg.HashedPassword)
+						return db.OidcLoginTxResult{User: user, Created: true}, nil
+					})
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Eq(db.GetMfaSettingParams{
+						AccountType: string(token.AccountTypeUser),
+						AccountID:   user.ID,
+					})).
+					Times(1).
+					Return(db.MfaSetting{}, sql.ErrNoRows)
+				store.EXPECT().
+					DeleteLoginAttempt(gomock.Any(), gomock.Eq("user:"+user.Email)).
+					Times(1).
+					Return(nil)
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Session{ID: uuid.New(), Email: user.Email}, nil)
+				store.EXPECT().
+					ListUserSkills(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.UserSkill{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res loginUserResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.NotEmpty(t, res.AccessToken)
+				require.Equal(t, user.Email, res.User.Email)
+			},
+		},
+		{
+			name: "MFA Enabled",
+			query: func(t *testing.T) url.Values {
+				return url.Values{
+					"state": {oidcState.State},
+					"code":  {provider.issueCode(t, oidcState.CodeVerifier, validClaims)},
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteOidcState(gomock.Any(), gomock.Eq(oidcState.State)).
+					Times(1).
+					Return(oidcState, nil)
+				store.EXPECT().
+					OidcLoginTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.OidcLoginTxResult{User: user}, nil)
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MfaSetting{IsEnabled: true}, nil)
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+			},
+		},
+		{
+			name:          "Not Configured",
+			notConfigured: true,
+			query: func(t *testing.T) url.Values {
+				return url.Values{
+					"state": {oidcState.State},
+					"code":  {utils.RandomString(32)},
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteOidcState(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "Missing State",
+			query: func(t *testing.T) url.Values {
+				return url.Values{
+					"code": {provider.issueCode(t, oidcState.CodeVerifier, validClaims)},
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteOidcState(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Invalid State",
+			query: func(t *testing.T) url.Values {
+				return url.Values{
+					"state": {utils.RandomString(32)},
+					"code":  {provider.issueCode(t, oidcState.CodeVerifier, validClaims)},
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteOidcState(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.OidcState{}, sql.ErrNoRows)
+				store.EXPECT().
+					OidcLoginTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "Provider Error",
+			query: func(t *testing.T) url.Values {
+				return url.Values{
+					"state": {oidcState.State},
+					"error": {"access_denied"},
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteOidcState(gomock.Any(), gomock.Eq(oidcState.State)).
+					Times(1).
+					Return(oidcState, nil)
+				store.EXPECT().
+					OidcLoginTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "Wrong Code Verifier",
+			query: func(t *testing.T) url.Values {
+				return url.Values{
+					"state": {oidcState.State},
+					"code":  {provider.issueCode(t, utils.RandomString(43), validClaims)},
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteOidcState(gomock.Any(), gomock.Eq(oidcState.State)).
+					Times(1).
+					Return(oidcState, nil)
+				store.EXPECT().
+					OidcLoginTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "Nonce Mismatch",
+			query: func(t *testing.T) url.Values {
+				claims := jwt.MapClaims{}
+				for k, v := range validClaims {
+					claims[k] = v
+				}
+				claims["nonce"] = utils.RandomString(32)
+				return url.Values{
+					"state": {oidcState.State},
+					"code":  {provider.issueCode(t, oidcState.CodeVerifier, claims)},
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteOidcState(gomock.Any(), gomock.Eq(oidcState.State)).
+					Times(1).
+					Return(oidcState, nil)
+				store.EXPECT().
+					OidcLoginTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "Wrong Audience",
+			query: func(t *testing.T) url.Values {
+				claims := jwt.MapClaims{}
+				for k, v := range validClaims {
+					claims[k] = v
+				}
+				claims["aud"] = "other-client"
+				return url.Values{
+					"state": {oidcState.State},
+					"code":  {provider.issueCode(t, oidcState.CodeVerifier, claims)},
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteOidcState(gomock.Any(), gomock.Eq(oidcState.State)).
+					Times(1).
+					Return(oidcState, nil)
+				store.EXPECT().
+					OidcLoginTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "Email Not Verified",
+			query: func(t *testing.T) url.Values {
+				claims := jwt.MapClaims{}
+				for k, v := range validClaims {
+					claims[k] = v
+				}
+				claims["email_verified"] = false
+				return url.Values{
+					"state": {oidcState.State},
+					"code":  {provider.issueCode(t, oidcState.CodeVerifier, claims)},
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteOidcState(gomock.Any(), gomock.Eq(oidcState.State)).
+					Times(1).
+					Return(oidcState, nil)
+				store.EXPECT().
+					OidcLoginTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error DeleteOidcState",
+			query: func(t *testing.T) url.Values {
+				return url.Values{
+					"state": {oidcState.State},
+					"code":  {provider.issueCode(t, oidcState.CodeVerifier, validClaims)},
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteOidcState(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.OidcState{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error OidcLoginTx",
+			query: func(t *testing.T) url.Values {
+				return url.Values{
+					"state": {oidcState.State},
+					"code":  {provider.issueCode(t, oidcState.CodeVerifier, validClaims)},
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteOidcState(gomock.Any(), gomock.Eq(oidcState.State)).
+					Times(1).
+					Return(oidcState, nil)
+				store.EXPECT().
+					OidcLoginTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.OidcLoginTxResult{}, sql.ErrConnDone)
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil, nil)
+			if !tc.notConfigured {
+				server.oidc = newOidcDetails(provider.config())
+			}
+			recorder := httptest.NewRecorder()
+
+			u := fmt.Sprintf("%s/users/oidc/callback?%s", BaseUrl, tc.query(t).Encode())
+			req, err := http.NewRequest(http.MethodGet, u, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"html"
+	"net/http"
+)
+
+// resetPasswordPage is the page of the reset password links of the emails, for both users and employers.
+// It posts the ID and the code from the link with the new password as a form to the URL of the page.
+const resetPasswordPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="utf-8">
+    <title>Reset Password</title>
+</head>
+<body>
+<form method="post">
+    <input type="hidden" name="id" value="%d">
+    <input type="hidden" name="code" value="%s">
+    <label for="new_password">New password</label>
+    <input type="password" id="new_password" name="new_password" minlength="6" required>
+    <button type="submit">Reset Password</button>
+</form>
+</body>
+</html>
+`
+
+type resetPasswordPageRequest struct {
+	ID         int64  `form:"id" binding:"required,min=1"`
+	SecretCode string `form:"code" binding:"required,min=32"`
+}
+
+// @Schemes
+// @Summary Reset password page
+// @Description The reset password link of the emails. Returns a page with the form of the new password, the form is posted to POST /users/reset-password or POST /employers/reset-password with the ID and the code from the link.
+// @Tags users, employers
+// @param id query integer true "Reset password ID"
+// @param code query string true "Reset password code"
+// @Produce html
+// @Success 200 {string} string "Reset password page"
+// @Failure 400 {object} ErrorResponse "Invalid request query"
+// @Router /users/reset-password [get]
+// @Router /employers/reset-password [get]
+// getResetPasswordPage handles the reset password links of the emails
+func (server *Server) getResetPasswordPage(ctx *gin.Context) {
+	var request resetPasswordPageRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	page := fmt.Sprintf(resetPasswordPage, request.ID, html.EscapeString(request.SecretCode))
+	ctx.Data(http.StatusOK, "text/html; charset=utf-8", []byte(page))
+}
+
+// bindResetPasswordRequest binds the request of resetting the password,
+// the page of the reset link posts a form and the API clients send JSON
+func bindResetPasswordRequest(ctx *gin.Context, request interface{}) error {
+	if ctx.ContentType() == binding.MIMEPOSTForm {
+		return ctx.ShouldBindWith(request, binding.Form)
+	}
+
+	return ctx.ShouldBindJSON(request)
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"fmt"
+	mockdb "github.com/aalug/job-finder-go/internal/db/mock"
+	db "github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestGetResetPasswordPageAPI(t *testing.T) {
+	id := int64(utils.RandomInt(1, 1000))
+	code := utils.RandomString(32)
+
+	testCases := []struct {
+		name          string
+		path          string
+		query         string
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK User",
+			path:  "/users/reset-password",
+			query: fmt.Sprintf("id=%d&code=%s", id, code),
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Header().Get("Content-Type"), "text/html")
+				require.Contains(t, recorder.Body.String(), `<form method="post">`)
+				require.Contains(t, recorder.Body.String(), fmt.Sprintf(`name="id" value="%d"`, id))
+				require.Contains(t, recorder.Body.String(), fmt.Sprintf(`name="code" value="%s"`, code))
+			},
+		},
+		{
+			name:  "OK Employer",
+			path:  "/employers/reset-password",
+			query: fmt.Sprintf("id=%d&code=%s", id, code),
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), fmt.Sprintf(`name="code" value="%s"`, code))
+			},
+		},
+		{
+			name:  "Code Is Escaped",
+			path:  "/users/reset-password",
+			query: fmt.Sprintf("id=%d&code=%s", id, url.QueryEscape(`"><script>`+code)),
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.NotContains(t, recorder.Body.String(), "<script>")
+			},
+		},
+		{
+			name:  "Missing Code",
+			path:  "/users/reset-password",
+			query: fmt.Sprintf("id=%d", id),
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// opening the link of the email does not use the code
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				ResetUserPasswordTx(gomock.Any(), gomock.Any()).
+				Times(0)
+			store.EXPECT().
+				ResetEmployerPasswordTx(gomock.Any(), gomock.Any()).
+				Times(0)
+
+			server := newTestServer(t, store, nil, nil)
+			recorder := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, BaseUrl+tc.path+"?"+tc.query, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestResetPasswordFormAPI(t *testing.T) {
+	id := int64(utils.RandomInt(1, 1000))
+	code := utils.RandomString(32)
+	newPassword := utils.RandomString(6)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ResetEmployerPasswordTx(gomock.Any(), EqResetPasswordTxParams(db.ResetPasswordTxParams{
+			ID:         id,
+			SecretCode: code,
+		}, newPassword)).
+		Times(1).
+		Return(db.ResetEmployerPasswordResult{}, nil)
+
+	server := newTestServer(t, store, nil, nil)
+	recorder := httptest.NewRecorder()
+
+	// the form of the reset password page
+	form := url.Values{}
+	form.Set("id", fmt.Sprint(id))
+	form.Set("code", code)
+	form.Set("new_password", newPassword)
+	req, err := http.NewRequest(http.MethodPost, BaseUrl+"/employers/reset-password", strings.NewReader(form.Encode()))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	server.router.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
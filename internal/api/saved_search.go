@@ -0,0 +1,469 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	db "github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/aalug/job-finder-go/internal/geo"
+	"github.com/aalug/job-finder-go/pkg/token"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+var savedSearchNotFoundError = errors.New("saved search with given ID does not exist")
+
+// savedSearchRequest is the query of searchJobs with the filters of filterAndListJobs
+// and the frequency of the digests of the new matching jobs
+type savedSearchRequest struct {
+	Name           string                  `json:"name" binding:"required,max=100"`
+	Query          string                  `json:"query" binding:"max=200"`
+	Title          string                  `json:"title"`
+	Industry       string                  `json:"industry"`
+	JobLocation    string                  `json:"job_location"`
+	SalaryMin      int32                   `json:"salary_min" binding:"min=0"`
+	SalaryMax      int32                   `json:"salary_max" binding:"min=0"`
+	EmploymentType db.EmploymentType       `json:"employment_type" binding:"omitempty,oneof=full_time part_time contract internship temporary"`
+	SeniorityLevel db.SeniorityLevel       `json:"seniority_level" binding:"omitempty,oneof=intern junior mid senior lead"`
+	RemotePolicy   db.RemotePolicy         `json:"remote_policy" binding:"omitempty,oneof=onsite hybrid remote"`
+	SalaryCurrency string                  `json:"salary_currency" binding:"omitempty,iso4217"`
+	SalaryPeriod   db.SalaryPeriod         `json:"salary_period" binding:"omitempty,oneof=hour day week month year"`
+	Near           string                  `json:"near"`
+	RadiusKm       float64                 `json:"radius_km" binding:"omitempty,gt=0,max=1000"`
+	Frequency      db.SavedSearchFrequency `json:"frequency" binding:"omitempty,oneof=daily weekly never"`
+}
+
+type savedSearchResponse struct {
+	ID             int64                   `json:"id"`
+	Name           string                  `json:"name"`
+	Query          string                  `json:"query"`
+	Title          string                  `json:"title"`
+	Industry       string                  `json:"industry"`
+	JobLocation    string                  `json:"job_location"`
+	SalaryMin      int32                   `json:"salary_min"`
+	SalaryMax      int32                   `json:"salary_max"`
+	EmploymentType db.EmploymentType       `json:"employment_type"`
+	SeniorityLevel db.SeniorityLevel       `json:"seniority_level"`
+	RemotePolicy   db.RemotePolicy         `json:"remote_policy"`
+	SalaryCurrency string                  `json:"salary_currency"`
+	SalaryPeriod   db.SalaryPeriod         `json:"salary_period"`
+	Near           string                  `json:"near"`
+	RadiusKm       float64                 `json:"radius_km"`
+	Frequency      db.SavedSearchFrequency `json:"frequency"`
+	LastRunAt      time.Time               `json:"last_run_at"`
+	CreatedAt      time.Time               `json:"created_at"`
+}
+
+// newSavedSearchResponse returns a saved search without its unsubscribe token,
+// the filters that are not set are empty
+func newSavedSearchResponse(savedSearch db.SavedSearch) savedSearchResponse {
+	return savedSearchResponse{
+		ID:             savedSearch.ID,
+		Name:           savedSearch.Name,
+		Query:          savedSearch.Query,
+		Title:          savedSearch.Title.String,
+		Industry:       savedSearch.Industry.String,
+		JobLocation:    savedSearch.JobLocation.String,
+		SalaryMin:      savedSearch.SalaryMin.Int32,
+		SalaryMax:      savedSearch.SalaryMax.Int32,
+		EmploymentType: savedSearch.EmploymentType.EmploymentType,
+		SeniorityLevel: savedSearch.SeniorityLevel.SeniorityLevel,
+		RemotePolicy:   savedSearch.RemotePolicy.RemotePolicy,
+		SalaryCurrency: savedSearch.SalaryCurrency.String,
+		SalaryPeriod:   savedSearch.SalaryPeriod.SalaryPeriod,
+		Near:           savedSearch.Near.String,
+		RadiusKm:       savedSearch.RadiusKm.Float64,
+		Frequency:      savedSearch.Frequency,
+		LastRunAt:      savedSearch.LastRunAt,
+		CreatedAt:      savedSearch.CreatedAt,
+	}
+}
+
+// newSavedSearchParams validates the saved search and returns the params to create it,
+// without the user and the unsubscribe token. The filters that are not set are NULL.
+func newSavedSearchParams(request savedSearchRequest) (db.CreateSavedSearchParams, error) {
+	if request.SalaryMin != 0 && request.SalaryMax != 0 && request.SalaryMin > request.SalaryMax {
+		return db.CreateSavedSearchParams{}, salaryRangeError
+	}
+
+	near, radiusKm, err := nearLocation(request.Near, request.RadiusKm)
+	if err != nil {
+		return db.CreateSavedSearchParams{}, err
+	}
+	// locations of the jobs are normalized, so the filter has to be normalized as well
+	jobLocation, _ := geo.NormalizeLocation(request.JobLocation)
+
+	frequency := request.Frequency
+	if frequency == "" {
+		frequency = db.SavedSearchFrequencyDaily
+	}
+
+	params := db.CreateSavedSearchParams{
+		Name:  request.Name,
+		Query: request.Query,
+		Title: sql.NullString{
+			String: request.Title,
+			Valid:  request.Title != "",
+		},
+		Industry: sql.NullString{
+			String: request.Industry,
+			Valid:  request.Industry != "",
+		},
+		JobLocation: sql.NullString{
+			String: jobLocation,
+			Valid:  jobLocation != "",
+		},
+		SalaryMin: sql.NullInt32{
+			Int32: request.SalaryMin,
+			Valid: request.SalaryMin != 0,
+		},
+		SalaryMax: sql.NullInt32{
+			Int32: request.SalaryMax,
+			Valid: request.SalaryMax != 0,
+		},
+		EmploymentType: db.NullEmploymentType{
+			EmploymentType: request.EmploymentType,
+			Valid:          request.EmploymentType != "",
+		},
+		SeniorityLevel: db.NullSeniorityLevel{
+			SeniorityLevel: request.SeniorityLevel,
+			Valid:          request.SeniorityLevel != "",
+		},
+		RemotePolicy: db.NullRemotePolicy{
+			RemotePolicy: request.RemotePolicy,
+			Valid:        request.RemotePolicy != "",
+		},
+		SalaryCurrency: sql.NullString{
+			String: request.SalaryCurrency,
+			Valid:  request.SalaryCurrency != "",
+		},
+		SalaryPeriod: db.NullSalaryPeriod{
+			SalaryPeriod: request.SalaryPeriod,
+			Valid:        request.SalaryPeriod != "",
+		},
+		Frequency: frequency,
+	}
+	if near != nil {
+		params.Near = sql.NullString{String: near.String(), Valid: true}
+		params.NearLatitude = sql.NullFloat64{Float64: near.Latitude, Valid: true}
+		params.NearLongitude = sql.NullFloat64{Float64: near.Longitude, Valid: true}
+		params.RadiusKm = sql.NullFloat64{Float64: radiusKm, Valid: true}
+	}
+
+	return params, nil
+}
+
+// @Schemes
+// @Summary Create saved search
+// @Description Save a search of the logged-in user - the query of the job search and the filters of the jobs list. The user gets daily (default) or weekly emails with the new jobs matching it.
+// @Tags saved searches
+// @Accept json
+// @Produce json
+// @param SavedSearchRequest body savedSearchRequest true "Name, query, filters and frequency (daily, weekly or never) of the saved search"
+// @Success 201 {object} savedSearchResponse
+// @Failure 400 {object} ErrorResponse "Invalid request body or unknown near city"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Only users can access this endpoint"
+// @Failure 500 {object} ErrorResponse "Any other error"
+// @Security ApiKeyAuth
+// @Router /saved-searches [post]
+// createSavedSearch handles creating saved searches of users
+func (server *Server) createSavedSearch(ctx *gin.Context) {
+	var request savedSearchRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	params, err := newSavedSearchParams(request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// requireUser middleware guarantees that this is a user
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	params.UserID = authPayload.AccountID
+
+	params.UnsubscribeToken, err = randomURLSafeString(32)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	savedSearch, err := server.store.CreateSavedSearch(ctx, params)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, newSavedSearchResponse(savedSearch))
+}
+
+// @Schemes
+// @Summary List saved searches
+// @Description List saved searches of the logged-in user, newest first
+// @Tags saved searches
+// @Produce json
+// @Success 200 {array} savedSearchResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Only users can access this endpoint"
+// @Failure 500 {object} ErrorResponse "Any other error"
+// @Security ApiKeyAuth
+// @Router /saved-searches [get]
+// listSavedSearches lists saved searches of the logged-in user
+func (server *Server) listSavedSearches(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	savedSearches, err := server.store.ListSavedSearchesByUserID(ctx, authPayload.AccountID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]savedSearchResponse, len(savedSearches))
+	for i, savedSearch := range savedSearches {
+		res[i] = newSavedSearchResponse(savedSearch)
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type savedSearchUriRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// @Schemes
+// @Summary Get saved search
+// @Description Get a saved search of the logged-in user
+// @Tags saved searches
+// @param id path integer true "Saved search ID"
+// @Produce json
+// @Success 200 {object} savedSearchResponse
+// @Failure 400 {object} ErrorResponse "Invalid saved search ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Only users can access this endpoint"
+// @Failure 404 {object} ErrorResponse "Saved search not found"
+// @Failure 500 {object} ErrorResponse "Any other error"
+// @Security ApiKeyAuth
+// @Router /saved-searches/{id} [get]
+// getSavedSearch handles getting a saved search of the logged-in user
+func (server *Server) getSavedSearch(ctx *gin.Context) {
+	var request savedSearchUriRequest
+	if err := ctx.ShouldBindUri(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	savedSearch, err := server.store.GetSavedSearch(ctx, db.GetSavedSearchParams{
+		ID:     request.ID,
+		UserID: authPayload.AccountID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(savedSearchNotFoundError))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newSavedSearchResponse(savedSearch))
+}
+
+// @Schemes
+// @Summary Update saved search
+// @Description Replace the query, filters and frequency of a saved search of the logged-in user. Turning the emails on again does not send the jobs published while they were off.
+// @Tags saved searches
+// @param id path integer true "Saved search ID"
+// @param SavedSearchRequest body savedSearchRequest true "Name, query, filters and frequency (daily, weekly or never) of the saved search"
+// @Accept json
+// @Produce json
+// @Success 200 {object} savedSearchResponse
+// @Failure 400 {object} ErrorResponse "Invalid saved search ID, request body or unknown near city"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Only users can access this endpoint"
+// @Failure 404 {object} ErrorResponse "Saved search not found"
+// @Failure 500 {object} ErrorResponse "Any other error"
+// @Security ApiKeyAuth
+// @Router /saved-searches/{id} [put]
+// updateSavedSearch handles replacing a saved search of the logged-in user
+func (server *Server) updateSavedSearch(ctx *gin.Context) {
+	var uriRequest savedSearchUriRequest
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var request savedSearchRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	params, err := newSavedSearchParams(request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	savedSearch, err := server.store.UpdateSavedSearch(ctx, db.UpdateSavedSearchParams{
+		ID:             uriRequest.ID,
+		UserID:         authPayload.AccountID,
+		Name:           params.Name,
+		Query:          params.Query,
+		Title:          params.Title,
+		Industry:       params.Industry,
+		JobLocation:    params.JobLocation,
+		SalaryMin:      params.SalaryMin,
+		SalaryMax:      params.SalaryMax,
+		EmploymentType: params.EmploymentType,
+		SeniorityLevel: params.SeniorityLevel,
+		RemotePolicy:   params.RemotePolicy,
+		SalaryCurrency: params.SalaryCurrency,
+		SalaryPeriod:   params.SalaryPeriod,
+		Near:           params.Near,
+		NearLatitude:   params.NearLatitude,
+		NearLongitude:  params.NearLongitude,
+		RadiusKm:       params.RadiusKm,
+		Frequency:      params.Frequency,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(savedSearchNotFoundError))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newSavedSearchResponse(savedSearch))
+}
+
+// @Schemes
+// @Summary Delete saved search
+// @Description Delete a saved search of the logged-in user
+// @Tags saved searches
+// @param id path integer true "Saved search ID"
+// @Success 204 {null} null
+// @Failure 400 {object} ErrorResponse "Invalid saved search ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Only users can access this endpoint"
+// @Failure 404 {object} ErrorResponse "Saved search not found"
+// @Failure 500 {object} ErrorResponse "Any other error"
+// @Security ApiKeyAuth
+// @Router /saved-searches/{id} [delete]
+// deleteSavedSearch handles deleting a saved search of the logged-in user
+func (server *Server) deleteSavedSearch(ctx *gin.Context) {
+	var request savedSearchUriRequest
+	if err := ctx.ShouldBindUri(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	_, err := server.store.DeleteSavedSearch(ctx, db.DeleteSavedSearchParams{
+		ID:     request.ID,
+		UserID: authPayload.AccountID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(savedSearchNotFoundError))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+type unsubscribeSavedSearchRequest struct {
+	Token string `form:"token" binding:"required"`
+}
+
+type unsubscribeSavedSearchResponse struct {
+	Message string `json:"message"`
+}
+
+// unsubscribeSavedSearchPage is the page of the unsubscribe links of the emails. The emails are turned off
+// only by submitting its form, so mail scanners that open the links do not unsubscribe the users.
+// The form is posted to the URL of the page, with the token.
+const unsubscribeSavedSearchPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="utf-8">
+    <title>Unsubscribe</title>
+</head>
+<body>
+<p>Do you want to stop getting the emails of this saved search? The search stays saved.</p>
+<form method="post">
+    <button type="submit">Unsubscribe</button>
+</form>
+</body>
+</html>
+`
+
+// @Schemes
+// @Summary Unsubscribe page of saved search
+// @Description The unsubscribe link of the emails. Returns a page that asks to confirm unsubscribing, the page posts the form to POST /saved-searches/unsubscribe with the same token. Opening the link does not change the saved search.
+// @Tags saved searches
+// @param token query string true "Unsubscribe token"
+// @Produce html
+// @Success 200 {string} string "Confirmation page"
+// @Failure 400 {object} ErrorResponse "Invalid request query"
+// @Router /saved-searches/unsubscribe [get]
+// getUnsubscribeSavedSearchPage handles the unsubscribe links of the saved search digests
+func (server *Server) getUnsubscribeSavedSearchPage(ctx *gin.Context) {
+	var request unsubscribeSavedSearchRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	ctx.Data(http.StatusOK, "text/html; charset=utf-8", []byte(unsubscribeSavedSearchPage))
+}
+
+// @Schemes
+// @Summary Unsubscribe from saved search
+// @Description Turn off the emails of a saved search with the token from the unsubscribe link of the emails. The search stays saved. It is the one-click unsubscribe of the List-Unsubscribe-Post header of the emails (RFC 8058) and the form of the unsubscribe page.
+// @Tags saved searches
+// @param token query string true "Unsubscribe token"
+// @Produce json
+// @Success 200 {object} unsubscribeSavedSearchResponse
+// @Failure 400 {object} ErrorResponse "Invalid request query"
+// @Failure 404 {object} ErrorResponse "Saved search not found"
+// @Failure 500 {object} ErrorResponse "Any other error"
+// @Router /saved-searches/unsubscribe [post]
+// unsubscribeSavedSearch handles turning off the emails of a saved search
+func (server *Server) unsubscribeSavedSearch(ctx *gin.Context) {
+	var request unsubscribeSavedSearchRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	savedSearch, err := server.store.UnsubscribeSavedSearch(ctx, request.Token)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(errors.New("invalid unsubscribe token")))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, unsubscribeSavedSearchResponse{
+		Message: fmt.Sprintf("You will no longer get emails of the saved search %s", savedSearch.Name),
+	})
+}
@@ -0,0 +1,842 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	mockdb "github.com/aalug/job-finder-go/internal/db/mock"
+	db "github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/aalug/job-finder-go/pkg/token"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// generateRandomSavedSearch returns a saved search of the user with the industry filter
+func generateRandomSavedSearch(userID int32) db.SavedSearch {
+	return db.SavedSearch{
+		ID:     int64(utils.RandomInt(1, 1000)),
+		UserID: userID,
+		Name:   utils.RandomString(8),
+		Query:  utils.RandomString(6),
+		Industry: sql.NullString{
+			String: utils.RandomString(5),
+			Valid:  true,
+		},
+		Frequency:        db.SavedSearchFrequencyDaily,
+		UnsubscribeToken: utils.RandomString(32),
+		LastRunAt:        time.Now(),
+		CreatedAt:        time.Now(),
+	}
+}
+
+func TestCreateSavedSearchAPI(t *testing.T) {
+	user, _ := generateRandomUser(t)
+	employer, _, _ := generateRandomEmployerAndCompany(t)
+	savedSearch := generateRandomSavedSearch(user.ID)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, r *http.Request, maker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"name":            savedSearch.Name,
+				"query":           savedSearch.Query,
+				"industry":        savedSearch.Industry.String,
+				"employment_type": db.EmploymentTypeFullTime,
+				"near":            "berlin",
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateSavedSearch(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.CreateSavedSearchParams) (db.SavedSearch, error) {
+						require.Equal(t, user.ID, arg.UserID)
+						require.Equal(t, savedSearch.Name, arg.Name)
+						require.Equal(t, savedSearch.Query, arg.Query)
+						require.Equal(t, savedSearch.Industry, arg.Industry)
+						require.False(t, arg.Title.Valid)
+						require.False(t, arg.SalaryMin.Valid)
+						require.Equal(t, db.NullEmploymentType{EmploymentType: db.EmploymentTypeFullTime, Valid: true}, arg.EmploymentType)
+						// the near city is looked up, the radius is the default one
+						require.Equal(t, sql.NullString{String: "Berlin, Germany", Valid: true}, arg.Near)
+						require.True(t, arg.NearLatitude.Valid)
+						require.Equal(t, sql.NullFloat64{Float64: defaultRadiusKm, Valid: true}, arg.RadiusKm)
+						require.Equal(t, db.SavedSearchFrequencyDaily, arg.Frequency)
+						require.NotEmpty(t, arg.UnsubscribeToken)
+
+						res := savedSearch
+						res.EmploymentType = arg.EmploymentType
+						res.Near = arg.Near
+						res.RadiusKm = arg.RadiusKm
+						return res, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var res savedSearchResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, savedSearch.ID, res.ID)
+				require.Equal(t, savedSearch.Industry.String, res.Industry)
+				require.Equal(t, "Berlin, Germany", res.Near)
+				require.Empty(t, res.Title)
+				// the unsubscribe token is only sent in the emails
+				require.NotContains(t, recorder.Body.String(), savedSearch.UnsubscribeToken)
+			},
+		},
+		{
+			name: "OK Weekly",
+			body: gin.H{
+				"name":      savedSearch.Name,
+				"frequency": db.SavedSearchFrequencyWeekly,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateSavedSearch(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.CreateSavedSearchParams) (db.SavedSearch, error) {
+						require.Equal(t, db.SavedSearchFrequencyWeekly, arg.Frequency)
+						require.False(t, arg.Near.Valid)
+						require.False(t, arg.RadiusKm.Valid)
+						return savedSearch, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "Invalid Frequency",
+			body: gin.H{
+				"name":      savedSearch.Name,
+				"frequency": "hourly",
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateSavedSearch(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Missing Name",
+			body: gin.H{
+				"query": savedSearch.Query,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateSavedSearch(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Invalid Salary Range",
+			body: gin.H{
+				"name":       savedSearch.Name,
+				"salary_min": 90000,
+				"salary_max": 60000,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateSavedSearch(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Unknown Near City",
+			body: gin.H{
+				"name": savedSearch.Name,
+				"near": utils.RandomString(12),
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateSavedSearch(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Unauthorized",
+			body: gin.H{
+				"name": savedSearch.Name,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateSavedSearch(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden Employer",
+			body: gin.H{
+				"name": savedSearch.Name,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateSavedSearch(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error",
+			body: gin.H{
+				"name": savedSearch.Name,
+			},
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateSavedSearch(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.SavedSearch{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil, nil)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := BaseUrl + "/saved-searches"
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, req, server.tokenMaker)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListSavedSearchesAPI(t *testing.T) {
+	user, _ := generateRandomUser(t)
+	savedSearches := []db.SavedSearch{
+		generateRandomSavedSearch(user.ID),
+		generateRandomSavedSearch(user.ID),
+	}
+
+	testCases := []struct {
+		name          string
+		setupAuth     func(t *testing.T, r *http.Request, maker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListSavedSearchesByUserID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(savedSearches, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res []savedSearchResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Len(t, res, len(savedSearches))
+				for i, savedSearch := range savedSearches {
+					require.Equal(t, savedSearch.ID, res[i].ID)
+					require.Equal(t, savedSearch.Name, res[i].Name)
+				}
+			},
+		},
+		{
+			name:      "Unauthorized",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListSavedSearchesByUserID(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error",
+			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListSavedSearchesByUserID(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil, nil)
+			recorder := httptest.NewRecorder()
+
+			url := BaseUrl + "/saved-searches"
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, req, server.tokenMaker)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetSavedSearchAPI(t *testing.T) {
+	user, _ := generateRandomUser(t)
+	savedSearch := generateRandomSavedSearch(user.ID)
+
+	testCases := []struct {
+		name          string
+		id            int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			id:   savedSearch.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetSavedSearch(gomock.Any(), gomock.Eq(db.GetSavedSearchParams{
+						ID:     savedSearch.ID,
+						UserID: user.ID,
+					})).
+					Times(1).
+					Return(savedSearch, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res savedSearchResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, newSavedSearchResponse(savedSearch).Query, res.Query)
+				require.Equal(t, savedSearch.Frequency, res.Frequency)
+			},
+		},
+		{
+			name: "Not Found",
+			id:   savedSearch.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetSavedSearch(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.SavedSearch{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "Invalid ID",
+			id:   0,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetSavedSearch(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error",
+			id:   savedSearch.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetSavedSearch(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.SavedSearch{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil, nil)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("%s/saved-searches/%d", BaseUrl, tc.id)
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUpdateSavedSearchAPI(t *testing.T) {
+	user, _ := generateRandomUser(t)
+	savedSearch := generateRandomSavedSearch(user.ID)
+	title := utils.RandomString(6)
+
+	testCases := []struct {
+		name          string
+		id            int64
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			id:   savedSearch.ID,
+			body: gin.H{
+				"name":      savedSearch.Name,
+				"title":     title,
+				"frequency": db.SavedSearchFrequencyNever,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateSavedSearch(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.UpdateSavedSearchParams) (db.SavedSearch, error) {
+						require.Equal(t, savedSearch.ID, arg.ID)
+						require.Equal(t, user.ID, arg.UserID)
+						require.Equal(t, sql.NullString{String: title, Valid: true}, arg.Title)
+						// the search is replaced, the filters that are not sent are removed
+						require.Empty(t, arg.Query)
+						require.False(t, arg.Industry.Valid)
+						require.Equal(t, db.SavedSearchFrequencyNever, arg.Frequency)
+
+						res := savedSearch
+						res.Query = arg.Query
+						res.Title = arg.Title
+						res.Industry = arg.Industry
+						res.Frequency = arg.Frequency
+						return res, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res savedSearchResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, title, res.Title)
+				require.Empty(t, res.Industry)
+				require.Equal(t, db.SavedSearchFrequencyNever, res.Frequency)
+			},
+		},
+		{
+			name: "Not Found",
+			id:   savedSearch.ID,
+			body: gin.H{
+				"name": savedSearch.Name,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateSavedSearch(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.SavedSearch{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "Invalid Remote Policy",
+			id:   savedSearch.ID,
+			body: gin.H{
+				"name":          savedSearch.Name,
+				"remote_policy": "anywhere",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateSavedSearch(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Invalid ID",
+			id:   0,
+			body: gin.H{
+				"name": savedSearch.Name,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateSavedSearch(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error",
+			id:   savedSearch.ID,
+			body: gin.H{
+				"name": savedSearch.Name,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateSavedSearch(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.SavedSearch{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil, nil)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("%s/saved-searches/%d", BaseUrl, tc.id)
+			req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDeleteSavedSearchAPI(t *testing.T) {
+	user, _ := generateRandomUser(t)
+	savedSearch := generateRandomSavedSearch(user.ID)
+
+	testCases := []struct {
+		name          string
+		id            int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			id:   savedSearch.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteSavedSearch(gomock.Any(), gomock.Eq(db.DeleteSavedSearchParams{
+						ID:     savedSearch.ID,
+						UserID: user.ID,
+					})).
+					Times(1).
+					Return(savedSearch, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNoContent, recorder.Code)
+			},
+		},
+		{
+			name: "Not Found",
+			id:   savedSearch.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteSavedSearch(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.SavedSearch{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "Invalid ID",
+			id:   0,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteSavedSearch(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error",
+			id:   savedSearch.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteSavedSearch(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.SavedSearch{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil, nil)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("%s/saved-searches/%d", BaseUrl, tc.id)
+			req, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnsubscribeSavedSearchAPI(t *testing.T) {
+	user, _ := generateRandomUser(t)
+	savedSearch := generateRandomSavedSearch(user.ID)
+
+	testCases := []struct {
+		name          string
+		token         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			token: savedSearch.UnsubscribeToken,
+			buildStubs: func(store *mockdb.MockStore) {
+				unsubscribed := savedSearch
+				unsubscribed.Frequency = db.SavedSearchFrequencyNever
+				store.EXPECT().
+					UnsubscribeSavedSearch(gomock.Any(), gomock.Eq(savedSearch.UnsubscribeToken)).
+					Times(1).
+					Return(unsubscribed, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res unsubscribeSavedSearchResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Contains(t, res.Message, savedSearch.Name)
+			},
+		},
+		{
+			name:  "Not Found",
+			token: utils.RandomString(32),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UnsubscribeSavedSearch(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.SavedSearch{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:  "Missing Token",
+			token: "",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UnsubscribeSavedSearch(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Internal Server Error",
+			token: savedSearch.UnsubscribeToken,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UnsubscribeSavedSearch(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.SavedSearch{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil, nil)
+			recorder := httptest.NewRecorder()
+
+			// the one-click unsubscribe of the mail clients works without logging in
+			url := fmt.Sprintf("%s/saved-searches/unsubscribe?token=%s", BaseUrl, tc.token)
+			req, err := http.NewRequest(http.MethodPost, url, strings.NewReader("List-Unsubscribe=One-Click"))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetUnsubscribeSavedSearchPageAPI(t *testing.T) {
+	testCases := []struct {
+		name          string
+		token         string
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			token: utils.RandomString(32),
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Header().Get("Content-Type"), "text/html")
+				require.Contains(t, recorder.Body.String(), `<form method="post">`)
+			},
+		},
+		{
+			name:  "Missing Token",
+			token: "",
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// opening the link of the emails does not unsubscribe
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				UnsubscribeSavedSearch(gomock.Any(), gomock.Any()).
+				Times(0)
+
+			server := newTestServer(t, store, nil, nil)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("%s/saved-searches/unsubscribe?token=%s", BaseUrl, tc.token)
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
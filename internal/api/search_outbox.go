@@ -0,0 +1,68 @@
+package api
+
+import (
+	"github.com/aalug/job-finder-go/internal/worker"
+	"github.com/gin-gonic/gin"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	"net/http"
+	"time"
+)
+
+// syncSearchIndex distributes the task that applies the search outbox entries to the elasticsearch index.
+// The entries are already committed with the change, so the failure is only logged,
+// the periodic task processes the entries later.
+func (server *Server) syncSearchIndex(ctx *gin.Context) {
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.Queue(worker.QueueDefault),
+	}
+
+	err := server.taskDistributor.DistributeTaskProcessSearchOutbox(ctx, opts...)
+	if err != nil {
+		log.Error().Err(err).Msg("cannot distribute the task to process the search outbox")
+	}
+}
+
+type replaySearchOutboxRequest struct {
+	Since time.Time `json:"since" binding:"required"`
+}
+
+type replaySearchOutboxResponse struct {
+	ReplayedEntries int64 `json:"replayed_entries"`
+}
+
+// @Schemes
+// @Summary Replay search outbox
+// @Description Apply the search outbox entries created since the given time to the elasticsearch index again. Only admins can access this endpoint. Processed entries are kept for 7 days.
+// @Tags search
+// @Accept json
+// @Produce json
+// @param ReplaySearchOutboxRequest body replaySearchOutboxRequest true "Time since which the entries are replayed"
+// @Success 202 {object} replaySearchOutboxResponse
+// @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Only admins can access this endpoint"
+// @Failure 500 {object} ErrorResponse "Any other error"
+// @Security ApiKeyAuth
+// @Router /admin/search-outbox/replay [post]
+// replaySearchOutbox handles marking the processed search outbox entries as pending again
+func (server *Server) replaySearchOutbox(ctx *gin.Context) {
+	var request replaySearchOutboxRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	replayed, err := server.store.ReplaySearchOutboxEntries(ctx, request.Since)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if replayed > 0 {
+		server.syncSearchIndex(ctx)
+	}
+
+	ctx.JSON(http.StatusAccepted, replaySearchOutboxResponse{ReplayedEntries: replayed})
+}
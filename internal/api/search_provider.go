@@ -0,0 +1,310 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/aalug/job-finder-go/internal/config"
+	"github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/aalug/job-finder-go/internal/esearch"
+	"github.com/rs/zerolog/log"
+	"strconv"
+	"strings"
+)
+
+const (
+	searchProviderElasticsearch = "elasticsearch"
+	searchProviderPostgres      = "postgres"
+	searchProviderFailover      = "failover"
+
+	// headlineFragmentDelimiter separates the fragments of the headlines of postgres
+	headlineFragmentDelimiter = " ... "
+)
+
+// searchProvider searches for jobs, suggests their values and finds similar jobs,
+// the search client of the esearch package is one
+type searchProvider interface {
+	SearchJobs(ctx context.Context, query string, filters esearch.JobFilters, page, pageSize int32, explain bool) (esearch.SearchJobsResult, error)
+	Autocomplete(ctx context.Context, prefix string, size int) ([]esearch.Suggestion, error)
+	SimilarJobs(ctx context.Context, jobID int32, size int) ([]*esearch.JobHit, error)
+}
+
+// newSearchProvider creates the search provider selected with SEARCH_PROVIDER,
+// the search client is the default
+func newSearchProvider(config config.Config, store db.Store, client esearch.ESearchClient) (searchProvider, error) {
+	switch config.SearchProvider {
+	case "", searchProviderElasticsearch:
+		return client, nil
+	case searchProviderPostgres:
+		return postgresSearch{store: store}, nil
+	case searchProviderFailover:
+		return failoverSearch{
+			primary:  client,
+			fallback: postgresSearch{store: store},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported search provider %q, use elasticsearch, postgres or failover", config.SearchProvider)
+	}
+}
+
+// failoverSearch searches with the primary provider
+// and with the fallback provider when the primary one fails
+type failoverSearch struct {
+	primary  searchProvider
+	fallback searchProvider
+}
+
+func (search failoverSearch) SearchJobs(ctx context.Context, query string, filters esearch.JobFilters, page, pageSize int32, explain bool) (esearch.SearchJobsResult, error) {
+	result, err := search.primary.SearchJobs(ctx, query, filters, page, pageSize, explain)
+	if err == nil {
+		return result, nil
+	}
+
+	log.Error().Err(err).Msg("cannot search for jobs, falling back to postgres")
+	return search.fallback.SearchJobs(ctx, query, filters, page, pageSize, explain)
+}
+
+func (search failoverSearch) Autocomplete(ctx context.Context, prefix string, size int) ([]esearch.Suggestion, error) {
+	suggestions, err := search.primary.Autocomplete(ctx, prefix, size)
+	if err == nil {
+		return suggestions, nil
+	}
+
+	log.Error().Err(err).Msg("cannot autocomplete jobs, falling back to postgres")
+	return search.fallback.Autocomplete(ctx, prefix, size)
+}
+
+func (search failoverSearch) SimilarJobs(ctx context.Context, jobID int32, size int) ([]*esearch.JobHit, error) {
+	hits, err := search.primary.SimilarJobs(ctx, jobID, size)
+	if err == nil {
+		return hits, nil
+	}
+
+	log.Error().Err(err).Int32("job_id", jobID).Msg("cannot find similar jobs, falling back to postgres")
+	return search.fallback.SimilarJobs(ctx, jobID, size)
+}
+
+// postgresSearch searches for jobs with the full-text search of postgres.
+// There is no fuzzy matching and the scores cannot be explained.
+type postgresSearch struct {
+	store db.Store
+}
+
+func (search postgresSearch) SearchJobs(ctx context.Context, query string, filters esearch.JobFilters, page, pageSize int32, explain bool) (esearch.SearchJobsResult, error) {
+	result := esearch.SearchJobsResult{
+		Jobs:     []*esearch.JobHit{},
+		Page:     page,
+		PageSize: pageSize,
+	}
+	searchFilters := jobSearchFilters(filters)
+
+	salaryBounds := esearch.SalaryFacetBounds()
+	facets, err := search.store.ListJobSearchFacets(ctx, db.ListJobSearchFacetsParams{
+		Query:        query,
+		Filters:      searchFilters,
+		SalaryBounds: salaryBounds,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	counts := esearch.FacetCounts{
+		Industries: map[string]int64{},
+		Locations:  map[string]int64{},
+		Companies:  map[string]int64{},
+		Skills:     map[string]int64{},
+		Salaries:   make([]int64, len(salaryBounds)+1),
+	}
+	for _, facet := range facets {
+		switch facet.Facet {
+		case "total":
+			result.Total = facet.Count
+		case "industry":
+			counts.Industries[facet.Value] = facet.Count
+		case "location":
+			counts.Locations[facet.Value] = facet.Count
+		case "company":
+			counts.Companies[facet.Value] = facet.Count
+		case "skill":
+			counts.Skills[facet.Value] = facet.Count
+		case "salary":
+			salaryRange, err := strconv.Atoi(facet.Value)
+			if err != nil {
+				return result, fmt.Errorf("invalid salary range %q: %w", facet.Value, err)
+			}
+			counts.Salaries[salaryRange] = facet.Count
+		}
+	}
+	result.TotalPages = int32((result.Total + int64(pageSize) - 1) / int64(pageSize))
+	result.Facets = counts.Facets()
+
+	if result.Total == 0 {
+		return result, nil
+	}
+
+	jobs, err := search.store.SearchJobsFullText(ctx, db.SearchJobsFullTextParams{
+		Query:   query,
+		Filters: searchFilters,
+		Limit:   pageSize,
+		Offset:  (page - 1) * pageSize,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	for _, job := range jobs {
+		hit := &esearch.JobHit{
+			Job: esearch.Job{
+				ID:              job.ID,
+				Title:           job.Title,
+				Industry:        job.Industry,
+				CompanyName:     job.CompanyName,
+				Description:     job.Description,
+				Location:        job.Location,
+				SalaryMin:       job.SalaryMin,
+				SalaryMax:       job.SalaryMax,
+				Requirements:    job.Requirements,
+				JobSkills:       job.Skills,
+				EmploymentType:  string(job.EmploymentType),
+				SeniorityLevel:  string(job.SeniorityLevel),
+				RemotePolicy:    string(job.RemotePolicy),
+				SalaryCurrency:  job.SalaryCurrency,
+				SalaryPeriod:    string(job.SalaryPeriod),
+				SalaryMinAnnual: job.SalaryMinAnnual,
+				SalaryMaxAnnual: job.SalaryMaxAnnual,
+				LocationPoint:   esearch.NewGeoPoint(job.Latitude, job.Longitude),
+			},
+			Score:      float64(job.Rank),
+			Highlights: map[string][]string{},
+		}
+		headlines := map[string]string{
+			"title":        job.TitleHeadline,
+			"description":  job.DescriptionHeadline,
+			"requirements": job.RequirementsHeadline,
+		}
+		for field, headline := range headlines {
+			// the headline is the beginning of the text when nothing in it matches
+			if strings.Contains(headline, "<em>") {
+				hit.Highlights[field] = strings.Split(headline, headlineFragmentDelimiter)
+			}
+		}
+		result.Jobs = append(result.Jobs, hit)
+	}
+
+	return result, nil
+}
+
+// Autocomplete suggests the values of the published jobs like the search client,
+// the prefix words are matched case-insensitively with the words of the values
+func (search postgresSearch) Autocomplete(ctx context.Context, prefix string, size int) ([]esearch.Suggestion, error) {
+	suggestions := []esearch.Suggestion{}
+
+	rows, err := search.store.ListJobSuggestions(ctx, db.ListJobSuggestionsParams{
+		PrefixWords: strings.Fields(strings.ToLower(prefix)),
+		Size:        int32(size),
+	})
+	if err != nil {
+		return suggestions, err
+	}
+
+	for _, row := range rows {
+		suggestions = append(suggestions, esearch.Suggestion{
+			Type:  row.Type,
+			Value: row.Value,
+			Count: row.Count,
+		})
+	}
+
+	return suggestions, nil
+}
+
+// SimilarJobs finds the jobs that share the most words with the job, the words are
+// the lexemes of the search vectors, so there is no weighting of the terms like in more_like_this
+func (search postgresSearch) SimilarJobs(ctx context.Context, jobID int32, size int) ([]*esearch.JobHit, error) {
+	hits := []*esearch.JobHit{}
+
+	jobs, err := search.store.ListJobsByTextSimilarity(ctx, db.ListJobsByTextSimilarityParams{
+		JobID: jobID,
+		Size:  int32(size),
+	})
+	if err != nil {
+		return hits, err
+	}
+
+	for _, job := range jobs {
+		hits = append(hits, &esearch.JobHit{
+			Job: esearch.Job{
+				ID:             job.ID,
+				Title:          job.Title,
+				Industry:       job.Industry,
+				CompanyName:    job.CompanyName,
+				Location:       job.Location,
+				SalaryMin:      job.SalaryMin,
+				SalaryMax:      job.SalaryMax,
+				JobSkills:      job.Skills,
+				EmploymentType: string(job.EmploymentType),
+				SeniorityLevel: string(job.SeniorityLevel),
+				RemotePolicy:   string(job.RemotePolicy),
+				SalaryCurrency: job.SalaryCurrency,
+				SalaryPeriod:   string(job.SalaryPeriod),
+			},
+			Score: job.Score,
+		})
+	}
+
+	return hits, nil
+}
+
+// jobSearchFilters converts the filters of the search to the filters of the full-text search
+func jobSearchFilters(filters esearch.JobFilters) db.JobSearchFilters {
+	searchFilters := db.JobSearchFilters{
+		Industry: sql.NullString{
+			String: filters.Industry,
+			Valid:  filters.Industry != "",
+		},
+		JobLocation: sql.NullString{
+			String: filters.Location,
+			Valid:  filters.Location != "",
+		},
+		CompanyName: sql.NullString{
+			String: filters.CompanyName,
+			Valid:  filters.CompanyName != "",
+		},
+		SalaryMin: sql.NullInt32{
+			Int32: filters.SalaryMin,
+			Valid: filters.SalaryMin != 0,
+		},
+		SalaryMax: sql.NullInt32{
+			Int32: filters.SalaryMax,
+			Valid: filters.SalaryMax != 0,
+		},
+		EmploymentType: db.NullEmploymentType{
+			EmploymentType: db.EmploymentType(filters.EmploymentType),
+			Valid:          filters.EmploymentType != "",
+		},
+		SeniorityLevel: db.NullSeniorityLevel{
+			SeniorityLevel: db.SeniorityLevel(filters.SeniorityLevel),
+			Valid:          filters.SeniorityLevel != "",
+		},
+		RemotePolicy: db.NullRemotePolicy{
+			RemotePolicy: db.RemotePolicy(filters.RemotePolicy),
+			Valid:        filters.RemotePolicy != "",
+		},
+		SalaryCurrency: sql.NullString{
+			String: filters.SalaryCurrency,
+			Valid:  filters.SalaryCurrency != "",
+		},
+		SalaryPeriod: db.NullSalaryPeriod{
+			SalaryPeriod: db.SalaryPeriod(filters.SalaryPeriod),
+			Valid:        filters.SalaryPeriod != "",
+		},
+		Skills: filters.Skills,
+	}
+	if filters.Near != nil {
+		searchFilters.NearLatitude = sql.NullFloat64{Float64: filters.Near.Lat, Valid: true}
+		searchFilters.NearLongitude = sql.NullFloat64{Float64: filters.Near.Lon, Valid: true}
+		searchFilters.RadiusKm = sql.NullFloat64{Float64: filters.RadiusKm, Valid: true}
+	}
+
+	return searchFilters
+}
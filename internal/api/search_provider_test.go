@@ -0,0 +1,539 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/aalug/job-finder-go/internal/config"
+	"github.com/aalug/job-finder-go/internal/db/mock"
+	"github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/aalug/job-finder-go/internal/esearch"
+	mockesearch "github.com/aalug/job-finder-go/internal/esearch/mock"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSearchJobsProvidersAPI(t *testing.T) {
+	job := generateRandomJob()
+	skill := utils.RandomString(6)
+	var page int32 = 1
+	var pageSize int32 = 10
+
+	filters := esearch.JobFilters{
+		Industry: job.Industry,
+		Skills:   []string{skill},
+	}
+	searchFilters := db.JobSearchFilters{
+		Industry: sql.NullString{
+			String: job.Industry,
+			Valid:  true,
+		},
+		Skills: []string{skill},
+	}
+	facetsParams := db.ListJobSearchFacetsParams{
+		Query:        job.Title,
+		Filters:      searchFilters,
+		SalaryBounds: esearch.SalaryFacetBounds(),
+	}
+	facets := []db.ListJobSearchFacetsRow{
+		{Facet: "total", Value: "", Count: 1},
+		{Facet: "industry", Value: job.Industry, Count: 1},
+		{Facet: "location", Value: job.Location, Count: 1},
+		{Facet: "company", Value: "Company", Count: 1},
+		{Facet: "skill", Value: skill, Count: 1},
+		{Facet: "salary", Value: "2", Count: 1},
+	}
+	jobRows := []db.SearchJobsFullTextRow{
+		{
+			ID:                  job.ID,
+			Title:               job.Title,
+			Industry:            job.Industry,
+			Description:         job.Description,
+			Location:            job.Location,
+			EmploymentType:      job.EmploymentType,
+			SalaryMinAnnual:     job.SalaryMinAnnual,
+			SalaryMaxAnnual:     job.SalaryMaxAnnual,
+			CompanyName:         "Company",
+			Skills:              []string{skill},
+			Rank:                0.5,
+			TitleHeadline:       "<em>" + job.Title + "</em>",
+			DescriptionHeadline: job.Description,
+		},
+	}
+	esResult := esearch.SearchJobsResult{
+		Jobs: []*esearch.JobHit{
+			{
+				Job:   esearch.Job{ID: job.ID, Title: job.Title},
+				Score: 3,
+			},
+		},
+		Total:      1,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: 1,
+	}
+
+	requirePostgresResult := func(t *testing.T, recorder *httptest.ResponseRecorder) {
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		data, err := io.ReadAll(recorder.Body)
+		require.NoError(t, err)
+		var result esearch.SearchJobsResult
+		err = json.Unmarshal(data, &result)
+		require.NoError(t, err)
+
+		require.Equal(t, int64(1), result.Total)
+		require.Equal(t, int32(1), result.TotalPages)
+		require.Len(t, result.Jobs, 1)
+		require.Equal(t, job.ID, result.Jobs[0].ID)
+		require.Equal(t, string(job.EmploymentType), result.Jobs[0].EmploymentType)
+		require.Equal(t, []string{skill}, result.Jobs[0].JobSkills)
+		require.Equal(t, 0.5, result.Jobs[0].Score)
+		// the description headline has no matches
+		require.Equal(t, map[string][]string{"title": {"<em>" + job.Title + "</em>"}}, result.Jobs[0].Highlights)
+
+		require.Equal(t, []esearch.FacetBucket{{Value: job.Industry, Count: 1}}, result.Facets.Industries)
+		require.Equal(t, []esearch.FacetBucket{{Value: skill, Count: 1}}, result.Facets.Skills)
+		require.Equal(t, int32(60000), *result.Facets.Salaries[2].From)
+		require.Equal(t, int64(1), result.Facets.Salaries[2].Count)
+		require.Zero(t, result.Facets.Salaries[0].Count)
+	}
+
+	testCases := []struct {
+		name          string
+		provider      string
+		buildStubs    func(store *mockdb.MockStore, client *mockesearch.MockESearchClient)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "Postgres",
+			provider: searchProviderPostgres,
+			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+				client.EXPECT().
+					SearchJobs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					ListJobSearchFacets(gomock.Any(), gomock.Eq(facetsParams)).
+					Times(1).
+					Return(facets, nil)
+				store.EXPECT().
+					SearchJobsFullText(gomock.Any(), gomock.Eq(db.SearchJobsFullTextParams{
+						Query:   job.Title,
+						Filters: searchFilters,
+						Limit:   pageSize,
+						Offset:  0,
+					})).
+					Times(1).
+					Return(jobRows, nil)
+			},
+			checkResponse: requirePostgresResult,
+		},
+		{
+			name:     "Postgres No Match",
+			provider: searchProviderPostgres,
+			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+				store.EXPECT().
+					ListJobSearchFacets(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListJobSearchFacetsRow{{Facet: "total", Value: "", Count: 0}}, nil)
+				store.EXPECT().
+					SearchJobsFullText(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+				var result esearch.SearchJobsResult
+				err = json.Unmarshal(data, &result)
+				require.NoError(t, err)
+				require.Zero(t, result.Total)
+				require.Empty(t, result.Jobs)
+				require.Len(t, result.Facets.Salaries, len(esearch.SalaryFacetBounds())+1)
+			},
+		},
+		{
+			name:     "Postgres Internal Server Error",
+			provider: searchProviderPostgres,
+			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+				store.EXPECT().
+					ListJobSearchFacets(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+				store.EXPECT().
+					SearchJobsFullText(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:     "Failover",
+			provider: searchProviderFailover,
+			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+				client.EXPECT().
+					SearchJobs(gomock.Any(), gomock.Eq(job.Title), gomock.Eq(filters), gomock.Eq(page), gomock.Eq(pageSize), gomock.Eq(false)).
+					Times(1).
+					Return(esearch.SearchJobsResult{}, errors.New("elasticsearch is unavailable"))
+				store.EXPECT().
+					ListJobSearchFacets(gomock.Any(), gomock.Eq(facetsParams)).
+					Times(1).
+					Return(facets, nil)
+				store.EXPECT().
+					SearchJobsFullText(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(jobRows, nil)
+			},
+			checkResponse: requirePostgresResult,
+		},
+		{
+			name:     "Failover Not Needed",
+			provider: searchProviderFailover,
+			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+				client.EXPECT().
+					SearchJobs(gomock.Any(), gomock.Eq(job.Title), gomock.Eq(filters), gomock.Eq(page), gomock.Eq(pageSize), gomock.Eq(false)).
+					Times(1).
+					Return(esResult, nil)
+				store.EXPECT().
+					ListJobSearchFacets(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchJobs(t, recorder.Body, esResult)
+			},
+		},
+		{
+			name:     "Failover Internal Server Error",
+			provider: searchProviderFailover,
+			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+				client.EXPECT().
+					SearchJobs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(esearch.SearchJobsResult{}, errors.New("elasticsearch is unavailable"))
+				store.EXPECT().
+					ListJobSearchFacets(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			client := mockesearch.NewMockESearchClient(ctrl)
+			tc.buildStubs(store, client)
+
+			server := newTestServer(t, store, client, nil)
+			var err error
+			server.search, err = newSearchProvider(config.Config{SearchProvider: tc.provider}, store, client)
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+
+			url := BaseUrl + "/jobs/search"
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			q := req.URL.Query()
+			q.Add("page", fmt.Sprintf("%d", page))
+			q.Add("page_size", fmt.Sprintf("%d", pageSize))
+			q.Add("search", job.Title)
+			q.Add("industry", job.Industry)
+			q.Add("skills", skill)
+			req.URL.RawQuery = q.Encode()
+
+			server.router.ServeHTTP(recorder, req)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestAutocompleteJobsProvidersAPI(t *testing.T) {
+	rows := []db.ListJobSuggestionsRow{
+		{Type: esearch.SuggestionTypeTitle, Value: "Senior Go Developer", Count: 3},
+		{Type: esearch.SuggestionTypeSkill, Value: "Go", Count: 5},
+	}
+	suggestions := []esearch.Suggestion{
+		{Type: esearch.SuggestionTypeTitle, Value: "Senior Go Developer", Count: 3},
+		{Type: esearch.SuggestionTypeSkill, Value: "Go", Count: 5},
+	}
+	suggestionsParams := db.ListJobSuggestionsParams{
+		PrefixWords: []string{"go", "dev"},
+		Size:        defaultSuggestionsSize,
+	}
+
+	requireSuggestions := func(t *testing.T, recorder *httptest.ResponseRecorder) {
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		data, err := io.ReadAll(recorder.Body)
+		require.NoError(t, err)
+		var gotSuggestions []esearch.Suggestion
+		err = json.Unmarshal(data, &gotSuggestions)
+		require.NoError(t, err)
+		require.Equal(t, suggestions, gotSuggestions)
+	}
+
+	testCases := []struct {
+		name          string
+		provider      string
+		buildStubs    func(store *mockdb.MockStore, client *mockesearch.MockESearchClient)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "Postgres",
+			provider: searchProviderPostgres,
+			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+				client.EXPECT().
+					Autocomplete(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					ListJobSuggestions(gomock.Any(), gomock.Eq(suggestionsParams)).
+					Times(1).
+					Return(rows, nil)
+			},
+			checkResponse: requireSuggestions,
+		},
+		{
+			name:     "Postgres Internal Server Error",
+			provider: searchProviderPostgres,
+			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+				store.EXPECT().
+					ListJobSuggestions(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:     "Failover",
+			provider: searchProviderFailover,
+			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+				client.EXPECT().
+					Autocomplete(gomock.Any(), gomock.Eq("Go Dev"), gomock.Eq(defaultSuggestionsSize)).
+					Times(1).
+					Return([]esearch.Suggestion{}, errors.New("elasticsearch is unavailable"))
+				store.EXPECT().
+					ListJobSuggestions(gomock.Any(), gomock.Eq(suggestionsParams)).
+					Times(1).
+					Return(rows, nil)
+			},
+			checkResponse: requireSuggestions,
+		},
+		{
+			name:     "Failover Not Needed",
+			provider: searchProviderFailover,
+			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+				client.EXPECT().
+					Autocomplete(gomock.Any(), gomock.Eq("Go Dev"), gomock.Eq(defaultSuggestionsSize)).
+					Times(1).
+					Return(suggestions, nil)
+				store.EXPECT().
+					ListJobSuggestions(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: requireSuggestions,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			client := mockesearch.NewMockESearchClient(ctrl)
+			tc.buildStubs(store, client)
+
+			server := newTestServer(t, store, client, nil)
+			var err error
+			server.search, err = newSearchProvider(config.Config{SearchProvider: tc.provider}, store, client)
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+
+			url := BaseUrl + "/jobs/autocomplete"
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			q := req.URL.Query()
+			q.Add("q", "Go Dev")
+			req.URL.RawQuery = q.Encode()
+
+			server.router.ServeHTTP(recorder, req)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestSimilarJobsProvidersAPI(t *testing.T) {
+	job := generateRandomJob()
+	jobDetails := db.GetJobDetailsRow{
+		ID:     job.ID,
+		Title:  job.Title,
+		Status: db.JobStatusPublished,
+	}
+	textSimilarityParams := db.ListJobsByTextSimilarityParams{
+		JobID: job.ID,
+		Size:  similarJobCandidates,
+	}
+	similarRows := []db.ListJobsByTextSimilarityRow{
+		{
+			ID:             job.ID + 1,
+			Title:          "Senior Go Developer",
+			CompanyName:    "Company",
+			EmploymentType: db.EmploymentTypeFullTime,
+			Skills:         []string{"Go"},
+			Score:          0.4,
+		},
+	}
+
+	requireTextSimilarity := func(t *testing.T, recorder *httptest.ResponseRecorder) {
+		require.Equal(t, http.StatusOK, recorder.Code)
+		similarJobs := requireBodySimilarJobs(t, recorder)
+
+		require.Len(t, similarJobs, 1)
+		require.Equal(t, job.ID+1, similarJobs[0].ID)
+		require.Equal(t, "Company", similarJobs[0].CompanyName)
+		require.Equal(t, string(db.EmploymentTypeFullTime), similarJobs[0].EmploymentType)
+		// the best text score and the only skill of both jobs
+		require.InDelta(t, 1.0, similarJobs[0].Score, 1e-9)
+		require.Equal(t, []string{"Go"}, similarJobs[0].SharedSkills)
+	}
+
+	testCases := []struct {
+		name          string
+		provider      string
+		buildStubs    func(store *mockdb.MockStore, client *mockesearch.MockESearchClient)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "Postgres",
+			provider: searchProviderPostgres,
+			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+				client.EXPECT().
+					SimilarJobs(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					ListJobsByTextSimilarity(gomock.Any(), gomock.Eq(textSimilarityParams)).
+					Times(1).
+					Return(similarRows, nil)
+			},
+			checkResponse: requireTextSimilarity,
+		},
+		{
+			name:     "Failover",
+			provider: searchProviderFailover,
+			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+				client.EXPECT().
+					SimilarJobs(gomock.Any(), gomock.Eq(job.ID), gomock.Eq(similarJobCandidates)).
+					Times(1).
+					Return(nil, errors.New("elasticsearch is unavailable"))
+				store.EXPECT().
+					ListJobsByTextSimilarity(gomock.Any(), gomock.Eq(textSimilarityParams)).
+					Times(1).
+					Return(similarRows, nil)
+			},
+			checkResponse: requireTextSimilarity,
+		},
+		{
+			name:     "Failover Error",
+			provider: searchProviderFailover,
+			buildStubs: func(store *mockdb.MockStore, client *mockesearch.MockESearchClient) {
+				client.EXPECT().
+					SimilarJobs(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, errors.New("elasticsearch is unavailable"))
+				store.EXPECT().
+					ListJobsByTextSimilarity(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				// only the skills are compared
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Empty(t, requireBodySimilarJobs(t, recorder))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			client := mockesearch.NewMockESearchClient(ctrl)
+			store.EXPECT().
+				GetJobDetails(gomock.Any(), gomock.Eq(job.ID)).
+				Times(1).
+				Return(jobDetails, nil)
+			store.EXPECT().
+				ListAllJobSkillsByJobID(gomock.Any(), gomock.Eq(job.ID)).
+				Times(1).
+				Return([]string{"Go"}, nil)
+			store.EXPECT().
+				ListJobsBySkillOverlap(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return([]db.ListJobsBySkillOverlapRow{}, nil)
+			tc.buildStubs(store, client)
+
+			server := newTestServer(t, store, client, nil)
+			var err error
+			server.search, err = newSearchProvider(config.Config{SearchProvider: tc.provider}, store, client)
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("%s/jobs/%d/similar", BaseUrl, job.ID)
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, req)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestNewSearchProvider(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+	client := mockesearch.NewMockESearchClient(ctrl)
+
+	for _, provider := range []string{"", searchProviderElasticsearch} {
+		search, err := newSearchProvider(config.Config{SearchProvider: provider}, store, client)
+		require.NoError(t, err)
+		require.Equal(t, client, search)
+	}
+
+	search, err := newSearchProvider(config.Config{SearchProvider: searchProviderPostgres}, store, client)
+	require.NoError(t, err)
+	require.IsType(t, postgresSearch{}, search)
+
+	_, err = newSearchProvider(config.Config{SearchProvider: "solr"}, store, client)
+	require.Error(t, err)
+
+	_, err = NewServer(config.Config{TokenSymmetricKey: utils.RandomString(32), SearchProvider: "solr"}, store, client, nil)
+	require.Error(t, err)
+}
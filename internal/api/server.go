@@ -23,13 +23,14 @@ type Server struct {
 	tokenMaker      token.Maker
 	router          *gin.Engine
 	esDetails       elasticSearchDetails
+	search          searchProvider
 	taskDistributor worker.TaskDistributor
+	oidc            *oidcDetails
 }
 
 type elasticSearchDetails struct {
-	client            esearch.ESearchClient
-	jobs              []esearch.Job
-	lastDocumentIndex int64
+	client esearch.ESearchClient
+	jobs   []esearch.Job
 }
 
 // NewServer creates a new HTTP server and setups routing
@@ -37,7 +38,7 @@ func NewServer(config config.Config, store db.Store, client esearch.ESearchClien
 	BaseUrl = config.BaseUrl
 
 	// === tokens ===
-	tokenMaker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
+	tokenMaker, err := newTokenMaker(config)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
@@ -47,12 +48,20 @@ func NewServer(config config.Config, store db.Store, client esearch.ESearchClien
 		client: client,
 	}
 
+	// === search ===
+	search, err := newSearchProvider(config, store, client)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create search provider: %w", err)
+	}
+
 	server := &Server{
 		config:          config,
 		store:           store,
 		tokenMaker:      tokenMaker,
 		esDetails:       esDetails,
+		search:          search,
 		taskDistributor: taskDistributor,
+		oidc:            newOidcDetails(config),
 	}
 
 	server.setupRouter()
@@ -60,6 +69,22 @@ func NewServer(config config.Config, store db.Store, client esearch.ESearchClien
 	return server, nil
 }
 
+// newTokenMaker creates the token maker selected with TOKEN_MAKER, PASETO is the default
+func newTokenMaker(config config.Config) (token.Maker, error) {
+	switch config.TokenMaker {
+	case "", "paseto":
+		return token.NewPasetoMaker(config.TokenSymmetricKey)
+	case "jwt":
+		keyring, err := token.LoadKeyring(config.TokenKeysDir, config.TokenCurrentKeyID)
+		if err != nil {
+			return nil, err
+		}
+		return token.NewJWTMaker(keyring)
+	default:
+		return nil, fmt.Errorf("unsupported token maker %q, use paseto or jwt", config.TokenMaker)
+	}
+}
+
 // setupRouter sets up the HTTP routing
 func (server *Server) setupRouter() {
 	router := gin.Default()
@@ -79,61 +104,132 @@ func (server *Server) setupRouter() {
 	// === users ===
 	routerV1.POST("/users", server.createUser)
 	routerV1.POST("/users/login", server.loginUser)
+	routerV1.POST("/users/login/mfa", server.loginUserMfa)
 	routerV1.GET("/users/verify-email", server.verifyUserEmail)
 	routerV1.GET("/users/send-verification-email", server.sendVerificationEmailToUser)
+	routerV1.POST("/users/forgot-password", server.forgotUserPassword)
+	routerV1.GET("/users/reset-password", server.getResetPasswordPage)
+	routerV1.POST("/users/reset-password", server.resetUserPassword)
+	routerV1.GET("/users/oidc/login", server.startOidcLogin)
+	routerV1.GET("/users/oidc/callback", server.finishOidcLogin)
 
 	// === employers ===
 	routerV1.POST("/employers", server.createEmployer)
 	routerV1.POST("/employers/login", server.loginEmployer)
+	routerV1.POST("/employers/login/mfa", server.loginEmployerMfa)
 	routerV1.GET("/employers/verify-email", server.verifyEmployerEmail)
 	routerV1.GET("/employers/send-verification-email", server.sendVerificationEmailToEmployer)
+	routerV1.POST("/employers/forgot-password", server.forgotEmployerPassword)
+	routerV1.GET("/employers/reset-password", server.getResetPasswordPage)
+	routerV1.POST("/employers/reset-password", server.resetEmployerPassword)
+	routerV1.POST("/employers/company/invitations/accept", server.acceptCompanyInvitation)
 
 	routerV1.GET("/employers/employer-company-details/:email", server.getEmployerAndCompanyDetails)
 
 	// === jobs ===
 	routerV1.GET("/jobs/:id", server.getJob)
+	routerV1.GET("/jobs/:id/similar", server.listSimilarJobs)
 	routerV1.GET("/jobs", server.filterAndListJobs)
 	routerV1.GET("/jobs/company", server.listJobsByCompany)
 	routerV1.GET("/jobs/search", server.searchJobs)
+	routerV1.GET("/jobs/autocomplete", server.autocompleteJobs)
+
+	// === saved searches ===
+	// the unsubscribe links of the emails work without logging in,
+	// the link only opens the page that posts the unsubscribe
+	routerV1.GET("/saved-searches/unsubscribe", server.getUnsubscribeSavedSearchPage)
+	routerV1.POST("/saved-searches/unsubscribe", server.unsubscribeSavedSearch)
+
+	// === currency rates ===
+	routerV1.GET("/currency-rates", server.listCurrencyRates)
+
+	// === tokens ===
+	routerV1.POST("/tokens/renew-access", server.renewAccessToken)
+	routerV1.GET("/tokens/jwks", server.getJWKS)
 
 	// ===== routes that require authentication =====
-	authRoutesV1 := routerV1.Group("/").Use(authMiddleware(server.tokenMaker))
+	// API keys can be used only on the routes that require their scope
+	authRoutesV1 := routerV1.Group("/").Use(authMiddleware(server.tokenMaker, server.store), requireApiKeyScope())
+	// wrong account type gets 403 before reaching the handler
+	userRoutesV1 := routerV1.Group("/").Use(authMiddleware(server.tokenMaker, server.store), requireUser())
+	employerRoutesV1 := routerV1.Group("/").Use(authMiddleware(server.tokenMaker, server.store), requireEmployer(), requireApiKeyScope())
+	jobsReadRoutesV1 := routerV1.Group("/").Use(authMiddleware(server.tokenMaker, server.store), requireEmployer(), requireApiKeyScope(apiKeyScopeJobsRead))
+	jobsWriteRoutesV1 := routerV1.Group("/").Use(authMiddleware(server.tokenMaker, server.store), requireEmployer(), requireApiKeyScope(apiKeyScopeJobsWrite))
+	applicationsReadRoutesV1 := routerV1.Group("/").Use(authMiddleware(server.tokenMaker, server.store), requireEmployer(), requireApiKeyScope(apiKeyScopeApplicationsRead))
+	adminRoutesV1 := routerV1.Group("/").Use(authMiddleware(server.tokenMaker, server.store), requireApiKeyScope(), requireAdmin(server.config.AdminEmails))
 
 	// === users ===
-	authRoutesV1.GET("/users", server.getUser)
-	authRoutesV1.PATCH("/users", server.updateUser)
-	authRoutesV1.PATCH("/users/password", server.updateUserPassword)
-	authRoutesV1.DELETE("/users", server.deleteUser)
+	userRoutesV1.POST("/users/logout", server.logout)
+	userRoutesV1.GET("/users", server.getUser)
+	userRoutesV1.PATCH("/users", server.updateUser)
+	userRoutesV1.PATCH("/users/password", server.updateUserPassword)
+	userRoutesV1.DELETE("/users", server.deleteUser)
+	userRoutesV1.POST("/users/mfa/enroll", server.enrollMfa)
+	userRoutesV1.POST("/users/mfa/confirm", server.confirmMfa)
+	userRoutesV1.DELETE("/users/mfa", server.disableMfa)
 
 	// === employers ===
-	authRoutesV1.GET("/employers", server.getEmployer)
-	authRoutesV1.PATCH("/employers", server.updateEmployer)
-	authRoutesV1.PATCH("/employers/password", server.updateEmployerPassword)
-	authRoutesV1.DELETE("/employers", server.deleteEmployer)
-	authRoutesV1.GET("/employers/user-details/:email", server.getUserAsEmployer)
+	employerRoutesV1.POST("/employers/logout", server.logout)
+	employerRoutesV1.GET("/employers", server.getEmployer)
+	employerRoutesV1.PATCH("/employers", server.updateEmployer)
+	employerRoutesV1.PATCH("/employers/password", server.updateEmployerPassword)
+	employerRoutesV1.DELETE("/employers", server.deleteEmployer)
+	employerRoutesV1.GET("/employers/user-details/:email", server.getUserAsEmployer)
+	employerRoutesV1.POST("/employers/mfa/enroll", server.enrollMfa)
+	employerRoutesV1.POST("/employers/mfa/confirm", server.confirmMfa)
+	employerRoutesV1.DELETE("/employers/mfa", server.disableMfa)
+	employerRoutesV1.POST("/employers/api-keys", server.createApiKey)
+	employerRoutesV1.GET("/employers/api-keys", server.listApiKeys)
+	employerRoutesV1.DELETE("/employers/api-keys/:id", server.revokeApiKey)
+	employerRoutesV1.GET("/employers/company/members", server.listCompanyMembers)
+	employerRoutesV1.PATCH("/employers/company/members/:id/role", server.updateCompanyMemberRole)
+	employerRoutesV1.DELETE("/employers/company/members/:id", server.removeCompanyMember)
+	employerRoutesV1.POST("/employers/company/invitations", server.inviteCompanyMember)
 
 	// === jobs ===
-	// for employers, jobs CRUD
-	authRoutesV1.POST("/jobs", server.createJob)
-	authRoutesV1.GET("/jobs/employer", server.listEmployerJobs)
-	authRoutesV1.PATCH("/jobs/:id", server.updateJob)
-	authRoutesV1.DELETE("/jobs/:id", server.deleteJob)
+	// for employers, jobs CRUD, also with API keys
+	jobsWriteRoutesV1.POST("/jobs", server.createJob)
+	jobsReadRoutesV1.GET("/jobs/employer", server.listEmployerJobs)
+	jobsWriteRoutesV1.PATCH("/jobs/:id", server.updateJob)
+	jobsWriteRoutesV1.DELETE("/jobs/:id", server.deleteJob)
+	jobsWriteRoutesV1.PATCH("/jobs/:id/status", server.changeJobStatus)
 
 	// for users, listing jobs that use user details
-	authRoutesV1.GET("/jobs/match-skills", server.listJobsByMatchingSkills)
+	userRoutesV1.GET("/jobs/match-skills", server.listJobsByMatchingSkills)
 
 	// === job applications ===
 	// for users, job applications CRUD
-	authRoutesV1.POST("/job-applications", server.createJobApplication)
-	authRoutesV1.GET("/job-applications/user/:id", server.getJobApplicationForUser)
-	authRoutesV1.PATCH("/job-applications/user/:id", server.updateJobApplication)
-	authRoutesV1.DELETE("/job-applications/user/:id", server.deleteJobApplication)
-	authRoutesV1.GET("/job-applications/user", server.listJobApplicationsForUser)
+	userRoutesV1.POST("/job-applications", server.createJobApplication)
+	userRoutesV1.GET("/job-applications/user/:id", server.getJobApplicationForUser)
+	userRoutesV1.PATCH("/job-applications/user/:id", server.updateJobApplication)
+	userRoutesV1.DELETE("/job-applications/user/:id", server.deleteJobApplication)
+	userRoutesV1.GET("/job-applications/user", server.listJobApplicationsForUser)
 
 	// for employers, reading, changing statuses (rejecting, offering)
-	authRoutesV1.GET("/job-applications/employer/:id", server.getJobApplicationForEmployer)
-	authRoutesV1.PATCH("/job-applications/employer/:id/status", server.changeJobApplicationStatus)
-	authRoutesV1.GET("/job-applications/employer", server.listJobApplicationsForEmployer)
+	// reading is possible with API keys too
+	applicationsReadRoutesV1.GET("/job-applications/employer/:id", server.getJobApplicationForEmployer)
+	employerRoutesV1.PATCH("/job-applications/employer/:id/status", server.changeJobApplicationStatus)
+	applicationsReadRoutesV1.GET("/job-applications/employer", server.listJobApplicationsForEmployer)
+
+	// === saved searches ===
+	// for users, saved searches CRUD
+	userRoutesV1.POST("/saved-searches", server.createSavedSearch)
+	userRoutesV1.GET("/saved-searches", server.listSavedSearches)
+	userRoutesV1.GET("/saved-searches/:id", server.getSavedSearch)
+	userRoutesV1.PUT("/saved-searches/:id", server.updateSavedSearch)
+	userRoutesV1.DELETE("/saved-searches/:id", server.deleteSavedSearch)
+
+	// === sessions ===
+	// for both users and employers
+	authRoutesV1.GET("/sessions", server.listSessions)
+	authRoutesV1.DELETE("/sessions/:id", server.revokeSession)
+
+	// === currency rates ===
+	adminRoutesV1.PUT("/admin/currency-rates/:currency", server.updateCurrencyRate)
+
+	// === search relevance ===
+	adminRoutesV1.GET("/admin/jobs/search", server.searchJobs)
+	adminRoutesV1.POST("/admin/search-outbox/replay", server.replaySearchOutbox)
 
 	server.router = router
 }
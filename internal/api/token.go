@@ -0,0 +1,198 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	db "github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/aalug/job-finder-go/pkg/token"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+var (
+	noPublicKeysError    = errors.New("tokens are not signed with public keys")
+	notRefreshTokenError = errors.New("token is not a refresh token")
+)
+
+type renewAccessTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type renewAccessTokenResponse struct {
+	AccessToken          string    `json:"access_token"`
+	AccessTokenExpiresAt time.Time `json:"access_token_expires_at"`
+}
+
+// @Schemes
+// @Summary Renew access token
+// @Description Renew access token with a refresh token that was returned at login. Works for both users and employers.
+// @Tags tokens
+// @Accept json
+// @Produce json
+// @param RenewAccessTokenRequest body renewAccessTokenRequest true "Refresh token"
+// @Success 200 {object} renewAccessTokenResponse
+// @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 401 {object} ErrorResponse "Invalid, expired or blocked refresh token"
+// @Failure 404 {object} ErrorResponse "Session not found"
+// @Failure 500 {object} ErrorResponse "Any other error"
+// @Router /tokens/renew-access [post]
+// renewAccessToken handles creating a new access token from a refresh token
+func (server *Server) renewAccessToken(ctx *gin.Context) {
+	var request renewAccessTokenRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	refreshPayload, err := server.tokenMaker.VerifyToken(request.RefreshToken)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	if refreshPayload.TokenType != token.TokenTypeRefresh {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(notRefreshTokenError))
+		return
+	}
+
+	session, err := server.store.GetSession(ctx, refreshPayload.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if session.IsBlocked {
+		err := fmt.Errorf("session is blocked")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	if session.Email != refreshPayload.Email {
+		err := fmt.Errorf("incorrect session user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	if session.RefreshToken != request.RefreshToken {
+		err := fmt.Errorf("mismatched session token")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	if time.Now().After(session.ExpiresAt) {
+		err := fmt.Errorf("session has expired")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	accessToken, accessPayload, err := server.tokenMaker.CreateToken(
+		refreshPayload.Email,
+		refreshPayload.AccountType,
+		refreshPayload.AccountID,
+		server.config.AccessTokenDuration,
+	)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := renewAccessTokenResponse{
+		AccessToken:          accessToken,
+		AccessTokenExpiresAt: accessPayload.ExpiredAt,
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type logoutRequest struct {
+	SessionID string `json:"session_id" binding:"omitempty,uuid"`
+}
+
+type logoutResponse struct {
+	Message string `json:"message"`
+}
+
+// @Schemes
+// @Summary Logout
+// @Description Logout the authenticated user or employer. The access token used for the request is revoked. If session_id is provided, the session is blocked as well, so its refresh token can no longer be used.
+// @Tags users, employers
+// @Accept json
+// @Produce json
+// @param LogoutRequest body logoutRequest false "Session to block"
+// @Success 200 {object} logoutResponse
+// @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 404 {object} ErrorResponse "Session not found"
+// @Failure 500 {object} ErrorResponse "Any other error"
+// @Security ApiKeyAuth
+// @Router /users/logout [post]
+// @Router /employers/logout [post]
+// logout handles revoking the access token (and optionally the session) of the authenticated user or employer
+func (server *Server) logout(ctx *gin.Context) {
+	var request logoutRequest
+	// the body is optional
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	if request.SessionID != "" {
+		_, err := server.store.BlockSession(ctx, db.BlockSessionParams{
+			ID:    uuid.MustParse(request.SessionID),
+			Email: authPayload.Email,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				err = fmt.Errorf("session with given ID does not exist")
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	err := server.store.RevokeToken(ctx, db.RevokeTokenParams{
+		ID:        authPayload.ID,
+		Email:     authPayload.Email,
+		ExpiresAt: authPayload.ExpiredAt,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, logoutResponse{Message: "logged out successfully"})
+}
+
+// @Schemes
+// @Summary Get token public keys
+// @Description Get the public keys (JSON Web Key Set) that can be used to verify the tokens. Available only when tokens are JWTs signed with public key cryptography (TOKEN_MAKER=jwt).
+// @Tags tokens
+// @Produce json
+// @Success 200 {object} token.JWKS
+// @Failure 404 {object} ErrorResponse "Tokens are not signed with public keys"
+// @Router /tokens/jwks [get]
+// getJWKS handles getting the public keys of the token maker
+func (server *Server) getJWKS(ctx *gin.Context) {
+	provider, ok := server.tokenMaker.(token.PublicKeyProvider)
+	if !ok {
+		ctx.JSON(http.StatusNotFound, errorResponse(noPublicKeysError))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, provider.JWKS())
+}
@@ -6,11 +6,13 @@ import (
 	"errors"
 	"fmt"
 	db "github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/aalug/job-finder-go/internal/geo"
 	"github.com/aalug/job-finder-go/internal/worker"
 	"github.com/aalug/job-finder-go/pkg/token"
 	"github.com/aalug/job-finder-go/pkg/utils"
 	"github.com/aalug/job-finder-go/pkg/validation"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
 	"github.com/lib/pq"
 	"net/http"
@@ -18,7 +20,8 @@ import (
 )
 
 var (
-	emailNotVerifiedErr = errors.New("email not verified. Please verify your email before logging in")
+	emailNotVerifiedErr      = errors.New("email not verified. Please verify your email before logging in")
+	resetPasswordNotFoundErr = errors.New("no reset password request found with the provided details. It may have expired or been used already")
 )
 
 type Skill struct {
@@ -114,12 +117,13 @@ func (server *Server) createUser(ctx *gin.Context) {
 		return
 	}
 
+	location, _ := geo.NormalizeLocation(request.Location)
 	params := db.CreateUserTxParams{
 		CreateUserParams: db.CreateUserParams{
 			FullName:         request.FullName,
 			Email:            request.Email,
 			HashedPassword:   hashedPassword,
-			Location:         request.Location,
+			Location:         location,
 			DesiredJobTitle:  request.DesiredJobTitle,
 			DesiredIndustry:  request.DesiredIndustry,
 			DesiredSalaryMin: request.DesiredSalaryMin,
@@ -188,8 +192,12 @@ type loginUserRequest struct {
 }
 
 type loginUserResponse struct {
-	AccessToken string       `json:"access_token"`
-	User        userResponse `json:"user"`
+	SessionID             uuid.UUID    `json:"session_id"`
+	AccessToken           string       `json:"access_token"`
+	AccessTokenExpiresAt  time.Time    `json:"access_token_expires_at"`
+	RefreshToken          string       `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time    `json:"refresh_token_expires_at"`
+	User                  userResponse `json:"user"`
 }
 
 // @Schemes
@@ -200,10 +208,13 @@ type loginUserResponse struct {
 // @Produce json
 // @param LoginUserRequest body loginUserRequest true "User credentials"
 // @Success 200 {object} loginUserResponse
+// @Success 202 {object} mfaRequiredResponse "MFA is enabled, finish the login with POST /users/login/mfa"
 // @Failure 400 {object} ErrorResponse "Invalid request body"
 // @Failure 401 {object} ErrorResponse "Incorrect password"
 // @Failure 403 {object} ErrorResponse "Email not verified"
 // @Failure 404 {object} ErrorResponse "User with given email does not exist"
+// @Failure 423 {object} ErrorResponse "Account locked after too many failed login attempts"
+// @Failure 429 {object} ErrorResponse "Too many failed login attempts, retry after the time in the Retry-After header"
 // @Failure 500 {object} ErrorResponse "Any other error"
 // @Router /users/login [post]
 // loginUser handles user login
@@ -214,9 +225,17 @@ func (server *Server) loginUser(ctx *gin.Context) {
 		return
 	}
 
+	if !server.checkLoginAttempts(ctx, token.AccountTypeUser, request.Email) {
+		return
+	}
+
 	user, err := server.store.GetUserByEmail(ctx, request.Email)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
+			if err := server.recordFailedLoginFromIP(ctx); err != nil {
+				ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+				return
+			}
 			err = fmt.Errorf("user with this email does not exist")
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
 			return
@@ -234,12 +253,59 @@ func (server *Server) loginUser(ctx *gin.Context) {
 
 	err = utils.CheckPassword(request.Password, user.HashedPassword)
 	if err != nil {
+		locked, err := server.recordFailedLogin(ctx, token.AccountTypeUser, user.Email)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if locked {
+			ctx.JSON(http.StatusLocked, errorResponse(accountLockedError))
+			return
+		}
+
 		err = fmt.Errorf("incorrect password")
 		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
 	}
 
-	accessToken, err := server.tokenMaker.CreateToken(user.Email, server.config.AccessTokenDuration)
+	// accounts with MFA enabled have to finish the login with POST /users/login/mfa
+	if server.requireMfaLogin(ctx, user.Email, token.AccountTypeUser, user.ID) {
+		return
+	}
+
+	server.completeUserLogin(ctx, user)
+}
+
+// completeUserLogin creates the access and refresh tokens and the session of the user
+// and writes the login response
+func (server *Server) completeUserLogin(ctx *gin.Context, user db.User) {
+	// the login succeeded, so the failed attempts of the account are forgotten
+	if err := server.resetLoginAttempts(ctx, token.AccountTypeUser, user.Email); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	accessToken, accessPayload, err := server.tokenMaker.CreateToken(user.Email, token.AccountTypeUser, user.ID, server.config.AccessTokenDuration)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	refreshToken, refreshPayload, err := server.tokenMaker.CreateRefreshToken(user.Email, token.AccountTypeUser, user.ID, server.config.RefreshTokenDuration)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	session, err := server.store.CreateSession(ctx, db.CreateSessionParams{
+		ID:           refreshPayload.ID,
+		Email:        user.Email,
+		RefreshToken: refreshToken,
+		UserAgent:    ctx.Request.UserAgent(),
+		ClientIp:     ctx.ClientIP(),
+		IsBlocked:    false,
+		ExpiresAt:    refreshPayload.ExpiredAt,
+	})
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
@@ -258,20 +324,60 @@ func (server *Server) loginUser(ctx *gin.Context) {
 	}
 
 	res := loginUserResponse{
-		AccessToken: accessToken,
-		User:        newUserResponse(user, userSkills),
+		SessionID:             session.ID,
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessPayload.ExpiredAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: refreshPayload.ExpiredAt,
+		User:                  newUserResponse(user, userSkills),
 	}
 
 	ctx.JSON(http.StatusOK, res)
 }
 
+// @Schemes
+// @Summary Login user with MFA
+// @Description Second step of the login of a user with MFA enabled. Exchanges the "mfa pending" token returned by POST /users/login and a code from the authenticator app (or a recovery code) for the access and refresh tokens.
+// @Tags users
+// @Accept json
+// @Produce json
+// @param LoginMfaRequest body loginMfaRequest true "MFA token and code"
+// @Success 200 {object} loginUserResponse
+// @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 401 {object} ErrorResponse "Invalid or used MFA token or invalid code"
+// @Failure 423 {object} ErrorResponse "Account locked after too many failed login attempts"
+// @Failure 429 {object} ErrorResponse "Too many failed login attempts, retry after the time in the Retry-After header"
+// @Failure 500 {object} ErrorResponse "Any other error"
+// @Router /users/login/mfa [post]
+// loginUserMfa handles the second step of the login of a user with MFA enabled
+func (server *Server) loginUserMfa(ctx *gin.Context) {
+	mfaPayload, ok := server.verifyMfaLogin(ctx, token.AccountTypeUser)
+	if !ok {
+		return
+	}
+
+	user, err := server.store.GetUserByEmail(ctx, mfaPayload.Email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(accountNotFoundError))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.completeUserLogin(ctx, user)
+}
+
 // @Schemes
 // @Summary Get user
 // @Description Get details of the logged-in user
 // @Tags users
 // @Produce json
 // @Success 200 {object} userResponse
-// @Failure 401 {object} ErrorResponse "Only users can access this endpoint, not employers."
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Only users can access this endpoint, not employers."
 // @Failure 500 {object} ErrorResponse "Any other error"
 // @Security ApiKeyAuth
 // @Router /users [get]
@@ -281,9 +387,7 @@ func (server *Server) getUser(ctx *gin.Context) {
 	user, userSkills, err := server.store.GetUserDetailsByEmail(ctx, authPayload.Email)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			// but middleware did not stop the request, so we assume
-			// that the request was made by an employer
-			ctx.JSON(http.StatusUnauthorized, errorResponse(onlyUsersAccessError))
+			ctx.JSON(http.StatusUnauthorized, errorResponse(accountNotFoundError))
 			return
 		}
 
@@ -317,7 +421,8 @@ type updateUserRequest struct {
 // @param UpdateUserRequest body updateUserRequest true "User details to update"
 // @Success 200 {object} userResponse
 // @Failure 400 {object} ErrorResponse "Invalid request body"
-// @Failure 401 {object} ErrorResponse "Only users can update their details using this endpoint."
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Only users can access this endpoint, not employers."
 // @Failure 500 {object} ErrorResponse "Any other error"
 // @Security ApiKeyAuth
 // @Router /users [patch]
@@ -341,9 +446,7 @@ func (server *Server) updateUser(ctx *gin.Context) {
 	authUser, err := server.store.GetUserByEmail(ctx, authPayload.Email)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			// but middleware did not stop the request, so we assume
-			// that the request was made by an employer
-			ctx.JSON(http.StatusUnauthorized, errorResponse(onlyUsersAccessError))
+			ctx.JSON(http.StatusUnauthorized, errorResponse(accountNotFoundError))
 			return
 		}
 
@@ -388,6 +491,8 @@ func (server *Server) updateUser(ctx *gin.Context) {
 	}
 	if request.Location == "" {
 		params.Location = authUser.Location
+	} else {
+		params.Location, _ = geo.NormalizeLocation(request.Location)
 	}
 	if request.DesiredJobTitle == "" {
 		params.DesiredJobTitle = authUser.DesiredJobTitle
@@ -459,14 +564,15 @@ type updateUserPasswordResponse struct {
 
 // @Schemes
 // @Summary Update user password
-// @Description Change / update password of the logged-in user
+// @Description Change / update password of the logged-in user. All existing tokens and sessions of the user are revoked, so they have to log in again.
 // @Tags users
 // @Accept json
 // @Produce json
 // @param UpdateUserPasswordRequest body updateUserPasswordRequest true "Users old and new password"
 // @Success 200 {object} updateUserPasswordResponse
 // @Failure 400 {object} ErrorResponse "Invalid request body"
-// @Failure 401 {object} ErrorResponse "Incorrect password or the account making the request is not a user."
+// @Failure 401 {object} ErrorResponse "Incorrect password"
+// @Failure 403 {object} ErrorResponse "Only users can access this endpoint, not employers."
 // @Failure 500 {object} ErrorResponse "Any other error"
 // @Security ApiKeyAuth
 // @Router /users/password [patch]
@@ -482,9 +588,7 @@ func (server *Server) updateUserPassword(ctx *gin.Context) {
 	authUser, err := server.store.GetUserByEmail(ctx, authPayload.Email)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			// but middleware did not stop the request, so we assume
-			// that the request was made by an employer
-			ctx.JSON(http.StatusUnauthorized, errorResponse(onlyUsersAccessError))
+			ctx.JSON(http.StatusUnauthorized, errorResponse(accountNotFoundError))
 			return
 		}
 
@@ -505,12 +609,12 @@ func (server *Server) updateUserPassword(ctx *gin.Context) {
 		return
 	}
 
-	params := db.UpdatePasswordParams{
+	// every token issued before the password change is revoked
+	err = server.store.UpdateUserPasswordTx(ctx, db.UpdatePasswordTxParams{
 		ID:             authUser.ID,
+		Email:          authUser.Email,
 		HashedPassword: hashedPassword,
-	}
-
-	err = server.store.UpdatePassword(ctx, params)
+	})
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
@@ -524,7 +628,8 @@ func (server *Server) updateUserPassword(ctx *gin.Context) {
 // @Description Delete the logged-in user
 // @Tags users
 // @Success 204 {null} null
-// @Failure 401 {object} ErrorResponse "Only users can update their details using this endpoint."
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Only users can access this endpoint, not employers."
 // @Failure 500 {object} ErrorResponse "Any other error"
 // @Security ApiKeyAuth
 // @Router /users [delete]
@@ -534,9 +639,7 @@ func (server *Server) deleteUser(ctx *gin.Context) {
 	authUser, err := server.store.GetUserByEmail(ctx, authPayload.Email)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			// but middleware did not stop the request, so we assume
-			// that the request was made by an employer
-			ctx.JSON(http.StatusUnauthorized, errorResponse(onlyUsersAccessError))
+			ctx.JSON(http.StatusUnauthorized, errorResponse(accountNotFoundError))
 			return
 		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
@@ -674,3 +777,128 @@ func (server *Server) sendVerificationEmailToUser(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, sendVerificationEmailToUserResponse{Message: "verification email sent"})
 }
+
+type forgotUserPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type forgotUserPasswordResponse struct {
+	Message string `json:"message"`
+}
+
+// @Schemes
+// @Summary Forgot user password
+// @Description Send to the user an email with a link and a single-use code that can be used to reset the password.
+// @Tags users
+// @Accept json
+// @Produce json
+// @param ForgotUserPasswordRequest body forgotUserPasswordRequest true "Email address of the user"
+// @Success 200 {object} forgotUserPasswordResponse
+// @Failure 400 {object} ErrorResponse "Invalid request body."
+// @Failure 404 {object} ErrorResponse "No user found with the provided email."
+// @Failure 500 {object} ErrorResponse "Any other error."
+// @Router /users/forgot-password [post]
+// forgotUserPassword sends the reset password email to the user
+func (server *Server) forgotUserPassword(ctx *gin.Context) {
+	var request forgotUserPasswordRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	user, err := server.store.GetUserByEmail(ctx, request.Email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = fmt.Errorf("user with email %s does not exist", request.Email)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// delete previous reset password from the database,
+	// so only the code from the latest email can be used
+	err = server.store.DeleteResetPassword(ctx, db.DeleteResetPasswordParams{
+		Email:       user.Email,
+		AccountType: string(token.AccountTypeUser),
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	taskPayload := &worker.PayloadSendResetPasswordEmail{
+		Email:       user.Email,
+		FullName:    user.FullName,
+		AccountType: "users",
+	}
+
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.Queue(worker.QueueCritical),
+	}
+
+	err = server.taskDistributor.DistributeTaskSendResetPasswordEmail(ctx, taskPayload, opts...)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, forgotUserPasswordResponse{Message: "reset password email sent"})
+}
+
+type resetUserPasswordRequest struct {
+	ID          int64  `json:"id" form:"id" binding:"required,min=1"`
+	SecretCode  string `json:"code" form:"code" binding:"required,min=32"`
+	NewPassword string `json:"new_password" form:"new_password" binding:"required,min=6"`
+}
+
+type resetUserPasswordResponse struct {
+	Message string `json:"message"`
+}
+
+// @Schemes
+// @Summary Reset user password
+// @Description Set a new password of the user using the ID and the code from the reset password email. The code can be used only once. All existing tokens and sessions of the user are revoked. The page of the reset link posts the request as a form.
+// @Tags users
+// @Accept json,x-www-form-urlencoded
+// @Produce json
+// @param ResetUserPasswordRequest body resetUserPasswordRequest true "Reset password ID, code and the new password"
+// @Success 200 {object} resetUserPasswordResponse
+// @Failure 400 {object} ErrorResponse "Invalid request body."
+// @Failure 404 {object} ErrorResponse "Code not found, expired or already used."
+// @Failure 500 {object} ErrorResponse "Any other error."
+// @Router /users/reset-password [post]
+// resetUserPassword sets the new password of the user
+func (server *Server) resetUserPassword(ctx *gin.Context) {
+	var request resetUserPasswordRequest
+	if err := bindResetPasswordRequest(ctx, &request); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	hashedPassword, err := utils.HashPassword(request.NewPassword)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	_, err = server.store.ResetUserPasswordTx(ctx, db.ResetPasswordTxParams{
+		ID:             request.ID,
+		SecretCode:     request.SecretCode,
+		HashedPassword: hashedPassword,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(resetPasswordNotFoundErr))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resetUserPasswordResponse{Message: "password reset successfully"})
+}
@@ -14,6 +14,7 @@ import (
 	utils "github.com/aalug/job-finder-go/pkg/utils"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 	"io"
@@ -58,6 +59,62 @@ func EqCreateUserTxParams(arg db.CreateUserTxParams, password string, user db.Us
 	return eqCreateUserTxParamsMatcher{arg, password, user}
 }
 
+type eqResetPasswordTxParamsMatcher struct {
+	arg      db.ResetPasswordTxParams
+	password string
+}
+
+func (e eqResetPasswordTxParamsMatcher) Matches(x interface{}) bool {
+	actualArg, ok := x.(db.ResetPasswordTxParams)
+	if !ok {
+		return false
+	}
+
+	err := utils.CheckPassword(e.password, actualArg.HashedPassword)
+	if err != nil {
+		return false
+	}
+
+	e.arg.HashedPassword = actualArg.HashedPassword
+	return reflect.DeepEqual(e.arg, actualArg)
+}
+
+func (e eqResetPasswordTxParamsMatcher) String() string {
+	return fmt.Sprintf("matches arg %v and password %v", e.arg, e.password)
+}
+
+func EqResetPasswordTxParams(arg db.ResetPasswordTxParams, password string) gomock.Matcher {
+	return eqResetPasswordTxParamsMatcher{arg, password}
+}
+
+type eqUpdatePasswordTxParamsMatcher struct {
+	arg      db.UpdatePasswordTxParams
+	password string
+}
+
+func (e eqUpdatePasswordTxParamsMatcher) Matches(x interface{}) bool {
+	actualArg, ok := x.(db.UpdatePasswordTxParams)
+	if !ok {
+		return false
+	}
+
+	err := utils.CheckPassword(e.password, actualArg.HashedPassword)
+	if err != nil {
+		return false
+	}
+
+	e.arg.HashedPassword = actualArg.HashedPassword
+	return reflect.DeepEqual(e.arg, actualArg)
+}
+
+func (e eqUpdatePasswordTxParamsMatcher) String() string {
+	return fmt.Sprintf("matches arg %v and password %v", e.arg, e.password)
+}
+
+func EqUpdatePasswordTxParams(arg db.UpdatePasswordTxParams, password string) gomock.Matcher {
+	return eqUpdatePasswordTxParamsMatcher{arg, password}
+}
+
 func TestCreateUserAPI(t *testing.T) {
 	user, password := generateRandomUser(t)
 
@@ -288,10 +345,29 @@ func TestLoginUserAPI(t *testing.T) {
 				"password": password,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{}, nil)
 				store.EXPECT().
 					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
 					Times(1).
 					Return(user, nil)
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Eq(db.GetMfaSettingParams{
+						AccountType: string(token.AccountTypeUser),
+						AccountID:   user.ID,
+					})).
+					Times(1).
+					Return(db.MfaSetting{}, sql.ErrNoRows)
+				store.EXPECT().
+					DeleteLoginAttempt(gomock.Any(), gomock.Eq("user:"+user.Email)).
+					Times(1).
+					Return(nil)
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Session{ID: uuid.New(), Email: user.Email}, nil)
 				params := db.ListUserSkillsParams{
 					UserID: user.ID,
 					Limit:  10,
@@ -313,10 +389,18 @@ func TestLoginUserAPI(t *testing.T) {
 				"password": password,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{}, nil)
 				store.EXPECT().
 					GetUserByEmail(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(db.User{}, sql.ErrNoRows)
+				store.EXPECT().
+					RecordFailedLoginAttempt(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.LoginAttempt{FailedAttempts: 1}, nil)
 				store.EXPECT().
 					ListUserSkills(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -332,6 +416,10 @@ func TestLoginUserAPI(t *testing.T) {
 				"password": password,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{}, nil)
 				store.EXPECT().
 					GetUserByEmail(gomock.Any(), gomock.Any()).
 					Times(1).
@@ -351,10 +439,29 @@ func TestLoginUserAPI(t *testing.T) {
 				"password": password,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{}, nil)
 				store.EXPECT().
 					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
 					Times(1).
 					Return(user, nil)
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Eq(db.GetMfaSettingParams{
+						AccountType: string(token.AccountTypeUser),
+						AccountID:   user.ID,
+					})).
+					Times(1).
+					Return(db.MfaSetting{}, sql.ErrNoRows)
+				store.EXPECT().
+					DeleteLoginAttempt(gomock.Any(), gomock.Eq("user:"+user.Email)).
+					Times(1).
+					Return(nil)
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Session{ID: uuid.New(), Email: user.Email}, nil)
 				store.EXPECT().
 					ListUserSkills(gomock.Any(), gomock.Any()).
 					Times(1).
@@ -364,6 +471,44 @@ func TestLoginUserAPI(t *testing.T) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
+		{
+			name: "Internal Server Error CreateSession",
+			body: gin.H{
+				"email":    user.Email,
+				"password": password,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{}, nil)
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Eq(db.GetMfaSettingParams{
+						AccountType: string(token.AccountTypeUser),
+						AccountID:   user.ID,
+					})).
+					Times(1).
+					Return(db.MfaSetting{}, sql.ErrNoRows)
+				store.EXPECT().
+					DeleteLoginAttempt(gomock.Any(), gomock.Eq("user:"+user.Email)).
+					Times(1).
+					Return(nil)
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Session{}, sql.ErrConnDone)
+				store.EXPECT().
+					ListUserSkills(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
 		{
 			name: "Invalid Email",
 			body: gin.H{
@@ -389,10 +534,18 @@ func TestLoginUserAPI(t *testing.T) {
 				"password": fmt.Sprintf("%d, %s", utils.RandomInt(1, 1000), utils.RandomString(10)),
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{}, nil)
 				store.EXPECT().
 					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
 					Times(1).
 					Return(user, nil)
+				store.EXPECT().
+					RecordFailedLoginAttempt(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(db.LoginAttempt{FailedAttempts: 1}, nil)
 				store.EXPECT().
 					ListUserSkills(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -427,6 +580,10 @@ func TestLoginUserAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				user.IsEmailVerified = false
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{}, nil)
 				store.EXPECT().
 					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
 					Times(1).
@@ -439,6 +596,72 @@ func TestLoginUserAPI(t *testing.T) {
 				require.Equal(t, http.StatusForbidden, recorder.Code)
 			},
 		},
+		{
+			name: "MFA Required",
+			body: gin.H{
+				"email":    user.Email,
+				"password": password,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				user.IsEmailVerified = true
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{}, nil)
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MfaSetting{
+						AccountType: string(token.AccountTypeUser),
+						AccountID:   user.ID,
+						IsEnabled:   true,
+					}, nil)
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+
+				var res mfaRequiredResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.True(t, res.MfaRequired)
+				require.NotEmpty(t, res.MfaToken)
+			},
+		},
+		{
+			name: "Internal Server Error GetMfaSetting",
+			body: gin.H{
+				"email":    user.Email,
+				"password": password,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				user.IsEmailVerified = true
+				store.EXPECT().
+					ListLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.LoginAttempt{}, nil)
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetMfaSetting(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MfaSetting{}, sql.ErrConnDone)
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
 	}
 	for i := range testCases {
 		tc := testCases[i]
@@ -480,7 +703,7 @@ func TestGetUserAPI(t *testing.T) {
 		{
 			name: "OK",
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -494,24 +717,23 @@ func TestGetUserAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "Unauthorized Only Users Access",
+			name: "Forbidden Only Users Access",
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
 					GetUserDetailsByEmail(gomock.Any(), gomock.Eq(employer.Email)).
-					Times(1).
-					Return(db.User{}, []db.UserSkill{}, sql.ErrNoRows)
+					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				require.Equal(t, http.StatusForbidden, recorder.Code)
 			},
 		},
 		{
 			name: "Internal Server Error",
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -600,7 +822,7 @@ func TestUpdateUserAPI(t *testing.T) {
 				"skill_ids_to_remove": skillIDsToRemove,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -635,7 +857,7 @@ func TestUpdateUserAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "Unauthorized Only User Access",
+			name: "Forbidden Only User Access",
 			body: gin.H{
 				"location":            newDetails.Location,
 				"desired_job_title":   newDetails.DesiredJobTitle,
@@ -644,13 +866,12 @@ func TestUpdateUserAPI(t *testing.T) {
 				"skill_ids_to_remove": skillIDsToRemove,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
 					GetUserByEmail(gomock.Any(), gomock.Eq(employer.Email)).
-					Times(1).
-					Return(db.User{}, sql.ErrNoRows)
+					Times(0)
 				store.EXPECT().
 					UpdateUser(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -665,7 +886,7 @@ func TestUpdateUserAPI(t *testing.T) {
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				require.Equal(t, http.StatusForbidden, recorder.Code)
 			},
 		},
 		{
@@ -678,7 +899,7 @@ func TestUpdateUserAPI(t *testing.T) {
 				"skill_ids_to_remove": skillIDsToRemove,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -712,7 +933,7 @@ func TestUpdateUserAPI(t *testing.T) {
 				"skill_ids_to_remove": skillIDsToRemove,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -747,7 +968,7 @@ func TestUpdateUserAPI(t *testing.T) {
 				"skill_ids_to_remove": skillIDsToRemove,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -782,7 +1003,7 @@ func TestUpdateUserAPI(t *testing.T) {
 				"skill_ids_to_remove": skillIDsToRemove,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -819,7 +1040,7 @@ func TestUpdateUserAPI(t *testing.T) {
 				"desired_salary_max":  2000,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -858,7 +1079,7 @@ func TestUpdateUserAPI(t *testing.T) {
 				"email": "invalid",
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -887,7 +1108,7 @@ func TestUpdateUserAPI(t *testing.T) {
 				"location": 123,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -917,7 +1138,7 @@ func TestUpdateUserAPI(t *testing.T) {
 				"desired_salary_max": 100,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -989,7 +1210,7 @@ func TestUpdateUserPasswordAPI(t *testing.T) {
 				"new_password": newPassword,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -997,7 +1218,10 @@ func TestUpdateUserPasswordAPI(t *testing.T) {
 					Times(1).
 					Return(user, nil)
 				store.EXPECT().
-					UpdatePassword(gomock.Any(), gomock.Any()).
+					UpdateUserPasswordTx(gomock.Any(), EqUpdatePasswordTxParams(db.UpdatePasswordTxParams{
+						ID:    user.ID,
+						Email: user.Email,
+					}, newPassword)).
 					Times(1).
 					Return(nil)
 			},
@@ -1012,7 +1236,7 @@ func TestUpdateUserPasswordAPI(t *testing.T) {
 				"new_password": newPassword,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -1020,7 +1244,7 @@ func TestUpdateUserPasswordAPI(t *testing.T) {
 					Times(1).
 					Return(user, nil)
 				store.EXPECT().
-					UpdatePassword(gomock.Any(), gomock.Any()).
+					UpdateUserPasswordTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -1034,14 +1258,14 @@ func TestUpdateUserPasswordAPI(t *testing.T) {
 				"new_password": "123",
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
 					GetUserByEmail(gomock.Any(), gomock.Any()).
 					Times(0)
 				store.EXPECT().
-					UpdatePassword(gomock.Any(), gomock.Any()).
+					UpdateUserPasswordTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -1055,7 +1279,7 @@ func TestUpdateUserPasswordAPI(t *testing.T) {
 				"new_password": newPassword,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -1063,7 +1287,7 @@ func TestUpdateUserPasswordAPI(t *testing.T) {
 					Times(1).
 					Return(user, nil)
 				store.EXPECT().
-					UpdatePassword(gomock.Any(), gomock.Any()).
+					UpdateUserPasswordTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -1071,25 +1295,24 @@ func TestUpdateUserPasswordAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "Unauthorized Only User Access",
+			name: "Forbidden Only User Access",
 			body: gin.H{
 				"old_password": oldPassword,
 				"new_password": newPassword,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
 					GetUserByEmail(gomock.Any(), gomock.Eq(employer.Email)).
-					Times(1).
-					Return(db.User{}, sql.ErrNoRows)
+					Times(0)
 				store.EXPECT().
-					UpdatePassword(gomock.Any(), gomock.Any()).
+					UpdateUserPasswordTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				require.Equal(t, http.StatusForbidden, recorder.Code)
 			},
 		},
 		{
@@ -1099,7 +1322,7 @@ func TestUpdateUserPasswordAPI(t *testing.T) {
 				"new_password": newPassword,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -1107,7 +1330,7 @@ func TestUpdateUserPasswordAPI(t *testing.T) {
 					Times(1).
 					Return(db.User{}, sql.ErrConnDone)
 				store.EXPECT().
-					UpdatePassword(gomock.Any(), gomock.Any()).
+					UpdateUserPasswordTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -1115,13 +1338,13 @@ func TestUpdateUserPasswordAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "Internal Server Error UpdatePassword",
+			name: "Internal Server Error UpdateUserPasswordTx",
 			body: gin.H{
 				"old_password": oldPassword,
 				"new_password": newPassword,
 			},
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -1129,7 +1352,7 @@ func TestUpdateUserPasswordAPI(t *testing.T) {
 					Times(1).
 					Return(user, nil)
 				store.EXPECT().
-					UpdatePassword(gomock.Any(), gomock.Any()).
+					UpdateUserPasswordTx(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(sql.ErrConnDone)
 			},
@@ -1179,7 +1402,7 @@ func TestDeleteUserAPI(t *testing.T) {
 		{
 			name: "OK",
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -1200,15 +1423,14 @@ func TestDeleteUserAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "Unauthorized Only User Access",
+			name: "Forbidden Only User Access",
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, employer.Email, token.AccountTypeEmployer, employer.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
 					GetUserByEmail(gomock.Any(), gomock.Eq(employer.Email)).
-					Times(1).
-					Return(db.User{}, sql.ErrNoRows)
+					Times(0)
 				store.EXPECT().
 					DeleteAllUserSkills(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -1217,13 +1439,13 @@ func TestDeleteUserAPI(t *testing.T) {
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				require.Equal(t, http.StatusForbidden, recorder.Code)
 			},
 		},
 		{
 			name: "Internal Server Error GetUserByEmail",
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -1244,7 +1466,7 @@ func TestDeleteUserAPI(t *testing.T) {
 		{
 			name: "Internal Server Error DeleteAllUserSkills",
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -1266,7 +1488,7 @@ func TestDeleteUserAPI(t *testing.T) {
 		{
 			name: "Internal Server Error DeleteAllUserSkills",
 			setupAuth: func(t *testing.T, r *http.Request, maker token.Maker) {
-				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, time.Minute)
+				addAuthorization(t, r, maker, authorizationTypeBearer, user.Email, token.AccountTypeUser, user.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -1637,6 +1859,325 @@ func TestSendVerificationEmailToUserAPI(t *testing.T) {
 	}
 }
 
+func TestForgotUserPasswordAPI(t *testing.T) {
+	user, _ := generateRandomUser(t)
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"email": user.Email,
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					DeleteResetPassword(gomock.Any(), gomock.Eq(db.DeleteResetPasswordParams{
+						Email:       user.Email,
+						AccountType: string(token.AccountTypeUser),
+					})).
+					Times(1).
+					Return(nil)
+				taskPayload := &worker.PayloadSendResetPasswordEmail{
+					Email:       user.Email,
+					FullName:    user.FullName,
+					AccountType: "users",
+				}
+				distributor.EXPECT().
+					DistributeTaskSendResetPasswordEmail(gomock.Any(), gomock.Eq(taskPayload), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Invalid Email",
+			body: gin.H{
+				"email": "invalid",
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					DeleteResetPassword(gomock.Any(), gomock.Any()).
+					Times(0)
+				distributor.EXPECT().
+					DistributeTaskSendResetPasswordEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "User Not Found",
+			body: gin.H{
+				"email": user.Email,
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(db.User{}, sql.ErrNoRows)
+				store.EXPECT().
+					DeleteResetPassword(gomock.Any(), gomock.Any()).
+					Times(0)
+				distributor.EXPECT().
+					DistributeTaskSendResetPasswordEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error GetUserByEmail",
+			body: gin.H{
+				"email": user.Email,
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(db.User{}, sql.ErrConnDone)
+				store.EXPECT().
+					DeleteResetPassword(gomock.Any(), gomock.Any()).
+					Times(0)
+				distributor.EXPECT().
+					DistributeTaskSendResetPasswordEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error DeleteResetPassword",
+			body: gin.H{
+				"email": user.Email,
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					DeleteResetPassword(gomock.Any(), gomock.Eq(db.DeleteResetPasswordParams{
+						Email:       user.Email,
+						AccountType: string(token.AccountTypeUser),
+					})).
+					Times(1).
+					Return(sql.ErrConnDone)
+				distributor.EXPECT().
+					DistributeTaskSendResetPasswordEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error DistributeTaskSendResetPasswordEmail",
+			body: gin.H{
+				"email": user.Email,
+			},
+			buildStubs: func(store *mockdb.MockStore, distributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					DeleteResetPassword(gomock.Any(), gomock.Eq(db.DeleteResetPasswordParams{
+						Email:       user.Email,
+						AccountType: string(token.AccountTypeUser),
+					})).
+					Times(1).
+					Return(nil)
+				distributor.EXPECT().
+					DistributeTaskSendResetPasswordEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("some error"))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockworker.NewMockTaskDistributor(taskCtrl)
+
+			tc.buildStubs(store, taskDistributor)
+
+			server := newTestServer(t, store, nil, taskDistributor)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := BaseUrl + "/users/forgot-password"
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestResetUserPasswordAPI(t *testing.T) {
+	user, _ := generateRandomUser(t)
+	resetPassword := db.ResetPassword{
+		ID:         int64(utils.RandomInt(1, 1000)),
+		Email:      user.Email,
+		SecretCode: utils.RandomString(32),
+		CreatedAt:  time.Now(),
+		ExpiredAt:  time.Now().Add(30 * time.Minute),
+	}
+	newPassword := utils.RandomString(6)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"id":           resetPassword.ID,
+				"code":         resetPassword.SecretCode,
+				"new_password": newPassword,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				params := db.ResetPasswordTxParams{
+					ID:         resetPassword.ID,
+					SecretCode: resetPassword.SecretCode,
+				}
+				store.EXPECT().
+					ResetUserPasswordTx(gomock.Any(), EqResetPasswordTxParams(params, newPassword)).
+					Times(1).
+					Return(db.ResetUserPasswordResult{
+						User:          user,
+						ResetPassword: resetPassword,
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Invalid Code",
+			body: gin.H{
+				"id":           resetPassword.ID,
+				"code":         "short",
+				"new_password": newPassword,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ResetUserPasswordTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Password Too Short",
+			body: gin.H{
+				"id":           resetPassword.ID,
+				"code":         resetPassword.SecretCode,
+				"new_password": "123",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ResetUserPasswordTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Not Found",
+			body: gin.H{
+				"id":           resetPassword.ID,
+				"code":         resetPassword.SecretCode,
+				"new_password": newPassword,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ResetUserPasswordTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ResetUserPasswordResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "Internal Server Error",
+			body: gin.H{
+				"id":           resetPassword.ID,
+				"code":         resetPassword.SecretCode,
+				"new_password": newPassword,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ResetUserPasswordTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ResetUserPasswordResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil, nil)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := BaseUrl + "/users/reset-password"
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
 // generateRandomUser generates a random user and returns it with the password
 func generateRandomUser(t *testing.T) (db.User, string) {
 	password := utils.RandomString(6)
@@ -12,10 +12,41 @@ type Config struct {
 	ServerAddress        string        `mapstructure:"SERVER_ADDRESS"`
 	BaseUrl              string        `mapstructure:"BASE_URL"`
 	ElasticSearchAddress string        `mapstructure:"ELASTICSEARCH_ADDRESS"`
+	SearchBackend        string        `mapstructure:"SEARCH_BACKEND"`
+	SearchProvider       string        `mapstructure:"SEARCH_PROVIDER"`
 	RedisAddress         string        `mapstructure:"REDIS_ADDRESS"`
+	TokenMaker           string        `mapstructure:"TOKEN_MAKER"`
 	TokenSymmetricKey    string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
+	TokenKeysDir         string        `mapstructure:"TOKEN_KEYS_DIR"`
+	TokenCurrentKeyID    string        `mapstructure:"TOKEN_CURRENT_KEY_ID"`
 	AccessTokenDuration  time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	RefreshTokenDuration time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	MfaTokenDuration     time.Duration `mapstructure:"MFA_TOKEN_DURATION"`
+	LoginMaxAttempts     int           `mapstructure:"LOGIN_MAX_ATTEMPTS"`
+	LoginLockoutDuration time.Duration `mapstructure:"LOGIN_LOCKOUT_DURATION"`
 	EmailSenderAddress   string        `mapstructure:"EMAIL_SENDER_ADDRESS"`
+	OidcProviderName     string        `mapstructure:"OIDC_PROVIDER_NAME"`
+	OidcIssuerURL        string        `mapstructure:"OIDC_ISSUER_URL"`
+	OidcAuthURL          string        `mapstructure:"OIDC_AUTH_URL"`
+	OidcTokenURL         string        `mapstructure:"OIDC_TOKEN_URL"`
+	OidcJwksURL          string        `mapstructure:"OIDC_JWKS_URL"`
+	OidcClientID         string        `mapstructure:"OIDC_CLIENT_ID"`
+	OidcClientSecret     string        `mapstructure:"OIDC_CLIENT_SECRET"`
+	OidcRedirectURL      string        `mapstructure:"OIDC_REDIRECT_URL"`
+	// intervals of the periodic tasks, a task with interval 0 is not scheduled
+	ExpireJobsInterval         time.Duration `mapstructure:"EXPIRE_JOBS_INTERVAL"`
+	PurgeVerifyEmailsInterval  time.Duration `mapstructure:"PURGE_VERIFY_EMAILS_INTERVAL"`
+	CleanSearchIndexInterval   time.Duration `mapstructure:"CLEAN_SEARCH_INDEX_INTERVAL"`
+	JobExpiryRemindersInterval time.Duration `mapstructure:"JOB_EXPIRY_REMINDERS_INTERVAL"`
+	JobExpiryReminderBefore    time.Duration `mapstructure:"JOB_EXPIRY_REMINDER_BEFORE"`
+	// the search outbox is also processed right after the jobs change, the periodic task retries what failed
+	ProcessSearchOutboxInterval time.Duration `mapstructure:"PROCESS_SEARCH_OUTBOX_INTERVAL"`
+	// the saved searches are daily or weekly, the task sends the digests of the searches that are due
+	SendSavedSearchDigestsInterval time.Duration `mapstructure:"SEND_SAVED_SEARCH_DIGESTS_INTERVAL"`
+	// accounts (users or employers) with these emails can update the currency rates
+	AdminEmails []string `mapstructure:"ADMIN_EMAILS"`
+	// file with the synonyms of the job search, no synonyms if empty
+	ElasticSearchSynonymsFile string `mapstructure:"ELASTICSEARCH_SYNONYMS_FILE"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
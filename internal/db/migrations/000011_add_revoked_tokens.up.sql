@@ -0,0 +1,16 @@
+CREATE TABLE "revoked_tokens"
+(
+    "id"         uuid PRIMARY KEY,
+    "email"      varchar     NOT NULL,
+    "expires_at" timestamptz NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX "idx_revoked_tokens_expires_at" ON "revoked_tokens" ("expires_at");
+
+-- every token of the email issued before revoked_at is revoked
+CREATE TABLE "email_token_revocations"
+(
+    "email"      varchar PRIMARY KEY,
+    "revoked_at" timestamptz NOT NULL DEFAULT (now())
+);
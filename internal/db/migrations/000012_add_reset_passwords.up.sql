@@ -0,0 +1,12 @@
+CREATE TABLE "reset_passwords"
+(
+    "id"          bigserial PRIMARY KEY,
+    "email"       varchar     NOT NULL,
+    "secret_code" varchar     NOT NULL,
+    "is_used"     bool        NOT NULL DEFAULT false,
+    "created_at"  timestamptz NOT NULL DEFAULT (now()),
+    "expired_at"  timestamptz NOT NULL DEFAULT (now() + interval '30 minutes')
+);
+
+ALTER TABLE "reset_passwords"
+    ADD CONSTRAINT "unique_emails_reset_passwords" UNIQUE ("email");
@@ -0,0 +1,21 @@
+CREATE TABLE "mfa_settings"
+(
+    "account_type" varchar     NOT NULL,
+    "account_id"   integer     NOT NULL,
+    "secret"       varchar     NOT NULL,
+    "is_enabled"   bool        NOT NULL DEFAULT false,
+    "created_at"   timestamptz NOT NULL DEFAULT (now()),
+    PRIMARY KEY ("account_type", "account_id")
+);
+
+CREATE TABLE "mfa_recovery_codes"
+(
+    "id"           bigserial PRIMARY KEY,
+    "account_type" varchar     NOT NULL,
+    "account_id"   integer     NOT NULL,
+    "hashed_code"  varchar     NOT NULL,
+    "is_used"      bool        NOT NULL DEFAULT false,
+    "created_at"   timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX "idx_mfa_recovery_codes_account" ON "mfa_recovery_codes" ("account_type", "account_id");
@@ -0,0 +1,9 @@
+CREATE TABLE "login_attempts"
+(
+    "subject"         varchar PRIMARY KEY,
+    "failed_attempts" integer     NOT NULL DEFAULT 0,
+    "locked_until"    timestamptz NOT NULL DEFAULT (now()),
+    "last_failed_at"  timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "login_attempts"."subject" IS 'user:<email>, employer:<email> or ip:<client ip>';
@@ -0,0 +1,21 @@
+CREATE TABLE "user_identities"
+(
+    "provider"   varchar     NOT NULL,
+    "subject"    varchar     NOT NULL,
+    "user_id"    integer     NOT NULL,
+    "email"      varchar     NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    PRIMARY KEY ("provider", "subject"),
+    FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX "idx_user_identities_user_id" ON "user_identities" ("user_id");
+
+CREATE TABLE "oidc_states"
+(
+    "state"         varchar PRIMARY KEY,
+    "code_verifier" varchar     NOT NULL,
+    "nonce"         varchar     NOT NULL,
+    "created_at"    timestamptz NOT NULL DEFAULT (now()),
+    "expired_at"    timestamptz NOT NULL DEFAULT (now() + interval '10 minutes')
+);
@@ -0,0 +1,13 @@
+CREATE TABLE "api_keys"
+(
+    "id"           bigserial PRIMARY KEY,
+    "employer_id"  integer     NOT NULL,
+    "name"         varchar     NOT NULL,
+    "prefix"       varchar     NOT NULL UNIQUE,
+    "hashed_key"   varchar     NOT NULL,
+    "scopes"       varchar[]   NOT NULL DEFAULT '{}',
+    "last_used_at" timestamptz,
+    "created_at"   timestamptz NOT NULL DEFAULT (now()),
+    FOREIGN KEY ("employer_id") REFERENCES "employers" ("id") ON DELETE CASCADE,
+    CONSTRAINT unique_employer_api_key_name UNIQUE ("employer_id", "name")
+);
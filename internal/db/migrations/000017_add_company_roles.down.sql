@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "company_invitations";
+DROP INDEX IF EXISTS "idx_employers_company_id";
+ALTER TABLE "employers" DROP COLUMN IF EXISTS "role";
+DROP TYPE IF EXISTS company_role;
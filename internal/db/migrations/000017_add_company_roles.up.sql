@@ -0,0 +1,24 @@
+CREATE TYPE company_role AS ENUM ('owner', 'admin', 'recruiter', 'viewer');
+
+-- every existing employer created their company, so they own it
+ALTER TABLE "employers"
+    ADD COLUMN "role" company_role NOT NULL DEFAULT 'owner';
+
+CREATE INDEX "idx_employers_company_id" ON "employers" ("company_id");
+
+CREATE TABLE "company_invitations"
+(
+    "id"          bigserial PRIMARY KEY,
+    "company_id"  integer      NOT NULL,
+    "email"       varchar      NOT NULL,
+    "role"        company_role NOT NULL,
+    "invited_by"  integer      NOT NULL,
+    "secret_code" varchar      NOT NULL,
+    "is_used"     bool         NOT NULL DEFAULT false,
+    "created_at"  timestamptz  NOT NULL DEFAULT (now()),
+    "expired_at"  timestamptz  NOT NULL DEFAULT (now() + interval '7 days'),
+    FOREIGN KEY ("company_id") REFERENCES "companies" ("id") ON DELETE CASCADE,
+    FOREIGN KEY ("invited_by") REFERENCES "employers" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX "idx_company_invitations_company_id" ON "company_invitations" ("company_id");
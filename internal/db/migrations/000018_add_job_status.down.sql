@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "idx_jobs_status";
+ALTER TABLE "jobs" DROP COLUMN IF EXISTS "closes_at";
+ALTER TABLE "jobs" DROP COLUMN IF EXISTS "published_at";
+ALTER TABLE "jobs" DROP COLUMN IF EXISTS "status";
+DROP TYPE IF EXISTS job_status;
@@ -0,0 +1,16 @@
+CREATE TYPE job_status AS ENUM ('draft', 'published', 'paused', 'closed', 'expired');
+
+-- jobs created before the statuses existed were public, so they stay published
+ALTER TABLE "jobs"
+    ADD COLUMN "status"       job_status NOT NULL DEFAULT 'published',
+    ADD COLUMN "published_at" timestamptz,
+    ADD COLUMN "closes_at"    timestamptz;
+
+UPDATE "jobs"
+SET "published_at" = "created_at";
+
+-- new jobs are drafts until they are published
+ALTER TABLE "jobs"
+    ALTER COLUMN "status" SET DEFAULT 'draft';
+
+CREATE INDEX "idx_jobs_status" ON "jobs" ("status");
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "idx_jobs_closes_at";
+DROP TABLE IF EXISTS "job_expiry_reminders";
@@ -0,0 +1,12 @@
+-- reminders are sent once for every closes_at of a job,
+-- so extending the job makes it possible to send a new one
+CREATE TABLE "job_expiry_reminders"
+(
+    "job_id"     integer     NOT NULL,
+    "closes_at"  timestamptz NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    PRIMARY KEY ("job_id", "closes_at"),
+    FOREIGN KEY ("job_id") REFERENCES "jobs" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX "idx_jobs_closes_at" ON "jobs" ("closes_at");
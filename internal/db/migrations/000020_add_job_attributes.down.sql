@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS "idx_jobs_remote_policy";
+DROP INDEX IF EXISTS "idx_jobs_seniority_level";
+DROP INDEX IF EXISTS "idx_jobs_employment_type";
+ALTER TABLE "jobs" DROP COLUMN IF EXISTS "salary_period";
+ALTER TABLE "jobs" DROP COLUMN IF EXISTS "salary_currency";
+ALTER TABLE "jobs" DROP COLUMN IF EXISTS "remote_policy";
+ALTER TABLE "jobs" DROP COLUMN IF EXISTS "seniority_level";
+ALTER TABLE "jobs" DROP COLUMN IF EXISTS "employment_type";
+DROP TYPE IF EXISTS salary_period;
+DROP TYPE IF EXISTS remote_policy;
+DROP TYPE IF EXISTS seniority_level;
+DROP TYPE IF EXISTS employment_type;
@@ -0,0 +1,17 @@
+CREATE TYPE employment_type AS ENUM ('full_time', 'part_time', 'contract', 'internship', 'temporary');
+CREATE TYPE seniority_level AS ENUM ('intern', 'junior', 'mid', 'senior', 'lead');
+CREATE TYPE remote_policy AS ENUM ('onsite', 'hybrid', 'remote');
+CREATE TYPE salary_period AS ENUM ('hour', 'day', 'week', 'month', 'year');
+
+-- jobs created before the attributes existed get the most common values
+ALTER TABLE "jobs"
+    ADD COLUMN "employment_type" employment_type NOT NULL DEFAULT 'full_time',
+    ADD COLUMN "seniority_level" seniority_level NOT NULL DEFAULT 'mid',
+    ADD COLUMN "remote_policy"   remote_policy   NOT NULL DEFAULT 'onsite',
+    ADD COLUMN "salary_currency" varchar(3)      NOT NULL DEFAULT 'USD'
+        CONSTRAINT "jobs_salary_currency_check" CHECK ("salary_currency" ~ '^[A-Z]{3}$'),
+    ADD COLUMN "salary_period"   salary_period   NOT NULL DEFAULT 'year';
+
+CREATE INDEX "idx_jobs_employment_type" ON "jobs" ("employment_type");
+CREATE INDEX "idx_jobs_seniority_level" ON "jobs" ("seniority_level");
+CREATE INDEX "idx_jobs_remote_policy" ON "jobs" ("remote_policy");
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "idx_jobs_salary_max_annual";
+DROP INDEX IF EXISTS "idx_jobs_salary_min_annual";
+ALTER TABLE "jobs" DROP COLUMN IF EXISTS "salary_max_annual";
+ALTER TABLE "jobs" DROP COLUMN IF EXISTS "salary_min_annual";
+DROP TABLE IF EXISTS "currency_rates";
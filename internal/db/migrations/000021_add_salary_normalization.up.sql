@@ -0,0 +1,45 @@
+-- rate is the value of one unit of the currency in the base currency (USD)
+CREATE TABLE "currency_rates"
+(
+    "currency"   varchar(3) PRIMARY KEY
+        CONSTRAINT "currency_rates_currency_check" CHECK ("currency" ~ '^[A-Z]{3}$'),
+    "rate"       double precision NOT NULL
+        CONSTRAINT "currency_rates_rate_check" CHECK ("rate" > 0),
+    "updated_at" timestamptz      NOT NULL DEFAULT (now())
+);
+
+-- approximate rates, admins keep them up to date
+INSERT INTO "currency_rates" ("currency", "rate")
+VALUES ('USD', 1),
+       ('EUR', 1.08),
+       ('GBP', 1.27),
+       ('CHF', 1.12),
+       ('PLN', 0.25),
+       ('CZK', 0.043),
+       ('SEK', 0.095),
+       ('NOK', 0.094),
+       ('DKK', 0.145),
+       ('CAD', 0.73),
+       ('AUD', 0.66),
+       ('JPY', 0.0067),
+       ('INR', 0.012);
+
+-- salaries of the jobs annualized and converted to the base currency
+ALTER TABLE "jobs"
+    ADD COLUMN "salary_min_annual" integer NOT NULL DEFAULT 0,
+    ADD COLUMN "salary_max_annual" integer NOT NULL DEFAULT 0;
+
+UPDATE "jobs" j
+SET "salary_min_annual" = round(j."salary_min" * r."rate" * p."periods"),
+    "salary_max_annual" = round(j."salary_max" * r."rate" * p."periods")
+FROM "currency_rates" r,
+     (VALUES ('hour'::salary_period, 2080),
+             ('day'::salary_period, 260),
+             ('week'::salary_period, 52),
+             ('month'::salary_period, 12),
+             ('year'::salary_period, 1)) AS p ("period", "periods")
+WHERE r."currency" = j."salary_currency"
+  AND p."period" = j."salary_period";
+
+CREATE INDEX "idx_jobs_salary_min_annual" ON "jobs" ("salary_min_annual");
+CREATE INDEX "idx_jobs_salary_max_annual" ON "jobs" ("salary_max_annual");
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "idx_jobs_coordinates";
+ALTER TABLE "jobs" DROP CONSTRAINT IF EXISTS "jobs_coordinates_check";
+ALTER TABLE "jobs" DROP COLUMN IF EXISTS "longitude";
+ALTER TABLE "jobs" DROP COLUMN IF EXISTS "latitude";
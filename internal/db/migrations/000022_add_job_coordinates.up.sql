@@ -0,0 +1,10 @@
+-- coordinates of the gazetteer city of the job location,
+-- they are NULL for locations that are not in the gazetteer
+ALTER TABLE "jobs"
+    ADD COLUMN "latitude"  double precision,
+    ADD COLUMN "longitude" double precision,
+    ADD CONSTRAINT "jobs_coordinates_check" CHECK (
+        ("latitude" IS NULL AND "longitude" IS NULL) OR
+        ("latitude" BETWEEN -90 AND 90 AND "longitude" BETWEEN -180 AND 180));
+
+CREATE INDEX "idx_jobs_coordinates" ON "jobs" ("latitude", "longitude");
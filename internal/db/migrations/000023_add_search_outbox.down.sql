@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "idx_search_outbox_pending";
+DROP TABLE IF EXISTS "search_outbox";
@@ -0,0 +1,12 @@
+-- changes of the jobs that have to be applied to the elasticsearch index,
+-- written in the same transaction as the change and applied by the worker.
+-- job_id has no foreign key, deleted jobs have to be removed from the index too
+CREATE TABLE "search_outbox"
+(
+    "id"           bigserial PRIMARY KEY,
+    "job_id"       integer     NOT NULL,
+    "created_at"   timestamptz NOT NULL DEFAULT (now()),
+    "processed_at" timestamptz
+);
+
+CREATE INDEX "idx_search_outbox_pending" ON "search_outbox" ("id") WHERE "processed_at" IS NULL;
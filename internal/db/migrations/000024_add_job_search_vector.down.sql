@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS "job_skills_search_vector_update" ON "job_skills";
+DROP TRIGGER IF EXISTS "jobs_search_vector_update" ON "jobs";
+DROP FUNCTION IF EXISTS "job_skills_search_vector_trigger"();
+DROP FUNCTION IF EXISTS "jobs_search_vector_trigger"();
+DROP FUNCTION IF EXISTS "job_search_vector"(text, text, text, text);
+DROP FUNCTION IF EXISTS "job_skills_text"(integer);
+DROP INDEX IF EXISTS "idx_jobs_search_vector";
+ALTER TABLE "jobs" DROP COLUMN IF EXISTS "search_vector";
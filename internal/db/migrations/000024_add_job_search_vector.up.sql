@@ -0,0 +1,76 @@
+-- full-text search document of the job, searched when elasticsearch is not used.
+-- The skills are in another table, so it is kept up to date by the triggers below
+-- instead of being a generated column
+ALTER TABLE "jobs"
+    ADD COLUMN "search_vector" tsvector NOT NULL DEFAULT '';
+
+CREATE INDEX "idx_jobs_search_vector" ON "jobs" USING GIN ("search_vector");
+
+-- the skills of the job separated by spaces
+CREATE FUNCTION "job_skills_text"(job integer) RETURNS text
+    LANGUAGE sql
+    STABLE
+AS
+$$
+SELECT coalesce(string_agg(skill, ' ' ORDER BY id), '')
+FROM job_skills
+WHERE job_id = job
+$$;
+
+-- the search document of the job, the matches in the title rank the highest,
+-- then the matches in the skills, the requirements and the description
+CREATE FUNCTION "job_search_vector"(title text, description text, requirements text, skills text) RETURNS tsvector
+    LANGUAGE sql
+    IMMUTABLE
+AS
+$$
+SELECT setweight(to_tsvector('english', title), 'A') ||
+       setweight(to_tsvector('english', skills), 'B') ||
+       setweight(to_tsvector('english', requirements), 'C') ||
+       setweight(to_tsvector('english', description), 'D')
+$$;
+
+CREATE FUNCTION "jobs_search_vector_trigger"() RETURNS trigger
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    NEW.search_vector := job_search_vector(NEW.title, NEW.description, NEW.requirements, job_skills_text(NEW.id));
+    RETURN NEW;
+END
+$$;
+
+CREATE TRIGGER "jobs_search_vector_update"
+    BEFORE INSERT OR UPDATE OF "title", "description", "requirements"
+    ON "jobs"
+    FOR EACH ROW
+EXECUTE FUNCTION jobs_search_vector_trigger();
+
+-- the search document of the job is updated when its skills change
+CREATE FUNCTION "job_skills_search_vector_trigger"() RETURNS trigger
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        UPDATE jobs
+        SET search_vector = job_search_vector(title, description, requirements, job_skills_text(id))
+        WHERE id = OLD.job_id;
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        UPDATE jobs
+        SET search_vector = job_search_vector(title, description, requirements, job_skills_text(id))
+        WHERE id = NEW.job_id;
+    END IF;
+    RETURN NULL;
+END
+$$;
+
+CREATE TRIGGER "job_skills_search_vector_update"
+    AFTER INSERT OR UPDATE OR DELETE
+    ON "job_skills"
+    FOR EACH ROW
+EXECUTE FUNCTION job_skills_search_vector_trigger();
+
+UPDATE "jobs"
+SET "search_vector" = job_search_vector("title", "description", "requirements", job_skills_text("id"));
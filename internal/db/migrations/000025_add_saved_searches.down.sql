@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "idx_saved_searches_due";
+DROP INDEX IF EXISTS "idx_saved_searches_user_id";
+DROP TABLE IF EXISTS "saved_searches";
+DROP TYPE IF EXISTS saved_search_frequency;
@@ -0,0 +1,33 @@
+CREATE TYPE saved_search_frequency AS ENUM ('daily', 'weekly', 'never');
+
+-- searches of the users with the filters of the jobs list, the users get digests
+-- of the new jobs matching them. The city of the near filter is stored with its coordinates.
+CREATE TABLE "saved_searches"
+(
+    "id"                bigserial PRIMARY KEY,
+    "user_id"           integer                NOT NULL,
+    "name"              varchar                NOT NULL,
+    "query"             varchar                NOT NULL DEFAULT '',
+    "title"             varchar,
+    "industry"          varchar,
+    "job_location"      varchar,
+    "salary_min"        integer,
+    "salary_max"        integer,
+    "employment_type"   employment_type,
+    "seniority_level"   seniority_level,
+    "remote_policy"     remote_policy,
+    "salary_currency"   varchar,
+    "salary_period"     salary_period,
+    "near"              varchar,
+    "near_latitude"     double precision,
+    "near_longitude"    double precision,
+    "radius_km"         double precision,
+    "frequency"         saved_search_frequency NOT NULL DEFAULT 'daily',
+    "unsubscribe_token" varchar                NOT NULL UNIQUE,
+    "last_run_at"       timestamptz            NOT NULL DEFAULT (now()),
+    "created_at"        timestamptz            NOT NULL DEFAULT (now()),
+    FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX "idx_saved_searches_user_id" ON "saved_searches" ("user_id");
+CREATE INDEX "idx_saved_searches_due" ON "saved_searches" ("last_run_at") WHERE "frequency" <> 'never';
@@ -0,0 +1,11 @@
+DELETE
+FROM "reset_passwords";
+
+ALTER TABLE "reset_passwords"
+    DROP CONSTRAINT IF EXISTS "unique_account_emails_reset_passwords";
+
+ALTER TABLE "reset_passwords"
+    DROP COLUMN IF EXISTS "account_type";
+
+ALTER TABLE "reset_passwords"
+    ADD CONSTRAINT "unique_emails_reset_passwords" UNIQUE ("email");
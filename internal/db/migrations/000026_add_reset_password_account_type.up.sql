@@ -0,0 +1,12 @@
+-- the codes are valid for 30 minutes, the pending ones are not kept
+DELETE
+FROM "reset_passwords";
+
+ALTER TABLE "reset_passwords"
+    ADD COLUMN "account_type" varchar NOT NULL;
+
+ALTER TABLE "reset_passwords"
+    DROP CONSTRAINT "unique_emails_reset_passwords";
+
+ALTER TABLE "reset_passwords"
+    ADD CONSTRAINT "unique_account_emails_reset_passwords" UNIQUE ("account_type", "email");
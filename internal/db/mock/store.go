@@ -7,9 +7,11 @@ package mockdb
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	db "github.com/aalug/job-finder-go/internal/db/sqlc"
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
 )
 
 // MockStore is a mock of Store interface.
@@ -35,6 +37,65 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AcceptCompanyInvitationTx mocks base method.
+func (m *MockStore) AcceptCompanyInvitationTx(arg0 context.Context, arg1 db.AcceptCompanyInvitationTxParams) (db.AcceptCompanyInvitationTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptCompanyInvitationTx", arg0, arg1)
+	ret0, _ := ret[0].(db.AcceptCompanyInvitationTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptCompanyInvitationTx indicates an expected call of AcceptCompanyInvitationTx.
+func (mr *MockStoreMockRecorder) AcceptCompanyInvitationTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptCompanyInvitationTx", reflect.TypeOf((*MockStore)(nil).AcceptCompanyInvitationTx), arg0, arg1)
+}
+
+// BlockAllSessionsByEmail mocks base method.
+func (m *MockStore) BlockAllSessionsByEmail(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockAllSessionsByEmail", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BlockAllSessionsByEmail indicates an expected call of BlockAllSessionsByEmail.
+func (mr *MockStoreMockRecorder) BlockAllSessionsByEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockAllSessionsByEmail", reflect.TypeOf((*MockStore)(nil).BlockAllSessionsByEmail), arg0, arg1)
+}
+
+// BlockSession mocks base method.
+func (m *MockStore) BlockSession(arg0 context.Context, arg1 db.BlockSessionParams) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockSession", arg0, arg1)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockSession indicates an expected call of BlockSession.
+func (mr *MockStoreMockRecorder) BlockSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockSession", reflect.TypeOf((*MockStore)(nil).BlockSession), arg0, arg1)
+}
+
+// CreateApiKey mocks base method.
+func (m *MockStore) CreateApiKey(arg0 context.Context, arg1 db.CreateApiKeyParams) (db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateApiKey", arg0, arg1)
+	ret0, _ := ret[0].(db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateApiKey indicates an expected call of CreateApiKey.
+func (mr *MockStoreMockRecorder) CreateApiKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApiKey", reflect.TypeOf((*MockStore)(nil).CreateApiKey), arg0, arg1)
+}
+
 // CreateCompany mocks base method.
 func (m *MockStore) CreateCompany(arg0 context.Context, arg1 db.CreateCompanyParams) (db.Company, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +111,21 @@ func (mr *MockStoreMockRecorder) CreateCompany(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCompany", reflect.TypeOf((*MockStore)(nil).CreateCompany), arg0, arg1)
 }
 
+// CreateCompanyInvitation mocks base method.
+func (m *MockStore) CreateCompanyInvitation(arg0 context.Context, arg1 db.CreateCompanyInvitationParams) (db.CompanyInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCompanyInvitation", arg0, arg1)
+	ret0, _ := ret[0].(db.CompanyInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCompanyInvitation indicates an expected call of CreateCompanyInvitation.
+func (mr *MockStoreMockRecorder) CreateCompanyInvitation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCompanyInvitation", reflect.TypeOf((*MockStore)(nil).CreateCompanyInvitation), arg0, arg1)
+}
+
 // CreateEmployer mocks base method.
 func (m *MockStore) CreateEmployer(arg0 context.Context, arg1 db.CreateEmployerParams) (db.Employer, error) {
 	m.ctrl.T.Helper()
@@ -125,6 +201,20 @@ func (mr *MockStoreMockRecorder) CreateJobApplicationTx(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJobApplicationTx", reflect.TypeOf((*MockStore)(nil).CreateJobApplicationTx), arg0, arg1)
 }
 
+// CreateJobExpiryReminder mocks base method.
+func (m *MockStore) CreateJobExpiryReminder(arg0 context.Context, arg1 db.CreateJobExpiryReminderParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJobExpiryReminder", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateJobExpiryReminder indicates an expected call of CreateJobExpiryReminder.
+func (mr *MockStoreMockRecorder) CreateJobExpiryReminder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJobExpiryReminder", reflect.TypeOf((*MockStore)(nil).CreateJobExpiryReminder), arg0, arg1)
+}
+
 // CreateJobSkill mocks base method.
 func (m *MockStore) CreateJobSkill(arg0 context.Context, arg1 db.CreateJobSkillParams) (db.JobSkill, error) {
 	m.ctrl.T.Helper()
@@ -140,6 +230,36 @@ func (mr *MockStoreMockRecorder) CreateJobSkill(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJobSkill", reflect.TypeOf((*MockStore)(nil).CreateJobSkill), arg0, arg1)
 }
 
+// CreateJobTx mocks base method.
+func (m *MockStore) CreateJobTx(arg0 context.Context, arg1 db.CreateJobTxParams) (db.CreateJobTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJobTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CreateJobTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateJobTx indicates an expected call of CreateJobTx.
+func (mr *MockStoreMockRecorder) CreateJobTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJobTx", reflect.TypeOf((*MockStore)(nil).CreateJobTx), arg0, arg1)
+}
+
+// CreateMfaRecoveryCode mocks base method.
+func (m *MockStore) CreateMfaRecoveryCode(arg0 context.Context, arg1 db.CreateMfaRecoveryCodeParams) (db.MfaRecoveryCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMfaRecoveryCode", arg0, arg1)
+	ret0, _ := ret[0].(db.MfaRecoveryCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMfaRecoveryCode indicates an expected call of CreateMfaRecoveryCode.
+func (mr *MockStoreMockRecorder) CreateMfaRecoveryCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMfaRecoveryCode", reflect.TypeOf((*MockStore)(nil).CreateMfaRecoveryCode), arg0, arg1)
+}
+
 // CreateMultipleJobSkills mocks base method.
 func (m *MockStore) CreateMultipleJobSkills(arg0 context.Context, arg1 []string, arg2 int32) error {
 	m.ctrl.T.Helper()
@@ -169,6 +289,94 @@ func (mr *MockStoreMockRecorder) CreateMultipleUserSkills(arg0, arg1, arg2 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMultipleUserSkills", reflect.TypeOf((*MockStore)(nil).CreateMultipleUserSkills), arg0, arg1, arg2)
 }
 
+// CreateOidcState mocks base method.
+func (m *MockStore) CreateOidcState(arg0 context.Context, arg1 db.CreateOidcStateParams) (db.OidcState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOidcState", arg0, arg1)
+	ret0, _ := ret[0].(db.OidcState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOidcState indicates an expected call of CreateOidcState.
+func (mr *MockStoreMockRecorder) CreateOidcState(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOidcState", reflect.TypeOf((*MockStore)(nil).CreateOidcState), arg0, arg1)
+}
+
+// CreateResetPassword mocks base method.
+func (m *MockStore) CreateResetPassword(arg0 context.Context, arg1 db.CreateResetPasswordParams) (db.ResetPassword, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateResetPassword", arg0, arg1)
+	ret0, _ := ret[0].(db.ResetPassword)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateResetPassword indicates an expected call of CreateResetPassword.
+func (mr *MockStoreMockRecorder) CreateResetPassword(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateResetPassword", reflect.TypeOf((*MockStore)(nil).CreateResetPassword), arg0, arg1)
+}
+
+// CreateSavedSearch mocks base method.
+func (m *MockStore) CreateSavedSearch(arg0 context.Context, arg1 db.CreateSavedSearchParams) (db.SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSavedSearch", arg0, arg1)
+	ret0, _ := ret[0].(db.SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSavedSearch indicates an expected call of CreateSavedSearch.
+func (mr *MockStoreMockRecorder) CreateSavedSearch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSavedSearch", reflect.TypeOf((*MockStore)(nil).CreateSavedSearch), arg0, arg1)
+}
+
+// CreateSearchOutboxEntriesForCompany mocks base method.
+func (m *MockStore) CreateSearchOutboxEntriesForCompany(arg0 context.Context, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSearchOutboxEntriesForCompany", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSearchOutboxEntriesForCompany indicates an expected call of CreateSearchOutboxEntriesForCompany.
+func (mr *MockStoreMockRecorder) CreateSearchOutboxEntriesForCompany(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSearchOutboxEntriesForCompany", reflect.TypeOf((*MockStore)(nil).CreateSearchOutboxEntriesForCompany), arg0, arg1)
+}
+
+// CreateSearchOutboxEntry mocks base method.
+func (m *MockStore) CreateSearchOutboxEntry(arg0 context.Context, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSearchOutboxEntry", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSearchOutboxEntry indicates an expected call of CreateSearchOutboxEntry.
+func (mr *MockStoreMockRecorder) CreateSearchOutboxEntry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSearchOutboxEntry", reflect.TypeOf((*MockStore)(nil).CreateSearchOutboxEntry), arg0, arg1)
+}
+
+// CreateSession mocks base method.
+func (m *MockStore) CreateSession(arg0 context.Context, arg1 db.CreateSessionParams) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", arg0, arg1)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockStoreMockRecorder) CreateSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), arg0, arg1)
+}
+
 // CreateUser mocks base method.
 func (m *MockStore) CreateUser(arg0 context.Context, arg1 db.CreateUserParams) (db.User, error) {
 	m.ctrl.T.Helper()
@@ -184,6 +392,21 @@ func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
 }
 
+// CreateUserIdentity mocks base method.
+func (m *MockStore) CreateUserIdentity(arg0 context.Context, arg1 db.CreateUserIdentityParams) (db.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserIdentity", arg0, arg1)
+	ret0, _ := ret[0].(db.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUserIdentity indicates an expected call of CreateUserIdentity.
+func (mr *MockStoreMockRecorder) CreateUserIdentity(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserIdentity", reflect.TypeOf((*MockStore)(nil).CreateUserIdentity), arg0, arg1)
+}
+
 // CreateUserSkill mocks base method.
 func (m *MockStore) CreateUserSkill(arg0 context.Context, arg1 db.CreateUserSkillParams) (db.UserSkill, error) {
 	m.ctrl.T.Helper()
@@ -243,6 +466,21 @@ func (mr *MockStoreMockRecorder) DeleteAllUserSkills(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllUserSkills", reflect.TypeOf((*MockStore)(nil).DeleteAllUserSkills), arg0, arg1)
 }
 
+// DeleteApiKey mocks base method.
+func (m *MockStore) DeleteApiKey(arg0 context.Context, arg1 db.DeleteApiKeyParams) (db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteApiKey", arg0, arg1)
+	ret0, _ := ret[0].(db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteApiKey indicates an expected call of DeleteApiKey.
+func (mr *MockStoreMockRecorder) DeleteApiKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteApiKey", reflect.TypeOf((*MockStore)(nil).DeleteApiKey), arg0, arg1)
+}
+
 // DeleteCompany mocks base method.
 func (m *MockStore) DeleteCompany(arg0 context.Context, arg1 int32) error {
 	m.ctrl.T.Helper()
@@ -271,6 +509,20 @@ func (mr *MockStoreMockRecorder) DeleteEmployer(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmployer", reflect.TypeOf((*MockStore)(nil).DeleteEmployer), arg0, arg1)
 }
 
+// DeleteEmployerTx mocks base method.
+func (m *MockStore) DeleteEmployerTx(arg0 context.Context, arg1 db.DeleteEmployerTxParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEmployerTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEmployerTx indicates an expected call of DeleteEmployerTx.
+func (mr *MockStoreMockRecorder) DeleteEmployerTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmployerTx", reflect.TypeOf((*MockStore)(nil).DeleteEmployerTx), arg0, arg1)
+}
+
 // DeleteJob mocks base method.
 func (m *MockStore) DeleteJob(arg0 context.Context, arg1 int32) error {
 	m.ctrl.T.Helper()
@@ -341,6 +593,48 @@ func (mr *MockStoreMockRecorder) DeleteJobSkillsByJobID(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteJobSkillsByJobID", reflect.TypeOf((*MockStore)(nil).DeleteJobSkillsByJobID), arg0, arg1)
 }
 
+// DeleteLoginAttempt mocks base method.
+func (m *MockStore) DeleteLoginAttempt(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLoginAttempt", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLoginAttempt indicates an expected call of DeleteLoginAttempt.
+func (mr *MockStoreMockRecorder) DeleteLoginAttempt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoginAttempt", reflect.TypeOf((*MockStore)(nil).DeleteLoginAttempt), arg0, arg1)
+}
+
+// DeleteMfaRecoveryCodes mocks base method.
+func (m *MockStore) DeleteMfaRecoveryCodes(arg0 context.Context, arg1 db.DeleteMfaRecoveryCodesParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMfaRecoveryCodes", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMfaRecoveryCodes indicates an expected call of DeleteMfaRecoveryCodes.
+func (mr *MockStoreMockRecorder) DeleteMfaRecoveryCodes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMfaRecoveryCodes", reflect.TypeOf((*MockStore)(nil).DeleteMfaRecoveryCodes), arg0, arg1)
+}
+
+// DeleteMfaSetting mocks base method.
+func (m *MockStore) DeleteMfaSetting(arg0 context.Context, arg1 db.DeleteMfaSettingParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMfaSetting", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMfaSetting indicates an expected call of DeleteMfaSetting.
+func (mr *MockStoreMockRecorder) DeleteMfaSetting(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMfaSetting", reflect.TypeOf((*MockStore)(nil).DeleteMfaSetting), arg0, arg1)
+}
+
 // DeleteMultipleJobSkills mocks base method.
 func (m *MockStore) DeleteMultipleJobSkills(arg0 context.Context, arg1 []int32) error {
 	m.ctrl.T.Helper()
@@ -369,6 +663,80 @@ func (mr *MockStoreMockRecorder) DeleteMultipleUserSkills(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMultipleUserSkills", reflect.TypeOf((*MockStore)(nil).DeleteMultipleUserSkills), arg0, arg1)
 }
 
+// DeleteOidcState mocks base method.
+func (m *MockStore) DeleteOidcState(arg0 context.Context, arg1 string) (db.OidcState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOidcState", arg0, arg1)
+	ret0, _ := ret[0].(db.OidcState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOidcState indicates an expected call of DeleteOidcState.
+func (mr *MockStoreMockRecorder) DeleteOidcState(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOidcState", reflect.TypeOf((*MockStore)(nil).DeleteOidcState), arg0, arg1)
+}
+
+// DeleteProcessedSearchOutboxEntries mocks base method.
+func (m *MockStore) DeleteProcessedSearchOutboxEntries(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProcessedSearchOutboxEntries", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteProcessedSearchOutboxEntries indicates an expected call of DeleteProcessedSearchOutboxEntries.
+func (mr *MockStoreMockRecorder) DeleteProcessedSearchOutboxEntries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProcessedSearchOutboxEntries", reflect.TypeOf((*MockStore)(nil).DeleteProcessedSearchOutboxEntries), arg0, arg1)
+}
+
+// DeleteResetPassword mocks base method.
+func (m *MockStore) DeleteResetPassword(arg0 context.Context, arg1 db.DeleteResetPasswordParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteResetPassword", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteResetPassword indicates an expected call of DeleteResetPassword.
+func (mr *MockStoreMockRecorder) DeleteResetPassword(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteResetPassword", reflect.TypeOf((*MockStore)(nil).DeleteResetPassword), arg0, arg1)
+}
+
+// DeleteSavedSearch mocks base method.
+func (m *MockStore) DeleteSavedSearch(arg0 context.Context, arg1 db.DeleteSavedSearchParams) (db.SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSavedSearch", arg0, arg1)
+	ret0, _ := ret[0].(db.SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSavedSearch indicates an expected call of DeleteSavedSearch.
+func (mr *MockStoreMockRecorder) DeleteSavedSearch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSavedSearch", reflect.TypeOf((*MockStore)(nil).DeleteSavedSearch), arg0, arg1)
+}
+
+// DeleteStaleVerifyEmails mocks base method.
+func (m *MockStore) DeleteStaleVerifyEmails(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStaleVerifyEmails", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteStaleVerifyEmails indicates an expected call of DeleteStaleVerifyEmails.
+func (mr *MockStoreMockRecorder) DeleteStaleVerifyEmails(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStaleVerifyEmails", reflect.TypeOf((*MockStore)(nil).DeleteStaleVerifyEmails), arg0)
+}
+
 // DeleteUser mocks base method.
 func (m *MockStore) DeleteUser(arg0 context.Context, arg1 int32) error {
 	m.ctrl.T.Helper()
@@ -411,6 +779,50 @@ func (mr *MockStoreMockRecorder) DeleteVerifyEmail(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVerifyEmail", reflect.TypeOf((*MockStore)(nil).DeleteVerifyEmail), arg0, arg1)
 }
 
+// DisableMfaTx mocks base method.
+func (m *MockStore) DisableMfaTx(arg0 context.Context, arg1 string, arg2 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableMfaTx", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableMfaTx indicates an expected call of DisableMfaTx.
+func (mr *MockStoreMockRecorder) DisableMfaTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableMfaTx", reflect.TypeOf((*MockStore)(nil).DisableMfaTx), arg0, arg1, arg2)
+}
+
+// EnableMfaSetting mocks base method.
+func (m *MockStore) EnableMfaSetting(arg0 context.Context, arg1 db.EnableMfaSettingParams) (db.MfaSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableMfaSetting", arg0, arg1)
+	ret0, _ := ret[0].(db.MfaSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableMfaSetting indicates an expected call of EnableMfaSetting.
+func (mr *MockStoreMockRecorder) EnableMfaSetting(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableMfaSetting", reflect.TypeOf((*MockStore)(nil).EnableMfaSetting), arg0, arg1)
+}
+
+// EnableMfaTx mocks base method.
+func (m *MockStore) EnableMfaTx(arg0 context.Context, arg1 db.EnableMfaTxParams) (db.MfaSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableMfaTx", arg0, arg1)
+	ret0, _ := ret[0].(db.MfaSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableMfaTx indicates an expected call of EnableMfaTx.
+func (mr *MockStoreMockRecorder) EnableMfaTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableMfaTx", reflect.TypeOf((*MockStore)(nil).EnableMfaTx), arg0, arg1)
+}
+
 // ExecTx mocks base method.
 func (m *MockStore) ExecTx(arg0 context.Context, arg1 func(*db.Queries) error) error {
 	m.ctrl.T.Helper()
@@ -425,6 +837,36 @@ func (mr *MockStoreMockRecorder) ExecTx(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecTx", reflect.TypeOf((*MockStore)(nil).ExecTx), arg0, arg1)
 }
 
+// ExpireJobs mocks base method.
+func (m *MockStore) ExpireJobs(arg0 context.Context) ([]int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireJobs", arg0)
+	ret0, _ := ret[0].([]int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireJobs indicates an expected call of ExpireJobs.
+func (mr *MockStoreMockRecorder) ExpireJobs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireJobs", reflect.TypeOf((*MockStore)(nil).ExpireJobs), arg0)
+}
+
+// GetApiKeyByPrefix mocks base method.
+func (m *MockStore) GetApiKeyByPrefix(arg0 context.Context, arg1 string) (db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApiKeyByPrefix", arg0, arg1)
+	ret0, _ := ret[0].(db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApiKeyByPrefix indicates an expected call of GetApiKeyByPrefix.
+func (mr *MockStoreMockRecorder) GetApiKeyByPrefix(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApiKeyByPrefix", reflect.TypeOf((*MockStore)(nil).GetApiKeyByPrefix), arg0, arg1)
+}
+
 // GetCompanyByID mocks base method.
 func (m *MockStore) GetCompanyByID(arg0 context.Context, arg1 int32) (db.Company, error) {
 	m.ctrl.T.Helper()
@@ -485,6 +927,21 @@ func (mr *MockStoreMockRecorder) GetCompanyNameByID(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompanyNameByID", reflect.TypeOf((*MockStore)(nil).GetCompanyNameByID), arg0, arg1)
 }
 
+// GetCurrencyRate mocks base method.
+func (m *MockStore) GetCurrencyRate(arg0 context.Context, arg1 string) (db.CurrencyRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrencyRate", arg0, arg1)
+	ret0, _ := ret[0].(db.CurrencyRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrencyRate indicates an expected call of GetCurrencyRate.
+func (mr *MockStoreMockRecorder) GetCurrencyRate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrencyRate", reflect.TypeOf((*MockStore)(nil).GetCurrencyRate), arg0, arg1)
+}
+
 // GetEmployerAndCompanyDetails mocks base method.
 func (m *MockStore) GetEmployerAndCompanyDetails(arg0 context.Context, arg1 string) (db.GetEmployerAndCompanyDetailsRow, error) {
 	m.ctrl.T.Helper()
@@ -635,19 +1092,79 @@ func (mr *MockStoreMockRecorder) GetJobDetails(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobDetails", reflect.TypeOf((*MockStore)(nil).GetJobDetails), arg0, arg1)
 }
 
-// GetJobIDOfJobApplication mocks base method.
-func (m *MockStore) GetJobIDOfJobApplication(arg0 context.Context, arg1 int32) (int32, error) {
+// GetJobForES mocks base method.
+func (m *MockStore) GetJobForES(arg0 context.Context, arg1 int32) (db.GetJobForESRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetJobIDOfJobApplication", arg0, arg1)
-	ret0, _ := ret[0].(int32)
+	ret := m.ctrl.Call(m, "GetJobForES", arg0, arg1)
+	ret0, _ := ret[0].(db.GetJobForESRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetJobIDOfJobApplication indicates an expected call of GetJobIDOfJobApplication.
-func (mr *MockStoreMockRecorder) GetJobIDOfJobApplication(arg0, arg1 interface{}) *gomock.Call {
+// GetJobForES indicates an expected call of GetJobForES.
+func (mr *MockStoreMockRecorder) GetJobForES(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobIDOfJobApplication", reflect.TypeOf((*MockStore)(nil).GetJobIDOfJobApplication), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobForES", reflect.TypeOf((*MockStore)(nil).GetJobForES), arg0, arg1)
+}
+
+// GetJobIDOfJobApplication mocks base method.
+func (m *MockStore) GetJobIDOfJobApplication(arg0 context.Context, arg1 int32) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobIDOfJobApplication", arg0, arg1)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJobIDOfJobApplication indicates an expected call of GetJobIDOfJobApplication.
+func (mr *MockStoreMockRecorder) GetJobIDOfJobApplication(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobIDOfJobApplication", reflect.TypeOf((*MockStore)(nil).GetJobIDOfJobApplication), arg0, arg1)
+}
+
+// GetMfaSetting mocks base method.
+func (m *MockStore) GetMfaSetting(arg0 context.Context, arg1 db.GetMfaSettingParams) (db.MfaSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMfaSetting", arg0, arg1)
+	ret0, _ := ret[0].(db.MfaSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMfaSetting indicates an expected call of GetMfaSetting.
+func (mr *MockStoreMockRecorder) GetMfaSetting(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMfaSetting", reflect.TypeOf((*MockStore)(nil).GetMfaSetting), arg0, arg1)
+}
+
+// GetSavedSearch mocks base method.
+func (m *MockStore) GetSavedSearch(arg0 context.Context, arg1 db.GetSavedSearchParams) (db.SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSavedSearch", arg0, arg1)
+	ret0, _ := ret[0].(db.SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSavedSearch indicates an expected call of GetSavedSearch.
+func (mr *MockStoreMockRecorder) GetSavedSearch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSavedSearch", reflect.TypeOf((*MockStore)(nil).GetSavedSearch), arg0, arg1)
+}
+
+// GetSession mocks base method.
+func (m *MockStore) GetSession(arg0 context.Context, arg1 uuid.UUID) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", arg0, arg1)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockStoreMockRecorder) GetSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), arg0, arg1)
 }
 
 // GetUserByEmail mocks base method.
@@ -696,6 +1213,36 @@ func (mr *MockStoreMockRecorder) GetUserDetailsByEmail(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserDetailsByEmail", reflect.TypeOf((*MockStore)(nil).GetUserDetailsByEmail), arg0, arg1)
 }
 
+// GetUserIdentity mocks base method.
+func (m *MockStore) GetUserIdentity(arg0 context.Context, arg1 db.GetUserIdentityParams) (db.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIdentity", arg0, arg1)
+	ret0, _ := ret[0].(db.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIdentity indicates an expected call of GetUserIdentity.
+func (mr *MockStoreMockRecorder) GetUserIdentity(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIdentity", reflect.TypeOf((*MockStore)(nil).GetUserIdentity), arg0, arg1)
+}
+
+// IsTokenRevoked mocks base method.
+func (m *MockStore) IsTokenRevoked(arg0 context.Context, arg1 db.IsTokenRevokedParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTokenRevoked", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsTokenRevoked indicates an expected call of IsTokenRevoked.
+func (mr *MockStoreMockRecorder) IsTokenRevoked(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTokenRevoked", reflect.TypeOf((*MockStore)(nil).IsTokenRevoked), arg0, arg1)
+}
+
 // ListAllJobSkillsByJobID mocks base method.
 func (m *MockStore) ListAllJobSkillsByJobID(arg0 context.Context, arg1 int32) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -726,6 +1273,81 @@ func (mr *MockStoreMockRecorder) ListAllJobsForES(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllJobsForES", reflect.TypeOf((*MockStore)(nil).ListAllJobsForES), arg0)
 }
 
+// ListApiKeysByEmployerID mocks base method.
+func (m *MockStore) ListApiKeysByEmployerID(arg0 context.Context, arg1 int32) ([]db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListApiKeysByEmployerID", arg0, arg1)
+	ret0, _ := ret[0].([]db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListApiKeysByEmployerID indicates an expected call of ListApiKeysByEmployerID.
+func (mr *MockStoreMockRecorder) ListApiKeysByEmployerID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListApiKeysByEmployerID", reflect.TypeOf((*MockStore)(nil).ListApiKeysByEmployerID), arg0, arg1)
+}
+
+// ListCompanyEmployers mocks base method.
+func (m *MockStore) ListCompanyEmployers(arg0 context.Context, arg1 int32) ([]db.Employer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCompanyEmployers", arg0, arg1)
+	ret0, _ := ret[0].([]db.Employer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCompanyEmployers indicates an expected call of ListCompanyEmployers.
+func (mr *MockStoreMockRecorder) ListCompanyEmployers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCompanyEmployers", reflect.TypeOf((*MockStore)(nil).ListCompanyEmployers), arg0, arg1)
+}
+
+// ListCompanyLocations mocks base method.
+func (m *MockStore) ListCompanyLocations(arg0 context.Context) ([]db.ListCompanyLocationsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCompanyLocations", arg0)
+	ret0, _ := ret[0].([]db.ListCompanyLocationsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCompanyLocations indicates an expected call of ListCompanyLocations.
+func (mr *MockStoreMockRecorder) ListCompanyLocations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCompanyLocations", reflect.TypeOf((*MockStore)(nil).ListCompanyLocations), arg0)
+}
+
+// ListCurrencyRates mocks base method.
+func (m *MockStore) ListCurrencyRates(arg0 context.Context) ([]db.CurrencyRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCurrencyRates", arg0)
+	ret0, _ := ret[0].([]db.CurrencyRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCurrencyRates indicates an expected call of ListCurrencyRates.
+func (mr *MockStoreMockRecorder) ListCurrencyRates(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCurrencyRates", reflect.TypeOf((*MockStore)(nil).ListCurrencyRates), arg0)
+}
+
+// ListDueSavedSearches mocks base method.
+func (m *MockStore) ListDueSavedSearches(arg0 context.Context, arg1 db.ListDueSavedSearchesParams) ([]db.ListDueSavedSearchesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueSavedSearches", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListDueSavedSearchesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueSavedSearches indicates an expected call of ListDueSavedSearches.
+func (mr *MockStoreMockRecorder) ListDueSavedSearches(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueSavedSearches", reflect.TypeOf((*MockStore)(nil).ListDueSavedSearches), arg0, arg1)
+}
+
 // ListJobApplicationsForEmployer mocks base method.
 func (m *MockStore) ListJobApplicationsForEmployer(arg0 context.Context, arg1 db.ListJobApplicationsForEmployerParams) ([]db.ListJobApplicationsForEmployerRow, error) {
 	m.ctrl.T.Helper()
@@ -756,6 +1378,51 @@ func (mr *MockStoreMockRecorder) ListJobApplicationsForUser(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobApplicationsForUser", reflect.TypeOf((*MockStore)(nil).ListJobApplicationsForUser), arg0, arg1)
 }
 
+// ListJobLocations mocks base method.
+func (m *MockStore) ListJobLocations(arg0 context.Context) ([]db.ListJobLocationsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJobLocations", arg0)
+	ret0, _ := ret[0].([]db.ListJobLocationsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJobLocations indicates an expected call of ListJobLocations.
+func (mr *MockStoreMockRecorder) ListJobLocations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobLocations", reflect.TypeOf((*MockStore)(nil).ListJobLocations), arg0)
+}
+
+// ListJobSalariesByCurrency mocks base method.
+func (m *MockStore) ListJobSalariesByCurrency(arg0 context.Context, arg1 string) ([]db.ListJobSalariesByCurrencyRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJobSalariesByCurrency", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListJobSalariesByCurrencyRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJobSalariesByCurrency indicates an expected call of ListJobSalariesByCurrency.
+func (mr *MockStoreMockRecorder) ListJobSalariesByCurrency(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobSalariesByCurrency", reflect.TypeOf((*MockStore)(nil).ListJobSalariesByCurrency), arg0, arg1)
+}
+
+// ListJobSearchFacets mocks base method.
+func (m *MockStore) ListJobSearchFacets(arg0 context.Context, arg1 db.ListJobSearchFacetsParams) ([]db.ListJobSearchFacetsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJobSearchFacets", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListJobSearchFacetsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJobSearchFacets indicates an expected call of ListJobSearchFacets.
+func (mr *MockStoreMockRecorder) ListJobSearchFacets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobSearchFacets", reflect.TypeOf((*MockStore)(nil).ListJobSearchFacets), arg0, arg1)
+}
+
 // ListJobSkillsByJobID mocks base method.
 func (m *MockStore) ListJobSkillsByJobID(arg0 context.Context, arg1 db.ListJobSkillsByJobIDParams) ([]db.ListJobSkillsByJobIDRow, error) {
 	m.ctrl.T.Helper()
@@ -771,6 +1438,21 @@ func (mr *MockStoreMockRecorder) ListJobSkillsByJobID(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobSkillsByJobID", reflect.TypeOf((*MockStore)(nil).ListJobSkillsByJobID), arg0, arg1)
 }
 
+// ListJobSuggestions mocks base method.
+func (m *MockStore) ListJobSuggestions(arg0 context.Context, arg1 db.ListJobSuggestionsParams) ([]db.ListJobSuggestionsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJobSuggestions", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListJobSuggestionsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJobSuggestions indicates an expected call of ListJobSuggestions.
+func (mr *MockStoreMockRecorder) ListJobSuggestions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobSuggestions", reflect.TypeOf((*MockStore)(nil).ListJobSuggestions), arg0, arg1)
+}
+
 // ListJobsByCompanyExactName mocks base method.
 func (m *MockStore) ListJobsByCompanyExactName(arg0 context.Context, arg1 db.ListJobsByCompanyExactNameParams) ([]db.ListJobsByCompanyExactNameRow, error) {
 	m.ctrl.T.Helper()
@@ -891,6 +1573,36 @@ func (mr *MockStoreMockRecorder) ListJobsBySkill(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobsBySkill", reflect.TypeOf((*MockStore)(nil).ListJobsBySkill), arg0, arg1)
 }
 
+// ListJobsBySkillOverlap mocks base method.
+func (m *MockStore) ListJobsBySkillOverlap(arg0 context.Context, arg1 db.ListJobsBySkillOverlapParams) ([]db.ListJobsBySkillOverlapRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJobsBySkillOverlap", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListJobsBySkillOverlapRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJobsBySkillOverlap indicates an expected call of ListJobsBySkillOverlap.
+func (mr *MockStoreMockRecorder) ListJobsBySkillOverlap(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobsBySkillOverlap", reflect.TypeOf((*MockStore)(nil).ListJobsBySkillOverlap), arg0, arg1)
+}
+
+// ListJobsByTextSimilarity mocks base method.
+func (m *MockStore) ListJobsByTextSimilarity(arg0 context.Context, arg1 db.ListJobsByTextSimilarityParams) ([]db.ListJobsByTextSimilarityRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJobsByTextSimilarity", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListJobsByTextSimilarityRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJobsByTextSimilarity indicates an expected call of ListJobsByTextSimilarity.
+func (mr *MockStoreMockRecorder) ListJobsByTextSimilarity(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobsByTextSimilarity", reflect.TypeOf((*MockStore)(nil).ListJobsByTextSimilarity), arg0, arg1)
+}
+
 // ListJobsByTitle mocks base method.
 func (m *MockStore) ListJobsByTitle(arg0 context.Context, arg1 db.ListJobsByTitleParams) ([]db.Job, error) {
 	m.ctrl.T.Helper()
@@ -906,6 +1618,21 @@ func (mr *MockStoreMockRecorder) ListJobsByTitle(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobsByTitle", reflect.TypeOf((*MockStore)(nil).ListJobsByTitle), arg0, arg1)
 }
 
+// ListJobsClosingSoon mocks base method.
+func (m *MockStore) ListJobsClosingSoon(arg0 context.Context, arg1 time.Time) ([]db.ListJobsClosingSoonRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJobsClosingSoon", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListJobsClosingSoonRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJobsClosingSoon indicates an expected call of ListJobsClosingSoon.
+func (mr *MockStoreMockRecorder) ListJobsClosingSoon(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobsClosingSoon", reflect.TypeOf((*MockStore)(nil).ListJobsClosingSoon), arg0, arg1)
+}
+
 // ListJobsForEmployer mocks base method.
 func (m *MockStore) ListJobsForEmployer(arg0 context.Context, arg1 db.ListJobsForEmployerParams) ([]db.ListJobsForEmployerRow, error) {
 	m.ctrl.T.Helper()
@@ -936,6 +1663,126 @@ func (mr *MockStoreMockRecorder) ListJobsMatchingUserSkills(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobsMatchingUserSkills", reflect.TypeOf((*MockStore)(nil).ListJobsMatchingUserSkills), arg0, arg1)
 }
 
+// ListLoginAttempts mocks base method.
+func (m *MockStore) ListLoginAttempts(arg0 context.Context, arg1 []string) ([]db.LoginAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLoginAttempts", arg0, arg1)
+	ret0, _ := ret[0].([]db.LoginAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLoginAttempts indicates an expected call of ListLoginAttempts.
+func (mr *MockStoreMockRecorder) ListLoginAttempts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoginAttempts", reflect.TypeOf((*MockStore)(nil).ListLoginAttempts), arg0, arg1)
+}
+
+// ListNewJobsForSavedSearch mocks base method.
+func (m *MockStore) ListNewJobsForSavedSearch(arg0 context.Context, arg1 db.ListNewJobsForSavedSearchParams) ([]db.ListNewJobsForSavedSearchRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNewJobsForSavedSearch", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListNewJobsForSavedSearchRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNewJobsForSavedSearch indicates an expected call of ListNewJobsForSavedSearch.
+func (mr *MockStoreMockRecorder) ListNewJobsForSavedSearch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNewJobsForSavedSearch", reflect.TypeOf((*MockStore)(nil).ListNewJobsForSavedSearch), arg0, arg1)
+}
+
+// ListPendingSearchOutboxEntries mocks base method.
+func (m *MockStore) ListPendingSearchOutboxEntries(arg0 context.Context, arg1 int32) ([]db.SearchOutbox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingSearchOutboxEntries", arg0, arg1)
+	ret0, _ := ret[0].([]db.SearchOutbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingSearchOutboxEntries indicates an expected call of ListPendingSearchOutboxEntries.
+func (mr *MockStoreMockRecorder) ListPendingSearchOutboxEntries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingSearchOutboxEntries", reflect.TypeOf((*MockStore)(nil).ListPendingSearchOutboxEntries), arg0, arg1)
+}
+
+// ListPublishedJobIDs mocks base method.
+func (m *MockStore) ListPublishedJobIDs(arg0 context.Context, arg1 []int32) ([]int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPublishedJobIDs", arg0, arg1)
+	ret0, _ := ret[0].([]int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPublishedJobIDs indicates an expected call of ListPublishedJobIDs.
+func (mr *MockStoreMockRecorder) ListPublishedJobIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPublishedJobIDs", reflect.TypeOf((*MockStore)(nil).ListPublishedJobIDs), arg0, arg1)
+}
+
+// ListSavedSearchesByUserID mocks base method.
+func (m *MockStore) ListSavedSearchesByUserID(arg0 context.Context, arg1 int32) ([]db.SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSavedSearchesByUserID", arg0, arg1)
+	ret0, _ := ret[0].([]db.SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSavedSearchesByUserID indicates an expected call of ListSavedSearchesByUserID.
+func (mr *MockStoreMockRecorder) ListSavedSearchesByUserID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSavedSearchesByUserID", reflect.TypeOf((*MockStore)(nil).ListSavedSearchesByUserID), arg0, arg1)
+}
+
+// ListSessionsByEmail mocks base method.
+func (m *MockStore) ListSessionsByEmail(arg0 context.Context, arg1 db.ListSessionsByEmailParams) ([]db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessionsByEmail", arg0, arg1)
+	ret0, _ := ret[0].([]db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessionsByEmail indicates an expected call of ListSessionsByEmail.
+func (mr *MockStoreMockRecorder) ListSessionsByEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessionsByEmail", reflect.TypeOf((*MockStore)(nil).ListSessionsByEmail), arg0, arg1)
+}
+
+// ListUnusedMfaRecoveryCodes mocks base method.
+func (m *MockStore) ListUnusedMfaRecoveryCodes(arg0 context.Context, arg1 db.ListUnusedMfaRecoveryCodesParams) ([]db.MfaRecoveryCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnusedMfaRecoveryCodes", arg0, arg1)
+	ret0, _ := ret[0].([]db.MfaRecoveryCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnusedMfaRecoveryCodes indicates an expected call of ListUnusedMfaRecoveryCodes.
+func (mr *MockStoreMockRecorder) ListUnusedMfaRecoveryCodes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnusedMfaRecoveryCodes", reflect.TypeOf((*MockStore)(nil).ListUnusedMfaRecoveryCodes), arg0, arg1)
+}
+
+// ListUserLocations mocks base method.
+func (m *MockStore) ListUserLocations(arg0 context.Context) ([]db.ListUserLocationsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserLocations", arg0)
+	ret0, _ := ret[0].([]db.ListUserLocationsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserLocations indicates an expected call of ListUserLocations.
+func (mr *MockStoreMockRecorder) ListUserLocations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserLocations", reflect.TypeOf((*MockStore)(nil).ListUserLocations), arg0)
+}
+
 // ListUserSkills mocks base method.
 func (m *MockStore) ListUserSkills(arg0 context.Context, arg1 db.ListUserSkillsParams) ([]db.UserSkill, error) {
 	m.ctrl.T.Helper()
@@ -978,6 +1825,211 @@ func (mr *MockStoreMockRecorder) LoadTestData(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadTestData", reflect.TypeOf((*MockStore)(nil).LoadTestData), arg0)
 }
 
+// LockLoginAttempt mocks base method.
+func (m *MockStore) LockLoginAttempt(arg0 context.Context, arg1 db.LockLoginAttemptParams) (db.LoginAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockLoginAttempt", arg0, arg1)
+	ret0, _ := ret[0].(db.LoginAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockLoginAttempt indicates an expected call of LockLoginAttempt.
+func (mr *MockStoreMockRecorder) LockLoginAttempt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockLoginAttempt", reflect.TypeOf((*MockStore)(nil).LockLoginAttempt), arg0, arg1)
+}
+
+// MarkSearchOutboxEntriesProcessed mocks base method.
+func (m *MockStore) MarkSearchOutboxEntriesProcessed(arg0 context.Context, arg1 []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSearchOutboxEntriesProcessed", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSearchOutboxEntriesProcessed indicates an expected call of MarkSearchOutboxEntriesProcessed.
+func (mr *MockStoreMockRecorder) MarkSearchOutboxEntriesProcessed(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSearchOutboxEntriesProcessed", reflect.TypeOf((*MockStore)(nil).MarkSearchOutboxEntriesProcessed), arg0, arg1)
+}
+
+// NormalizeLocations mocks base method.
+func (m *MockStore) NormalizeLocations(arg0 context.Context) (db.NormalizeLocationsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NormalizeLocations", arg0)
+	ret0, _ := ret[0].(db.NormalizeLocationsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NormalizeLocations indicates an expected call of NormalizeLocations.
+func (mr *MockStoreMockRecorder) NormalizeLocations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NormalizeLocations", reflect.TypeOf((*MockStore)(nil).NormalizeLocations), arg0)
+}
+
+// OidcLoginTx mocks base method.
+func (m *MockStore) OidcLoginTx(arg0 context.Context, arg1 db.OidcLoginTxParams) (db.OidcLoginTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OidcLoginTx", arg0, arg1)
+	ret0, _ := ret[0].(db.OidcLoginTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OidcLoginTx indicates an expected call of OidcLoginTx.
+func (mr *MockStoreMockRecorder) OidcLoginTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OidcLoginTx", reflect.TypeOf((*MockStore)(nil).OidcLoginTx), arg0, arg1)
+}
+
+// RecordFailedLoginAttempt mocks base method.
+func (m *MockStore) RecordFailedLoginAttempt(arg0 context.Context, arg1 db.RecordFailedLoginAttemptParams) (db.LoginAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFailedLoginAttempt", arg0, arg1)
+	ret0, _ := ret[0].(db.LoginAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordFailedLoginAttempt indicates an expected call of RecordFailedLoginAttempt.
+func (mr *MockStoreMockRecorder) RecordFailedLoginAttempt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailedLoginAttempt", reflect.TypeOf((*MockStore)(nil).RecordFailedLoginAttempt), arg0, arg1)
+}
+
+// ReplaySearchOutboxEntries mocks base method.
+func (m *MockStore) ReplaySearchOutboxEntries(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaySearchOutboxEntries", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaySearchOutboxEntries indicates an expected call of ReplaySearchOutboxEntries.
+func (mr *MockStoreMockRecorder) ReplaySearchOutboxEntries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaySearchOutboxEntries", reflect.TypeOf((*MockStore)(nil).ReplaySearchOutboxEntries), arg0, arg1)
+}
+
+// ResetEmployerPasswordTx mocks base method.
+func (m *MockStore) ResetEmployerPasswordTx(arg0 context.Context, arg1 db.ResetPasswordTxParams) (db.ResetEmployerPasswordResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetEmployerPasswordTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ResetEmployerPasswordResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetEmployerPasswordTx indicates an expected call of ResetEmployerPasswordTx.
+func (mr *MockStoreMockRecorder) ResetEmployerPasswordTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetEmployerPasswordTx", reflect.TypeOf((*MockStore)(nil).ResetEmployerPasswordTx), arg0, arg1)
+}
+
+// ResetUserPasswordTx mocks base method.
+func (m *MockStore) ResetUserPasswordTx(arg0 context.Context, arg1 db.ResetPasswordTxParams) (db.ResetUserPasswordResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetUserPasswordTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ResetUserPasswordResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetUserPasswordTx indicates an expected call of ResetUserPasswordTx.
+func (mr *MockStoreMockRecorder) ResetUserPasswordTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetUserPasswordTx", reflect.TypeOf((*MockStore)(nil).ResetUserPasswordTx), arg0, arg1)
+}
+
+// RevokeAllTokensByEmail mocks base method.
+func (m *MockStore) RevokeAllTokensByEmail(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAllTokensByEmail", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAllTokensByEmail indicates an expected call of RevokeAllTokensByEmail.
+func (mr *MockStoreMockRecorder) RevokeAllTokensByEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllTokensByEmail", reflect.TypeOf((*MockStore)(nil).RevokeAllTokensByEmail), arg0, arg1)
+}
+
+// RevokeAllTokensTx mocks base method.
+func (m *MockStore) RevokeAllTokensTx(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAllTokensTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAllTokensTx indicates an expected call of RevokeAllTokensTx.
+func (mr *MockStoreMockRecorder) RevokeAllTokensTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllTokensTx", reflect.TypeOf((*MockStore)(nil).RevokeAllTokensTx), arg0, arg1)
+}
+
+// RevokeToken mocks base method.
+func (m *MockStore) RevokeToken(arg0 context.Context, arg1 db.RevokeTokenParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockStoreMockRecorder) RevokeToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockStore)(nil).RevokeToken), arg0, arg1)
+}
+
+// SearchJobsFullText mocks base method.
+func (m *MockStore) SearchJobsFullText(arg0 context.Context, arg1 db.SearchJobsFullTextParams) ([]db.SearchJobsFullTextRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchJobsFullText", arg0, arg1)
+	ret0, _ := ret[0].([]db.SearchJobsFullTextRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchJobsFullText indicates an expected call of SearchJobsFullText.
+func (mr *MockStoreMockRecorder) SearchJobsFullText(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchJobsFullText", reflect.TypeOf((*MockStore)(nil).SearchJobsFullText), arg0, arg1)
+}
+
+// UnsubscribeSavedSearch mocks base method.
+func (m *MockStore) UnsubscribeSavedSearch(arg0 context.Context, arg1 string) (db.SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnsubscribeSavedSearch", arg0, arg1)
+	ret0, _ := ret[0].(db.SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnsubscribeSavedSearch indicates an expected call of UnsubscribeSavedSearch.
+func (mr *MockStoreMockRecorder) UnsubscribeSavedSearch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsubscribeSavedSearch", reflect.TypeOf((*MockStore)(nil).UnsubscribeSavedSearch), arg0, arg1)
+}
+
+// UpdateApiKeyLastUsedAt mocks base method.
+func (m *MockStore) UpdateApiKeyLastUsedAt(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateApiKeyLastUsedAt", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateApiKeyLastUsedAt indicates an expected call of UpdateApiKeyLastUsedAt.
+func (mr *MockStoreMockRecorder) UpdateApiKeyLastUsedAt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateApiKeyLastUsedAt", reflect.TypeOf((*MockStore)(nil).UpdateApiKeyLastUsedAt), arg0, arg1)
+}
+
 // UpdateCompany mocks base method.
 func (m *MockStore) UpdateCompany(arg0 context.Context, arg1 db.UpdateCompanyParams) (db.Company, error) {
 	m.ctrl.T.Helper()
@@ -993,6 +2045,35 @@ func (mr *MockStoreMockRecorder) UpdateCompany(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCompany", reflect.TypeOf((*MockStore)(nil).UpdateCompany), arg0, arg1)
 }
 
+// UpdateCompanyLocation mocks base method.
+func (m *MockStore) UpdateCompanyLocation(arg0 context.Context, arg1 db.UpdateCompanyLocationParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCompanyLocation", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCompanyLocation indicates an expected call of UpdateCompanyLocation.
+func (mr *MockStoreMockRecorder) UpdateCompanyLocation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCompanyLocation", reflect.TypeOf((*MockStore)(nil).UpdateCompanyLocation), arg0, arg1)
+}
+
+// UpdateCompanyTx mocks base method.
+func (m *MockStore) UpdateCompanyTx(arg0 context.Context, arg1 db.UpdateCompanyParams) (db.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCompanyTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCompanyTx indicates an expected call of UpdateCompanyTx.
+func (mr *MockStoreMockRecorder) UpdateCompanyTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCompanyTx", reflect.TypeOf((*MockStore)(nil).UpdateCompanyTx), arg0, arg1)
+}
+
 // UpdateEmployer mocks base method.
 func (m *MockStore) UpdateEmployer(arg0 context.Context, arg1 db.UpdateEmployerParams) (db.Employer, error) {
 	m.ctrl.T.Helper()
@@ -1022,6 +2103,35 @@ func (mr *MockStoreMockRecorder) UpdateEmployerPassword(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEmployerPassword", reflect.TypeOf((*MockStore)(nil).UpdateEmployerPassword), arg0, arg1)
 }
 
+// UpdateEmployerPasswordTx mocks base method.
+func (m *MockStore) UpdateEmployerPasswordTx(arg0 context.Context, arg1 db.UpdatePasswordTxParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEmployerPasswordTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEmployerPasswordTx indicates an expected call of UpdateEmployerPasswordTx.
+func (mr *MockStoreMockRecorder) UpdateEmployerPasswordTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEmployerPasswordTx", reflect.TypeOf((*MockStore)(nil).UpdateEmployerPasswordTx), arg0, arg1)
+}
+
+// UpdateEmployerRole mocks base method.
+func (m *MockStore) UpdateEmployerRole(arg0 context.Context, arg1 db.UpdateEmployerRoleParams) (db.Employer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEmployerRole", arg0, arg1)
+	ret0, _ := ret[0].(db.Employer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEmployerRole indicates an expected call of UpdateEmployerRole.
+func (mr *MockStoreMockRecorder) UpdateEmployerRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEmployerRole", reflect.TypeOf((*MockStore)(nil).UpdateEmployerRole), arg0, arg1)
+}
+
 // UpdateJob mocks base method.
 func (m *MockStore) UpdateJob(arg0 context.Context, arg1 db.UpdateJobParams) (db.Job, error) {
 	m.ctrl.T.Helper()
@@ -1037,6 +2147,20 @@ func (mr *MockStoreMockRecorder) UpdateJob(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJob", reflect.TypeOf((*MockStore)(nil).UpdateJob), arg0, arg1)
 }
 
+// UpdateJobAnnualSalary mocks base method.
+func (m *MockStore) UpdateJobAnnualSalary(arg0 context.Context, arg1 db.UpdateJobAnnualSalaryParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateJobAnnualSalary", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateJobAnnualSalary indicates an expected call of UpdateJobAnnualSalary.
+func (mr *MockStoreMockRecorder) UpdateJobAnnualSalary(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJobAnnualSalary", reflect.TypeOf((*MockStore)(nil).UpdateJobAnnualSalary), arg0, arg1)
+}
+
 // UpdateJobApplication mocks base method.
 func (m *MockStore) UpdateJobApplication(arg0 context.Context, arg1 db.UpdateJobApplicationParams) (db.JobApplication, error) {
 	m.ctrl.T.Helper()
@@ -1066,6 +2190,20 @@ func (mr *MockStoreMockRecorder) UpdateJobApplicationStatus(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJobApplicationStatus", reflect.TypeOf((*MockStore)(nil).UpdateJobApplicationStatus), arg0, arg1)
 }
 
+// UpdateJobLocation mocks base method.
+func (m *MockStore) UpdateJobLocation(arg0 context.Context, arg1 db.UpdateJobLocationParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateJobLocation", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateJobLocation indicates an expected call of UpdateJobLocation.
+func (mr *MockStoreMockRecorder) UpdateJobLocation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJobLocation", reflect.TypeOf((*MockStore)(nil).UpdateJobLocation), arg0, arg1)
+}
+
 // UpdateJobSkill mocks base method.
 func (m *MockStore) UpdateJobSkill(arg0 context.Context, arg1 db.UpdateJobSkillParams) (db.JobSkill, error) {
 	m.ctrl.T.Helper()
@@ -1081,6 +2219,51 @@ func (mr *MockStoreMockRecorder) UpdateJobSkill(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJobSkill", reflect.TypeOf((*MockStore)(nil).UpdateJobSkill), arg0, arg1)
 }
 
+// UpdateJobStatus mocks base method.
+func (m *MockStore) UpdateJobStatus(arg0 context.Context, arg1 db.UpdateJobStatusParams) (db.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateJobStatus", arg0, arg1)
+	ret0, _ := ret[0].(db.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateJobStatus indicates an expected call of UpdateJobStatus.
+func (mr *MockStoreMockRecorder) UpdateJobStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJobStatus", reflect.TypeOf((*MockStore)(nil).UpdateJobStatus), arg0, arg1)
+}
+
+// UpdateJobStatusTx mocks base method.
+func (m *MockStore) UpdateJobStatusTx(arg0 context.Context, arg1 db.UpdateJobStatusParams) (db.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateJobStatusTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateJobStatusTx indicates an expected call of UpdateJobStatusTx.
+func (mr *MockStoreMockRecorder) UpdateJobStatusTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJobStatusTx", reflect.TypeOf((*MockStore)(nil).UpdateJobStatusTx), arg0, arg1)
+}
+
+// UpdateJobTx mocks base method.
+func (m *MockStore) UpdateJobTx(arg0 context.Context, arg1 db.UpdateJobTxParams) (db.UpdateJobTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateJobTx", arg0, arg1)
+	ret0, _ := ret[0].(db.UpdateJobTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateJobTx indicates an expected call of UpdateJobTx.
+func (mr *MockStoreMockRecorder) UpdateJobTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJobTx", reflect.TypeOf((*MockStore)(nil).UpdateJobTx), arg0, arg1)
+}
+
 // UpdatePassword mocks base method.
 func (m *MockStore) UpdatePassword(arg0 context.Context, arg1 db.UpdatePasswordParams) error {
 	m.ctrl.T.Helper()
@@ -1095,6 +2278,50 @@ func (mr *MockStoreMockRecorder) UpdatePassword(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockStore)(nil).UpdatePassword), arg0, arg1)
 }
 
+// UpdateResetPassword mocks base method.
+func (m *MockStore) UpdateResetPassword(arg0 context.Context, arg1 db.UpdateResetPasswordParams) (db.ResetPassword, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateResetPassword", arg0, arg1)
+	ret0, _ := ret[0].(db.ResetPassword)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateResetPassword indicates an expected call of UpdateResetPassword.
+func (mr *MockStoreMockRecorder) UpdateResetPassword(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateResetPassword", reflect.TypeOf((*MockStore)(nil).UpdateResetPassword), arg0, arg1)
+}
+
+// UpdateSavedSearch mocks base method.
+func (m *MockStore) UpdateSavedSearch(arg0 context.Context, arg1 db.UpdateSavedSearchParams) (db.SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSavedSearch", arg0, arg1)
+	ret0, _ := ret[0].(db.SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSavedSearch indicates an expected call of UpdateSavedSearch.
+func (mr *MockStoreMockRecorder) UpdateSavedSearch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSavedSearch", reflect.TypeOf((*MockStore)(nil).UpdateSavedSearch), arg0, arg1)
+}
+
+// UpdateSavedSearchLastRunAt mocks base method.
+func (m *MockStore) UpdateSavedSearchLastRunAt(arg0 context.Context, arg1 db.UpdateSavedSearchLastRunAtParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSavedSearchLastRunAt", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSavedSearchLastRunAt indicates an expected call of UpdateSavedSearchLastRunAt.
+func (mr *MockStoreMockRecorder) UpdateSavedSearchLastRunAt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSavedSearchLastRunAt", reflect.TypeOf((*MockStore)(nil).UpdateSavedSearchLastRunAt), arg0, arg1)
+}
+
 // UpdateUser mocks base method.
 func (m *MockStore) UpdateUser(arg0 context.Context, arg1 db.UpdateUserParams) (db.User, error) {
 	m.ctrl.T.Helper()
@@ -1110,6 +2337,34 @@ func (mr *MockStoreMockRecorder) UpdateUser(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockStore)(nil).UpdateUser), arg0, arg1)
 }
 
+// UpdateUserLocation mocks base method.
+func (m *MockStore) UpdateUserLocation(arg0 context.Context, arg1 db.UpdateUserLocationParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserLocation", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserLocation indicates an expected call of UpdateUserLocation.
+func (mr *MockStoreMockRecorder) UpdateUserLocation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserLocation", reflect.TypeOf((*MockStore)(nil).UpdateUserLocation), arg0, arg1)
+}
+
+// UpdateUserPasswordTx mocks base method.
+func (m *MockStore) UpdateUserPasswordTx(arg0 context.Context, arg1 db.UpdatePasswordTxParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPasswordTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserPasswordTx indicates an expected call of UpdateUserPasswordTx.
+func (mr *MockStoreMockRecorder) UpdateUserPasswordTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPasswordTx", reflect.TypeOf((*MockStore)(nil).UpdateUserPasswordTx), arg0, arg1)
+}
+
 // UpdateUserSkill mocks base method.
 func (m *MockStore) UpdateUserSkill(arg0 context.Context, arg1 db.UpdateUserSkillParams) (db.UserSkill, error) {
 	m.ctrl.T.Helper()
@@ -1140,6 +2395,66 @@ func (mr *MockStoreMockRecorder) UpdateVerifyEmail(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVerifyEmail", reflect.TypeOf((*MockStore)(nil).UpdateVerifyEmail), arg0, arg1)
 }
 
+// UpsertCurrencyRate mocks base method.
+func (m *MockStore) UpsertCurrencyRate(arg0 context.Context, arg1 db.UpsertCurrencyRateParams) (db.CurrencyRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertCurrencyRate", arg0, arg1)
+	ret0, _ := ret[0].(db.CurrencyRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertCurrencyRate indicates an expected call of UpsertCurrencyRate.
+func (mr *MockStoreMockRecorder) UpsertCurrencyRate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCurrencyRate", reflect.TypeOf((*MockStore)(nil).UpsertCurrencyRate), arg0, arg1)
+}
+
+// UpsertMfaSetting mocks base method.
+func (m *MockStore) UpsertMfaSetting(arg0 context.Context, arg1 db.UpsertMfaSettingParams) (db.MfaSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertMfaSetting", arg0, arg1)
+	ret0, _ := ret[0].(db.MfaSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertMfaSetting indicates an expected call of UpsertMfaSetting.
+func (mr *MockStoreMockRecorder) UpsertMfaSetting(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertMfaSetting", reflect.TypeOf((*MockStore)(nil).UpsertMfaSetting), arg0, arg1)
+}
+
+// UseCompanyInvitation mocks base method.
+func (m *MockStore) UseCompanyInvitation(arg0 context.Context, arg1 db.UseCompanyInvitationParams) (db.CompanyInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCompanyInvitation", arg0, arg1)
+	ret0, _ := ret[0].(db.CompanyInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseCompanyInvitation indicates an expected call of UseCompanyInvitation.
+func (mr *MockStoreMockRecorder) UseCompanyInvitation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCompanyInvitation", reflect.TypeOf((*MockStore)(nil).UseCompanyInvitation), arg0, arg1)
+}
+
+// UseMfaRecoveryCode mocks base method.
+func (m *MockStore) UseMfaRecoveryCode(arg0 context.Context, arg1 int64) (db.MfaRecoveryCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseMfaRecoveryCode", arg0, arg1)
+	ret0, _ := ret[0].(db.MfaRecoveryCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseMfaRecoveryCode indicates an expected call of UseMfaRecoveryCode.
+func (mr *MockStoreMockRecorder) UseMfaRecoveryCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseMfaRecoveryCode", reflect.TypeOf((*MockStore)(nil).UseMfaRecoveryCode), arg0, arg1)
+}
+
 // VerifyEmployerEmail mocks base method.
 func (m *MockStore) VerifyEmployerEmail(arg0 context.Context, arg1 string) (db.Employer, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,28 @@
+-- name: CreateApiKey :one
+INSERT INTO api_keys
+    (employer_id, name, prefix, hashed_key, scopes)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetApiKeyByPrefix :one
+SELECT *
+FROM api_keys
+WHERE prefix = $1;
+
+-- name: ListApiKeysByEmployerID :many
+SELECT *
+FROM api_keys
+WHERE employer_id = $1
+ORDER BY created_at DESC;
+
+-- name: UpdateApiKeyLastUsedAt :exec
+UPDATE api_keys
+SET last_used_at = now()
+WHERE id = $1;
+
+-- name: DeleteApiKey :one
+DELETE
+FROM api_keys
+WHERE id = $1
+  AND employer_id = $2
+RETURNING *;
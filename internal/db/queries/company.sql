@@ -29,4 +29,13 @@ RETURNING *;
 -- name: DeleteCompany :exec
 DELETE
 FROM companies
+WHERE id = $1;
+
+-- name: ListCompanyLocations :many
+SELECT id, location
+FROM companies;
+
+-- name: UpdateCompanyLocation :exec
+UPDATE companies
+SET location = $2
 WHERE id = $1;
\ No newline at end of file
@@ -0,0 +1,14 @@
+-- name: CreateCompanyInvitation :one
+INSERT INTO company_invitations
+    (company_id, email, role, invited_by, secret_code)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: UseCompanyInvitation :one
+UPDATE company_invitations
+SET is_used = TRUE
+WHERE id = $1
+  AND secret_code = $2
+  AND is_used = FALSE
+  AND expired_at > now()
+RETURNING *;
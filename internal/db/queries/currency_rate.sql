@@ -0,0 +1,16 @@
+-- name: GetCurrencyRate :one
+SELECT *
+FROM currency_rates
+WHERE currency = $1;
+
+-- name: ListCurrencyRates :many
+SELECT *
+FROM currency_rates
+ORDER BY currency;
+
+-- name: UpsertCurrencyRate :one
+INSERT INTO currency_rates (currency, rate)
+VALUES ($1, $2)
+ON CONFLICT (currency) DO UPDATE SET rate       = EXCLUDED.rate,
+                                     updated_at = now()
+RETURNING *;
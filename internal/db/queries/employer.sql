@@ -1,6 +1,6 @@
 -- name: CreateEmployer :one
-INSERT INTO employers (company_id, full_name, email, hashed_password)
-VALUES ($1, $2, $3, $4)
+INSERT INTO employers (company_id, full_name, email, hashed_password, role)
+VALUES ($1, $2, $3, $4, $5)
 RETURNING *;
 
 -- name: GetEmployerByID :one
@@ -48,3 +48,16 @@ SELECT c.name      AS company_name,
 FROM employers e
          JOIN companies c ON c.id = e.company_id
 WHERE e.email = $1;
+
+-- name: ListCompanyEmployers :many
+SELECT *
+FROM employers
+WHERE company_id = $1
+ORDER BY id;
+
+-- name: UpdateEmployerRole :one
+UPDATE employers
+SET role = $3
+WHERE id = $1
+  AND company_id = $2
+RETURNING *;
@@ -6,8 +6,20 @@ INSERT INTO jobs (title,
                   location,
                   salary_min,
                   salary_max,
-                  requirements)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+                  requirements,
+                  status,
+                  published_at,
+                  closes_at,
+                  employment_type,
+                  seniority_level,
+                  remote_policy,
+                  salary_currency,
+                  salary_period,
+                  salary_min_annual,
+                  salary_max_annual,
+                  latitude,
+                  longitude)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
 RETURNING *;
 
 -- name: GetJob :one
@@ -16,6 +28,7 @@ FROM jobs
 WHERE id = $1;
 
 -- name: GetJobDetails :one
+-- the employer of the job is the first owner of the company (the company always has one while it has members)
 SELECT j.*,
        c.name      AS company_name,
        c.location  AS company_location,
@@ -25,7 +38,11 @@ SELECT j.*,
        e.full_name AS employer_full_name
 FROM jobs j
          JOIN companies c ON j.company_id = c.id
-         JOIN employers e ON c.id = e.company_id
+         JOIN LATERAL (SELECT *
+                       FROM employers
+                       WHERE company_id = c.id
+                       ORDER BY role, id
+                       LIMIT 1) e ON TRUE
 WHERE j.id = $1;
 
 -- name: ListJobsByTitle :many
@@ -52,6 +69,8 @@ SELECT j.*,
 FROM jobs j
          JOIN companies c ON j.company_id = c.id
 WHERE j.company_id = $1
+  AND j.status = 'published'
+  AND (j.closes_at IS NULL OR j.closes_at > now())
 LIMIT $2 OFFSET $3;
 
 -- name: ListJobsByCompanyExactName :many
@@ -60,6 +79,8 @@ SELECT j.*,
 FROM jobs j
          JOIN companies c ON j.company_id = c.id
 WHERE c.name = $1
+  AND j.status = 'published'
+  AND (j.closes_at IS NULL OR j.closes_at > now())
 LIMIT $2 OFFSET $3;
 
 -- name: ListJobsByCompanyName :many
@@ -68,6 +89,8 @@ SELECT j.*,
 FROM jobs j
          JOIN companies c ON j.company_id = c.id
 WHERE c.name ILIKE '%' || @name::text || '%'
+  AND j.status = 'published'
+  AND (j.closes_at IS NULL OR j.closes_at > now())
 LIMIT $1 OFFSET $2;
 
 -- name: ListJobsBySalaryRange :many
@@ -87,18 +110,40 @@ WHERE j.id IN (SELECT job_id
                WHERE skill IN (SELECT skill
                                FROM user_skills
                                WHERE user_id = $1))
+  AND j.salary_max_annual >= (SELECT desired_salary_min FROM users WHERE id = $1)
+  AND j.salary_min_annual <= (SELECT desired_salary_max FROM users WHERE id = $1)
+  AND j.status = 'published'
+  AND (j.closes_at IS NULL OR j.closes_at > now())
 LIMIT $2 OFFSET $3;
 
 -- name: UpdateJob :one
 UPDATE jobs
-SET title        = $2,
-    industry     = $3,
-    company_id   = $4,
-    description  = $5,
-    location     = $6,
-    salary_min   = $7,
-    salary_max   = $8,
-    requirements = $9
+SET title             = $2,
+    industry          = $3,
+    company_id        = $4,
+    description       = $5,
+    location          = $6,
+    salary_min        = $7,
+    salary_max        = $8,
+    requirements      = $9,
+    closes_at         = $10,
+    employment_type   = $11,
+    seniority_level   = $12,
+    remote_policy     = $13,
+    salary_currency   = $14,
+    salary_period     = $15,
+    salary_min_annual = $16,
+    salary_max_annual = $17,
+    latitude          = $18,
+    longitude         = $19
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateJobStatus :one
+UPDATE jobs
+SET status       = $2,
+    published_at = CASE WHEN $2 = 'published'::job_status THEN COALESCE(published_at, now()) ELSE published_at END,
+    closes_at    = COALESCE(sqlc.narg(closes_at), closes_at)
 WHERE id = $1
 RETURNING *;
 
@@ -116,9 +161,46 @@ SELECT j.id,
        c.name AS company_name,
        j.salary_min,
        j.salary_max,
-       j.requirements
+       j.requirements,
+       j.employment_type,
+       j.seniority_level,
+       j.remote_policy,
+       j.salary_currency,
+       j.salary_period,
+       j.salary_min_annual,
+       j.salary_max_annual,
+       j.latitude,
+       j.longitude
 FROM jobs j
-         JOIN companies c ON j.company_id = c.id;
+         JOIN companies c ON j.company_id = c.id
+WHERE j.status = 'published'
+  AND (j.closes_at IS NULL OR j.closes_at > now());
+
+-- name: GetJobForES :one
+-- the job is returned only if it should be in the elasticsearch index
+SELECT j.id,
+       j.title,
+       j.industry,
+       j.location,
+       j.description,
+       c.name AS company_name,
+       j.salary_min,
+       j.salary_max,
+       j.requirements,
+       j.employment_type,
+       j.seniority_level,
+       j.remote_policy,
+       j.salary_currency,
+       j.salary_period,
+       j.salary_min_annual,
+       j.salary_max_annual,
+       j.latitude,
+       j.longitude
+FROM jobs j
+         JOIN companies c ON j.company_id = c.id
+WHERE j.id = $1
+  AND j.status = 'published'
+  AND (j.closes_at IS NULL OR j.closes_at > now());
 
 -- name: GetCompanyIDOfJob :one
 SELECT company_id
@@ -133,7 +215,17 @@ SELECT id,
        location,
        salary_min,
        salary_max,
-       created_at
+       created_at,
+       status,
+       published_at,
+       closes_at,
+       employment_type,
+       seniority_level,
+       remote_policy,
+       salary_currency,
+       salary_period,
+       salary_min_annual,
+       salary_max_annual
 FROM jobs
 WHERE company_id = $1
 ORDER BY CASE WHEN @created_at_asc::bool THEN created_at END ASC,
@@ -147,4 +239,136 @@ SELECT
     c.name AS company_name
 FROM jobs j
          JOIN companies c ON j.company_id = c.id
-WHERE j.id = $1;
\ No newline at end of file
+WHERE j.id = $1;
+
+-- name: ExpireJobs :many
+UPDATE jobs
+SET status = 'expired'
+WHERE status = 'published'
+  AND closes_at <= now()
+RETURNING id;
+
+-- name: ListPublishedJobIDs :many
+SELECT id
+FROM jobs
+WHERE id = ANY (@ids::int[])
+  AND status = 'published';
+
+-- name: ListJobsClosingSoon :many
+SELECT j.id,
+       j.title,
+       j.company_id,
+       j.closes_at,
+       c.name AS company_name
+FROM jobs j
+         JOIN companies c ON j.company_id = c.id
+WHERE j.status = 'published'
+  AND j.closes_at > now()
+  AND j.closes_at <= @closes_before::timestamptz
+  AND NOT EXISTS (SELECT 1
+                  FROM job_expiry_reminders r
+                  WHERE r.job_id = j.id
+                    AND r.closes_at = j.closes_at);
+
+-- name: ListJobSalariesByCurrency :many
+SELECT id,
+       salary_min,
+       salary_max,
+       salary_period,
+       status
+FROM jobs
+WHERE salary_currency = $1;
+
+-- name: ListJobSuggestions :many
+-- the titles, skills and company names of the published jobs in which every prefix word is a prefix
+-- of a word, case-insensitively, up to size values of every type with the most jobs
+WITH published AS (SELECT j.id, j.title, c.name AS company_name
+                   FROM jobs j
+                            JOIN companies c ON j.company_id = c.id
+                   WHERE j.status = 'published'
+                     AND (j.closes_at IS NULL OR j.closes_at > now())),
+     candidates AS (SELECT 1 AS type_order, 'title' AS type, title AS value, COUNT(*) AS count
+                    FROM published
+                    GROUP BY title
+                    UNION ALL
+                    SELECT 2, 'skill', s.skill, COUNT(DISTINCT s.job_id)
+                    FROM published p
+                             JOIN job_skills s ON s.job_id = p.id
+                    GROUP BY s.skill
+                    UNION ALL
+                    SELECT 3, 'company', company_name, COUNT(*)
+                    FROM published
+                    GROUP BY company_name),
+     matched AS (SELECT type_order,
+                        type,
+                        value,
+                        count,
+                        row_number() OVER (PARTITION BY type_order ORDER BY count DESC, value) AS position
+                 FROM candidates
+                 WHERE NOT EXISTS (SELECT 1
+                                   FROM unnest(@prefix_words::text[]) prefix(word)
+                                   WHERE NOT EXISTS (SELECT 1
+                                                     FROM regexp_split_to_table(lower(value), '\s+') value_word(word)
+                                                     WHERE starts_with(value_word.word, prefix.word))))
+SELECT type::text AS type, value::text AS value, count::bigint AS count
+FROM matched
+WHERE position <= @size::int
+ORDER BY type_order, position;
+
+-- name: ListJobsByTextSimilarity :many
+-- the published jobs that share the words (lexemes of the search vectors) with the job,
+-- the score is the Jaccard index of the words of both jobs
+WITH source AS (SELECT tsvector_to_array(search_vector) AS words
+                FROM jobs
+                WHERE jobs.id = @job_id),
+     candidates AS (SELECT j.id,
+                           tsvector_to_array(j.search_vector) AS words
+                    FROM jobs j,
+                         source
+                    WHERE tsvector_to_array(j.search_vector) && source.words
+                      AND j.id <> @job_id
+                      AND j.status = 'published'
+                      AND (j.closes_at IS NULL OR j.closes_at > now())),
+     scored AS (SELECT candidates.id,
+                       (SELECT COUNT(*) FROM unnest(candidates.words) word WHERE word = ANY (source.words))::float8 /
+                       cardinality(ARRAY(SELECT DISTINCT unnest(candidates.words || source.words)))::float8 AS score
+                FROM candidates,
+                     source)
+SELECT j.id,
+       j.title,
+       j.industry,
+       j.location,
+       j.salary_min,
+       j.salary_max,
+       j.salary_currency,
+       j.salary_period,
+       j.employment_type,
+       j.seniority_level,
+       j.remote_policy,
+       c.name                                                                                  AS company_name,
+       ARRAY(SELECT s.skill FROM job_skills s WHERE s.job_id = j.id ORDER BY s.skill)::text[] AS skills,
+       scored.score::float8                                                                    AS score
+FROM scored
+         JOIN jobs j ON scored.id = j.id
+         JOIN companies c ON j.company_id = c.id
+ORDER BY scored.score DESC, j.id
+LIMIT @size;
+
+-- name: UpdateJobAnnualSalary :exec
+UPDATE jobs
+SET salary_min_annual = $2,
+    salary_max_annual = $3
+WHERE id = $1;
+
+-- name: ListJobLocations :many
+SELECT id,
+       location
+FROM jobs
+WHERE latitude IS NULL;
+
+-- name: UpdateJobLocation :exec
+UPDATE jobs
+SET location  = $2,
+    latitude  = $3,
+    longitude = $4
+WHERE id = $1;
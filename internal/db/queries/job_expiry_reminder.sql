@@ -0,0 +1,3 @@
+-- name: CreateJobExpiryReminder :exec
+INSERT INTO job_expiry_reminders (job_id, closes_at)
+VALUES ($1, $2);
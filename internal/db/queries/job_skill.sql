@@ -20,6 +20,32 @@ FROM job_skills
 WHERE skill = $1
 LIMIT $2 OFFSET $3;
 
+-- name: ListJobsBySkillOverlap :many
+SELECT j.id,
+       j.title,
+       j.industry,
+       j.location,
+       j.salary_min,
+       j.salary_max,
+       j.salary_currency,
+       j.salary_period,
+       j.employment_type,
+       j.seniority_level,
+       j.remote_policy,
+       c.name                                                      AS company_name,
+       array_agg(s.skill ORDER BY s.skill)::text[]                 AS shared_skills,
+       (SELECT COUNT(*) FROM job_skills js WHERE js.job_id = j.id) AS skills_count
+FROM job_skills s
+         JOIN jobs j ON s.job_id = j.id
+         JOIN companies c ON j.company_id = c.id
+WHERE s.skill IN (SELECT skill FROM job_skills WHERE job_skills.job_id = @job_id)
+  AND j.id <> @job_id
+  AND j.status = 'published'
+  AND (j.closes_at IS NULL OR j.closes_at > now())
+GROUP BY j.id, c.name
+ORDER BY COUNT(*) DESC, j.id
+LIMIT @size;
+
 -- name: UpdateJobSkill :one
 UPDATE job_skills
 SET skill = $2
@@ -0,0 +1,27 @@
+-- name: ListLoginAttempts :many
+SELECT *
+FROM login_attempts
+WHERE subject = ANY (@subjects::varchar[]);
+
+-- name: RecordFailedLoginAttempt :one
+INSERT INTO login_attempts
+    (subject, failed_attempts, last_failed_at)
+VALUES (@subject, 1, now())
+ON CONFLICT (subject) DO UPDATE
+    SET failed_attempts = CASE
+                              WHEN login_attempts.last_failed_at < @reset_before THEN 1
+                              ELSE login_attempts.failed_attempts + 1
+        END,
+        last_failed_at  = now()
+RETURNING *;
+
+-- name: LockLoginAttempt :one
+UPDATE login_attempts
+SET locked_until = $2
+WHERE subject = $1
+RETURNING *;
+
+-- name: DeleteLoginAttempt :exec
+DELETE
+FROM login_attempts
+WHERE subject = $1;
@@ -0,0 +1,54 @@
+-- name: UpsertMfaSetting :one
+INSERT INTO mfa_settings
+    (account_type, account_id, secret)
+VALUES ($1, $2, $3)
+ON CONFLICT (account_type, account_id) DO UPDATE
+    SET secret     = EXCLUDED.secret,
+        is_enabled = FALSE,
+        created_at = now()
+RETURNING *;
+
+-- name: GetMfaSetting :one
+SELECT *
+FROM mfa_settings
+WHERE account_type = $1
+  AND account_id = $2;
+
+-- name: EnableMfaSetting :one
+UPDATE mfa_settings
+SET is_enabled = TRUE
+WHERE account_type = $1
+  AND account_id = $2
+RETURNING *;
+
+-- name: DeleteMfaSetting :exec
+DELETE
+FROM mfa_settings
+WHERE account_type = $1
+  AND account_id = $2;
+
+-- name: CreateMfaRecoveryCode :one
+INSERT INTO mfa_recovery_codes
+    (account_type, account_id, hashed_code)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: ListUnusedMfaRecoveryCodes :many
+SELECT *
+FROM mfa_recovery_codes
+WHERE account_type = $1
+  AND account_id = $2
+  AND is_used = FALSE;
+
+-- name: UseMfaRecoveryCode :one
+UPDATE mfa_recovery_codes
+SET is_used = TRUE
+WHERE id = $1
+  AND is_used = FALSE
+RETURNING *;
+
+-- name: DeleteMfaRecoveryCodes :exec
+DELETE
+FROM mfa_recovery_codes
+WHERE account_type = $1
+  AND account_id = $2;
@@ -0,0 +1,24 @@
+-- name: CreateUserIdentity :one
+INSERT INTO user_identities
+    (provider, subject, user_id, email)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetUserIdentity :one
+SELECT *
+FROM user_identities
+WHERE provider = $1
+  AND subject = $2;
+
+-- name: CreateOidcState :one
+INSERT INTO oidc_states
+    (state, code_verifier, nonce)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: DeleteOidcState :one
+DELETE
+FROM oidc_states
+WHERE state = $1
+  AND expired_at > now()
+RETURNING *;
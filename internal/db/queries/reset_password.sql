@@ -0,0 +1,28 @@
+-- name: CreateResetPassword :one
+-- a new code of the email replaces the previous one of the same account type
+INSERT INTO reset_passwords
+    (email, account_type, secret_code)
+VALUES ($1, $2, $3)
+ON CONFLICT (account_type, email) DO UPDATE
+    SET secret_code = excluded.secret_code,
+        is_used     = FALSE,
+        created_at  = now(),
+        expired_at  = now() + interval '30 minutes'
+RETURNING *;
+
+-- name: UpdateResetPassword :one
+-- the code can be used only for the account type that requested it
+UPDATE reset_passwords
+SET is_used = TRUE
+WHERE id = $1
+  AND secret_code = $2
+  AND account_type = $3
+  AND is_used = FALSE
+  AND expired_at > now()
+RETURNING *;
+
+-- name: DeleteResetPassword :exec
+DELETE
+FROM reset_passwords
+WHERE email = $1
+  AND account_type = $2;
@@ -0,0 +1,18 @@
+-- name: RevokeToken :exec
+INSERT INTO revoked_tokens (id, email, expires_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (id) DO NOTHING;
+
+-- name: RevokeAllTokensByEmail :exec
+INSERT INTO email_token_revocations (email, revoked_at)
+VALUES ($1, now())
+ON CONFLICT (email) DO UPDATE SET revoked_at = now();
+
+-- name: IsTokenRevoked :one
+SELECT (EXISTS(SELECT 1
+               FROM revoked_tokens
+               WHERE revoked_tokens.id = sqlc.arg(id))
+    OR EXISTS(SELECT 1
+              FROM email_token_revocations
+              WHERE email_token_revocations.email = sqlc.arg(email)
+                AND email_token_revocations.revoked_at >= sqlc.arg(issued_at)))::bool AS is_revoked;
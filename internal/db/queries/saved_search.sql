@@ -0,0 +1,109 @@
+-- name: CreateSavedSearch :one
+INSERT INTO saved_searches
+(user_id, name, query, title, industry, job_location, salary_min, salary_max, employment_type, seniority_level,
+ remote_policy, salary_currency, salary_period, near, near_latitude, near_longitude, radius_km, frequency,
+ unsubscribe_token)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+RETURNING *;
+
+-- name: GetSavedSearch :one
+SELECT *
+FROM saved_searches
+WHERE id = $1
+  AND user_id = $2;
+
+-- name: ListSavedSearchesByUserID :many
+SELECT *
+FROM saved_searches
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: UpdateSavedSearch :one
+-- turning the digests on again does not send the jobs published while they were off
+UPDATE saved_searches
+SET name            = $3,
+    query           = $4,
+    title           = $5,
+    industry        = $6,
+    job_location    = $7,
+    salary_min      = $8,
+    salary_max      = $9,
+    employment_type = $10,
+    seniority_level = $11,
+    remote_policy   = $12,
+    salary_currency = $13,
+    salary_period   = $14,
+    near            = $15,
+    near_latitude   = $16,
+    near_longitude  = $17,
+    radius_km       = $18,
+    frequency       = $19,
+    last_run_at     = CASE WHEN frequency = 'never' THEN now() ELSE last_run_at END
+WHERE id = $1
+  AND user_id = $2
+RETURNING *;
+
+-- name: UnsubscribeSavedSearch :one
+UPDATE saved_searches
+SET frequency = 'never'
+WHERE unsubscribe_token = $1
+RETURNING *;
+
+-- name: DeleteSavedSearch :one
+DELETE
+FROM saved_searches
+WHERE id = $1
+  AND user_id = $2
+RETURNING *;
+
+-- name: ListDueSavedSearches :many
+-- the searches of the users whose emails are not verified get no digests.
+-- The skipped searches failed in the current run, they stay due until the next one.
+SELECT s.*, u.email, u.full_name
+FROM saved_searches s
+         JOIN users u ON s.user_id = u.id
+WHERE ((s.frequency = 'daily' AND s.last_run_at <= @run_at::timestamptz - interval '1 day')
+    OR (s.frequency = 'weekly' AND s.last_run_at <= @run_at::timestamptz - interval '7 days'))
+  AND u.is_email_verified
+  AND s.id <> ALL (@skipped_ids::bigint[])
+ORDER BY s.last_run_at, s.id
+LIMIT @size;
+
+-- name: ListNewJobsForSavedSearch :many
+-- the published jobs matching the saved search, that were published after its last run and until run_at.
+-- The query is matched with the full-text search of postgres (whatever the search provider is), the filters just like in ListJobsByFilters.
+SELECT j.id, j.title, j.industry, j.location, j.salary_min, j.salary_max, j.salary_currency, j.salary_period,
+       j.employment_type, j.remote_policy, j.published_at,
+       c.name AS company_name
+FROM saved_searches s
+         JOIN jobs j ON j.published_at > s.last_run_at AND j.published_at <= @run_at::timestamptz
+         JOIN companies c ON j.company_id = c.id
+WHERE s.id = @id
+  AND (s.query = '' OR j.search_vector @@ websearch_to_tsquery('english', s.query))
+  AND (s.title IS NULL OR j.title ILIKE '%' || s.title || '%')
+  AND (s.job_location IS NULL OR j.location = s.job_location)
+  AND (s.industry IS NULL OR j.industry = s.industry)
+  AND (s.salary_min IS NULL OR j.salary_min_annual >= s.salary_min)
+  AND (s.salary_max IS NULL OR j.salary_max_annual <= s.salary_max)
+  AND (s.employment_type IS NULL OR j.employment_type = s.employment_type)
+  AND (s.seniority_level IS NULL OR j.seniority_level = s.seniority_level)
+  AND (s.remote_policy IS NULL OR j.remote_policy = s.remote_policy)
+  AND (s.salary_currency IS NULL OR j.salary_currency = s.salary_currency)
+  AND (s.salary_period IS NULL OR j.salary_period = s.salary_period)
+  AND (s.near_latitude IS NULL OR (
+        j.latitude BETWEEN s.near_latitude - s.radius_km / 111.0 AND s.near_latitude + s.radius_km / 111.0
+        AND 2 * 6371 * asin(sqrt(
+                power(sin(radians(j.latitude - s.near_latitude) / 2), 2) +
+                cos(radians(s.near_latitude)) * cos(radians(j.latitude)) *
+                power(sin(radians(j.longitude - s.near_longitude) / 2), 2)
+            )) <= s.radius_km
+    ))
+  AND j.status = 'published'
+  AND (j.closes_at IS NULL OR j.closes_at > now())
+ORDER BY j.published_at DESC, j.id
+LIMIT @size;
+
+-- name: UpdateSavedSearchLastRunAt :exec
+UPDATE saved_searches
+SET last_run_at = $2
+WHERE id = $1;
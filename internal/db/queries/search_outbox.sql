@@ -0,0 +1,32 @@
+-- name: CreateSearchOutboxEntry :exec
+INSERT INTO search_outbox (job_id)
+VALUES ($1);
+
+-- name: CreateSearchOutboxEntriesForCompany :exec
+INSERT INTO search_outbox (job_id)
+SELECT id
+FROM jobs
+WHERE company_id = $1;
+
+-- name: ListPendingSearchOutboxEntries :many
+SELECT *
+FROM search_outbox
+WHERE processed_at IS NULL
+ORDER BY id
+LIMIT $1;
+
+-- name: MarkSearchOutboxEntriesProcessed :exec
+UPDATE search_outbox
+SET processed_at = now()
+WHERE id = ANY (@ids::bigint[]);
+
+-- name: ReplaySearchOutboxEntries :execrows
+UPDATE search_outbox
+SET processed_at = NULL
+WHERE created_at >= $1
+  AND processed_at IS NOT NULL;
+
+-- name: DeleteProcessedSearchOutboxEntries :execrows
+DELETE
+FROM search_outbox
+WHERE processed_at < @processed_before::timestamptz;
@@ -0,0 +1,35 @@
+-- name: CreateSession :one
+INSERT INTO sessions (id,
+                      email,
+                      refresh_token,
+                      user_agent,
+                      client_ip,
+                      is_blocked,
+                      expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetSession :one
+SELECT *
+FROM sessions
+WHERE id = $1;
+
+-- name: ListSessionsByEmail :many
+SELECT *
+FROM sessions
+WHERE email = $1
+  AND expires_at > now()
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: BlockSession :one
+UPDATE sessions
+SET is_blocked = TRUE
+WHERE id = $1
+  AND email = $2
+RETURNING *;
+
+-- name: BlockAllSessionsByEmail :exec
+UPDATE sessions
+SET is_blocked = TRUE
+WHERE email = $1;
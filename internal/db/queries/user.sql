@@ -42,4 +42,13 @@ RETURNING *;
 -- name: DeleteUser :exec
 DELETE
 FROM users
+WHERE id = $1;
+
+-- name: ListUserLocations :many
+SELECT id, location
+FROM users;
+
+-- name: UpdateUserLocation :exec
+UPDATE users
+SET location = $2
 WHERE id = $1;
\ No newline at end of file
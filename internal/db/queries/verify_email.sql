@@ -18,3 +18,8 @@ DELETE
 FROM verify_emails
 WHERE email = $1;
 
+-- name: DeleteStaleVerifyEmails :execrows
+DELETE
+FROM verify_emails
+WHERE is_used = TRUE
+   OR expired_at <= now();
@@ -0,0 +1,105 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func createRandomApiKey(t *testing.T, employerID int32, scopes []string) ApiKey {
+	params := CreateApiKeyParams{
+		EmployerID: employerID,
+		Name:       utils.RandomString(8),
+		Prefix:     utils.RandomString(8),
+		HashedKey:  utils.RandomString(32),
+		Scopes:     scopes,
+	}
+
+	apiKey, err := testQueries.CreateApiKey(context.Background(), params)
+	require.NoError(t, err)
+	require.NotZero(t, apiKey.ID)
+	require.Equal(t, params.EmployerID, apiKey.EmployerID)
+	require.Equal(t, params.Name, apiKey.Name)
+	require.Equal(t, params.Prefix, apiKey.Prefix)
+	require.Equal(t, params.HashedKey, apiKey.HashedKey)
+	require.ElementsMatch(t, params.Scopes, apiKey.Scopes)
+	require.False(t, apiKey.LastUsedAt.Valid)
+	require.NotZero(t, apiKey.CreatedAt)
+
+	return apiKey
+}
+
+func TestQueries_CreateApiKey(t *testing.T) {
+	employer := createRandomEmployer(t, 0)
+	apiKey := createRandomApiKey(t, employer.ID, []string{"jobs:write", "applications:read"})
+
+	// names are unique per employer
+	_, err := testQueries.CreateApiKey(context.Background(), CreateApiKeyParams{
+		EmployerID: employer.ID,
+		Name:       apiKey.Name,
+		Prefix:     utils.RandomString(8),
+		HashedKey:  utils.RandomString(32),
+		Scopes:     []string{},
+	})
+	require.Error(t, err)
+}
+
+func TestQueries_GetApiKeyByPrefix(t *testing.T) {
+	employer := createRandomEmployer(t, 0)
+	apiKey := createRandomApiKey(t, employer.ID, []string{"jobs:write"})
+
+	apiKey2, err := testQueries.GetApiKeyByPrefix(context.Background(), apiKey.Prefix)
+	require.NoError(t, err)
+	require.Equal(t, apiKey, apiKey2)
+}
+
+func TestQueries_ListApiKeysByEmployerID(t *testing.T) {
+	employer := createRandomEmployer(t, 0)
+	for i := 0; i < 3; i++ {
+		createRandomApiKey(t, employer.ID, []string{})
+	}
+	createRandomApiKey(t, createRandomEmployer(t, 0).ID, []string{})
+
+	apiKeys, err := testQueries.ListApiKeysByEmployerID(context.Background(), employer.ID)
+	require.NoError(t, err)
+	require.Len(t, apiKeys, 3)
+	for _, apiKey := range apiKeys {
+		require.Equal(t, employer.ID, apiKey.EmployerID)
+	}
+}
+
+func TestQueries_UpdateApiKeyLastUsedAt(t *testing.T) {
+	employer := createRandomEmployer(t, 0)
+	apiKey := createRandomApiKey(t, employer.ID, []string{})
+
+	err := testQueries.UpdateApiKeyLastUsedAt(context.Background(), apiKey.ID)
+	require.NoError(t, err)
+
+	apiKey2, err := testQueries.GetApiKeyByPrefix(context.Background(), apiKey.Prefix)
+	require.NoError(t, err)
+	require.True(t, apiKey2.LastUsedAt.Valid)
+}
+
+func TestQueries_DeleteApiKey(t *testing.T) {
+	employer := createRandomEmployer(t, 0)
+	apiKey := createRandomApiKey(t, employer.ID, []string{})
+
+	// only the owner can delete the key
+	_, err := testQueries.DeleteApiKey(context.Background(), DeleteApiKeyParams{
+		ID:         apiKey.ID,
+		EmployerID: employer.ID + 1,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	apiKey2, err := testQueries.DeleteApiKey(context.Background(), DeleteApiKeyParams{
+		ID:         apiKey.ID,
+		EmployerID: employer.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, apiKey.ID, apiKey2.ID)
+
+	_, err = testQueries.GetApiKeyByPrefix(context.Background(), apiKey.Prefix)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -93,6 +93,39 @@ func (q *Queries) GetCompanyNameByID(ctx context.Context, id int32) (string, err
 	return name, err
 }
 
+const listCompanyLocations = `-- name: ListCompanyLocations :many
+SELECT id, location
+FROM companies
+`
+
+type ListCompanyLocationsRow struct {
+	ID       int32  `json:"id"`
+	Location string `json:"location"`
+}
+
+func (q *Queries) ListCompanyLocations(ctx context.Context) ([]ListCompanyLocationsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCompanyLocations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListCompanyLocationsRow{}
+	for rows.Next() {
+		var i ListCompanyLocationsRow
+		if err := rows.Scan(&i.ID, &i.Location); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateCompany = `-- name: UpdateCompany :one
 UPDATE companies
 SET name     = $2,
@@ -125,3 +158,19 @@ func (q *Queries) UpdateCompany(ctx context.Context, arg UpdateCompanyParams) (C
 	)
 	return i, err
 }
+
+const updateCompanyLocation = `-- name: UpdateCompanyLocation :exec
+UPDATE companies
+SET location = $2
+WHERE id = $1
+`
+
+type UpdateCompanyLocationParams struct {
+	ID       int32  `json:"id"`
+	Location string `json:"location"`
+}
+
+func (q *Queries) UpdateCompanyLocation(ctx context.Context, arg UpdateCompanyLocationParams) error {
+	_, err := q.db.ExecContext(ctx, updateCompanyLocation, arg.ID, arg.Location)
+	return err
+}
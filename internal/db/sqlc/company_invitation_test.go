@@ -0,0 +1,60 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func createRandomCompanyInvitation(t *testing.T, inviter Employer) CompanyInvitation {
+	params := CreateCompanyInvitationParams{
+		CompanyID:  inviter.CompanyID,
+		Email:      utils.RandomEmail(),
+		Role:       CompanyRoleRecruiter,
+		InvitedBy:  inviter.ID,
+		SecretCode: utils.RandomString(32),
+	}
+
+	invitation, err := testQueries.CreateCompanyInvitation(context.Background(), params)
+	require.NoError(t, err)
+	require.Equal(t, params.CompanyID, invitation.CompanyID)
+	require.Equal(t, params.Email, invitation.Email)
+	require.Equal(t, params.Role, invitation.Role)
+	require.Equal(t, params.InvitedBy, invitation.InvitedBy)
+	require.Equal(t, params.SecretCode, invitation.SecretCode)
+	require.False(t, invitation.IsUsed)
+	require.NotZero(t, invitation.ID)
+	require.True(t, invitation.ExpiredAt.After(invitation.CreatedAt))
+
+	return invitation
+}
+
+func TestQueries_CreateCompanyInvitation(t *testing.T) {
+	createRandomCompanyInvitation(t, createRandomEmployer(t, 0))
+}
+
+func TestQueries_UseCompanyInvitation(t *testing.T) {
+	invitation := createRandomCompanyInvitation(t, createRandomEmployer(t, 0))
+
+	// wrong secret code
+	_, err := testQueries.UseCompanyInvitation(context.Background(), UseCompanyInvitationParams{
+		ID:         invitation.ID,
+		SecretCode: utils.RandomString(32),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	params := UseCompanyInvitationParams{
+		ID:         invitation.ID,
+		SecretCode: invitation.SecretCode,
+	}
+	usedInvitation, err := testQueries.UseCompanyInvitation(context.Background(), params)
+	require.NoError(t, err)
+	require.Equal(t, invitation.ID, usedInvitation.ID)
+	require.True(t, usedInvitation.IsUsed)
+
+	// the invitation can be used only once
+	_, err = testQueries.UseCompanyInvitation(context.Background(), params)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
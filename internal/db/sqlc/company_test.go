@@ -110,3 +110,35 @@ func TestQueries_DeleteCompany(t *testing.T) {
 	require.EqualError(t, err, sql.ErrNoRows.Error())
 	require.Empty(t, company2)
 }
+
+func TestQueries_ListCompanyLocations(t *testing.T) {
+	company := createRandomCompany(t, "")
+
+	companies, err := testQueries.ListCompanyLocations(context.Background())
+	require.NoError(t, err)
+
+	var found bool
+	for _, c := range companies {
+		if c.ID == company.ID {
+			found = true
+			require.Equal(t, company.Location, c.Location)
+		}
+	}
+	require.True(t, found)
+}
+
+func TestQueries_UpdateCompanyLocation(t *testing.T) {
+	company := createRandomCompany(t, "")
+
+	params := UpdateCompanyLocationParams{
+		ID:       company.ID,
+		Location: "Berlin, Germany",
+	}
+	err := testQueries.UpdateCompanyLocation(context.Background(), params)
+	require.NoError(t, err)
+
+	company2, err := testQueries.GetCompanyByID(context.Background(), company.ID)
+	require.NoError(t, err)
+	require.Equal(t, params.Location, company2.Location)
+	require.Equal(t, company.Name, company2.Name)
+}
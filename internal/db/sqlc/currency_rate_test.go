@@ -0,0 +1,79 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+// upsertRandomCurrencyRate creates or updates the rate of a random currency
+func upsertRandomCurrencyRate(t *testing.T) CurrencyRate {
+	params := UpsertCurrencyRateParams{
+		Currency: strings.ToUpper(utils.RandomString(3)),
+		Rate:     float64(utils.RandomInt(1, 100)) / 10,
+	}
+
+	rate, err := testQueries.UpsertCurrencyRate(context.Background(), params)
+	require.NoError(t, err)
+	require.Equal(t, params.Currency, rate.Currency)
+	require.Equal(t, params.Rate, rate.Rate)
+	require.WithinDuration(t, time.Now(), rate.UpdatedAt, 2*time.Second)
+
+	return rate
+}
+
+func TestQueries_UpsertCurrencyRate(t *testing.T) {
+	rate := upsertRandomCurrencyRate(t)
+
+	// updates the rate of the existing currency
+	params := UpsertCurrencyRateParams{
+		Currency: rate.Currency,
+		Rate:     rate.Rate + 1,
+	}
+	rate2, err := testQueries.UpsertCurrencyRate(context.Background(), params)
+	require.NoError(t, err)
+	require.Equal(t, rate.Currency, rate2.Currency)
+	require.Equal(t, params.Rate, rate2.Rate)
+	require.False(t, rate2.UpdatedAt.Before(rate.UpdatedAt))
+}
+
+func TestQueries_GetCurrencyRate(t *testing.T) {
+	rate := upsertRandomCurrencyRate(t)
+
+	rate2, err := testQueries.GetCurrencyRate(context.Background(), rate.Currency)
+	require.NoError(t, err)
+	require.Equal(t, rate.Currency, rate2.Currency)
+	require.Equal(t, rate.Rate, rate2.Rate)
+	require.WithinDuration(t, rate.UpdatedAt, rate2.UpdatedAt, time.Second)
+
+	// the base currency is seeded by the migration
+	usd, err := testQueries.GetCurrencyRate(context.Background(), "USD")
+	require.NoError(t, err)
+	require.Equal(t, float64(1), usd.Rate)
+
+	_, err = testQueries.GetCurrencyRate(context.Background(), "123")
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestQueries_ListCurrencyRates(t *testing.T) {
+	rate := upsertRandomCurrencyRate(t)
+
+	rates, err := testQueries.ListCurrencyRates(context.Background())
+	require.NoError(t, err)
+	require.NotEmpty(t, rates)
+
+	var found bool
+	for i, r := range rates {
+		if i > 0 {
+			require.True(t, rates[i-1].Currency < r.Currency)
+		}
+		if r.Currency == rate.Currency {
+			found = true
+		}
+	}
+	require.True(t, found)
+}
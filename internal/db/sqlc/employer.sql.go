@@ -10,16 +10,17 @@ import (
 )
 
 const createEmployer = `-- name: CreateEmployer :one
-INSERT INTO employers (company_id, full_name, email, hashed_password)
-VALUES ($1, $2, $3, $4)
-RETURNING id, company_id, full_name, email, hashed_password, created_at, is_email_verified
+INSERT INTO employers (company_id, full_name, email, hashed_password, role)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, company_id, full_name, email, hashed_password, created_at, is_email_verified, role
 `
 
 type CreateEmployerParams struct {
-	CompanyID      int32  `json:"company_id"`
-	FullName       string `json:"full_name"`
-	Email          string `json:"email"`
-	HashedPassword string `json:"hashed_password"`
+	CompanyID      int32       `json:"company_id"`
+	FullName       string      `json:"full_name"`
+	Email          string      `json:"email"`
+	HashedPassword string      `json:"hashed_password"`
+	Role           CompanyRole `json:"role"`
 }
 
 func (q *Queries) CreateEmployer(ctx context.Context, arg CreateEmployerParams) (Employer, error) {
@@ -28,6 +29,7 @@ func (q *Queries) CreateEmployer(ctx context.Context, arg CreateEmployerParams)
 		arg.FullName,
 		arg.Email,
 		arg.HashedPassword,
+		arg.Role,
 	)
 	var i Employer
 	err := row.Scan(
@@ -38,6 +40,7 @@ func (q *Queries) CreateEmployer(ctx context.Context, arg CreateEmployerParams)
 		&i.HashedPassword,
 		&i.CreatedAt,
 		&i.IsEmailVerified,
+		&i.Role,
 	)
 	return i, err
 }
@@ -92,7 +95,7 @@ func (q *Queries) GetEmployerAndCompanyDetails(ctx context.Context, email string
 }
 
 const getEmployerByEmail = `-- name: GetEmployerByEmail :one
-SELECT id, company_id, full_name, email, hashed_password, created_at, is_email_verified
+SELECT id, company_id, full_name, email, hashed_password, created_at, is_email_verified, role
 FROM employers
 WHERE email = $1
 `
@@ -108,12 +111,13 @@ func (q *Queries) GetEmployerByEmail(ctx context.Context, email string) (Employe
 		&i.HashedPassword,
 		&i.CreatedAt,
 		&i.IsEmailVerified,
+		&i.Role,
 	)
 	return i, err
 }
 
 const getEmployerByID = `-- name: GetEmployerByID :one
-SELECT id, company_id, full_name, email, hashed_password, created_at, is_email_verified
+SELECT id, company_id, full_name, email, hashed_password, created_at, is_email_verified, role
 FROM employers
 WHERE id = $1
 `
@@ -129,17 +133,57 @@ func (q *Queries) GetEmployerByID(ctx context.Context, id int32) (Employer, erro
 		&i.HashedPassword,
 		&i.CreatedAt,
 		&i.IsEmailVerified,
+		&i.Role,
 	)
 	return i, err
 }
 
+const listCompanyEmployers = `-- name: ListCompanyEmployers :many
+SELECT id, company_id, full_name, email, hashed_password, created_at, is_email_verified, role
+FROM employers
+WHERE company_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListCompanyEmployers(ctx context.Context, companyID int32) ([]Employer, error) {
+	rows, err := q.db.QueryContext(ctx, listCompanyEmployers, companyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Employer{}
+	for rows.Next() {
+		var i Employer
+		if err := rows.Scan(
+			&i.ID,
+			&i.CompanyID,
+			&i.FullName,
+			&i.Email,
+			&i.HashedPassword,
+			&i.CreatedAt,
+			&i.IsEmailVerified,
+			&i.Role,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateEmployer = `-- name: UpdateEmployer :one
 UPDATE employers
 SET company_id = $2,
     full_name  = $3,
     email      = $4
 WHERE id = $1
-RETURNING id, company_id, full_name, email, hashed_password, created_at, is_email_verified
+RETURNING id, company_id, full_name, email, hashed_password, created_at, is_email_verified, role
 `
 
 type UpdateEmployerParams struct {
@@ -165,6 +209,7 @@ func (q *Queries) UpdateEmployer(ctx context.Context, arg UpdateEmployerParams)
 		&i.HashedPassword,
 		&i.CreatedAt,
 		&i.IsEmailVerified,
+		&i.Role,
 	)
 	return i, err
 }
@@ -185,11 +230,41 @@ func (q *Queries) UpdateEmployerPassword(ctx context.Context, arg UpdateEmployer
 	return err
 }
 
+const updateEmployerRole = `-- name: UpdateEmployerRole :one
+UPDATE employers
+SET role = $3
+WHERE id = $1
+  AND company_id = $2
+RETURNING id, company_id, full_name, email, hashed_password, created_at, is_email_verified, role
+`
+
+type UpdateEmployerRoleParams struct {
+	ID        int32       `json:"id"`
+	CompanyID int32       `json:"company_id"`
+	Role      CompanyRole `json:"role"`
+}
+
+func (q *Queries) UpdateEmployerRole(ctx context.Context, arg UpdateEmployerRoleParams) (Employer, error) {
+	row := q.db.QueryRowContext(ctx, updateEmployerRole, arg.ID, arg.CompanyID, arg.Role)
+	var i Employer
+	err := row.Scan(
+		&i.ID,
+		&i.CompanyID,
+		&i.FullName,
+		&i.Email,
+		&i.HashedPassword,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+	)
+	return i, err
+}
+
 const verifyEmployerEmail = `-- name: VerifyEmployerEmail :one
 UPDATE employers
 SET is_email_verified = TRUE
 WHERE email = $1
-RETURNING id, company_id, full_name, email, hashed_password, created_at, is_email_verified
+RETURNING id, company_id, full_name, email, hashed_password, created_at, is_email_verified, role
 `
 
 func (q *Queries) VerifyEmployerEmail(ctx context.Context, email string) (Employer, error) {
@@ -203,6 +278,7 @@ func (q *Queries) VerifyEmployerEmail(ctx context.Context, email string) (Employ
 		&i.HashedPassword,
 		&i.CreatedAt,
 		&i.IsEmailVerified,
+		&i.Role,
 	)
 	return i, err
 }
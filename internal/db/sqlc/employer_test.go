@@ -15,6 +15,7 @@ func createRandomEmployer(t *testing.T, companyID int32) Employer {
 		FullName:       utils.RandomString(6),
 		Email:          utils.RandomEmail(),
 		HashedPassword: utils.RandomString(6),
+		Role:           CompanyRoleOwner,
 	}
 
 	if companyID == 0 {
@@ -30,6 +31,7 @@ func createRandomEmployer(t *testing.T, companyID int32) Employer {
 	require.Equal(t, params.Email, employer.Email)
 	require.Equal(t, params.HashedPassword, employer.HashedPassword)
 	require.Equal(t, params.CompanyID, employer.CompanyID)
+	require.Equal(t, params.Role, employer.Role)
 	require.NotZero(t, employer.ID)
 	require.NotZero(t, employer.CreatedAt)
 
@@ -98,12 +100,45 @@ func TestQueries_UpdateEmployerPassword(t *testing.T) {
 	require.Equal(t, params.HashedPassword, employer2.HashedPassword)
 }
 
+func TestQueries_ListCompanyEmployers(t *testing.T) {
+	company := createRandomCompany(t, "")
+	employer1 := createRandomEmployer(t, company.ID)
+	employer2 := createRandomEmployer(t, company.ID)
+	createRandomEmployer(t, 0)
+
+	employers, err := testQueries.ListCompanyEmployers(context.Background(), company.ID)
+	require.NoError(t, err)
+	require.Len(t, employers, 2)
+	compareTwoEmployers(t, employer1, employers[0])
+	compareTwoEmployers(t, employer2, employers[1])
+}
+
+func TestQueries_UpdateEmployerRole(t *testing.T) {
+	employer := createRandomEmployer(t, 0)
+	params := UpdateEmployerRoleParams{
+		ID:        employer.ID,
+		CompanyID: employer.CompanyID,
+		Role:      CompanyRoleRecruiter,
+	}
+
+	employer2, err := testQueries.UpdateEmployerRole(context.Background(), params)
+	require.NoError(t, err)
+	require.Equal(t, employer.ID, employer2.ID)
+	require.Equal(t, CompanyRoleRecruiter, employer2.Role)
+
+	// employers of other companies cannot be updated
+	params.CompanyID = createRandomCompany(t, "").ID
+	_, err = testQueries.UpdateEmployerRole(context.Background(), params)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
 func compareTwoEmployers(t *testing.T, employer1, employer2 Employer) {
 	require.Equal(t, employer1.ID, employer2.ID)
 	require.Equal(t, employer1.FullName, employer2.FullName)
 	require.Equal(t, employer1.Email, employer2.Email)
 	require.Equal(t, employer1.HashedPassword, employer2.HashedPassword)
 	require.Equal(t, employer1.CompanyID, employer2.CompanyID)
+	require.Equal(t, employer1.Role, employer2.Role)
 	require.WithinDuration(t, employer1.CreatedAt, employer2.CreatedAt, time.Second)
 }
 
@@ -7,7 +7,10 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 const createJob = `-- name: CreateJob :one
@@ -18,20 +21,44 @@ INSERT INTO jobs (title,
                   location,
                   salary_min,
                   salary_max,
-                  requirements)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-RETURNING id, title, industry, company_id, description, location, salary_min, salary_max, requirements, created_at
+                  requirements,
+                  status,
+                  published_at,
+                  closes_at,
+                  employment_type,
+                  seniority_level,
+                  remote_policy,
+                  salary_currency,
+                  salary_period,
+                  salary_min_annual,
+                  salary_max_annual,
+                  latitude,
+                  longitude)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+RETURNING id, title, industry, company_id, description, location, salary_min, salary_max, requirements, created_at, status, published_at, closes_at, employment_type, seniority_level, remote_policy, salary_currency, salary_period, salary_min_annual, salary_max_annual, latitude, longitude, search_vector
 `
 
 type CreateJobParams struct {
-	Title        string `json:"title"`
-	Industry     string `json:"industry"`
-	CompanyID    int32  `json:"company_id"`
-	Description  string `json:"description"`
-	Location     string `json:"location"`
-	SalaryMin    int32  `json:"salary_min"`
-	SalaryMax    int32  `json:"salary_max"`
-	Requirements string `json:"requirements"`
+	Title           string          `json:"title"`
+	Industry        string          `json:"industry"`
+	CompanyID       int32           `json:"company_id"`
+	Description     string          `json:"description"`
+	Location        string          `json:"location"`
+	SalaryMin       int32           `json:"salary_min"`
+	SalaryMax       int32           `json:"salary_max"`
+	Requirements    string          `json:"requirements"`
+	Status          JobStatus       `json:"status"`
+	PublishedAt     sql.NullTime    `json:"published_at"`
+	ClosesAt        sql.NullTime    `json:"closes_at"`
+	EmploymentType  EmploymentType  `json:"employment_type"`
+	SeniorityLevel  SeniorityLevel  `json:"seniority_level"`
+	RemotePolicy    RemotePolicy    `json:"remote_policy"`
+	SalaryCurrency  string          `json:"salary_currency"`
+	SalaryPeriod    SalaryPeriod    `json:"salary_period"`
+	SalaryMinAnnual int32           `json:"salary_min_annual"`
+	SalaryMaxAnnual int32           `json:"salary_max_annual"`
+	Latitude        sql.NullFloat64 `json:"latitude"`
+	Longitude       sql.NullFloat64 `json:"longitude"`
 }
 
 func (q *Queries) CreateJob(ctx context.Context, arg CreateJobParams) (Job, error) {
@@ -44,6 +71,18 @@ func (q *Queries) CreateJob(ctx context.Context, arg CreateJobParams) (Job, erro
 		arg.SalaryMin,
 		arg.SalaryMax,
 		arg.Requirements,
+		arg.Status,
+		arg.PublishedAt,
+		arg.ClosesAt,
+		arg.EmploymentType,
+		arg.SeniorityLevel,
+		arg.RemotePolicy,
+		arg.SalaryCurrency,
+		arg.SalaryPeriod,
+		arg.SalaryMinAnnual,
+		arg.SalaryMaxAnnual,
+		arg.Latitude,
+		arg.Longitude,
 	)
 	var i Job
 	err := row.Scan(
@@ -57,6 +96,19 @@ func (q *Queries) CreateJob(ctx context.Context, arg CreateJobParams) (Job, erro
 		&i.SalaryMax,
 		&i.Requirements,
 		&i.CreatedAt,
+		&i.Status,
+		&i.PublishedAt,
+		&i.ClosesAt,
+		&i.EmploymentType,
+		&i.SeniorityLevel,
+		&i.RemotePolicy,
+		&i.SalaryCurrency,
+		&i.SalaryPeriod,
+		&i.SalaryMinAnnual,
+		&i.SalaryMaxAnnual,
+		&i.Latitude,
+		&i.Longitude,
+		&i.SearchVector,
 	)
 	return i, err
 }
@@ -72,6 +124,37 @@ func (q *Queries) DeleteJob(ctx context.Context, id int32) error {
 	return err
 }
 
+const expireJobs = `-- name: ExpireJobs :many
+UPDATE jobs
+SET status = 'expired'
+WHERE status = 'published'
+  AND closes_at <= now()
+RETURNING id
+`
+
+func (q *Queries) ExpireJobs(ctx context.Context) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, expireJobs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int32{}
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getCompanyIDOfJob = `-- name: GetCompanyIDOfJob :one
 SELECT company_id
 FROM jobs
@@ -86,7 +169,7 @@ func (q *Queries) GetCompanyIDOfJob(ctx context.Context, id int32) (int32, error
 }
 
 const getJob = `-- name: GetJob :one
-SELECT id, title, industry, company_id, description, location, salary_min, salary_max, requirements, created_at
+SELECT id, title, industry, company_id, description, location, salary_min, salary_max, requirements, created_at, status, published_at, closes_at, employment_type, seniority_level, remote_policy, salary_currency, salary_period, salary_min_annual, salary_max_annual, latitude, longitude, search_vector
 FROM jobs
 WHERE id = $1
 `
@@ -105,6 +188,19 @@ func (q *Queries) GetJob(ctx context.Context, id int32) (Job, error) {
 		&i.SalaryMax,
 		&i.Requirements,
 		&i.CreatedAt,
+		&i.Status,
+		&i.PublishedAt,
+		&i.ClosesAt,
+		&i.EmploymentType,
+		&i.SeniorityLevel,
+		&i.RemotePolicy,
+		&i.SalaryCurrency,
+		&i.SalaryPeriod,
+		&i.SalaryMinAnnual,
+		&i.SalaryMaxAnnual,
+		&i.Latitude,
+		&i.Longitude,
+		&i.SearchVector,
 	)
 	return i, err
 }
@@ -131,7 +227,7 @@ func (q *Queries) GetJobBasicInfo(ctx context.Context, id int32) (GetJobBasicInf
 }
 
 const getJobDetails = `-- name: GetJobDetails :one
-SELECT j.id, j.title, j.industry, j.company_id, j.description, j.location, j.salary_min, j.salary_max, j.requirements, j.created_at,
+SELECT j.id, j.title, j.industry, j.company_id, j.description, j.location, j.salary_min, j.salary_max, j.requirements, j.created_at, j.status, j.published_at, j.closes_at, j.employment_type, j.seniority_level, j.remote_policy, j.salary_currency, j.salary_period, j.salary_min_annual, j.salary_max_annual, j.latitude, j.longitude, j.search_vector,
        c.name      AS company_name,
        c.location  AS company_location,
        c.industry  AS company_industry,
@@ -140,29 +236,47 @@ SELECT j.id, j.title, j.industry, j.company_id, j.description, j.location, j.sal
        e.full_name AS employer_full_name
 FROM jobs j
          JOIN companies c ON j.company_id = c.id
-         JOIN employers e ON c.id = e.company_id
+         JOIN LATERAL (SELECT *
+                       FROM employers
+                       WHERE company_id = c.id
+                       ORDER BY role, id
+                       LIMIT 1) e ON TRUE
 WHERE j.id = $1
 `
 
 type GetJobDetailsRow struct {
-	ID               int32     `json:"id"`
-	Title            string    `json:"title"`
-	Industry         string    `json:"industry"`
-	CompanyID        int32     `json:"company_id"`
-	Description      string    `json:"description"`
-	Location         string    `json:"location"`
-	SalaryMin        int32     `json:"salary_min"`
-	SalaryMax        int32     `json:"salary_max"`
-	Requirements     string    `json:"requirements"`
-	CreatedAt        time.Time `json:"created_at"`
-	CompanyName      string    `json:"company_name"`
-	CompanyLocation  string    `json:"company_location"`
-	CompanyIndustry  string    `json:"company_industry"`
-	EmployerID       int32     `json:"employer_id"`
-	EmployerEmail    string    `json:"employer_email"`
-	EmployerFullName string    `json:"employer_full_name"`
+	ID               int32           `json:"id"`
+	Title            string          `json:"title"`
+	Industry         string          `json:"industry"`
+	CompanyID        int32           `json:"company_id"`
+	Description      string          `json:"description"`
+	Location         string          `json:"location"`
+	SalaryMin        int32           `json:"salary_min"`
+	SalaryMax        int32           `json:"salary_max"`
+	Requirements     string          `json:"requirements"`
+	CreatedAt        time.Time       `json:"created_at"`
+	Status           JobStatus       `json:"status"`
+	PublishedAt      sql.NullTime    `json:"published_at"`
+	ClosesAt         sql.NullTime    `json:"closes_at"`
+	EmploymentType   EmploymentType  `json:"employment_type"`
+	SeniorityLevel   SeniorityLevel  `json:"seniority_level"`
+	RemotePolicy     RemotePolicy    `json:"remote_policy"`
+	SalaryCurrency   string          `json:"salary_currency"`
+	SalaryPeriod     SalaryPeriod    `json:"salary_period"`
+	SalaryMinAnnual  int32           `json:"salary_min_annual"`
+	SalaryMaxAnnual  int32           `json:"salary_max_annual"`
+	Latitude         sql.NullFloat64 `json:"latitude"`
+	Longitude        sql.NullFloat64 `json:"longitude"`
+	SearchVector     string          `json:"-"`
+	CompanyName      string          `json:"company_name"`
+	CompanyLocation  string          `json:"company_location"`
+	CompanyIndustry  string          `json:"company_industry"`
+	EmployerID       int32           `json:"employer_id"`
+	EmployerEmail    string          `json:"employer_email"`
+	EmployerFullName string          `json:"employer_full_name"`
 }
 
+// the employer of the job is the first owner of the company (the company always has one while it has members)
 func (q *Queries) GetJobDetails(ctx context.Context, id int32) (GetJobDetailsRow, error) {
 	row := q.db.QueryRowContext(ctx, getJobDetails, id)
 	var i GetJobDetailsRow
@@ -177,6 +291,19 @@ func (q *Queries) GetJobDetails(ctx context.Context, id int32) (GetJobDetailsRow
 		&i.SalaryMax,
 		&i.Requirements,
 		&i.CreatedAt,
+		&i.Status,
+		&i.PublishedAt,
+		&i.ClosesAt,
+		&i.EmploymentType,
+		&i.SeniorityLevel,
+		&i.RemotePolicy,
+		&i.SalaryCurrency,
+		&i.SalaryPeriod,
+		&i.SalaryMinAnnual,
+		&i.SalaryMaxAnnual,
+		&i.Latitude,
+		&i.Longitude,
+		&i.SearchVector,
 		&i.CompanyName,
 		&i.CompanyLocation,
 		&i.CompanyIndustry,
@@ -187,6 +314,80 @@ func (q *Queries) GetJobDetails(ctx context.Context, id int32) (GetJobDetailsRow
 	return i, err
 }
 
+const getJobForES = `-- name: GetJobForES :one
+SELECT j.id,
+       j.title,
+       j.industry,
+       j.location,
+       j.description,
+       c.name AS company_name,
+       j.salary_min,
+       j.salary_max,
+       j.requirements,
+       j.employment_type,
+       j.seniority_level,
+       j.remote_policy,
+       j.salary_currency,
+       j.salary_period,
+       j.salary_min_annual,
+       j.salary_max_annual,
+       j.latitude,
+       j.longitude
+FROM jobs j
+         JOIN companies c ON j.company_id = c.id
+WHERE j.id = $1
+  AND j.status = 'published'
+  AND (j.closes_at IS NULL OR j.closes_at > now())
+`
+
+type GetJobForESRow struct {
+	ID              int32           `json:"id"`
+	Title           string          `json:"title"`
+	Industry        string          `json:"industry"`
+	Location        string          `json:"location"`
+	Description     string          `json:"description"`
+	CompanyName     string          `json:"company_name"`
+	SalaryMin       int32           `json:"salary_min"`
+	SalaryMax       int32           `json:"salary_max"`
+	Requirements    string          `json:"requirements"`
+	EmploymentType  EmploymentType  `json:"employment_type"`
+	SeniorityLevel  SeniorityLevel  `json:"seniority_level"`
+	RemotePolicy    RemotePolicy    `json:"remote_policy"`
+	SalaryCurrency  string          `json:"salary_currency"`
+	SalaryPeriod    SalaryPeriod    `json:"salary_period"`
+	SalaryMinAnnual int32           `json:"salary_min_annual"`
+	SalaryMaxAnnual int32           `json:"salary_max_annual"`
+	Latitude        sql.NullFloat64 `json:"latitude"`
+	Longitude       sql.NullFloat64 `json:"longitude"`
+}
+
+// the job is returned only if it should be in the elasticsearch index
+func (q *Queries) GetJobForES(ctx context.Context, id int32) (GetJobForESRow, error) {
+	row := q.db.QueryRowContext(ctx, getJobForES, id)
+	var i GetJobForESRow
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Industry,
+		&i.Location,
+		&i.Description,
+		&i.CompanyName,
+		&i.SalaryMin,
+		&i.SalaryMax,
+		&i.Requirements,
+		&i.EmploymentType,
+		&i.SeniorityLevel,
+		&i.RemotePolicy,
+		&i.SalaryCurrency,
+		&i.SalaryPeriod,
+		&i.SalaryMinAnnual,
+		&i.SalaryMaxAnnual,
+		&i.Latitude,
+		&i.Longitude,
+	)
+	return i, err
+}
+
 const listAllJobsForES = `-- name: ListAllJobsForES :many
 SELECT j.id,
        j.title,
@@ -196,21 +397,41 @@ SELECT j.id,
        c.name AS company_name,
        j.salary_min,
        j.salary_max,
-       j.requirements
+       j.requirements,
+       j.employment_type,
+       j.seniority_level,
+       j.remote_policy,
+       j.salary_currency,
+       j.salary_period,
+       j.salary_min_annual,
+       j.salary_max_annual,
+       j.latitude,
+       j.longitude
 FROM jobs j
          JOIN companies c ON j.company_id = c.id
+WHERE j.status = 'published'
+  AND (j.closes_at IS NULL OR j.closes_at > now())
 `
 
 type ListAllJobsForESRow struct {
-	ID           int32  `json:"id"`
-	Title        string `json:"title"`
-	Industry     string `json:"industry"`
-	Location     string `json:"location"`
-	Description  string `json:"description"`
-	CompanyName  string `json:"company_name"`
-	SalaryMin    int32  `json:"salary_min"`
-	SalaryMax    int32  `json:"salary_max"`
-	Requirements string `json:"requirements"`
+	ID              int32           `json:"id"`
+	Title           string          `json:"title"`
+	Industry        string          `json:"industry"`
+	Location        string          `json:"location"`
+	Description     string          `json:"description"`
+	CompanyName     string          `json:"company_name"`
+	SalaryMin       int32           `json:"salary_min"`
+	SalaryMax       int32           `json:"salary_max"`
+	Requirements    string          `json:"requirements"`
+	EmploymentType  EmploymentType  `json:"employment_type"`
+	SeniorityLevel  SeniorityLevel  `json:"seniority_level"`
+	RemotePolicy    RemotePolicy    `json:"remote_policy"`
+	SalaryCurrency  string          `json:"salary_currency"`
+	SalaryPeriod    SalaryPeriod    `json:"salary_period"`
+	SalaryMinAnnual int32           `json:"salary_min_annual"`
+	SalaryMaxAnnual int32           `json:"salary_max_annual"`
+	Latitude        sql.NullFloat64 `json:"latitude"`
+	Longitude       sql.NullFloat64 `json:"longitude"`
 }
 
 func (q *Queries) ListAllJobsForES(ctx context.Context) ([]ListAllJobsForESRow, error) {
@@ -232,6 +453,15 @@ func (q *Queries) ListAllJobsForES(ctx context.Context) ([]ListAllJobsForESRow,
 			&i.SalaryMin,
 			&i.SalaryMax,
 			&i.Requirements,
+			&i.EmploymentType,
+			&i.SeniorityLevel,
+			&i.RemotePolicy,
+			&i.SalaryCurrency,
+			&i.SalaryPeriod,
+			&i.SalaryMinAnnual,
+			&i.SalaryMaxAnnual,
+			&i.Latitude,
+			&i.Longitude,
 		); err != nil {
 			return nil, err
 		}
@@ -246,12 +476,167 @@ func (q *Queries) ListAllJobsForES(ctx context.Context) ([]ListAllJobsForESRow,
 	return items, nil
 }
 
+const listJobLocations = `-- name: ListJobLocations :many
+SELECT id,
+       location
+FROM jobs
+WHERE latitude IS NULL
+`
+
+type ListJobLocationsRow struct {
+	ID       int32  `json:"id"`
+	Location string `json:"location"`
+}
+
+func (q *Queries) ListJobLocations(ctx context.Context) ([]ListJobLocationsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listJobLocations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListJobLocationsRow{}
+	for rows.Next() {
+		var i ListJobLocationsRow
+		if err := rows.Scan(&i.ID, &i.Location); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listJobSalariesByCurrency = `-- name: ListJobSalariesByCurrency :many
+SELECT id,
+       salary_min,
+       salary_max,
+       salary_period,
+       status
+FROM jobs
+WHERE salary_currency = $1
+`
+
+type ListJobSalariesByCurrencyRow struct {
+	ID           int32        `json:"id"`
+	SalaryMin    int32        `json:"salary_min"`
+	SalaryMax    int32        `json:"salary_max"`
+	SalaryPeriod SalaryPeriod `json:"salary_period"`
+	Status       JobStatus    `json:"status"`
+}
+
+func (q *Queries) ListJobSalariesByCurrency(ctx context.Context, salaryCurrency string) ([]ListJobSalariesByCurrencyRow, error) {
+	rows, err := q.db.QueryContext(ctx, listJobSalariesByCurrency, salaryCurrency)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListJobSalariesByCurrencyRow{}
+	for rows.Next() {
+		var i ListJobSalariesByCurrencyRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.SalaryMin,
+			&i.SalaryMax,
+			&i.SalaryPeriod,
+			&i.Status,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listJobSuggestions = `-- name: ListJobSuggestions :many
+WITH published AS (SELECT j.id, j.title, c.name AS company_name
+                   FROM jobs j
+                            JOIN companies c ON j.company_id = c.id
+                   WHERE j.status = 'published'
+                     AND (j.closes_at IS NULL OR j.closes_at > now())),
+     candidates AS (SELECT 1 AS type_order, 'title' AS type, title AS value, COUNT(*) AS count
+                    FROM published
+                    GROUP BY title
+                    UNION ALL
+                    SELECT 2, 'skill', s.skill, COUNT(DISTINCT s.job_id)
+                    FROM published p
+                             JOIN job_skills s ON s.job_id = p.id
+                    GROUP BY s.skill
+                    UNION ALL
+                    SELECT 3, 'company', company_name, COUNT(*)
+                    FROM published
+                    GROUP BY company_name),
+     matched AS (SELECT type_order,
+                        type,
+                        value,
+                        count,
+                        row_number() OVER (PARTITION BY type_order ORDER BY count DESC, value) AS position
+                 FROM candidates
+                 WHERE NOT EXISTS (SELECT 1
+                                   FROM unnest($1::text[]) prefix(word)
+                                   WHERE NOT EXISTS (SELECT 1
+                                                     FROM regexp_split_to_table(lower(value), '\s+') value_word(word)
+                                                     WHERE starts_with(value_word.word, prefix.word))))
+SELECT type::text AS type, value::text AS value, count::bigint AS count
+FROM matched
+WHERE position <= $2::int
+ORDER BY type_order, position
+`
+
+type ListJobSuggestionsParams struct {
+	PrefixWords []string `json:"prefix_words"`
+	Size        int32    `json:"size"`
+}
+
+type ListJobSuggestionsRow struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// the titles, skills and company names of the published jobs in which every prefix word is a prefix
+// of a word, case-insensitively, up to size values of every type with the most jobs
+func (q *Queries) ListJobSuggestions(ctx context.Context, arg ListJobSuggestionsParams) ([]ListJobSuggestionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listJobSuggestions, pq.Array(arg.PrefixWords), arg.Size)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListJobSuggestionsRow{}
+	for rows.Next() {
+		var i ListJobSuggestionsRow
+		if err := rows.Scan(&i.Type, &i.Value, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listJobsByCompanyExactName = `-- name: ListJobsByCompanyExactName :many
-SELECT j.id, j.title, j.industry, j.company_id, j.description, j.location, j.salary_min, j.salary_max, j.requirements, j.created_at,
+SELECT j.id, j.title, j.industry, j.company_id, j.description, j.location, j.salary_min, j.salary_max, j.requirements, j.created_at, j.status, j.published_at, j.closes_at, j.employment_type, j.seniority_level, j.remote_policy, j.salary_currency, j.salary_period, j.salary_min_annual, j.salary_max_annual, j.latitude, j.longitude, j.search_vector,
        c.name AS company_name
 FROM jobs j
          JOIN companies c ON j.company_id = c.id
 WHERE c.name = $1
+  AND j.status = 'published'
+  AND (j.closes_at IS NULL OR j.closes_at > now())
 LIMIT $2 OFFSET $3
 `
 
@@ -262,17 +647,30 @@ type ListJobsByCompanyExactNameParams struct {
 }
 
 type ListJobsByCompanyExactNameRow struct {
-	ID           int32     `json:"id"`
-	Title        string    `json:"title"`
-	Industry     string    `json:"industry"`
-	CompanyID    int32     `json:"company_id"`
-	Description  string    `json:"description"`
-	Location     string    `json:"location"`
-	SalaryMin    int32     `json:"salary_min"`
-	SalaryMax    int32     `json:"salary_max"`
-	Requirements string    `json:"requirements"`
-	CreatedAt    time.Time `json:"created_at"`
-	CompanyName  string    `json:"company_name"`
+	ID              int32           `json:"id"`
+	Title           string          `json:"title"`
+	Industry        string          `json:"industry"`
+	CompanyID       int32           `json:"company_id"`
+	Description     string          `json:"description"`
+	Location        string          `json:"location"`
+	SalaryMin       int32           `json:"salary_min"`
+	SalaryMax       int32           `json:"salary_max"`
+	Requirements    string          `json:"requirements"`
+	CreatedAt       time.Time       `json:"created_at"`
+	Status          JobStatus       `json:"status"`
+	PublishedAt     sql.NullTime    `json:"published_at"`
+	ClosesAt        sql.NullTime    `json:"closes_at"`
+	EmploymentType  EmploymentType  `json:"employment_type"`
+	SeniorityLevel  SeniorityLevel  `json:"seniority_level"`
+	RemotePolicy    RemotePolicy    `json:"remote_policy"`
+	SalaryCurrency  string          `json:"salary_currency"`
+	SalaryPeriod    SalaryPeriod    `json:"salary_period"`
+	SalaryMinAnnual int32           `json:"salary_min_annual"`
+	SalaryMaxAnnual int32           `json:"salary_max_annual"`
+	Latitude        sql.NullFloat64 `json:"latitude"`
+	Longitude       sql.NullFloat64 `json:"longitude"`
+	SearchVector    string          `json:"-"`
+	CompanyName     string          `json:"company_name"`
 }
 
 func (q *Queries) ListJobsByCompanyExactName(ctx context.Context, arg ListJobsByCompanyExactNameParams) ([]ListJobsByCompanyExactNameRow, error) {
@@ -295,6 +693,19 @@ func (q *Queries) ListJobsByCompanyExactName(ctx context.Context, arg ListJobsBy
 			&i.SalaryMax,
 			&i.Requirements,
 			&i.CreatedAt,
+			&i.Status,
+			&i.PublishedAt,
+			&i.ClosesAt,
+			&i.EmploymentType,
+			&i.SeniorityLevel,
+			&i.RemotePolicy,
+			&i.SalaryCurrency,
+			&i.SalaryPeriod,
+			&i.SalaryMinAnnual,
+			&i.SalaryMaxAnnual,
+			&i.Latitude,
+			&i.Longitude,
+			&i.SearchVector,
 			&i.CompanyName,
 		); err != nil {
 			return nil, err
@@ -311,11 +722,13 @@ func (q *Queries) ListJobsByCompanyExactName(ctx context.Context, arg ListJobsBy
 }
 
 const listJobsByCompanyID = `-- name: ListJobsByCompanyID :many
-SELECT j.id, j.title, j.industry, j.company_id, j.description, j.location, j.salary_min, j.salary_max, j.requirements, j.created_at,
+SELECT j.id, j.title, j.industry, j.company_id, j.description, j.location, j.salary_min, j.salary_max, j.requirements, j.created_at, j.status, j.published_at, j.closes_at, j.employment_type, j.seniority_level, j.remote_policy, j.salary_currency, j.salary_period, j.salary_min_annual, j.salary_max_annual, j.latitude, j.longitude, j.search_vector,
        c.name AS company_name
 FROM jobs j
          JOIN companies c ON j.company_id = c.id
 WHERE j.company_id = $1
+  AND j.status = 'published'
+  AND (j.closes_at IS NULL OR j.closes_at > now())
 LIMIT $2 OFFSET $3
 `
 
@@ -326,17 +739,30 @@ type ListJobsByCompanyIDParams struct {
 }
 
 type ListJobsByCompanyIDRow struct {
-	ID           int32     `json:"id"`
-	Title        string    `json:"title"`
-	Industry     string    `json:"industry"`
-	CompanyID    int32     `json:"company_id"`
-	Description  string    `json:"description"`
-	Location     string    `json:"location"`
-	SalaryMin    int32     `json:"salary_min"`
-	SalaryMax    int32     `json:"salary_max"`
-	Requirements string    `json:"requirements"`
-	CreatedAt    time.Time `json:"created_at"`
-	CompanyName  string    `json:"company_name"`
+	ID              int32           `json:"id"`
+	Title           string          `json:"title"`
+	Industry        string          `json:"industry"`
+	CompanyID       int32           `json:"company_id"`
+	Description     string          `json:"description"`
+	Location        string          `json:"location"`
+	SalaryMin       int32           `json:"salary_min"`
+	SalaryMax       int32           `json:"salary_max"`
+	Requirements    string          `json:"requirements"`
+	CreatedAt       time.Time       `json:"created_at"`
+	Status          JobStatus       `json:"status"`
+	PublishedAt     sql.NullTime    `json:"published_at"`
+	ClosesAt        sql.NullTime    `json:"closes_at"`
+	EmploymentType  EmploymentType  `json:"employment_type"`
+	SeniorityLevel  SeniorityLevel  `json:"seniority_level"`
+	RemotePolicy    RemotePolicy    `json:"remote_policy"`
+	SalaryCurrency  string          `json:"salary_currency"`
+	SalaryPeriod    SalaryPeriod    `json:"salary_period"`
+	SalaryMinAnnual int32           `json:"salary_min_annual"`
+	SalaryMaxAnnual int32           `json:"salary_max_annual"`
+	Latitude        sql.NullFloat64 `json:"latitude"`
+	Longitude       sql.NullFloat64 `json:"longitude"`
+	SearchVector    string          `json:"-"`
+	CompanyName     string          `json:"company_name"`
 }
 
 func (q *Queries) ListJobsByCompanyID(ctx context.Context, arg ListJobsByCompanyIDParams) ([]ListJobsByCompanyIDRow, error) {
@@ -359,6 +785,19 @@ func (q *Queries) ListJobsByCompanyID(ctx context.Context, arg ListJobsByCompany
 			&i.SalaryMax,
 			&i.Requirements,
 			&i.CreatedAt,
+			&i.Status,
+			&i.PublishedAt,
+			&i.ClosesAt,
+			&i.EmploymentType,
+			&i.SeniorityLevel,
+			&i.RemotePolicy,
+			&i.SalaryCurrency,
+			&i.SalaryPeriod,
+			&i.SalaryMinAnnual,
+			&i.SalaryMaxAnnual,
+			&i.Latitude,
+			&i.Longitude,
+			&i.SearchVector,
 			&i.CompanyName,
 		); err != nil {
 			return nil, err
@@ -375,11 +814,13 @@ func (q *Queries) ListJobsByCompanyID(ctx context.Context, arg ListJobsByCompany
 }
 
 const listJobsByCompanyName = `-- name: ListJobsByCompanyName :many
-SELECT j.id, j.title, j.industry, j.company_id, j.description, j.location, j.salary_min, j.salary_max, j.requirements, j.created_at,
+SELECT j.id, j.title, j.industry, j.company_id, j.description, j.location, j.salary_min, j.salary_max, j.requirements, j.created_at, j.status, j.published_at, j.closes_at, j.employment_type, j.seniority_level, j.remote_policy, j.salary_currency, j.salary_period, j.salary_min_annual, j.salary_max_annual, j.latitude, j.longitude, j.search_vector,
        c.name AS company_name
 FROM jobs j
          JOIN companies c ON j.company_id = c.id
 WHERE c.name ILIKE '%' || $3::text || '%'
+  AND j.status = 'published'
+  AND (j.closes_at IS NULL OR j.closes_at > now())
 LIMIT $1 OFFSET $2
 `
 
@@ -390,17 +831,30 @@ type ListJobsByCompanyNameParams struct {
 }
 
 type ListJobsByCompanyNameRow struct {
-	ID           int32     `json:"id"`
-	Title        string    `json:"title"`
-	Industry     string    `json:"industry"`
-	CompanyID    int32     `json:"company_id"`
-	Description  string    `json:"description"`
-	Location     string    `json:"location"`
-	SalaryMin    int32     `json:"salary_min"`
-	SalaryMax    int32     `json:"salary_max"`
-	Requirements string    `json:"requirements"`
-	CreatedAt    time.Time `json:"created_at"`
-	CompanyName  string    `json:"company_name"`
+	ID              int32           `json:"id"`
+	Title           string          `json:"title"`
+	Industry        string          `json:"industry"`
+	CompanyID       int32           `json:"company_id"`
+	Description     string          `json:"description"`
+	Location        string          `json:"location"`
+	SalaryMin       int32           `json:"salary_min"`
+	SalaryMax       int32           `json:"salary_max"`
+	Requirements    string          `json:"requirements"`
+	CreatedAt       time.Time       `json:"created_at"`
+	Status          JobStatus       `json:"status"`
+	PublishedAt     sql.NullTime    `json:"published_at"`
+	ClosesAt        sql.NullTime    `json:"closes_at"`
+	EmploymentType  EmploymentType  `json:"employment_type"`
+	SeniorityLevel  SeniorityLevel  `json:"seniority_level"`
+	RemotePolicy    RemotePolicy    `json:"remote_policy"`
+	SalaryCurrency  string          `json:"salary_currency"`
+	SalaryPeriod    SalaryPeriod    `json:"salary_period"`
+	SalaryMinAnnual int32           `json:"salary_min_annual"`
+	SalaryMaxAnnual int32           `json:"salary_max_annual"`
+	Latitude        sql.NullFloat64 `json:"latitude"`
+	Longitude       sql.NullFloat64 `json:"longitude"`
+	SearchVector    string          `json:"-"`
+	CompanyName     string          `json:"company_name"`
 }
 
 func (q *Queries) ListJobsByCompanyName(ctx context.Context, arg ListJobsByCompanyNameParams) ([]ListJobsByCompanyNameRow, error) {
@@ -423,6 +877,19 @@ func (q *Queries) ListJobsByCompanyName(ctx context.Context, arg ListJobsByCompa
 			&i.SalaryMax,
 			&i.Requirements,
 			&i.CreatedAt,
+			&i.Status,
+			&i.PublishedAt,
+			&i.ClosesAt,
+			&i.EmploymentType,
+			&i.SeniorityLevel,
+			&i.RemotePolicy,
+			&i.SalaryCurrency,
+			&i.SalaryPeriod,
+			&i.SalaryMinAnnual,
+			&i.SalaryMaxAnnual,
+			&i.Latitude,
+			&i.Longitude,
+			&i.SearchVector,
 			&i.CompanyName,
 		); err != nil {
 			return nil, err
@@ -439,7 +906,7 @@ func (q *Queries) ListJobsByCompanyName(ctx context.Context, arg ListJobsByCompa
 }
 
 const listJobsByIndustry = `-- name: ListJobsByIndustry :many
-SELECT id, title, industry, company_id, description, location, salary_min, salary_max, requirements, created_at
+SELECT id, title, industry, company_id, description, location, salary_min, salary_max, requirements, created_at, status, published_at, closes_at, employment_type, seniority_level, remote_policy, salary_currency, salary_period, salary_min_annual, salary_max_annual, latitude, longitude, search_vector
 FROM jobs
 WHERE industry = $1
 LIMIT $2 OFFSET $3
@@ -471,6 +938,19 @@ func (q *Queries) ListJobsByIndustry(ctx context.Context, arg ListJobsByIndustry
 			&i.SalaryMax,
 			&i.Requirements,
 			&i.CreatedAt,
+			&i.Status,
+			&i.PublishedAt,
+			&i.ClosesAt,
+			&i.EmploymentType,
+			&i.SeniorityLevel,
+			&i.RemotePolicy,
+			&i.SalaryCurrency,
+			&i.SalaryPeriod,
+			&i.SalaryMinAnnual,
+			&i.SalaryMaxAnnual,
+			&i.Latitude,
+			&i.Longitude,
+			&i.SearchVector,
 		); err != nil {
 			return nil, err
 		}
@@ -486,7 +966,7 @@ func (q *Queries) ListJobsByIndustry(ctx context.Context, arg ListJobsByIndustry
 }
 
 const listJobsByLocation = `-- name: ListJobsByLocation :many
-SELECT id, title, industry, company_id, description, location, salary_min, salary_max, requirements, created_at
+SELECT id, title, industry, company_id, description, location, salary_min, salary_max, requirements, created_at, status, published_at, closes_at, employment_type, seniority_level, remote_policy, salary_currency, salary_period, salary_min_annual, salary_max_annual, latitude, longitude, search_vector
 FROM jobs
 WHERE location = $1
 LIMIT $2 OFFSET $3
@@ -518,6 +998,19 @@ func (q *Queries) ListJobsByLocation(ctx context.Context, arg ListJobsByLocation
 			&i.SalaryMax,
 			&i.Requirements,
 			&i.CreatedAt,
+			&i.Status,
+			&i.PublishedAt,
+			&i.ClosesAt,
+			&i.EmploymentType,
+			&i.SeniorityLevel,
+			&i.RemotePolicy,
+			&i.SalaryCurrency,
+			&i.SalaryPeriod,
+			&i.SalaryMinAnnual,
+			&i.SalaryMaxAnnual,
+			&i.Latitude,
+			&i.Longitude,
+			&i.SearchVector,
 		); err != nil {
 			return nil, err
 		}
@@ -533,7 +1026,7 @@ func (q *Queries) ListJobsByLocation(ctx context.Context, arg ListJobsByLocation
 }
 
 const listJobsBySalaryRange = `-- name: ListJobsBySalaryRange :many
-SELECT id, title, industry, company_id, description, location, salary_min, salary_max, requirements, created_at
+SELECT id, title, industry, company_id, description, location, salary_min, salary_max, requirements, created_at, status, published_at, closes_at, employment_type, seniority_level, remote_policy, salary_currency, salary_period, salary_min_annual, salary_max_annual, latitude, longitude, search_vector
 FROM jobs
 WHERE salary_min >= $1
   AND salary_max <= $2
@@ -572,6 +1065,119 @@ func (q *Queries) ListJobsBySalaryRange(ctx context.Context, arg ListJobsBySalar
 			&i.SalaryMax,
 			&i.Requirements,
 			&i.CreatedAt,
+			&i.Status,
+			&i.PublishedAt,
+			&i.ClosesAt,
+			&i.EmploymentType,
+			&i.SeniorityLevel,
+			&i.RemotePolicy,
+			&i.SalaryCurrency,
+			&i.SalaryPeriod,
+			&i.SalaryMinAnnual,
+			&i.SalaryMaxAnnual,
+			&i.Latitude,
+			&i.Longitude,
+			&i.SearchVector,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listJobsByTextSimilarity = `-- name: ListJobsByTextSimilarity :many
+WITH source AS (SELECT tsvector_to_array(search_vector) AS words
+                FROM jobs
+                WHERE jobs.id = $1),
+     candidates AS (SELECT j.id,
+                           tsvector_to_array(j.search_vector) AS words
+                    FROM jobs j,
+                         source
+                    WHERE tsvector_to_array(j.search_vector) && source.words
+                      AND j.id <> $1
+                      AND j.status = 'published'
+                      AND (j.closes_at IS NULL OR j.closes_at > now())),
+     scored AS (SELECT candidates.id,
+                       (SELECT COUNT(*) FROM unnest(candidates.words) word WHERE word = ANY (source.words))::float8 /
+                       cardinality(ARRAY(SELECT DISTINCT unnest(candidates.words || source.words)))::float8 AS score
+                FROM candidates,
+                     source)
+SELECT j.id,
+       j.title,
+       j.industry,
+       j.location,
+       j.salary_min,
+       j.salary_max,
+       j.salary_currency,
+       j.salary_period,
+       j.employment_type,
+       j.seniority_level,
+       j.remote_policy,
+       c.name                                                                                  AS company_name,
+       ARRAY(SELECT s.skill FROM job_skills s WHERE s.job_id = j.id ORDER BY s.skill)::text[] AS skills,
+       scored.score::float8                                                                    AS score
+FROM scored
+         JOIN jobs j ON scored.id = j.id
+         JOIN companies c ON j.company_id = c.id
+ORDER BY scored.score DESC, j.id
+LIMIT $2
+`
+
+type ListJobsByTextSimilarityParams struct {
+	JobID int32 `json:"job_id"`
+	Size  int32 `json:"size"`
+}
+
+type ListJobsByTextSimilarityRow struct {
+	ID             int32          `json:"id"`
+	Title          string         `json:"title"`
+	Industry       string         `json:"industry"`
+	Location       string         `json:"location"`
+	SalaryMin      int32          `json:"salary_min"`
+	SalaryMax      int32          `json:"salary_max"`
+	SalaryCurrency string         `json:"salary_currency"`
+	SalaryPeriod   SalaryPeriod   `json:"salary_period"`
+	EmploymentType EmploymentType `json:"employment_type"`
+	SeniorityLevel SeniorityLevel `json:"seniority_level"`
+	RemotePolicy   RemotePolicy   `json:"remote_policy"`
+	CompanyName    string         `json:"company_name"`
+	Skills         []string       `json:"skills"`
+	Score          float64        `json:"score"`
+}
+
+// the published jobs that share the words (lexemes of the search vectors) with the job,
+// the score is the Jaccard index of the words of both jobs
+func (q *Queries) ListJobsByTextSimilarity(ctx context.Context, arg ListJobsByTextSimilarityParams) ([]ListJobsByTextSimilarityRow, error) {
+	rows, err := q.db.QueryContext(ctx, listJobsByTextSimilarity, arg.JobID, arg.Size)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListJobsByTextSimilarityRow{}
+	for rows.Next() {
+		var i ListJobsByTextSimilarityRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Industry,
+			&i.Location,
+			&i.SalaryMin,
+			&i.SalaryMax,
+			&i.SalaryCurrency,
+			&i.SalaryPeriod,
+			&i.EmploymentType,
+			&i.SeniorityLevel,
+			&i.RemotePolicy,
+			&i.CompanyName,
+			pq.Array(&i.Skills),
+			&i.Score,
 		); err != nil {
 			return nil, err
 		}
@@ -587,7 +1193,7 @@ func (q *Queries) ListJobsBySalaryRange(ctx context.Context, arg ListJobsBySalar
 }
 
 const listJobsByTitle = `-- name: ListJobsByTitle :many
-SELECT id, title, industry, company_id, description, location, salary_min, salary_max, requirements, created_at
+SELECT id, title, industry, company_id, description, location, salary_min, salary_max, requirements, created_at, status, published_at, closes_at, employment_type, seniority_level, remote_policy, salary_currency, salary_period, salary_min_annual, salary_max_annual, latitude, longitude, search_vector
 FROM jobs
 WHERE title ILIKE '%' || $3::text || '%'
 LIMIT $1 OFFSET $2
@@ -619,6 +1225,73 @@ func (q *Queries) ListJobsByTitle(ctx context.Context, arg ListJobsByTitleParams
 			&i.SalaryMax,
 			&i.Requirements,
 			&i.CreatedAt,
+			&i.Status,
+			&i.PublishedAt,
+			&i.ClosesAt,
+			&i.EmploymentType,
+			&i.SeniorityLevel,
+			&i.RemotePolicy,
+			&i.SalaryCurrency,
+			&i.SalaryPeriod,
+			&i.SalaryMinAnnual,
+			&i.SalaryMaxAnnual,
+			&i.Latitude,
+			&i.Longitude,
+			&i.SearchVector,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listJobsClosingSoon = `-- name: ListJobsClosingSoon :many
+SELECT j.id,
+       j.title,
+       j.company_id,
+       j.closes_at,
+       c.name AS company_name
+FROM jobs j
+         JOIN companies c ON j.company_id = c.id
+WHERE j.status = 'published'
+  AND j.closes_at > now()
+  AND j.closes_at <= $1::timestamptz
+  AND NOT EXISTS (SELECT 1
+                  FROM job_expiry_reminders r
+                  WHERE r.job_id = j.id
+                    AND r.closes_at = j.closes_at)
+`
+
+type ListJobsClosingSoonRow struct {
+	ID          int32        `json:"id"`
+	Title       string       `json:"title"`
+	CompanyID   int32        `json:"company_id"`
+	ClosesAt    sql.NullTime `json:"closes_at"`
+	CompanyName string       `json:"company_name"`
+}
+
+func (q *Queries) ListJobsClosingSoon(ctx context.Context, closesBefore time.Time) ([]ListJobsClosingSoonRow, error) {
+	rows, err := q.db.QueryContext(ctx, listJobsClosingSoon, closesBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListJobsClosingSoonRow{}
+	for rows.Next() {
+		var i ListJobsClosingSoonRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.CompanyID,
+			&i.ClosesAt,
+			&i.CompanyName,
 		); err != nil {
 			return nil, err
 		}
@@ -641,7 +1314,17 @@ SELECT id,
        location,
        salary_min,
        salary_max,
-       created_at
+       created_at,
+       status,
+       published_at,
+       closes_at,
+       employment_type,
+       seniority_level,
+       remote_policy,
+       salary_currency,
+       salary_period,
+       salary_min_annual,
+       salary_max_annual
 FROM jobs
 WHERE company_id = $1
 ORDER BY CASE WHEN $4::bool THEN created_at END ASC,
@@ -659,14 +1342,24 @@ type ListJobsForEmployerParams struct {
 }
 
 type ListJobsForEmployerRow struct {
-	ID          int32     `json:"id"`
-	Title       string    `json:"title"`
-	Industry    string    `json:"industry"`
-	Description string    `json:"description"`
-	Location    string    `json:"location"`
-	SalaryMin   int32     `json:"salary_min"`
-	SalaryMax   int32     `json:"salary_max"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID              int32          `json:"id"`
+	Title           string         `json:"title"`
+	Industry        string         `json:"industry"`
+	Description     string         `json:"description"`
+	Location        string         `json:"location"`
+	SalaryMin       int32          `json:"salary_min"`
+	SalaryMax       int32          `json:"salary_max"`
+	CreatedAt       time.Time      `json:"created_at"`
+	Status          JobStatus      `json:"status"`
+	PublishedAt     sql.NullTime   `json:"published_at"`
+	ClosesAt        sql.NullTime   `json:"closes_at"`
+	EmploymentType  EmploymentType `json:"employment_type"`
+	SeniorityLevel  SeniorityLevel `json:"seniority_level"`
+	RemotePolicy    RemotePolicy   `json:"remote_policy"`
+	SalaryCurrency  string         `json:"salary_currency"`
+	SalaryPeriod    SalaryPeriod   `json:"salary_period"`
+	SalaryMinAnnual int32          `json:"salary_min_annual"`
+	SalaryMaxAnnual int32          `json:"salary_max_annual"`
 }
 
 func (q *Queries) ListJobsForEmployer(ctx context.Context, arg ListJobsForEmployerParams) ([]ListJobsForEmployerRow, error) {
@@ -693,6 +1386,16 @@ func (q *Queries) ListJobsForEmployer(ctx context.Context, arg ListJobsForEmploy
 			&i.SalaryMin,
 			&i.SalaryMax,
 			&i.CreatedAt,
+			&i.Status,
+			&i.PublishedAt,
+			&i.ClosesAt,
+			&i.EmploymentType,
+			&i.SeniorityLevel,
+			&i.RemotePolicy,
+			&i.SalaryCurrency,
+			&i.SalaryPeriod,
+			&i.SalaryMinAnnual,
+			&i.SalaryMaxAnnual,
 		); err != nil {
 			return nil, err
 		}
@@ -708,7 +1411,7 @@ func (q *Queries) ListJobsForEmployer(ctx context.Context, arg ListJobsForEmploy
 }
 
 const listJobsMatchingUserSkills = `-- name: ListJobsMatchingUserSkills :many
-SELECT j.id, j.title, j.industry, j.company_id, j.description, j.location, j.salary_min, j.salary_max, j.requirements, j.created_at,
+SELECT j.id, j.title, j.industry, j.company_id, j.description, j.location, j.salary_min, j.salary_max, j.requirements, j.created_at, j.status, j.published_at, j.closes_at, j.employment_type, j.seniority_level, j.remote_policy, j.salary_currency, j.salary_period, j.salary_min_annual, j.salary_max_annual, j.latitude, j.longitude, j.search_vector,
        c.name AS company_name
 FROM jobs j
          JOIN companies c ON j.company_id = c.id
@@ -717,6 +1420,10 @@ WHERE j.id IN (SELECT job_id
                WHERE skill IN (SELECT skill
                                FROM user_skills
                                WHERE user_id = $1))
+  AND j.salary_max_annual >= (SELECT desired_salary_min FROM users WHERE id = $1)
+  AND j.salary_min_annual <= (SELECT desired_salary_max FROM users WHERE id = $1)
+  AND j.status = 'published'
+  AND (j.closes_at IS NULL OR j.closes_at > now())
 LIMIT $2 OFFSET $3
 `
 
@@ -727,17 +1434,30 @@ type ListJobsMatchingUserSkillsParams struct {
 }
 
 type ListJobsMatchingUserSkillsRow struct {
-	ID           int32     `json:"id"`
-	Title        string    `json:"title"`
-	Industry     string    `json:"industry"`
-	CompanyID    int32     `json:"company_id"`
-	Description  string    `json:"description"`
-	Location     string    `json:"location"`
-	SalaryMin    int32     `json:"salary_min"`
-	SalaryMax    int32     `json:"salary_max"`
-	Requirements string    `json:"requirements"`
-	CreatedAt    time.Time `json:"created_at"`
-	CompanyName  string    `json:"company_name"`
+	ID              int32           `json:"id"`
+	Title           string          `json:"title"`
+	Industry        string          `json:"industry"`
+	CompanyID       int32           `json:"company_id"`
+	Description     string          `json:"description"`
+	Location        string          `json:"location"`
+	SalaryMin       int32           `json:"salary_min"`
+	SalaryMax       int32           `json:"salary_max"`
+	Requirements    string          `json:"requirements"`
+	CreatedAt       time.Time       `json:"created_at"`
+	Status          JobStatus       `json:"status"`
+	PublishedAt     sql.NullTime    `json:"published_at"`
+	ClosesAt        sql.NullTime    `json:"closes_at"`
+	EmploymentType  EmploymentType  `json:"employment_type"`
+	SeniorityLevel  SeniorityLevel  `json:"seniority_level"`
+	RemotePolicy    RemotePolicy    `json:"remote_policy"`
+	SalaryCurrency  string          `json:"salary_currency"`
+	SalaryPeriod    SalaryPeriod    `json:"salary_period"`
+	SalaryMinAnnual int32           `json:"salary_min_annual"`
+	SalaryMaxAnnual int32           `json:"salary_max_annual"`
+	Latitude        sql.NullFloat64 `json:"latitude"`
+	Longitude       sql.NullFloat64 `json:"longitude"`
+	SearchVector    string          `json:"-"`
+	CompanyName     string          `json:"company_name"`
 }
 
 func (q *Queries) ListJobsMatchingUserSkills(ctx context.Context, arg ListJobsMatchingUserSkillsParams) ([]ListJobsMatchingUserSkillsRow, error) {
@@ -760,6 +1480,19 @@ func (q *Queries) ListJobsMatchingUserSkills(ctx context.Context, arg ListJobsMa
 			&i.SalaryMax,
 			&i.Requirements,
 			&i.CreatedAt,
+			&i.Status,
+			&i.PublishedAt,
+			&i.ClosesAt,
+			&i.EmploymentType,
+			&i.SeniorityLevel,
+			&i.RemotePolicy,
+			&i.SalaryCurrency,
+			&i.SalaryPeriod,
+			&i.SalaryMinAnnual,
+			&i.SalaryMaxAnnual,
+			&i.Latitude,
+			&i.Longitude,
+			&i.SearchVector,
 			&i.CompanyName,
 		); err != nil {
 			return nil, err
@@ -775,30 +1508,80 @@ func (q *Queries) ListJobsMatchingUserSkills(ctx context.Context, arg ListJobsMa
 	return items, nil
 }
 
+const listPublishedJobIDs = `-- name: ListPublishedJobIDs :many
+SELECT id
+FROM jobs
+WHERE id = ANY ($1::int[])
+  AND status = 'published'
+`
+
+func (q *Queries) ListPublishedJobIDs(ctx context.Context, ids []int32) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, listPublishedJobIDs, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int32{}
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateJob = `-- name: UpdateJob :one
 UPDATE jobs
-SET title        = $2,
-    industry     = $3,
-    company_id   = $4,
-    description  = $5,
-    location     = $6,
-    salary_min   = $7,
-    salary_max   = $8,
-    requirements = $9
+SET title             = $2,
+    industry          = $3,
+    company_id        = $4,
+    description       = $5,
+    location          = $6,
+    salary_min        = $7,
+    salary_max        = $8,
+    requirements      = $9,
+    closes_at         = $10,
+    employment_type   = $11,
+    seniority_level   = $12,
+    remote_policy     = $13,
+    salary_currency   = $14,
+    salary_period     = $15,
+    salary_min_annual = $16,
+    salary_max_annual = $17,
+    latitude          = $18,
+    longitude         = $19
 WHERE id = $1
-RETURNING id, title, industry, company_id, description, location, salary_min, salary_max, requirements, created_at
+RETURNING id, title, industry, company_id, description, location, salary_min, salary_max, requirements, created_at, status, published_at, closes_at, employment_type, seniority_level, remote_policy, salary_currency, salary_period, salary_min_annual, salary_max_annual, latitude, longitude, search_vector
 `
 
 type UpdateJobParams struct {
-	ID           int32  `json:"id"`
-	Title        string `json:"title"`
-	Industry     string `json:"industry"`
-	CompanyID    int32  `json:"company_id"`
-	Description  string `json:"description"`
-	Location     string `json:"location"`
-	SalaryMin    int32  `json:"salary_min"`
-	SalaryMax    int32  `json:"salary_max"`
-	Requirements string `json:"requirements"`
+	ID              int32           `json:"id"`
+	Title           string          `json:"title"`
+	Industry        string          `json:"industry"`
+	CompanyID       int32           `json:"company_id"`
+	Description     string          `json:"description"`
+	Location        string          `json:"location"`
+	SalaryMin       int32           `json:"salary_min"`
+	SalaryMax       int32           `json:"salary_max"`
+	Requirements    string          `json:"requirements"`
+	ClosesAt        sql.NullTime    `json:"closes_at"`
+	EmploymentType  EmploymentType  `json:"employment_type"`
+	SeniorityLevel  SeniorityLevel  `json:"seniority_level"`
+	RemotePolicy    RemotePolicy    `json:"remote_policy"`
+	SalaryCurrency  string          `json:"salary_currency"`
+	SalaryPeriod    SalaryPeriod    `json:"salary_period"`
+	SalaryMinAnnual int32           `json:"salary_min_annual"`
+	SalaryMaxAnnual int32           `json:"salary_max_annual"`
+	Latitude        sql.NullFloat64 `json:"latitude"`
+	Longitude       sql.NullFloat64 `json:"longitude"`
 }
 
 func (q *Queries) UpdateJob(ctx context.Context, arg UpdateJobParams) (Job, error) {
@@ -812,7 +1595,106 @@ func (q *Queries) UpdateJob(ctx context.Context, arg UpdateJobParams) (Job, erro
 		arg.SalaryMin,
 		arg.SalaryMax,
 		arg.Requirements,
+		arg.ClosesAt,
+		arg.EmploymentType,
+		arg.SeniorityLevel,
+		arg.RemotePolicy,
+		arg.SalaryCurrency,
+		arg.SalaryPeriod,
+		arg.SalaryMinAnnual,
+		arg.SalaryMaxAnnual,
+		arg.Latitude,
+		arg.Longitude,
+	)
+	var i Job
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Industry,
+		&i.CompanyID,
+		&i.Description,
+		&i.Location,
+		&i.SalaryMin,
+		&i.SalaryMax,
+		&i.Requirements,
+		&i.CreatedAt,
+		&i.Status,
+		&i.PublishedAt,
+		&i.ClosesAt,
+		&i.EmploymentType,
+		&i.SeniorityLevel,
+		&i.RemotePolicy,
+		&i.SalaryCurrency,
+		&i.SalaryPeriod,
+		&i.SalaryMinAnnual,
+		&i.SalaryMaxAnnual,
+		&i.Latitude,
+		&i.Longitude,
+		&i.SearchVector,
 	)
+	return i, err
+}
+
+const updateJobAnnualSalary = `-- name: UpdateJobAnnualSalary :exec
+UPDATE jobs
+SET salary_min_annual = $2,
+    salary_max_annual = $3
+WHERE id = $1
+`
+
+type UpdateJobAnnualSalaryParams struct {
+	ID              int32 `json:"id"`
+	SalaryMinAnnual int32 `json:"salary_min_annual"`
+	SalaryMaxAnnual int32 `json:"salary_max_annual"`
+}
+
+func (q *Queries) UpdateJobAnnualSalary(ctx context.Context, arg UpdateJobAnnualSalaryParams) error {
+	_, err := q.db.ExecContext(ctx, updateJobAnnualSalary, arg.ID, arg.SalaryMinAnnual, arg.SalaryMaxAnnual)
+	return err
+}
+
+const updateJobLocation = `-- name: UpdateJobLocation :exec
+UPDATE jobs
+SET location  = $2,
+    latitude  = $3,
+    longitude = $4
+WHERE id = $1
+`
+
+type UpdateJobLocationParams struct {
+	ID        int32           `json:"id"`
+	Location  string          `json:"location"`
+	Latitude  sql.NullFloat64 `json:"latitude"`
+	Longitude sql.NullFloat64 `json:"longitude"`
+}
+
+func (q *Queries) UpdateJobLocation(ctx context.Context, arg UpdateJobLocationParams) error {
+	_, err := q.db.ExecContext(ctx, updateJobLocation,
+		arg.ID,
+		arg.Location,
+		arg.Latitude,
+		arg.Longitude,
+	)
+	return err
+}
+
+const updateJobStatus = `-- name: UpdateJobStatus :one
+UPDATE jobs
+SET status       = $2,
+    published_at = CASE WHEN $2 = 'published'::job_status THEN COALESCE(published_at, now()) ELSE published_at END,
+    closes_at    = COALESCE($3, closes_at)
+WHERE id = $1
+RETURNING id, title, industry, company_id, description, location, salary_min, salary_max, requirements, created_at, status, published_at, closes_at, employment_type, seniority_level, remote_policy, salary_currency, salary_period, salary_min_annual, salary_max_annual, latitude, longitude, search_vector
+`
+
+type UpdateJobStatusParams struct {
+	ID       int32        `json:"id"`
+	Status   JobStatus    `json:"status"`
+	ClosesAt sql.NullTime `json:"closes_at"`
+}
+
+func (q *Queries) UpdateJobStatus(ctx context.Context, arg UpdateJobStatusParams) (Job, error) {
+	row := q.db.QueryRowContext(ctx, updateJobStatus, arg.ID, arg.Status, arg.ClosesAt)
 	var i Job
 	err := row.Scan(
 		&i.ID,
@@ -825,6 +1707,19 @@ func (q *Queries) UpdateJob(ctx context.Context, arg UpdateJobParams) (Job, erro
 		&i.SalaryMax,
 		&i.Requirements,
 		&i.CreatedAt,
+		&i.Status,
+		&i.PublishedAt,
+		&i.ClosesAt,
+		&i.EmploymentType,
+		&i.SeniorityLevel,
+		&i.RemotePolicy,
+		&i.SalaryCurrency,
+		&i.SalaryPeriod,
+		&i.SalaryMinAnnual,
+		&i.SalaryMaxAnnual,
+		&i.Latitude,
+		&i.Longitude,
+		&i.SearchVector,
 	)
 	return i, err
 }
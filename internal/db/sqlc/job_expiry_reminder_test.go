@@ -0,0 +1,23 @@
+package db
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestQueries_CreateJobExpiryReminder(t *testing.T) {
+	job := setJobClosesAt(t, createRandomJob(t, nil, jobDetails{}), time.Now().Add(time.Hour))
+	params := CreateJobExpiryReminderParams{
+		JobID:    job.ID,
+		ClosesAt: job.ClosesAt.Time,
+	}
+
+	err := testQueries.CreateJobExpiryReminder(context.Background(), params)
+	require.NoError(t, err)
+
+	// only one reminder can be saved for the same closes_at
+	err = testQueries.CreateJobExpiryReminder(context.Background(), params)
+	require.Error(t, err)
+}
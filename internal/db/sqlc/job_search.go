@@ -0,0 +1,274 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/lib/pq"
+	"html"
+	"strings"
+)
+
+// The full-text search queries share the optional filters, which sqlc could not generate.
+// Because of that, they are implemented manually.
+//
+// jobSearchMatches selects the published jobs matching the query ($1) and the filters ($2 - $15),
+// the tsquery is available as query.
+const jobSearchMatches = `FROM jobs j
+         JOIN companies c ON j.company_id = c.id
+         CROSS JOIN websearch_to_tsquery('english', $1::text) query
+WHERE j.search_vector @@ query
+  AND ($2::text IS NULL OR j.industry = $2)
+  AND ($3::text IS NULL OR j.location = $3)
+  AND ($4::text IS NULL OR c.name = $4)
+  AND ($5::int IS NULL OR j.salary_min_annual >= $5)
+  AND ($6::int IS NULL OR j.salary_max_annual <= $6)
+  AND ($7::employment_type IS NULL OR j.employment_type = $7)
+  AND ($8::seniority_level IS NULL OR j.seniority_level = $8)
+  AND ($9::remote_policy IS NULL OR j.remote_policy = $9)
+  AND ($10::text IS NULL OR j.salary_currency = $10)
+  AND ($11::salary_period IS NULL OR j.salary_period = $11)
+  AND NOT EXISTS(SELECT 1
+                 FROM unnest($12::text[]) required(skill)
+                 WHERE required.skill NOT IN (SELECT s.skill FROM job_skills s WHERE s.job_id = j.id))
+  AND ($13::float8 IS NULL OR (
+        j.latitude BETWEEN $13::float8 - $15::float8 / 111.0 AND $13::float8 + $15::float8 / 111.0
+        AND 2 * 6371 * asin(sqrt(
+                power(sin(radians(j.latitude - $13::float8) / 2), 2) +
+                cos(radians($13::float8)) * cos(radians(j.latitude)) *
+                power(sin(radians(j.longitude - $14::float8) / 2), 2)
+            )) <= $15::float8
+    ))
+  AND j.status = 'published'
+  AND (j.closes_at IS NULL OR j.closes_at > now())`
+
+// JobSearchFilters are the filters of the full-text search of the jobs,
+// a job has to have all the skills
+type JobSearchFilters struct {
+	Industry       sql.NullString     `json:"industry"`
+	JobLocation    sql.NullString     `json:"job_location"`
+	CompanyName    sql.NullString     `json:"company_name"`
+	SalaryMin      sql.NullInt32      `json:"salary_min"`
+	SalaryMax      sql.NullInt32      `json:"salary_max"`
+	EmploymentType NullEmploymentType `json:"employment_type"`
+	SeniorityLevel NullSeniorityLevel `json:"seniority_level"`
+	RemotePolicy   NullRemotePolicy   `json:"remote_policy"`
+	SalaryCurrency sql.NullString     `json:"salary_currency"`
+	SalaryPeriod   NullSalaryPeriod   `json:"salary_period"`
+	Skills         []string           `json:"skills"`
+	NearLatitude   sql.NullFloat64    `json:"near_latitude"`
+	NearLongitude  sql.NullFloat64    `json:"near_longitude"`
+	RadiusKm       sql.NullFloat64    `json:"radius_km"`
+}
+
+// args returns the arguments of jobSearchMatches
+func (filters JobSearchFilters) args(query string) []interface{} {
+	return []interface{}{
+		query,
+		filters.Industry,
+		filters.JobLocation,
+		filters.CompanyName,
+		filters.SalaryMin,
+		filters.SalaryMax,
+		filters.EmploymentType,
+		filters.SeniorityLevel,
+		filters.RemotePolicy,
+		filters.SalaryCurrency,
+		filters.SalaryPeriod,
+		pq.Array(filters.Skills),
+		filters.NearLatitude,
+		filters.NearLongitude,
+		filters.RadiusKm,
+	}
+}
+
+const listJobSearchFacets = `-- name: ListJobSearchFacets :many
+WITH matched AS (SELECT j.id, j.industry, j.location, c.name AS company_name, j.salary_min_annual
+                 ` + jobSearchMatches + `)
+SELECT 'total' AS facet, '' AS value, COUNT(*) AS count
+FROM matched
+UNION ALL
+SELECT 'industry', industry, COUNT(*)
+FROM matched
+GROUP BY industry
+UNION ALL
+SELECT 'location', location, COUNT(*)
+FROM matched
+GROUP BY location
+UNION ALL
+SELECT 'company', company_name, COUNT(*)
+FROM matched
+GROUP BY company_name
+UNION ALL
+SELECT 'skill', s.skill, COUNT(DISTINCT s.job_id)
+FROM matched m
+         JOIN job_skills s ON s.job_id = m.id
+GROUP BY s.skill
+UNION ALL
+SELECT 'salary', width_bucket(salary_min_annual, $16::int[])::text, COUNT(*)
+FROM matched
+GROUP BY width_bucket(salary_min_annual, $16::int[])
+`
+
+type ListJobSearchFacetsParams struct {
+	Query   string           `json:"query"`
+	Filters JobSearchFilters `json:"filters"`
+	// SalaryBounds are the ascending bounds of the salary ranges, the value of a salary facet
+	// is the number of the bounds that are lower than or equal to the min annual salary
+	SalaryBounds []int32 `json:"salary_bounds"`
+}
+
+type ListJobSearchFacetsRow struct {
+	Facet string `json:"facet"`
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// ListJobSearchFacets counts the jobs matching the full-text search by the values of the facets:
+// industry, location, company, skill and salary. The count of the total facet is the number of the jobs.
+func (store *SQLStore) ListJobSearchFacets(ctx context.Context, arg ListJobSearchFacetsParams) ([]ListJobSearchFacetsRow, error) {
+	args := append(arg.Filters.args(arg.Query), pq.Array(arg.SalaryBounds))
+	rows, err := store.db.QueryContext(ctx, listJobSearchFacets, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListJobSearchFacetsRow{}
+	for rows.Next() {
+		var i ListJobSearchFacetsRow
+		if err := rows.Scan(&i.Facet, &i.Value, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchJobsFullText = `-- name: SearchJobsFullText :many
+WITH page AS (SELECT j.id, ts_rank(j.search_vector, query, 1) AS rank
+              ` + jobSearchMatches + `
+              ORDER BY rank DESC, j.id
+              LIMIT $16 OFFSET $17)
+SELECT j.id, j.title, j.industry, j.description, j.location, j.salary_min, j.salary_max, j.requirements, j.employment_type, j.seniority_level, j.remote_policy, j.salary_currency, j.salary_period, j.salary_min_annual, j.salary_max_annual, j.latitude, j.longitude,
+       c.name AS company_name,
+       ARRAY(SELECT s.skill FROM job_skills s WHERE s.job_id = j.id ORDER BY s.id)::text[] AS skills,
+       page.rank,
+       ts_headline('english', translate(j.title, E'\x02\x03', ''), websearch_to_tsquery('english', $1::text),
+                   E'StartSel=\x02, StopSel=\x03, HighlightAll=true') AS title_headline,
+       ts_headline('english', translate(j.description, E'\x02\x03', ''), websearch_to_tsquery('english', $1::text),
+                   E'StartSel=\x02, StopSel=\x03, MaxFragments=3, MaxWords=25, MinWords=10') AS description_headline,
+       ts_headline('english', translate(j.requirements, E'\x02\x03', ''), websearch_to_tsquery('english', $1::text),
+                   E'StartSel=\x02, StopSel=\x03, MaxFragments=3, MaxWords=25, MinWords=10') AS requirements_headline
+FROM page
+         JOIN jobs j ON j.id = page.id
+         JOIN companies c ON j.company_id = c.id
+ORDER BY page.rank DESC, j.id
+`
+
+type SearchJobsFullTextParams struct {
+	Query   string           `json:"query"`
+	Filters JobSearchFilters `json:"filters"`
+	Limit   int32            `json:"limit"`
+	Offset  int32            `json:"offset"`
+}
+
+type SearchJobsFullTextRow struct {
+	ID                   int32           `json:"id"`
+	Title                string          `json:"title"`
+	Industry             string          `json:"industry"`
+	Description          string          `json:"description"`
+	Location             string          `json:"location"`
+	SalaryMin            int32           `json:"salary_min"`
+	SalaryMax            int32           `json:"salary_max"`
+	Requirements         string          `json:"requirements"`
+	EmploymentType       EmploymentType  `json:"employment_type"`
+	SeniorityLevel       SeniorityLevel  `json:"seniority_level"`
+	RemotePolicy         RemotePolicy    `json:"remote_policy"`
+	SalaryCurrency       string          `json:"salary_currency"`
+	SalaryPeriod         SalaryPeriod    `json:"salary_period"`
+	SalaryMinAnnual      int32           `json:"salary_min_annual"`
+	SalaryMaxAnnual      int32           `json:"salary_max_annual"`
+	Latitude             sql.NullFloat64 `json:"latitude"`
+	Longitude            sql.NullFloat64 `json:"longitude"`
+	CompanyName          string          `json:"company_name"`
+	Skills               []string        `json:"skills"`
+	Rank                 float32         `json:"rank"`
+	TitleHeadline        string          `json:"title_headline"`
+	DescriptionHeadline  string          `json:"description_headline"`
+	RequirementsHeadline string          `json:"requirements_headline"`
+}
+
+// The matches of the headlines are marked with the STX and ETX control characters, which are removed
+// from the texts before, so text like <em> written by employers cannot be taken for a match.
+const (
+	headlineStartSel = "\x02"
+	headlineStopSel  = "\x03"
+)
+
+// headlineTags replaces the marks of the matches of the headlines with the <em> tags after they were escaped
+var headlineTags = strings.NewReplacer(headlineStartSel, "<em>", headlineStopSel, "</em>")
+
+// escapeHeadline HTML escapes the headline, the text is written by employers.
+// Only the marks of the matches become the <em> tags, so they are the only HTML of the headline
+func escapeHeadline(headline string) string {
+	return headlineTags.Replace(html.EscapeString(headline))
+}
+
+// SearchJobsFullText searches for the published jobs with the full-text search of postgres,
+// the jobs are ranked with ts_rank of their search vectors and the headlines have the matches in <em> tags,
+// the rest of the headlines is HTML escaped
+func (store *SQLStore) SearchJobsFullText(ctx context.Context, arg SearchJobsFullTextParams) ([]SearchJobsFullTextRow, error) {
+	args := append(arg.Filters.args(arg.Query), arg.Limit, arg.Offset)
+	rows, err := store.db.QueryContext(ctx, searchJobsFullText, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchJobsFullTextRow{}
+	for rows.Next() {
+		var i SearchJobsFullTextRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Industry,
+			&i.Description,
+			&i.Location,
+			&i.SalaryMin,
+			&i.SalaryMax,
+			&i.Requirements,
+			&i.EmploymentType,
+			&i.SeniorityLevel,
+			&i.RemotePolicy,
+			&i.SalaryCurrency,
+			&i.SalaryPeriod,
+			&i.SalaryMinAnnual,
+			&i.SalaryMaxAnnual,
+			&i.Latitude,
+			&i.Longitude,
+			&i.CompanyName,
+			pq.Array(&i.Skills),
+			&i.Rank,
+			&i.TitleHeadline,
+			&i.DescriptionHeadline,
+			&i.RequirementsHeadline,
+		); err != nil {
+			return nil, err
+		}
+		i.TitleHeadline = escapeHeadline(i.TitleHeadline)
+		i.DescriptionHeadline = escapeHeadline(i.DescriptionHeadline)
+		i.RequirementsHeadline = escapeHeadline(i.RequirementsHeadline)
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
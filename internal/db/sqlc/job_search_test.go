@@ -0,0 +1,125 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSQLStore_SearchJobsFullText(t *testing.T) {
+	company := createRandomCompany(t, "")
+	word := utils.RandomString(8)
+	titleJob := createRandomJob(t, &company, jobDetails{title: "Senior " + word + " Developer"})
+	skillJob := createRandomJob(t, &company, jobDetails{})
+	// the skills are added after the job, the trigger of the skills updates the search vector
+	jobSkill := createRandomJobSkill(t, &skillJob, word)
+	createRandomJob(t, &company, jobDetails{})
+
+	filters := JobSearchFilters{
+		CompanyName: sql.NullString{
+			String: company.Name,
+			Valid:  true,
+		},
+	}
+
+	jobs, err := testStore.SearchJobsFullText(context.Background(), SearchJobsFullTextParams{
+		Query:   word,
+		Filters: filters,
+		Limit:   10,
+		Offset:  0,
+	})
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	// the matches in the title rank higher than the matches in the skills
+	require.Equal(t, titleJob.ID, jobs[0].ID)
+	require.Equal(t, skillJob.ID, jobs[1].ID)
+	require.Greater(t, jobs[0].Rank, jobs[1].Rank)
+	require.Equal(t, "Senior <em>"+word+"</em> Developer", jobs[0].TitleHeadline)
+	require.Equal(t, []string{word}, jobs[1].Skills)
+	require.Equal(t, company.Name, jobs[1].CompanyName)
+
+	facets, err := testStore.ListJobSearchFacets(context.Background(), ListJobSearchFacetsParams{
+		Query:        word,
+		Filters:      filters,
+		SalaryBounds: []int32{1000},
+	})
+	require.NoError(t, err)
+	require.Contains(t, facets, ListJobSearchFacetsRow{Facet: "total", Value: "", Count: 2})
+	require.Contains(t, facets, ListJobSearchFacetsRow{Facet: "company", Value: company.Name, Count: 2})
+	require.Contains(t, facets, ListJobSearchFacetsRow{Facet: "skill", Value: word, Count: 1})
+	// the annual salaries of the random jobs are lower than the only bound
+	require.Contains(t, facets, ListJobSearchFacetsRow{Facet: "salary", Value: "0", Count: 2})
+
+	filters.Skills = []string{word}
+	jobs, err = testStore.SearchJobsFullText(context.Background(), SearchJobsFullTextParams{
+		Query:   word,
+		Filters: filters,
+		Limit:   10,
+		Offset:  0,
+	})
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	require.Equal(t, skillJob.ID, jobs[0].ID)
+
+	// removing the skill removes it from the search vector
+	err = testQueries.DeleteJobSkill(context.Background(), jobSkill.ID)
+	require.NoError(t, err)
+	filters.Skills = nil
+	jobs, err = testStore.SearchJobsFullText(context.Background(), SearchJobsFullTextParams{
+		Query:   word,
+		Filters: filters,
+		Limit:   10,
+		Offset:  0,
+	})
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	require.Equal(t, titleJob.ID, jobs[0].ID)
+}
+
+func TestSQLStore_SearchJobsFullTextUpdatedJob(t *testing.T) {
+	company := createRandomCompany(t, "")
+	job := createRandomJob(t, &company, jobDetails{})
+	word := utils.RandomString(8)
+
+	filters := JobSearchFilters{
+		CompanyName: sql.NullString{
+			String: company.Name,
+			Valid:  true,
+		},
+	}
+	search := func() []SearchJobsFullTextRow {
+		jobs, err := testStore.SearchJobsFullText(context.Background(), SearchJobsFullTextParams{
+			Query:   word,
+			Filters: filters,
+			Limit:   10,
+			Offset:  0,
+		})
+		require.NoError(t, err)
+		return jobs
+	}
+	require.Empty(t, search())
+
+	_, err := testDB.Exec("UPDATE jobs SET description = $1 WHERE id = $2", "We are looking for a "+word+" expert.", job.ID)
+	require.NoError(t, err)
+
+	jobs := search()
+	require.Len(t, jobs, 1)
+	require.Equal(t, job.ID, jobs[0].ID)
+	require.Contains(t, jobs[0].DescriptionHeadline, "<em>"+word+"</em>")
+
+	// the jobs that are not published are not searched
+	_, err = testDB.Exec("UPDATE jobs SET status = 'closed' WHERE id = $1", job.ID)
+	require.NoError(t, err)
+	require.Empty(t, search())
+}
+
+func TestEscapeHeadline(t *testing.T) {
+	require.Equal(t, "Senior <em>Go</em> Developer", escapeHeadline("Senior \x02Go\x03 Developer"))
+	require.Equal(t, "&lt;script&gt;alert(&#34;<em>go</em>&#34;)&lt;/script&gt; &amp; more",
+		escapeHeadline("<script>alert(\"\x02go\x03\")</script> & more"))
+	// the tags written by employers are not matches
+	require.Equal(t, "&lt;em&gt;Senior&lt;/em&gt; <em>Go</em> Developer",
+		escapeHeadline("<em>Senior</em> \x02Go\x03 Developer"))
+}
@@ -168,6 +168,93 @@ func (q *Queries) ListJobsBySkill(ctx context.Context, arg ListJobsBySkillParams
 	return items, nil
 }
 
+const listJobsBySkillOverlap = `-- name: ListJobsBySkillOverlap :many
+SELECT j.id,
+       j.title,
+       j.industry,
+       j.location,
+       j.salary_min,
+       j.salary_max,
+       j.salary_currency,
+       j.salary_period,
+       j.employment_type,
+       j.seniority_level,
+       j.remote_policy,
+       c.name                                                      AS company_name,
+       array_agg(s.skill ORDER BY s.skill)::text[]                 AS shared_skills,
+       (SELECT COUNT(*) FROM job_skills js WHERE js.job_id = j.id) AS skills_count
+FROM job_skills s
+         JOIN jobs j ON s.job_id = j.id
+         JOIN companies c ON j.company_id = c.id
+WHERE s.skill IN (SELECT skill FROM job_skills WHERE job_skills.job_id = $1)
+  AND j.id <> $1
+  AND j.status = 'published'
+  AND (j.closes_at IS NULL OR j.closes_at > now())
+GROUP BY j.id, c.name
+ORDER BY COUNT(*) DESC, j.id
+LIMIT $2
+`
+
+type ListJobsBySkillOverlapParams struct {
+	JobID int32 `json:"job_id"`
+	Size  int32 `json:"size"`
+}
+
+type ListJobsBySkillOverlapRow struct {
+	ID             int32          `json:"id"`
+	Title          string         `json:"title"`
+	Industry       string         `json:"industry"`
+	Location       string         `json:"location"`
+	SalaryMin      int32          `json:"salary_min"`
+	SalaryMax      int32          `json:"salary_max"`
+	SalaryCurrency string         `json:"salary_currency"`
+	SalaryPeriod   SalaryPeriod   `json:"salary_period"`
+	EmploymentType EmploymentType `json:"employment_type"`
+	SeniorityLevel SeniorityLevel `json:"seniority_level"`
+	RemotePolicy   RemotePolicy   `json:"remote_policy"`
+	CompanyName    string         `json:"company_name"`
+	SharedSkills   []string       `json:"shared_skills"`
+	SkillsCount    int64          `json:"skills_count"`
+}
+
+func (q *Queries) ListJobsBySkillOverlap(ctx context.Context, arg ListJobsBySkillOverlapParams) ([]ListJobsBySkillOverlapRow, error) {
+	rows, err := q.db.QueryContext(ctx, listJobsBySkillOverlap, arg.JobID, arg.Size)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListJobsBySkillOverlapRow{}
+	for rows.Next() {
+		var i ListJobsBySkillOverlapRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Industry,
+			&i.Location,
+			&i.SalaryMin,
+			&i.SalaryMax,
+			&i.SalaryCurrency,
+			&i.SalaryPeriod,
+			&i.EmploymentType,
+			&i.SeniorityLevel,
+			&i.RemotePolicy,
+			&i.CompanyName,
+			pq.Array(&i.SharedSkills),
+			&i.SkillsCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateJobSkill = `-- name: UpdateJobSkill :one
 UPDATE job_skills
 SET skill = $2
@@ -90,6 +90,41 @@ func TestQueries_ListJobsBySkill(t *testing.T) {
 	}
 }
 
+func TestQueries_ListJobsBySkillOverlap(t *testing.T) {
+	first := utils.RandomString(8)
+	second := utils.RandomString(8)
+	job := createRandomJob(t, nil, jobDetails{})
+	createRandomJobSkill(t, &job, first)
+	createRandomJobSkill(t, &job, second)
+
+	bothSkillsJob := createRandomJob(t, nil, jobDetails{})
+	createRandomJobSkill(t, &bothSkillsJob, second)
+	createRandomJobSkill(t, &bothSkillsJob, first)
+	oneSkillJob := createRandomJob(t, nil, jobDetails{})
+	createRandomJobSkill(t, &oneSkillJob, first)
+	createRandomJobSkill(t, &oneSkillJob, "")
+	createRandomJobSkill(t, &oneSkillJob, "")
+	draftJob := createRandomJob(t, nil, jobDetails{status: JobStatusDraft})
+	createRandomJobSkill(t, &draftJob, first)
+
+	params := ListJobsBySkillOverlapParams{
+		JobID: job.ID,
+		Size:  10,
+	}
+
+	jobs, err := testQueries.ListJobsBySkillOverlap(context.Background(), params)
+	require.NoError(t, err)
+	// the job itself and the jobs that are not published are not listed
+	require.Len(t, jobs, 2)
+	require.Equal(t, bothSkillsJob.ID, jobs[0].ID)
+	require.ElementsMatch(t, []string{first, second}, jobs[0].SharedSkills)
+	require.Equal(t, int64(2), jobs[0].SkillsCount)
+	require.Equal(t, oneSkillJob.ID, jobs[1].ID)
+	require.Equal(t, []string{first}, jobs[1].SharedSkills)
+	require.Equal(t, int64(3), jobs[1].SkillsCount)
+	require.Equal(t, oneSkillJob.Title, jobs[1].Title)
+}
+
 func TestQueries_UpdateJobSkill(t *testing.T) {
 	jobSkill := createRandomJobSkill(t, nil, "")
 	params := UpdateJobSkillParams{
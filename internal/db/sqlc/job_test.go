@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"database/sql"
+	"github.com/aalug/job-finder-go/internal/geo"
 	"github.com/aalug/job-finder-go/pkg/utils"
 	"github.com/stretchr/testify/require"
 	"strings"
@@ -11,11 +12,15 @@ import (
 )
 
 type jobDetails struct {
-	title     string
-	industry  string
-	location  string
-	salaryMin int32
-	salaryMax int32
+	title          string
+	industry       string
+	location       string
+	salaryMin      int32
+	salaryMax      int32
+	status         JobStatus
+	employmentType EmploymentType
+	remotePolicy   RemotePolicy
+	city           *geo.City
 }
 
 // createRandomJob  creates and return a random job
@@ -28,9 +33,14 @@ func createRandomJob(t *testing.T, company *Company, details jobDetails) Job {
 	}
 
 	params := CreateJobParams{
-		CompanyID:    c.ID,
-		Description:  utils.RandomString(7),
-		Requirements: utils.RandomString(5),
+		CompanyID:      c.ID,
+		Description:    utils.RandomString(7),
+		Requirements:   utils.RandomString(5),
+		EmploymentType: EmploymentTypeFullTime,
+		SeniorityLevel: SeniorityLevelMid,
+		RemotePolicy:   RemotePolicyOnsite,
+		SalaryCurrency: "EUR",
+		SalaryPeriod:   SalaryPeriodMonth,
 	}
 
 	if details.title != "" {
@@ -56,6 +66,31 @@ func createRandomJob(t *testing.T, company *Company, details jobDetails) Job {
 		params.SalaryMin = utils.RandomInt(100, 110)
 		params.SalaryMax = utils.RandomInt(100, 110)
 	}
+	// the filters compare the annual salaries, keep them equal to the details
+	params.SalaryMinAnnual = params.SalaryMin
+	params.SalaryMaxAnnual = params.SalaryMax
+	if details.status != "" {
+		params.Status = details.status
+	} else {
+		params.Status = JobStatusPublished
+	}
+	if details.employmentType != "" {
+		params.EmploymentType = details.employmentType
+	}
+	if details.remotePolicy != "" {
+		params.RemotePolicy = details.remotePolicy
+	}
+	if details.city != nil {
+		params.Location = details.city.String()
+		params.Latitude = sql.NullFloat64{Float64: details.city.Latitude, Valid: true}
+		params.Longitude = sql.NullFloat64{Float64: details.city.Longitude, Valid: true}
+	}
+	if params.Status == JobStatusPublished {
+		params.PublishedAt = sql.NullTime{
+			Time:  time.Now(),
+			Valid: true,
+		}
+	}
 
 	job, err := testQueries.CreateJob(context.Background(), params)
 
@@ -70,11 +105,53 @@ func createRandomJob(t *testing.T, company *Company, details jobDetails) Job {
 	require.Equal(t, job.SalaryMin, params.SalaryMin)
 	require.Equal(t, job.SalaryMax, params.SalaryMax)
 	require.Equal(t, job.Requirements, params.Requirements)
+	require.Equal(t, job.Status, params.Status)
+	require.Equal(t, job.PublishedAt.Valid, params.PublishedAt.Valid)
+	require.False(t, job.ClosesAt.Valid)
+	require.Equal(t, job.EmploymentType, params.EmploymentType)
+	require.Equal(t, job.SeniorityLevel, params.SeniorityLevel)
+	require.Equal(t, job.RemotePolicy, params.RemotePolicy)
+	require.Equal(t, job.SalaryCurrency, params.SalaryCurrency)
+	require.Equal(t, job.SalaryPeriod, params.SalaryPeriod)
+	require.Equal(t, job.SalaryMinAnnual, params.SalaryMinAnnual)
+	require.Equal(t, job.SalaryMaxAnnual, params.SalaryMaxAnnual)
+	require.Equal(t, job.Latitude, params.Latitude)
+	require.Equal(t, job.Longitude, params.Longitude)
 	require.WithinDuration(t, job.CreatedAt, time.Now(), 2*time.Second)
 
 	return job
 }
 
+// setJobClosesAt updates closes_at of the job
+func setJobClosesAt(t *testing.T, job Job, closesAt time.Time) Job {
+	job, err := testQueries.UpdateJob(context.Background(), UpdateJobParams{
+		ID:           job.ID,
+		Title:        job.Title,
+		Industry:     job.Industry,
+		CompanyID:    job.CompanyID,
+		Description:  job.Description,
+		Location:     job.Location,
+		SalaryMin:    job.SalaryMin,
+		SalaryMax:    job.SalaryMax,
+		Requirements: job.Requirements,
+		ClosesAt: sql.NullTime{
+			Time:  closesAt,
+			Valid: true,
+		},
+		EmploymentType:  job.EmploymentType,
+		SeniorityLevel:  job.SeniorityLevel,
+		RemotePolicy:    job.RemotePolicy,
+		SalaryCurrency:  job.SalaryCurrency,
+		SalaryPeriod:    job.SalaryPeriod,
+		SalaryMinAnnual: job.SalaryMinAnnual,
+		SalaryMaxAnnual: job.SalaryMaxAnnual,
+		Latitude:        job.Latitude,
+		Longitude:       job.Longitude,
+	})
+	require.NoError(t, err)
+	return job
+}
+
 func TestQueries_CreateJob(t *testing.T) {
 	createRandomJob(t, nil, jobDetails{})
 }
@@ -82,7 +159,16 @@ func TestQueries_CreateJob(t *testing.T) {
 func TestQueries_GetJobDetails(t *testing.T) {
 	company := createRandomCompany(t, "")
 	job := createRandomJob(t, &company, jobDetails{})
+	// the recruiter joined first, but the owner is the employer of the job
+	recruiter := createRandomEmployer(t, job.CompanyID)
+	_, err := testQueries.UpdateEmployerRole(context.Background(), UpdateEmployerRoleParams{
+		ID:        recruiter.ID,
+		CompanyID: job.CompanyID,
+		Role:      CompanyRoleRecruiter,
+	})
+	require.NoError(t, err)
 	employer := createRandomEmployer(t, job.CompanyID)
+	createRandomEmployer(t, job.CompanyID)
 
 	job2, err := testQueries.GetJobDetails(context.Background(), job.ID)
 
@@ -139,6 +225,19 @@ func TestQueries_UpdateJob(t *testing.T) {
 		SalaryMin:    utils.RandomInt(100, 110),
 		SalaryMax:    utils.RandomInt(110, 120),
 		Requirements: job.Requirements,
+		ClosesAt: sql.NullTime{
+			Time:  time.Now().Add(24 * time.Hour),
+			Valid: true,
+		},
+		EmploymentType:  EmploymentTypeContract,
+		SeniorityLevel:  SeniorityLevelSenior,
+		RemotePolicy:    RemotePolicyRemote,
+		SalaryCurrency:  "USD",
+		SalaryPeriod:    SalaryPeriodHour,
+		SalaryMinAnnual: utils.RandomInt(200000, 210000),
+		SalaryMaxAnnual: utils.RandomInt(210000, 220000),
+		Latitude:        sql.NullFloat64{Float64: 52.52, Valid: true},
+		Longitude:       sql.NullFloat64{Float64: 13.405, Valid: true},
 	}
 
 	job2, err := testQueries.UpdateJob(context.Background(), params)
@@ -153,9 +252,51 @@ func TestQueries_UpdateJob(t *testing.T) {
 	require.Equal(t, params.SalaryMin, job2.SalaryMin)
 	require.Equal(t, params.SalaryMax, job2.SalaryMax)
 	require.Equal(t, params.Requirements, job2.Requirements)
+	require.WithinDuration(t, params.ClosesAt.Time, job2.ClosesAt.Time, time.Second)
+	require.Equal(t, params.EmploymentType, job2.EmploymentType)
+	require.Equal(t, params.SeniorityLevel, job2.SeniorityLevel)
+	require.Equal(t, params.RemotePolicy, job2.RemotePolicy)
+	require.Equal(t, params.SalaryCurrency, job2.SalaryCurrency)
+	require.Equal(t, params.SalaryPeriod, job2.SalaryPeriod)
+	require.Equal(t, params.SalaryMinAnnual, job2.SalaryMinAnnual)
+	require.Equal(t, params.SalaryMaxAnnual, job2.SalaryMaxAnnual)
+	require.Equal(t, params.Latitude, job2.Latitude)
+	require.Equal(t, params.Longitude, job2.Longitude)
+	require.Equal(t, job.Status, job2.Status)
 	require.WithinDuration(t, job.CreatedAt, job2.CreatedAt, time.Second)
 }
 
+func TestQueries_UpdateJobStatus(t *testing.T) {
+	job := createRandomJob(t, nil, jobDetails{status: JobStatusDraft})
+	require.False(t, job.PublishedAt.Valid)
+
+	// publishing sets published_at
+	closesAt := time.Now().Add(24 * time.Hour)
+	job2, err := testQueries.UpdateJobStatus(context.Background(), UpdateJobStatusParams{
+		ID:     job.ID,
+		Status: JobStatusPublished,
+		ClosesAt: sql.NullTime{
+			Time:  closesAt,
+			Valid: true,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, JobStatusPublished, job2.Status)
+	require.True(t, job2.PublishedAt.Valid)
+	require.WithinDuration(t, time.Now(), job2.PublishedAt.Time, 2*time.Second)
+	require.WithinDuration(t, closesAt, job2.ClosesAt.Time, time.Second)
+
+	// other statuses keep published_at and closes_at
+	job3, err := testQueries.UpdateJobStatus(context.Background(), UpdateJobStatusParams{
+		ID:     job.ID,
+		Status: JobStatusClosed,
+	})
+	require.NoError(t, err)
+	require.Equal(t, JobStatusClosed, job3.Status)
+	require.WithinDuration(t, job2.PublishedAt.Time, job3.PublishedAt.Time, time.Microsecond)
+	require.WithinDuration(t, job2.ClosesAt.Time, job3.ClosesAt.Time, time.Microsecond)
+}
+
 func TestQueries_DeleteJob(t *testing.T) {
 	job := createRandomJob(t, nil, jobDetails{})
 	err := testQueries.DeleteJob(context.Background(), job.ID)
@@ -257,9 +398,20 @@ func TestQueries_ListJobsByCompanyID(t *testing.T) {
 		}
 	}
 
+	// jobs that are not published or are past their closes_at are not listed
+	createRandomJob(t, &company, jobDetails{status: JobStatusDraft})
+	closedJob := createRandomJob(t, &company, jobDetails{})
+	_, err := testQueries.UpdateJobStatus(context.Background(), UpdateJobStatusParams{
+		ID:     closedJob.ID,
+		Status: JobStatusClosed,
+	})
+	require.NoError(t, err)
+	passedJob := createRandomJob(t, &company, jobDetails{})
+	setJobClosesAt(t, passedJob, time.Now().Add(-time.Hour))
+
 	params := ListJobsByCompanyIDParams{
 		CompanyID: company.ID,
-		Limit:     5,
+		Limit:     10,
 		Offset:    0,
 	}
 
@@ -270,6 +422,7 @@ func TestQueries_ListJobsByCompanyID(t *testing.T) {
 	for _, job := range jobs {
 		require.NotEmpty(t, job)
 		require.Equal(t, company.ID, job.CompanyID)
+		require.Equal(t, JobStatusPublished, job.Status)
 	}
 }
 
@@ -367,13 +520,24 @@ func TestQueries_ListJobsMatchingUserSkills(t *testing.T) {
 	user := createRandomUser(t)
 	createRandomUserSkill(t, user.ID, skillName)
 
+	// salary ranges of the jobs overlap with the desired salary of the user
 	var jobIDs []int32
 	for i := 0; i < 5; i++ {
-		job := createRandomJob(t, nil, jobDetails{})
+		job := createRandomJob(t, nil, jobDetails{
+			salaryMin: 1,
+			salaryMax: user.DesiredSalaryMin + 10,
+		})
 		jobIDs = append(jobIDs, job.ID)
 		createRandomJobSkill(t, &job, skillName)
 	}
 
+	// too well paid for the user's desired salary
+	job := createRandomJob(t, nil, jobDetails{
+		salaryMin: user.DesiredSalaryMax + 1000,
+		salaryMax: user.DesiredSalaryMax + 2000,
+	})
+	createRandomJobSkill(t, &job, skillName)
+
 	params := ListJobsMatchingUserSkillsParams{
 		UserID: user.ID,
 		Limit:  5,
@@ -383,6 +547,7 @@ func TestQueries_ListJobsMatchingUserSkills(t *testing.T) {
 	jobs, err := testQueries.ListJobsMatchingUserSkills(context.Background(), params)
 	require.NoError(t, err)
 	require.Len(t, jobs, 5)
+	require.NotContains(t, jobIDs, job.ID)
 	for _, job := range jobs {
 		require.NotEmpty(t, job)
 		require.Contains(t, jobIDs, job.ID)
@@ -409,9 +574,35 @@ func TestQueries_ListAllJobsForES(t *testing.T) {
 		require.NotZero(t, job.SalaryMax)
 		require.NotEmpty(t, job.Industry)
 		require.NotEmpty(t, job.Requirements)
+		require.NotEmpty(t, job.EmploymentType)
+		require.NotEmpty(t, job.SeniorityLevel)
+		require.NotEmpty(t, job.RemotePolicy)
+		require.Len(t, job.SalaryCurrency, 3)
+		require.NotEmpty(t, job.SalaryPeriod)
 	}
 }
 
+func TestQueries_GetJobForES(t *testing.T) {
+	company := createRandomCompany(t, "")
+	job := createRandomJob(t, &company, jobDetails{})
+
+	esJob, err := testQueries.GetJobForES(context.Background(), job.ID)
+	require.NoError(t, err)
+	require.Equal(t, job.ID, esJob.ID)
+	require.Equal(t, job.Title, esJob.Title)
+	require.Equal(t, company.Name, esJob.CompanyName)
+	require.Equal(t, job.SalaryMinAnnual, esJob.SalaryMinAnnual)
+	require.Equal(t, job.SalaryMaxAnnual, esJob.SalaryMaxAnnual)
+
+	// jobs that are not published are not in the index
+	draftJob := createRandomJob(t, &company, jobDetails{status: JobStatusDraft})
+	_, err = testQueries.GetJobForES(context.Background(), draftJob.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = testQueries.GetJobForES(context.Background(), 0)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
 func TestQueries_GetCompanyIDOfJob(t *testing.T) {
 	company := createRandomCompany(t, "")
 	job := createRandomJob(t, &company, jobDetails{})
@@ -460,3 +651,228 @@ func TestQueries_GetJobBasicInfo(t *testing.T) {
 	require.Equal(t, job.Title, jobBasicInfo.JobTitle)
 	require.Equal(t, company.Name, jobBasicInfo.CompanyName)
 }
+
+func TestQueries_ExpireJobs(t *testing.T) {
+	passedJob := setJobClosesAt(t, createRandomJob(t, nil, jobDetails{}), time.Now().Add(-time.Hour))
+	openJob := setJobClosesAt(t, createRandomJob(t, nil, jobDetails{}), time.Now().Add(time.Hour))
+	pausedJob := createRandomJob(t, nil, jobDetails{status: JobStatusPaused})
+	setJobClosesAt(t, pausedJob, time.Now().Add(-time.Hour))
+
+	jobIDs, err := testQueries.ExpireJobs(context.Background())
+	require.NoError(t, err)
+	require.Contains(t, jobIDs, passedJob.ID)
+	require.NotContains(t, jobIDs, openJob.ID)
+	require.NotContains(t, jobIDs, pausedJob.ID)
+
+	job, err := testQueries.GetJob(context.Background(), passedJob.ID)
+	require.NoError(t, err)
+	require.Equal(t, JobStatusExpired, job.Status)
+
+	job, err = testQueries.GetJob(context.Background(), openJob.ID)
+	require.NoError(t, err)
+	require.Equal(t, JobStatusPublished, job.Status)
+}
+
+func TestQueries_ListPublishedJobIDs(t *testing.T) {
+	publishedJob := createRandomJob(t, nil, jobDetails{})
+	draftJob := createRandomJob(t, nil, jobDetails{status: JobStatusDraft})
+	deletedJob := createRandomJob(t, nil, jobDetails{})
+	err := testQueries.DeleteJob(context.Background(), deletedJob.ID)
+	require.NoError(t, err)
+
+	jobIDs, err := testQueries.ListPublishedJobIDs(context.Background(), []int32{publishedJob.ID, draftJob.ID, deletedJob.ID})
+	require.NoError(t, err)
+	require.Equal(t, []int32{publishedJob.ID}, jobIDs)
+}
+
+func TestQueries_ListJobsClosingSoon(t *testing.T) {
+	company := createRandomCompany(t, "")
+	closingJob := setJobClosesAt(t, createRandomJob(t, &company, jobDetails{}), time.Now().Add(time.Hour))
+	laterJob := setJobClosesAt(t, createRandomJob(t, &company, jobDetails{}), time.Now().Add(48*time.Hour))
+	passedJob := setJobClosesAt(t, createRandomJob(t, &company, jobDetails{}), time.Now().Add(-time.Hour))
+
+	containsJob := func(jobs []ListJobsClosingSoonRow, jobID int32) bool {
+		for _, job := range jobs {
+			if job.ID == jobID {
+				return true
+			}
+		}
+		return false
+	}
+
+	jobs, err := testQueries.ListJobsClosingSoon(context.Background(), time.Now().Add(24*time.Hour))
+	require.NoError(t, err)
+	require.True(t, containsJob(jobs, closingJob.ID))
+	require.False(t, containsJob(jobs, laterJob.ID))
+	require.False(t, containsJob(jobs, passedJob.ID))
+	for _, job := range jobs {
+		if job.ID == closingJob.ID {
+			require.Equal(t, company.Name, job.CompanyName)
+			require.Equal(t, closingJob.Title, job.Title)
+		}
+	}
+
+	// jobs are listed only until the reminder for their closes_at is saved
+	err = testQueries.CreateJobExpiryReminder(context.Background(), CreateJobExpiryReminderParams{
+		JobID:    closingJob.ID,
+		ClosesAt: closingJob.ClosesAt.Time,
+	})
+	require.NoError(t, err)
+
+	jobs, err = testQueries.ListJobsClosingSoon(context.Background(), time.Now().Add(24*time.Hour))
+	require.NoError(t, err)
+	require.False(t, containsJob(jobs, closingJob.ID))
+
+	// a new closes_at makes the job listed again
+	setJobClosesAt(t, closingJob, time.Now().Add(2*time.Hour))
+	jobs, err = testQueries.ListJobsClosingSoon(context.Background(), time.Now().Add(24*time.Hour))
+	require.NoError(t, err)
+	require.True(t, containsJob(jobs, closingJob.ID))
+}
+
+func TestQueries_ListJobSalariesByCurrency(t *testing.T) {
+	job := createRandomJob(t, nil, jobDetails{})
+
+	jobs, err := testQueries.ListJobSalariesByCurrency(context.Background(), job.SalaryCurrency)
+	require.NoError(t, err)
+	require.NotEmpty(t, jobs)
+
+	var found bool
+	for _, j := range jobs {
+		if j.ID != job.ID {
+			continue
+		}
+		found = true
+		require.Equal(t, job.SalaryMin, j.SalaryMin)
+		require.Equal(t, job.SalaryMax, j.SalaryMax)
+		require.Equal(t, job.SalaryPeriod, j.SalaryPeriod)
+		require.Equal(t, job.Status, j.Status)
+	}
+	require.True(t, found)
+
+	jobs, err = testQueries.ListJobSalariesByCurrency(context.Background(), "XXX")
+	require.NoError(t, err)
+	require.Empty(t, jobs)
+}
+
+func TestQueries_ListJobSuggestions(t *testing.T) {
+	word := utils.RandomString(12)
+	company := createRandomCompany(t, word+" Inc")
+	title := "Senior " + word + " Developer"
+	job := createRandomJob(t, &company, jobDetails{title: title})
+	createRandomJob(t, &company, jobDetails{title: title})
+	createRandomJob(t, &company, jobDetails{title: title, status: JobStatusDraft})
+	createRandomJobSkill(t, &job, word)
+
+	suggestions, err := testQueries.ListJobSuggestions(context.Background(), ListJobSuggestionsParams{
+		PrefixWords: []string{word[:8]},
+		Size:        5,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []ListJobSuggestionsRow{
+		{Type: "title", Value: title, Count: 2},
+		{Type: "skill", Value: word, Count: 1},
+		{Type: "company", Value: company.Name, Count: 2},
+	}, suggestions)
+
+	// every prefix word has to match a word of the value
+	suggestions, err = testQueries.ListJobSuggestions(context.Background(), ListJobSuggestionsParams{
+		PrefixWords: []string{word[:8], "dev"},
+		Size:        5,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []ListJobSuggestionsRow{{Type: "title", Value: title, Count: 2}}, suggestions)
+}
+
+func TestQueries_ListJobsByTextSimilarity(t *testing.T) {
+	word := utils.RandomString(12)
+	job := createRandomJob(t, nil, jobDetails{title: word + " developer"})
+	similarJob := createRandomJob(t, nil, jobDetails{title: word + " developer"})
+	draft := createRandomJob(t, nil, jobDetails{title: word + " developer", status: JobStatusDraft})
+	createRandomJobSkill(t, &similarJob, "go")
+
+	jobs, err := testQueries.ListJobsByTextSimilarity(context.Background(), ListJobsByTextSimilarityParams{
+		JobID: job.ID,
+		Size:  50,
+	})
+	require.NoError(t, err)
+
+	var found bool
+	for _, j := range jobs {
+		// neither the job nor the drafts are similar jobs
+		require.NotEqual(t, job.ID, j.ID)
+		require.NotEqual(t, draft.ID, j.ID)
+		require.Greater(t, j.Score, 0.0)
+		require.LessOrEqual(t, j.Score, 1.0)
+		if j.ID == similarJob.ID {
+			found = true
+			require.Equal(t, similarJob.Title, j.Title)
+			require.Equal(t, []string{"go"}, j.Skills)
+		}
+	}
+	require.True(t, found)
+}
+
+func TestQueries_UpdateJobAnnualSalary(t *testing.T) {
+	job := createRandomJob(t, nil, jobDetails{})
+
+	params := UpdateJobAnnualSalaryParams{
+		ID:              job.ID,
+		SalaryMinAnnual: utils.RandomInt(50000, 60000),
+		SalaryMaxAnnual: utils.RandomInt(60000, 70000),
+	}
+	err := testQueries.UpdateJobAnnualSalary(context.Background(), params)
+	require.NoError(t, err)
+
+	job2, err := testQueries.GetJob(context.Background(), job.ID)
+	require.NoError(t, err)
+	require.Equal(t, params.SalaryMinAnnual, job2.SalaryMinAnnual)
+	require.Equal(t, params.SalaryMaxAnnual, job2.SalaryMaxAnnual)
+	require.Equal(t, job.SalaryMin, job2.SalaryMin)
+	require.Equal(t, job.SalaryMax, job2.SalaryMax)
+}
+
+func TestQueries_ListJobLocations(t *testing.T) {
+	job := createRandomJob(t, nil, jobDetails{})
+	berlin, ok := geo.Lookup("Berlin")
+	require.True(t, ok)
+	jobWithCoordinates := createRandomJob(t, nil, jobDetails{city: &berlin})
+
+	jobs, err := testQueries.ListJobLocations(context.Background())
+	require.NoError(t, err)
+
+	var found bool
+	for _, j := range jobs {
+		require.NotEqual(t, jobWithCoordinates.ID, j.ID)
+		if j.ID == job.ID {
+			found = true
+			require.Equal(t, job.Location, j.Location)
+		}
+	}
+	require.True(t, found)
+}
+
+func TestQueries_UpdateJobLocation(t *testing.T) {
+	job := createRandomJob(t, nil, jobDetails{})
+
+	params := UpdateJobLocationParams{
+		ID:        job.ID,
+		Location:  "Berlin, Germany",
+		Latitude:  sql.NullFloat64{Float64: 52.52, Valid: true},
+		Longitude: sql.NullFloat64{Float64: 13.405, Valid: true},
+	}
+	err := testQueries.UpdateJobLocation(context.Background(), params)
+	require.NoError(t, err)
+
+	job2, err := testQueries.GetJob(context.Background(), job.ID)
+	require.NoError(t, err)
+	require.Equal(t, params.Location, job2.Location)
+	require.Equal(t, params.Latitude, job2.Latitude)
+	require.Equal(t, params.Longitude, job2.Longitude)
+	require.Equal(t, job.Title, job2.Title)
+
+	// coordinates have to be set together
+	params.Longitude = sql.NullFloat64{}
+	err = testQueries.UpdateJobLocation(context.Background(), params)
+	require.Error(t, err)
+}
@@ -2,11 +2,14 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"github.com/aalug/job-finder-go/internal/geo"
 	"github.com/aalug/job-finder-go/pkg/utils"
 	"github.com/bxcodec/faker/v3"
 	"log"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 // LoadTestData loads the test data into the database
@@ -14,6 +17,9 @@ func (store *SQLStore) LoadTestData(ctx context.Context) {
 	var wg sync.WaitGroup
 	nOfJobsCreated := int32(0)
 	jobTitles := append(utils.GenerateEngineerJobs(), utils.GenerateDeveloperJobs()...)
+	employmentTypes := []EmploymentType{EmploymentTypeFullTime, EmploymentTypePartTime, EmploymentTypeContract}
+	seniorityLevels := []SeniorityLevel{SeniorityLevelJunior, SeniorityLevelMid, SeniorityLevelSenior}
+	remotePolicies := []RemotePolicy{RemotePolicyOnsite, RemotePolicyHybrid, RemotePolicyRemote}
 
 	// create fake companies
 	for i := 0; i < 5; i++ {
@@ -26,7 +32,12 @@ func (store *SQLStore) LoadTestData(ctx context.Context) {
 				defer wg.Done()
 
 				idx := utils.RandomInt(0, int32(len(utils.Locations)-1))
-				location := utils.Locations[idx]
+				location, city := geo.NormalizeLocation(utils.Locations[idx])
+				var latitude, longitude sql.NullFloat64
+				if city != nil {
+					latitude = sql.NullFloat64{Float64: city.Latitude, Valid: true}
+					longitude = sql.NullFloat64{Float64: city.Longitude, Valid: true}
+				}
 				companyParams := CreateCompanyParams{
 					Name:     faker.DomainName(),
 					Industry: industry,
@@ -52,15 +63,32 @@ func (store *SQLStore) LoadTestData(ctx context.Context) {
 
 						idx := utils.RandomInt(0, int32(len(jobTitles)-1))
 						jobTitle := jobTitles[idx]
+						// monthly salaries in the base currency, 12 months a year
+						salaryMin := utils.RandomInt(0, 2000)
+						salaryMax := utils.RandomInt(2001, 5000)
 						jobParams := CreateJobParams{
-							Title:        jobTitle,
-							Industry:     industry,
-							CompanyID:    company.ID,
-							Description:  jobTitle + " " + faker.Paragraph(),
-							Location:     location,
-							SalaryMin:    utils.RandomInt(0, 2000),
-							SalaryMax:    utils.RandomInt(2001, 5000),
-							Requirements: jobTitle + " " + faker.Paragraph(),
+							Title:           jobTitle,
+							Industry:        industry,
+							CompanyID:       company.ID,
+							Description:     jobTitle + " " + faker.Paragraph(),
+							Location:        location,
+							SalaryMin:       salaryMin,
+							SalaryMax:       salaryMax,
+							Requirements:    jobTitle + " " + faker.Paragraph(),
+							Status:          JobStatusPublished,
+							EmploymentType:  employmentTypes[utils.RandomInt(0, int32(len(employmentTypes)-1))],
+							SeniorityLevel:  seniorityLevels[utils.RandomInt(0, int32(len(seniorityLevels)-1))],
+							RemotePolicy:    remotePolicies[utils.RandomInt(0, int32(len(remotePolicies)-1))],
+							SalaryCurrency:  "USD",
+							SalaryPeriod:    SalaryPeriodMonth,
+							SalaryMinAnnual: salaryMin * 12,
+							SalaryMaxAnnual: salaryMax * 12,
+							Latitude:        latitude,
+							Longitude:       longitude,
+							PublishedAt: sql.NullTime{
+								Time:  time.Now(),
+								Valid: true,
+							},
 						}
 						_, err := store.CreateJob(ctx, jobParams)
 						if err != nil {
@@ -0,0 +1,85 @@
+package db
+
+import (
+	"context"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func createRandomLoginAttempt(t *testing.T) LoginAttempt {
+	params := RecordFailedLoginAttemptParams{
+		Subject:     "users:" + utils.RandomEmail(),
+		ResetBefore: time.Now().Add(-time.Minute),
+	}
+
+	loginAttempt, err := testQueries.RecordFailedLoginAttempt(context.Background(), params)
+	require.NoError(t, err)
+	require.Equal(t, params.Subject, loginAttempt.Subject)
+	require.Equal(t, int32(1), loginAttempt.FailedAttempts)
+	require.WithinDuration(t, time.Now(), loginAttempt.LastFailedAt, time.Second)
+
+	return loginAttempt
+}
+
+func TestQueries_RecordFailedLoginAttempt(t *testing.T) {
+	loginAttempt := createRandomLoginAttempt(t)
+
+	loginAttempt2, err := testQueries.RecordFailedLoginAttempt(context.Background(), RecordFailedLoginAttemptParams{
+		Subject:     loginAttempt.Subject,
+		ResetBefore: time.Now().Add(-time.Minute),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), loginAttempt2.FailedAttempts)
+
+	// the last failure is older than reset_before, so counting starts again
+	loginAttempt3, err := testQueries.RecordFailedLoginAttempt(context.Background(), RecordFailedLoginAttemptParams{
+		Subject:     loginAttempt.Subject,
+		ResetBefore: time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), loginAttempt3.FailedAttempts)
+}
+
+func TestQueries_LockLoginAttempt(t *testing.T) {
+	loginAttempt := createRandomLoginAttempt(t)
+	lockedUntil := time.Now().Add(time.Hour)
+
+	loginAttempt2, err := testQueries.LockLoginAttempt(context.Background(), LockLoginAttemptParams{
+		Subject:     loginAttempt.Subject,
+		LockedUntil: lockedUntil,
+	})
+	require.NoError(t, err)
+	require.Equal(t, loginAttempt.FailedAttempts, loginAttempt2.FailedAttempts)
+	require.WithinDuration(t, lockedUntil, loginAttempt2.LockedUntil, time.Second)
+}
+
+func TestQueries_ListLoginAttempts(t *testing.T) {
+	loginAttempt1 := createRandomLoginAttempt(t)
+	loginAttempt2 := createRandomLoginAttempt(t)
+	createRandomLoginAttempt(t)
+
+	loginAttempts, err := testQueries.ListLoginAttempts(context.Background(), []string{
+		loginAttempt1.Subject,
+		loginAttempt2.Subject,
+		"ip:" + utils.RandomString(8),
+	})
+	require.NoError(t, err)
+	require.Len(t, loginAttempts, 2)
+
+	for _, loginAttempt := range loginAttempts {
+		require.Contains(t, []string{loginAttempt1.Subject, loginAttempt2.Subject}, loginAttempt.Subject)
+	}
+}
+
+func TestQueries_DeleteLoginAttempt(t *testing.T) {
+	loginAttempt := createRandomLoginAttempt(t)
+
+	err := testQueries.DeleteLoginAttempt(context.Background(), loginAttempt.Subject)
+	require.NoError(t, err)
+
+	loginAttempts, err := testQueries.ListLoginAttempts(context.Background(), []string{loginAttempt.Subject})
+	require.NoError(t, err)
+	require.Empty(t, loginAttempts)
+}
@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func createRandomMfaSetting(t *testing.T, accountID int32) MfaSetting {
+	params := UpsertMfaSettingParams{
+		AccountType: "user",
+		AccountID:   accountID,
+		Secret:      utils.RandomString(32),
+	}
+
+	mfaSetting, err := testQueries.UpsertMfaSetting(context.Background(), params)
+	require.NoError(t, err)
+	require.Equal(t, params.AccountType, mfaSetting.AccountType)
+	require.Equal(t, params.AccountID, mfaSetting.AccountID)
+	require.Equal(t, params.Secret, mfaSetting.Secret)
+	require.False(t, mfaSetting.IsEnabled)
+	require.NotZero(t, mfaSetting.CreatedAt)
+
+	return mfaSetting
+}
+
+func TestQueries_UpsertMfaSetting(t *testing.T) {
+	user := createRandomUser(t)
+	mfaSetting := createRandomMfaSetting(t, user.ID)
+
+	// enrolling again replaces the secret and disables MFA until it is confirmed
+	_, err := testQueries.EnableMfaSetting(context.Background(), EnableMfaSettingParams{
+		AccountType: mfaSetting.AccountType,
+		AccountID:   mfaSetting.AccountID,
+	})
+	require.NoError(t, err)
+
+	newSetting := createRandomMfaSetting(t, user.ID)
+	require.NotEqual(t, mfaSetting.Secret, newSetting.Secret)
+	require.False(t, newSetting.IsEnabled)
+}
+
+func TestQueries_GetMfaSetting(t *testing.T) {
+	user := createRandomUser(t)
+	mfaSetting := createRandomMfaSetting(t, user.ID)
+
+	mfaSetting2, err := testQueries.GetMfaSetting(context.Background(), GetMfaSettingParams{
+		AccountType: mfaSetting.AccountType,
+		AccountID:   mfaSetting.AccountID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, mfaSetting.Secret, mfaSetting2.Secret)
+
+	_, err = testQueries.GetMfaSetting(context.Background(), GetMfaSettingParams{
+		AccountType: "employer",
+		AccountID:   mfaSetting.AccountID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestQueries_UseMfaRecoveryCode(t *testing.T) {
+	user := createRandomUser(t)
+	code, err := testQueries.CreateMfaRecoveryCode(context.Background(), CreateMfaRecoveryCodeParams{
+		AccountType: "user",
+		AccountID:   user.ID,
+		HashedCode:  utils.RandomString(60),
+	})
+	require.NoError(t, err)
+	require.False(t, code.IsUsed)
+
+	codes, err := testQueries.ListUnusedMfaRecoveryCodes(context.Background(), ListUnusedMfaRecoveryCodesParams{
+		AccountType: "user",
+		AccountID:   user.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, codes, 1)
+
+	usedCode, err := testQueries.UseMfaRecoveryCode(context.Background(), code.ID)
+	require.NoError(t, err)
+	require.True(t, usedCode.IsUsed)
+
+	// a recovery code can be used only once
+	_, err = testQueries.UseMfaRecoveryCode(context.Background(), code.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	codes, err = testQueries.ListUnusedMfaRecoveryCodes(context.Background(), ListUnusedMfaRecoveryCodesParams{
+		AccountType: "user",
+		AccountID:   user.ID,
+	})
+	require.NoError(t, err)
+	require.Empty(t, codes)
+}
@@ -9,6 +9,8 @@ import (
 	"database/sql/driver"
 	"fmt"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 type ApplicationStatus string
@@ -56,6 +58,327 @@ func (ns NullApplicationStatus) Value() (driver.Value, error) {
 	return string(ns.ApplicationStatus), nil
 }
 
+type CompanyRole string
+
+const (
+	CompanyRoleOwner     CompanyRole = "owner"
+	CompanyRoleAdmin     CompanyRole = "admin"
+	CompanyRoleRecruiter CompanyRole = "recruiter"
+	CompanyRoleViewer    CompanyRole = "viewer"
+)
+
+func (e *CompanyRole) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = CompanyRole(s)
+	case string:
+		*e = CompanyRole(s)
+	default:
+		return fmt.Errorf("unsupported scan type for CompanyRole: %T", src)
+	}
+	return nil
+}
+
+type NullCompanyRole struct {
+	CompanyRole CompanyRole `json:"company_role"`
+	Valid       bool        `json:"valid"` // Valid is true if CompanyRole is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullCompanyRole) Scan(value interface{}) error {
+	if value == nil {
+		ns.CompanyRole, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.CompanyRole.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullCompanyRole) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.CompanyRole), nil
+}
+
+type EmploymentType string
+
+const (
+	EmploymentTypeFullTime   EmploymentType = "full_time"
+	EmploymentTypePartTime   EmploymentType = "part_time"
+	EmploymentTypeContract   EmploymentType = "contract"
+	EmploymentTypeInternship EmploymentType = "internship"
+	EmploymentTypeTemporary  EmploymentType = "temporary"
+)
+
+func (e *EmploymentType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EmploymentType(s)
+	case string:
+		*e = EmploymentType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EmploymentType: %T", src)
+	}
+	return nil
+}
+
+type NullEmploymentType struct {
+	EmploymentType EmploymentType `json:"employment_type"`
+	Valid          bool           `json:"valid"` // Valid is true if EmploymentType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEmploymentType) Scan(value interface{}) error {
+	if value == nil {
+		ns.EmploymentType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EmploymentType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEmploymentType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EmploymentType), nil
+}
+
+type JobStatus string
+
+const (
+	JobStatusDraft     JobStatus = "draft"
+	JobStatusPublished JobStatus = "published"
+	JobStatusPaused    JobStatus = "paused"
+	JobStatusClosed    JobStatus = "closed"
+	JobStatusExpired   JobStatus = "expired"
+)
+
+func (e *JobStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = JobStatus(s)
+	case string:
+		*e = JobStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for JobStatus: %T", src)
+	}
+	return nil
+}
+
+type NullJobStatus struct {
+	JobStatus JobStatus `json:"job_status"`
+	Valid     bool      `json:"valid"` // Valid is true if JobStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullJobStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.JobStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.JobStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullJobStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.JobStatus), nil
+}
+
+type RemotePolicy string
+
+const (
+	RemotePolicyOnsite RemotePolicy = "onsite"
+	RemotePolicyHybrid RemotePolicy = "hybrid"
+	RemotePolicyRemote RemotePolicy = "remote"
+)
+
+func (e *RemotePolicy) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = RemotePolicy(s)
+	case string:
+		*e = RemotePolicy(s)
+	default:
+		return fmt.Errorf("unsupported scan type for RemotePolicy: %T", src)
+	}
+	return nil
+}
+
+type NullRemotePolicy struct {
+	RemotePolicy RemotePolicy `json:"remote_policy"`
+	Valid        bool         `json:"valid"` // Valid is true if RemotePolicy is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullRemotePolicy) Scan(value interface{}) error {
+	if value == nil {
+		ns.RemotePolicy, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.RemotePolicy.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullRemotePolicy) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.RemotePolicy), nil
+}
+
+type SalaryPeriod string
+
+const (
+	SalaryPeriodHour  SalaryPeriod = "hour"
+	SalaryPeriodDay   SalaryPeriod = "day"
+	SalaryPeriodWeek  SalaryPeriod = "week"
+	SalaryPeriodMonth SalaryPeriod = "month"
+	SalaryPeriodYear  SalaryPeriod = "year"
+)
+
+func (e *SalaryPeriod) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = SalaryPeriod(s)
+	case string:
+		*e = SalaryPeriod(s)
+	default:
+		return fmt.Errorf("unsupported scan type for SalaryPeriod: %T", src)
+	}
+	return nil
+}
+
+type NullSalaryPeriod struct {
+	SalaryPeriod SalaryPeriod `json:"salary_period"`
+	Valid        bool         `json:"valid"` // Valid is true if SalaryPeriod is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullSalaryPeriod) Scan(value interface{}) error {
+	if value == nil {
+		ns.SalaryPeriod, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.SalaryPeriod.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullSalaryPeriod) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.SalaryPeriod), nil
+}
+
+type SavedSearchFrequency string
+
+const (
+	SavedSearchFrequencyDaily  SavedSearchFrequency = "daily"
+	SavedSearchFrequencyWeekly SavedSearchFrequency = "weekly"
+	SavedSearchFrequencyNever  SavedSearchFrequency = "never"
+)
+
+func (e *SavedSearchFrequency) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = SavedSearchFrequency(s)
+	case string:
+		*e = SavedSearchFrequency(s)
+	default:
+		return fmt.Errorf("unsupported scan type for SavedSearchFrequency: %T", src)
+	}
+	return nil
+}
+
+type NullSavedSearchFrequency struct {
+	SavedSearchFrequency SavedSearchFrequency `json:"saved_search_frequency"`
+	Valid                bool                 `json:"valid"` // Valid is true if SavedSearchFrequency is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullSavedSearchFrequency) Scan(value interface{}) error {
+	if value == nil {
+		ns.SavedSearchFrequency, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.SavedSearchFrequency.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullSavedSearchFrequency) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.SavedSearchFrequency), nil
+}
+
+type SeniorityLevel string
+
+const (
+	SeniorityLevelIntern SeniorityLevel = "intern"
+	SeniorityLevelJunior SeniorityLevel = "junior"
+	SeniorityLevelMid    SeniorityLevel = "mid"
+	SeniorityLevelSenior SeniorityLevel = "senior"
+	SeniorityLevelLead   SeniorityLevel = "lead"
+)
+
+func (e *SeniorityLevel) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = SeniorityLevel(s)
+	case string:
+		*e = SeniorityLevel(s)
+	default:
+		return fmt.Errorf("unsupported scan type for SeniorityLevel: %T", src)
+	}
+	return nil
+}
+
+type NullSeniorityLevel struct {
+	SeniorityLevel SeniorityLevel `json:"seniority_level"`
+	Valid          bool           `json:"valid"` // Valid is true if SeniorityLevel is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullSeniorityLevel) Scan(value interface{}) error {
+	if value == nil {
+		ns.SeniorityLevel, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.SeniorityLevel.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullSeniorityLevel) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.SeniorityLevel), nil
+}
+
+type ApiKey struct {
+	ID         int64        `json:"id"`
+	EmployerID int32        `json:"employer_id"`
+	Name       string       `json:"name"`
+	Prefix     string       `json:"prefix"`
+	HashedKey  string       `json:"hashed_key"`
+	Scopes     []string     `json:"scopes"`
+	LastUsedAt sql.NullTime `json:"last_used_at"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
 type Company struct {
 	ID       int32  `json:"id"`
 	Name     string `json:"name"`
@@ -63,27 +386,64 @@ type Company struct {
 	Location string `json:"location"`
 }
 
+type CompanyInvitation struct {
+	ID         int64       `json:"id"`
+	CompanyID  int32       `json:"company_id"`
+	Email      string      `json:"email"`
+	Role       CompanyRole `json:"role"`
+	InvitedBy  int32       `json:"invited_by"`
+	SecretCode string      `json:"secret_code"`
+	IsUsed     bool        `json:"is_used"`
+	CreatedAt  time.Time   `json:"created_at"`
+	ExpiredAt  time.Time   `json:"expired_at"`
+}
+
+type CurrencyRate struct {
+	Currency  string    `json:"currency"`
+	Rate      float64   `json:"rate"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type EmailTokenRevocation struct {
+	Email     string    `json:"email"`
+	RevokedAt time.Time `json:"revoked_at"`
+}
+
 type Employer struct {
-	ID              int32     `json:"id"`
-	CompanyID       int32     `json:"company_id"`
-	FullName        string    `json:"full_name"`
-	Email           string    `json:"email"`
-	HashedPassword  string    `json:"hashed_password"`
-	CreatedAt       time.Time `json:"created_at"`
-	IsEmailVerified bool      `json:"is_email_verified"`
+	ID              int32       `json:"id"`
+	CompanyID       int32       `json:"company_id"`
+	FullName        string      `json:"full_name"`
+	Email           string      `json:"email"`
+	HashedPassword  string      `json:"hashed_password"`
+	CreatedAt       time.Time   `json:"created_at"`
+	IsEmailVerified bool        `json:"is_email_verified"`
+	Role            CompanyRole `json:"role"`
 }
 
 type Job struct {
-	ID           int32     `json:"id"`
-	Title        string    `json:"title"`
-	Industry     string    `json:"industry"`
-	CompanyID    int32     `json:"company_id"`
-	Description  string    `json:"description"`
-	Location     string    `json:"location"`
-	SalaryMin    int32     `json:"salary_min"`
-	SalaryMax    int32     `json:"salary_max"`
-	Requirements string    `json:"requirements"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID              int32           `json:"id"`
+	Title           string          `json:"title"`
+	Industry        string          `json:"industry"`
+	CompanyID       int32           `json:"company_id"`
+	Description     string          `json:"description"`
+	Location        string          `json:"location"`
+	SalaryMin       int32           `json:"salary_min"`
+	SalaryMax       int32           `json:"salary_max"`
+	Requirements    string          `json:"requirements"`
+	CreatedAt       time.Time       `json:"created_at"`
+	Status          JobStatus       `json:"status"`
+	PublishedAt     sql.NullTime    `json:"published_at"`
+	ClosesAt        sql.NullTime    `json:"closes_at"`
+	EmploymentType  EmploymentType  `json:"employment_type"`
+	SeniorityLevel  SeniorityLevel  `json:"seniority_level"`
+	RemotePolicy    RemotePolicy    `json:"remote_policy"`
+	SalaryCurrency  string          `json:"salary_currency"`
+	SalaryPeriod    SalaryPeriod    `json:"salary_period"`
+	SalaryMinAnnual int32           `json:"salary_min_annual"`
+	SalaryMaxAnnual int32           `json:"salary_max_annual"`
+	Latitude        sql.NullFloat64 `json:"latitude"`
+	Longitude       sql.NullFloat64 `json:"longitude"`
+	SearchVector    string          `json:"-"`
 }
 
 type JobApplication struct {
@@ -96,12 +456,111 @@ type JobApplication struct {
 	AppliedAt time.Time         `json:"applied_at"`
 }
 
+type JobExpiryReminder struct {
+	JobID     int32     `json:"job_id"`
+	ClosesAt  time.Time `json:"closes_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type JobSkill struct {
 	ID    int32  `json:"id"`
 	JobID int32  `json:"job_id"`
 	Skill string `json:"skill"`
 }
 
+type LoginAttempt struct {
+	// user:<email>, employer:<email> or ip:<client ip>
+	Subject        string    `json:"subject"`
+	FailedAttempts int32     `json:"failed_attempts"`
+	LockedUntil    time.Time `json:"locked_until"`
+	LastFailedAt   time.Time `json:"last_failed_at"`
+}
+
+type MfaRecoveryCode struct {
+	ID          int64     `json:"id"`
+	AccountType string    `json:"account_type"`
+	AccountID   int32     `json:"account_id"`
+	HashedCode  string    `json:"hashed_code"`
+	IsUsed      bool      `json:"is_used"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type MfaSetting struct {
+	AccountType string    `json:"account_type"`
+	AccountID   int32     `json:"account_id"`
+	Secret      string    `json:"secret"`
+	IsEnabled   bool      `json:"is_enabled"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type OidcState struct {
+	State        string    `json:"state"`
+	CodeVerifier string    `json:"code_verifier"`
+	Nonce        string    `json:"nonce"`
+	CreatedAt    time.Time `json:"created_at"`
+	ExpiredAt    time.Time `json:"expired_at"`
+}
+
+type ResetPassword struct {
+	ID          int64     `json:"id"`
+	Email       string    `json:"email"`
+	SecretCode  string    `json:"secret_code"`
+	IsUsed      bool      `json:"is_used"`
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiredAt   time.Time `json:"expired_at"`
+	AccountType string    `json:"account_type"`
+}
+
+type RevokedToken struct {
+	ID        uuid.UUID `json:"id"`
+	Email     string    `json:"email"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type SavedSearch struct {
+	ID               int64                `json:"id"`
+	UserID           int32                `json:"user_id"`
+	Name             string               `json:"name"`
+	Query            string               `json:"query"`
+	Title            sql.NullString       `json:"title"`
+	Industry         sql.NullString       `json:"industry"`
+	JobLocation      sql.NullString       `json:"job_location"`
+	SalaryMin        sql.NullInt32        `json:"salary_min"`
+	SalaryMax        sql.NullInt32        `json:"salary_max"`
+	EmploymentType   NullEmploymentType   `json:"employment_type"`
+	SeniorityLevel   NullSeniorityLevel   `json:"seniority_level"`
+	RemotePolicy     NullRemotePolicy     `json:"remote_policy"`
+	SalaryCurrency   sql.NullString       `json:"salary_currency"`
+	SalaryPeriod     NullSalaryPeriod     `json:"salary_period"`
+	Near             sql.NullString       `json:"near"`
+	NearLatitude     sql.NullFloat64      `json:"near_latitude"`
+	NearLongitude    sql.NullFloat64      `json:"near_longitude"`
+	RadiusKm         sql.NullFloat64      `json:"radius_km"`
+	Frequency        SavedSearchFrequency `json:"frequency"`
+	UnsubscribeToken string               `json:"unsubscribe_token"`
+	LastRunAt        time.Time            `json:"last_run_at"`
+	CreatedAt        time.Time            `json:"created_at"`
+}
+
+type SearchOutbox struct {
+	ID          int64        `json:"id"`
+	JobID       int32        `json:"job_id"`
+	CreatedAt   time.Time    `json:"created_at"`
+	ProcessedAt sql.NullTime `json:"processed_at"`
+}
+
+type Session struct {
+	ID           uuid.UUID `json:"id"`
+	Email        string    `json:"email"`
+	RefreshToken string    `json:"refresh_token"`
+	UserAgent    string    `json:"user_agent"`
+	ClientIp     string    `json:"client_ip"`
+	IsBlocked    bool      `json:"is_blocked"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
 type User struct {
 	ID               int32     `json:"id"`
 	FullName         string    `json:"full_name"`
@@ -118,6 +577,14 @@ type User struct {
 	IsEmailVerified  bool      `json:"is_email_verified"`
 }
 
+type UserIdentity struct {
+	Provider  string    `json:"provider"`
+	Subject   string    `json:"subject"`
+	UserID    int32     `json:"user_id"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type UserSkill struct {
 	ID         int32  `json:"id"`
 	UserID     int32  `json:"user_id"`
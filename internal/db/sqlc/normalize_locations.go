@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/aalug/job-finder-go/internal/geo"
+)
+
+// NormalizeLocationsResult contains the number of rows updated by NormalizeLocations
+type NormalizeLocationsResult struct {
+	Jobs      int
+	Companies int
+	Users     int
+}
+
+// NormalizeLocations normalizes the existing free-text locations of the jobs,
+// companies and users to the gazetteer entries. Jobs in the gazetteer cities
+// get their coordinates and are updated in the elasticsearch index through the search outbox.
+// Locations that are not in the gazetteer are left as they are.
+func (store *SQLStore) NormalizeLocations(ctx context.Context) (NormalizeLocationsResult, error) {
+	var result NormalizeLocationsResult
+
+	jobs, err := store.ListJobLocations(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed to list job locations: %w", err)
+	}
+	for _, job := range jobs {
+		location, city := geo.NormalizeLocation(job.Location)
+		if city == nil {
+			continue
+		}
+
+		err = store.ExecTx(ctx, func(q *Queries) error {
+			err := q.UpdateJobLocation(ctx, UpdateJobLocationParams{
+				ID:        job.ID,
+				Location:  location,
+				Latitude:  sql.NullFloat64{Float64: city.Latitude, Valid: true},
+				Longitude: sql.NullFloat64{Float64: city.Longitude, Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+
+			return q.CreateSearchOutboxEntry(ctx, job.ID)
+		})
+		if err != nil {
+			return result, fmt.Errorf("failed to update location of job %d: %w", job.ID, err)
+		}
+		result.Jobs++
+	}
+
+	companies, err := store.ListCompanyLocations(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed to list company locations: %w", err)
+	}
+	for _, company := range companies {
+		location, city := geo.NormalizeLocation(company.Location)
+		if city == nil || location == company.Location {
+			continue
+		}
+
+		err = store.UpdateCompanyLocation(ctx, UpdateCompanyLocationParams{
+			ID:       company.ID,
+			Location: location,
+		})
+		if err != nil {
+			return result, fmt.Errorf("failed to update location of company %d: %w", company.ID, err)
+		}
+		result.Companies++
+	}
+
+	users, err := store.ListUserLocations(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed to list user locations: %w", err)
+	}
+	for _, user := range users {
+		location, city := geo.NormalizeLocation(user.Location)
+		if city == nil || location == user.Location {
+			continue
+		}
+
+		err = store.UpdateUserLocation(ctx, UpdateUserLocationParams{
+			ID:       user.ID,
+			Location: location,
+		})
+		if err != nil {
+			return result, fmt.Errorf("failed to update location of user %d: %w", user.ID, err)
+		}
+		result.Users++
+	}
+
+	return result, nil
+}
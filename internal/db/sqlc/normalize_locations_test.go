@@ -0,0 +1,55 @@
+package db
+
+import (
+	"context"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSQLStore_NormalizeLocations(t *testing.T) {
+	company := createRandomCompany(t, "")
+	err := testQueries.UpdateCompanyLocation(context.Background(), UpdateCompanyLocationParams{
+		ID:       company.ID,
+		Location: "munich, de",
+	})
+	require.NoError(t, err)
+
+	job := createRandomJob(t, &company, jobDetails{location: " Warszawa "})
+	unknownJob := createRandomJob(t, &company, jobDetails{location: utils.RandomString(8)})
+
+	user := createRandomUser(t)
+	err = testQueries.UpdateUserLocation(context.Background(), UpdateUserLocationParams{
+		ID:       user.ID,
+		Location: "new york, usa",
+	})
+	require.NoError(t, err)
+
+	result, err := testStore.NormalizeLocations(context.Background())
+	require.NoError(t, err)
+	require.NotZero(t, result.Jobs)
+	require.NotZero(t, result.Companies)
+	require.NotZero(t, result.Users)
+
+	job2, err := testQueries.GetJob(context.Background(), job.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Warsaw, Poland", job2.Location)
+	require.True(t, job2.Latitude.Valid)
+	require.True(t, job2.Longitude.Valid)
+	require.InDelta(t, 52.2298, job2.Latitude.Float64, 0.0001)
+	require.InDelta(t, 21.0118, job2.Longitude.Float64, 0.0001)
+
+	// locations that are not in the gazetteer are left as they are
+	unknownJob2, err := testQueries.GetJob(context.Background(), unknownJob.ID)
+	require.NoError(t, err)
+	require.Equal(t, unknownJob.Location, unknownJob2.Location)
+	require.False(t, unknownJob2.Latitude.Valid)
+
+	company2, err := testQueries.GetCompanyByID(context.Background(), company.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Munich, Germany", company2.Location)
+
+	user2, err := testQueries.GetUserByID(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Equal(t, "New York, United States", user2.Location)
+}
@@ -0,0 +1,91 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func createRandomUserIdentity(t *testing.T, user User) UserIdentity {
+	params := CreateUserIdentityParams{
+		Provider: "test",
+		Subject:  utils.RandomString(16),
+		UserID:   user.ID,
+		Email:    user.Email,
+	}
+
+	identity, err := testQueries.CreateUserIdentity(context.Background(), params)
+	require.NoError(t, err)
+	require.Equal(t, params.Provider, identity.Provider)
+	require.Equal(t, params.Subject, identity.Subject)
+	require.Equal(t, params.UserID, identity.UserID)
+	require.Equal(t, params.Email, identity.Email)
+	require.NotZero(t, identity.CreatedAt)
+
+	return identity
+}
+
+func createRandomOidcState(t *testing.T) OidcState {
+	params := CreateOidcStateParams{
+		State:        utils.RandomString(32),
+		CodeVerifier: utils.RandomString(43),
+		Nonce:        utils.RandomString(32),
+	}
+
+	oidcState, err := testQueries.CreateOidcState(context.Background(), params)
+	require.NoError(t, err)
+	require.Equal(t, params.State, oidcState.State)
+	require.Equal(t, params.CodeVerifier, oidcState.CodeVerifier)
+	require.Equal(t, params.Nonce, oidcState.Nonce)
+	require.WithinDuration(t, oidcState.CreatedAt.Add(10*time.Minute), oidcState.ExpiredAt, time.Second)
+
+	return oidcState
+}
+
+func TestQueries_CreateUserIdentity(t *testing.T) {
+	user := createRandomUser(t)
+	identity := createRandomUserIdentity(t, user)
+
+	// the same identity cannot be linked twice
+	_, err := testQueries.CreateUserIdentity(context.Background(), CreateUserIdentityParams{
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		UserID:   user.ID,
+		Email:    user.Email,
+	})
+	require.Error(t, err)
+}
+
+func TestQueries_GetUserIdentity(t *testing.T) {
+	user := createRandomUser(t)
+	identity := createRandomUserIdentity(t, user)
+
+	identity2, err := testQueries.GetUserIdentity(context.Background(), GetUserIdentityParams{
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+	})
+	require.NoError(t, err)
+	require.Equal(t, identity.UserID, identity2.UserID)
+
+	_, err = testQueries.GetUserIdentity(context.Background(), GetUserIdentityParams{
+		Provider: "other",
+		Subject:  identity.Subject,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestQueries_DeleteOidcState(t *testing.T) {
+	oidcState := createRandomOidcState(t)
+
+	oidcState2, err := testQueries.DeleteOidcState(context.Background(), oidcState.State)
+	require.NoError(t, err)
+	require.Equal(t, oidcState.CodeVerifier, oidcState2.CodeVerifier)
+	require.Equal(t, oidcState.Nonce, oidcState2.Nonce)
+
+	// the state can be used only once
+	_, err = testQueries.DeleteOidcState(context.Background(), oidcState.State)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -6,33 +6,63 @@ package db
 
 import (
 	"context"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type Querier interface {
+	BlockAllSessionsByEmail(ctx context.Context, email string) error
+	BlockSession(ctx context.Context, arg BlockSessionParams) (Session, error)
+	CreateApiKey(ctx context.Context, arg CreateApiKeyParams) (ApiKey, error)
 	CreateCompany(ctx context.Context, arg CreateCompanyParams) (Company, error)
+	CreateCompanyInvitation(ctx context.Context, arg CreateCompanyInvitationParams) (CompanyInvitation, error)
 	CreateEmployer(ctx context.Context, arg CreateEmployerParams) (Employer, error)
 	CreateJob(ctx context.Context, arg CreateJobParams) (Job, error)
 	CreateJobApplication(ctx context.Context, arg CreateJobApplicationParams) (JobApplication, error)
+	CreateJobExpiryReminder(ctx context.Context, arg CreateJobExpiryReminderParams) error
 	CreateJobSkill(ctx context.Context, arg CreateJobSkillParams) (JobSkill, error)
+	CreateMfaRecoveryCode(ctx context.Context, arg CreateMfaRecoveryCodeParams) (MfaRecoveryCode, error)
+	CreateOidcState(ctx context.Context, arg CreateOidcStateParams) (OidcState, error)
+	// a new code of the email replaces the previous one of the same account type
+	CreateResetPassword(ctx context.Context, arg CreateResetPasswordParams) (ResetPassword, error)
+	CreateSavedSearch(ctx context.Context, arg CreateSavedSearchParams) (SavedSearch, error)
+	CreateSearchOutboxEntriesForCompany(ctx context.Context, companyID int32) error
+	CreateSearchOutboxEntry(ctx context.Context, jobID int32) error
+	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateUserIdentity(ctx context.Context, arg CreateUserIdentityParams) (UserIdentity, error)
 	CreateUserSkill(ctx context.Context, arg CreateUserSkillParams) (UserSkill, error)
 	CreateVerifyEmail(ctx context.Context, arg CreateVerifyEmailParams) (VerifyEmail, error)
 	DeleteAllUserSkills(ctx context.Context, userID int32) error
+	DeleteApiKey(ctx context.Context, arg DeleteApiKeyParams) (ApiKey, error)
 	DeleteCompany(ctx context.Context, id int32) error
 	DeleteEmployer(ctx context.Context, id int32) error
 	DeleteJob(ctx context.Context, id int32) error
 	DeleteJobApplication(ctx context.Context, id int32) error
 	DeleteJobSkill(ctx context.Context, id int32) error
 	DeleteJobSkillsByJobID(ctx context.Context, jobID int32) error
+	DeleteLoginAttempt(ctx context.Context, subject string) error
+	DeleteMfaRecoveryCodes(ctx context.Context, arg DeleteMfaRecoveryCodesParams) error
+	DeleteMfaSetting(ctx context.Context, arg DeleteMfaSettingParams) error
 	DeleteMultipleJobSkills(ctx context.Context, ids []int32) error
 	DeleteMultipleUserSkills(ctx context.Context, ids []int32) error
+	DeleteOidcState(ctx context.Context, state string) (OidcState, error)
+	DeleteProcessedSearchOutboxEntries(ctx context.Context, processedBefore time.Time) (int64, error)
+	DeleteResetPassword(ctx context.Context, arg DeleteResetPasswordParams) error
+	DeleteSavedSearch(ctx context.Context, arg DeleteSavedSearchParams) (SavedSearch, error)
+	DeleteStaleVerifyEmails(ctx context.Context) (int64, error)
 	DeleteUser(ctx context.Context, id int32) error
 	DeleteUserSkill(ctx context.Context, id int32) error
 	DeleteVerifyEmail(ctx context.Context, email string) error
+	EnableMfaSetting(ctx context.Context, arg EnableMfaSettingParams) (MfaSetting, error)
+	ExpireJobs(ctx context.Context) ([]int32, error)
+	GetApiKeyByPrefix(ctx context.Context, prefix string) (ApiKey, error)
 	GetCompanyByID(ctx context.Context, id int32) (Company, error)
 	GetCompanyByName(ctx context.Context, name string) (Company, error)
 	GetCompanyIDOfJob(ctx context.Context, id int32) (int32, error)
 	GetCompanyNameByID(ctx context.Context, id int32) (string, error)
+	GetCurrencyRate(ctx context.Context, currency string) (CurrencyRate, error)
 	GetEmployerAndCompanyDetails(ctx context.Context, email string) (GetEmployerAndCompanyDetailsRow, error)
 	GetEmployerByEmail(ctx context.Context, email string) (Employer, error)
 	GetEmployerByID(ctx context.Context, id int32) (Employer, error)
@@ -44,14 +74,34 @@ type Querier interface {
 	GetJobApplicationUserID(ctx context.Context, id int32) (int32, error)
 	GetJobApplicationUserIDAndStatus(ctx context.Context, id int32) (GetJobApplicationUserIDAndStatusRow, error)
 	GetJobBasicInfo(ctx context.Context, id int32) (GetJobBasicInfoRow, error)
+	// the employer of the job is the first owner of the company (the company always has one while it has members)
 	GetJobDetails(ctx context.Context, id int32) (GetJobDetailsRow, error)
+	// the job is returned only if it should be in the elasticsearch index
+	GetJobForES(ctx context.Context, id int32) (GetJobForESRow, error)
 	GetJobIDOfJobApplication(ctx context.Context, id int32) (int32, error)
+	GetMfaSetting(ctx context.Context, arg GetMfaSettingParams) (MfaSetting, error)
+	GetSavedSearch(ctx context.Context, arg GetSavedSearchParams) (SavedSearch, error)
+	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByID(ctx context.Context, id int32) (User, error)
+	GetUserIdentity(ctx context.Context, arg GetUserIdentityParams) (UserIdentity, error)
+	IsTokenRevoked(ctx context.Context, arg IsTokenRevokedParams) (bool, error)
 	ListAllJobSkillsByJobID(ctx context.Context, jobID int32) ([]string, error)
 	ListAllJobsForES(ctx context.Context) ([]ListAllJobsForESRow, error)
+	ListApiKeysByEmployerID(ctx context.Context, employerID int32) ([]ApiKey, error)
+	ListCompanyEmployers(ctx context.Context, companyID int32) ([]Employer, error)
+	ListCompanyLocations(ctx context.Context) ([]ListCompanyLocationsRow, error)
+	ListCurrencyRates(ctx context.Context) ([]CurrencyRate, error)
+	// the searches of the users whose emails are not verified get no digests.
+	// The skipped searches failed in the current run, they stay due until the next one.
+	ListDueSavedSearches(ctx context.Context, arg ListDueSavedSearchesParams) ([]ListDueSavedSearchesRow, error)
 	ListJobApplicationsForEmployer(ctx context.Context, arg ListJobApplicationsForEmployerParams) ([]ListJobApplicationsForEmployerRow, error)
 	ListJobApplicationsForUser(ctx context.Context, arg ListJobApplicationsForUserParams) ([]ListJobApplicationsForUserRow, error)
+	ListJobLocations(ctx context.Context) ([]ListJobLocationsRow, error)
+	ListJobSalariesByCurrency(ctx context.Context, salaryCurrency string) ([]ListJobSalariesByCurrencyRow, error)
+	// the titles, skills and company names of the published jobs in which every prefix word is a prefix
+	// of a word, case-insensitively, up to size values of every type with the most jobs
+	ListJobSuggestions(ctx context.Context, arg ListJobSuggestionsParams) ([]ListJobSuggestionsRow, error)
 	ListJobSkillsByJobID(ctx context.Context, arg ListJobSkillsByJobIDParams) ([]ListJobSkillsByJobIDRow, error)
 	ListJobsByCompanyExactName(ctx context.Context, arg ListJobsByCompanyExactNameParams) ([]ListJobsByCompanyExactNameRow, error)
 	ListJobsByCompanyID(ctx context.Context, arg ListJobsByCompanyIDParams) ([]ListJobsByCompanyIDRow, error)
@@ -60,22 +110,60 @@ type Querier interface {
 	ListJobsByLocation(ctx context.Context, arg ListJobsByLocationParams) ([]Job, error)
 	ListJobsBySalaryRange(ctx context.Context, arg ListJobsBySalaryRangeParams) ([]Job, error)
 	ListJobsBySkill(ctx context.Context, arg ListJobsBySkillParams) ([]int32, error)
+	ListJobsBySkillOverlap(ctx context.Context, arg ListJobsBySkillOverlapParams) ([]ListJobsBySkillOverlapRow, error)
+	// the published jobs that share the words (lexemes of the search vectors) with the job,
+	// the score is the Jaccard index of the words of both jobs
+	ListJobsByTextSimilarity(ctx context.Context, arg ListJobsByTextSimilarityParams) ([]ListJobsByTextSimilarityRow, error)
 	ListJobsByTitle(ctx context.Context, arg ListJobsByTitleParams) ([]Job, error)
+	ListJobsClosingSoon(ctx context.Context, closesBefore time.Time) ([]ListJobsClosingSoonRow, error)
 	ListJobsForEmployer(ctx context.Context, arg ListJobsForEmployerParams) ([]ListJobsForEmployerRow, error)
 	ListJobsMatchingUserSkills(ctx context.Context, arg ListJobsMatchingUserSkillsParams) ([]ListJobsMatchingUserSkillsRow, error)
+	ListLoginAttempts(ctx context.Context, subjects []string) ([]LoginAttempt, error)
+	// the published jobs matching the saved search, that were published after its last run and until run_at.
+	// The query is matched with the full-text search of postgres (whatever the search provider is), the filters just like in ListJobsByFilters.
+	ListNewJobsForSavedSearch(ctx context.Context, arg ListNewJobsForSavedSearchParams) ([]ListNewJobsForSavedSearchRow, error)
+	ListPendingSearchOutboxEntries(ctx context.Context, limit int32) ([]SearchOutbox, error)
+	ListPublishedJobIDs(ctx context.Context, ids []int32) ([]int32, error)
+	ListSavedSearchesByUserID(ctx context.Context, userID int32) ([]SavedSearch, error)
+	ListSessionsByEmail(ctx context.Context, arg ListSessionsByEmailParams) ([]Session, error)
+	ListUnusedMfaRecoveryCodes(ctx context.Context, arg ListUnusedMfaRecoveryCodesParams) ([]MfaRecoveryCode, error)
+	ListUserLocations(ctx context.Context) ([]ListUserLocationsRow, error)
 	ListUserSkills(ctx context.Context, arg ListUserSkillsParams) ([]UserSkill, error)
 	ListUsersBySkill(ctx context.Context, arg ListUsersBySkillParams) ([]User, error)
+	LockLoginAttempt(ctx context.Context, arg LockLoginAttemptParams) (LoginAttempt, error)
+	MarkSearchOutboxEntriesProcessed(ctx context.Context, ids []int64) error
+	RecordFailedLoginAttempt(ctx context.Context, arg RecordFailedLoginAttemptParams) (LoginAttempt, error)
+	ReplaySearchOutboxEntries(ctx context.Context, createdAt time.Time) (int64, error)
+	RevokeAllTokensByEmail(ctx context.Context, email string) error
+	RevokeToken(ctx context.Context, arg RevokeTokenParams) error
+	UnsubscribeSavedSearch(ctx context.Context, unsubscribeToken string) (SavedSearch, error)
+	UpdateApiKeyLastUsedAt(ctx context.Context, id int64) error
 	UpdateCompany(ctx context.Context, arg UpdateCompanyParams) (Company, error)
+	UpdateCompanyLocation(ctx context.Context, arg UpdateCompanyLocationParams) error
 	UpdateEmployer(ctx context.Context, arg UpdateEmployerParams) (Employer, error)
 	UpdateEmployerPassword(ctx context.Context, arg UpdateEmployerPasswordParams) error
+	UpdateEmployerRole(ctx context.Context, arg UpdateEmployerRoleParams) (Employer, error)
 	UpdateJob(ctx context.Context, arg UpdateJobParams) (Job, error)
+	UpdateJobAnnualSalary(ctx context.Context, arg UpdateJobAnnualSalaryParams) error
 	UpdateJobApplication(ctx context.Context, arg UpdateJobApplicationParams) (JobApplication, error)
 	UpdateJobApplicationStatus(ctx context.Context, arg UpdateJobApplicationStatusParams) error
+	UpdateJobLocation(ctx context.Context, arg UpdateJobLocationParams) error
 	UpdateJobSkill(ctx context.Context, arg UpdateJobSkillParams) (JobSkill, error)
+	UpdateJobStatus(ctx context.Context, arg UpdateJobStatusParams) (Job, error)
 	UpdatePassword(ctx context.Context, arg UpdatePasswordParams) error
+	// the code can be used only for the account type that requested it
+	UpdateResetPassword(ctx context.Context, arg UpdateResetPasswordParams) (ResetPassword, error)
+	// turning the digests on again does not send the jobs published while they were off
+	UpdateSavedSearch(ctx context.Context, arg UpdateSavedSearchParams) (SavedSearch, error)
+	UpdateSavedSearchLastRunAt(ctx context.Context, arg UpdateSavedSearchLastRunAtParams) error
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateUserLocation(ctx context.Context, arg UpdateUserLocationParams) error
 	UpdateUserSkill(ctx context.Context, arg UpdateUserSkillParams) (UserSkill, error)
 	UpdateVerifyEmail(ctx context.Context, arg UpdateVerifyEmailParams) (VerifyEmail, error)
+	UpsertCurrencyRate(ctx context.Context, arg UpsertCurrencyRateParams) (CurrencyRate, error)
+	UpsertMfaSetting(ctx context.Context, arg UpsertMfaSettingParams) (MfaSetting, error)
+	UseCompanyInvitation(ctx context.Context, arg UseCompanyInvitationParams) (CompanyInvitation, error)
+	UseMfaRecoveryCode(ctx context.Context, id int64) (MfaRecoveryCode, error)
 	VerifyEmployerEmail(ctx context.Context, email string) (Employer, error)
 	VerifyUserEmail(ctx context.Context, email string) (User, error)
 }
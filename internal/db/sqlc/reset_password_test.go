@@ -0,0 +1,100 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/aalug/job-finder-go/pkg/token"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func createRandomResetPassword(t *testing.T, email, accountType string) ResetPassword {
+	params := CreateResetPasswordParams{
+		Email:       email,
+		AccountType: accountType,
+		SecretCode:  utils.RandomString(32),
+	}
+
+	resetPassword, err := testQueries.CreateResetPassword(context.Background(), params)
+	require.NoError(t, err)
+	require.Equal(t, params.Email, resetPassword.Email)
+	require.Equal(t, params.AccountType, resetPassword.AccountType)
+	require.Equal(t, params.SecretCode, resetPassword.SecretCode)
+	require.False(t, resetPassword.IsUsed)
+	require.NotZero(t, resetPassword.ID)
+	require.NotZero(t, resetPassword.CreatedAt)
+	require.True(t, resetPassword.ExpiredAt.After(resetPassword.CreatedAt))
+
+	return resetPassword
+}
+
+func TestQueries_CreateResetPassword(t *testing.T) {
+	user := createRandomUser(t)
+	resetPassword := createRandomResetPassword(t, user.Email, string(token.AccountTypeUser))
+	_, err := testQueries.UpdateResetPassword(context.Background(), UpdateResetPasswordParams{
+		ID:          resetPassword.ID,
+		SecretCode:  resetPassword.SecretCode,
+		AccountType: resetPassword.AccountType,
+	})
+	require.NoError(t, err)
+
+	// a new code of the same email replaces the previous one
+	resetPassword2 := createRandomResetPassword(t, user.Email, string(token.AccountTypeUser))
+	require.Equal(t, resetPassword.ID, resetPassword2.ID)
+	require.NotEqual(t, resetPassword.SecretCode, resetPassword2.SecretCode)
+
+	// the code of the employer with the same email is a separate one
+	employerResetPassword := createRandomResetPassword(t, user.Email, string(token.AccountTypeEmployer))
+	require.NotEqual(t, resetPassword2.ID, employerResetPassword.ID)
+
+	_, err = testQueries.UpdateResetPassword(context.Background(), UpdateResetPasswordParams{
+		ID:          resetPassword.ID,
+		SecretCode:  resetPassword.SecretCode,
+		AccountType: resetPassword.AccountType,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestQueries_UpdateResetPassword(t *testing.T) {
+	user := createRandomUser(t)
+	resetPassword := createRandomResetPassword(t, user.Email, string(token.AccountTypeUser))
+	params := UpdateResetPasswordParams{
+		ID:          resetPassword.ID,
+		SecretCode:  resetPassword.SecretCode,
+		AccountType: string(token.AccountTypeEmployer),
+	}
+
+	// the code of the user cannot be used for the employer
+	_, err := testQueries.UpdateResetPassword(context.Background(), params)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	params.AccountType = string(token.AccountTypeUser)
+	updatedResetPassword, err := testQueries.UpdateResetPassword(context.Background(), params)
+	require.NoError(t, err)
+	require.Equal(t, resetPassword.ID, updatedResetPassword.ID)
+	require.Equal(t, resetPassword.Email, updatedResetPassword.Email)
+	require.True(t, updatedResetPassword.IsUsed)
+
+	// the code can be used only once
+	_, err = testQueries.UpdateResetPassword(context.Background(), params)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestQueries_DeleteResetPassword(t *testing.T) {
+	user := createRandomUser(t)
+	resetPassword := createRandomResetPassword(t, user.Email, string(token.AccountTypeUser))
+
+	err := testQueries.DeleteResetPassword(context.Background(), DeleteResetPasswordParams{
+		Email:       user.Email,
+		AccountType: resetPassword.AccountType,
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.UpdateResetPassword(context.Background(), UpdateResetPasswordParams{
+		ID:          resetPassword.ID,
+		SecretCode:  resetPassword.SecretCode,
+		AccountType: resetPassword.AccountType,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestQueries_RevokeToken(t *testing.T) {
+	params := RevokeTokenParams{
+		ID:        uuid.New(),
+		Email:     utils.RandomEmail(),
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+
+	revoked, err := testQueries.IsTokenRevoked(context.Background(), IsTokenRevokedParams{
+		ID:       params.ID,
+		Email:    params.Email,
+		IssuedAt: time.Now(),
+	})
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	err = testQueries.RevokeToken(context.Background(), params)
+	require.NoError(t, err)
+
+	// revoking the same token twice is not an error
+	err = testQueries.RevokeToken(context.Background(), params)
+	require.NoError(t, err)
+
+	revoked, err = testQueries.IsTokenRevoked(context.Background(), IsTokenRevokedParams{
+		ID:       params.ID,
+		Email:    params.Email,
+		IssuedAt: time.Now(),
+	})
+	require.NoError(t, err)
+	require.True(t, revoked)
+}
+
+func TestQueries_RevokeAllTokensByEmail(t *testing.T) {
+	email := utils.RandomEmail()
+	issuedBefore := time.Now().Add(-time.Minute)
+
+	err := testQueries.RevokeAllTokensByEmail(context.Background(), email)
+	require.NoError(t, err)
+
+	// token issued before the revocation
+	revoked, err := testQueries.IsTokenRevoked(context.Background(), IsTokenRevokedParams{
+		ID:       uuid.New(),
+		Email:    email,
+		IssuedAt: issuedBefore,
+	})
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	// token issued after the revocation
+	revoked, err = testQueries.IsTokenRevoked(context.Background(), IsTokenRevokedParams{
+		ID:       uuid.New(),
+		Email:    email,
+		IssuedAt: time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	// revoking again moves the revocation time forward
+	err = testQueries.RevokeAllTokensByEmail(context.Background(), email)
+	require.NoError(t, err)
+}
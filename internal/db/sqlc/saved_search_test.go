@@ -0,0 +1,272 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func createRandomSavedSearch(t *testing.T, userID int32, params CreateSavedSearchParams) SavedSearch {
+	params.UserID = userID
+	params.Name = utils.RandomString(8)
+	params.UnsubscribeToken = utils.RandomString(32)
+	if params.Frequency == "" {
+		params.Frequency = SavedSearchFrequencyDaily
+	}
+
+	savedSearch, err := testQueries.CreateSavedSearch(context.Background(), params)
+	require.NoError(t, err)
+	require.NotZero(t, savedSearch.ID)
+	require.Equal(t, params.UserID, savedSearch.UserID)
+	require.Equal(t, params.Name, savedSearch.Name)
+	require.Equal(t, params.Query, savedSearch.Query)
+	require.Equal(t, params.Industry, savedSearch.Industry)
+	require.Equal(t, params.Frequency, savedSearch.Frequency)
+	require.Equal(t, params.UnsubscribeToken, savedSearch.UnsubscribeToken)
+	require.WithinDuration(t, time.Now(), savedSearch.LastRunAt, time.Minute)
+	require.NotZero(t, savedSearch.CreatedAt)
+
+	return savedSearch
+}
+
+func TestQueries_CreateSavedSearch(t *testing.T) {
+	user := createRandomUser(t)
+	createRandomSavedSearch(t, user.ID, CreateSavedSearchParams{
+		Query: "golang developer",
+		Industry: sql.NullString{
+			String: utils.RandomString(6),
+			Valid:  true,
+		},
+		Frequency: SavedSearchFrequencyWeekly,
+	})
+}
+
+func TestQueries_GetSavedSearch(t *testing.T) {
+	user := createRandomUser(t)
+	savedSearch := createRandomSavedSearch(t, user.ID, CreateSavedSearchParams{})
+
+	savedSearch2, err := testQueries.GetSavedSearch(context.Background(), GetSavedSearchParams{
+		ID:     savedSearch.ID,
+		UserID: user.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, savedSearch, savedSearch2)
+
+	// the saved searches of other users are not found
+	_, err = testQueries.GetSavedSearch(context.Background(), GetSavedSearchParams{
+		ID:     savedSearch.ID,
+		UserID: createRandomUser(t).ID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestQueries_ListSavedSearchesByUserID(t *testing.T) {
+	user := createRandomUser(t)
+	for i := 0; i < 3; i++ {
+		createRandomSavedSearch(t, user.ID, CreateSavedSearchParams{})
+	}
+	createRandomSavedSearch(t, createRandomUser(t).ID, CreateSavedSearchParams{})
+
+	savedSearches, err := testQueries.ListSavedSearchesByUserID(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Len(t, savedSearches, 3)
+	for _, savedSearch := range savedSearches {
+		require.Equal(t, user.ID, savedSearch.UserID)
+	}
+}
+
+func TestQueries_UpdateSavedSearch(t *testing.T) {
+	user := createRandomUser(t)
+	savedSearch := createRandomSavedSearch(t, user.ID, CreateSavedSearchParams{
+		Frequency: SavedSearchFrequencyNever,
+	})
+	lastRunAt := time.Now().Add(-48 * time.Hour)
+	err := testQueries.UpdateSavedSearchLastRunAt(context.Background(), UpdateSavedSearchLastRunAtParams{
+		ID:        savedSearch.ID,
+		LastRunAt: lastRunAt,
+	})
+	require.NoError(t, err)
+
+	params := UpdateSavedSearchParams{
+		ID:     savedSearch.ID,
+		UserID: user.ID,
+		Name:   utils.RandomString(8),
+		Query:  "remote golang",
+		SalaryMin: sql.NullInt32{
+			Int32: 50000,
+			Valid: true,
+		},
+		Frequency: SavedSearchFrequencyDaily,
+	}
+	savedSearch2, err := testQueries.UpdateSavedSearch(context.Background(), params)
+	require.NoError(t, err)
+	require.Equal(t, params.Name, savedSearch2.Name)
+	require.Equal(t, params.Query, savedSearch2.Query)
+	require.Equal(t, params.SalaryMin, savedSearch2.SalaryMin)
+	require.Equal(t, params.Frequency, savedSearch2.Frequency)
+	require.Equal(t, savedSearch.UnsubscribeToken, savedSearch2.UnsubscribeToken)
+	// turning the digests on starts them from now
+	require.WithinDuration(t, time.Now(), savedSearch2.LastRunAt, time.Minute)
+
+	// the last run is kept while the digests stay on
+	err = testQueries.UpdateSavedSearchLastRunAt(context.Background(), UpdateSavedSearchLastRunAtParams{
+		ID:        savedSearch.ID,
+		LastRunAt: lastRunAt,
+	})
+	require.NoError(t, err)
+	params.Frequency = SavedSearchFrequencyWeekly
+	savedSearch3, err := testQueries.UpdateSavedSearch(context.Background(), params)
+	require.NoError(t, err)
+	require.WithinDuration(t, lastRunAt, savedSearch3.LastRunAt, time.Second)
+
+	// the saved searches of other users are not updated
+	params.UserID = createRandomUser(t).ID
+	_, err = testQueries.UpdateSavedSearch(context.Background(), params)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestQueries_UnsubscribeSavedSearch(t *testing.T) {
+	user := createRandomUser(t)
+	savedSearch := createRandomSavedSearch(t, user.ID, CreateSavedSearchParams{})
+
+	savedSearch2, err := testQueries.UnsubscribeSavedSearch(context.Background(), savedSearch.UnsubscribeToken)
+	require.NoError(t, err)
+	require.Equal(t, savedSearch.ID, savedSearch2.ID)
+	require.Equal(t, SavedSearchFrequencyNever, savedSearch2.Frequency)
+
+	_, err = testQueries.UnsubscribeSavedSearch(context.Background(), utils.RandomString(32))
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestQueries_DeleteSavedSearch(t *testing.T) {
+	user := createRandomUser(t)
+	savedSearch := createRandomSavedSearch(t, user.ID, CreateSavedSearchParams{})
+
+	// the saved searches of other users are not deleted
+	_, err := testQueries.DeleteSavedSearch(context.Background(), DeleteSavedSearchParams{
+		ID:     savedSearch.ID,
+		UserID: createRandomUser(t).ID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = testQueries.DeleteSavedSearch(context.Background(), DeleteSavedSearchParams{
+		ID:     savedSearch.ID,
+		UserID: user.ID,
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.GetSavedSearch(context.Background(), GetSavedSearchParams{
+		ID:     savedSearch.ID,
+		UserID: user.ID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestQueries_ListDueSavedSearches(t *testing.T) {
+	user := createRandomUser(t)
+	_, err := testQueries.VerifyUserEmail(context.Background(), user.Email)
+	require.NoError(t, err)
+	unverifiedUser := createRandomUser(t)
+
+	daily := createRandomSavedSearch(t, user.ID, CreateSavedSearchParams{Frequency: SavedSearchFrequencyDaily})
+	weekly := createRandomSavedSearch(t, user.ID, CreateSavedSearchParams{Frequency: SavedSearchFrequencyWeekly})
+	never := createRandomSavedSearch(t, user.ID, CreateSavedSearchParams{Frequency: SavedSearchFrequencyNever})
+	unverified := createRandomSavedSearch(t, unverifiedUser.ID, CreateSavedSearchParams{})
+
+	listDueIDs := func(runAt time.Time, skippedIDs ...int64) []int64 {
+		searches, err := testQueries.ListDueSavedSearches(context.Background(), ListDueSavedSearchesParams{
+			RunAt:      runAt,
+			SkippedIds: append([]int64{}, skippedIDs...),
+			Size:       10000,
+		})
+		require.NoError(t, err)
+
+		var ids []int64
+		for _, search := range searches {
+			ids = append(ids, search.ID)
+			if search.ID == daily.ID {
+				require.Equal(t, user.Email, search.Email)
+				require.Equal(t, user.FullName, search.FullName)
+			}
+		}
+		return ids
+	}
+
+	ids := listDueIDs(time.Now().Add(time.Hour))
+	require.NotContains(t, ids, daily.ID)
+
+	ids = listDueIDs(time.Now().Add(25 * time.Hour))
+	require.Contains(t, ids, daily.ID)
+	require.NotContains(t, ids, weekly.ID)
+	require.NotContains(t, ids, never.ID)
+	require.NotContains(t, ids, unverified.ID)
+
+	ids = listDueIDs(time.Now().Add(8 * 24 * time.Hour))
+	require.Contains(t, ids, daily.ID)
+	require.Contains(t, ids, weekly.ID)
+	require.NotContains(t, ids, never.ID)
+
+	ids = listDueIDs(time.Now().Add(8*24*time.Hour), daily.ID)
+	require.NotContains(t, ids, daily.ID)
+	require.Contains(t, ids, weekly.ID)
+}
+
+func TestQueries_ListNewJobsForSavedSearch(t *testing.T) {
+	user := createRandomUser(t)
+	company := createRandomCompany(t, "")
+	industry := utils.RandomString(8)
+	word := utils.RandomString(8)
+
+	savedSearch := createRandomSavedSearch(t, user.ID, CreateSavedSearchParams{
+		Query: word,
+		Industry: sql.NullString{
+			String: industry,
+			Valid:  true,
+		},
+		EmploymentType: NullEmploymentType{
+			EmploymentType: EmploymentTypeFullTime,
+			Valid:          true,
+		},
+	})
+	err := testQueries.UpdateSavedSearchLastRunAt(context.Background(), UpdateSavedSearchLastRunAtParams{
+		ID:        savedSearch.ID,
+		LastRunAt: time.Now().Add(-time.Hour),
+	})
+	require.NoError(t, err)
+
+	job := createRandomJob(t, &company, jobDetails{title: "Senior " + word + " Developer", industry: industry})
+	// jobs of other industries, without the word, of other employment types or not published do not match
+	createRandomJob(t, &company, jobDetails{title: word, industry: utils.RandomString(8)})
+	createRandomJob(t, &company, jobDetails{industry: industry})
+	createRandomJob(t, &company, jobDetails{title: word, industry: industry, employmentType: EmploymentTypeContract})
+	createRandomJob(t, &company, jobDetails{title: word, industry: industry, status: JobStatusDraft})
+
+	runAt := time.Now().Add(time.Minute)
+	jobs, err := testQueries.ListNewJobsForSavedSearch(context.Background(), ListNewJobsForSavedSearchParams{
+		RunAt: runAt,
+		ID:    savedSearch.ID,
+		Size:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	require.Equal(t, job.ID, jobs[0].ID)
+	require.Equal(t, job.Title, jobs[0].Title)
+	require.Equal(t, company.Name, jobs[0].CompanyName)
+
+	// the jobs published before the last run are not new
+	err = testQueries.UpdateSavedSearchLastRunAt(context.Background(), UpdateSavedSearchLastRunAtParams{
+		ID:        savedSearch.ID,
+		LastRunAt: runAt,
+	})
+	require.NoError(t, err)
+	jobs, err = testQueries.ListNewJobsForSavedSearch(context.Background(), ListNewJobsForSavedSearchParams{
+		RunAt: runAt.Add(time.Minute),
+		ID:    savedSearch.ID,
+		Size:  10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, jobs)
+}
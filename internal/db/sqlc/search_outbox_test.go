@@ -0,0 +1,142 @@
+package db
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// listSearchOutboxEntriesOfJob returns the search outbox entries of the job,
+// the pending entries of other tests are ignored
+func listSearchOutboxEntriesOfJob(t *testing.T, jobID int32) []SearchOutbox {
+	rows, err := testDB.QueryContext(context.Background(),
+		"SELECT id, job_id, created_at, processed_at FROM search_outbox WHERE job_id = $1 ORDER BY id", jobID)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var entries []SearchOutbox
+	for rows.Next() {
+		var entry SearchOutbox
+		err = rows.Scan(&entry.ID, &entry.JobID, &entry.CreatedAt, &entry.ProcessedAt)
+		require.NoError(t, err)
+		entries = append(entries, entry)
+	}
+	require.NoError(t, rows.Err())
+
+	return entries
+}
+
+func TestQueries_CreateSearchOutboxEntry(t *testing.T) {
+	job := createRandomJob(t, nil, jobDetails{})
+
+	err := testQueries.CreateSearchOutboxEntry(context.Background(), job.ID)
+	require.NoError(t, err)
+
+	entries := listSearchOutboxEntriesOfJob(t, job.ID)
+	require.Len(t, entries, 1)
+	require.Equal(t, job.ID, entries[0].JobID)
+	require.NotZero(t, entries[0].ID)
+	require.WithinDuration(t, time.Now(), entries[0].CreatedAt, time.Minute)
+	require.False(t, entries[0].ProcessedAt.Valid)
+}
+
+func TestQueries_CreateSearchOutboxEntriesForCompany(t *testing.T) {
+	company := createRandomCompany(t, "")
+	var jobs []Job
+	for i := 0; i < 3; i++ {
+		jobs = append(jobs, createRandomJob(t, &company, jobDetails{}))
+	}
+	otherJob := createRandomJob(t, nil, jobDetails{})
+
+	err := testQueries.CreateSearchOutboxEntriesForCompany(context.Background(), company.ID)
+	require.NoError(t, err)
+
+	for _, job := range jobs {
+		require.Len(t, listSearchOutboxEntriesOfJob(t, job.ID), 1)
+	}
+	require.Empty(t, listSearchOutboxEntriesOfJob(t, otherJob.ID))
+}
+
+func TestQueries_ListPendingSearchOutboxEntries(t *testing.T) {
+	for i := 0; i < 3; i++ {
+		job := createRandomJob(t, nil, jobDetails{})
+		err := testQueries.CreateSearchOutboxEntry(context.Background(), job.ID)
+		require.NoError(t, err)
+	}
+
+	entries, err := testQueries.ListPendingSearchOutboxEntries(context.Background(), 2)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Less(t, entries[0].ID, entries[1].ID)
+	for _, entry := range entries {
+		require.False(t, entry.ProcessedAt.Valid)
+	}
+}
+
+func TestQueries_MarkSearchOutboxEntriesProcessed(t *testing.T) {
+	job := createRandomJob(t, nil, jobDetails{})
+	for i := 0; i < 2; i++ {
+		err := testQueries.CreateSearchOutboxEntry(context.Background(), job.ID)
+		require.NoError(t, err)
+	}
+	entries := listSearchOutboxEntriesOfJob(t, job.ID)
+	require.Len(t, entries, 2)
+
+	err := testQueries.MarkSearchOutboxEntriesProcessed(context.Background(), []int64{entries[0].ID})
+	require.NoError(t, err)
+
+	entries = listSearchOutboxEntriesOfJob(t, job.ID)
+	require.True(t, entries[0].ProcessedAt.Valid)
+	require.WithinDuration(t, time.Now(), entries[0].ProcessedAt.Time, time.Minute)
+	require.False(t, entries[1].ProcessedAt.Valid)
+}
+
+func TestQueries_ReplaySearchOutboxEntries(t *testing.T) {
+	job := createRandomJob(t, nil, jobDetails{})
+	err := testQueries.CreateSearchOutboxEntry(context.Background(), job.ID)
+	require.NoError(t, err)
+	entries := listSearchOutboxEntriesOfJob(t, job.ID)
+	require.Len(t, entries, 1)
+
+	err = testQueries.MarkSearchOutboxEntriesProcessed(context.Background(), []int64{entries[0].ID})
+	require.NoError(t, err)
+
+	// entries created after the given time are not replayed
+	replayed, err := testQueries.ReplaySearchOutboxEntries(context.Background(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Zero(t, replayed)
+	require.True(t, listSearchOutboxEntriesOfJob(t, job.ID)[0].ProcessedAt.Valid)
+
+	replayed, err = testQueries.ReplaySearchOutboxEntries(context.Background(), entries[0].CreatedAt)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, replayed, int64(1))
+	require.False(t, listSearchOutboxEntriesOfJob(t, job.ID)[0].ProcessedAt.Valid)
+}
+
+func TestQueries_DeleteProcessedSearchOutboxEntries(t *testing.T) {
+	job := createRandomJob(t, nil, jobDetails{})
+	for i := 0; i < 2; i++ {
+		err := testQueries.CreateSearchOutboxEntry(context.Background(), job.ID)
+		require.NoError(t, err)
+	}
+	entries := listSearchOutboxEntriesOfJob(t, job.ID)
+	require.Len(t, entries, 2)
+
+	err := testQueries.MarkSearchOutboxEntriesProcessed(context.Background(), []int64{entries[0].ID})
+	require.NoError(t, err)
+
+	// entries processed after the given time are kept
+	_, err = testQueries.DeleteProcessedSearchOutboxEntries(context.Background(), time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Len(t, listSearchOutboxEntriesOfJob(t, job.ID), 2)
+
+	deleted, err := testQueries.DeleteProcessedSearchOutboxEntries(context.Background(), time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, deleted, int64(1))
+
+	// pending entries are never deleted
+	entries = listSearchOutboxEntriesOfJob(t, job.ID)
+	require.Len(t, entries, 1)
+	require.False(t, entries[0].ProcessedAt.Valid)
+}
@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// createRandomSession creates and returns a random session for a given email
+func createRandomSession(t *testing.T, email string) Session {
+	params := CreateSessionParams{
+		ID:           uuid.New(),
+		Email:        email,
+		RefreshToken: utils.RandomString(32),
+		UserAgent:    utils.RandomString(10),
+		ClientIp:     "127.0.0.1",
+		IsBlocked:    false,
+		ExpiresAt:    time.Now().Add(time.Hour),
+	}
+
+	session, err := testQueries.CreateSession(context.Background(), params)
+	require.NoError(t, err)
+	require.Equal(t, params.ID, session.ID)
+	require.Equal(t, params.Email, session.Email)
+	require.Equal(t, params.RefreshToken, session.RefreshToken)
+	require.Equal(t, params.UserAgent, session.UserAgent)
+	require.Equal(t, params.ClientIp, session.ClientIp)
+	require.False(t, session.IsBlocked)
+	require.WithinDuration(t, params.ExpiresAt, session.ExpiresAt, time.Second)
+	require.NotZero(t, session.CreatedAt)
+
+	return session
+}
+
+func TestQueries_CreateSession(t *testing.T) {
+	user := createRandomUser(t)
+	createRandomSession(t, user.Email)
+}
+
+func TestQueries_GetSession(t *testing.T) {
+	user := createRandomUser(t)
+	session := createRandomSession(t, user.Email)
+
+	session2, err := testQueries.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.Equal(t, session.ID, session2.ID)
+	require.Equal(t, session.Email, session2.Email)
+	require.Equal(t, session.RefreshToken, session2.RefreshToken)
+	require.WithinDuration(t, session.ExpiresAt, session2.ExpiresAt, time.Second)
+	require.WithinDuration(t, session.CreatedAt, session2.CreatedAt, time.Second)
+}
+
+func TestQueries_ListSessionsByEmail(t *testing.T) {
+	employer := createRandomEmployer(t, 0)
+	for i := 0; i < 5; i++ {
+		createRandomSession(t, employer.Email)
+	}
+
+	params := ListSessionsByEmailParams{
+		Email:  employer.Email,
+		Limit:  3,
+		Offset: 1,
+	}
+	sessions, err := testQueries.ListSessionsByEmail(context.Background(), params)
+	require.NoError(t, err)
+	require.Len(t, sessions, 3)
+	for _, s := range sessions {
+		require.Equal(t, employer.Email, s.Email)
+	}
+}
+
+func TestQueries_BlockSession(t *testing.T) {
+	user := createRandomUser(t)
+	session := createRandomSession(t, user.Email)
+
+	// other email cannot block the session
+	_, err := testQueries.BlockSession(context.Background(), BlockSessionParams{
+		ID:    session.ID,
+		Email: utils.RandomEmail(),
+	})
+	require.Error(t, err)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	blocked, err := testQueries.BlockSession(context.Background(), BlockSessionParams{
+		ID:    session.ID,
+		Email: user.Email,
+	})
+	require.NoError(t, err)
+	require.Equal(t, session.ID, blocked.ID)
+	require.True(t, blocked.IsBlocked)
+}
@@ -14,13 +14,30 @@ type Store interface {
 	DeleteJobPosting(ctx context.Context, jobID int32) error
 	GetUserDetailsByEmail(ctx context.Context, email string) (User, []UserSkill, error)
 	ListJobsByFilters(ctx context.Context, arg ListJobsByFiltersParams) ([]ListJobsByFiltersRow, error)
+	ListJobSearchFacets(ctx context.Context, arg ListJobSearchFacetsParams) ([]ListJobSearchFacetsRow, error)
+	SearchJobsFullText(ctx context.Context, arg SearchJobsFullTextParams) ([]SearchJobsFullTextRow, error)
 	CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error)
 	CreateEmployerTx(ctx context.Context, arg CreateEmployerTxParams) (CreateEmployerTxResult, error)
 	ExecTx(ctx context.Context, fn func(*Queries) error) error
 	VerifyUserEmailTx(ctx context.Context, arg VerifyEmailTxParams) (VerifyUserEmailResult, error)
 	VerifyEmployerEmailTx(ctx context.Context, arg VerifyEmailTxParams) (VerifyEmployerEmailResult, error)
 	CreateJobApplicationTx(ctx context.Context, arg CreateJobApplicationTxParams) (CreateJobApplicationTxResult, error)
+	RevokeAllTokensTx(ctx context.Context, email string) error
+	UpdateUserPasswordTx(ctx context.Context, arg UpdatePasswordTxParams) error
+	UpdateEmployerPasswordTx(ctx context.Context, arg UpdatePasswordTxParams) error
+	DeleteEmployerTx(ctx context.Context, arg DeleteEmployerTxParams) error
+	ResetUserPasswordTx(ctx context.Context, arg ResetPasswordTxParams) (ResetUserPasswordResult, error)
+	ResetEmployerPasswordTx(ctx context.Context, arg ResetPasswordTxParams) (ResetEmployerPasswordResult, error)
+	EnableMfaTx(ctx context.Context, arg EnableMfaTxParams) (MfaSetting, error)
+	DisableMfaTx(ctx context.Context, accountType string, accountID int32) error
+	OidcLoginTx(ctx context.Context, arg OidcLoginTxParams) (OidcLoginTxResult, error)
+	AcceptCompanyInvitationTx(ctx context.Context, arg AcceptCompanyInvitationTxParams) (AcceptCompanyInvitationTxResult, error)
 	LoadTestData(ctx context.Context)
+	NormalizeLocations(ctx context.Context) (NormalizeLocationsResult, error)
+	CreateJobTx(ctx context.Context, arg CreateJobTxParams) (CreateJobTxResult, error)
+	UpdateJobTx(ctx context.Context, arg UpdateJobTxParams) (UpdateJobTxResult, error)
+	UpdateJobStatusTx(ctx context.Context, arg UpdateJobStatusParams) (Job, error)
+	UpdateCompanyTx(ctx context.Context, arg UpdateCompanyParams) (Company, error)
 }
 
 // SQLStore provides all functions to execute db queries and transactions
@@ -65,13 +82,18 @@ func (store *SQLStore) CreateMultipleUserSkills(ctx context.Context, arg []Creat
 
 // CreateMultipleJobSkills creates multiple job skills for a job with ID of jobID
 func (store *SQLStore) CreateMultipleJobSkills(ctx context.Context, skills []string, jobID int32) error {
+	return createJobSkills(ctx, store.Queries, skills, jobID)
+}
+
+// createJobSkills creates the job skills with the queries, that can run in a transaction
+func createJobSkills(ctx context.Context, q *Queries, skills []string, jobID int32) error {
 	for _, skill := range skills {
 		params := CreateJobSkillParams{
 			Skill: skill,
 			JobID: jobID,
 		}
 
-		_, err := store.CreateJobSkill(ctx, params)
+		_, err := q.CreateJobSkill(ctx, params)
 		if err != nil {
 			return err
 		}
@@ -80,21 +102,24 @@ func (store *SQLStore) CreateMultipleJobSkills(ctx context.Context, skills []str
 	return nil
 }
 
-// DeleteJobPosting deletes a job with all its skills
+// DeleteJobPosting deletes a job with all its skills and creates the search outbox entry
+// that removes the job from the elasticsearch index
 func (store *SQLStore) DeleteJobPosting(ctx context.Context, jobID int32) error {
-	// Delete job skills
-	err := store.DeleteJobSkillsByJobID(ctx, jobID)
-	if err != nil {
-		return err
-	}
+	return store.ExecTx(ctx, func(q *Queries) error {
+		// Delete job skills
+		err := q.DeleteJobSkillsByJobID(ctx, jobID)
+		if err != nil {
+			return err
+		}
 
-	// Delete job
-	err = store.DeleteJob(ctx, jobID)
-	if err != nil {
-		return err
-	}
+		// Delete job
+		err = q.DeleteJob(ctx, jobID)
+		if err != nil {
+			return err
+		}
 
-	return nil
+		return q.CreateSearchOutboxEntry(ctx, jobID)
+	})
 }
 
 // GetUserDetailsByEmail gets user details (user, user skills) by email
@@ -120,40 +145,75 @@ func (store *SQLStore) GetUserDetailsByEmail(ctx context.Context, email string)
 // This function could not be implemented using sqlc.
 // Because of that, it is implemented manually.
 const listJobsByFilters = `-- name: ListJobsByFilters :many
-SELECT j.id, j.title, j.industry, j.company_id, j.description, j.location, j.salary_min, j.salary_max, j.requirements, j.created_at,
+SELECT j.id, j.title, j.industry, j.company_id, j.description, j.location, j.salary_min, j.salary_max, j.requirements, j.created_at, j.status, j.published_at, j.closes_at, j.employment_type, j.seniority_level, j.remote_policy, j.salary_currency, j.salary_period, j.salary_min_annual, j.salary_max_annual, j.latitude, j.longitude,
        c.name AS company_name
 FROM jobs j
          JOIN companies c ON j.company_id = c.id
 WHERE ($3::text IS NULL OR j.title ILIKE '%' || $3 || '%')
   AND ($4::text IS NULL OR j.location = $4)
   AND ($5::text IS NULL OR j.industry = $5)
-  AND ($6::int IS NULL OR j.salary_min >= $6)
-  AND ($7::int IS NULL OR j.salary_max <= $7)
+  AND ($6::int IS NULL OR j.salary_min_annual >= $6)
+  AND ($7::int IS NULL OR j.salary_max_annual <= $7)
+  AND ($8::employment_type IS NULL OR j.employment_type = $8)
+  AND ($9::seniority_level IS NULL OR j.seniority_level = $9)
+  AND ($10::remote_policy IS NULL OR j.remote_policy = $10)
+  AND ($11::text IS NULL OR j.salary_currency = $11)
+  AND ($12::salary_period IS NULL OR j.salary_period = $12)
+  AND ($13::float8 IS NULL OR (
+        j.latitude BETWEEN $13::float8 - $15::float8 / 111.0 AND $13::float8 + $15::float8 / 111.0
+        AND 2 * 6371 * asin(sqrt(
+                power(sin(radians(j.latitude - $13::float8) / 2), 2) +
+                cos(radians($13::float8)) * cos(radians(j.latitude)) *
+                power(sin(radians(j.longitude - $14::float8) / 2), 2)
+            )) <= $15::float8
+    ))
+  AND j.status = 'published'
+  AND (j.closes_at IS NULL OR j.closes_at > now())
 LIMIT $1 OFFSET $2
 `
 
 type ListJobsByFiltersParams struct {
-	Limit       int32          `json:"limit"`
-	Offset      int32          `json:"offset"`
-	Title       sql.NullString `json:"title"`
-	JobLocation sql.NullString `json:"job_location"`
-	Industry    sql.NullString `json:"industry"`
-	SalaryMin   sql.NullInt32  `json:"salary_min"`
-	SalaryMax   sql.NullInt32  `json:"salary_max"`
+	Limit          int32              `json:"limit"`
+	Offset         int32              `json:"offset"`
+	Title          sql.NullString     `json:"title"`
+	JobLocation    sql.NullString     `json:"job_location"`
+	Industry       sql.NullString     `json:"industry"`
+	SalaryMin      sql.NullInt32      `json:"salary_min"`
+	SalaryMax      sql.NullInt32      `json:"salary_max"`
+	EmploymentType NullEmploymentType `json:"employment_type"`
+	SeniorityLevel NullSeniorityLevel `json:"seniority_level"`
+	RemotePolicy   NullRemotePolicy   `json:"remote_policy"`
+	SalaryCurrency sql.NullString     `json:"salary_currency"`
+	SalaryPeriod   NullSalaryPeriod   `json:"salary_period"`
+	NearLatitude   sql.NullFloat64    `json:"near_latitude"`
+	NearLongitude  sql.NullFloat64    `json:"near_longitude"`
+	RadiusKm       sql.NullFloat64    `json:"radius_km"`
 }
 
 type ListJobsByFiltersRow struct {
-	ID           int32     `json:"id"`
-	Title        string    `json:"title"`
-	Industry     string    `json:"industry"`
-	CompanyID    int32     `json:"company_id"`
-	Description  string    `json:"description"`
-	Location     string    `json:"location"`
-	SalaryMin    int32     `json:"salary_min"`
-	SalaryMax    int32     `json:"salary_max"`
-	Requirements string    `json:"requirements"`
-	CreatedAt    time.Time `json:"created_at"`
-	CompanyName  string    `json:"company_name"`
+	ID              int32           `json:"id"`
+	Title           string          `json:"title"`
+	Industry        string          `json:"industry"`
+	CompanyID       int32           `json:"company_id"`
+	Description     string          `json:"description"`
+	Location        string          `json:"location"`
+	SalaryMin       int32           `json:"salary_min"`
+	SalaryMax       int32           `json:"salary_max"`
+	Requirements    string          `json:"requirements"`
+	CreatedAt       time.Time       `json:"created_at"`
+	Status          JobStatus       `json:"status"`
+	PublishedAt     sql.NullTime    `json:"published_at"`
+	ClosesAt        sql.NullTime    `json:"closes_at"`
+	EmploymentType  EmploymentType  `json:"employment_type"`
+	SeniorityLevel  SeniorityLevel  `json:"seniority_level"`
+	RemotePolicy    RemotePolicy    `json:"remote_policy"`
+	SalaryCurrency  string          `json:"salary_currency"`
+	SalaryPeriod    SalaryPeriod    `json:"salary_period"`
+	SalaryMinAnnual int32           `json:"salary_min_annual"`
+	SalaryMaxAnnual int32           `json:"salary_max_annual"`
+	Latitude        sql.NullFloat64 `json:"latitude"`
+	Longitude       sql.NullFloat64 `json:"longitude"`
+	CompanyName     string          `json:"company_name"`
 }
 
 func (store *SQLStore) ListJobsByFilters(ctx context.Context, arg ListJobsByFiltersParams) ([]ListJobsByFiltersRow, error) {
@@ -165,6 +225,14 @@ func (store *SQLStore) ListJobsByFilters(ctx context.Context, arg ListJobsByFilt
 		arg.Industry,
 		arg.SalaryMin,
 		arg.SalaryMax,
+		arg.EmploymentType,
+		arg.SeniorityLevel,
+		arg.RemotePolicy,
+		arg.SalaryCurrency,
+		arg.SalaryPeriod,
+		arg.NearLatitude,
+		arg.NearLongitude,
+		arg.RadiusKm,
 	)
 	if err != nil {
 		return nil, err
@@ -184,6 +252,18 @@ func (store *SQLStore) ListJobsByFilters(ctx context.Context, arg ListJobsByFilt
 			&i.SalaryMax,
 			&i.Requirements,
 			&i.CreatedAt,
+			&i.Status,
+			&i.PublishedAt,
+			&i.ClosesAt,
+			&i.EmploymentType,
+			&i.SeniorityLevel,
+			&i.RemotePolicy,
+			&i.SalaryCurrency,
+			&i.SalaryPeriod,
+			&i.SalaryMinAnnual,
+			&i.SalaryMaxAnnual,
+			&i.Latitude,
+			&i.Longitude,
 			&i.CompanyName,
 		); err != nil {
 			return nil, err
@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"database/sql"
+	"github.com/aalug/job-finder-go/internal/geo"
 	"github.com/aalug/job-finder-go/pkg/utils"
 	"github.com/stretchr/testify/require"
 	"testing"
@@ -53,6 +54,9 @@ func TestSQLStore_DeleteJobPosting(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, jobSkills, 0)
 	require.Empty(t, jobSkills)
+
+	// the entry removes the job from the elasticsearch index
+	require.Len(t, listSearchOutboxEntriesOfJob(t, job.ID), 1)
 }
 
 func TestSQLStore_GetUserDetailsByEmail(t *testing.T) {
@@ -123,3 +127,111 @@ func TestSQLStore_ListJobsByFilters(t *testing.T) {
 		require.NotZero(t, job.ID)
 	}
 }
+
+func TestSQLStore_ListJobsByFiltersAttributes(t *testing.T) {
+	company := createRandomCompany(t, "")
+	title := utils.RandomString(5)
+	remoteContractJob := createRandomJob(t, &company, jobDetails{
+		title:          title,
+		employmentType: EmploymentTypeContract,
+		remotePolicy:   RemotePolicyRemote,
+	})
+	createRandomJob(t, &company, jobDetails{
+		title:          title,
+		employmentType: EmploymentTypeContract,
+		remotePolicy:   RemotePolicyOnsite,
+	})
+	createRandomJob(t, &company, jobDetails{
+		title:          title,
+		employmentType: EmploymentTypeFullTime,
+		remotePolicy:   RemotePolicyRemote,
+	})
+
+	params := ListJobsByFiltersParams{
+		Limit:  5,
+		Offset: 0,
+		Title: sql.NullString{
+			String: title,
+			Valid:  true,
+		},
+		EmploymentType: NullEmploymentType{
+			EmploymentType: EmploymentTypeContract,
+			Valid:          true,
+		},
+		RemotePolicy: NullRemotePolicy{
+			RemotePolicy: RemotePolicyRemote,
+			Valid:        true,
+		},
+		SalaryCurrency: sql.NullString{
+			String: remoteContractJob.SalaryCurrency,
+			Valid:  true,
+		},
+		SalaryPeriod: NullSalaryPeriod{
+			SalaryPeriod: remoteContractJob.SalaryPeriod,
+			Valid:        true,
+		},
+	}
+
+	jobs, err := testStore.ListJobsByFilters(context.Background(), params)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	require.Equal(t, remoteContractJob.ID, jobs[0].ID)
+	require.Equal(t, EmploymentTypeContract, jobs[0].EmploymentType)
+	require.Equal(t, RemotePolicyRemote, jobs[0].RemotePolicy)
+	require.Equal(t, remoteContractJob.SeniorityLevel, jobs[0].SeniorityLevel)
+}
+
+func TestSQLStore_ListJobsByFiltersNear(t *testing.T) {
+	company := createRandomCompany(t, "")
+	title := utils.RandomString(5)
+	berlin, ok := geo.Lookup("Berlin")
+	require.True(t, ok)
+	munich, ok := geo.Lookup("Munich")
+	require.True(t, ok)
+	berlinJob := createRandomJob(t, &company, jobDetails{
+		title: title,
+		city:  &berlin,
+	})
+	createRandomJob(t, &company, jobDetails{
+		title: title,
+		city:  &munich,
+	})
+	// jobs without coordinates are not in any radius
+	createRandomJob(t, &company, jobDetails{
+		title: title,
+	})
+
+	params := ListJobsByFiltersParams{
+		Limit:  5,
+		Offset: 0,
+		Title: sql.NullString{
+			String: title,
+			Valid:  true,
+		},
+		NearLatitude: sql.NullFloat64{
+			Float64: berlin.Latitude,
+			Valid:   true,
+		},
+		NearLongitude: sql.NullFloat64{
+			Float64: berlin.Longitude,
+			Valid:   true,
+		},
+		RadiusKm: sql.NullFloat64{
+			Float64: 50,
+			Valid:   true,
+		},
+	}
+
+	jobs, err := testStore.ListJobsByFilters(context.Background(), params)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	require.Equal(t, berlinJob.ID, jobs[0].ID)
+	require.Equal(t, berlinJob.Latitude, jobs[0].Latitude)
+	require.Equal(t, berlinJob.Longitude, jobs[0].Longitude)
+
+	// Munich is about 504 km from Berlin
+	params.RadiusKm.Float64 = 600
+	jobs, err = testStore.ListJobsByFilters(context.Background(), params)
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+}
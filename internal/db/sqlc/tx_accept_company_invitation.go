@@ -0,0 +1,50 @@
+package db
+
+import "context"
+
+type AcceptCompanyInvitationTxParams struct {
+	ID             int64
+	SecretCode     string
+	FullName       string
+	HashedPassword string
+}
+
+type AcceptCompanyInvitationTxResult struct {
+	Employer   Employer
+	Invitation CompanyInvitation
+}
+
+// AcceptCompanyInvitationTx uses the invitation and creates a new employer in the company
+// of the invitation, with its role. The email of the employer is marked as verified,
+// because the invitation was sent to it.
+func (store *SQLStore) AcceptCompanyInvitationTx(ctx context.Context, arg AcceptCompanyInvitationTxParams) (AcceptCompanyInvitationTxResult, error) {
+	var result AcceptCompanyInvitationTxResult
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Invitation, err = q.UseCompanyInvitation(ctx, UseCompanyInvitationParams{
+			ID:         arg.ID,
+			SecretCode: arg.SecretCode,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreateEmployer(ctx, CreateEmployerParams{
+			CompanyID:      result.Invitation.CompanyID,
+			FullName:       arg.FullName,
+			Email:          result.Invitation.Email,
+			HashedPassword: arg.HashedPassword,
+			Role:           result.Invitation.Role,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Employer, err = q.VerifyEmployerEmail(ctx, result.Invitation.Email)
+		return err
+	})
+
+	return result, err
+}
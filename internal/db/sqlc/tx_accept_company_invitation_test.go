@@ -0,0 +1,34 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSQLStore_AcceptCompanyInvitationTx(t *testing.T) {
+	store := NewStore(testDB)
+	inviter := createRandomEmployer(t, 0)
+	invitation := createRandomCompanyInvitation(t, inviter)
+
+	params := AcceptCompanyInvitationTxParams{
+		ID:             invitation.ID,
+		SecretCode:     invitation.SecretCode,
+		FullName:       utils.RandomString(6),
+		HashedPassword: utils.RandomString(6),
+	}
+	result, err := store.AcceptCompanyInvitationTx(context.Background(), params)
+	require.NoError(t, err)
+	require.True(t, result.Invitation.IsUsed)
+	require.Equal(t, inviter.CompanyID, result.Employer.CompanyID)
+	require.Equal(t, invitation.Email, result.Employer.Email)
+	require.Equal(t, invitation.Role, result.Employer.Role)
+	require.Equal(t, params.FullName, result.Employer.FullName)
+	require.True(t, result.Employer.IsEmailVerified)
+
+	// the invitation cannot be accepted twice
+	_, err = store.AcceptCompanyInvitationTx(context.Background(), params)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
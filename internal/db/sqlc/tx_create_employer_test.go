@@ -15,6 +15,7 @@ func TestSQLStore_CreateEmployerTx(t *testing.T) {
 			Email:          utils.RandomEmail(),
 			HashedPassword: utils.RandomString(6),
 			CompanyID:      company.ID,
+			Role:           CompanyRoleOwner,
 		},
 		AfterCreate: func(employer Employer) error {
 			return nil
@@ -27,6 +28,7 @@ func TestSQLStore_CreateEmployerTx(t *testing.T) {
 	require.Equal(t, params.CreateEmployerParams.FullName, result.Employer.FullName)
 	require.Equal(t, params.CreateEmployerParams.Email, result.Employer.Email)
 	require.Equal(t, params.CreateEmployerParams.CompanyID, result.Employer.CompanyID)
+	require.Equal(t, CompanyRoleOwner, result.Employer.Role)
 	require.NotZero(t, result.Employer.ID)
 	require.NotZero(t, result.Employer.CreatedAt)
 }
@@ -0,0 +1,36 @@
+package db
+
+import "context"
+
+type CreateJobTxParams struct {
+	CreateJobParams
+	Skills []string
+}
+
+type CreateJobTxResult struct {
+	Job Job
+}
+
+// CreateJobTx creates the job with its skills and the search outbox entry
+// that adds the job to the elasticsearch index
+func (store *SQLStore) CreateJobTx(ctx context.Context, arg CreateJobTxParams) (CreateJobTxResult, error) {
+	var result CreateJobTxResult
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Job, err = q.CreateJob(ctx, arg.CreateJobParams)
+		if err != nil {
+			return err
+		}
+
+		err = createJobSkills(ctx, q, arg.Skills, result.Job.ID)
+		if err != nil {
+			return err
+		}
+
+		return q.CreateSearchOutboxEntry(ctx, result.Job.ID)
+	})
+
+	return result, err
+}
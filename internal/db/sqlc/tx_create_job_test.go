@@ -0,0 +1,45 @@
+package db
+
+import (
+	"context"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSQLStore_CreateJobTx(t *testing.T) {
+	company := createRandomCompany(t, "")
+	params := CreateJobTxParams{
+		CreateJobParams: CreateJobParams{
+			Title:          utils.RandomString(5),
+			Industry:       utils.RandomString(5),
+			CompanyID:      company.ID,
+			Description:    utils.RandomString(7),
+			Location:       utils.RandomString(4),
+			SalaryMin:      utils.RandomInt(100, 110),
+			SalaryMax:      utils.RandomInt(110, 120),
+			Requirements:   utils.RandomString(5),
+			Status:         JobStatusDraft,
+			EmploymentType: EmploymentTypeFullTime,
+			SeniorityLevel: SeniorityLevelMid,
+			RemotePolicy:   RemotePolicyOnsite,
+			SalaryCurrency: "EUR",
+			SalaryPeriod:   SalaryPeriodMonth,
+		},
+		Skills: []string{utils.RandomString(4), utils.RandomString(4)},
+	}
+
+	result, err := testStore.CreateJobTx(context.Background(), params)
+	require.NoError(t, err)
+	require.NotZero(t, result.Job.ID)
+	require.Equal(t, params.Title, result.Job.Title)
+	require.Equal(t, company.ID, result.Job.CompanyID)
+
+	skills, err := testQueries.ListAllJobSkillsByJobID(context.Background(), result.Job.ID)
+	require.NoError(t, err)
+	require.ElementsMatch(t, params.Skills, skills)
+
+	entries := listSearchOutboxEntriesOfJob(t, result.Job.ID)
+	require.Len(t, entries, 1)
+	require.False(t, entries[0].ProcessedAt.Valid)
+}
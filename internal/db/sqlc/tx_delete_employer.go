@@ -0,0 +1,31 @@
+package db
+
+import "context"
+
+type DeleteEmployerTxParams struct {
+	ID    int32
+	Email string
+	// CompanyID is deleted with the employer if DeleteCompany is set, when the employer is its last member
+	CompanyID     int32
+	DeleteCompany bool
+}
+
+// DeleteEmployerTx deletes the employer and revokes all of its tokens and sessions,
+// so the tokens issued before cannot be used by the deleted account
+func (store *SQLStore) DeleteEmployerTx(ctx context.Context, arg DeleteEmployerTxParams) error {
+	return store.ExecTx(ctx, func(q *Queries) error {
+		err := q.DeleteEmployer(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		if arg.DeleteCompany {
+			err = q.DeleteCompany(ctx, arg.CompanyID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return revokeAllTokens(ctx, q, arg.Email)
+	})
+}
@@ -0,0 +1,56 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestSQLStore_DeleteEmployerTx(t *testing.T) {
+	employer := createRandomEmployer(t, 0)
+	member := createRandomEmployer(t, employer.CompanyID)
+	session := createRandomSession(t, member.Email)
+	issuedAt := time.Now().Add(-time.Second)
+
+	store := NewStore(testDB)
+	err := store.DeleteEmployerTx(context.Background(), DeleteEmployerTxParams{
+		ID:        member.ID,
+		Email:     member.Email,
+		CompanyID: member.CompanyID,
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.GetEmployerByID(context.Background(), member.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	revoked, err := testQueries.IsTokenRevoked(context.Background(), IsTokenRevokedParams{
+		ID:       uuid.New(),
+		Email:    member.Email,
+		IssuedAt: issuedAt,
+	})
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	blockedSession, err := testQueries.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, blockedSession.IsBlocked)
+
+	// the company is kept for the other members
+	_, err = testQueries.GetCompanyByID(context.Background(), employer.CompanyID)
+	require.NoError(t, err)
+
+	// the last member is deleted with the company
+	err = store.DeleteEmployerTx(context.Background(), DeleteEmployerTxParams{
+		ID:            employer.ID,
+		Email:         employer.Email,
+		CompanyID:     employer.CompanyID,
+		DeleteCompany: true,
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.GetCompanyByID(context.Background(), employer.CompanyID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+)
+
+type EnableMfaTxParams struct {
+	AccountType         string
+	AccountID           int32
+	HashedRecoveryCodes []string
+}
+
+// EnableMfaTx enables MFA of the account and replaces
+// all of its recovery codes with the new ones
+func (store *SQLStore) EnableMfaTx(ctx context.Context, arg EnableMfaTxParams) (MfaSetting, error) {
+	var mfaSetting MfaSetting
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		var err error
+
+		mfaSetting, err = q.EnableMfaSetting(ctx, EnableMfaSettingParams{
+			AccountType: arg.AccountType,
+			AccountID:   arg.AccountID,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteMfaRecoveryCodes(ctx, DeleteMfaRecoveryCodesParams{
+			AccountType: arg.AccountType,
+			AccountID:   arg.AccountID,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, hashedCode := range arg.HashedRecoveryCodes {
+			_, err = q.CreateMfaRecoveryCode(ctx, CreateMfaRecoveryCodeParams{
+				AccountType: arg.AccountType,
+				AccountID:   arg.AccountID,
+				HashedCode:  hashedCode,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return mfaSetting, err
+}
+
+// DisableMfaTx deletes the MFA setting and all recovery codes of the account
+func (store *SQLStore) DisableMfaTx(ctx context.Context, accountType string, accountID int32) error {
+	return store.ExecTx(ctx, func(q *Queries) error {
+		err := q.DeleteMfaRecoveryCodes(ctx, DeleteMfaRecoveryCodesParams{
+			AccountType: accountType,
+			AccountID:   accountID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.DeleteMfaSetting(ctx, DeleteMfaSettingParams{
+			AccountType: accountType,
+			AccountID:   accountID,
+		})
+	})
+}
@@ -0,0 +1,73 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSQLStore_EnableMfaTx(t *testing.T) {
+	user := createRandomUser(t)
+	mfaSetting := createRandomMfaSetting(t, user.ID)
+
+	params := EnableMfaTxParams{
+		AccountType:         mfaSetting.AccountType,
+		AccountID:           mfaSetting.AccountID,
+		HashedRecoveryCodes: []string{utils.RandomString(60), utils.RandomString(60)},
+	}
+
+	store := NewStore(testDB)
+	enabledSetting, err := store.EnableMfaTx(context.Background(), params)
+	require.NoError(t, err)
+	require.True(t, enabledSetting.IsEnabled)
+
+	codes, err := testQueries.ListUnusedMfaRecoveryCodes(context.Background(), ListUnusedMfaRecoveryCodesParams{
+		AccountType: mfaSetting.AccountType,
+		AccountID:   mfaSetting.AccountID,
+	})
+	require.NoError(t, err)
+	require.Len(t, codes, 2)
+
+	// enabling again replaces the recovery codes
+	params.HashedRecoveryCodes = []string{utils.RandomString(60)}
+	_, err = store.EnableMfaTx(context.Background(), params)
+	require.NoError(t, err)
+
+	codes, err = testQueries.ListUnusedMfaRecoveryCodes(context.Background(), ListUnusedMfaRecoveryCodesParams{
+		AccountType: mfaSetting.AccountType,
+		AccountID:   mfaSetting.AccountID,
+	})
+	require.NoError(t, err)
+	require.Len(t, codes, 1)
+}
+
+func TestSQLStore_DisableMfaTx(t *testing.T) {
+	user := createRandomUser(t)
+	mfaSetting := createRandomMfaSetting(t, user.ID)
+
+	store := NewStore(testDB)
+	_, err := store.EnableMfaTx(context.Background(), EnableMfaTxParams{
+		AccountType:         mfaSetting.AccountType,
+		AccountID:           mfaSetting.AccountID,
+		HashedRecoveryCodes: []string{utils.RandomString(60)},
+	})
+	require.NoError(t, err)
+
+	err = store.DisableMfaTx(context.Background(), mfaSetting.AccountType, mfaSetting.AccountID)
+	require.NoError(t, err)
+
+	_, err = testQueries.GetMfaSetting(context.Background(), GetMfaSettingParams{
+		AccountType: mfaSetting.AccountType,
+		AccountID:   mfaSetting.AccountID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	codes, err := testQueries.ListUnusedMfaRecoveryCodes(context.Background(), ListUnusedMfaRecoveryCodesParams{
+		AccountType: mfaSetting.AccountType,
+		AccountID:   mfaSetting.AccountID,
+	})
+	require.NoError(t, err)
+	require.Empty(t, codes)
+}
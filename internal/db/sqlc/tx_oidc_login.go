@@ -0,0 +1,99 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+type OidcLoginTxParams struct {
+	Provider string
+	Subject  string
+	Email    string
+	FullName string
+	// HashedPassword is used when a new user is created or an unverified user is linked.
+	// These users can set their own password with the forgot password flow
+	HashedPassword string
+}
+
+type OidcLoginTxResult struct {
+	User     User
+	Identity UserIdentity
+	// Created is true if a new user was signed up
+	Created bool
+}
+
+// OidcLoginTx gets the user that the external identity (provider and subject) is linked to.
+// If the identity is not linked yet, it is linked to the user with the same email
+// or a new user is created. The email of the user is marked as verified.
+// Nobody proved owning the email of an unverified user, so when such a user is linked,
+// the password is replaced and all tokens and sessions are revoked - whoever registered
+// the email before cannot log in to the account anymore.
+func (store *SQLStore) OidcLoginTx(ctx context.Context, arg OidcLoginTxParams) (OidcLoginTxResult, error) {
+	var result OidcLoginTxResult
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Identity, err = q.GetUserIdentity(ctx, GetUserIdentityParams{
+			Provider: arg.Provider,
+			Subject:  arg.Subject,
+		})
+		if err == nil {
+			result.User, err = q.GetUserByID(ctx, result.Identity.UserID)
+			return err
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		result.User, err = q.GetUserByEmail(ctx, arg.Email)
+		if err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+
+			result.User, err = q.CreateUser(ctx, CreateUserParams{
+				FullName:       arg.FullName,
+				Email:          arg.Email,
+				HashedPassword: arg.HashedPassword,
+			})
+			if err != nil {
+				return err
+			}
+			result.Created = true
+		}
+
+		if !result.User.IsEmailVerified {
+			if !result.Created {
+				err = q.UpdatePassword(ctx, UpdatePasswordParams{
+					ID:             result.User.ID,
+					HashedPassword: arg.HashedPassword,
+				})
+				if err != nil {
+					return err
+				}
+
+				err = revokeAllTokens(ctx, q, result.User.Email)
+				if err != nil {
+					return err
+				}
+			}
+
+			result.User, err = q.VerifyUserEmail(ctx, result.User.Email)
+			if err != nil {
+				return err
+			}
+		}
+
+		result.Identity, err = q.CreateUserIdentity(ctx, CreateUserIdentityParams{
+			Provider: arg.Provider,
+			Subject:  arg.Subject,
+			UserID:   result.User.ID,
+			Email:    arg.Email,
+		})
+		return err
+	})
+
+	return result, err
+}
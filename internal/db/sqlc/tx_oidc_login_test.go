@@ -0,0 +1,75 @@
+package db
+
+import (
+	"context"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSQLStore_OidcLoginTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	// new user
+	params := OidcLoginTxParams{
+		Provider:       "test",
+		Subject:        utils.RandomString(16),
+		Email:          utils.RandomEmail(),
+		FullName:       utils.RandomString(6),
+		HashedPassword: utils.RandomString(6),
+	}
+	result, err := store.OidcLoginTx(context.Background(), params)
+	require.NoError(t, err)
+	require.True(t, result.Created)
+	require.Equal(t, params.Email, result.User.Email)
+	require.Equal(t, params.FullName, result.User.FullName)
+	require.True(t, result.User.IsEmailVerified)
+	require.Equal(t, result.User.ID, result.Identity.UserID)
+
+	// the identity is linked now
+	result2, err := store.OidcLoginTx(context.Background(), params)
+	require.NoError(t, err)
+	require.False(t, result2.Created)
+	require.Equal(t, result.User.ID, result2.User.ID)
+
+	// existing unverified user with the same email gets linked and verified,
+	// the password and the sessions of whoever registered the email stop working
+	user := createRandomUser(t)
+	require.False(t, user.IsEmailVerified)
+	session := createRandomSession(t, user.Email)
+
+	params3 := OidcLoginTxParams{
+		Provider:       "test",
+		Subject:        utils.RandomString(16),
+		Email:          user.Email,
+		FullName:       utils.RandomString(6),
+		HashedPassword: utils.RandomString(6),
+	}
+	result3, err := store.OidcLoginTx(context.Background(), params3)
+	require.NoError(t, err)
+	require.False(t, result3.Created)
+	require.Equal(t, user.ID, result3.User.ID)
+	require.Equal(t, user.FullName, result3.User.FullName)
+	require.Equal(t, params3.HashedPassword, result3.User.HashedPassword)
+	require.True(t, result3.User.IsEmailVerified)
+
+	session2, err := testQueries.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, session2.IsBlocked)
+
+	// existing verified user keeps the password
+	user2 := createRandomUser(t)
+	_, err = testQueries.VerifyUserEmail(context.Background(), user2.Email)
+	require.NoError(t, err)
+
+	result4, err := store.OidcLoginTx(context.Background(), OidcLoginTxParams{
+		Provider:       "test",
+		Subject:        utils.RandomString(16),
+		Email:          user2.Email,
+		FullName:       utils.RandomString(6),
+		HashedPassword: utils.RandomString(6),
+	})
+	require.NoError(t, err)
+	require.Equal(t, user2.ID, result4.User.ID)
+	require.Equal(t, user2.HashedPassword, result4.User.HashedPassword)
+}
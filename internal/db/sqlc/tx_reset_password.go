@@ -0,0 +1,96 @@
+package db
+
+import (
+	"context"
+	"github.com/aalug/job-finder-go/pkg/token"
+)
+
+type ResetPasswordTxParams struct {
+	ID             int64
+	SecretCode     string
+	HashedPassword string
+}
+
+type ResetUserPasswordResult struct {
+	User          User
+	ResetPassword ResetPassword
+}
+
+// ResetUserPasswordTx uses the reset password code of a user, sets the new password
+// of the user and revokes all of their tokens and sessions
+func (store *SQLStore) ResetUserPasswordTx(ctx context.Context, arg ResetPasswordTxParams) (ResetUserPasswordResult, error) {
+	var result ResetUserPasswordResult
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.ResetPassword, err = q.UpdateResetPassword(ctx, UpdateResetPasswordParams{
+			ID:          arg.ID,
+			SecretCode:  arg.SecretCode,
+			AccountType: string(token.AccountTypeUser),
+		})
+		if err != nil {
+			return err
+		}
+
+		result.User, err = q.GetUserByEmail(ctx, result.ResetPassword.Email)
+		if err != nil {
+			return err
+		}
+
+		err = q.UpdatePassword(ctx, UpdatePasswordParams{
+			ID:             result.User.ID,
+			HashedPassword: arg.HashedPassword,
+		})
+		if err != nil {
+			return err
+		}
+		result.User.HashedPassword = arg.HashedPassword
+
+		return revokeAllTokens(ctx, q, result.User.Email)
+	})
+
+	return result, err
+}
+
+type ResetEmployerPasswordResult struct {
+	Employer      Employer
+	ResetPassword ResetPassword
+}
+
+// ResetEmployerPasswordTx uses the reset password code of an employer, sets the new password
+// of the employer and revokes all of their tokens and sessions
+func (store *SQLStore) ResetEmployerPasswordTx(ctx context.Context, arg ResetPasswordTxParams) (ResetEmployerPasswordResult, error) {
+	var result ResetEmployerPasswordResult
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.ResetPassword, err = q.UpdateResetPassword(ctx, UpdateResetPasswordParams{
+			ID:          arg.ID,
+			SecretCode:  arg.SecretCode,
+			AccountType: string(token.AccountTypeEmployer),
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Employer, err = q.GetEmployerByEmail(ctx, result.ResetPassword.Email)
+		if err != nil {
+			return err
+		}
+
+		err = q.UpdateEmployerPassword(ctx, UpdateEmployerPasswordParams{
+			ID:             result.Employer.ID,
+			HashedPassword: arg.HashedPassword,
+		})
+		if err != nil {
+			return err
+		}
+		result.Employer.HashedPassword = arg.HashedPassword
+
+		return revokeAllTokens(ctx, q, result.Employer.Email)
+	})
+
+	return result, err
+}
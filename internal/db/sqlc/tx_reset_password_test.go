@@ -0,0 +1,75 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/aalug/job-finder-go/pkg/token"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestSQLStore_ResetUserPasswordTx(t *testing.T) {
+	user := createRandomUser(t)
+	resetPassword := createRandomResetPassword(t, user.Email, string(token.AccountTypeUser))
+	issuedAt := time.Now().Add(-time.Second)
+
+	hashedPassword, err := utils.HashPassword(utils.RandomString(6))
+	require.NoError(t, err)
+
+	store := NewStore(testDB)
+	result, err := store.ResetUserPasswordTx(context.Background(), ResetPasswordTxParams{
+		ID:             resetPassword.ID,
+		SecretCode:     resetPassword.SecretCode,
+		HashedPassword: hashedPassword,
+	})
+	require.NoError(t, err)
+	require.True(t, result.ResetPassword.IsUsed)
+	require.Equal(t, user.ID, result.User.ID)
+
+	updatedUser, err := testQueries.GetUserByID(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Equal(t, hashedPassword, updatedUser.HashedPassword)
+
+	revoked, err := testQueries.IsTokenRevoked(context.Background(), IsTokenRevokedParams{
+		ID:       uuid.New(),
+		Email:    user.Email,
+		IssuedAt: issuedAt,
+	})
+	require.NoError(t, err)
+	require.True(t, revoked)
+}
+
+func TestSQLStore_ResetEmployerPasswordTx(t *testing.T) {
+	employer := createRandomEmployer(t, 0)
+	resetPassword := createRandomResetPassword(t, employer.Email, string(token.AccountTypeEmployer))
+
+	hashedPassword, err := utils.HashPassword(utils.RandomString(6))
+	require.NoError(t, err)
+
+	store := NewStore(testDB)
+
+	// the code of a user with the same email cannot reset the password of the employer
+	userResetPassword := createRandomResetPassword(t, employer.Email, string(token.AccountTypeUser))
+	_, err = store.ResetEmployerPasswordTx(context.Background(), ResetPasswordTxParams{
+		ID:             userResetPassword.ID,
+		SecretCode:     userResetPassword.SecretCode,
+		HashedPassword: hashedPassword,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	result, err := store.ResetEmployerPasswordTx(context.Background(), ResetPasswordTxParams{
+		ID:             resetPassword.ID,
+		SecretCode:     resetPassword.SecretCode,
+		HashedPassword: hashedPassword,
+	})
+	require.NoError(t, err)
+	require.True(t, result.ResetPassword.IsUsed)
+	require.Equal(t, employer.ID, result.Employer.ID)
+
+	updatedEmployer, err := testQueries.GetEmployerByID(context.Background(), employer.ID)
+	require.NoError(t, err)
+	require.Equal(t, hashedPassword, updatedEmployer.HashedPassword)
+}
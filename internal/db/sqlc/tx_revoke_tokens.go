@@ -0,0 +1,24 @@
+package db
+
+import (
+	"context"
+)
+
+// RevokeAllTokensTx revokes every token issued so far for the email
+// and blocks all of its sessions, so refresh tokens stop working too
+func (store *SQLStore) RevokeAllTokensTx(ctx context.Context, email string) error {
+	return store.ExecTx(ctx, func(q *Queries) error {
+		return revokeAllTokens(ctx, q, email)
+	})
+}
+
+// revokeAllTokens runs the queries of RevokeAllTokensTx,
+// so they can be reused inside other transactions
+func revokeAllTokens(ctx context.Context, q *Queries, email string) error {
+	err := q.RevokeAllTokensByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	return q.BlockAllSessionsByEmail(ctx, email)
+}
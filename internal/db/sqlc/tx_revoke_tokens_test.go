@@ -0,0 +1,31 @@
+package db
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestSQLStore_RevokeAllTokensTx(t *testing.T) {
+	user := createRandomUser(t)
+	session := createRandomSession(t, user.Email)
+	issuedAt := time.Now().Add(-time.Second)
+
+	store := NewStore(testDB)
+	err := store.RevokeAllTokensTx(context.Background(), user.Email)
+	require.NoError(t, err)
+
+	revoked, err := testQueries.IsTokenRevoked(context.Background(), IsTokenRevokedParams{
+		ID:       uuid.New(),
+		Email:    user.Email,
+		IssuedAt: issuedAt,
+	})
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	blockedSession, err := testQueries.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, blockedSession.IsBlocked)
+}
@@ -0,0 +1,22 @@
+package db
+
+import "context"
+
+// UpdateCompanyTx updates the company and creates the search outbox entries of all its jobs,
+// the company name is a part of the documents in the elasticsearch index
+func (store *SQLStore) UpdateCompanyTx(ctx context.Context, arg UpdateCompanyParams) (Company, error) {
+	var company Company
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		var err error
+
+		company, err = q.UpdateCompany(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		return q.CreateSearchOutboxEntriesForCompany(ctx, company.ID)
+	})
+
+	return company, err
+}
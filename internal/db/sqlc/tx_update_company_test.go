@@ -0,0 +1,31 @@
+package db
+
+import (
+	"context"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSQLStore_UpdateCompanyTx(t *testing.T) {
+	company := createRandomCompany(t, "")
+	var jobs []Job
+	for i := 0; i < 2; i++ {
+		jobs = append(jobs, createRandomJob(t, &company, jobDetails{}))
+	}
+
+	params := UpdateCompanyParams{
+		ID:       company.ID,
+		Name:     utils.RandomString(6),
+		Industry: company.Industry,
+		Location: company.Location,
+	}
+	updatedCompany, err := testStore.UpdateCompanyTx(context.Background(), params)
+	require.NoError(t, err)
+	require.Equal(t, params.Name, updatedCompany.Name)
+
+	// the company name is a part of the documents of all its jobs
+	for _, job := range jobs {
+		require.Len(t, listSearchOutboxEntriesOfJob(t, job.ID), 1)
+	}
+}
@@ -0,0 +1,44 @@
+package db
+
+import "context"
+
+type UpdateJobTxParams struct {
+	UpdateJobParams
+	SkillIDsToRemove []int32
+	SkillsToAdd      []string
+}
+
+type UpdateJobTxResult struct {
+	Job Job
+}
+
+// UpdateJobTx updates the job and its skills and creates the search outbox entry
+// that updates the job in the elasticsearch index
+func (store *SQLStore) UpdateJobTx(ctx context.Context, arg UpdateJobTxParams) (UpdateJobTxResult, error) {
+	var result UpdateJobTxResult
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Job, err = q.UpdateJob(ctx, arg.UpdateJobParams)
+		if err != nil {
+			return err
+		}
+
+		if len(arg.SkillIDsToRemove) > 0 {
+			err = q.DeleteMultipleJobSkills(ctx, arg.SkillIDsToRemove)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = createJobSkills(ctx, q, arg.SkillsToAdd, result.Job.ID)
+		if err != nil {
+			return err
+		}
+
+		return q.CreateSearchOutboxEntry(ctx, result.Job.ID)
+	})
+
+	return result, err
+}
@@ -0,0 +1,22 @@
+package db
+
+import "context"
+
+// UpdateJobStatusTx updates the status of the job and creates the search outbox entry
+// that adds the job to the elasticsearch index or removes it from there
+func (store *SQLStore) UpdateJobStatusTx(ctx context.Context, arg UpdateJobStatusParams) (Job, error) {
+	var job Job
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		var err error
+
+		job, err = q.UpdateJobStatus(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		return q.CreateSearchOutboxEntry(ctx, job.ID)
+	})
+
+	return job, err
+}
@@ -0,0 +1,21 @@
+package db
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSQLStore_UpdateJobStatusTx(t *testing.T) {
+	job := createRandomJob(t, nil, jobDetails{})
+
+	updatedJob, err := testStore.UpdateJobStatusTx(context.Background(), UpdateJobStatusParams{
+		ID:     job.ID,
+		Status: JobStatusPaused,
+	})
+	require.NoError(t, err)
+	require.Equal(t, job.ID, updatedJob.ID)
+	require.Equal(t, JobStatusPaused, updatedJob.Status)
+
+	require.Len(t, listSearchOutboxEntriesOfJob(t, job.ID), 1)
+}
@@ -0,0 +1,47 @@
+package db
+
+import (
+	"context"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSQLStore_UpdateJobTx(t *testing.T) {
+	job := createRandomJob(t, nil, jobDetails{})
+	skill := createRandomJobSkill(t, &job, utils.RandomString(4))
+
+	params := UpdateJobTxParams{
+		UpdateJobParams: UpdateJobParams{
+			ID:              job.ID,
+			Title:           utils.RandomString(6),
+			Industry:        job.Industry,
+			CompanyID:       job.CompanyID,
+			Description:     job.Description,
+			Location:        job.Location,
+			SalaryMin:       job.SalaryMin,
+			SalaryMax:       job.SalaryMax,
+			Requirements:    job.Requirements,
+			EmploymentType:  job.EmploymentType,
+			SeniorityLevel:  job.SeniorityLevel,
+			RemotePolicy:    job.RemotePolicy,
+			SalaryCurrency:  job.SalaryCurrency,
+			SalaryPeriod:    job.SalaryPeriod,
+			SalaryMinAnnual: job.SalaryMinAnnual,
+			SalaryMaxAnnual: job.SalaryMaxAnnual,
+		},
+		SkillIDsToRemove: []int32{skill.ID},
+		SkillsToAdd:      []string{utils.RandomString(5)},
+	}
+
+	result, err := testStore.UpdateJobTx(context.Background(), params)
+	require.NoError(t, err)
+	require.Equal(t, job.ID, result.Job.ID)
+	require.Equal(t, params.Title, result.Job.Title)
+
+	skills, err := testQueries.ListAllJobSkillsByJobID(context.Background(), job.ID)
+	require.NoError(t, err)
+	require.Equal(t, params.SkillsToAdd, skills)
+
+	require.Len(t, listSearchOutboxEntriesOfJob(t, job.ID), 1)
+}
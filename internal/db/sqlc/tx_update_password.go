@@ -0,0 +1,41 @@
+package db
+
+import "context"
+
+type UpdatePasswordTxParams struct {
+	ID             int32
+	Email          string
+	HashedPassword string
+}
+
+// UpdateUserPasswordTx updates the password of the user and revokes every token
+// issued before, so the password and the tokens cannot get out of sync
+func (store *SQLStore) UpdateUserPasswordTx(ctx context.Context, arg UpdatePasswordTxParams) error {
+	return store.ExecTx(ctx, func(q *Queries) error {
+		err := q.UpdatePassword(ctx, UpdatePasswordParams{
+			ID:             arg.ID,
+			HashedPassword: arg.HashedPassword,
+		})
+		if err != nil {
+			return err
+		}
+
+		return revokeAllTokens(ctx, q, arg.Email)
+	})
+}
+
+// UpdateEmployerPasswordTx updates the password of the employer and revokes every token
+// issued before, so the password and the tokens cannot get out of sync
+func (store *SQLStore) UpdateEmployerPasswordTx(ctx context.Context, arg UpdatePasswordTxParams) error {
+	return store.ExecTx(ctx, func(q *Queries) error {
+		err := q.UpdateEmployerPassword(ctx, UpdateEmployerPasswordParams{
+			ID:             arg.ID,
+			HashedPassword: arg.HashedPassword,
+		})
+		if err != nil {
+			return err
+		}
+
+		return revokeAllTokens(ctx, q, arg.Email)
+	})
+}
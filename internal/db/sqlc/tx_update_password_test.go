@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestSQLStore_UpdateUserPasswordTx(t *testing.T) {
+	user := createRandomUser(t)
+	session := createRandomSession(t, user.Email)
+	issuedAt := time.Now().Add(-time.Second)
+
+	hashedPassword, err := utils.HashPassword(utils.RandomString(6))
+	require.NoError(t, err)
+
+	store := NewStore(testDB)
+	err = store.UpdateUserPasswordTx(context.Background(), UpdatePasswordTxParams{
+		ID:             user.ID,
+		Email:          user.Email,
+		HashedPassword: hashedPassword,
+	})
+	require.NoError(t, err)
+
+	updatedUser, err := testQueries.GetUserByID(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Equal(t, hashedPassword, updatedUser.HashedPassword)
+
+	revoked, err := testQueries.IsTokenRevoked(context.Background(), IsTokenRevokedParams{
+		ID:       uuid.New(),
+		Email:    user.Email,
+		IssuedAt: issuedAt,
+	})
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	blockedSession, err := testQueries.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, blockedSession.IsBlocked)
+}
+
+func TestSQLStore_UpdateEmployerPasswordTx(t *testing.T) {
+	employer := createRandomEmployer(t, 0)
+	issuedAt := time.Now().Add(-time.Second)
+
+	hashedPassword, err := utils.HashPassword(utils.RandomString(6))
+	require.NoError(t, err)
+
+	store := NewStore(testDB)
+	err = store.UpdateEmployerPasswordTx(context.Background(), UpdatePasswordTxParams{
+		ID:             employer.ID,
+		Email:          employer.Email,
+		HashedPassword: hashedPassword,
+	})
+	require.NoError(t, err)
+
+	updatedEmployer, err := testQueries.GetEmployerByID(context.Background(), employer.ID)
+	require.NoError(t, err)
+	require.Equal(t, hashedPassword, updatedEmployer.HashedPassword)
+
+	revoked, err := testQueries.IsTokenRevoked(context.Background(), IsTokenRevokedParams{
+		ID:       uuid.New(),
+		Email:    employer.Email,
+		IssuedAt: issuedAt,
+	})
+	require.NoError(t, err)
+	require.True(t, revoked)
+}
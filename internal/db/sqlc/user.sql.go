@@ -126,6 +126,39 @@ func (q *Queries) GetUserByID(ctx context.Context, id int32) (User, error) {
 	return i, err
 }
 
+const listUserLocations = `-- name: ListUserLocations :many
+SELECT id, location
+FROM users
+`
+
+type ListUserLocationsRow struct {
+	ID       int32  `json:"id"`
+	Location string `json:"location"`
+}
+
+func (q *Queries) ListUserLocations(ctx context.Context) ([]ListUserLocationsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listUserLocations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListUserLocationsRow{}
+	for rows.Next() {
+		var i ListUserLocationsRow
+		if err := rows.Scan(&i.ID, &i.Location); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updatePassword = `-- name: UpdatePassword :exec
 UPDATE users
 SET hashed_password = $2
@@ -202,6 +235,22 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 	return i, err
 }
 
+const updateUserLocation = `-- name: UpdateUserLocation :exec
+UPDATE users
+SET location = $2
+WHERE id = $1
+`
+
+type UpdateUserLocationParams struct {
+	ID       int32  `json:"id"`
+	Location string `json:"location"`
+}
+
+func (q *Queries) UpdateUserLocation(ctx context.Context, arg UpdateUserLocationParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserLocation, arg.ID, arg.Location)
+	return err
+}
+
 const verifyUserEmail = `-- name: VerifyUserEmail :one
 UPDATE users
 SET is_email_verified = TRUE
@@ -140,3 +140,35 @@ func TestQueries_VerifyUserEmail(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, user.IsEmailVerified)
 }
+
+func TestQueries_ListUserLocations(t *testing.T) {
+	user := createRandomUser(t)
+
+	users, err := testQueries.ListUserLocations(context.Background())
+	require.NoError(t, err)
+
+	var found bool
+	for _, u := range users {
+		if u.ID == user.ID {
+			found = true
+			require.Equal(t, user.Location, u.Location)
+		}
+	}
+	require.True(t, found)
+}
+
+func TestQueries_UpdateUserLocation(t *testing.T) {
+	user := createRandomUser(t)
+
+	params := UpdateUserLocationParams{
+		ID:       user.ID,
+		Location: "Warsaw, Poland",
+	}
+	err := testQueries.UpdateUserLocation(context.Background(), params)
+	require.NoError(t, err)
+
+	user2, err := testQueries.GetUserByID(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Equal(t, params.Location, user2.Location)
+	require.Equal(t, user.Email, user2.Email)
+}
@@ -46,6 +46,21 @@ func (q *Queries) DeleteVerifyEmail(ctx context.Context, email string) error {
 	return err
 }
 
+const deleteStaleVerifyEmails = `-- name: DeleteStaleVerifyEmails :execrows
+DELETE
+FROM verify_emails
+WHERE is_used = TRUE
+   OR expired_at <= now()
+`
+
+func (q *Queries) DeleteStaleVerifyEmails(ctx context.Context) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteStaleVerifyEmails)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateVerifyEmail = `-- name: UpdateVerifyEmail :one
 UPDATE verify_emails
 SET is_used = TRUE
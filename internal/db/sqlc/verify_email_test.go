@@ -64,3 +64,24 @@ func TestQueries_UpdateVerifyEmail(t *testing.T) {
 	require.Equal(t, updatedVerifyEmail.SecretCode, verifyEmail.SecretCode)
 	require.True(t, updatedVerifyEmail.IsUsed)
 }
+
+func TestQueries_DeleteStaleVerifyEmails(t *testing.T) {
+	usedVerifyEmail := createVerifyEmailForUser(t)
+	_, err := testQueries.UpdateVerifyEmail(context.Background(), UpdateVerifyEmailParams{
+		ID:         usedVerifyEmail.ID,
+		SecretCode: usedVerifyEmail.SecretCode,
+	})
+	require.NoError(t, err)
+	verifyEmail := createVerifyEmailForUser(t)
+
+	deleted, err := testQueries.DeleteStaleVerifyEmails(context.Background())
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, deleted, int64(1))
+
+	// the unused verify email is kept and can still be used
+	_, err = testQueries.UpdateVerifyEmail(context.Background(), UpdateVerifyEmailParams{
+		ID:         verifyEmail.ID,
+		SecretCode: verifyEmail.SecretCode,
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,347 @@
+package esearch
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The analysis of the in-memory backend, it mirrors the analyzers of the jobs index:
+// the standard tokenizer, the possessive stemmer, lowercasing, the english stop words,
+// the porter stemmer and the synonyms applied at search time.
+
+// token is a term of the analyzed text with its position and its offsets in the value
+type token struct {
+	term     string
+	position int
+	// value is the index of the value of a multi-valued field
+	value      int
+	start, end int
+}
+
+// positionIncrementGap is the gap between the positions of the values of a multi-valued field,
+// so that phrases do not match across the values
+const positionIncrementGap = 100
+
+// englishStopWords are the stop words of the _english_ stop filter
+var englishStopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true,
+	"but": true, "by": true, "for": true, "if": true, "in": true, "into": true, "is": true,
+	"it": true, "no": true, "not": true, "of": true, "on": true, "or": true, "such": true,
+	"that": true, "the": true, "their": true, "then": true, "there": true, "these": true,
+	"they": true, "this": true, "to": true, "was": true, "will": true, "with": true,
+}
+
+// analyzeValues analyzes the values of the field, the english analysis
+// removes the stop words and stems the terms, otherwise the terms are only lowercased
+func analyzeValues(values []string, english bool) []token {
+	var tokens []token
+	position := 0
+	for i, value := range values {
+		if i > 0 {
+			position += positionIncrementGap
+		}
+		valueTokens := tokenize(value)
+		for _, t := range valueTokens {
+			t.value = i
+			t.position += position
+			if english {
+				if englishStopWords[t.term] {
+					continue
+				}
+				t.term = stem(t.term)
+			}
+			tokens = append(tokens, t)
+		}
+		position += len(valueTokens)
+	}
+
+	return tokens
+}
+
+// tokenize splits the text into lowercased words like the standard tokenizer,
+// the possessive 's is removed. Letters joined with a dot or an apostrophe and digits
+// joined with a dot or a comma are kept together, e.g. node.js or 1,000.
+func tokenize(text string) []token {
+	var tokens []token
+	start := -1
+	emit := func(end int) {
+		word := strings.ToLower(text[start:end])
+		word = strings.TrimSuffix(strings.TrimSuffix(word, "'s"), "’s")
+		tokens = append(tokens, token{
+			term:     word,
+			position: len(tokens),
+			start:    start,
+			end:      end,
+		})
+		start = -1
+	}
+
+	for i, r := range text {
+		if isWordRune(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 && joinsWords(text, start, i, r) {
+			continue
+		}
+		if start >= 0 {
+			emit(i)
+		}
+	}
+	if start >= 0 {
+		emit(len(text))
+	}
+
+	return tokens
+}
+
+// isWordRune reports whether the rune is a part of a word
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r) || r == '_'
+}
+
+// joinsWords reports whether the punctuation r at the index i joins the word
+// that starts at start with the next one
+func joinsWords(text string, start, i int, r rune) bool {
+	previous, _ := utf8.DecodeLastRuneInString(text[start:i])
+	next, _ := utf8.DecodeRuneInString(text[i+utf8.RuneLen(r):])
+
+	switch r {
+	case '.':
+		return unicode.IsLetter(previous) && unicode.IsLetter(next) ||
+			unicode.IsDigit(previous) && unicode.IsDigit(next)
+	case '\'', '’', ':':
+		return unicode.IsLetter(previous) && unicode.IsLetter(next)
+	case ',':
+		return unicode.IsDigit(previous) && unicode.IsDigit(next)
+	}
+
+	return false
+}
+
+// synonymRule is a parsed rule in the Solr format, the inputs are replaced with the outputs.
+// The outputs of an equivalence rule like "golang, go" are its inputs.
+type synonymRule struct {
+	inputs  [][]string
+	outputs [][]string
+}
+
+// parseSynonyms parses the synonym rules, the terms are lowercased like the searched text
+func parseSynonyms(synonyms []string) ([]synonymRule, error) {
+	var rules []synonymRule
+	for _, synonym := range synonyms {
+		sides := strings.Split(synonym, "=>")
+		if len(sides) > 2 {
+			return nil, fmt.Errorf("invalid synonym rule %q", synonym)
+		}
+
+		inputs := parseSynonymTerms(sides[0])
+		outputs := inputs
+		if len(sides) == 2 {
+			outputs = parseSynonymTerms(sides[1])
+		}
+		if len(inputs) == 0 || len(outputs) == 0 {
+			return nil, fmt.Errorf("invalid synonym rule %q", synonym)
+		}
+
+		rules = append(rules, synonymRule{
+			inputs:  inputs,
+			outputs: outputs,
+		})
+	}
+
+	return rules, nil
+}
+
+// parseSynonymTerms parses the comma separated terms of a side of the synonym rule
+func parseSynonymTerms(side string) [][]string {
+	var terms [][]string
+	for _, term := range strings.Split(side, ",") {
+		var words []string
+		for _, t := range tokenize(term) {
+			words = append(words, t.term)
+		}
+		if len(words) > 0 {
+			terms = append(terms, words)
+		}
+	}
+
+	return terms
+}
+
+// queryClause is a position of the analyzed query with its alternatives, which are
+// the query word or its synonyms. An alternative with more than one term is a phrase,
+// empty terms are the positions of the removed stop words.
+type queryClause [][]string
+
+// analyzeQuery analyzes the query with the synonyms, the english analysis
+// removes the stop words and stems the terms, otherwise the terms are only lowercased
+func analyzeQuery(query string, english bool, rules []synonymRule) []queryClause {
+	var words []string
+	for _, t := range tokenize(query) {
+		words = append(words, t.term)
+	}
+
+	var clauses []queryClause
+	for i := 0; i < len(words); {
+		alternatives := [][]string{{words[i]}}
+		length := 1
+		if english {
+			if outputs, n := matchSynonyms(rules, words[i:]); n > 0 {
+				alternatives = outputs
+				length = n
+			}
+		}
+		i += length
+
+		var clause queryClause
+		for _, alternative := range alternatives {
+			if english {
+				alternative = stemPhrase(alternative)
+			}
+			if len(alternative) > 0 {
+				clause = append(clause, alternative)
+			}
+		}
+		if len(clause) > 0 {
+			clauses = append(clauses, clause)
+		}
+	}
+
+	return clauses
+}
+
+// matchSynonyms returns the outputs of the rules with the longest input at the start
+// of the words and the length of the input, the length is 0 if no rule matches
+func matchSynonyms(rules []synonymRule, words []string) ([][]string, int) {
+	var outputs [][]string
+	length := 0
+	for _, rule := range rules {
+		for _, input := range rule.inputs {
+			if len(input) < length || len(input) > len(words) || !equalTerms(input, words[:len(input)]) {
+				continue
+			}
+			if len(input) > length {
+				outputs = nil
+				length = len(input)
+			}
+			outputs = append(outputs, rule.outputs...)
+		}
+	}
+
+	return outputs, length
+}
+
+// stemPhrase removes the stop words of the phrase and stems its terms, the stop words
+// in the middle of the phrase are kept as empty terms to keep the positions
+func stemPhrase(words []string) []string {
+	var phrase []string
+	for _, word := range words {
+		if englishStopWords[word] {
+			word = ""
+		} else {
+			word = stem(word)
+		}
+		phrase = append(phrase, word)
+	}
+
+	for len(phrase) > 0 && phrase[0] == "" {
+		phrase = phrase[1:]
+	}
+	for len(phrase) > 0 && phrase[len(phrase)-1] == "" {
+		phrase = phrase[:len(phrase)-1]
+	}
+
+	return phrase
+}
+
+func equalTerms(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// fuzziness returns the maximum edit distance of the term with the AUTO fuzziness:
+// 0 for up to 2 characters, 1 for 3 to 5 characters and 2 for longer terms
+func fuzziness(term string) int {
+	switch n := utf8.RuneCountInString(term); {
+	case n < 3:
+		return 0
+	case n < 6:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// editDistance returns the Levenshtein distance of the strings where the transposition
+// of two adjacent characters is one edit, or max+1 if the distance is greater than max
+func editDistance(a, b string, max int) int {
+	s, t := []rune(a), []rune(b)
+	if abs(len(s)-len(t)) > max {
+		return max + 1
+	}
+
+	// rows of the distances of the prefixes of s and t, for i-2, i-1 and i
+	previous2 := make([]int, len(t)+1)
+	previous := make([]int, len(t)+1)
+	current := make([]int, len(t)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		current[0] = i
+		rowMin := current[0]
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				current[j] = minInt(current[j], previous2[j-2]+1)
+			}
+			rowMin = minInt(rowMin, current[j])
+		}
+		if rowMin > max {
+			return max + 1
+		}
+		previous2, previous, current = previous, current, previous2
+	}
+
+	if previous[len(t)] > max {
+		return max + 1
+	}
+	return previous[len(t)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+
+	return m
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
@@ -0,0 +1,178 @@
+package esearch
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tokens := tokenize("The developer's Node.js, C++ & 1,000 e-mail")
+
+	var terms []string
+	for _, token := range tokens {
+		terms = append(terms, token.term)
+	}
+	require.Equal(t, []string{"the", "developer", "node.js", "c", "1,000", "e", "mail"}, terms)
+
+	// the offsets point to the words in the text, the possessive is a part of the word
+	require.Equal(t, 4, tokens[1].start)
+	require.Equal(t, 15, tokens[1].end)
+	require.Equal(t, 1, tokens[1].position)
+}
+
+func TestAnalyzeValues(t *testing.T) {
+	tokens := analyzeValues([]string{"Running the tests", "Go"}, true)
+
+	var terms []string
+	var positions []int
+	for _, token := range tokens {
+		terms = append(terms, token.term)
+		positions = append(positions, token.position)
+	}
+	// stop words are removed, but they keep their positions
+	require.Equal(t, []string{"run", "test", "go"}, terms)
+	require.Equal(t, []int{0, 2, 3 + positionIncrementGap}, positions)
+	require.Equal(t, 1, tokens[2].value)
+
+	tokens = analyzeValues([]string{"Running the tests"}, false)
+	require.Len(t, tokens, 3)
+	require.Equal(t, "running", tokens[0].term)
+}
+
+func TestStem(t *testing.T) {
+	testCases := map[string]string{
+		"caresses":       "caress",
+		"ponies":         "poni",
+		"cats":           "cat",
+		"agreed":         "agre",
+		"plastered":      "plaster",
+		"motoring":       "motor",
+		"hopping":        "hop",
+		"filing":         "file",
+		"happy":          "happi",
+		"relational":     "relat",
+		"conditional":    "condit",
+		"digitizer":      "digit",
+		"hopefulness":    "hope",
+		"electrical":     "electr",
+		"adjustment":     "adjust",
+		"adoption":       "adopt",
+		"controlling":    "control",
+		"developers":     "develop",
+		"development":    "develop",
+		"engineering":    "engin",
+		"engineer":       "engin",
+		"management":     "manag",
+		"organization":   "organ",
+		"responsibility": "respons",
+		"go":             "go",
+		"sql":            "sql",
+	}
+
+	for word, stemmed := range testCases {
+		require.Equal(t, stemmed, stem(word), word)
+	}
+}
+
+func TestParseSynonyms(t *testing.T) {
+	rules, err := parseSynonyms([]string{"golang, go", "k8s => kubernetes", "Machine Learning, ML"})
+	require.NoError(t, err)
+	require.Equal(t, []synonymRule{
+		{
+			inputs:  [][]string{{"golang"}, {"go"}},
+			outputs: [][]string{{"golang"}, {"go"}},
+		},
+		{
+			inputs:  [][]string{{"k8s"}},
+			outputs: [][]string{{"kubernetes"}},
+		},
+		{
+			inputs:  [][]string{{"machine", "learning"}, {"ml"}},
+			outputs: [][]string{{"machine", "learning"}, {"ml"}},
+		},
+	}, rules)
+
+	for _, synonym := range []string{"k8s =>", "=> kubernetes", "a => b => c", ", ,"} {
+		_, err = parseSynonyms([]string{synonym})
+		require.Error(t, err, synonym)
+	}
+}
+
+func TestAnalyzeQuery(t *testing.T) {
+	rules, err := parseSynonyms([]string{"golang, go", "k8s => kubernetes", "machine learning, ml", "head of engineering, vp engineering"})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		query   string
+		english bool
+		clauses []queryClause
+	}{
+		{
+			query:   "Golang developers",
+			english: true,
+			clauses: []queryClause{{{"golang"}, {"go"}}, {{"develop"}}},
+		},
+		{
+			query:   "k8s",
+			english: true,
+			clauses: []queryClause{{{"kubernet"}}},
+		},
+		{
+			query:   "machine learning",
+			english: true,
+			clauses: []queryClause{{{"machin", "learn"}, {"ml"}}},
+		},
+		{
+			query:   "ml in the cloud",
+			english: true,
+			clauses: []queryClause{{{"machin", "learn"}, {"ml"}}, {{"cloud"}}},
+		},
+		{
+			query:   "head of engineering",
+			english: true,
+			clauses: []queryClause{{{"head", "", "engin"}, {"vp", "engin"}}},
+		},
+		{
+			// the synonyms and the english analysis are not applied to the other fields
+			query:   "Golang developers",
+			english: false,
+			clauses: []queryClause{{{"golang"}}, {{"developers"}}},
+		},
+		{
+			query:   "the",
+			english: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.clauses, analyzeQuery(tc.query, tc.english, rules), tc.query)
+	}
+}
+
+func TestFuzziness(t *testing.T) {
+	require.Equal(t, 0, fuzziness("go"))
+	require.Equal(t, 1, fuzziness("java"))
+	require.Equal(t, 1, fuzziness("swift"))
+	require.Equal(t, 2, fuzziness("python"))
+}
+
+func TestEditDistance(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		max      int
+		distance int
+	}{
+		{"python", "python", 2, 0},
+		{"python", "pyhton", 2, 1},
+		{"python", "pythn", 2, 1},
+		{"python", "pythons", 2, 1},
+		{"python", "pithen", 2, 2},
+		{"python", "java", 2, 3},
+		{"kotlin", "kotl", 1, 2},
+		{"zürich", "zurich", 2, 1},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.distance, editDistance(tc.a, tc.b, tc.max), "%q %q", tc.a, tc.b)
+	}
+}
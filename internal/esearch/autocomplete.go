@@ -0,0 +1,162 @@
+package esearch
+
+import (
+	"context"
+	"github.com/elastic/go-elasticsearch/v8/esutil"
+	"strings"
+)
+
+// types of the suggestions
+const (
+	SuggestionTypeTitle   = "title"
+	SuggestionTypeSkill   = "skill"
+	SuggestionTypeCompany = "company"
+)
+
+// suggestionFields are the fields of the suggestions mapped by their types,
+// in the order of the suggestions in the response
+var suggestionFields = []struct {
+	suggestionType string
+	field          string
+}{
+	{SuggestionTypeTitle, "title"},
+	{SuggestionTypeSkill, "job_skills"},
+	{SuggestionTypeCompany, "company_name"},
+}
+
+// suggestionCandidates is the number of the values of every type aggregated
+// before the values that do not match the prefix are dropped
+const suggestionCandidates = 50
+
+// Suggestion is a value of the job field that matches the typed prefix,
+// with the number of the jobs with this value
+type Suggestion struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+type autocompleteResponse struct {
+	Aggregations map[string]struct {
+		Values termsAggregation `json:"values"`
+	} `json:"aggregations"`
+}
+
+// Autocomplete suggests job titles, skills and company names that match the prefix
+// as it is typed. It returns up to size suggestions of every type, ordered by type
+// and the number of the jobs.
+func (client ESClient) Autocomplete(ctx context.Context, prefix string, size int) ([]Suggestion, error) {
+	suggestions := []Suggestion{}
+
+	should := []interface{}{}
+	aggregations := map[string]interface{}{}
+	for _, suggestionField := range suggestionFields {
+		match := prefixMatch(prefix, suggestionField.field)
+		should = append(should, match)
+		aggregations[suggestionField.suggestionType] = map[string]interface{}{
+			"filter": match,
+			"aggs": map[string]interface{}{
+				"values": map[string]interface{}{
+					"terms": map[string]interface{}{
+						"field": suggestionField.field + ".keyword",
+						"size":  suggestionCandidates,
+					},
+				},
+			},
+		}
+	}
+
+	query := map[string]interface{}{
+		"size": 0,
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"should":               should,
+				"minimum_should_match": 1,
+			},
+		},
+		"aggs": aggregations,
+	}
+
+	response, err := client.client.Search(
+		client.client.Search.WithContext(ctx),
+		client.client.Search.WithIndex("jobs"),
+		client.client.Search.WithBody(esutil.NewJSONReader(query)),
+	)
+	if err != nil {
+		return suggestions, err
+	}
+
+	var autocomplete autocompleteResponse
+	err = decodeResponse(response, &autocomplete)
+	if err != nil {
+		return suggestions, err
+	}
+
+	for _, suggestionField := range suggestionFields {
+		buckets := autocomplete.Aggregations[suggestionField.suggestionType].Values.facetBuckets()
+		suggestions = appendSuggestions(suggestions, suggestionField.suggestionType, buckets, prefix, size)
+	}
+
+	return suggestions, nil
+}
+
+// appendSuggestions appends up to size values of the buckets that match the prefix
+// to the suggestions as the suggestions of the type
+func appendSuggestions(suggestions []Suggestion, suggestionType string, buckets []FacetBucket, prefix string, size int) []Suggestion {
+	n := 0
+	for _, bucket := range buckets {
+		if n == size {
+			break
+		}
+		// skills of the matching jobs that do not match the prefix are aggregated as well
+		if !matchesPrefix(bucket.Value, prefix) {
+			continue
+		}
+		suggestions = append(suggestions, Suggestion{
+			Type:  suggestionType,
+			Value: bucket.Value,
+			Count: bucket.Count,
+		})
+		n++
+	}
+
+	return suggestions
+}
+
+// prefixMatch returns the query that matches the search_as_you_type sub-field
+// of the field with the prefix, the last word of the prefix can be incomplete
+func prefixMatch(prefix, field string) map[string]interface{} {
+	suggestField := field + ".suggest"
+	return map[string]interface{}{
+		"multi_match": map[string]interface{}{
+			"query": prefix,
+			"type":  "bool_prefix",
+			"fields": []string{
+				suggestField,
+				suggestField + "._2gram",
+				suggestField + "._3gram",
+			},
+			"operator": "and",
+		},
+	}
+}
+
+// matchesPrefix reports whether every word of the prefix is a prefix of a word of the value,
+// case-insensitively, e.g. "Senior Go Developer" matches "go dev"
+func matchesPrefix(value, prefix string) bool {
+	words := strings.Fields(strings.ToLower(value))
+	for _, prefixWord := range strings.Fields(strings.ToLower(prefix)) {
+		found := false
+		for _, word := range words {
+			if strings.HasPrefix(word, prefixWord) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,33 @@
+package esearch
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestMatchesPrefix(t *testing.T) {
+	testCases := []struct {
+		value   string
+		prefix  string
+		matches bool
+	}{
+		{"Senior Go Developer", "go", true},
+		{"Senior Go Developer", "go dev", true},
+		{"Senior Go Developer", "DEV sen", true},
+		{"Senior Go Developer", "golang", false},
+		{"Senior Go Developer", "go java", false},
+		{"PostgreSQL", "sql", false},
+		{"PostgreSQL", "post", true},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.matches, matchesPrefix(tc.value, tc.prefix), "%q %q", tc.value, tc.prefix)
+	}
+}
+
+func TestPrefixMatch(t *testing.T) {
+	match := prefixMatch("go dev", "title")["multi_match"].(map[string]interface{})
+	require.Equal(t, "go dev", match["query"])
+	require.Equal(t, "bool_prefix", match["type"])
+	require.Equal(t, []string{"title.suggest", "title.suggest._2gram", "title.suggest._3gram"}, match["fields"])
+}
@@ -0,0 +1,302 @@
+package esearch
+
+import (
+	"context"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/stretchr/testify/require"
+	"strconv"
+	"testing"
+)
+
+// searchBackend is a search backend the shared test suite runs against,
+// refresh makes the indexed documents searchable
+type searchBackend struct {
+	client  ESearchClient
+	refresh func(t *testing.T)
+}
+
+func TestElasticsearchBackend(t *testing.T) {
+	c, err := elasticsearch.NewDefaultClient()
+	require.NoError(t, err)
+
+	testSearchBackend(t, searchBackend{
+		client: ESClient{client: c},
+		refresh: func(t *testing.T) {
+			response, err := c.Indices.Refresh(c.Indices.Refresh.WithIndex("jobs"))
+			require.NoError(t, err)
+			response.Body.Close()
+		},
+	})
+}
+
+func TestMemoryBackend(t *testing.T) {
+	testSearchBackend(t, searchBackend{
+		client:  NewMemoryClient(),
+		refresh: func(t *testing.T) {},
+	})
+}
+
+// randomWord returns a random word that is not changed by the stemmer,
+// so it is searched the same way by every backend
+func randomWord() string {
+	return utils.RandomString(7) + "x"
+}
+
+// misspell returns the word with its third letter changed
+func misspell(word string) string {
+	letter := byte('a')
+	if word[2] == letter {
+		letter = 'b'
+	}
+
+	return word[:2] + string(letter) + word[3:]
+}
+
+// testSearchBackend tests the search of the backend. The jobs are indexed with a random company,
+// which is used as a filter of every search, so the suite can run against an index with other jobs.
+func testSearchBackend(t *testing.T, backend searchBackend) {
+	ctx := context.Background()
+	client := backend.client
+	company := randomWord()
+	word := randomWord()
+	skill := randomWord()
+	companyFilter := JobFilters{CompanyName: company}
+
+	firstID := utils.RandomInt(1000000, 2000000)
+	jobs := []Job{
+		{
+			Title:           "Senior " + word + " Developer",
+			Industry:        "IT",
+			Description:     "We build " + word + " services.",
+			Location:        "Berlin, Germany",
+			JobSkills:       []string{skill, "Docker"},
+			EmploymentType:  "Full-time",
+			SalaryMinAnnual: 50000,
+			SalaryMaxAnnual: 70000,
+			LocationPoint:   &GeoPoint{Lat: 52.52, Lon: 13.405},
+		},
+		{
+			Title:           "Backend Engineer",
+			Industry:        "Finance",
+			Description:     "Experience with " + word + " and the distributed payment systems is welcome.",
+			Location:        "Warsaw, Poland",
+			JobSkills:       []string{"Docker"},
+			EmploymentType:  "Contract",
+			SalaryMinAnnual: 100000,
+			SalaryMaxAnnual: 120000,
+			LocationPoint:   &GeoPoint{Lat: 52.2297, Lon: 21.0122},
+		},
+		{
+			Title:           "Data Analyst",
+			Industry:        "IT",
+			Description:     "Reports and dashboards.",
+			Location:        "Paris, France",
+			JobSkills:       []string{skill},
+			EmploymentType:  "Full-time",
+			SalaryMinAnnual: 130000,
+			SalaryMaxAnnual: 140000,
+			LocationPoint:   &GeoPoint{Lat: 48.8566, Lon: 2.3522},
+		},
+	}
+	for i := range jobs {
+		jobs[i].ID = firstID + int32(i)
+		jobs[i].CompanyName = company
+		err := client.IndexJobAsDocument(int(jobs[i].ID), jobs[i])
+		require.NoError(t, err)
+	}
+	t.Cleanup(func() {
+		for _, job := range jobs {
+			err := client.DeleteJobDocument(strconv.Itoa(int(job.ID)))
+			require.NoError(t, err)
+		}
+	})
+	backend.refresh(t)
+
+	search := func(t *testing.T, query string, filters JobFilters) SearchJobsResult {
+		result, err := client.SearchJobs(ctx, query, filters, 1, 10, false)
+		require.NoError(t, err)
+		return result
+	}
+	jobIDs := func(result SearchJobsResult) []int32 {
+		var ids []int32
+		for _, job := range result.Jobs {
+			ids = append(ids, job.ID)
+		}
+		return ids
+	}
+
+	t.Run("Title Ranks Higher", func(t *testing.T) {
+		result := search(t, word, companyFilter)
+		require.Equal(t, int64(2), result.Total)
+		require.Equal(t, []int32{jobs[0].ID, jobs[1].ID}, jobIDs(result))
+		require.Greater(t, result.Jobs[0].Score, result.Jobs[1].Score)
+
+		require.Equal(t, []string{"Senior <em>" + word + "</em> Developer"}, result.Jobs[0].Highlights["title"])
+		require.Equal(t, []string{"We build <em>" + word + "</em> services."}, result.Jobs[0].Highlights["description"])
+		require.NotContains(t, result.Jobs[1].Highlights, "title")
+		require.Len(t, result.Jobs[1].Highlights["description"], 1)
+		require.Empty(t, result.Jobs[0].Explanation)
+	})
+
+	t.Run("Fuzzy", func(t *testing.T) {
+		result := search(t, misspell(word), companyFilter)
+		require.Equal(t, []int32{jobs[0].ID, jobs[1].ID}, jobIDs(result))
+	})
+
+	t.Run("Skills", func(t *testing.T) {
+		result := search(t, skill, companyFilter)
+		require.ElementsMatch(t, []int32{jobs[0].ID, jobs[2].ID}, jobIDs(result))
+	})
+
+	t.Run("No Match", func(t *testing.T) {
+		result := search(t, randomWord(), companyFilter)
+		require.Zero(t, result.Total)
+		require.Empty(t, result.Jobs)
+		require.Zero(t, result.TotalPages)
+	})
+
+	t.Run("Filters", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			filters JobFilters
+			jobIDs  []int32
+		}{
+			{
+				name:    "Skills",
+				filters: JobFilters{CompanyName: company, Skills: []string{skill, "Docker"}},
+				jobIDs:  []int32{jobs[0].ID},
+			},
+			{
+				name:    "Employment Type",
+				filters: JobFilters{CompanyName: company, EmploymentType: "Contract"},
+				jobIDs:  []int32{jobs[1].ID},
+			},
+			{
+				name:    "Salary Range",
+				filters: JobFilters{CompanyName: company, SalaryMin: 60000, SalaryMax: 130000},
+				jobIDs:  []int32{jobs[1].ID},
+			},
+			{
+				name:    "Near",
+				filters: JobFilters{CompanyName: company, Near: &GeoPoint{Lat: 52.4, Lon: 13.1}, RadiusKm: 50},
+				jobIDs:  []int32{jobs[0].ID},
+			},
+			{
+				name:    "Keyword Is Exact",
+				filters: JobFilters{CompanyName: company, Location: "berlin, germany"},
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				result := search(t, word+" "+skill, tc.filters)
+				require.Equal(t, tc.jobIDs, jobIDs(result))
+			})
+		}
+	})
+
+	t.Run("Facets", func(t *testing.T) {
+		result := search(t, skill, companyFilter)
+
+		require.Equal(t, []FacetBucket{{Value: skill, Count: 2}, {Value: "Docker", Count: 1}}, result.Facets.Skills)
+		require.Equal(t, []FacetBucket{{Value: "IT", Count: 2}}, result.Facets.Industries)
+		require.Equal(t, []FacetBucket{{Value: company, Count: 2}}, result.Facets.Companies)
+		require.Equal(t, []FacetBucket{{Value: "Berlin, Germany", Count: 1}, {Value: "Paris, France", Count: 1}}, result.Facets.Locations)
+
+		counts := map[int32]int64{}
+		require.Len(t, result.Facets.Salaries, len(salaryRanges))
+		for _, bucket := range result.Facets.Salaries {
+			if bucket.From != nil {
+				counts[*bucket.From] = bucket.Count
+			}
+		}
+		require.Equal(t, int64(1), counts[30000])
+		require.Equal(t, int64(1), counts[120000])
+		require.Equal(t, int64(0), counts[90000])
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		firstPage, err := client.SearchJobs(ctx, word+" "+skill, companyFilter, 1, 2, false)
+		require.NoError(t, err)
+		secondPage, err := client.SearchJobs(ctx, word+" "+skill, companyFilter, 2, 2, false)
+		require.NoError(t, err)
+
+		require.Equal(t, int64(3), secondPage.Total)
+		require.Equal(t, int32(2), secondPage.TotalPages)
+		require.Len(t, firstPage.Jobs, 2)
+		require.Len(t, secondPage.Jobs, 1)
+		require.ElementsMatch(t, []int32{jobs[0].ID, jobs[1].ID, jobs[2].ID}, append(jobIDs(firstPage), jobIDs(secondPage)...))
+	})
+
+	t.Run("Explain", func(t *testing.T) {
+		result, err := client.SearchJobs(ctx, word, companyFilter, 1, 10, true)
+		require.NoError(t, err)
+		require.NotEmpty(t, result.Jobs)
+		for _, job := range result.Jobs {
+			require.NotEmpty(t, job.Explanation)
+		}
+	})
+
+	t.Run("Autocomplete", func(t *testing.T) {
+		suggestions, err := client.Autocomplete(ctx, word[:6], 5)
+		require.NoError(t, err)
+		require.Equal(t, []Suggestion{{Type: SuggestionTypeTitle, Value: jobs[0].Title, Count: 1}}, suggestions)
+
+		suggestions, err = client.Autocomplete(ctx, skill[:6], 5)
+		require.NoError(t, err)
+		require.Equal(t, []Suggestion{{Type: SuggestionTypeSkill, Value: skill, Count: 2}}, suggestions)
+	})
+
+	t.Run("Similar", func(t *testing.T) {
+		// other jobs of the index can be similar as well, only the order of these jobs is checked
+		hits, err := client.SimilarJobs(ctx, jobs[0].ID, 100)
+		require.NoError(t, err)
+
+		var similarIDs []int32
+		for _, hit := range hits {
+			similarIDs = append(similarIDs, hit.ID)
+		}
+		require.Contains(t, similarIDs, jobs[1].ID)
+		require.NotContains(t, similarIDs, jobs[0].ID)
+		// only the skill is shared, less than 30% of the terms of the job
+		require.NotContains(t, similarIDs, jobs[2].ID)
+
+		hits, err = client.SimilarJobs(ctx, firstID-1, 10)
+		require.NoError(t, err)
+		require.Empty(t, hits)
+	})
+
+	t.Run("List Documents", func(t *testing.T) {
+		documents, err := client.ListDocumentJobIDs(ctx)
+		require.NoError(t, err)
+		for _, job := range jobs {
+			require.Equal(t, job.ID, documents[strconv.Itoa(int(job.ID))])
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		updatedJob := jobs[0]
+		updatedJob.Title = "Senior " + randomWord() + " Developer"
+		updatedJob.Description = "We build services."
+		err := client.UpdateJobDocument(strconv.Itoa(int(updatedJob.ID)), updatedJob)
+		require.NoError(t, err)
+		backend.refresh(t)
+
+		require.Equal(t, updatedJob.Title, client.QueryJobsByDocumentID(int(updatedJob.ID)).Title)
+		require.Equal(t, []int32{jobs[1].ID}, jobIDs(search(t, word, companyFilter)))
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		err := client.DeleteJobDocument(strconv.Itoa(int(jobs[2].ID)))
+		require.NoError(t, err)
+		backend.refresh(t)
+
+		require.Nil(t, client.QueryJobsByDocumentID(int(jobs[2].ID)))
+		require.Equal(t, []int32{jobs[0].ID}, jobIDs(search(t, skill, companyFilter)))
+
+		// deleting the document again is not an error
+		err = client.DeleteJobDocument(strconv.Itoa(int(jobs[2].ID)))
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,167 @@
+package esearch
+
+import "sort"
+
+const (
+	// facetSize is the max number of the values of a facet
+	facetSize = 10
+	// skillsFacetSize is the max number of the skills in the facet
+	skillsFacetSize = 20
+)
+
+// salaryRanges are the ranges of the salary facet, annual amounts in the base currency
+var salaryRanges = []map[string]interface{}{
+	{"to": 30000},
+	{"from": 30000, "to": 60000},
+	{"from": 60000, "to": 90000},
+	{"from": 90000, "to": 120000},
+	{"from": 120000, "to": 150000},
+	{"from": 150000},
+}
+
+// facetAggregations returns the aggregations of the facets of the search
+func facetAggregations() map[string]interface{} {
+	termsFacet := func(field string, size int) map[string]interface{} {
+		return map[string]interface{}{
+			"terms": map[string]interface{}{
+				"field": field + ".keyword",
+				"size":  size,
+			},
+		}
+	}
+
+	return map[string]interface{}{
+		"industries": termsFacet("industry", facetSize),
+		"locations":  termsFacet("location", facetSize),
+		"companies":  termsFacet("company_name", facetSize),
+		"skills":     termsFacet("job_skills", skillsFacetSize),
+		"salaries": map[string]interface{}{
+			"range": map[string]interface{}{
+				"field":  "salary_min_annual",
+				"ranges": salaryRanges,
+			},
+		},
+	}
+}
+
+// facets returns the facets from the aggregations of the search response
+func (response SearchResponse) facets() Facets {
+	facets := Facets{
+		Industries: response.Aggregations.Industries.facetBuckets(),
+		Locations:  response.Aggregations.Locations.facetBuckets(),
+		Companies:  response.Aggregations.Companies.facetBuckets(),
+		Skills:     response.Aggregations.Skills.facetBuckets(),
+		Salaries:   []SalaryBucket{},
+	}
+
+	for _, bucket := range response.Aggregations.Salaries.Buckets {
+		salaryBucket := SalaryBucket{
+			Count: bucket.DocCount,
+		}
+		if bucket.From != nil {
+			from := int32(*bucket.From)
+			salaryBucket.From = &from
+		}
+		if bucket.To != nil {
+			to := int32(*bucket.To)
+			salaryBucket.To = &to
+		}
+		facets.Salaries = append(facets.Salaries, salaryBucket)
+	}
+
+	return facets
+}
+
+// facetBuckets converts the buckets of the terms aggregation to the facet buckets
+func (aggregation termsAggregation) facetBuckets() []FacetBucket {
+	buckets := []FacetBucket{}
+	for _, bucket := range aggregation.Buckets {
+		buckets = append(buckets, FacetBucket{
+			Value: bucket.Key,
+			Count: bucket.DocCount,
+		})
+	}
+
+	return buckets
+}
+
+// FacetCounts are the numbers of the matching jobs by the values of the facets,
+// they are turned into the facets of the searches that are not run by elasticsearch
+type FacetCounts struct {
+	Industries map[string]int64
+	Locations  map[string]int64
+	Companies  map[string]int64
+	Skills     map[string]int64
+	// Salaries are the numbers of the jobs in the salary ranges, see SalaryFacetBounds
+	Salaries []int64
+}
+
+// SalaryFacetBounds returns the bounds between the ranges of the salary facet in ascending order,
+// the min annual salary of a job in the range i is lower than the bound i and not lower than the bound i-1
+func SalaryFacetBounds() []int32 {
+	var bounds []int32
+	for _, salaryRange := range salaryRanges[1:] {
+		bounds = append(bounds, *salaryRangeBound(salaryRange, "from"))
+	}
+
+	return bounds
+}
+
+// Facets returns the facets with the most common values of the counts
+func (counts FacetCounts) Facets() Facets {
+	facets := Facets{
+		Industries: topBuckets(counts.Industries, facetSize),
+		Locations:  topBuckets(counts.Locations, facetSize),
+		Companies:  topBuckets(counts.Companies, facetSize),
+		Skills:     topBuckets(counts.Skills, skillsFacetSize),
+		Salaries:   []SalaryBucket{},
+	}
+
+	for i, salaryRange := range salaryRanges {
+		bucket := SalaryBucket{
+			From: salaryRangeBound(salaryRange, "from"),
+			To:   salaryRangeBound(salaryRange, "to"),
+		}
+		if i < len(counts.Salaries) {
+			bucket.Count = counts.Salaries[i]
+		}
+		facets.Salaries = append(facets.Salaries, bucket)
+	}
+
+	return facets
+}
+
+// salaryRangeBound returns the bound of the salary range, nil if it is unbounded
+func salaryRangeBound(salaryRange map[string]interface{}, key string) *int32 {
+	value, ok := salaryRange[key].(int)
+	if !ok {
+		return nil
+	}
+
+	bound := int32(value)
+	return &bound
+}
+
+// topBuckets returns up to size buckets of the counts ordered by the count
+// and then by the value
+func topBuckets(counts map[string]int64, size int) []FacetBucket {
+	buckets := []FacetBucket{}
+	for value, count := range counts {
+		buckets = append(buckets, FacetBucket{
+			Value: value,
+			Count: count,
+		})
+	}
+
+	sort.Slice(buckets, func(i, j int) bool {
+		if buckets[i].Count != buckets[j].Count {
+			return buckets[i].Count > buckets[j].Count
+		}
+		return buckets[i].Value < buckets[j].Value
+	})
+	if len(buckets) > size {
+		buckets = buckets[:size]
+	}
+
+	return buckets
+}
@@ -0,0 +1,73 @@
+package esearch
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSearchResponseFacets(t *testing.T) {
+	data := []byte(`{
+		"hits": {"total": {"value": 3}, "hits": []},
+		"aggregations": {
+			"industries": {"buckets": [{"key": "IT", "doc_count": 2}, {"key": "Finance", "doc_count": 1}]},
+			"locations": {"buckets": [{"key": "Berlin, Germany", "doc_count": 3}]},
+			"companies": {"buckets": []},
+			"skills": {"buckets": [{"key": "Go", "doc_count": 2}]},
+			"salaries": {"buckets": [
+				{"key": "*-30000.0", "to": 30000.0, "doc_count": 1},
+				{"key": "30000.0-60000.0", "from": 30000.0, "to": 60000.0, "doc_count": 0},
+				{"key": "150000.0-*", "from": 150000.0, "doc_count": 2}
+			]}
+		}
+	}`)
+
+	var response SearchResponse
+	err := json.Unmarshal(data, &response)
+	require.NoError(t, err)
+
+	from30k, to30k, to60k, from150k := int32(30000), int32(30000), int32(60000), int32(150000)
+	require.Equal(t, Facets{
+		Industries: []FacetBucket{{Value: "IT", Count: 2}, {Value: "Finance", Count: 1}},
+		Locations:  []FacetBucket{{Value: "Berlin, Germany", Count: 3}},
+		Companies:  []FacetBucket{},
+		Skills:     []FacetBucket{{Value: "Go", Count: 2}},
+		Salaries: []SalaryBucket{
+			{To: &to30k, Count: 1},
+			{From: &from30k, To: &to60k, Count: 0},
+			{From: &from150k, Count: 2},
+		},
+	}, response.facets())
+}
+
+func TestFacetAggregations(t *testing.T) {
+	aggregations := facetAggregations()
+	require.Len(t, aggregations, 5)
+	for _, name := range []string{"industries", "locations", "companies", "skills", "salaries"} {
+		require.Contains(t, aggregations, name)
+	}
+}
+
+func TestFacetCountsFacets(t *testing.T) {
+	bounds := SalaryFacetBounds()
+	require.Equal(t, []int32{30000, 60000, 90000, 120000, 150000}, bounds)
+
+	salaries := make([]int64, len(bounds)+1)
+	salaries[0] = 2
+	salaries[5] = 1
+	facets := FacetCounts{
+		Industries: map[string]int64{"IT": 1, "Finance": 2},
+		Skills:     map[string]int64{"Go": 3},
+		Salaries:   salaries,
+	}.Facets()
+
+	require.Equal(t, []FacetBucket{{Value: "Finance", Count: 2}, {Value: "IT", Count: 1}}, facets.Industries)
+	require.Equal(t, []FacetBucket{}, facets.Locations)
+	require.Equal(t, []FacetBucket{{Value: "Go", Count: 3}}, facets.Skills)
+	require.Len(t, facets.Salaries, len(salaryRanges))
+	require.Nil(t, facets.Salaries[0].From)
+	require.Equal(t, int64(2), facets.Salaries[0].Count)
+	require.Equal(t, int32(150000), *facets.Salaries[5].From)
+	require.Nil(t, facets.Salaries[5].To)
+	require.Equal(t, int64(1), facets.Salaries[5].Count)
+}
@@ -3,9 +3,10 @@ package esearch
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"github.com/elastic/go-elasticsearch/v8/esutil"
 	"io"
-	"log"
+	"net/http"
 	"strconv"
 )
 
@@ -13,46 +14,23 @@ import (
 func (client ESClient) IndexJobsAsDocuments(ctx context.Context) error {
 	jobs := ctx.Value(JobKey).([]Job)
 
-	bulkIndexer, err := esutil.NewBulkIndexer(esutil.BulkIndexerConfig{
-		Index:      "jobs",
-		Client:     client.client,
-		NumWorkers: 5,
-	})
-	if err != nil {
-		return err
-	}
-
-	for _, document := range jobs {
-		body, err := readerToReadSeeker(esutil.NewJSONReader(document))
-		if err != nil {
-			return err
-		}
-		err = bulkIndexer.Add(
-			ctx,
-			esutil.BulkIndexerItem{
-				Action:     "index",
-				DocumentID: strconv.Itoa(int(document.ID)),
-				Body:       body,
-			},
-		)
-		if err != nil {
-			return err
-		}
-	}
-
-	bulkIndexer.Close(ctx)
-	biStats := bulkIndexer.Stats()
-	log.Printf("Jobs indexed on Elasticsearch: %d \n", biStats.NumIndexed)
-	return nil
+	return client.indexJobs(ctx, "jobs", jobs)
 }
 
-// IndexJobAsDocument index one job as document
+// IndexJobAsDocument index one job as document, the job is replaced if it is already indexed.
+// The jobs alias has to exist, otherwise elasticsearch would create a jobs index without the mapping
 func (client ESClient) IndexJobAsDocument(documentID int, job Job) error {
-	_, err := client.client.Index("jobs", esutil.NewJSONReader(job),
-		client.client.Index.WithDocumentID(strconv.Itoa(documentID)))
+	response, err := client.client.Index("jobs", esutil.NewJSONReader(job),
+		client.client.Index.WithDocumentID(strconv.Itoa(documentID)),
+		client.client.Index.WithRequireAlias(true))
 	if err != nil {
 		return err
 	}
+	defer response.Body.Close()
+
+	if response.IsError() {
+		return fmt.Errorf("failed to index document %d: %s", documentID, response.String())
+	}
 	return nil
 }
 
@@ -68,12 +46,18 @@ func (client ESClient) UpdateJobDocument(documentID string, updatedJob Job) erro
 	return nil
 }
 
-// DeleteJobDocument delete document from the index
+// DeleteJobDocument delete document from the index,
+// deleting a document that does not exist is not an error
 func (client ESClient) DeleteJobDocument(documentID string) error {
-	_, err := client.client.Delete("jobs", documentID)
+	response, err := client.client.Delete("jobs", documentID)
 	if err != nil {
 		return err
 	}
+	defer response.Body.Close()
+
+	if response.IsError() && response.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to delete document %s: %s", documentID, response.String())
+	}
 	return nil
 }
 
@@ -67,6 +67,10 @@ func TestIndexJobAsDocument(t *testing.T) {
 		client: mockClient,
 	}
 
+	// the document is indexed through the jobs alias
+	err = client.EnsureJobsIndex(context.Background(), nil)
+	require.NoError(t, err)
+
 	// Test data
 	documentID := 123
 	job := createRandomJob()
@@ -33,16 +33,24 @@ func LoadJobsFromDB(ctx context.Context, store db.Store) (context.Context, error
 				panic(err)
 			}
 			j := Job{
-				ID:           job.ID,
-				Title:        job.Title,
-				Industry:     job.Industry,
-				CompanyName:  job.CompanyName,
-				Description:  job.Description,
-				Location:     job.Location,
-				SalaryMin:    job.SalaryMin,
-				SalaryMax:    job.SalaryMax,
-				Requirements: job.Requirements,
-				JobSkills:    skills,
+				ID:              job.ID,
+				Title:           job.Title,
+				Industry:        job.Industry,
+				CompanyName:     job.CompanyName,
+				Description:     job.Description,
+				Location:        job.Location,
+				SalaryMin:       job.SalaryMin,
+				SalaryMax:       job.SalaryMax,
+				Requirements:    job.Requirements,
+				JobSkills:       skills,
+				EmploymentType:  string(job.EmploymentType),
+				SeniorityLevel:  string(job.SeniorityLevel),
+				RemotePolicy:    string(job.RemotePolicy),
+				SalaryCurrency:  job.SalaryCurrency,
+				SalaryPeriod:    string(job.SalaryPeriod),
+				SalaryMinAnnual: job.SalaryMinAnnual,
+				SalaryMaxAnnual: job.SalaryMaxAnnual,
+				LocationPoint:   NewGeoPoint(job.Latitude, job.Longitude),
 			}
 			workQueue <- j
 		}
@@ -20,15 +20,22 @@ func TestLoadJobsFromDB(t *testing.T) {
 	// Define test data
 	testJobsFromDB := []db.ListAllJobsForESRow{
 		{
-			ID:           utils.RandomInt(1, 1000),
-			Title:        utils.RandomString(5),
-			Industry:     utils.RandomString(2),
-			Location:     utils.RandomString(2),
-			Description:  utils.RandomString(4),
-			CompanyName:  utils.RandomString(3),
-			SalaryMin:    utils.RandomInt(100, 200),
-			SalaryMax:    utils.RandomInt(201, 300),
-			Requirements: utils.RandomString(2),
+			ID:              utils.RandomInt(1, 1000),
+			Title:           utils.RandomString(5),
+			Industry:        utils.RandomString(2),
+			Location:        utils.RandomString(2),
+			Description:     utils.RandomString(4),
+			CompanyName:     utils.RandomString(3),
+			SalaryMin:       utils.RandomInt(100, 200),
+			SalaryMax:       utils.RandomInt(201, 300),
+			Requirements:    utils.RandomString(2),
+			EmploymentType:  db.EmploymentTypeContract,
+			SeniorityLevel:  db.SeniorityLevelSenior,
+			RemotePolicy:    db.RemotePolicyRemote,
+			SalaryCurrency:  "EUR",
+			SalaryPeriod:    db.SalaryPeriodYear,
+			SalaryMinAnnual: utils.RandomInt(100, 200),
+			SalaryMaxAnnual: utils.RandomInt(201, 300),
 		},
 	}
 
@@ -74,5 +81,12 @@ func TestLoadJobsFromDB(t *testing.T) {
 		require.Equal(t, loadedJobs[i].SalaryMax, expectedJob.SalaryMax)
 		require.Equal(t, loadedJobs[i].Requirements, expectedJob.Requirements)
 		require.Equal(t, loadedJobs[i].JobSkills, []string{"Skill1", "Skill2"})
+		require.Equal(t, loadedJobs[i].EmploymentType, string(expectedJob.EmploymentType))
+		require.Equal(t, loadedJobs[i].SeniorityLevel, string(expectedJob.SeniorityLevel))
+		require.Equal(t, loadedJobs[i].RemotePolicy, string(expectedJob.RemotePolicy))
+		require.Equal(t, loadedJobs[i].SalaryCurrency, expectedJob.SalaryCurrency)
+		require.Equal(t, loadedJobs[i].SalaryPeriod, string(expectedJob.SalaryPeriod))
+		require.Equal(t, loadedJobs[i].SalaryMinAnnual, expectedJob.SalaryMinAnnual)
+		require.Equal(t, loadedJobs[i].SalaryMaxAnnual, expectedJob.SalaryMaxAnnual)
 	}
 }
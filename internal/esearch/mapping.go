@@ -0,0 +1,388 @@
+package esearch
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/elastic/go-elasticsearch/v8/esutil"
+	"net/http"
+	"reflect"
+)
+
+const (
+	// jobTextAnalyzer is the english analyzer with stemming used when the jobs are indexed
+	jobTextAnalyzer = "job_text"
+	// jobSearchAnalyzer is jobTextAnalyzer with the synonyms, used when the jobs are searched.
+	// Synonyms are applied only at search time, so changing them does not require reindexing.
+	jobSearchAnalyzer = "job_search"
+	// jobSynonymsFilter is the name of the synonym filter
+	jobSynonymsFilter = "job_synonyms"
+)
+
+// jobsIndexDefinition returns the settings and the mapping of the jobs index with the synonyms,
+// the hash of the definition is stored in the _meta of the mapping
+func jobsIndexDefinition(synonyms []string) map[string]interface{} {
+	mapping := jobsMapping()
+	mapping["_meta"] = map[string]interface{}{
+		"hash": jobsDefinitionHash(),
+	}
+
+	return map[string]interface{}{
+		"settings": map[string]interface{}{
+			"analysis": jobsAnalysis(synonyms),
+		},
+		"mappings": mapping,
+	}
+}
+
+// jobsDefinitionHash returns the hash of the analysis settings and the mapping of the jobs index.
+// Every change of the definition changes the hash, so the existing index with a different hash
+// has to be recreated. The synonyms are not part of it, they are updated in place.
+func jobsDefinitionHash() string {
+	// maps are encoded with sorted keys, so the encoding of the same definition is always the same
+	definition, err := json.Marshal(map[string]interface{}{
+		"analysis": jobsAnalysis(nil),
+		"mappings": jobsMapping(),
+	})
+	if err != nil {
+		panic(fmt.Sprintf("failed to encode the jobs index definition: %v", err))
+	}
+
+	hash := sha256.Sum256(definition)
+	return hex.EncodeToString(hash[:])
+}
+
+// jobsAnalysis returns the analysis settings of the jobs index
+func jobsAnalysis(synonyms []string) map[string]interface{} {
+	englishFilters := []string{"english_possessive_stemmer", "lowercase", "english_stop", "english_stemmer"}
+	// synonyms go before the stop words and stemming, so they are analyzed like the searched text
+	searchFilters := []string{"english_possessive_stemmer", "lowercase", jobSynonymsFilter, "english_stop", "english_stemmer"}
+
+	return map[string]interface{}{
+		"filter": map[string]interface{}{
+			"english_possessive_stemmer": map[string]interface{}{
+				"type":     "stemmer",
+				"language": "possessive_english",
+			},
+			"english_stop": map[string]interface{}{
+				"type":      "stop",
+				"stopwords": "_english_",
+			},
+			"english_stemmer": map[string]interface{}{
+				"type":     "stemmer",
+				"language": "english",
+			},
+			jobSynonymsFilter: synonymsFilter(synonyms),
+		},
+		"analyzer": map[string]interface{}{
+			jobTextAnalyzer: map[string]interface{}{
+				"type":      "custom",
+				"tokenizer": "standard",
+				"filter":    englishFilters,
+			},
+			jobSearchAnalyzer: map[string]interface{}{
+				"type":      "custom",
+				"tokenizer": "standard",
+				"filter":    searchFilters,
+			},
+		},
+	}
+}
+
+// synonymsFilter returns the synonym filter with the synonyms in the Solr format
+func synonymsFilter(synonyms []string) map[string]interface{} {
+	if synonyms == nil {
+		synonyms = []string{}
+	}
+
+	return map[string]interface{}{
+		"type":     "synonym_graph",
+		"synonyms": synonyms,
+	}
+}
+
+// jobsMapping returns the mapping of the jobs index. Dynamic mapping is disabled,
+// so documents with the fields that are not mapped here are rejected.
+func jobsMapping() map[string]interface{} {
+	return map[string]interface{}{
+		"dynamic": "strict",
+		"properties": map[string]interface{}{
+			"id":                typedField("integer"),
+			"title":             englishTextField(true),
+			"industry":          englishTextField(false),
+			"company_name":      suggestedTextField(),
+			"description":       englishTextField(false),
+			"location":          keywordTextField(),
+			"salary_min":        typedField("integer"),
+			"salary_max":        typedField("integer"),
+			"requirements":      englishTextField(false),
+			"job_skills":        englishTextField(true),
+			"employment_type":   keywordTextField(),
+			"seniority_level":   keywordTextField(),
+			"remote_policy":     keywordTextField(),
+			"salary_currency":   keywordTextField(),
+			"salary_period":     keywordTextField(),
+			"salary_min_annual": typedField("integer"),
+			"salary_max_annual": typedField("integer"),
+			"location_point":    typedField("geo_point"),
+		},
+	}
+}
+
+// typedField returns the mapping of a field of the type
+func typedField(fieldType string) map[string]interface{} {
+	return map[string]interface{}{
+		"type": fieldType,
+	}
+}
+
+// keywordTextField returns the mapping of a text field with the keyword sub-field
+// used by the term filters and the facets
+func keywordTextField() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "text",
+		"fields": map[string]interface{}{
+			"keyword": map[string]interface{}{
+				"type":         "keyword",
+				"ignore_above": 256,
+			},
+		},
+	}
+}
+
+// suggestedTextField returns the mapping of a text field with the keyword sub-field
+// and the search_as_you_type sub-field for autocomplete
+func suggestedTextField() map[string]interface{} {
+	field := keywordTextField()
+	field["fields"].(map[string]interface{})["suggest"] = map[string]interface{}{
+		"type": "search_as_you_type",
+	}
+
+	return field
+}
+
+// englishTextField returns the mapping of a text field analyzed with the english analyzer,
+// searched with the synonyms, with the keyword sub-field and, if suggested is true,
+// the search_as_you_type sub-field
+func englishTextField(suggested bool) map[string]interface{} {
+	field := keywordTextField()
+	if suggested {
+		field = suggestedTextField()
+	}
+	field["analyzer"] = jobTextAnalyzer
+	field["search_analyzer"] = jobSearchAnalyzer
+
+	return field
+}
+
+type indexMapping struct {
+	Mappings struct {
+		Meta struct {
+			Hash string `json:"hash"`
+		} `json:"_meta"`
+	} `json:"mappings"`
+}
+
+type indexSettings struct {
+	Settings struct {
+		Index struct {
+			Analysis struct {
+				Filter map[string]struct {
+					Synonyms []string `json:"synonyms"`
+				} `json:"filter"`
+			} `json:"analysis"`
+		} `json:"index"`
+	} `json:"settings"`
+}
+
+// EnsureJobsIndex creates the first versioned jobs index with the definition and the synonyms
+// and points the jobs alias to it if the alias does not exist. If it exists, it fails when
+// the index was created with a different definition, otherwise it updates
+// the synonyms of the index if they have changed. It does not index the jobs, see Reindex.
+func (client ESClient) EnsureJobsIndex(ctx context.Context, synonyms []string) error {
+	response, err := client.client.Indices.Exists(
+		[]string{"jobs"},
+		client.client.Indices.Exists.WithContext(ctx),
+	)
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		indices, err := client.getJobsIndices(ctx)
+		if err != nil {
+			return err
+		}
+		return client.createJobsIndex(ctx, nextJobsIndex(indices), synonyms, true)
+	}
+
+	err = client.checkJobsDefinitionHash(ctx)
+	if err != nil {
+		return err
+	}
+
+	return client.updateJobsSynonyms(ctx, synonyms)
+}
+
+// createJobsIndex creates the index with the jobs index definition,
+// if alias is true, the jobs alias points to the new index
+func (client ESClient) createJobsIndex(ctx context.Context, index string, synonyms []string, alias bool) error {
+	body := jobsIndexDefinition(synonyms)
+	if alias {
+		body["aliases"] = map[string]interface{}{
+			"jobs": map[string]interface{}{},
+		}
+	}
+
+	response, err := client.client.Indices.Create(
+		index,
+		client.client.Indices.Create.WithContext(ctx),
+		client.client.Indices.Create.WithBody(esutil.NewJSONReader(body)),
+	)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.IsError() {
+		return fmt.Errorf("failed to create the index %s: %s", index, response.String())
+	}
+
+	return nil
+}
+
+// checkJobsDefinitionHash returns an error if the jobs index was not created
+// with the current definition
+func (client ESClient) checkJobsDefinitionHash(ctx context.Context) error {
+	response, err := client.client.Indices.GetMapping(
+		client.client.Indices.GetMapping.WithContext(ctx),
+		client.client.Indices.GetMapping.WithIndex("jobs"),
+	)
+	if err != nil {
+		return err
+	}
+
+	var mappings map[string]indexMapping
+	err = decodeResponse(response, &mappings)
+	if err != nil {
+		return err
+	}
+
+	return checkDefinitionHash(mappings)
+}
+
+// checkDefinitionHash returns an error if any of the indices has a different definition hash
+// than jobsDefinitionHash, indices mapped dynamically have no hash
+func checkDefinitionHash(mappings map[string]indexMapping) error {
+	hash := jobsDefinitionHash()
+	for index, mapping := range mappings {
+		if mapping.Mappings.Meta.Hash != hash {
+			return fmt.Errorf(
+				"index %s has definition hash %q, expected %q: run the app with -reindex to rebuild it",
+				index, mapping.Mappings.Meta.Hash, hash,
+			)
+		}
+	}
+
+	return nil
+}
+
+// updateJobsSynonyms updates the synonyms of the jobs index if they are different from the given ones.
+// Analysis settings can be changed only when the index is closed,
+// so the index is unavailable for a moment.
+func (client ESClient) updateJobsSynonyms(ctx context.Context, synonyms []string) error {
+	response, err := client.client.Indices.GetSettings(
+		client.client.Indices.GetSettings.WithContext(ctx),
+		client.client.Indices.GetSettings.WithIndex("jobs"),
+	)
+	if err != nil {
+		return err
+	}
+
+	var settings map[string]indexSettings
+	err = decodeResponse(response, &settings)
+	if err != nil {
+		return err
+	}
+
+	if !synonymsChanged(settings, synonyms) {
+		return nil
+	}
+
+	closeResponse, err := client.client.Indices.Close(
+		[]string{"jobs"},
+		client.client.Indices.Close.WithContext(ctx),
+	)
+	if err != nil {
+		return err
+	}
+	closeResponse.Body.Close()
+	if closeResponse.IsError() {
+		return fmt.Errorf("failed to close the jobs index: %s", closeResponse.String())
+	}
+
+	// the index is opened again even if the update failed
+	err = client.putJobsSynonyms(ctx, synonyms)
+
+	openResponse, openErr := client.client.Indices.Open(
+		[]string{"jobs"},
+		client.client.Indices.Open.WithContext(ctx),
+	)
+	if openErr != nil {
+		return openErr
+	}
+	openResponse.Body.Close()
+	if openResponse.IsError() {
+		return fmt.Errorf("failed to open the jobs index: %s", openResponse.String())
+	}
+
+	return err
+}
+
+// putJobsSynonyms puts the synonym filter into the settings of the closed jobs index
+func (client ESClient) putJobsSynonyms(ctx context.Context, synonyms []string) error {
+	body := map[string]interface{}{
+		"analysis": map[string]interface{}{
+			"filter": map[string]interface{}{
+				jobSynonymsFilter: synonymsFilter(synonyms),
+			},
+		},
+	}
+	response, err := client.client.Indices.PutSettings(
+		esutil.NewJSONReader(body),
+		client.client.Indices.PutSettings.WithContext(ctx),
+		client.client.Indices.PutSettings.WithIndex("jobs"),
+	)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.IsError() {
+		return fmt.Errorf("failed to update the synonyms of the jobs index: %s", response.String())
+	}
+
+	return nil
+}
+
+// synonymsChanged reports whether the synonyms of any of the indices are different from the given ones
+func synonymsChanged(settings map[string]indexSettings, synonyms []string) bool {
+	if synonyms == nil {
+		synonyms = []string{}
+	}
+
+	for _, index := range settings {
+		current := index.Settings.Index.Analysis.Filter[jobSynonymsFilter].Synonyms
+		if current == nil {
+			current = []string{}
+		}
+		if !reflect.DeepEqual(current, synonyms) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,93 @@
+package esearch
+
+import (
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestJobsIndexDefinition(t *testing.T) {
+	synonyms := []string{"golang, go", "k8s, kubernetes"}
+	definition := jobsIndexDefinition(synonyms)
+
+	analysis := definition["settings"].(map[string]interface{})["analysis"].(map[string]interface{})
+	filter := analysis["filter"].(map[string]interface{})[jobSynonymsFilter].(map[string]interface{})
+	require.Equal(t, synonyms, filter["synonyms"])
+
+	mapping := definition["mappings"].(map[string]interface{})
+	require.Equal(t, "strict", mapping["dynamic"])
+	require.Equal(t, jobsDefinitionHash(), mapping["_meta"].(map[string]interface{})["hash"])
+
+	properties := mapping["properties"].(map[string]interface{})
+	title := properties["title"].(map[string]interface{})
+	require.Equal(t, jobTextAnalyzer, title["analyzer"])
+	require.Equal(t, jobSearchAnalyzer, title["search_analyzer"])
+	require.Contains(t, title["fields"], "keyword")
+	require.Contains(t, title["fields"], "suggest")
+
+	location := properties["location"].(map[string]interface{})
+	require.NotContains(t, location, "analyzer")
+	require.Contains(t, location["fields"], "keyword")
+
+	// the dynamic mapping is strict, so every field of the job has to be mapped
+	jobType := reflect.TypeOf(Job{})
+	require.Len(t, properties, jobType.NumField())
+	for i := 0; i < jobType.NumField(); i++ {
+		name := strings.Split(jobType.Field(i).Tag.Get("json"), ",")[0]
+		require.Contains(t, properties, name)
+	}
+}
+
+func TestJobsIndexDefinitionNoSynonyms(t *testing.T) {
+	definition := jobsIndexDefinition(nil)
+
+	analysis := definition["settings"].(map[string]interface{})["analysis"].(map[string]interface{})
+	filter := analysis["filter"].(map[string]interface{})[jobSynonymsFilter].(map[string]interface{})
+	require.Equal(t, []string{}, filter["synonyms"])
+}
+
+func TestJobsDefinitionHash(t *testing.T) {
+	hash := jobsDefinitionHash()
+	require.Len(t, hash, 64)
+	require.Equal(t, hash, jobsDefinitionHash())
+
+	// the synonyms are updated in place, so they do not change the hash
+	definition := jobsIndexDefinition([]string{"golang, go"})
+	require.Equal(t, hash, definition["mappings"].(map[string]interface{})["_meta"].(map[string]interface{})["hash"])
+}
+
+func TestCheckDefinitionHash(t *testing.T) {
+	var current indexMapping
+	current.Mappings.Meta.Hash = jobsDefinitionHash()
+	err := checkDefinitionHash(map[string]indexMapping{"jobs": current})
+	require.NoError(t, err)
+
+	// index created with a different definition or with the old numeric version
+	var old indexMapping
+	old.Mappings.Meta.Hash = strings.Repeat("0", 64)
+	err = checkDefinitionHash(map[string]indexMapping{"jobs": old})
+	require.Error(t, err)
+
+	// dynamically mapped index does not have the hash
+	err = checkDefinitionHash(map[string]indexMapping{"jobs": {}})
+	require.Error(t, err)
+}
+
+func TestSynonymsChanged(t *testing.T) {
+	settingsWith := func(synonyms []string) map[string]indexSettings {
+		var settings indexSettings
+		settings.Settings.Index.Analysis.Filter = map[string]struct {
+			Synonyms []string `json:"synonyms"`
+		}{
+			jobSynonymsFilter: {Synonyms: synonyms},
+		}
+		return map[string]indexSettings{"jobs": settings}
+	}
+
+	require.False(t, synonymsChanged(settingsWith([]string{"golang, go"}), []string{"golang, go"}))
+	require.False(t, synonymsChanged(settingsWith(nil), nil))
+	require.False(t, synonymsChanged(settingsWith(nil), []string{}))
+	require.True(t, synonymsChanged(settingsWith([]string{"golang, go"}), []string{"golang, go", "k8s, kubernetes"}))
+	require.True(t, synonymsChanged(settingsWith([]string{"golang, go"}), nil))
+}
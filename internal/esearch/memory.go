@@ -0,0 +1,225 @@
+package esearch
+
+import (
+	"context"
+	"fmt"
+	"github.com/aalug/job-finder-go/internal/db/sqlc"
+	"log"
+	"strconv"
+	"sync"
+)
+
+// MemoryClient is the in-process search backend, it keeps the jobs in memory
+// and searches them like the jobs index of elasticsearch does, so the application
+// can run without elasticsearch. The jobs are lost when the process exits,
+// so they have to be indexed with Reindex when it starts.
+type MemoryClient struct {
+	mutex     sync.RWMutex
+	documents map[string]*memoryDocument
+	synonyms  []synonymRule
+	// version is the version of the jobs index, 0 before the index is created
+	version int
+	// sequence is the number of the indexed documents, it orders the documents with equal scores
+	sequence int
+}
+
+// memoryDocument is an indexed job with its analyzed searchable fields
+type memoryDocument struct {
+	job      Job
+	fields   map[string][]token
+	sequence int
+}
+
+// searchedFields are the text fields of the jobs searched by the query,
+// mapped to whether they are analyzed with the english analysis
+var searchedFields = map[string]bool{
+	"title":        true,
+	"description":  true,
+	"requirements": true,
+	"job_skills":   true,
+	"location":     false,
+}
+
+func NewMemoryClient() ESearchClient {
+	return &MemoryClient{
+		documents: map[string]*memoryDocument{},
+	}
+}
+
+// newMemoryDocument analyzes the searchable fields of the job
+func newMemoryDocument(job Job, sequence int) *memoryDocument {
+	job.JobSkills = append([]string(nil), job.JobSkills...)
+	if job.LocationPoint != nil {
+		point := *job.LocationPoint
+		job.LocationPoint = &point
+	}
+
+	document := &memoryDocument{
+		job:      job,
+		fields:   map[string][]token{},
+		sequence: sequence,
+	}
+	for field, english := range searchedFields {
+		document.fields[field] = analyzeValues(fieldValues(job, field), english)
+	}
+
+	return document
+}
+
+// fieldValues returns the values of the field of the job
+func fieldValues(job Job, field string) []string {
+	switch field {
+	case "title":
+		return []string{job.Title}
+	case "description":
+		return []string{job.Description}
+	case "requirements":
+		return []string{job.Requirements}
+	case "job_skills":
+		return job.JobSkills
+	case "location":
+		return []string{job.Location}
+	case "industry":
+		return []string{job.Industry}
+	case "company_name":
+		return []string{job.CompanyName}
+	}
+
+	return nil
+}
+
+// IndexJobsAsDocuments index jobs as documents
+func (client *MemoryClient) IndexJobsAsDocuments(ctx context.Context) error {
+	jobs := ctx.Value(JobKey).([]Job)
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	for _, job := range jobs {
+		client.index(strconv.Itoa(int(job.ID)), job)
+	}
+	log.Printf("Jobs indexed in memory: %d \n", len(jobs))
+	return nil
+}
+
+// IndexJobAsDocument index one job as document, the job is replaced if it is already indexed
+func (client *MemoryClient) IndexJobAsDocument(documentID int, job Job) error {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	client.index(strconv.Itoa(documentID), job)
+	return nil
+}
+
+// UpdateJobDocument update one job document, it fails if the document does not exist
+func (client *MemoryClient) UpdateJobDocument(documentID string, updatedJob Job) error {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if _, ok := client.documents[documentID]; !ok {
+		return fmt.Errorf("failed to update document %s: document missing", documentID)
+	}
+	client.index(documentID, updatedJob)
+	return nil
+}
+
+// DeleteJobDocument delete document from the index,
+// deleting a document that does not exist is not an error
+func (client *MemoryClient) DeleteJobDocument(documentID string) error {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	delete(client.documents, documentID)
+	return nil
+}
+
+// index indexes the job as the document, the mutex has to be locked
+func (client *MemoryClient) index(documentID string, job Job) {
+	client.sequence++
+	client.documents[documentID] = newMemoryDocument(job, client.sequence)
+}
+
+// ListDocumentJobIDs lists the job IDs of all documents, mapped by the document ID
+func (client *MemoryClient) ListDocumentJobIDs(ctx context.Context) (map[string]int32, error) {
+	client.mutex.RLock()
+	defer client.mutex.RUnlock()
+
+	documents := make(map[string]int32, len(client.documents))
+	for documentID, document := range client.documents {
+		documents[documentID] = document.job.ID
+	}
+
+	return documents, nil
+}
+
+// QueryJobsByDocumentID returns the job of the document, nil if it does not exist.
+// This is a helper function for testing purposes.
+func (client *MemoryClient) QueryJobsByDocumentID(documentID int) *Job {
+	client.mutex.RLock()
+	defer client.mutex.RUnlock()
+
+	document, ok := client.documents[strconv.Itoa(documentID)]
+	if !ok {
+		return nil
+	}
+
+	job := document.job
+	return &job
+}
+
+// EnsureJobsIndex creates the jobs index if it does not exist and sets its synonyms,
+// the indexed jobs are kept. It does not index the jobs, see Reindex.
+func (client *MemoryClient) EnsureJobsIndex(ctx context.Context, synonyms []string) error {
+	rules, err := parseSynonyms(synonyms)
+	if err != nil {
+		return err
+	}
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	client.synonyms = rules
+	if client.version == 0 {
+		client.version = 1
+	}
+	return nil
+}
+
+// Reindex replaces the indexed jobs with the jobs from the db and sets the synonyms.
+// The jobs are swapped at once, so the search keeps working during and after a failed reindex.
+func (client *MemoryClient) Reindex(ctx context.Context, store db.Store, synonyms []string) (ReindexResult, error) {
+	var result ReindexResult
+
+	rules, err := parseSynonyms(synonyms)
+	if err != nil {
+		return result, err
+	}
+
+	jobsCtx, err := LoadJobsFromDB(ctx, store)
+	if err != nil {
+		return result, err
+	}
+	jobs := jobsCtx.Value(JobKey).([]Job)
+	result.Jobs = len(jobs)
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	documents := make(map[string]*memoryDocument, len(jobs))
+	for _, job := range jobs {
+		client.sequence++
+		documents[strconv.Itoa(int(job.ID))] = newMemoryDocument(job, client.sequence)
+	}
+
+	if client.version > 0 {
+		result.DeletedIndices = []string{jobsIndexPrefix + strconv.Itoa(client.version)}
+	}
+	client.version++
+	client.documents = documents
+	client.synonyms = rules
+	result.Index = jobsIndexPrefix + strconv.Itoa(client.version)
+	result.Documents = int64(len(documents))
+
+	log.Printf("Jobs reindexed into %s: %d\n", result.Index, result.Documents)
+	return result, nil
+}
@@ -0,0 +1,724 @@
+package esearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/aalug/job-finder-go/internal/geo"
+	"html"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// parameters of the BM25 similarity, the defaults of elasticsearch
+	bm25K1 = 1.2
+	bm25B  = 0.75
+	// maxResultWindow is the max from + size of the search, the default of elasticsearch
+	maxResultWindow = 10000
+	// keywordIgnoreAbove is the max length of the values of the keyword sub-fields,
+	// longer values are not indexed by them
+	keywordIgnoreAbove = 256
+)
+
+// multiMatchFields are the fields of the multi_match part of the search query
+var multiMatchFields = []string{"description", "requirements", "job_skills", "location"}
+
+// fieldStats are the statistics of a searched field over all the documents
+type fieldStats struct {
+	docCount    int
+	totalLength int
+	docFreq     map[string]int
+}
+
+// idf returns the inverse document frequency of a term in docFreq documents
+func (stats *fieldStats) idf(docFreq int) float64 {
+	return math.Log(1 + (float64(stats.docCount)-float64(docFreq)+0.5)/(float64(docFreq)+0.5))
+}
+
+// termQuery is a term of the query expanded to the terms of the field within its fuzziness,
+// the expansions are mapped to their boosts. The expansions share the highest document frequency.
+type termQuery struct {
+	term       string
+	expansions map[string]float64
+	idf        float64
+}
+
+// phraseQuery is a phrase of the synonyms, empty terms are the removed stop words
+type phraseQuery struct {
+	terms []string
+	idf   float64
+}
+
+// fieldQuery is the query compiled for a field
+type fieldQuery struct {
+	field   string
+	stats   *fieldStats
+	terms   []termQuery
+	phrases []phraseQuery
+}
+
+// fieldMatch is the score of the field query in a document with the matched terms
+type fieldMatch struct {
+	score       float64
+	terms       map[string]bool
+	explanation explanation
+}
+
+// explanation is the explanation of a score in the format of elasticsearch
+type explanation struct {
+	Value       float64       `json:"value"`
+	Description string        `json:"description"`
+	Details     []explanation `json:"details"`
+}
+
+// SearchJobs searches for jobs the same way as the jobs index of elasticsearch does.
+// Jobs have to match the query and all the filters that are set. The query is matched
+// with the title and with the best of the other searched fields, the terms can differ
+// by the AUTO fuzziness and the scores are calculated with BM25.
+func (client *MemoryClient) SearchJobs(ctx context.Context, query string, filters JobFilters, page, pageSize int32, explain bool) (SearchJobsResult, error) {
+	result := SearchJobsResult{
+		Jobs:     []*JobHit{},
+		Page:     page,
+		PageSize: pageSize,
+	}
+
+	from := int((page - 1) * pageSize)
+	if from < 0 || pageSize < 0 {
+		return result, fmt.Errorf("invalid page %d or page size %d", page, pageSize)
+	}
+	if from+int(pageSize) > maxResultWindow {
+		return result, fmt.Errorf("result window is too large, from + size must be less than or equal to %d", maxResultWindow)
+	}
+
+	client.mutex.RLock()
+	defer client.mutex.RUnlock()
+
+	stats := client.fieldStats()
+	titleQuery := compileFieldQuery("title", query, stats, client.synonyms)
+	var otherQueries []*fieldQuery
+	for _, field := range multiMatchFields {
+		otherQueries = append(otherQueries, compileFieldQuery(field, query, stats, client.synonyms))
+	}
+
+	var hits []*JobHit
+	var matched []Job
+	sequences := map[*JobHit]int{}
+	for _, document := range client.documents {
+		if !filters.match(document.job) {
+			continue
+		}
+
+		title := titleQuery.match(document)
+		var best fieldMatch
+		var others []explanation
+		highlights := map[string][]string{}
+		for _, fieldQuery := range otherQueries {
+			match := fieldQuery.match(document)
+			if match.score == 0 {
+				continue
+			}
+			others = append(others, match.explanation)
+			if match.score > best.score {
+				best = match
+			}
+			if fragments := highlightField(fieldValues(document.job, fieldQuery.field), document.fields[fieldQuery.field], match.terms); len(fragments) > 0 {
+				highlights[fieldQuery.field] = fragments
+			}
+		}
+
+		// minimum_should_match 1, the title or any of the other fields has to match
+		score := title.score + best.score
+		if score == 0 {
+			continue
+		}
+		if title.score > 0 {
+			highlights["title"] = highlightField(fieldValues(document.job, "title"), document.fields["title"], title.terms)
+		}
+
+		hit := &JobHit{
+			Job:        document.job,
+			Score:      score,
+			Highlights: highlights,
+		}
+		if explain {
+			hit.Explanation, _ = json.Marshal(scoreExplanation(title, best, others))
+		}
+		hits = append(hits, hit)
+		sequences[hit] = document.sequence
+		matched = append(matched, document.job)
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return sequences[hits[i]] < sequences[hits[j]]
+	})
+
+	for i := from; i < len(hits) && i < from+int(pageSize); i++ {
+		result.Jobs = append(result.Jobs, hits[i])
+	}
+	result.Total = int64(len(hits))
+	if pageSize > 0 {
+		result.TotalPages = int32((result.Total + int64(pageSize) - 1) / int64(pageSize))
+	}
+	result.Facets = memoryFacets(matched)
+
+	return result, nil
+}
+
+// scoreExplanation explains the score, the sum of the title score and the best score of the other fields
+func scoreExplanation(title, best fieldMatch, others []explanation) explanation {
+	sum := explanation{
+		Value:       title.score + best.score,
+		Description: "sum of:",
+		Details:     []explanation{},
+	}
+	if title.score > 0 {
+		sum.Details = append(sum.Details, title.explanation)
+	}
+	if best.score > 0 {
+		sum.Details = append(sum.Details, explanation{
+			Value:       best.score,
+			Description: "max of:",
+			Details:     others,
+		})
+	}
+
+	return sum
+}
+
+// fieldStats calculates the statistics of the searched fields, the mutex has to be locked
+func (client *MemoryClient) fieldStats() map[string]*fieldStats {
+	stats := map[string]*fieldStats{}
+	for field := range searchedFields {
+		stats[field] = &fieldStats{
+			docFreq: map[string]int{},
+		}
+	}
+
+	for _, document := range client.documents {
+		for field, tokens := range document.fields {
+			if len(tokens) == 0 {
+				continue
+			}
+			fieldStats := stats[field]
+			fieldStats.docCount++
+			fieldStats.totalLength += len(tokens)
+			seen := map[string]bool{}
+			for _, t := range tokens {
+				if !seen[t.term] {
+					seen[t.term] = true
+					fieldStats.docFreq[t.term]++
+				}
+			}
+		}
+	}
+
+	return stats
+}
+
+// compileFieldQuery analyzes the query for the field and expands its terms
+// to the terms of the field within their fuzziness
+func compileFieldQuery(field, query string, stats map[string]*fieldStats, synonyms []synonymRule) *fieldQuery {
+	fieldQuery := &fieldQuery{
+		field: field,
+		stats: stats[field],
+	}
+
+	for _, clause := range analyzeQuery(query, searchedFields[field], synonyms) {
+		for _, alternative := range clause {
+			if len(alternative) > 1 {
+				fieldQuery.phrases = append(fieldQuery.phrases, fieldQuery.phrase(alternative))
+				continue
+			}
+			fieldQuery.terms = append(fieldQuery.terms, fieldQuery.expand(alternative[0]))
+		}
+	}
+
+	return fieldQuery
+}
+
+// expand expands the term to the terms of the field within its fuzziness, the boost
+// of an expansion is lower the more edits it differs by
+func (query *fieldQuery) expand(term string) termQuery {
+	termQuery := termQuery{
+		term:       term,
+		expansions: map[string]float64{},
+	}
+
+	maxEdits := fuzziness(term)
+	docFreq := 0
+	for candidate, candidateFreq := range query.stats.docFreq {
+		edits := editDistance(term, candidate, maxEdits)
+		if edits > maxEdits {
+			continue
+		}
+		length := minInt(utf8.RuneCountInString(term), utf8.RuneCountInString(candidate))
+		termQuery.expansions[candidate] = 1 - float64(edits)/float64(length)
+		if candidateFreq > docFreq {
+			docFreq = candidateFreq
+		}
+	}
+	termQuery.idf = query.stats.idf(docFreq)
+
+	return termQuery
+}
+
+// phrase returns the phrase query of the terms, its idf is the sum of the idfs of the terms
+func (query *fieldQuery) phrase(terms []string) phraseQuery {
+	phrase := phraseQuery{
+		terms: terms,
+	}
+	for _, term := range terms {
+		if term != "" {
+			phrase.idf += query.stats.idf(query.stats.docFreq[term])
+		}
+	}
+
+	return phrase
+}
+
+// match scores the document with the query, the score is 0 if the document does not match
+func (query *fieldQuery) match(document *memoryDocument) fieldMatch {
+	match := fieldMatch{
+		terms: map[string]bool{},
+		explanation: explanation{
+			Description: "sum of:",
+			Details:     []explanation{},
+		},
+	}
+
+	tokens := document.fields[query.field]
+	if len(tokens) == 0 {
+		return match
+	}
+
+	frequencies := map[string]int{}
+	for _, t := range tokens {
+		frequencies[t.term]++
+	}
+	averageLength := float64(query.stats.totalLength) / float64(query.stats.docCount)
+	norm := bm25K1 * (1 - bm25B + bm25B*float64(len(tokens))/averageLength)
+	bm25 := func(idf, boost float64, frequency int) float64 {
+		return idf * boost * float64(frequency) / (float64(frequency) + norm)
+	}
+
+	add := func(term string, score float64, description string) {
+		match.score += score
+		match.explanation.Details = append(match.explanation.Details, explanation{
+			Value:       score,
+			Description: fmt.Sprintf("weight(%s:%s in %d) [BM25], %s", query.field, term, document.job.ID, description),
+			Details:     []explanation{},
+		})
+	}
+
+	for _, termQuery := range query.terms {
+		for term, boost := range termQuery.expansions {
+			frequency := frequencies[term]
+			if frequency == 0 {
+				continue
+			}
+			match.terms[term] = true
+			add(term, bm25(termQuery.idf, boost, frequency),
+				fmt.Sprintf("freq=%d, boost=%g, fuzzy expansion of %s", frequency, boost, termQuery.term))
+		}
+	}
+
+	for _, phraseQuery := range query.phrases {
+		frequency := phraseFrequency(tokens, phraseQuery.terms)
+		if frequency == 0 {
+			continue
+		}
+		for _, term := range phraseQuery.terms {
+			if term != "" {
+				match.terms[term] = true
+			}
+		}
+		phrase := strings.TrimSpace(strings.Join(phraseQuery.terms, " "))
+		add("\""+phrase+"\"", bm25(phraseQuery.idf, 1, frequency), fmt.Sprintf("phraseFreq=%d", frequency))
+	}
+
+	match.explanation.Value = match.score
+	// the details are sorted, the expansions are iterated in a random order
+	sort.Slice(match.explanation.Details, func(i, j int) bool {
+		return match.explanation.Details[i].Description < match.explanation.Details[j].Description
+	})
+	return match
+}
+
+// phraseFrequency returns the number of the occurrences of the phrase in the tokens
+func phraseFrequency(tokens []token, phrase []string) int {
+	positions := map[int]string{}
+	for _, t := range tokens {
+		positions[t.position] = t.term
+	}
+
+	frequency := 0
+	for _, t := range tokens {
+		if t.term != phrase[0] {
+			continue
+		}
+		found := true
+		for i, term := range phrase {
+			if term != "" && positions[t.position+i] != term {
+				found = false
+				break
+			}
+		}
+		if found {
+			frequency++
+		}
+	}
+
+	return frequency
+}
+
+// match reports whether the job matches all the filters that are set
+func (filters JobFilters) match(job Job) bool {
+	terms := [][2]string{
+		{job.EmploymentType, filters.EmploymentType},
+		{job.SeniorityLevel, filters.SeniorityLevel},
+		{job.RemotePolicy, filters.RemotePolicy},
+		{job.SalaryCurrency, filters.SalaryCurrency},
+		{job.SalaryPeriod, filters.SalaryPeriod},
+		{job.Industry, filters.Industry},
+		{job.Location, filters.Location},
+		{job.CompanyName, filters.CompanyName},
+	}
+	// the filters are matched with the keyword sub-fields
+	for _, term := range terms {
+		if term[1] != "" && (term[0] != term[1] || !isKeyword(term[0])) {
+			return false
+		}
+	}
+
+	// the same as in the database, the whole salary range has to be within the filters
+	if filters.SalaryMin != 0 && job.SalaryMinAnnual < filters.SalaryMin {
+		return false
+	}
+	if filters.SalaryMax != 0 && job.SalaryMaxAnnual > filters.SalaryMax {
+		return false
+	}
+
+	if filters.Near != nil {
+		if job.LocationPoint == nil {
+			return false
+		}
+		distance := geo.DistanceKm(filters.Near.Lat, filters.Near.Lon, job.LocationPoint.Lat, job.LocationPoint.Lon)
+		if distance > filters.RadiusKm {
+			return false
+		}
+	}
+
+	// every skill has to be required by the job
+	for _, skill := range filters.Skills {
+		found := false
+		for _, jobSkill := range job.JobSkills {
+			if jobSkill == skill && isKeyword(jobSkill) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isKeyword reports whether the value is indexed by the keyword sub-field
+func isKeyword(value string) bool {
+	return utf8.RuneCountInString(value) <= keywordIgnoreAbove
+}
+
+// memoryFacets returns the facets of the jobs
+func memoryFacets(jobs []Job) Facets {
+	counts := FacetCounts{
+		Industries: countValues(jobs, "industry"),
+		Locations:  countValues(jobs, "location"),
+		Companies:  countValues(jobs, "company_name"),
+		Skills:     countValues(jobs, "job_skills"),
+		Salaries:   make([]int64, len(salaryRanges)),
+	}
+
+	bounds := SalaryFacetBounds()
+	for _, job := range jobs {
+		salaryRange := sort.Search(len(bounds), func(i int) bool {
+			return bounds[i] > job.SalaryMinAnnual
+		})
+		counts.Salaries[salaryRange]++
+	}
+
+	return counts.Facets()
+}
+
+// countValues counts the jobs by the values of the field indexed by the keyword sub-field
+func countValues(jobs []Job, field string) map[string]int64 {
+	counts := map[string]int64{}
+	for _, job := range jobs {
+		for _, value := range keywordValues(fieldValues(job, field)) {
+			counts[value]++
+		}
+	}
+
+	return counts
+}
+
+// keywordValues returns the distinct values indexed by the keyword sub-field
+func keywordValues(values []string) []string {
+	var keywords []string
+	seen := map[string]bool{}
+	for _, value := range values {
+		if !seen[value] && isKeyword(value) {
+			seen[value] = true
+			keywords = append(keywords, value)
+		}
+	}
+
+	return keywords
+}
+
+// highlightField returns up to highlightFragments fragments of the values of the field
+// with the matched terms wrapped in <em> tags and the rest HTML escaped, just like
+// the "html" encoder of elasticsearch. Values longer than highlightFragmentSize
+// are split into sentences and only the sentences with the matched terms are returned.
+func highlightField(values []string, tokens []token, terms map[string]bool) []string {
+	fragments := []string{}
+	for i, value := range values {
+		var matched []token
+		for _, t := range tokens {
+			if t.value == i && terms[t.term] {
+				matched = append(matched, t)
+			}
+		}
+		if len(matched) == 0 {
+			continue
+		}
+
+		if utf8.RuneCountInString(value) <= highlightFragmentSize {
+			fragments = append(fragments, wrapMatches(value, 0, len(value), matched))
+		} else {
+			for _, sentence := range sentences(value) {
+				fragment := wrapMatches(value, sentence[0], sentence[1], matched)
+				if strings.Contains(fragment, "<em>") {
+					fragments = append(fragments, strings.TrimSpace(fragment))
+				}
+			}
+		}
+	}
+
+	if len(fragments) > highlightFragments {
+		fragments = fragments[:highlightFragments]
+	}
+	return fragments
+}
+
+// wrapMatches returns value[start:end] HTML escaped with the matched tokens wrapped in <em> tags
+func wrapMatches(value string, start, end int, matched []token) string {
+	var builder strings.Builder
+	last := start
+	for _, t := range matched {
+		if t.start < start || t.end > end {
+			continue
+		}
+		builder.WriteString(html.EscapeString(value[last:t.start]))
+		builder.WriteString("<em>")
+		builder.WriteString(html.EscapeString(value[t.start:t.end]))
+		builder.WriteString("</em>")
+		last = t.end
+	}
+	builder.WriteString(html.EscapeString(value[last:end]))
+
+	return builder.String()
+}
+
+// sentences returns the start and end offsets of the sentences of the text
+func sentences(text string) [][2]int {
+	var offsets [][2]int
+	start := 0
+	for i, r := range text {
+		if r != '.' && r != '!' && r != '?' && r != '\n' {
+			continue
+		}
+		next := i + utf8.RuneLen(r)
+		if r != '\n' && next < len(text) && !strings.ContainsRune(" \t\r\n", rune(text[next])) {
+			continue
+		}
+		offsets = append(offsets, [2]int{start, next})
+		start = next
+	}
+	if start < len(text) {
+		offsets = append(offsets, [2]int{start, len(text)})
+	}
+
+	return offsets
+}
+
+// Autocomplete suggests job titles, skills and company names that match the prefix
+// as it is typed. It returns up to size suggestions of every type, ordered by type
+// and the number of the jobs.
+func (client *MemoryClient) Autocomplete(ctx context.Context, prefix string, size int) ([]Suggestion, error) {
+	suggestions := []Suggestion{}
+	if strings.TrimSpace(prefix) == "" {
+		return suggestions, nil
+	}
+
+	client.mutex.RLock()
+	defer client.mutex.RUnlock()
+
+	for _, suggestionField := range suggestionFields {
+		counts := map[string]int64{}
+		for _, document := range client.documents {
+			values := fieldValues(document.job, suggestionField.field)
+			if !anyMatchesPrefix(values, prefix) {
+				continue
+			}
+			for _, value := range keywordValues(values) {
+				counts[value]++
+			}
+		}
+
+		buckets := topBuckets(counts, suggestionCandidates)
+		suggestions = appendSuggestions(suggestions, suggestionField.suggestionType, buckets, prefix, size)
+	}
+
+	return suggestions, nil
+}
+
+// anyMatchesPrefix reports whether any of the values matches the prefix
+func anyMatchesPrefix(values []string, prefix string) bool {
+	for _, value := range values {
+		if matchesPrefix(value, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// likeTerm is a term of a field of the job the similar jobs are searched for, with its tf-idf
+type likeTerm struct {
+	field string
+	term  string
+	score float64
+}
+
+// SimilarJobs returns up to size jobs similar to the job like the more_like_this query does:
+// the terms of the job with the highest tf-idf are selected and the other jobs are scored
+// with them by BM25. The job itself is not returned.
+func (client *MemoryClient) SimilarJobs(ctx context.Context, jobID int32, size int) ([]*JobHit, error) {
+	hits := []*JobHit{}
+
+	client.mutex.RLock()
+	defer client.mutex.RUnlock()
+
+	document, ok := client.documents[strconv.Itoa(int(jobID))]
+	if !ok {
+		// more_like_this does not match any job if the liked job is not indexed
+		return hits, nil
+	}
+
+	stats := client.fieldStats()
+	terms := client.likeTerms(document, stats)
+	queries := map[string]*fieldQuery{}
+	for _, term := range terms {
+		query, ok := queries[term.field]
+		if !ok {
+			query = &fieldQuery{
+				field: term.field,
+				stats: stats[term.field],
+			}
+			queries[term.field] = query
+		}
+		query.terms = append(query.terms, termQuery{
+			term:       term.term,
+			expansions: map[string]float64{term.term: 1},
+			idf:        query.stats.idf(query.stats.docFreq[term.term]),
+		})
+	}
+	minimumShouldMatch := len(terms) * similarMinimumShouldMatch / 100
+
+	sequences := map[*JobHit]int{}
+	for _, other := range client.documents {
+		if other == document {
+			continue
+		}
+
+		score := 0.0
+		matched := 0
+		for _, query := range queries {
+			match := query.match(other)
+			score += match.score
+			matched += len(match.terms)
+		}
+		if score == 0 || matched < minimumShouldMatch {
+			continue
+		}
+
+		hit := &JobHit{
+			Job:   other.job,
+			Score: score,
+		}
+		hits = append(hits, hit)
+		sequences[hit] = other.sequence
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return sequences[hits[i]] < sequences[hits[j]]
+	})
+	if len(hits) > size {
+		hits = hits[:size]
+	}
+
+	return hits, nil
+}
+
+// likeTerms selects up to similarMaxQueryTerms terms of the similar fields of the document
+// with the highest tf-idf, the idf is the classic one of lucene used by more_like_this
+func (client *MemoryClient) likeTerms(document *memoryDocument, stats map[string]*fieldStats) []likeTerm {
+	var terms []likeTerm
+	numDocs := float64(len(client.documents))
+	for _, field := range similarFields {
+		frequencies := map[string]int{}
+		for _, t := range document.fields[field] {
+			frequencies[t.term]++
+		}
+
+		for term, frequency := range frequencies {
+			docFreq := stats[field].docFreq[term]
+			if frequency < similarMinTermFreq || docFreq < similarMinDocFreq {
+				continue
+			}
+			idf := 1 + math.Log((numDocs+1)/float64(docFreq+1))
+			terms = append(terms, likeTerm{
+				field: field,
+				term:  term,
+				score: float64(frequency) * idf,
+			})
+		}
+	}
+
+	sort.Slice(terms, func(i, j int) bool {
+		if terms[i].score != terms[j].score {
+			return terms[i].score > terms[j].score
+		}
+		if terms[i].field != terms[j].field {
+			return terms[i].field < terms[j].field
+		}
+		return terms[i].term < terms[j].term
+	})
+	if len(terms) > similarMaxQueryTerms {
+		terms = terms[:similarMaxQueryTerms]
+	}
+
+	return terms
+}
@@ -0,0 +1,189 @@
+package esearch
+
+import (
+	"context"
+	"database/sql"
+	"github.com/aalug/job-finder-go/internal/db/mock"
+	"github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestMemorySynonyms(t *testing.T) {
+	ctx := context.Background()
+	client := NewMemoryClient()
+
+	err := client.EnsureJobsIndex(ctx, []string{"golang, go", "k8s => kubernetes", "machine learning, ml"})
+	require.NoError(t, err)
+
+	titles := []string{"Go Developer", "Kubernetes Administrator", "Machine Learning Engineer", "K8s Operator"}
+	for i, title := range titles {
+		err = client.IndexJobAsDocument(i+1, Job{ID: int32(i + 1), Title: title})
+		require.NoError(t, err)
+	}
+
+	testCases := []struct {
+		query  string
+		jobIDs []int32
+	}{
+		{"golang", []int32{1}},
+		{"k8s", []int32{2}},
+		{"ml", []int32{3}},
+		{"machine learning", []int32{3}},
+		// the explicit mapping replaces k8s only in the query
+		{"kubernetes", []int32{2}},
+	}
+
+	for _, tc := range testCases {
+		result, err := client.SearchJobs(ctx, tc.query, JobFilters{}, 1, 10, false)
+		require.NoError(t, err)
+
+		var jobIDs []int32
+		for _, job := range result.Jobs {
+			jobIDs = append(jobIDs, job.ID)
+		}
+		require.Equal(t, tc.jobIDs, jobIDs, tc.query)
+	}
+
+	err = client.EnsureJobsIndex(ctx, []string{"k8s =>"})
+	require.Error(t, err)
+}
+
+func TestMemoryReindex(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+
+	jobsFromDB := []db.ListAllJobsForESRow{
+		{
+			ID:             1,
+			Title:          "Go Developer",
+			EmploymentType: db.EmploymentTypeFullTime,
+			Latitude:       sql.NullFloat64{Float64: 52.52, Valid: true},
+			Longitude:      sql.NullFloat64{Float64: 13.405, Valid: true},
+		},
+		{
+			ID:    2,
+			Title: "Data Analyst",
+		},
+	}
+	store.EXPECT().
+		ListAllJobsForES(gomock.Any()).
+		Times(2).
+		Return(jobsFromDB, nil)
+	store.EXPECT().
+		ListAllJobSkillsByJobID(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return([]string{"Go"}, nil)
+
+	ctx := context.Background()
+	client := NewMemoryClient()
+	err := client.EnsureJobsIndex(ctx, nil)
+	require.NoError(t, err)
+	// the jobs that are not in the db are removed
+	err = client.IndexJobAsDocument(3, Job{ID: 3, Title: "Removed"})
+	require.NoError(t, err)
+
+	result, err := client.Reindex(ctx, store, []string{"golang, go"})
+	require.NoError(t, err)
+	require.Equal(t, ReindexResult{
+		Index:          "jobs_v2",
+		Jobs:           2,
+		Documents:      2,
+		DeletedIndices: []string{"jobs_v1"},
+	}, result)
+
+	documents, err := client.ListDocumentJobIDs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int32{"1": 1, "2": 2}, documents)
+
+	job := client.QueryJobsByDocumentID(1)
+	require.NotNil(t, job)
+	require.Equal(t, string(db.EmploymentTypeFullTime), job.EmploymentType)
+	require.Equal(t, []string{"Go"}, job.JobSkills)
+	require.Equal(t, &GeoPoint{Lat: 52.52, Lon: 13.405}, job.LocationPoint)
+
+	// the synonyms are replaced as well
+	search, err := client.SearchJobs(ctx, "golang", JobFilters{}, 1, 10, false)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), search.Total)
+
+	result, err = client.Reindex(ctx, store, nil)
+	require.NoError(t, err)
+	require.Equal(t, "jobs_v3", result.Index)
+	require.Equal(t, []string{"jobs_v2"}, result.DeletedIndices)
+}
+
+func TestMemoryUpdateJobDocument(t *testing.T) {
+	client := NewMemoryClient()
+
+	err := client.UpdateJobDocument("1", Job{ID: 1})
+	require.Error(t, err)
+
+	err = client.IndexJobAsDocument(1, Job{ID: 1, Title: "Go Developer"})
+	require.NoError(t, err)
+	err = client.UpdateJobDocument("1", Job{ID: 1, Title: "Rust Developer"})
+	require.NoError(t, err)
+	require.Equal(t, "Rust Developer", client.QueryJobsByDocumentID(1).Title)
+}
+
+func TestMemoryHighlightEscapesHTML(t *testing.T) {
+	client := NewMemoryClient()
+
+	err := client.IndexJobAsDocument(1, Job{
+		ID:          1,
+		Title:       "Go Developer",
+		Description: `<script>alert("go")</script> & more`,
+	})
+	require.NoError(t, err)
+
+	result, err := client.SearchJobs(context.Background(), "go", JobFilters{}, 1, 10, false)
+	require.NoError(t, err)
+	require.Len(t, result.Jobs, 1)
+	require.Equal(t, []string{"<em>Go</em> Developer"}, result.Jobs[0].Highlights["title"])
+	require.Equal(t, []string{"&lt;script&gt;alert(&#34;<em>go</em>&#34;)&lt;/script&gt; &amp; more"},
+		result.Jobs[0].Highlights["description"])
+}
+
+func TestMemorySearchJobsResultWindow(t *testing.T) {
+	client := NewMemoryClient()
+
+	_, err := client.SearchJobs(context.Background(), "go", JobFilters{}, 1001, 10, false)
+	require.Error(t, err)
+
+	result, err := client.SearchJobs(context.Background(), "go", JobFilters{}, 1000, 10, false)
+	require.NoError(t, err)
+	require.Empty(t, result.Jobs)
+}
+
+func TestMemorySimilarJobs(t *testing.T) {
+	client := NewMemoryClient()
+
+	jobs := []Job{
+		{ID: 1, Title: "Go Developer", Description: "Building payment services.", JobSkills: []string{"Go", "PostgreSQL", "Docker"}},
+		{ID: 2, Title: "Senior Go Developer", Description: "Building payment APIs.", JobSkills: []string{"Go", "Docker"}},
+		{ID: 3, Title: "Go Engineer", Description: "Reports.", JobSkills: []string{"Go"}},
+		{ID: 4, Title: "Accountant", Description: "Payments and invoices.", JobSkills: []string{"Excel"}},
+	}
+	for _, job := range jobs {
+		err := client.IndexJobAsDocument(int(job.ID), job)
+		require.NoError(t, err)
+	}
+
+	hits, err := client.SimilarJobs(context.Background(), 1, 10)
+	require.NoError(t, err)
+
+	var jobIDs []int32
+	for _, hit := range hits {
+		jobIDs = append(jobIDs, hit.ID)
+		require.Greater(t, hit.Score, 0.0)
+	}
+	// the accountant shares only the stemmed payment, less than 30% of the terms
+	require.Equal(t, []int32{2, 3}, jobIDs)
+
+	hits, err = client.SimilarJobs(context.Background(), 1, 1)
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	require.Equal(t, int32(2), hits[0].ID)
+}
@@ -8,6 +8,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	db "github.com/aalug/job-finder-go/internal/db/sqlc"
 	esearch "github.com/aalug/job-finder-go/internal/esearch"
 	gomock "github.com/golang/mock/gomock"
 )
@@ -35,6 +36,21 @@ func (m *MockESearchClient) EXPECT() *MockESearchClientMockRecorder {
 	return m.recorder
 }
 
+// Autocomplete mocks base method.
+func (m *MockESearchClient) Autocomplete(arg0 context.Context, arg1 string, arg2 int) ([]esearch.Suggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Autocomplete", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]esearch.Suggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Autocomplete indicates an expected call of Autocomplete.
+func (mr *MockESearchClientMockRecorder) Autocomplete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Autocomplete", reflect.TypeOf((*MockESearchClient)(nil).Autocomplete), arg0, arg1, arg2)
+}
+
 // DeleteJobDocument mocks base method.
 func (m *MockESearchClient) DeleteJobDocument(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -49,19 +65,18 @@ func (mr *MockESearchClientMockRecorder) DeleteJobDocument(arg0 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteJobDocument", reflect.TypeOf((*MockESearchClient)(nil).DeleteJobDocument), arg0)
 }
 
-// GetDocumentIDByJobID mocks base method.
-func (m *MockESearchClient) GetDocumentIDByJobID(arg0 int) (string, error) {
+// EnsureJobsIndex mocks base method.
+func (m *MockESearchClient) EnsureJobsIndex(arg0 context.Context, arg1 []string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDocumentIDByJobID", arg0)
-	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "EnsureJobsIndex", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetDocumentIDByJobID indicates an expected call of GetDocumentIDByJobID.
-func (mr *MockESearchClientMockRecorder) GetDocumentIDByJobID(arg0 interface{}) *gomock.Call {
+// EnsureJobsIndex indicates an expected call of EnsureJobsIndex.
+func (mr *MockESearchClientMockRecorder) EnsureJobsIndex(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDocumentIDByJobID", reflect.TypeOf((*MockESearchClient)(nil).GetDocumentIDByJobID), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureJobsIndex", reflect.TypeOf((*MockESearchClient)(nil).EnsureJobsIndex), arg0, arg1)
 }
 
 // IndexJobAsDocument mocks base method.
@@ -92,6 +107,21 @@ func (mr *MockESearchClientMockRecorder) IndexJobsAsDocuments(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexJobsAsDocuments", reflect.TypeOf((*MockESearchClient)(nil).IndexJobsAsDocuments), arg0)
 }
 
+// ListDocumentJobIDs mocks base method.
+func (m *MockESearchClient) ListDocumentJobIDs(arg0 context.Context) (map[string]int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDocumentJobIDs", arg0)
+	ret0, _ := ret[0].(map[string]int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDocumentJobIDs indicates an expected call of ListDocumentJobIDs.
+func (mr *MockESearchClientMockRecorder) ListDocumentJobIDs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDocumentJobIDs", reflect.TypeOf((*MockESearchClient)(nil).ListDocumentJobIDs), arg0)
+}
+
 // QueryJobsByDocumentID mocks base method.
 func (m *MockESearchClient) QueryJobsByDocumentID(arg0 int) *esearch.Job {
 	m.ctrl.T.Helper()
@@ -106,19 +136,49 @@ func (mr *MockESearchClientMockRecorder) QueryJobsByDocumentID(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryJobsByDocumentID", reflect.TypeOf((*MockESearchClient)(nil).QueryJobsByDocumentID), arg0)
 }
 
+// Reindex mocks base method.
+func (m *MockESearchClient) Reindex(arg0 context.Context, arg1 db.Store, arg2 []string) (esearch.ReindexResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reindex", arg0, arg1, arg2)
+	ret0, _ := ret[0].(esearch.ReindexResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reindex indicates an expected call of Reindex.
+func (mr *MockESearchClientMockRecorder) Reindex(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reindex", reflect.TypeOf((*MockESearchClient)(nil).Reindex), arg0, arg1, arg2)
+}
+
 // SearchJobs mocks base method.
-func (m *MockESearchClient) SearchJobs(arg0 context.Context, arg1 string, arg2, arg3 int32) ([]*esearch.Job, error) {
+func (m *MockESearchClient) SearchJobs(arg0 context.Context, arg1 string, arg2 esearch.JobFilters, arg3, arg4 int32, arg5 bool) (esearch.SearchJobsResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchJobs", arg0, arg1, arg2, arg3)
-	ret0, _ := ret[0].([]*esearch.Job)
+	ret := m.ctrl.Call(m, "SearchJobs", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(esearch.SearchJobsResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SearchJobs indicates an expected call of SearchJobs.
-func (mr *MockESearchClientMockRecorder) SearchJobs(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockESearchClientMockRecorder) SearchJobs(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchJobs", reflect.TypeOf((*MockESearchClient)(nil).SearchJobs), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// SimilarJobs mocks base method.
+func (m *MockESearchClient) SimilarJobs(arg0 context.Context, arg1 int32, arg2 int) ([]*esearch.JobHit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SimilarJobs", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*esearch.JobHit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SimilarJobs indicates an expected call of SimilarJobs.
+func (mr *MockESearchClientMockRecorder) SimilarJobs(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchJobs", reflect.TypeOf((*MockESearchClient)(nil).SearchJobs), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimilarJobs", reflect.TypeOf((*MockESearchClient)(nil).SimilarJobs), arg0, arg1, arg2)
 }
 
 // UpdateJobDocument mocks base method.
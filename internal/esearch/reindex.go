@@ -0,0 +1,313 @@
+package esearch
+
+import (
+	"context"
+	"fmt"
+	"github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/elastic/go-elasticsearch/v8/esutil"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// jobsIndexPrefix is the prefix of the versioned jobs indices, jobs_v1, jobs_v2, ...
+// The jobs alias points to the current one, all the reads and writes go through the alias.
+const jobsIndexPrefix = "jobs_v"
+
+// reindexReplayMargin is subtracted from the start of the reindex when the search outbox entries are replayed,
+// so the entries are not missed because of the clock difference between the app and the db.
+// Replaying more entries is harmless.
+const reindexReplayMargin = time.Minute
+
+// ReindexResult is the result of rebuilding the jobs index
+type ReindexResult struct {
+	// Index is the new index the jobs alias points to
+	Index string
+	// Jobs is the number of the jobs loaded from the db
+	Jobs int
+	// Documents is the number of the documents in the new index
+	Documents int64
+	// DeletedIndices are the previous indices deleted after the alias was moved
+	DeletedIndices []string
+	// ReplayedEntries is the number of the search outbox entries created during the reindex
+	// that are applied again to the new index
+	ReplayedEntries int64
+}
+
+type jobsIndex struct {
+	Aliases map[string]interface{} `json:"aliases"`
+}
+
+type countResponse struct {
+	Count int64 `json:"count"`
+}
+
+// Reindex rebuilds the jobs index from the db. It creates the next versioned index with
+// the current definition, indexes all the jobs into it and checks that it contains
+// as many documents as there are jobs in the db. Then it atomically moves the jobs alias
+// to the new index and deletes the previous indices. If the check fails, the new index is deleted
+// and the alias is left untouched, so the search keeps working during and after a failed reindex.
+// The jobs changed during the reindex are written to the previous index through the alias,
+// so the search outbox entries created since the reindex started are marked as pending again
+// after the alias is moved and the search outbox task applies them to the new index.
+func (client ESClient) Reindex(ctx context.Context, store db.Store, synonyms []string) (ReindexResult, error) {
+	var result ReindexResult
+	startedAt := time.Now().Add(-reindexReplayMargin)
+
+	indices, err := client.getJobsIndices(ctx)
+	if err != nil {
+		return result, err
+	}
+	result.Index = nextJobsIndex(indices)
+
+	err = client.createJobsIndex(ctx, result.Index, synonyms, false)
+	if err != nil {
+		return result, err
+	}
+
+	jobsCtx, err := LoadJobsFromDB(ctx, store)
+	if err != nil {
+		return result, client.abortReindex(ctx, result.Index, err)
+	}
+	jobs := jobsCtx.Value(JobKey).([]Job)
+	result.Jobs = len(jobs)
+
+	err = client.indexJobs(ctx, result.Index, jobs)
+	if err != nil {
+		return result, client.abortReindex(ctx, result.Index, err)
+	}
+
+	result.Documents, err = client.countDocuments(ctx, result.Index)
+	if err != nil {
+		return result, client.abortReindex(ctx, result.Index, err)
+	}
+	if result.Documents != int64(result.Jobs) {
+		err = fmt.Errorf("index %s has %d documents, but there are %d jobs in the db", result.Index, result.Documents, result.Jobs)
+		return result, client.abortReindex(ctx, result.Index, err)
+	}
+
+	err = client.moveJobsAlias(ctx, indices, result.Index)
+	if err != nil {
+		return result, client.abortReindex(ctx, result.Index, err)
+	}
+
+	result.ReplayedEntries, err = store.ReplaySearchOutboxEntries(ctx, startedAt)
+	if err != nil {
+		return result, fmt.Errorf("the alias was moved to %s, but the search outbox entries were not replayed: %w", result.Index, err)
+	}
+
+	for name := range indices {
+		if name != "jobs" {
+			result.DeletedIndices = append(result.DeletedIndices, name)
+		}
+	}
+	sort.Strings(result.DeletedIndices)
+	if len(result.DeletedIndices) > 0 {
+		err = client.deleteIndices(ctx, result.DeletedIndices)
+		if err != nil {
+			return result, fmt.Errorf("the alias was moved to %s, but the previous indices were not deleted: %w", result.Index, err)
+		}
+	}
+
+	log.Printf("Jobs reindexed into %s: %d\n", result.Index, result.Documents)
+	return result, nil
+}
+
+// getJobsIndices gets the jobs indices mapped by their names: the versioned indices
+// and the jobs index created before the versioned ones, if it exists
+func (client ESClient) getJobsIndices(ctx context.Context) (map[string]jobsIndex, error) {
+	response, err := client.client.Indices.Get(
+		[]string{"jobs", jobsIndexPrefix + "*"},
+		client.client.Indices.Get.WithContext(ctx),
+		client.client.Indices.Get.WithIgnoreUnavailable(true),
+		client.client.Indices.Get.WithAllowNoIndices(true),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	indices := map[string]jobsIndex{}
+	err = decodeResponse(response, &indices)
+	if err != nil {
+		return nil, err
+	}
+
+	return indices, nil
+}
+
+// nextJobsIndex returns the name of the versioned index after the existing ones
+func nextJobsIndex(indices map[string]jobsIndex) string {
+	last := 0
+	for name := range indices {
+		if !strings.HasPrefix(name, jobsIndexPrefix) {
+			continue
+		}
+		version, err := strconv.Atoi(strings.TrimPrefix(name, jobsIndexPrefix))
+		if err != nil {
+			continue
+		}
+		if version > last {
+			last = version
+		}
+	}
+
+	return jobsIndexPrefix + strconv.Itoa(last+1)
+}
+
+// indexJobs indexes the jobs into the index with the bulk API,
+// the job IDs are the document IDs
+func (client ESClient) indexJobs(ctx context.Context, index string, jobs []Job) error {
+	bulkIndexer, err := esutil.NewBulkIndexer(esutil.BulkIndexerConfig{
+		Index:      index,
+		Client:     client.client,
+		NumWorkers: 5,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, document := range jobs {
+		body, err := readerToReadSeeker(esutil.NewJSONReader(document))
+		if err != nil {
+			return err
+		}
+		err = bulkIndexer.Add(
+			ctx,
+			esutil.BulkIndexerItem{
+				Action:     "index",
+				DocumentID: strconv.Itoa(int(document.ID)),
+				Body:       body,
+			},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = bulkIndexer.Close(ctx)
+	if err != nil {
+		return err
+	}
+	biStats := bulkIndexer.Stats()
+	log.Printf("Jobs indexed on Elasticsearch: %d \n", biStats.NumIndexed)
+	return nil
+}
+
+// countDocuments refreshes the index and counts its documents
+func (client ESClient) countDocuments(ctx context.Context, index string) (int64, error) {
+	refreshResponse, err := client.client.Indices.Refresh(
+		client.client.Indices.Refresh.WithContext(ctx),
+		client.client.Indices.Refresh.WithIndex(index),
+	)
+	if err != nil {
+		return 0, err
+	}
+	refreshResponse.Body.Close()
+	if refreshResponse.IsError() {
+		return 0, fmt.Errorf("failed to refresh the index %s: %s", index, refreshResponse.String())
+	}
+
+	response, err := client.client.Count(
+		client.client.Count.WithContext(ctx),
+		client.client.Count.WithIndex(index),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	var count countResponse
+	err = decodeResponse(response, &count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count.Count, nil
+}
+
+// moveJobsAlias atomically moves the jobs alias from the indices to the new index
+func (client ESClient) moveJobsAlias(ctx context.Context, indices map[string]jobsIndex, newIndex string) error {
+	body := map[string]interface{}{
+		"actions": aliasActions(indices, newIndex),
+	}
+	response, err := client.client.Indices.UpdateAliases(
+		esutil.NewJSONReader(body),
+		client.client.Indices.UpdateAliases.WithContext(ctx),
+	)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.IsError() {
+		return fmt.Errorf("failed to move the jobs alias to %s: %s", newIndex, response.String())
+	}
+
+	return nil
+}
+
+// aliasActions returns the actions that move the jobs alias to the new index. The jobs index
+// created before the versioned ones is removed in the same request, so the alias can take its name.
+func aliasActions(indices map[string]jobsIndex, newIndex string) []interface{} {
+	names := make([]string, 0, len(indices))
+	for name := range indices {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	actions := []interface{}{}
+	for _, name := range names {
+		if name == "jobs" {
+			actions = append(actions, map[string]interface{}{
+				"remove_index": map[string]interface{}{
+					"index": name,
+				},
+			})
+			continue
+		}
+		if _, ok := indices[name].Aliases["jobs"]; ok {
+			actions = append(actions, map[string]interface{}{
+				"remove": map[string]interface{}{
+					"index": name,
+					"alias": "jobs",
+				},
+			})
+		}
+	}
+
+	return append(actions, map[string]interface{}{
+		"add": map[string]interface{}{
+			"index": newIndex,
+			"alias": "jobs",
+		},
+	})
+}
+
+// abortReindex deletes the new index after the reindex failed and returns the error
+func (client ESClient) abortReindex(ctx context.Context, index string, err error) error {
+	deleteErr := client.deleteIndices(ctx, []string{index})
+	if deleteErr != nil {
+		return fmt.Errorf("%w (the index %s was not deleted: %v)", err, index, deleteErr)
+	}
+
+	return err
+}
+
+// deleteIndices deletes the indices
+func (client ESClient) deleteIndices(ctx context.Context, indices []string) error {
+	response, err := client.client.Indices.Delete(
+		indices,
+		client.client.Indices.Delete.WithContext(ctx),
+	)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.IsError() {
+		return fmt.Errorf("failed to delete the indices %s: %s", strings.Join(indices, ", "), response.String())
+	}
+
+	return nil
+}
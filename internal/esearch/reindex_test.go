@@ -0,0 +1,50 @@
+package esearch
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNextJobsIndex(t *testing.T) {
+	require.Equal(t, "jobs_v1", nextJobsIndex(map[string]jobsIndex{}))
+	require.Equal(t, "jobs_v1", nextJobsIndex(map[string]jobsIndex{"jobs": {}}))
+	require.Equal(t, "jobs_v3", nextJobsIndex(map[string]jobsIndex{
+		"jobs_v2":    {},
+		"jobs_v1":    {},
+		"jobs_vtest": {},
+	}))
+	require.Equal(t, "jobs_v11", nextJobsIndex(map[string]jobsIndex{"jobs_v10": {}, "jobs_v9": {}}))
+}
+
+func TestAliasActions(t *testing.T) {
+	actions := aliasActions(map[string]jobsIndex{}, "jobs_v1")
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"add": map[string]interface{}{"index": "jobs_v1", "alias": "jobs"},
+		},
+	}, actions)
+
+	actions = aliasActions(map[string]jobsIndex{
+		"jobs_v1": {},
+		"jobs_v2": {Aliases: map[string]interface{}{"jobs": map[string]interface{}{}}},
+	}, "jobs_v3")
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"remove": map[string]interface{}{"index": "jobs_v2", "alias": "jobs"},
+		},
+		map[string]interface{}{
+			"add": map[string]interface{}{"index": "jobs_v3", "alias": "jobs"},
+		},
+	}, actions)
+
+	// the jobs index created before the versioned indices is replaced by the alias
+	actions = aliasActions(map[string]jobsIndex{"jobs": {}}, "jobs_v1")
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"remove_index": map[string]interface{}{"index": "jobs"},
+		},
+		map[string]interface{}{
+			"add": map[string]interface{}{"index": "jobs_v1", "alias": "jobs"},
+		},
+	}, actions)
+}
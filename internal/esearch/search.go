@@ -5,18 +5,25 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/aalug/job-finder-go/internal/db/sqlc"
 	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
 	"github.com/elastic/go-elasticsearch/v8/esutil"
+	"time"
 )
 
 type ESearchClient interface {
-	SearchJobs(ctx context.Context, query string, page, pageSize int32) ([]*Job, error)
-	GetDocumentIDByJobID(jobID int) (string, error)
+	SearchJobs(ctx context.Context, query string, filters JobFilters, page, pageSize int32, explain bool) (SearchJobsResult, error)
 	IndexJobAsDocument(documentID int, job Job) error
 	IndexJobsAsDocuments(ctx context.Context) error
 	UpdateJobDocument(documentID string, updatedJob Job) error
 	DeleteJobDocument(documentID string) error
+	ListDocumentJobIDs(ctx context.Context) (map[string]int32, error)
 	QueryJobsByDocumentID(documentID int) *Job
+	EnsureJobsIndex(ctx context.Context, synonyms []string) error
+	Reindex(ctx context.Context, store db.Store, synonyms []string) (ReindexResult, error)
+	Autocomplete(ctx context.Context, prefix string, size int) ([]Suggestion, error)
+	SimilarJobs(ctx context.Context, jobID int32, size int) ([]*JobHit, error)
 }
 
 type ESClient struct {
@@ -29,9 +36,17 @@ func NewClient(client *elasticsearch.Client) ESearchClient {
 	}
 }
 
-// SearchJobs searches for jobs in the jobs index
-func (client ESClient) SearchJobs(ctx context.Context, query string, page, pageSize int32) ([]*Job, error) {
-	var jobs []*Job
+// SearchJobs searches for jobs in the jobs index.
+// Jobs have to match the query and all the filters that are set.
+// The result contains a page of the jobs with their scores and highlighted fragments
+// of the matched fields, the total number of the matching jobs and the facets
+// of all the matching jobs. If explain is true, the jobs contain the explanation of their scores.
+func (client ESClient) SearchJobs(ctx context.Context, query string, filters JobFilters, page, pageSize int32, explain bool) (SearchJobsResult, error) {
+	result := SearchJobsResult{
+		Jobs:     []*JobHit{},
+		Page:     page,
+		PageSize: pageSize,
+	}
 
 	var searchBuffer bytes.Buffer
 	search := map[string]interface{}{
@@ -61,12 +76,17 @@ func (client ESClient) SearchJobs(ctx context.Context, query string, page, pageS
 						},
 					},
 				},
+				"minimum_should_match": 1,
+				"filter":               filters.terms(),
 			},
 		},
+		"aggs":      facetAggregations(),
+		"highlight": highlight(),
+		"explain":   explain,
 	}
 	err := json.NewEncoder(&searchBuffer).Encode(search)
 	if err != nil {
-		return jobs, err
+		return result, err
 	}
 
 	response, err := client.client.Search(
@@ -77,58 +97,205 @@ func (client ESClient) SearchJobs(ctx context.Context, query string, page, pageS
 		client.client.Search.WithPretty(),
 	)
 	if err != nil {
-		return jobs, err
+		return result, err
 	}
-	defer response.Body.Close()
 
-	var searchResponse = SearchResponse{}
-	err = json.NewDecoder(response.Body).Decode(&searchResponse)
+	searchResponse, err := decodeSearchResponse(response)
 	if err != nil {
-		return jobs, err
+		return result, err
 	}
 
-	if searchResponse.Hits.Total.Value > 0 {
-		for _, job := range searchResponse.Hits.Hits {
-			jobs = append(jobs, job.Source)
+	for _, hit := range searchResponse.Hits.Hits {
+		if hit.Source == nil {
+			continue
 		}
+		result.Jobs = append(result.Jobs, &JobHit{
+			Job:         *hit.Source,
+			Score:       hit.Score,
+			Highlights:  hit.Highlight,
+			Explanation: hit.Explanation,
+		})
 	}
-	return jobs, nil
+	result.Total = searchResponse.Hits.Total.Value
+	result.TotalPages = int32((result.Total + int64(pageSize) - 1) / int64(pageSize))
+	result.Facets = searchResponse.facets()
+
+	return result, nil
 }
 
-// GetDocumentIDByJobID gets the document ID of a job by job ID.
-func (client ESClient) GetDocumentIDByJobID(jobID int) (string, error) {
-	// Create a Term Query to match the job ID field with the provided jobID.
+// ListDocumentJobIDs lists the job IDs of all documents in the jobs index,
+// mapped by the document ID. It uses the scroll API, so it works for indexes
+// of any size.
+func (client ESClient) ListDocumentJobIDs(ctx context.Context) (map[string]int32, error) {
 	query := map[string]interface{}{
+		"size":    1000,
+		"_source": []string{"id"},
 		"query": map[string]interface{}{
-			"term": map[string]interface{}{
-				"id": jobID,
-			},
+			"match_all": map[string]interface{}{},
 		},
 	}
 
-	// Perform the search request with the Term Query.
 	response, err := client.client.Search(
+		client.client.Search.WithContext(ctx),
 		client.client.Search.WithIndex("jobs"),
 		client.client.Search.WithBody(esutil.NewJSONReader(query)),
+		client.client.Search.WithScroll(time.Minute),
 	)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	documents := make(map[string]int32)
+	var scrollID string
+	for {
+		searchResponse, err := decodeSearchResponse(response)
+		if err != nil {
+			return nil, err
+		}
+		scrollID = searchResponse.ScrollID
+
+		if len(searchResponse.Hits.Hits) == 0 {
+			break
+		}
+		for _, hit := range searchResponse.Hits.Hits {
+			if hit.Source != nil {
+				documents[hit.ID] = hit.Source.ID
+			}
+		}
+
+		response, err = client.client.Scroll(
+			client.client.Scroll.WithContext(ctx),
+			client.client.Scroll.WithScrollID(scrollID),
+			client.client.Scroll.WithScroll(time.Minute),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if scrollID != "" {
+		response, err = client.client.ClearScroll(
+			client.client.ClearScroll.WithContext(ctx),
+			client.client.ClearScroll.WithScrollID(scrollID),
+		)
+		if err != nil {
+			return nil, err
+		}
+		response.Body.Close()
 	}
+
+	return documents, nil
+}
+
+// decodeSearchResponse decodes and closes the body of the search or scroll response
+func decodeSearchResponse(response *esapi.Response) (SearchResponse, error) {
+	var searchResponse SearchResponse
+	err := decodeResponse(response, &searchResponse)
+	return searchResponse, err
+}
+
+// decodeResponse decodes the body of the response into v and closes it
+func decodeResponse(response *esapi.Response, v interface{}) error {
 	defer response.Body.Close()
 
-	// Parse the search response to get the documentID.
-	var searchResponse = SearchResponse{}
-	err = json.NewDecoder(response.Body).Decode(&searchResponse)
-	if err != nil {
-		return "", err
+	if response.IsError() {
+		return fmt.Errorf("elasticsearch request failed: %s", response.String())
+	}
+
+	return json.NewDecoder(response.Body).Decode(v)
+}
+
+// highlightedFields are the fields matched by the search query
+var highlightedFields = []string{"title", "description", "requirements", "job_skills", "location"}
+
+const (
+	// highlightFragmentSize is the size of the highlighted fragments in characters
+	highlightFragmentSize = 150
+	// highlightFragments is the max number of the highlighted fragments of a field
+	highlightFragments = 3
+)
+
+// highlight returns the highlighting of the fields matched by the search query,
+// matched terms are wrapped in <em> tags. The fields are written by employers,
+// so the rest of the text is HTML escaped and the <em> tags are the only HTML of the fragments
+func highlight() map[string]interface{} {
+	fields := map[string]interface{}{}
+	for _, field := range highlightedFields {
+		fields[field] = map[string]interface{}{}
+	}
+
+	return map[string]interface{}{
+		"encoder":             "html",
+		"pre_tags":            []string{"<em>"},
+		"post_tags":           []string{"</em>"},
+		"fragment_size":       highlightFragmentSize,
+		"number_of_fragments": highlightFragments,
+		"fields":              fields,
+	}
+}
+
+// terms returns the term, range and geo distance queries of the filters that are set
+func (filters JobFilters) terms() []interface{} {
+	fields := map[string]string{
+		"employment_type": filters.EmploymentType,
+		"seniority_level": filters.SeniorityLevel,
+		"remote_policy":   filters.RemotePolicy,
+		"salary_currency": filters.SalaryCurrency,
+		"salary_period":   filters.SalaryPeriod,
+		"industry":        filters.Industry,
+		"location":        filters.Location,
+		"company_name":    filters.CompanyName,
+	}
+
+	terms := []interface{}{}
+	for field, value := range fields {
+		if value == "" {
+			continue
+		}
+		terms = append(terms, map[string]interface{}{
+			"term": map[string]interface{}{
+				field + ".keyword": value,
+			},
+		})
 	}
 
-	// Extract the documentID from the search response.
-	if searchResponse.Hits.Total.Value > 0 {
-		documentID := searchResponse.Hits.Hits[0].ID
-		return documentID, nil
+	// the same as in the database, the whole salary range has to be within the filters
+	if filters.SalaryMin != 0 {
+		terms = append(terms, map[string]interface{}{
+			"range": map[string]interface{}{
+				"salary_min_annual": map[string]interface{}{
+					"gte": filters.SalaryMin,
+				},
+			},
+		})
+	}
+	if filters.SalaryMax != 0 {
+		terms = append(terms, map[string]interface{}{
+			"range": map[string]interface{}{
+				"salary_max_annual": map[string]interface{}{
+					"lte": filters.SalaryMax,
+				},
+			},
+		})
+	}
+
+	if filters.Near != nil {
+		terms = append(terms, map[string]interface{}{
+			"geo_distance": map[string]interface{}{
+				"distance":       fmt.Sprintf("%gkm", filters.RadiusKm),
+				"location_point": filters.Near,
+			},
+		})
+	}
+
+	// every skill has to be required by the job
+	for _, skill := range filters.Skills {
+		terms = append(terms, map[string]interface{}{
+			"term": map[string]interface{}{
+				"job_skills.keyword": skill,
+			},
+		})
 	}
 
-	// Return an error if no document is found with the given jobID.
-	return "", fmt.Errorf("no document found with jobID: %d", jobID)
+	return terms
 }
@@ -1,6 +1,8 @@
 package esearch
 
 import (
+	"context"
+	"github.com/aalug/job-finder-go/pkg/utils"
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -33,7 +35,7 @@ func TestSearchJobs(t *testing.T) {
 	//require.NoError(t, err)
 
 	//ctx := context.Background()
-	//results, err := client.SearchJobs(ctx, jobs[0].Title, 1, 10)
+	//results, err := client.SearchJobs(ctx, jobs[0].Title, JobFilters{}, 1, 10)
 	//require.NoError(t, err)
 
 	//require.Equal(t, jobs[0].ID, results[0].ID)
@@ -41,13 +43,10 @@ func TestSearchJobs(t *testing.T) {
 	//require.Equal(t, jobs[0].Description, results[0].Description)
 	//require.Equal(t, jobs[0].Location, results[0].Location)
 
-	// GetDocumentIDByJobID tests
-	//_, err = client.GetDocumentIDByJobID(jobID)
-	//require.Error(t, err)
-
-	documentID2, err := client.GetDocumentIDByJobID(9999999999)
-	require.Error(t, err)
-	require.Empty(t, documentID2)
+	result, err := client.SearchJobs(context.Background(), utils.RandomString(10), JobFilters{}, 1, 10, false)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), result.Page)
+	require.Equal(t, int32(10), result.PageSize)
 }
 
 func TestNewClient(t *testing.T) {
@@ -59,3 +58,48 @@ func TestNewClient(t *testing.T) {
 
 	require.Equal(t, c, client.(*ESClient).client, "ESearchClient should contain the same Elasticsearch client")
 }
+
+func TestJobFiltersTerms(t *testing.T) {
+	require.Empty(t, JobFilters{}.terms())
+
+	filters := JobFilters{
+		Near:     &GeoPoint{Lat: 52.52, Lon: 13.405},
+		RadiusKm: 12.5,
+	}
+	terms := filters.terms()
+	require.Len(t, terms, 1)
+	require.Equal(t, map[string]interface{}{
+		"geo_distance": map[string]interface{}{
+			"distance":       "12.5km",
+			"location_point": filters.Near,
+		},
+	}, terms[0])
+
+	// every skill is a separate filter, the job has to require all of them
+	filters = JobFilters{
+		Industry: "IT",
+		Skills:   []string{"Go", "SQL"},
+	}
+	terms = filters.terms()
+	require.Len(t, terms, 3)
+	require.Contains(t, terms, map[string]interface{}{
+		"term": map[string]interface{}{"industry.keyword": "IT"},
+	})
+	require.Contains(t, terms, map[string]interface{}{
+		"term": map[string]interface{}{"job_skills.keyword": "Go"},
+	})
+	require.Contains(t, terms, map[string]interface{}{
+		"term": map[string]interface{}{"job_skills.keyword": "SQL"},
+	})
+}
+
+func TestHighlight(t *testing.T) {
+	// the text of the fragments is escaped, only the tags are HTML
+	require.Equal(t, "html", highlight()["encoder"])
+
+	fields := highlight()["fields"].(map[string]interface{})
+	require.Len(t, fields, len(highlightedFields))
+	for _, field := range highlightedFields {
+		require.Contains(t, fields, field)
+	}
+}
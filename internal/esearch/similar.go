@@ -0,0 +1,72 @@
+package esearch
+
+import (
+	"context"
+	"github.com/elastic/go-elasticsearch/v8/esutil"
+	"strconv"
+)
+
+// parameters of the more_like_this query of the similar jobs. The terms have to occur
+// only once in the job and in one other job, the jobs are too short for the defaults.
+const (
+	similarMinTermFreq        = 1
+	similarMinDocFreq         = 1
+	similarMaxQueryTerms      = 25
+	similarMinimumShouldMatch = 30
+)
+
+// similarFields are the fields the similar jobs are found by
+var similarFields = []string{"title", "description", "job_skills"}
+
+// SimilarJobs returns up to size jobs similar to the job, ordered by their scores.
+// They are found with the more_like_this query over the title, the description and the skills:
+// the terms of the job with the highest tf-idf are selected and the other jobs are searched for them.
+// The job itself is not returned.
+func (client ESClient) SimilarJobs(ctx context.Context, jobID int32, size int) ([]*JobHit, error) {
+	hits := []*JobHit{}
+
+	query := map[string]interface{}{
+		"size": size,
+		"query": map[string]interface{}{
+			"more_like_this": map[string]interface{}{
+				"fields": similarFields,
+				"like": []interface{}{
+					map[string]interface{}{
+						"_index": "jobs",
+						"_id":    strconv.Itoa(int(jobID)),
+					},
+				},
+				"min_term_freq":        similarMinTermFreq,
+				"min_doc_freq":         similarMinDocFreq,
+				"max_query_terms":      similarMaxQueryTerms,
+				"minimum_should_match": strconv.Itoa(similarMinimumShouldMatch) + "%",
+			},
+		},
+	}
+
+	response, err := client.client.Search(
+		client.client.Search.WithContext(ctx),
+		client.client.Search.WithIndex("jobs"),
+		client.client.Search.WithBody(esutil.NewJSONReader(query)),
+	)
+	if err != nil {
+		return hits, err
+	}
+
+	searchResponse, err := decodeSearchResponse(response)
+	if err != nil {
+		return hits, err
+	}
+
+	for _, hit := range searchResponse.Hits.Hits {
+		if hit.Source == nil {
+			continue
+		}
+		hits = append(hits, &JobHit{
+			Job:   *hit.Source,
+			Score: hit.Score,
+		})
+	}
+
+	return hits, nil
+}
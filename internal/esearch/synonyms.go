@@ -0,0 +1,38 @@
+package esearch
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// LoadSynonyms loads the synonyms of the job search from the file in the Solr format,
+// one rule per line, e.g. "golang, go" or "k8s => kubernetes".
+// Empty lines and lines starting with # are skipped. Empty path means no synonyms.
+func LoadSynonyms(path string) ([]string, error) {
+	synonyms := []string{}
+	if path == "" {
+		return synonyms, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open the synonyms file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		synonyms = append(synonyms, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("cannot read the synonyms file: %w", err)
+	}
+
+	return synonyms, nil
+}
@@ -0,0 +1,35 @@
+package esearch
+
+import (
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadSynonyms(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "synonyms.txt")
+	content := "# comment\ngolang, go\n\n  k8s, kubernetes  \njs => javascript\n"
+	err := os.WriteFile(path, []byte(content), 0o600)
+	require.NoError(t, err)
+
+	synonyms, err := LoadSynonyms(path)
+	require.NoError(t, err)
+	require.Equal(t, []string{"golang, go", "k8s, kubernetes", "js => javascript"}, synonyms)
+}
+
+func TestLoadSynonymsNoFile(t *testing.T) {
+	synonyms, err := LoadSynonyms("")
+	require.NoError(t, err)
+	require.Empty(t, synonyms)
+
+	_, err = LoadSynonyms(filepath.Join(t.TempDir(), "missing.txt"))
+	require.Error(t, err)
+}
+
+func TestLoadSynonymsRepoFile(t *testing.T) {
+	synonyms, err := LoadSynonyms("../../synonyms.txt")
+	require.NoError(t, err)
+	require.Contains(t, synonyms, "golang, go")
+	require.Contains(t, synonyms, "k8s, kubernetes")
+}
@@ -1,18 +1,117 @@
 package esearch
 
+import (
+	"database/sql"
+	"encoding/json"
+)
+
 // === Types for the ES part of the Application ===
 
 type Job struct {
-	ID           int32    `json:"id"`
-	Title        string   `json:"title"`
-	Industry     string   `json:"industry"`
-	CompanyName  string   `json:"company_name"`
-	Description  string   `json:"description"`
-	Location     string   `json:"location"`
-	SalaryMin    int32    `json:"salary_min"`
-	SalaryMax    int32    `json:"salary_max"`
-	Requirements string   `json:"requirements"`
-	JobSkills    []string `json:"job_skills"`
+	ID             int32    `json:"id"`
+	Title          string   `json:"title"`
+	Industry       string   `json:"industry"`
+	CompanyName    string   `json:"company_name"`
+	Description    string   `json:"description"`
+	Location       string   `json:"location"`
+	SalaryMin      int32    `json:"salary_min"`
+	SalaryMax      int32    `json:"salary_max"`
+	Requirements   string   `json:"requirements"`
+	JobSkills      []string `json:"job_skills"`
+	EmploymentType string   `json:"employment_type"`
+	SeniorityLevel string   `json:"seniority_level"`
+	RemotePolicy   string   `json:"remote_policy"`
+	SalaryCurrency string   `json:"salary_currency"`
+	SalaryPeriod   string   `json:"salary_period"`
+	// salaries annualized in the base currency, used for the salary range filters
+	SalaryMinAnnual int32 `json:"salary_min_annual"`
+	SalaryMaxAnnual int32 `json:"salary_max_annual"`
+	// coordinates of the location, nil if the location is not in the gazetteer
+	LocationPoint *GeoPoint `json:"location_point"`
+}
+
+// GeoPoint is a point of the geo_point field
+type GeoPoint struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// NewGeoPoint creates a point from the coordinates of the job,
+// it returns nil if the coordinates are not set
+func NewGeoPoint(latitude, longitude sql.NullFloat64) *GeoPoint {
+	if !latitude.Valid || !longitude.Valid {
+		return nil
+	}
+
+	return &GeoPoint{
+		Lat: latitude.Float64,
+		Lon: longitude.Float64,
+	}
+}
+
+// JobFilters are filters of the job search, empty filters are not applied.
+// SalaryMin and SalaryMax are annual amounts in the base currency.
+// Near with RadiusKm filters the jobs within RadiusKm kilometers of the point.
+// Jobs have to require all the Skills. The filters narrow the facets as well.
+type JobFilters struct {
+	EmploymentType string
+	SeniorityLevel string
+	RemotePolicy   string
+	SalaryCurrency string
+	SalaryPeriod   string
+	SalaryMin      int32
+	SalaryMax      int32
+	Near           *GeoPoint
+	RadiusKm       float64
+	Industry       string
+	Location       string
+	CompanyName    string
+	Skills         []string
+}
+
+// SearchJobsResult is a page of the jobs matching the search
+// with the facets of all the matching jobs
+type SearchJobsResult struct {
+	Jobs       []*JobHit `json:"jobs"`
+	Total      int64     `json:"total"`
+	Page       int32     `json:"page"`
+	PageSize   int32     `json:"page_size"`
+	TotalPages int32     `json:"total_pages"`
+	Facets     Facets    `json:"facets"`
+}
+
+// JobHit is a job matching the search with its relevance score and the highlighted
+// fragments of the matched fields, mapped by the field names
+type JobHit struct {
+	Job
+	Score      float64             `json:"score"`
+	Highlights map[string][]string `json:"highlights,omitempty"`
+	// Explanation is the explanation of the score by elasticsearch, only set in the explain mode
+	Explanation json.RawMessage `json:"explanation,omitempty"`
+}
+
+// Facets are the counts of the matching jobs by the values of the fields
+type Facets struct {
+	Industries []FacetBucket  `json:"industries"`
+	Locations  []FacetBucket  `json:"locations"`
+	Companies  []FacetBucket  `json:"companies"`
+	Skills     []FacetBucket  `json:"skills"`
+	Salaries   []SalaryBucket `json:"salaries"`
+}
+
+// FacetBucket is the number of the matching jobs with the value
+type FacetBucket struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// SalaryBucket is the number of the matching jobs with the annual salary min
+// in the base currency from From (inclusive) to To (exclusive).
+// From or To is nil if the bucket is unbounded.
+type SalaryBucket struct {
+	From  *int32 `json:"from"`
+	To    *int32 `json:"to"`
+	Count int64  `json:"count"`
 }
 
 // === for the Context ===
@@ -33,13 +132,39 @@ type GetResponse struct {
 }
 
 type SearchResponse struct {
-	Hits struct {
+	ScrollID string `json:"_scroll_id"`
+	Hits     struct {
 		Total struct {
 			Value int64 `json:"value"`
 		} `json:"total"`
 		Hits []*struct {
-			Source *Job   `json:"_source"`
-			ID     string `json:"_id"`
+			Source      *Job                `json:"_source"`
+			ID          string              `json:"_id"`
+			Score       float64             `json:"_score"`
+			Highlight   map[string][]string `json:"highlight"`
+			Explanation json.RawMessage     `json:"_explanation"`
 		} `json:"hits"`
 	} `json:"hits"`
+	Aggregations struct {
+		Industries termsAggregation `json:"industries"`
+		Locations  termsAggregation `json:"locations"`
+		Companies  termsAggregation `json:"companies"`
+		Skills     termsAggregation `json:"skills"`
+		Salaries   rangeAggregation `json:"salaries"`
+	} `json:"aggregations"`
+}
+
+type termsAggregation struct {
+	Buckets []struct {
+		Key      string `json:"key"`
+		DocCount int64  `json:"doc_count"`
+	} `json:"buckets"`
+}
+
+type rangeAggregation struct {
+	Buckets []struct {
+		From     *float64 `json:"from"`
+		To       *float64 `json:"to"`
+		DocCount int64    `json:"doc_count"`
+	} `json:"buckets"`
 }
@@ -0,0 +1,172 @@
+package geo
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// earthRadiusKm is the mean radius of the Earth used by the haversine formula
+const earthRadiusKm = 6371.0
+
+// citiesCSV is the bundled offline gazetteer. Cities with the same name
+// are ordered by population, the first one is used when the country is not given.
+//
+//go:embed cities.csv
+var citiesCSV []byte
+
+// City is an entry of the gazetteer
+type City struct {
+	Name        string  `json:"name"`
+	CountryCode string  `json:"country_code"`
+	Country     string  `json:"country"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+}
+
+// String returns the normalized location of the city, e.g. "Berlin, Germany"
+func (city City) String() string {
+	return fmt.Sprintf("%s, %s", city.Name, city.Country)
+}
+
+// countryAliases are the common names of the countries that are not
+// their code or name in the gazetteer
+var countryAliases = map[string]string{
+	"usa":                      "US",
+	"united states of america": "US",
+	"america":                  "US",
+	"uk":                       "GB",
+	"great britain":            "GB",
+	"britain":                  "GB",
+	"england":                  "GB",
+	"scotland":                 "GB",
+	"wales":                    "GB",
+	"northern ireland":         "GB",
+	"holland":                  "NL",
+	"the netherlands":          "NL",
+	"czechia":                  "CZ",
+	"deutschland":              "DE",
+	"uae":                      "AE",
+	"korea":                    "KR",
+}
+
+var (
+	// citiesByName maps the normalized names and alternate names to the cities
+	citiesByName map[string][]City
+	// countryCodes maps the normalized country names and codes to the country codes
+	countryCodes map[string]string
+)
+
+func init() {
+	citiesByName = make(map[string][]City)
+	countryCodes = make(map[string]string)
+	for alias, code := range countryAliases {
+		countryCodes[alias] = code
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(citiesCSV)).ReadAll()
+	if err != nil {
+		panic(fmt.Sprintf("cannot read the gazetteer: %v", err))
+	}
+
+	// the first record is the header
+	for _, record := range records[1:] {
+		latitude, err := strconv.ParseFloat(record[3], 64)
+		if err != nil {
+			panic(fmt.Sprintf("invalid latitude of %s: %v", record[0], err))
+		}
+		longitude, err := strconv.ParseFloat(record[4], 64)
+		if err != nil {
+			panic(fmt.Sprintf("invalid longitude of %s: %v", record[0], err))
+		}
+
+		city := City{
+			Name:        record[0],
+			CountryCode: record[1],
+			Country:     record[2],
+			Latitude:    latitude,
+			Longitude:   longitude,
+		}
+
+		names := []string{city.Name}
+		if record[5] != "" {
+			names = append(names, strings.Split(record[5], "|")...)
+		}
+		for _, name := range names {
+			key := normalize(name)
+			citiesByName[key] = append(citiesByName[key], city)
+		}
+
+		countryCodes[normalize(city.CountryCode)] = city.CountryCode
+		countryCodes[normalize(city.Country)] = city.CountryCode
+	}
+}
+
+// Lookup finds the city of a free-text location, e.g. "Berlin", "berlin, DE"
+// or "Berlin, Germany". The part after the last comma is matched against
+// the country name or code. It returns false if the city is not in the gazetteer.
+func Lookup(location string) (City, bool) {
+	name := location
+	country := ""
+	if i := strings.LastIndex(location, ","); i != -1 {
+		name = location[:i]
+		country = location[i+1:]
+	}
+
+	cities := citiesByName[normalize(name)]
+	if len(cities) == 0 {
+		return City{}, false
+	}
+
+	if normalize(country) == "" {
+		return cities[0], true
+	}
+
+	code, ok := countryCodes[normalize(country)]
+	if !ok {
+		return City{}, false
+	}
+	for _, city := range cities {
+		if city.CountryCode == code {
+			return city, true
+		}
+	}
+
+	return City{}, false
+}
+
+// NormalizeLocation returns the normalized location and the city of a free-text location.
+// Locations that are not in the gazetteer are returned trimmed and without the city.
+func NormalizeLocation(location string) (string, *City) {
+	city, ok := Lookup(location)
+	if !ok {
+		return strings.TrimSpace(location), nil
+	}
+
+	return city.String(), &city
+}
+
+// DistanceKm returns the great-circle distance between two points in kilometers,
+// calculated with the haversine formula
+func DistanceKm(lat1, lon1, lat2, lon2 float64) float64 {
+	dLat := radians(lat2 - lat1)
+	dLon := radians(lon2 - lon1)
+
+	a := math.Pow(math.Sin(dLat/2), 2) +
+		math.Cos(radians(lat1))*math.Cos(radians(lat2))*math.Pow(math.Sin(dLon/2), 2)
+
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+// normalize lowercases the name and collapses the whitespace
+func normalize(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
@@ -0,0 +1,66 @@
+package geo
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	testCases := []struct {
+		name        string
+		location    string
+		city        string
+		countryCode string
+		found       bool
+	}{
+		{"City", "Berlin", "Berlin", "DE", true},
+		{"City And Country", "Berlin, Germany", "Berlin", "DE", true},
+		{"Country Code", "berlin, de", "Berlin", "DE", true},
+		{"Whitespace", "  new   york ,  usa ", "New York", "US", true},
+		{"Alternate Name", "München", "Munich", "DE", true},
+		{"Country Alias", "London, UK", "London", "GB", true},
+		{"Wrong Country", "Berlin, France", "", "", false},
+		{"Unknown Country", "Berlin, Atlantis", "", "", false},
+		{"Unknown City", "Atlantis", "", "", false},
+		{"Empty", "", "", "", false},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			city, ok := Lookup(tc.location)
+			require.Equal(t, tc.found, ok)
+			require.Equal(t, tc.city, city.Name)
+			require.Equal(t, tc.countryCode, city.CountryCode)
+		})
+	}
+}
+
+func TestNormalizeLocation(t *testing.T) {
+	location, city := NormalizeLocation("warszawa")
+	require.Equal(t, "Warsaw, Poland", location)
+	require.NotNil(t, city)
+	require.InDelta(t, 52.2298, city.Latitude, 0.0001)
+	require.InDelta(t, 21.0118, city.Longitude, 0.0001)
+
+	location, city = NormalizeLocation(" Remote ")
+	require.Equal(t, "Remote", location)
+	require.Nil(t, city)
+}
+
+func TestDistanceKm(t *testing.T) {
+	berlin, ok := Lookup("Berlin")
+	require.True(t, ok)
+	munich, ok := Lookup("Munich")
+	require.True(t, ok)
+
+	require.Zero(t, DistanceKm(berlin.Latitude, berlin.Longitude, berlin.Latitude, berlin.Longitude))
+	// about 504 km in a straight line
+	require.InDelta(t, 504, DistanceKm(berlin.Latitude, berlin.Longitude, munich.Latitude, munich.Longitude), 5)
+	require.InDelta(t,
+		DistanceKm(berlin.Latitude, berlin.Longitude, munich.Latitude, munich.Longitude),
+		DistanceKm(munich.Latitude, munich.Longitude, berlin.Latitude, berlin.Longitude),
+		0.0001,
+	)
+}
@@ -40,6 +40,8 @@ type Data struct {
 	Content  string
 	Files    []AttachFile
 	Template string
+	// Headers are the additional headers of the email, for example List-Unsubscribe
+	Headers map[string]string
 }
 
 // SendEmail sends an email
@@ -63,6 +65,11 @@ func (sender *HogSender) SendEmail(data Data) error {
 		email.AddTo(t)
 	}
 
+	// add headers
+	for header, value := range data.Headers {
+		email.AddHeader(header, value)
+	}
+
 	// attach files
 	for _, f := range data.Files {
 		email.AddAttachment(f.Path, f.Name)
@@ -0,0 +1,61 @@
+package salary
+
+import (
+	"errors"
+	"fmt"
+	db "github.com/aalug/job-finder-go/internal/db/sqlc"
+	"math"
+)
+
+// BaseCurrency is the currency that all salaries are converted to.
+// Rates in the currency_rates table are the values of one unit of a currency in the base currency.
+const BaseCurrency = "USD"
+
+var (
+	ErrUnsupportedPeriod = errors.New("unsupported salary period")
+	ErrInvalidRate       = errors.New("currency rate must be greater than 0")
+	ErrSalaryTooHigh     = errors.New("annual salary is too high")
+)
+
+// periodsPerYear is the number of pay periods in a year, based on a 40-hour, 5-day working week
+var periodsPerYear = map[db.SalaryPeriod]float64{
+	db.SalaryPeriodHour:  2080,
+	db.SalaryPeriodDay:   260,
+	db.SalaryPeriodWeek:  52,
+	db.SalaryPeriodMonth: 12,
+	db.SalaryPeriodYear:  1,
+}
+
+// Annualize converts the amount paid per the period in a currency with the given rate
+// to the annual amount in the base currency
+func Annualize(amount int32, period db.SalaryPeriod, rate float64) (int32, error) {
+	periods, ok := periodsPerYear[period]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrUnsupportedPeriod, period)
+	}
+	if rate <= 0 {
+		return 0, ErrInvalidRate
+	}
+
+	annual := math.Round(float64(amount) * periods * rate)
+	if annual > math.MaxInt32 {
+		return 0, ErrSalaryTooHigh
+	}
+
+	return int32(annual), nil
+}
+
+// AnnualizeRange converts both ends of the salary range with Annualize
+func AnnualizeRange(min, max int32, period db.SalaryPeriod, rate float64) (int32, int32, error) {
+	annualMin, err := Annualize(min, period, rate)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	annualMax, err := Annualize(max, period, rate)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return annualMin, annualMax, nil
+}
@@ -0,0 +1,55 @@
+package salary
+
+import (
+	db "github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"math"
+	"testing"
+)
+
+func TestAnnualize(t *testing.T) {
+	testCases := []struct {
+		name     string
+		amount   int32
+		period   db.SalaryPeriod
+		rate     float64
+		expected int32
+		err      error
+	}{
+		{"Year Base Currency", 100000, db.SalaryPeriodYear, 1, 100000, nil},
+		{"Month", 5000, db.SalaryPeriodMonth, 1, 60000, nil},
+		{"Week", 1000, db.SalaryPeriodWeek, 1, 52000, nil},
+		{"Day", 200, db.SalaryPeriodDay, 1, 52000, nil},
+		{"Hour", 25, db.SalaryPeriodHour, 1, 52000, nil},
+		{"Other Currency", 10000, db.SalaryPeriodMonth, 0.25, 30000, nil},
+		{"Rounded", 1, db.SalaryPeriodYear, 1.5, 2, nil},
+		{"Zero", 0, db.SalaryPeriodHour, 1.08, 0, nil},
+		{"Unsupported Period", 100, db.SalaryPeriod("quarter"), 1, 0, ErrUnsupportedPeriod},
+		{"Zero Rate", 100, db.SalaryPeriodYear, 0, 0, ErrInvalidRate},
+		{"Too High", math.MaxInt32, db.SalaryPeriodHour, 1, 0, ErrSalaryTooHigh},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			annual, err := Annualize(tc.amount, tc.period, tc.rate)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, annual)
+		})
+	}
+}
+
+func TestAnnualizeRange(t *testing.T) {
+	annualMin, annualMax, err := AnnualizeRange(4000, 6000, db.SalaryPeriodMonth, 1.08)
+	require.NoError(t, err)
+	require.Equal(t, int32(51840), annualMin)
+	require.Equal(t, int32(77760), annualMax)
+
+	_, _, err = AnnualizeRange(4000, math.MaxInt32, db.SalaryPeriodMonth, 1)
+	require.ErrorIs(t, err, ErrSalaryTooHigh)
+}
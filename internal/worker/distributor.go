@@ -16,6 +16,30 @@ type TaskDistributor interface {
 		payload *PayloadSendConfirmationEmail,
 		opts ...asynq.Option,
 	) error
+	DistributeTaskSendResetPasswordEmail(
+		ctx context.Context,
+		payload *PayloadSendResetPasswordEmail,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskSendAccountLockedEmail(
+		ctx context.Context,
+		payload *PayloadSendAccountLockedEmail,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskSendCompanyInvitationEmail(
+		ctx context.Context,
+		payload *PayloadSendCompanyInvitationEmail,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskNormalizeJobSalaries(
+		ctx context.Context,
+		payload *PayloadNormalizeJobSalaries,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskProcessSearchOutbox(
+		ctx context.Context,
+		opts ...asynq.Option,
+	) error
 }
 
 type RedisTaskDistributor struct {
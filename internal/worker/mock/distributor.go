@@ -36,6 +36,82 @@ func (m *MockTaskDistributor) EXPECT() *MockTaskDistributorMockRecorder {
 	return m.recorder
 }
 
+// DistributeTaskNormalizeJobSalaries mocks base method.
+func (m *MockTaskDistributor) DistributeTaskNormalizeJobSalaries(arg0 context.Context, arg1 *worker.PayloadNormalizeJobSalaries, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskNormalizeJobSalaries", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskNormalizeJobSalaries indicates an expected call of DistributeTaskNormalizeJobSalaries.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskNormalizeJobSalaries(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskNormalizeJobSalaries", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskNormalizeJobSalaries), varargs...)
+}
+
+// DistributeTaskProcessSearchOutbox mocks base method.
+func (m *MockTaskDistributor) DistributeTaskProcessSearchOutbox(arg0 context.Context, arg1 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskProcessSearchOutbox", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskProcessSearchOutbox indicates an expected call of DistributeTaskProcessSearchOutbox.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskProcessSearchOutbox(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskProcessSearchOutbox", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskProcessSearchOutbox), varargs...)
+}
+
+// DistributeTaskSendAccountLockedEmail mocks base method.
+func (m *MockTaskDistributor) DistributeTaskSendAccountLockedEmail(arg0 context.Context, arg1 *worker.PayloadSendAccountLockedEmail, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskSendAccountLockedEmail", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskSendAccountLockedEmail indicates an expected call of DistributeTaskSendAccountLockedEmail.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendAccountLockedEmail(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendAccountLockedEmail", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendAccountLockedEmail), varargs...)
+}
+
+// DistributeTaskSendCompanyInvitationEmail mocks base method.
+func (m *MockTaskDistributor) DistributeTaskSendCompanyInvitationEmail(arg0 context.Context, arg1 *worker.PayloadSendCompanyInvitationEmail, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskSendCompanyInvitationEmail", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskSendCompanyInvitationEmail indicates an expected call of DistributeTaskSendCompanyInvitationEmail.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendCompanyInvitationEmail(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendCompanyInvitationEmail", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendCompanyInvitationEmail), varargs...)
+}
+
 // DistributeTaskSendConfirmationEmail mocks base method.
 func (m *MockTaskDistributor) DistributeTaskSendConfirmationEmail(arg0 context.Context, arg1 *worker.PayloadSendConfirmationEmail, arg2 ...asynq.Option) error {
 	m.ctrl.T.Helper()
@@ -55,6 +131,25 @@ func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendConfirmationEmail(a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendConfirmationEmail", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendConfirmationEmail), varargs...)
 }
 
+// DistributeTaskSendResetPasswordEmail mocks base method.
+func (m *MockTaskDistributor) DistributeTaskSendResetPasswordEmail(arg0 context.Context, arg1 *worker.PayloadSendResetPasswordEmail, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskSendResetPasswordEmail", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskSendResetPasswordEmail indicates an expected call of DistributeTaskSendResetPasswordEmail.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendResetPasswordEmail(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendResetPasswordEmail", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendResetPasswordEmail), varargs...)
+}
+
 // DistributeTaskSendVerificationEmail mocks base method.
 func (m *MockTaskDistributor) DistributeTaskSendVerificationEmail(arg0 context.Context, arg1 *worker.PayloadSendVerificationEmail, arg2 ...asynq.Option) error {
 	m.ctrl.T.Helper()
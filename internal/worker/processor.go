@@ -4,8 +4,10 @@ import (
 	"context"
 	"github.com/aalug/job-finder-go/internal/config"
 	db "github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/aalug/job-finder-go/internal/esearch"
 	"github.com/aalug/job-finder-go/internal/mail"
 	"github.com/hibiken/asynq"
+	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog/log"
 )
 
@@ -18,16 +20,36 @@ type TaskProcessor interface {
 	Start() error
 	ProcessTaskSendVerificationEmail(ctx context.Context, task *asynq.Task) error
 	ProcessTaskSendConfirmationEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendResetPasswordEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendAccountLockedEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendCompanyInvitationEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskExpireJobs(ctx context.Context, task *asynq.Task) error
+	ProcessTaskPurgeVerifyEmails(ctx context.Context, task *asynq.Task) error
+	ProcessTaskCleanSearchIndex(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendJobExpiryReminders(ctx context.Context, task *asynq.Task) error
+	ProcessTaskNormalizeJobSalaries(ctx context.Context, task *asynq.Task) error
+	ProcessTaskProcessSearchOutbox(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendSavedSearchDigests(ctx context.Context, task *asynq.Task) error
 }
 
 type RedisTaskProcessor struct {
 	server      *asynq.Server
 	store       db.Store
 	emailSender mail.EmailSender
+	esClient    esearch.ESearchClient
 	config      config.Config
+	// distributor distributes the tasks that follow the processed ones
+	distributor TaskDistributor
+	// redisClient holds the locks of the tasks that cannot run concurrently
+	redisClient redis.UniversalClient
 }
 
-func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, emailSender mail.EmailSender) TaskProcessor {
+func NewRedisTaskProcessor(
+	redisOpt asynq.RedisClientOpt,
+	store db.Store,
+	emailSender mail.EmailSender,
+	esClient esearch.ESearchClient,
+) TaskProcessor {
 	server := asynq.NewServer(
 		redisOpt,
 		asynq.Config{
@@ -55,7 +77,10 @@ func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, emailS
 		server:      server,
 		store:       store,
 		emailSender: emailSender,
+		esClient:    esClient,
 		config:      cfg,
+		distributor: NewRedisTaskDistributor(redisOpt),
+		redisClient: redisOpt.MakeRedisClient().(redis.UniversalClient),
 	}
 }
 
@@ -65,6 +90,16 @@ func (processor *RedisTaskProcessor) Start() error {
 
 	mux.HandleFunc(TaskSendVerificationEmail, processor.ProcessTaskSendVerificationEmail)
 	mux.HandleFunc(TaskSendConfirmationEmail, processor.ProcessTaskSendConfirmationEmail)
+	mux.HandleFunc(TaskSendResetPasswordEmail, processor.ProcessTaskSendResetPasswordEmail)
+	mux.HandleFunc(TaskSendAccountLockedEmail, processor.ProcessTaskSendAccountLockedEmail)
+	mux.HandleFunc(TaskSendCompanyInvitationEmail, processor.ProcessTaskSendCompanyInvitationEmail)
+	mux.HandleFunc(TaskExpireJobs, processor.ProcessTaskExpireJobs)
+	mux.HandleFunc(TaskPurgeVerifyEmails, processor.ProcessTaskPurgeVerifyEmails)
+	mux.HandleFunc(TaskCleanSearchIndex, processor.ProcessTaskCleanSearchIndex)
+	mux.HandleFunc(TaskSendJobExpiryReminders, processor.ProcessTaskSendJobExpiryReminders)
+	mux.HandleFunc(TaskNormalizeJobSalaries, processor.ProcessTaskNormalizeJobSalaries)
+	mux.HandleFunc(TaskProcessSearchOutbox, processor.ProcessTaskProcessSearchOutbox)
+	mux.HandleFunc(TaskSendSavedSearchDigests, processor.ProcessTaskSendSavedSearchDigests)
 
 	return processor.server.Start(mux)
 }
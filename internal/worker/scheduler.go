@@ -0,0 +1,88 @@
+package worker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/aalug/job-finder-go/internal/config"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	"time"
+)
+
+type TaskScheduler interface {
+	Start() error
+}
+
+type RedisTaskScheduler struct {
+	scheduler *asynq.Scheduler
+	config    config.Config
+}
+
+func NewRedisTaskScheduler(redisOpt asynq.RedisClientOpt, config config.Config) TaskScheduler {
+	scheduler := asynq.NewScheduler(
+		redisOpt,
+		&asynq.SchedulerOpts{
+			PostEnqueueFunc: func(info *asynq.TaskInfo, err error) {
+				if errors.Is(err, asynq.ErrDuplicateTask) {
+					log.Info().Msg("periodic task is not enqueued, the previous one is still queued or running")
+					return
+				}
+				if err != nil {
+					log.Error().Err(err).Msg("enqueue periodic task failed")
+				}
+			},
+			Logger: NewLogger(),
+		},
+	)
+
+	return &RedisTaskScheduler{
+		scheduler: scheduler,
+		config:    config,
+	}
+}
+
+// Start registers the periodic tasks and starts the scheduler.
+// Tasks with interval 0 are not registered.
+func (scheduler *RedisTaskScheduler) Start() error {
+	payload, err := json.Marshal(PayloadSendJobExpiryReminders{
+		RemindBefore: scheduler.config.JobExpiryReminderBefore,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	tasks := []struct {
+		interval time.Duration
+		task     *asynq.Task
+	}{
+		{scheduler.config.ExpireJobsInterval, asynq.NewTask(TaskExpireJobs, nil)},
+		{scheduler.config.PurgeVerifyEmailsInterval, asynq.NewTask(TaskPurgeVerifyEmails, nil)},
+		{scheduler.config.CleanSearchIndexInterval, asynq.NewTask(TaskCleanSearchIndex, nil)},
+		{scheduler.config.JobExpiryRemindersInterval, asynq.NewTask(TaskSendJobExpiryReminders, payload)},
+		{scheduler.config.ProcessSearchOutboxInterval, asynq.NewTask(TaskProcessSearchOutbox, nil)},
+		{scheduler.config.SendSavedSearchDigestsInterval, asynq.NewTask(TaskSendSavedSearchDigests, nil)},
+	}
+
+	for _, t := range tasks {
+		if t.interval <= 0 {
+			continue
+		}
+
+		// the task is not enqueued again while the previous one is still waiting or running
+		_, err := scheduler.scheduler.Register(
+			fmt.Sprintf("@every %s", t.interval),
+			t.task,
+			asynq.Queue(QueueDefault),
+			asynq.MaxRetry(3),
+			asynq.Timeout(t.interval),
+			asynq.Unique(t.interval),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to register task %s: %w", t.task.Type(), err)
+		}
+		log.Info().Str("type", t.task.Type()).Str("interval", t.interval.String()).Msg("registered periodic task")
+	}
+
+	return scheduler.scheduler.Start()
+}
@@ -0,0 +1,51 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskCleanSearchIndex = "task:clean_search_index"
+
+// ProcessTaskCleanSearchIndex processes the periodic task of removing documents
+// from the elasticsearch index that belong to jobs that no longer exist
+// or are not published anymore.
+func (processor *RedisTaskProcessor) ProcessTaskCleanSearchIndex(ctx context.Context, task *asynq.Task) error {
+	documents, err := processor.esClient.ListDocumentJobIDs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list documents: %w", err)
+	}
+
+	jobIDs := make([]int32, 0, len(documents))
+	for _, jobID := range documents {
+		jobIDs = append(jobIDs, jobID)
+	}
+
+	publishedJobIDs, err := processor.store.ListPublishedJobIDs(ctx, jobIDs)
+	if err != nil {
+		return fmt.Errorf("failed to list published jobs: %w", err)
+	}
+	published := make(map[int32]bool, len(publishedJobIDs))
+	for _, jobID := range publishedJobIDs {
+		published[jobID] = true
+	}
+
+	deleted := 0
+	for documentID, jobID := range documents {
+		if published[jobID] {
+			continue
+		}
+
+		err = processor.esClient.DeleteJobDocument(documentID)
+		if err != nil {
+			return fmt.Errorf("failed to delete document %s: %w", documentID, err)
+		}
+		deleted++
+	}
+
+	log.Info().Str("type", task.Type()).Int("deleted_documents", deleted).Msg("processed task")
+
+	return nil
+}
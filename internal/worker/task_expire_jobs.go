@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	db "github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskExpireJobs = "task:expire_jobs"
+
+// ProcessTaskExpireJobs processes the periodic task of expiring published jobs
+// that are past their closes_at. The search outbox entries of the expired jobs
+// are created in the same transaction, they remove the jobs from the elasticsearch index.
+func (processor *RedisTaskProcessor) ProcessTaskExpireJobs(ctx context.Context, task *asynq.Task) error {
+	var jobIDs []int32
+	err := processor.store.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+
+		jobIDs, err = q.ExpireJobs(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, jobID := range jobIDs {
+			err = q.CreateSearchOutboxEntry(ctx, jobID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to expire jobs: %w", err)
+	}
+
+	if len(jobIDs) > 0 {
+		err = processor.distributor.DistributeTaskProcessSearchOutbox(ctx, asynq.MaxRetry(10), asynq.Queue(QueueDefault))
+		if err != nil {
+			// the periodic task processes the outbox again
+			log.Warn().Err(err).Msg("cannot distribute the task to process the search outbox")
+		}
+	}
+
+	log.Info().Str("type", task.Type()).Int("expired_jobs", len(jobIDs)).Msg("processed task")
+
+	return nil
+}
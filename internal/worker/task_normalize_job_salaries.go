@@ -0,0 +1,104 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	db "github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/aalug/job-finder-go/internal/salary"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskNormalizeJobSalaries = "task:normalize_job_salaries"
+
+type PayloadNormalizeJobSalaries struct {
+	Currency string `json:"currency"`
+}
+
+// DistributeTaskNormalizeJobSalaries distributes the task of recalculating
+// the normalized salaries of the jobs in a currency after its rate changed.
+func (distributor *RedisTaskDistributor) DistributeTaskNormalizeJobSalaries(
+	ctx context.Context,
+	payload *PayloadNormalizeJobSalaries,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskNormalizeJobSalaries, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).Int("max_retry", info.MaxRetry).Msg("enqueued task")
+
+	return nil
+}
+
+// ProcessTaskNormalizeJobSalaries processes the task of recalculating
+// the normalized salaries of the jobs in a currency with its current rate.
+// Documents of the published jobs are updated in the elasticsearch index through the search outbox.
+func (processor *RedisTaskProcessor) ProcessTaskNormalizeJobSalaries(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadNormalizeJobSalaries
+	err := json.Unmarshal(task.Payload(), &payload)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	rate, err := processor.store.GetCurrencyRate(ctx, payload.Currency)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("currency rate of %s does not exist: %w", payload.Currency, asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get currency rate: %w", err)
+	}
+
+	jobs, err := processor.store.ListJobSalariesByCurrency(ctx, payload.Currency)
+	if err != nil {
+		return fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	for _, job := range jobs {
+		salaryMinAnnual, salaryMaxAnnual, err := salary.AnnualizeRange(job.SalaryMin, job.SalaryMax, job.SalaryPeriod, rate.Rate)
+		if err != nil {
+			return fmt.Errorf("failed to annualize salary of job %d: %w", job.ID, err)
+		}
+
+		err = processor.store.ExecTx(ctx, func(q *db.Queries) error {
+			err := q.UpdateJobAnnualSalary(ctx, db.UpdateJobAnnualSalaryParams{
+				ID:              job.ID,
+				SalaryMinAnnual: salaryMinAnnual,
+				SalaryMaxAnnual: salaryMaxAnnual,
+			})
+			if err != nil {
+				return err
+			}
+
+			// only published jobs are in the elasticsearch index
+			if job.Status != db.JobStatusPublished {
+				return nil
+			}
+			return q.CreateSearchOutboxEntry(ctx, job.ID)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update salary of job %d: %w", job.ID, err)
+		}
+	}
+
+	err = processor.distributor.DistributeTaskProcessSearchOutbox(ctx, asynq.MaxRetry(10), asynq.Queue(QueueDefault))
+	if err != nil {
+		// the periodic task processes the outbox again
+		log.Warn().Err(err).Msg("cannot distribute the task to process the search outbox")
+	}
+
+	log.Info().Str("type", task.Type()).Str("currency", payload.Currency).
+		Int("updated_jobs", len(jobs)).Msg("processed task")
+
+	return nil
+}
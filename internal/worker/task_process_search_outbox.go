@@ -0,0 +1,197 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/aalug/job-finder-go/internal/esearch"
+	"github.com/hibiken/asynq"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+	"strconv"
+	"time"
+)
+
+const TaskProcessSearchOutbox = "task:process_search_outbox"
+
+const (
+	// searchOutboxBatchSize is the number of the outbox entries processed at once
+	searchOutboxBatchSize = 100
+	// searchOutboxRetention is how long the processed outbox entries are kept, so they can be replayed
+	searchOutboxRetention = 7 * 24 * time.Hour
+	// searchOutboxUniqueTTL is how long the task is not distributed again while it is queued or running.
+	// The uniqueness lock of asynq expires even if the task is archived, so a failed task
+	// does not stop the next ones.
+	searchOutboxUniqueTTL = 10 * time.Minute
+	// searchOutboxLockKey is the redis key of the lock held while the search outbox is processed
+	searchOutboxLockKey = "job_finder:lock:process_search_outbox"
+)
+
+// releaseLockScript deletes the lock only if it is still held with the given value,
+// so an expired lock taken over by another task is not released
+var releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// DistributeTaskProcessSearchOutbox distributes the task of applying the pending
+// search outbox entries to the elasticsearch index. It is distributed after the jobs change,
+// the periodic task processes the entries that were not processed then.
+// If the task is already queued or running, it is not distributed again,
+// the running task processes the new entries as well.
+func (distributor *RedisTaskDistributor) DistributeTaskProcessSearchOutbox(
+	ctx context.Context,
+	opts ...asynq.Option,
+) error {
+	task := asynq.NewTask(TaskProcessSearchOutbox, nil, append(opts, asynq.Unique(searchOutboxUniqueTTL))...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		if errors.Is(err, asynq.ErrDuplicateTask) {
+			log.Info().Str("type", task.Type()).Msg("task is not enqueued, it is already queued or running")
+			return nil
+		}
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+	log.Info().Str("type", task.Type()).
+		Str("queue", info.Queue).Int("max_retry", info.MaxRetry).Msg("enqueued task")
+
+	return nil
+}
+
+// ProcessTaskProcessSearchOutbox processes the task of applying the pending search outbox entries
+// to the elasticsearch index. Every entry makes the document of the job match the current job in the db:
+// the job is indexed if it should be searchable, otherwise its document is deleted. That makes processing
+// the same entry again harmless. Entries of the jobs that failed stay pending and the task is retried.
+// The entries are processed until none are pending, so the entries created while the task runs
+// (when it cannot be distributed again) are processed too. Processed entries older than
+// searchOutboxRetention are deleted.
+// Only one task processes the outbox at a time, so an older document cannot overwrite a newer one.
+// The task that cannot take the lock fails and is retried.
+func (processor *RedisTaskProcessor) ProcessTaskProcessSearchOutbox(ctx context.Context, task *asynq.Task) error {
+	release, err := processor.lockSearchOutbox(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	processed := 0
+	for {
+		entries, err := processor.store.ListPendingSearchOutboxEntries(ctx, searchOutboxBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to list search outbox entries: %w", err)
+		}
+		if len(entries) == 0 {
+			break
+		}
+
+		// a job changed many times is synced once
+		synced := make(map[int32]error, len(entries))
+		processedIDs := make([]int64, 0, len(entries))
+		var syncErr error
+		for _, entry := range entries {
+			err, ok := synced[entry.JobID]
+			if !ok {
+				err = processor.syncJobDocument(ctx, entry.JobID)
+				synced[entry.JobID] = err
+			}
+			if err != nil {
+				syncErr = fmt.Errorf("failed to sync document of job %d: %w", entry.JobID, err)
+				continue
+			}
+			processedIDs = append(processedIDs, entry.ID)
+		}
+
+		if len(processedIDs) > 0 {
+			err = processor.store.MarkSearchOutboxEntriesProcessed(ctx, processedIDs)
+			if err != nil {
+				return fmt.Errorf("failed to mark search outbox entries as processed: %w", err)
+			}
+			processed += len(processedIDs)
+		}
+
+		if syncErr != nil {
+			return syncErr
+		}
+	}
+
+	deleted, err := processor.store.DeleteProcessedSearchOutboxEntries(ctx, time.Now().Add(-searchOutboxRetention))
+	if err != nil {
+		return fmt.Errorf("failed to delete processed search outbox entries: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Int("processed_entries", processed).
+		Int64("deleted_entries", deleted).Msg("processed task")
+
+	return nil
+}
+
+// lockSearchOutbox takes the lock of processing the search outbox and returns the function releasing it.
+// The lock expires with the deadline of the task, so the lock of a crashed worker does not stay forever.
+func (processor *RedisTaskProcessor) lockSearchOutbox(ctx context.Context) (func(), error) {
+	ttl := searchOutboxUniqueTTL
+	if deadline, ok := ctx.Deadline(); ok {
+		ttl = time.Until(deadline)
+	}
+	value, ok := asynq.GetTaskID(ctx)
+	if !ok {
+		value = strconv.FormatInt(time.Now().UnixNano(), 10)
+	}
+
+	locked, err := processor.redisClient.SetNX(ctx, searchOutboxLockKey, value, ttl).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock the search outbox: %w", err)
+	}
+	if !locked {
+		log.Info().Str("type", TaskProcessSearchOutbox).Msg("search outbox is processed by another task")
+		return nil, fmt.Errorf("search outbox is processed by another task")
+	}
+
+	return func() {
+		// the context of the task may be canceled already
+		err := releaseLockScript.Run(context.Background(), processor.redisClient, []string{searchOutboxLockKey}, value).Err()
+		if err != nil {
+			log.Error().Err(err).Msg("failed to release the lock of the search outbox")
+		}
+	}, nil
+}
+
+// syncJobDocument indexes the job with the job ID as the document ID if it should be searchable,
+// otherwise it deletes the document of the job
+func (processor *RedisTaskProcessor) syncJobDocument(ctx context.Context, jobID int32) error {
+	job, err := processor.store.GetJobForES(ctx, jobID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return processor.esClient.DeleteJobDocument(strconv.Itoa(int(jobID)))
+		}
+		return err
+	}
+
+	skills, err := processor.store.ListAllJobSkillsByJobID(ctx, job.ID)
+	if err != nil {
+		return err
+	}
+
+	return processor.esClient.IndexJobAsDocument(int(job.ID), esearch.Job{
+		ID:              job.ID,
+		Title:           job.Title,
+		Industry:        job.Industry,
+		CompanyName:     job.CompanyName,
+		Description:     job.Description,
+		Location:        job.Location,
+		SalaryMin:       job.SalaryMin,
+		SalaryMax:       job.SalaryMax,
+		Requirements:    job.Requirements,
+		JobSkills:       skills,
+		EmploymentType:  string(job.EmploymentType),
+		SeniorityLevel:  string(job.SeniorityLevel),
+		RemotePolicy:    string(job.RemotePolicy),
+		SalaryCurrency:  job.SalaryCurrency,
+		SalaryPeriod:    string(job.SalaryPeriod),
+		SalaryMinAnnual: job.SalaryMinAnnual,
+		SalaryMaxAnnual: job.SalaryMaxAnnual,
+		LocationPoint:   esearch.NewGeoPoint(job.Latitude, job.Longitude),
+	})
+}
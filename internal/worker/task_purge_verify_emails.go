@@ -0,0 +1,23 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskPurgeVerifyEmails = "task:purge_verify_emails"
+
+// ProcessTaskPurgeVerifyEmails processes the periodic task of deleting
+// email verifications that were already used or have expired.
+func (processor *RedisTaskProcessor) ProcessTaskPurgeVerifyEmails(ctx context.Context, task *asynq.Task) error {
+	deleted, err := processor.store.DeleteStaleVerifyEmails(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete verify emails: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Int64("deleted_verify_emails", deleted).Msg("processed task")
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/aalug/job-finder-go/internal/mail"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	"time"
+)
+
+const TaskSendAccountLockedEmail = "task:send_account_locked_email"
+
+type PayloadSendAccountLockedEmail struct {
+	Email string `json:"email"`
+	// AccountType is either "users" or "employers"
+	AccountType string    `json:"account_type"`
+	LockedUntil time.Time `json:"locked_until"`
+}
+
+// DistributeTaskSendAccountLockedEmail distributes the task of sending an email
+// about an account that got locked after too many failed login attempts.
+func (distributor *RedisTaskDistributor) DistributeTaskSendAccountLockedEmail(
+	ctx context.Context,
+	payload *PayloadSendAccountLockedEmail,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskSendAccountLockedEmail, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).Int("max_retry", info.MaxRetry).Msg("enqueued task")
+
+	return nil
+}
+
+// ProcessTaskSendAccountLockedEmail processes the task of sending an account locked email.
+// It works for both employers and users.
+func (processor *RedisTaskProcessor) ProcessTaskSendAccountLockedEmail(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendAccountLockedEmail
+	err := json.Unmarshal(task.Payload(), &payload)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	var fullName string
+	switch payload.AccountType {
+	case "users":
+		user, err := processor.store.GetUserByEmail(ctx, payload.Email)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("user does not exist: %w", asynq.SkipRetry)
+			}
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		fullName = user.FullName
+	case "employers":
+		employer, err := processor.store.GetEmployerByEmail(ctx, payload.Email)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("employer does not exist: %w", asynq.SkipRetry)
+			}
+			return fmt.Errorf("failed to get employer: %w", err)
+		}
+		fullName = employer.FullName
+	default:
+		return fmt.Errorf("invalid account type %q: %w", payload.AccountType, asynq.SkipRetry)
+	}
+
+	// send email with the information about the lockout and the link to reset the password
+	forgotPasswordUrl := fmt.Sprintf("%s%s/%s/forgot-password",
+		processor.config.ServerAddress, processor.config.BaseUrl, payload.AccountType)
+	content := fmt.Sprintf(`
+		<h3>Hello %s</h3><br>
+		<p class="message">
+		There were too many failed attempts to log in to your account, so it has been locked
+		until %s. If it was not you, someone may be trying to guess your password.
+		You can reset your password using the link below.
+		</p>
+		<a class="button" href="%s">Reset Password</a>
+		`, fullName, payload.LockedUntil.UTC().Format("2006-01-02 15:04 MST"), forgotPasswordUrl)
+	err = processor.emailSender.SendEmail(mail.Data{
+		To:       []string{payload.Email},
+		Subject:  "Your Go Job Search account has been locked",
+		Content:  content,
+		Template: "verification_email.html",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("email", payload.Email).Msg("processed task")
+
+	return nil
+}
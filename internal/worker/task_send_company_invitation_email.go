@@ -0,0 +1,101 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	db "github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/aalug/job-finder-go/internal/mail"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskSendCompanyInvitationEmail = "task:send_company_invitation_email"
+
+type PayloadSendCompanyInvitationEmail struct {
+	Email           string         `json:"email"`
+	CompanyID       int32          `json:"company_id"`
+	CompanyName     string         `json:"company_name"`
+	Role            db.CompanyRole `json:"role"`
+	InvitedBy       int32          `json:"invited_by"`
+	InviterFullName string         `json:"inviter_full_name"`
+}
+
+// DistributeTaskSendCompanyInvitationEmail distributes the task of sending
+// an email with the invitation to join a company.
+func (distributor *RedisTaskDistributor) DistributeTaskSendCompanyInvitationEmail(
+	ctx context.Context,
+	payload *PayloadSendCompanyInvitationEmail,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskSendCompanyInvitationEmail, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).Int("max_retry", info.MaxRetry).Msg("enqueued task")
+
+	return nil
+}
+
+// ProcessTaskSendCompanyInvitationEmail processes the task of sending
+// an email with the invitation to join a company.
+func (processor *RedisTaskProcessor) ProcessTaskSendCompanyInvitationEmail(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendCompanyInvitationEmail
+	err := json.Unmarshal(task.Payload(), &payload)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	// the invitation grants the membership of the company, so the code must not be predictable
+	secretCode, err := utils.RandomSecretCode(32)
+	if err != nil {
+		return fmt.Errorf("failed to generate secret code: %w", err)
+	}
+
+	// create company invitation in the database
+	invitation, err := processor.store.CreateCompanyInvitation(ctx, db.CreateCompanyInvitationParams{
+		CompanyID:  payload.CompanyID,
+		Email:      payload.Email,
+		Role:       payload.Role,
+		InvitedBy:  payload.InvitedBy,
+		SecretCode: secretCode,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create company invitation in the db: %w", err)
+	}
+
+	// send email with the link and the code to accept the invitation
+	acceptUrl := fmt.Sprintf("%s%s/employers/company/invitations/accept?id=%d&code=%s",
+		processor.config.ServerAddress, processor.config.BaseUrl, invitation.ID, invitation.SecretCode)
+	content := fmt.Sprintf(`
+		<h3>Hello</h3><br>
+		<p class="message">
+		%s invited you to join %s on Go Job Search as a %s. Please click the link below
+		to create your employer account. The link expires in 7 days and can be used only once.
+		If you do not know this company, you can ignore this email.
+		</p>
+		<a class="button" href="%s">Accept Invitation</a>
+		`, payload.InviterFullName, payload.CompanyName, payload.Role, acceptUrl)
+	err = processor.emailSender.SendEmail(mail.Data{
+		To:       []string{payload.Email},
+		Subject:  fmt.Sprintf("Join %s on Go Job Search", payload.CompanyName),
+		Content:  content,
+		Template: "verification_email.html",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("email", payload.Email).Msg("processed task")
+
+	return nil
+}
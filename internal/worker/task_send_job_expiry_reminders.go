@@ -0,0 +1,83 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	db "github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/aalug/job-finder-go/internal/mail"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	"time"
+)
+
+const TaskSendJobExpiryReminders = "task:send_job_expiry_reminders"
+
+type PayloadSendJobExpiryReminders struct {
+	// RemindBefore is how long before closes_at of the job the reminder is sent
+	RemindBefore time.Duration `json:"remind_before"`
+}
+
+// ProcessTaskSendJobExpiryReminders processes the periodic task of sending emails
+// to the employers of jobs that are going to expire soon. Every job gets
+// one reminder for its closes_at. Viewers of the company do not get reminders.
+func (processor *RedisTaskProcessor) ProcessTaskSendJobExpiryReminders(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendJobExpiryReminders
+	err := json.Unmarshal(task.Payload(), &payload)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	jobs, err := processor.store.ListJobsClosingSoon(ctx, time.Now().Add(payload.RemindBefore))
+	if err != nil {
+		return fmt.Errorf("failed to list jobs closing soon: %w", err)
+	}
+
+	for _, job := range jobs {
+		employers, err := processor.store.ListCompanyEmployers(ctx, job.CompanyID)
+		if err != nil {
+			return fmt.Errorf("failed to list employers of company %d: %w", job.CompanyID, err)
+		}
+
+		var emails []string
+		for _, employer := range employers {
+			if employer.Role != db.CompanyRoleViewer {
+				emails = append(emails, employer.Email)
+			}
+		}
+
+		if len(emails) > 0 {
+			jobUrl := fmt.Sprintf("%s%s/jobs/%d",
+				processor.config.ServerAddress, processor.config.BaseUrl, job.ID)
+			content := fmt.Sprintf(`
+				<h3>Hello</h3><br>
+				<p class="message">
+				The job %s of %s closes on %s. After that it will not be listed and users will not be
+				able to apply for it. If you want to keep it open, update its closes_at.
+				</p>
+				<a class="button" href="%s">View Job</a>
+				`, job.Title, job.CompanyName, job.ClosesAt.Time.UTC().Format("2006-01-02 15:04 MST"), jobUrl)
+			err = processor.emailSender.SendEmail(mail.Data{
+				To:       emails,
+				Subject:  fmt.Sprintf("Your job %s closes soon", job.Title),
+				Content:  content,
+				Template: "verification_email.html",
+			})
+			if err != nil {
+				return fmt.Errorf("failed to send email: %w", err)
+			}
+		}
+
+		err = processor.store.CreateJobExpiryReminder(ctx, db.CreateJobExpiryReminderParams{
+			JobID:    job.ID,
+			ClosesAt: job.ClosesAt.Time,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to save job expiry reminder: %w", err)
+		}
+	}
+
+	log.Info().Str("type", task.Type()).Int("reminded_jobs", len(jobs)).Msg("processed task")
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	db "github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/aalug/job-finder-go/internal/mail"
+	"github.com/aalug/job-finder-go/pkg/token"
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	"net/url"
+)
+
+const TaskSendResetPasswordEmail = "task:send_reset_password_email"
+
+type PayloadSendResetPasswordEmail struct {
+	Email    string `json:"email"`
+	FullName string `json:"full_name"`
+	// AccountType is either "users" or "employers", it is used to build the reset link
+	// and the code can reset only the password of that account type
+	AccountType string `json:"account_type"`
+}
+
+// DistributeTaskSendResetPasswordEmail distributes the task of sending a reset password email.
+func (distributor *RedisTaskDistributor) DistributeTaskSendResetPasswordEmail(
+	ctx context.Context,
+	payload *PayloadSendResetPasswordEmail,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskSendResetPasswordEmail, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).Int("max_retry", info.MaxRetry).Msg("enqueued task")
+
+	return nil
+}
+
+// ProcessTaskSendResetPasswordEmail processes the task of sending a reset password email.
+// It works for both employers and users.
+func (processor *RedisTaskProcessor) ProcessTaskSendResetPasswordEmail(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendResetPasswordEmail
+	err := json.Unmarshal(task.Payload(), &payload)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	var accountType token.AccountType
+	switch payload.AccountType {
+	case "users":
+		accountType = token.AccountTypeUser
+	case "employers":
+		accountType = token.AccountTypeEmployer
+	default:
+		return fmt.Errorf("invalid account type %q: %w", payload.AccountType, asynq.SkipRetry)
+	}
+
+	secretCode, err := utils.RandomSecretCode(32)
+	if err != nil {
+		return fmt.Errorf("failed to generate secret code: %w", err)
+	}
+
+	// create reset password in the database, it replaces the previous code of the account
+	resetPassword, err := processor.store.CreateResetPassword(ctx, db.CreateResetPasswordParams{
+		Email:       payload.Email,
+		AccountType: string(accountType),
+		SecretCode:  secretCode,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create reset password in the db: %w", err)
+	}
+
+	// send email with the link to the page that sets the new password with the code
+	resetUrl := fmt.Sprintf("%s%s/%s/reset-password?id=%d&code=%s",
+		processor.config.ServerAddress, processor.config.BaseUrl, payload.AccountType, resetPassword.ID,
+		url.QueryEscape(resetPassword.SecretCode))
+	content := fmt.Sprintf(`
+		<h3>Hello %s</h3><br>
+		<p class="message">
+		We received a request to reset your password. Please click the link below to set a new password.
+		The link expires in 30 minutes and can be used only once. If you did not request a password reset,
+		you can ignore this email.
+		</p>
+		<a class="button" href="%s">Reset Password</a>
+		`, payload.FullName, resetUrl)
+	err = processor.emailSender.SendEmail(mail.Data{
+		To:       []string{payload.Email},
+		Subject:  "Reset your Go Job Search password",
+		Content:  content,
+		Template: "verification_email.html",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("email", payload.Email).Msg("processed task")
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	db "github.com/aalug/job-finder-go/internal/db/sqlc"
+	"github.com/aalug/job-finder-go/internal/mail"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	"html"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const TaskSendSavedSearchDigests = "task:send_saved_search_digests"
+
+const (
+	// savedSearchesBatchSize is the number of the due saved searches processed at once
+	savedSearchesBatchSize = 100
+	// savedSearchDigestSize is the max number of the jobs in a digest, the newest ones are sent
+	savedSearchDigestSize = 20
+)
+
+// ProcessTaskSendSavedSearchDigests processes the periodic task of sending the digests of the saved searches.
+// Every due search runs for the jobs published since its last run and the user gets an email
+// if any of them match. The run is saved after the email is sent, so the retried task
+// does not send the same digest again. A failed search does not stop the others,
+// the errors of all the failed searches are returned at the end.
+func (processor *RedisTaskProcessor) ProcessTaskSendSavedSearchDigests(ctx context.Context, task *asynq.Task) error {
+	runAt := time.Now()
+	processed, sent := 0, 0
+	failedIDs := []int64{}
+	var errs []error
+	for {
+		// the failed searches are still due, so they are skipped in the next batches
+		searches, err := processor.store.ListDueSavedSearches(ctx, db.ListDueSavedSearchesParams{
+			RunAt:      runAt,
+			SkippedIds: failedIDs,
+			Size:       savedSearchesBatchSize,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list due saved searches: %w", err)
+		}
+
+		for _, search := range searches {
+			digestSent, err := processor.sendSavedSearchDigest(ctx, search, runAt)
+			if err != nil {
+				log.Error().Err(err).Str("type", task.Type()).Int64("saved_search_id", search.ID).
+					Msg("cannot send the digest of the saved search")
+				failedIDs = append(failedIDs, search.ID)
+				errs = append(errs, err)
+				continue
+			}
+			if digestSent {
+				sent++
+			}
+		}
+
+		processed += len(searches)
+		if len(searches) < savedSearchesBatchSize {
+			break
+		}
+	}
+
+	log.Info().Str("type", task.Type()).Int("processed_searches", processed).
+		Int("sent_digests", sent).Int("failed_searches", len(failedIDs)).Msg("processed task")
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to process %d saved searches: %w", len(errs), errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// sendSavedSearchDigest sends the digest of the saved search if any new jobs match it
+// and saves the run, it reports whether the digest was sent
+func (processor *RedisTaskProcessor) sendSavedSearchDigest(ctx context.Context, search db.ListDueSavedSearchesRow, runAt time.Time) (bool, error) {
+	jobs, err := processor.store.ListNewJobsForSavedSearch(ctx, db.ListNewJobsForSavedSearchParams{
+		RunAt: runAt,
+		ID:    search.ID,
+		Size:  savedSearchDigestSize,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to list new jobs of saved search %d: %w", search.ID, err)
+	}
+
+	if len(jobs) > 0 {
+		err = processor.emailSender.SendEmail(mail.Data{
+			To:       []string{search.Email},
+			Subject:  fmt.Sprintf("New jobs for your saved search %s", search.Name),
+			Content:  processor.savedSearchDigestContent(search, jobs),
+			Template: "job_alert_email.html",
+			// the one-click unsubscribe of the mail clients (RFC 8058) posts to the unsubscribe link
+			Headers: map[string]string{
+				"List-Unsubscribe":      fmt.Sprintf("<%s>", processor.savedSearchUnsubscribeUrl(search)),
+				"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+			},
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to send email of saved search %d: %w", search.ID, err)
+		}
+	}
+
+	err = processor.store.UpdateSavedSearchLastRunAt(ctx, db.UpdateSavedSearchLastRunAtParams{
+		ID:        search.ID,
+		LastRunAt: runAt,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to save the run of saved search %d: %w", search.ID, err)
+	}
+
+	return len(jobs) > 0, nil
+}
+
+// savedSearchDigestContent returns the content of the digest email with the jobs
+// and the link that turns the digests of the search off
+func (processor *RedisTaskProcessor) savedSearchDigestContent(search db.ListDueSavedSearchesRow, jobs []db.ListNewJobsForSavedSearchRow) string {
+	baseUrl := processor.config.ServerAddress + processor.config.BaseUrl
+
+	var jobsContent strings.Builder
+	for _, job := range jobs {
+		fmt.Fprintf(&jobsContent, `
+			<div class="job">
+			<a class="job-title" href="%s/jobs/%d">%s</a>
+			<p class="job-details">%s - %s</p>
+			<p class="job-details">%d - %d %s per %s, %s, %s</p>
+			</div>
+			`, baseUrl, job.ID, html.EscapeString(job.Title),
+			html.EscapeString(job.CompanyName), html.EscapeString(job.Location),
+			job.SalaryMin, job.SalaryMax, job.SalaryCurrency, job.SalaryPeriod,
+			strings.ReplaceAll(string(job.EmploymentType), "_", " "), job.RemotePolicy)
+	}
+
+	return fmt.Sprintf(`
+		<h3>Hello %s</h3><br>
+		<p class="message">
+		These are the new jobs matching your saved search <strong>%s</strong>.
+		</p>
+		%s
+		<p class="unsubscribe">
+		You get this email %s. <a href="%s">Unsubscribe</a> from the emails of this search,
+		the search stays saved.
+		</p>
+		`, html.EscapeString(search.FullName), html.EscapeString(search.Name), jobsContent.String(),
+		search.Frequency, processor.savedSearchUnsubscribeUrl(search))
+}
+
+// savedSearchUnsubscribeUrl returns the unsubscribe link of the saved search. Opening it shows
+// the page that confirms unsubscribing, posting to it unsubscribes right away.
+func (processor *RedisTaskProcessor) savedSearchUnsubscribeUrl(search db.ListDueSavedSearchesRow) string {
+	return fmt.Sprintf("%s%s/saved-searches/unsubscribe?token=%s",
+		processor.config.ServerAddress, processor.config.BaseUrl, url.QueryEscape(search.UnsubscribeToken))
+}
@@ -0,0 +1,141 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"time"
+)
+
+// JWTMaker - a JSON Web Token maker. Tokens are signed with the current key
+// of the keyring (EdDSA or RS256) and have the key ID in the "kid" header
+type JWTMaker struct {
+	keyring *Keyring
+}
+
+// jwtClaims - claims of the JWT. The payload is kept as it is (with the exact times),
+// registered claims are set, so the tokens can be used by other services
+type jwtClaims struct {
+	Payload
+	jwt.RegisteredClaims
+}
+
+// NewJWTMaker creates a new JWTMaker. If the keyring has no current key,
+// the maker can only verify tokens
+func NewJWTMaker(keyring *Keyring) (Maker, error) {
+	if keyring == nil {
+		return nil, fmt.Errorf("keyring cannot be nil")
+	}
+
+	maker := &JWTMaker{
+		keyring: keyring,
+	}
+
+	return maker, nil
+}
+
+// CreateToken creates a new token for a specific email, account and duration
+func (maker *JWTMaker) CreateToken(email string, accountType AccountType, accountID int32, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(email, accountType, accountID, duration)
+	if err != nil {
+		return "", payload, err
+	}
+
+	token, err := maker.sign(payload)
+	return token, payload, err
+}
+
+// CreateRefreshToken creates a new refresh token for a specific email, account and duration
+func (maker *JWTMaker) CreateRefreshToken(email string, accountType AccountType, accountID int32, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(email, accountType, accountID, duration)
+	if err != nil {
+		return "", payload, err
+	}
+	payload.TokenType = TokenTypeRefresh
+
+	token, err := maker.sign(payload)
+	return token, payload, err
+}
+
+// CreateMfaPendingToken creates a new "mfa pending" token for a specific email, account and duration
+func (maker *JWTMaker) CreateMfaPendingToken(email string, accountType AccountType, accountID int32, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(email, accountType, accountID, duration)
+	if err != nil {
+		return "", payload, err
+	}
+	payload.MfaPending = true
+
+	token, err := maker.sign(payload)
+	return token, payload, err
+}
+
+// VerifyToken checks if the token is valid. Tokens signed with any key of the keyring are accepted
+func (maker *JWTMaker) VerifyToken(token string) (*Payload, error) {
+	claims := &jwtClaims{}
+
+	_, err := jwt.ParseWithClaims(token, claims, maker.verificationKey,
+		jwt.WithValidMethods([]string{AlgorithmEdDSA, AlgorithmRS256}),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrExpiredToken
+		}
+		return nil, ErrInvalidToken
+	}
+
+	payload := &claims.Payload
+	err = payload.Valid()
+	if err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+// JWKS returns the public keys that can be used to verify the tokens
+func (maker *JWTMaker) JWKS() JWKS {
+	return maker.keyring.JWKS()
+}
+
+// sign signs the payload with the current key of the keyring
+func (maker *JWTMaker) sign(payload *Payload) (string, error) {
+	key, err := maker.keyring.CurrentKey()
+	if err != nil {
+		return "", err
+	}
+
+	claims := jwtClaims{
+		Payload: *payload,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        payload.ID.String(),
+			Subject:   payload.Email,
+			IssuedAt:  jwt.NewNumericDate(payload.IssuedAt),
+			ExpiresAt: jwt.NewNumericDate(payload.ExpiredAt),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(key.Algorithm), claims)
+	token.Header["kid"] = key.ID
+
+	return token.SignedString(key.PrivateKey)
+}
+
+// verificationKey returns the public key that the token was signed with, based on its "kid" header
+func (maker *JWTMaker) verificationKey(token *jwt.Token) (any, error) {
+	keyID, ok := token.Header["kid"].(string)
+	if !ok {
+		return nil, fmt.Errorf("token has no key ID")
+	}
+
+	key, ok := maker.keyring.Key(keyID)
+	if !ok {
+		return nil, fmt.Errorf("unknown key ID %s", keyID)
+	}
+
+	if token.Method.Alg() != key.Algorithm {
+		return nil, fmt.Errorf("unexpected signing method %s for key %s", token.Method.Alg(), keyID)
+	}
+
+	return key.PublicKey, nil
+}
@@ -0,0 +1,191 @@
+package token
+
+import (
+	"github.com/aalug/job-finder-go/pkg/utils"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// newTestJWTMaker creates a new JWTMaker with a single key
+func newTestJWTMaker(t *testing.T, key *Key) Maker {
+	keyring, err := NewKeyring(key.ID, key)
+	require.NoError(t, err)
+
+	maker, err := NewJWTMaker(keyring)
+	require.NoError(t, err)
+	return maker
+}
+
+func TestJWTMaker(t *testing.T) {
+	for _, key := range []*Key{generateEd25519Key(t, "ed"), generateRSAKey(t, "rsa")} {
+		t.Run(key.Algorithm, func(t *testing.T) {
+			maker := newTestJWTMaker(t, key)
+
+			email := utils.RandomEmail()
+			accountID := utils.RandomInt(1, 1000)
+			duration := time.Minute
+
+			issuedAt := time.Now()
+			expiredAt := issuedAt.Add(duration)
+
+			token, payload, err := maker.CreateToken(email, AccountTypeEmployer, accountID, duration)
+			require.NoError(t, err)
+			require.NotEmpty(t, token)
+			require.NotEmpty(t, payload)
+
+			verifiedPayload, err := maker.VerifyToken(token)
+			require.NoError(t, err)
+			require.NotEmpty(t, verifiedPayload)
+
+			require.Equal(t, payload.ID, verifiedPayload.ID)
+			require.Equal(t, email, verifiedPayload.Email)
+			require.Equal(t, AccountTypeEmployer, verifiedPayload.AccountType)
+			require.Equal(t, accountID, verifiedPayload.AccountID)
+			require.False(t, verifiedPayload.MfaPending)
+			require.True(t, payload.IssuedAt.Equal(verifiedPayload.IssuedAt))
+			require.WithinDuration(t, issuedAt, verifiedPayload.IssuedAt, time.Second)
+			require.WithinDuration(t, expiredAt, verifiedPayload.ExpiredAt, time.Second)
+
+			// the key ID is in the header
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, &jwt.RegisteredClaims{})
+			require.NoError(t, err)
+			require.Equal(t, key.ID, parsed.Header["kid"])
+			require.Equal(t, key.Algorithm, parsed.Header["alg"])
+		})
+	}
+}
+
+func TestExpiredJWTToken(t *testing.T) {
+	maker := newTestJWTMaker(t, generateEd25519Key(t, "ed"))
+
+	// negative duration -> always expired
+	token, payload, err := maker.CreateToken(utils.RandomEmail(), AccountTypeUser, 1, -time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.NotEmpty(t, payload)
+
+	payload, err = maker.VerifyToken(token)
+	require.Error(t, err)
+	require.EqualError(t, err, ErrExpiredToken.Error())
+	require.Nil(t, payload)
+}
+
+func TestInvalidAccountTypeJWTToken(t *testing.T) {
+	maker := newTestJWTMaker(t, generateEd25519Key(t, "ed"))
+
+	token, _, err := maker.CreateToken(utils.RandomEmail(), AccountType("admin"), 1, time.Minute)
+	require.NoError(t, err)
+
+	payload, err := maker.VerifyToken(token)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+}
+
+func TestInvalidJWTToken(t *testing.T) {
+	key := generateEd25519Key(t, "ed")
+	maker := newTestJWTMaker(t, key)
+
+	// token without a signature
+	unsigned, err := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{
+		"email":        utils.RandomEmail(),
+		"account_type": AccountTypeUser,
+		"exp":          time.Now().Add(time.Minute).Unix(),
+	}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	payload, err := maker.VerifyToken(unsigned)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+
+	// token signed with another key that has the same ID
+	otherMaker := newTestJWTMaker(t, generateEd25519Key(t, "ed"))
+	token, _, err := otherMaker.CreateToken(utils.RandomEmail(), AccountTypeUser, 1, time.Minute)
+	require.NoError(t, err)
+
+	payload, err = maker.VerifyToken(token)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+
+	// PASETO token
+	pasetoMaker, err := NewPasetoMaker(utils.RandomString(32))
+	require.NoError(t, err)
+	token, _, err = pasetoMaker.CreateToken(utils.RandomEmail(), AccountTypeUser, 1, time.Minute)
+	require.NoError(t, err)
+
+	payload, err = maker.VerifyToken(token)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+}
+
+func TestRefreshJWTToken(t *testing.T) {
+	maker := newTestJWTMaker(t, generateEd25519Key(t, "ed"))
+
+	email := utils.RandomEmail()
+	token, payload, err := maker.CreateRefreshToken(email, AccountTypeUser, 1, time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.Equal(t, TokenTypeRefresh, payload.TokenType)
+
+	payload, err = maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.Equal(t, email, payload.Email)
+	require.Equal(t, TokenTypeRefresh, payload.TokenType)
+}
+
+func TestMfaPendingJWTToken(t *testing.T) {
+	maker := newTestJWTMaker(t, generateEd25519Key(t, "ed"))
+
+	email := utils.RandomEmail()
+	token, payload, err := maker.CreateMfaPendingToken(email, AccountTypeUser, 1, time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.True(t, payload.MfaPending)
+
+	payload, err = maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.Equal(t, email, payload.Email)
+	require.True(t, payload.MfaPending)
+}
+
+func TestJWTKeyRotation(t *testing.T) {
+	oldKey := generateEd25519Key(t, "old")
+	newKey := generateRSAKey(t, "new")
+
+	oldMaker := newTestJWTMaker(t, oldKey)
+	oldToken, _, err := oldMaker.CreateToken(utils.RandomEmail(), AccountTypeUser, 1, time.Minute)
+	require.NoError(t, err)
+
+	// after the rotation, new tokens are signed with the new key, old tokens are still valid
+	keyring, err := NewKeyring(newKey.ID, newKey, publicKeyOnly(oldKey))
+	require.NoError(t, err)
+	maker, err := NewJWTMaker(keyring)
+	require.NoError(t, err)
+
+	_, err = maker.VerifyToken(oldToken)
+	require.NoError(t, err)
+
+	newToken, _, err := maker.CreateToken(utils.RandomEmail(), AccountTypeUser, 1, time.Minute)
+	require.NoError(t, err)
+	_, err = maker.VerifyToken(newToken)
+	require.NoError(t, err)
+
+	// the old maker does not know the new key
+	_, err = oldMaker.VerifyToken(newToken)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+
+	// other services can verify the tokens with only the public keys
+	publicKeyring, err := NewKeyring("", publicKeyOnly(newKey), publicKeyOnly(oldKey))
+	require.NoError(t, err)
+	verifier, err := NewJWTMaker(publicKeyring)
+	require.NoError(t, err)
+
+	_, err = verifier.VerifyToken(newToken)
+	require.NoError(t, err)
+	_, err = verifier.VerifyToken(oldToken)
+	require.NoError(t, err)
+
+	_, _, err = verifier.CreateToken(utils.RandomEmail(), AccountTypeUser, 1, time.Minute)
+	require.ErrorIs(t, err, ErrNoSigningKey)
+}
@@ -0,0 +1,216 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	AlgorithmEdDSA = "EdDSA"
+	AlgorithmRS256 = "RS256"
+)
+
+var ErrNoSigningKey = errors.New("keyring has no key that can sign tokens")
+
+// Key - a key used to sign or verify tokens. Keys without the private key
+// can only be used to verify tokens
+type Key struct {
+	ID         string
+	Algorithm  string
+	PrivateKey crypto.Signer
+	PublicKey  crypto.PublicKey
+}
+
+// ParseKey parses a PEM encoded Ed25519 or RSA key. It accepts private keys
+// (PKCS #8 or PKCS #1) and public keys (PKIX)
+func ParseKey(id string, data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("key %s: no PEM data found", id)
+	}
+
+	var parsed any
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("key %s: unsupported PEM block type %q", id, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("key %s: %w", id, err)
+	}
+
+	return newKey(id, parsed)
+}
+
+// newKey creates a new Key from a parsed private or public key
+func newKey(id string, parsed any) (*Key, error) {
+	key := &Key{ID: id}
+
+	switch k := parsed.(type) {
+	case ed25519.PrivateKey:
+		key.Algorithm = AlgorithmEdDSA
+		key.PrivateKey = k
+		key.PublicKey = k.Public()
+	case ed25519.PublicKey:
+		key.Algorithm = AlgorithmEdDSA
+		key.PublicKey = k
+	case *rsa.PrivateKey:
+		key.Algorithm = AlgorithmRS256
+		key.PrivateKey = k
+		key.PublicKey = k.Public()
+	case *rsa.PublicKey:
+		key.Algorithm = AlgorithmRS256
+		key.PublicKey = k
+	default:
+		return nil, fmt.Errorf("key %s: unsupported key type %T, only Ed25519 and RSA keys are supported", id, parsed)
+	}
+
+	return key, nil
+}
+
+// Keyring - set of keys identified by their IDs. New tokens are signed
+// with the current key, tokens signed with any of the keys can be verified,
+// so previous keys can be kept until all tokens signed with them expire
+type Keyring struct {
+	currentKeyID string
+	keys         map[string]*Key
+}
+
+// NewKeyring creates a new Keyring. currentKeyID can be empty
+// for a keyring that is used only to verify tokens
+func NewKeyring(currentKeyID string, keys ...*Key) (*Keyring, error) {
+	keyring := &Keyring{
+		currentKeyID: currentKeyID,
+		keys:         make(map[string]*Key, len(keys)),
+	}
+
+	for _, key := range keys {
+		if key.ID == "" {
+			return nil, fmt.Errorf("key ID cannot be empty")
+		}
+		if _, ok := keyring.keys[key.ID]; ok {
+			return nil, fmt.Errorf("duplicate key ID %s", key.ID)
+		}
+		keyring.keys[key.ID] = key
+	}
+
+	if currentKeyID != "" {
+		current, ok := keyring.keys[currentKeyID]
+		if !ok {
+			return nil, fmt.Errorf("current key %s not found", currentKeyID)
+		}
+		if current.PrivateKey == nil {
+			return nil, fmt.Errorf("current key %s is a public key, it cannot sign tokens", currentKeyID)
+		}
+	}
+
+	return keyring, nil
+}
+
+// LoadKeyring loads all PEM files from the directory into a new Keyring.
+// The file name without the .pem extension is the key ID
+func LoadKeyring(dir string, currentKeyID string) (*Keyring, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*Key, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := ParseKey(strings.TrimSuffix(filepath.Base(path), ".pem"), data)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return NewKeyring(currentKeyID, keys...)
+}
+
+// CurrentKey returns the key that new tokens are signed with
+func (keyring *Keyring) CurrentKey() (*Key, error) {
+	if keyring.currentKeyID == "" {
+		return nil, ErrNoSigningKey
+	}
+	return keyring.keys[keyring.currentKeyID], nil
+}
+
+// Key returns the key with the given ID
+func (keyring *Keyring) Key(id string) (*Key, bool) {
+	key, ok := keyring.keys[id]
+	return key, ok
+}
+
+// JWK - JSON Web Key (RFC 7517) with the public part of a key
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+}
+
+// JWKS - JSON Web Key Set
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public keys of the keyring, so other services
+// can verify tokens without access to the private keys
+func (keyring *Keyring) JWKS() JWKS {
+	ids := make([]string, 0, len(keyring.keys))
+	for id := range keyring.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	jwks := JWKS{Keys: make([]JWK, 0, len(ids))}
+	for _, id := range ids {
+		key := keyring.keys[id]
+		jwk := JWK{
+			KeyID:     key.ID,
+			Algorithm: key.Algorithm,
+			Use:       "sig",
+		}
+
+		switch publicKey := key.PublicKey.(type) {
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+		}
+
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	return jwks
+}
@@ -0,0 +1,178 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// generateEd25519Key generates a new Ed25519 key for tests
+func generateEd25519Key(t *testing.T, id string) *Key {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	key, err := newKey(id, privateKey)
+	require.NoError(t, err)
+	return key
+}
+
+// generateRSAKey generates a new RSA key for tests
+func generateRSAKey(t *testing.T, id string) *Key {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	key, err := newKey(id, privateKey)
+	require.NoError(t, err)
+	return key
+}
+
+// publicKeyOnly returns a copy of the key without the private key
+func publicKeyOnly(key *Key) *Key {
+	return &Key{
+		ID:        key.ID,
+		Algorithm: key.Algorithm,
+		PublicKey: key.PublicKey,
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	_, edPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	edPKCS8, err := x509.MarshalPKCS8PrivateKey(edPrivateKey)
+	require.NoError(t, err)
+	edPKIX, err := x509.MarshalPKIXPublicKey(edPrivateKey.Public())
+	require.NoError(t, err)
+
+	rsaPrivateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name       string
+		block      *pem.Block
+		algorithm  string
+		hasPrivate bool
+	}{
+		{
+			name:       "Ed25519 Private Key",
+			block:      &pem.Block{Type: "PRIVATE KEY", Bytes: edPKCS8},
+			algorithm:  AlgorithmEdDSA,
+			hasPrivate: true,
+		},
+		{
+			name:       "Ed25519 Public Key",
+			block:      &pem.Block{Type: "PUBLIC KEY", Bytes: edPKIX},
+			algorithm:  AlgorithmEdDSA,
+			hasPrivate: false,
+		},
+		{
+			name:       "RSA PKCS1 Private Key",
+			block:      &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaPrivateKey)},
+			algorithm:  AlgorithmRS256,
+			hasPrivate: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := ParseKey("key-1", pem.EncodeToMemory(tc.block))
+			require.NoError(t, err)
+			require.Equal(t, "key-1", key.ID)
+			require.Equal(t, tc.algorithm, key.Algorithm)
+			require.NotNil(t, key.PublicKey)
+			require.Equal(t, tc.hasPrivate, key.PrivateKey != nil)
+		})
+	}
+
+	_, err = ParseKey("key-1", []byte("not a pem"))
+	require.Error(t, err)
+
+	_, err = ParseKey("key-1", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: edPKIX}))
+	require.Error(t, err)
+}
+
+func TestNewKeyring(t *testing.T) {
+	key1 := generateEd25519Key(t, "key-1")
+	key2 := generateEd25519Key(t, "key-2")
+
+	keyring, err := NewKeyring("key-2", key1, key2)
+	require.NoError(t, err)
+	current, err := keyring.CurrentKey()
+	require.NoError(t, err)
+	require.Equal(t, key2, current)
+
+	// verification only
+	keyring, err = NewKeyring("", publicKeyOnly(key1))
+	require.NoError(t, err)
+	_, err = keyring.CurrentKey()
+	require.ErrorIs(t, err, ErrNoSigningKey)
+
+	_, err = NewKeyring("key-3", key1, key2)
+	require.Error(t, err)
+
+	_, err = NewKeyring("key-1", publicKeyOnly(key1))
+	require.Error(t, err)
+
+	_, err = NewKeyring("key-1", key1, key1)
+	require.Error(t, err)
+}
+
+func TestLoadKeyring(t *testing.T) {
+	dir := t.TempDir()
+
+	_, edPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	edPKCS8, err := x509.MarshalPKCS8PrivateKey(edPrivateKey)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "2023-09.pem"), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: edPKCS8}), 0600)
+	require.NoError(t, err)
+
+	_, oldPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	oldPKIX, err := x509.MarshalPKIXPublicKey(oldPrivateKey.Public())
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "2023-08.pem"), pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: oldPKIX}), 0600)
+	require.NoError(t, err)
+
+	keyring, err := LoadKeyring(dir, "2023-09")
+	require.NoError(t, err)
+
+	current, err := keyring.CurrentKey()
+	require.NoError(t, err)
+	require.Equal(t, "2023-09", current.ID)
+
+	previous, ok := keyring.Key("2023-08")
+	require.True(t, ok)
+	require.Nil(t, previous.PrivateKey)
+
+	_, err = LoadKeyring(dir, "2023-08")
+	require.Error(t, err)
+}
+
+func TestKeyringJWKS(t *testing.T) {
+	edKey := generateEd25519Key(t, "a")
+	rsaKey := generateRSAKey(t, "b")
+
+	keyring, err := NewKeyring("a", edKey, rsaKey)
+	require.NoError(t, err)
+
+	jwks := keyring.JWKS()
+	require.Len(t, jwks.Keys, 2)
+
+	require.Equal(t, "a", jwks.Keys[0].KeyID)
+	require.Equal(t, "OKP", jwks.Keys[0].KeyType)
+	require.Equal(t, "Ed25519", jwks.Keys[0].Curve)
+	require.Equal(t, AlgorithmEdDSA, jwks.Keys[0].Algorithm)
+	require.NotEmpty(t, jwks.Keys[0].X)
+
+	require.Equal(t, "b", jwks.Keys[1].KeyID)
+	require.Equal(t, "RSA", jwks.Keys[1].KeyType)
+	require.Equal(t, AlgorithmRS256, jwks.Keys[1].Algorithm)
+	require.NotEmpty(t, jwks.Keys[1].N)
+	require.Equal(t, "AQAB", jwks.Keys[1].E)
+}
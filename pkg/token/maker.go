@@ -4,6 +4,18 @@ import "time"
 
 // Maker - interface for managing tokens
 type Maker interface {
-	CreateToken(email string, duration time.Duration) (string, error)
+	CreateToken(email string, accountType AccountType, accountID int32, duration time.Duration) (string, *Payload, error)
+	// CreateRefreshToken creates a token that can only be exchanged for new access tokens,
+	// it is never accepted as an access token
+	CreateRefreshToken(email string, accountType AccountType, accountID int32, duration time.Duration) (string, *Payload, error)
+	// CreateMfaPendingToken creates a token that can only be exchanged for an access token
+	// after the second factor is checked, it is never accepted as an access token
+	CreateMfaPendingToken(email string, accountType AccountType, accountID int32, duration time.Duration) (string, *Payload, error)
 	VerifyToken(token string) (*Payload, error)
 }
+
+// PublicKeyProvider - implemented by makers that sign tokens with asymmetric keys,
+// the public keys can be shared with other services to verify the tokens
+type PublicKeyProvider interface {
+	JWKS() JWKS
+}
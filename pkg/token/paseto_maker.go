@@ -27,15 +27,39 @@ func NewPasetoMaker(symmetricKey string) (Maker, error) {
 	return maker, nil
 }
 
-// CreateToken creates a new token for a specific username and duration
-func (maker *PasetoMaker) CreateToken(email string, duration time.Duration) (string, error) {
-	payload, err := NewPayload(email, duration)
+// CreateToken creates a new token for a specific email, account and duration
+func (maker *PasetoMaker) CreateToken(email string, accountType AccountType, accountID int32, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(email, accountType, accountID, duration)
 	if err != nil {
-		return "", err
+		return "", payload, err
 	}
 
 	token, err := maker.paseto.Encrypt(maker.symmetricKey, payload, nil)
-	return token, err
+	return token, payload, err
+}
+
+// CreateRefreshToken creates a new refresh token for a specific email, account and duration
+func (maker *PasetoMaker) CreateRefreshToken(email string, accountType AccountType, accountID int32, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(email, accountType, accountID, duration)
+	if err != nil {
+		return "", payload, err
+	}
+	payload.TokenType = TokenTypeRefresh
+
+	token, err := maker.paseto.Encrypt(maker.symmetricKey, payload, nil)
+	return token, payload, err
+}
+
+// CreateMfaPendingToken creates a new "mfa pending" token for a specific email, account and duration
+func (maker *PasetoMaker) CreateMfaPendingToken(email string, accountType AccountType, accountID int32, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(email, accountType, accountID, duration)
+	if err != nil {
+		return "", payload, err
+	}
+	payload.MfaPending = true
+
+	token, err := maker.paseto.Encrypt(maker.symmetricKey, payload, nil)
+	return token, payload, err
 }
 
 // VerifyToken checks if the token is valid
@@ -12,21 +12,27 @@ func TestPasetoMaker(t *testing.T) {
 	require.NoError(t, err)
 
 	email := utils.RandomEmail()
+	accountID := utils.RandomInt(1, 1000)
 	duration := time.Minute
 
 	issuedAt := time.Now()
 	expiredAt := issuedAt.Add(duration)
 
-	token, err := maker.CreateToken(email, duration)
+	token, payload, err := maker.CreateToken(email, AccountTypeEmployer, accountID, duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
+	require.NotEmpty(t, payload)
 
-	payload, err := maker.VerifyToken(token)
+	payload, err = maker.VerifyToken(token)
 	require.NoError(t, err)
 	require.NotEmpty(t, payload)
 
 	require.NotZero(t, payload.ID)
 	require.Equal(t, email, payload.Email)
+	require.Equal(t, AccountTypeEmployer, payload.AccountType)
+	require.Equal(t, accountID, payload.AccountID)
+	require.Equal(t, TokenTypeAccess, payload.TokenType)
+	require.False(t, payload.MfaPending)
 	require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
 	require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
 }
@@ -36,12 +42,59 @@ func TestExpiredPasetoToken(t *testing.T) {
 	require.NoError(t, err)
 
 	// negative duration -> always expired
-	token, err := maker.CreateToken(utils.RandomEmail(), -time.Minute)
+	token, payload, err := maker.CreateToken(utils.RandomEmail(), AccountTypeUser, 1, -time.Minute)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
+	require.NotEmpty(t, payload)
 
-	payload, err := maker.VerifyToken(token)
+	payload, err = maker.VerifyToken(token)
 	require.Error(t, err)
 	require.EqualError(t, err, ErrExpiredToken.Error())
 	require.Nil(t, payload)
 }
+
+func TestInvalidAccountTypePasetoToken(t *testing.T) {
+	maker, err := NewPasetoMaker(utils.RandomString(32))
+	require.NoError(t, err)
+
+	token, _, err := maker.CreateToken(utils.RandomEmail(), AccountType("admin"), 1, time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+
+	payload, err := maker.VerifyToken(token)
+	require.Error(t, err)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+}
+
+func TestRefreshPasetoToken(t *testing.T) {
+	maker, err := NewPasetoMaker(utils.RandomString(32))
+	require.NoError(t, err)
+
+	email := utils.RandomEmail()
+	token, payload, err := maker.CreateRefreshToken(email, AccountTypeUser, 1, time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.Equal(t, TokenTypeRefresh, payload.TokenType)
+
+	payload, err = maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.Equal(t, email, payload.Email)
+	require.Equal(t, TokenTypeRefresh, payload.TokenType)
+}
+
+func TestMfaPendingPasetoToken(t *testing.T) {
+	maker, err := NewPasetoMaker(utils.RandomString(32))
+	require.NoError(t, err)
+
+	email := utils.RandomEmail()
+	token, payload, err := maker.CreateMfaPendingToken(email, AccountTypeUser, 1, time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.True(t, payload.MfaPending)
+
+	payload, err = maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.Equal(t, email, payload.Email)
+	require.True(t, payload.MfaPending)
+}
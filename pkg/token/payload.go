@@ -9,26 +9,51 @@ import (
 var ErrExpiredToken = errors.New("token has expired")
 var ErrInvalidToken = errors.New("token is invalid")
 
+// AccountType - type of the account that the token was issued for
+type AccountType string
+
+const (
+	AccountTypeUser     AccountType = "user"
+	AccountTypeEmployer AccountType = "employer"
+)
+
+// TokenType - type of the token, access tokens authorize requests
+// and refresh tokens can only be exchanged for new access tokens
+type TokenType string
+
+const (
+	TokenTypeAccess  TokenType = "access"
+	TokenTypeRefresh TokenType = "refresh"
+)
+
 // Payload - payload data of the token
 type Payload struct {
-	ID        uuid.UUID `json:"id"`
-	Email     string    `json:"email"`
-	IssuedAt  time.Time `json:"issued_at"`
-	ExpiredAt time.Time `json:"expired_at"`
+	ID          uuid.UUID   `json:"id"`
+	Email       string      `json:"email"`
+	AccountType AccountType `json:"account_type"`
+	AccountID   int32       `json:"account_id"`
+	TokenType   TokenType   `json:"token_type"`
+	MfaPending  bool        `json:"mfa_pending"`
+	IssuedAt    time.Time   `json:"issued_at"`
+	ExpiredAt   time.Time   `json:"expired_at"`
 }
 
-// NewPayload creates a new token payload with a specific email and duration
-func NewPayload(email string, duration time.Duration) (*Payload, error) {
+// NewPayload creates a new access token payload with a specific email,
+// account (type and ID) and duration
+func NewPayload(email string, accountType AccountType, accountID int32, duration time.Duration) (*Payload, error) {
 	tokenID, err := uuid.NewRandom()
 	if err != nil {
 		return nil, err
 	}
 
 	payload := &Payload{
-		ID:        tokenID,
-		Email:     email,
-		IssuedAt:  time.Now(),
-		ExpiredAt: time.Now().Add(duration),
+		ID:          tokenID,
+		Email:       email,
+		AccountType: accountType,
+		AccountID:   accountID,
+		TokenType:   TokenTypeAccess,
+		IssuedAt:    time.Now(),
+		ExpiredAt:   time.Now().Add(duration),
 	}
 
 	return payload, nil
@@ -36,6 +61,9 @@ func NewPayload(email string, duration time.Duration) (*Payload, error) {
 
 // Valid checks if the token payload is valid
 func (payload *Payload) Valid() error {
+	if payload.AccountType != AccountTypeUser && payload.AccountType != AccountTypeEmployer {
+		return ErrInvalidToken
+	}
 	if time.Now().After(payload.ExpiredAt) {
 		return ErrExpiredToken
 	}
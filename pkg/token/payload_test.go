@@ -12,12 +12,17 @@ func TestNewPayload(t *testing.T) {
 	email := utils.RandomEmail()
 	duration := time.Hour
 
-	payload, err := NewPayload(email, duration)
+	accountID := utils.RandomInt(1, 1000)
+
+	payload, err := NewPayload(email, AccountTypeUser, accountID, duration)
 	require.NoError(t, err)
 
 	// Check that the payload fields are set correctly
 	require.NotEqual(t, uuid.Nil, payload.ID, "ID should not be nil")
 	require.Equal(t, email, payload.Email)
+	require.Equal(t, AccountTypeUser, payload.AccountType)
+	require.Equal(t, accountID, payload.AccountID)
+	require.Equal(t, TokenTypeAccess, payload.TokenType)
 	require.WithinDuration(t, time.Now(), payload.IssuedAt, 5*time.Second, "IssuedAt should be close to the current time")
 	require.WithinDuration(t, time.Now().Add(duration), payload.ExpiredAt, 5*time.Second, "ExpiredAt should be close to current time + duration")
 }
@@ -25,18 +30,30 @@ func TestNewPayload(t *testing.T) {
 func TestPayload_Valid(t *testing.T) {
 	// Create a payload that has not expired
 	validPayload := &Payload{
-		ID:        uuid.New(),
-		Email:     utils.RandomEmail(),
-		IssuedAt:  time.Now().Add(-time.Hour), // Issued 1 hour ago
-		ExpiredAt: time.Now().Add(time.Hour),  // Expires in 1 hour
+		ID:          uuid.New(),
+		Email:       utils.RandomEmail(),
+		AccountType: AccountTypeUser,
+		AccountID:   1,
+		IssuedAt:    time.Now().Add(-time.Hour), // Issued 1 hour ago
+		ExpiredAt:   time.Now().Add(time.Hour),  // Expires in 1 hour
 	}
 
 	// Create a payload that has already expired
 	expiredPayload := &Payload{
+		ID:          uuid.New(),
+		Email:       utils.RandomEmail(),
+		AccountType: AccountTypeEmployer,
+		AccountID:   1,
+		IssuedAt:    time.Now().Add(-2 * time.Hour), // Issued 2 hours ago
+		ExpiredAt:   time.Now().Add(-time.Hour),     // Expired 1 hour ago
+	}
+
+	// Create a payload without a valid account type
+	invalidPayload := &Payload{
 		ID:        uuid.New(),
 		Email:     utils.RandomEmail(),
-		IssuedAt:  time.Now().Add(-2 * time.Hour), // Issued 2 hours ago
-		ExpiredAt: time.Now().Add(-time.Hour),     // Expired 1 hour ago
+		IssuedAt:  time.Now(),
+		ExpiredAt: time.Now().Add(time.Hour),
 	}
 
 	// Check that a valid payload does not return an error
@@ -46,4 +63,8 @@ func TestPayload_Valid(t *testing.T) {
 	// Check that an expired payload returns an error
 	err = expiredPayload.Valid()
 	require.EqualError(t, err, ErrExpiredToken.Error())
+
+	// Check that a payload without an account type returns an error
+	err = invalidPayload.Valid()
+	require.EqualError(t, err, ErrInvalidToken.Error())
 }
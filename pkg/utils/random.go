@@ -1,6 +1,8 @@
 package utils
 
 import (
+	crand "crypto/rand"
+	"encoding/base64"
 	"math/rand"
 	"strings"
 	"time"
@@ -30,6 +32,16 @@ func RandomString(n int) string {
 	return sb.String()
 }
 
+// RandomSecretCode returns a URL-safe string of n random bytes from crypto/rand.
+// Unlike RandomString, it cannot be predicted, so it is used for the codes sent in emails
+func RandomSecretCode(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := crand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
 // RandomEmail returns a random email
 func RandomEmail() string {
 	return RandomString(10) + "@example.com"
@@ -68,6 +68,20 @@ func TestRandomString(t *testing.T) {
 	require.True(t, isStringInAlphabet(randomString), "RandomString should only contain characters from the alphabet")
 }
 
+func TestRandomSecretCode(t *testing.T) {
+	code, err := RandomSecretCode(32)
+	require.NoError(t, err)
+	// 32 bytes are 43 characters without padding
+	require.Len(t, code, 43)
+	require.NotContains(t, code, "=")
+	require.NotContains(t, code, "/")
+	require.NotContains(t, code, "+")
+
+	code2, err := RandomSecretCode(32)
+	require.NoError(t, err)
+	require.NotEqual(t, code, code2)
+}
+
 func TestRandomEmail(t *testing.T) {
 	// Test with multiple random emails
 	for i := 0; i < 10; i++ {